@@ -2,9 +2,15 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"mime"
+	"net"
 	gopath "path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/peer"
 )
 
 type key int
@@ -14,6 +20,9 @@ const (
 	tokenKey           key = 1
 	publicLinkKey      key = 2
 	publicLinkTokenKey key = 3
+	auditEventKey      key = 4
+	clientIPKey        key = 5
+	uploadedFileKey    key = 6
 )
 
 func ContextGetUser(ctx context.Context) (*User, bool) {
@@ -52,6 +61,108 @@ func ContextSetPublicLink(ctx context.Context, pl *PublicLink) context.Context {
 	return context.WithValue(ctx, publicLinkKey, pl)
 }
 
+func ContextGetAuditEvent(ctx context.Context) (*AuditEvent, bool) {
+	e, ok := ctx.Value(auditEventKey).(*AuditEvent)
+	return e, ok
+}
+
+func ContextSetAuditEvent(ctx context.Context, e *AuditEvent) context.Context {
+	return context.WithValue(ctx, auditEventKey, e)
+}
+
+func ContextGetClientIP(ctx context.Context) (string, bool) {
+	ip, ok := ctx.Value(clientIPKey).(string)
+	return ip, ok
+}
+
+func ContextSetClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey, ip)
+}
+
+// UploadedFile is filled by the storages that write an upload under
+// another name than the one asked, like the drop-only links renaming the
+// files that would overwrite another one.
+type UploadedFile struct {
+	Name string
+}
+
+// UploadedNameMetadata is the header metadata of FinishWriteTx telling the
+// name the file was written under.
+const UploadedNameMetadata = "x-uploaded-name-bin"
+
+// SearchTruncatedMetadata is the trailer metadata of Search set when
+// more files matched than were returned.
+const SearchTruncatedMetadata = "x-search-truncated"
+
+func ContextGetUploadedFile(ctx context.Context) (*UploadedFile, bool) {
+	f, ok := ctx.Value(uploadedFileKey).(*UploadedFile)
+	return f, ok
+}
+
+func ContextSetUploadedFile(ctx context.Context, f *UploadedFile) context.Context {
+	return context.WithValue(ctx, uploadedFileKey, f)
+}
+
+// GetClientIP returns the IP of the client of a gRPC call, as resolved by the
+// interceptors of TrustedProxies, or the address of the peer. The
+// x-forwarded-for metadata is only believed from the trusted proxies.
+func GetClientIP(ctx context.Context) string {
+	if ip, ok := ContextGetClientIP(ctx); ok && ip != "" {
+		return ip
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// IsUserRestricted returns true if the user has been authenticated
+// with a credential that limits its access, like an app password.
+// An app password without restrictions still cannot act in place of
+// the real login, for example to mint other app passwords.
+func IsUserRestricted(u *User) bool {
+	return u.AppPassword || u.ReadOnly || u.RestrictedPath != ""
+}
+
+// CheckUnrestrictedUser returns the user in the context, or an error if it
+// has been authenticated with a restricted credential. It guards the actions
+// whose results would outlive the restrictions of the credential, like
+// sharing or minting app passwords; what names them in the error.
+func CheckUnrestrictedUser(ctx context.Context, what string) (*User, error) {
+	u, ok := ContextGetUser(ctx)
+	if !ok {
+		return nil, NewError(ContextUserRequiredError)
+	}
+	if IsUserRestricted(u) {
+		return nil, NewError(StoragePermissionDeniedErrorCode).WithMessage(what + " with a restricted credential")
+	}
+	return u, nil
+}
+
+// CheckUserScope verifies that the user in the context is allowed to access p.
+// Users authenticated with a restricted credential can only access paths under
+// their restricted path, and only for reading if the credential is read-only.
+func CheckUserScope(ctx context.Context, p string, write bool) error {
+	u, ok := ContextGetUser(ctx)
+	if !ok {
+		return nil
+	}
+	if write && u.ReadOnly {
+		return NewError(StoragePermissionDeniedErrorCode).WithMessage("credential is read-only")
+	}
+	if u.RestrictedPath != "" {
+		root := gopath.Clean(u.RestrictedPath)
+		p = gopath.Clean(p)
+		if p != root && !strings.HasPrefix(p, strings.TrimSuffix(root, "/")+"/") {
+			return NewError(StoragePermissionDeniedErrorCode).WithMessage("credential is restricted to " + root)
+		}
+	}
+	return nil
+}
+
 type MountOptions struct {
 	ReadOnly        bool `json:"read_only"`
 	SharingDisabled bool `json:"sharing_disabled"`
@@ -114,27 +225,173 @@ type Storage interface {
 	RestoreRecycleEntry(ctx context.Context, restoreKey string) error
 	EmptyRecycle(ctx context.Context, path string) error
 	GetPathByID(ctx context.Context, id string) (string, error)
-	SetACL(ctx context.Context, path string, readOnly bool, recipient *ShareRecipient, shareList []*FolderShare) error
+	SetACL(ctx context.Context, path string, permissions SharePermissions, recipient *ShareRecipient, shareList []*FolderShare) error
 	UnsetACL(ctx context.Context, path string, recipient *ShareRecipient, shareList []*FolderShare) error
-	UpdateACL(ctx context.Context, path string, readOnly bool, recipient *ShareRecipient, shareList []*FolderShare) error
+	UpdateACL(ctx context.Context, path string, permissions SharePermissions, recipient *ShareRecipient, shareList []*FolderShare) error
 	GetQuota(ctx context.Context, path string) (int, int, error)
 }
 
+// An OwnershipTransferStorage can hand files over from a user to another,
+// moving them from the namespace of one to the namespace of the other.
+// The storages with no notion of users do not implement it.
+type OwnershipTransferStorage interface {
+	// TransferOwnership moves fromPath of from to toPath of to and makes to
+	// the owner of the files. toPath must not exist.
+	TransferOwnership(ctx context.Context, from, fromPath, to, toPath string) error
+}
+
+// A ProvisioningStorage creates directories on behalf of other users and
+// limits the space they take, to set up the project spaces.
+type ProvisioningStorage interface {
+	// ProvisionDir creates p with its parents if needed and makes owner
+	// the owner of p and the files under it.
+	ProvisionDir(ctx context.Context, owner, p string) error
+	// SetQuota limits the bytes that owner can store under p,
+	// 0 removes the limit.
+	SetQuota(ctx context.Context, owner, p string, maxBytes uint64) error
+}
+
+// SharePermissions are the ownCloud permission bits granted by a share
+// or an ACL.
+type SharePermissions uint32
+
+const (
+	SharePermissionRead   SharePermissions = 1
+	SharePermissionUpdate SharePermissions = 2
+	SharePermissionCreate SharePermissions = 4
+	SharePermissionDelete SharePermissions = 8
+	SharePermissionShare  SharePermissions = 16
+
+	SharePermissionsReadOnly  = SharePermissionRead
+	SharePermissionsReadWrite = SharePermissionRead | SharePermissionUpdate | SharePermissionCreate | SharePermissionDelete
+	SharePermissionsAll       = SharePermissionsReadWrite | SharePermissionShare
+)
+
+// Has returns true if all the permissions in q are granted.
+func (p SharePermissions) Has(q SharePermissions) bool {
+	return p&q == q
+}
+
+// IsReadOnly returns true if the permissions do not allow any modification.
+func (p SharePermissions) IsReadOnly() bool {
+	return p&(SharePermissionUpdate|SharePermissionCreate|SharePermissionDelete) == 0
+}
+
+// ForItemType drops the permissions that make no sense on a file,
+// files cannot receive new entries nor have them deleted.
+func (p SharePermissions) ForItemType(itemType FolderShare_ItemType) SharePermissions {
+	if itemType == FolderShare_FILE {
+		return p &^ (SharePermissionCreate | SharePermissionDelete)
+	}
+	return p
+}
+
+// CheckSharePermissions returns an error if the permissions cannot be
+// granted by a share of an item of the type: they need to allow reading and
+// only contain known bits. The ACLs of EOS cannot allow to update the files
+// of a folder without allowing to create them, so a folder cannot be shared
+// with the updates but not the creations.
+func CheckSharePermissions(p SharePermissions, itemType FolderShare_ItemType) error {
+	if !p.Has(SharePermissionRead) || p&^SharePermissionsAll != 0 {
+		return NewError(FolderShareInvalidPermissionsErrorCode).WithMessage(fmt.Sprintf("permissions %d", p))
+	}
+	if itemType == FolderShare_FOLDER && p.Has(SharePermissionUpdate) && !p.Has(SharePermissionCreate) {
+		return NewError(FolderShareInvalidPermissionsErrorCode).WithMessage(fmt.Sprintf("permissions %d allow to update the files of a folder but not to create them", p))
+	}
+	return nil
+}
+
+// PermissionsFromReadOnly returns the permissions of a share or an ACL
+// only described by a read-only flag.
+func PermissionsFromReadOnly(readOnly bool) SharePermissions {
+	if readOnly {
+		return SharePermissionsReadOnly
+	}
+	return SharePermissionsReadWrite
+}
+
+// GetSharePermissions returns the permissions of the share, falling back
+// to its read-only flag for shares created before permissions existed.
+func GetSharePermissions(share *FolderShare) SharePermissions {
+	if share.Permissions != 0 {
+		return SharePermissions(share.Permissions)
+	}
+	return PermissionsFromReadOnly(share.ReadOnly).ForItemType(share.ItemType)
+}
+
+// GetResharePermissions returns the permissions of a re-share of the
+// received share parent, capped at the permissions granted by parent.
+func GetResharePermissions(parent *FolderShare, p SharePermissions) (SharePermissions, error) {
+	granted := GetSharePermissions(parent)
+	if !granted.Has(SharePermissionShare) {
+		return 0, NewError(StoragePermissionDeniedErrorCode).WithMessage("share " + parent.Id + " cannot be re-shared")
+	}
+	return p & granted, nil
+}
+
+// GetShareInitiator returns the user who created the share.
+func GetShareInitiator(share *FolderShare) string {
+	if share.InitiatorId != "" {
+		return share.InitiatorId
+	}
+	return share.OwnerId
+}
+
+// GetShareTarget returns the target to mount a received share under from
+// the name chosen by its recipient, a single path element.
+func GetShareTarget(name string) (string, error) {
+	target := gopath.Join("/", name)
+	if target == "/" || gopath.Dir(target) != "/" {
+		return "", NewError(PathInvalidError).WithMessage("invalid share target: " + name)
+	}
+	return target, nil
+}
+
+// SetSharePermissions sets the permissions of the share and keeps its
+// read-only flag in sync for the clients that only know about it.
+func SetSharePermissions(share *FolderShare, p SharePermissions) {
+	share.Permissions = uint32(p)
+	share.ReadOnly = p.IsReadOnly()
+}
+
+type FolderShareOptions struct {
+	Permissions       SharePermissions
+	Expiration        uint64
+	UpdatePermissions bool
+	UpdateExpiration  bool
+}
+
 type PublicLinkOptions struct {
-	Password         string
-	ReadOnly         bool
-	DropOnly         bool
-	Expiration       uint64
-	UpdatePassword   bool
-	UpdateReadOnly   bool
-	UpdateDropOnly   bool
-	UpdateExpiration bool
+	Password            string
+	ReadOnly            bool
+	DropOnly            bool
+	NotifyUploads       bool
+	MaxDownloads        uint64 // 0 for unlimited downloads
+	Expiration          uint64
+	UpdatePassword      bool
+	UpdateReadOnly      bool
+	UpdateDropOnly      bool
+	UpdateNotifyUploads bool
+	UpdateMaxDownloads  bool
+	UpdateExpiration    bool
 }
 
+// TagManager manages the tags of the users and the system tags of the projects,
+// shared between the members of a project. Tags are bound to the file IDs and
+// follow the files when they are moved.
 type TagManager interface {
+	// GetTagsForKey returns the tags with the key, all the tags if the key is empty.
 	GetTagsForKey(ctx context.Context, key string) ([]*Tag, error)
+	GetTagsForPath(ctx context.Context, path string) ([]*Tag, error)
+	// GetTagsForFolder returns the tags of the folder and of its entries,
+	// bound to the IDs of the entries in the listing of the folder.
+	GetTagsForFolder(ctx context.Context, path string) ([]*Tag, error)
 	SetTag(ctx context.Context, key, val, path string) error
 	UnSetTag(ctx context.Context, key, val, path string) error
+	RenameTag(ctx context.Context, oldKey, newKey string) error
+	SetSystemTag(ctx context.Context, project, key, val, path string) error
+	UnSetSystemTag(ctx context.Context, project, key, val, path string) error
+	RenameSystemTag(ctx context.Context, project, oldKey, newKey string) error
 }
 
 type PublicLinkManager interface {
@@ -145,26 +402,83 @@ type PublicLinkManager interface {
 	ListPublicLinks(ctx context.Context, filterByPath string) ([]*PublicLink, error)
 	RevokePublicLink(ctx context.Context, token string) error
 
+	// AuthenticatePublicLink records a view of the link, and fails with
+	// PublicLinkDownloadLimitErrorCode once it has been downloaded MaxDownloads times.
+	// A view is counted each time a token is forged for the link, and ocproxy
+	// forges one for every request to the link, so the views count the
+	// requests to the link rather than its visitors.
 	AuthenticatePublicLink(ctx context.Context, token, password string) (*PublicLink, error)
 	IsPublicLinkProtected(ctx context.Context, token string) (bool, error)
+	// RecordPublicLinkDownload counts a download of a file of the link, from the
+	// client IP of the context. It fails if the download limit has been reached.
+	RecordPublicLinkDownload(ctx context.Context, token string) error
+
+	// The links of the files in the recycle bin are suspended, they cannot be
+	// accessed until the files are restored, and are purged with the bin.
+	// SuspendPublicLinks suspends, or reactivates if suspended is false,
+	// the links of all the users on the files fileIDs and returns them.
+	SuspendPublicLinks(ctx context.Context, fileIDs []string, suspended bool) ([]*PublicLink, error)
+	// PurgePublicLinks removes the links of all the users on the files fileIDs and returns them.
+	PurgePublicLinks(ctx context.Context, fileIDs []string) ([]*PublicLink, error)
+	// ListAllPublicLinks returns the links of all the users, or of owner if not
+	// empty, for the checks and the transfers of the admins.
+	ListAllPublicLinks(ctx context.Context, owner string) ([]*PublicLink, error)
+	// TransferPublicLinks hands the links of from on the files fileIDs over
+	// to to and returns them.
+	TransferPublicLinks(ctx context.Context, fileIDs []string, from, to string) ([]*PublicLink, error)
 }
 
+// ShareManager manages the shares of files and folders with users and groups.
+// Sharing a path received through a share re-shares it: the new share is
+// owned by the owner of the file, created by the re-sharer, and cannot grant
+// more than the received share. The shares are managed by their owner and
+// their initiator, and removing or restricting a share does the same to
+// its re-shares.
 type ShareManager interface {
-	AddFolderShare(ctx context.Context, path string, recipient *ShareRecipient, readOnly bool) (*FolderShare, error)
+	AddFolderShare(ctx context.Context, path string, recipient *ShareRecipient, opt *FolderShareOptions) (*FolderShare, error)
 	GetFolderShare(ctx context.Context, shareID string) (*FolderShare, error)
 	Unshare(ctx context.Context, shareID string) error
-	UpdateFolderShare(ctx context.Context, shareID string, updateReadOnly, readOnly bool) (*FolderShare, error)
+	UpdateFolderShare(ctx context.Context, shareID string, opt *FolderShareOptions) (*FolderShare, error)
+	// ListFolderShares returns the shares owned or created by the user,
+	// the owner of a file sees all its shares, re-shares included.
 	ListFolderShares(ctx context.Context, filterByPath string) ([]*FolderShare, error)
 
+	// The shares past their expiration are not received anymore.
+	// A received share starts pending, and each of its recipients accepts
+	// it by mounting it, or rejects it by unmounting it. The shares are
+	// received in all their states, the state and target of the user set.
 	ListReceivedShares(ctx context.Context) ([]*FolderShare, error)
 	GetReceivedFolderShare(ctx context.Context, shareID string) (*FolderShare, error)
+	// MountReceivedShare accepts the share under target, a name
+	// checked with GetShareTarget, or under its current target if empty.
+	MountReceivedShare(ctx context.Context, shareID, target string) (*FolderShare, error)
 	UnmountReceivedShare(ctx context.Context, shareID string) error
 
+	// ExpireFolderShares removes the shares of all the users past their
+	// expiration, with their ACLs and re-shares, and returns them.
+	// The suspended shares do not expire.
+	ExpireFolderShares(ctx context.Context) ([]*FolderShare, error)
+
+	// The shares of the files in the recycle bin are suspended, they are not
+	// received until the files are restored, and are purged with the bin.
+	// Their ACLs go to the bin and come back with the files, so they are not changed.
+	// SuspendFolderShares suspends, or reactivates if suspended is false,
+	// the shares of all the users on the files fileIDs and returns them.
+	SuspendFolderShares(ctx context.Context, fileIDs []string, suspended bool) ([]*FolderShare, error)
+	// PurgeFolderShares removes the shares of all the users on the files fileIDs and returns them.
+	PurgeFolderShares(ctx context.Context, fileIDs []string) ([]*FolderShare, error)
+	// ListAllFolderShares returns the shares of all the users, or of owner if not
+	// empty, for the checks and the transfers of the admins.
+	ListAllFolderShares(ctx context.Context, owner string) ([]*FolderShare, error)
+	// TransferFolderShares hands the shares of the files fileIDs owned by from
+	// over to to and returns them. The shares created by from are then created
+	// by to, the re-shares of the other users keep their initiators.
+	// The ACLs are left to the caller, which moves the files.
+	TransferFolderShares(ctx context.Context, fileIDs []string, from, to string) ([]*FolderShare, error)
+
 	/*
 		ListFolderRecipients(ctx context.Context, path string) ([]*ShareRecipient, error)
 		GetFolderSharesInPath(ctx context.Context, path string) ([]*FolderShare, error)
-
-		MountReceivedShare(ctx context.Context, shareID string) error
 	*/
 }
 
@@ -180,7 +494,47 @@ type Project struct {
 type ProjectManager interface {
 	GetAllProjects(ctx context.Context) ([]*Project, error)
 	GetProject(ctx context.Context, name string) (*Project, error)
+
+	// CreateProject records a new project, with the path and the groups
+	// left empty named after it. The project directory is left to the caller.
+	CreateProject(ctx context.Context, project *Project) (*Project, error)
+	// UpdateProject changes the owner and the groups of the project.
+	UpdateProject(ctx context.Context, project *Project) (*Project, error)
+	// DeleteProject forgets the project, its files are left to the caller.
+	DeleteProject(ctx context.Context, name string) error
 }
+
+// SetProjectDefaults names the path and the groups that are not set after
+// the project: the path is under the directory of its initial, as in
+// p/proj, and the groups are the cernbox-project-<name>-admins, -writers
+// and -readers e-groups.
+func SetProjectDefaults(project *Project) {
+	if project.Path == "" && project.Name != "" {
+		project.Path = gopath.Join(project.Name[:1], project.Name)
+	}
+	if project.AdminGroup == "" {
+		project.AdminGroup = "cernbox-project-" + project.Name + "-admins"
+	}
+	if project.WritersGroup == "" {
+		project.WritersGroup = "cernbox-project-" + project.Name + "-writers"
+	}
+	if project.ReadersGroup == "" {
+		project.ReadersGroup = "cernbox-project-" + project.Name + "-readers"
+	}
+}
+
+// CheckProjectOverlap returns an error if the path of the project is the
+// path of one of the others, or contains it or is under it: the ACLs of the
+// groups and the deletion of the files would reach the other project.
+func CheckProjectOverlap(project *Project, others []*Project) error {
+	for _, other := range others {
+		if other.Path == project.Path || strings.HasPrefix(other.Path, project.Path+"/") || strings.HasPrefix(project.Path, other.Path+"/") {
+			return NewError(ProjectInvalidErrorCode).WithMessage("the path of the project overlaps the one of " + other.Name + ": " + project.Path)
+		}
+	}
+	return nil
+}
+
 type UserManager interface {
 	GetUserGroups(ctx context.Context, username string) ([]string, error)
 	IsInGroup(ctx context.Context, username, group string) (bool, error)
@@ -189,6 +543,317 @@ type AuthManager interface {
 	Authenticate(ctx context.Context, clientID, clientPassword string) (*User, error)
 }
 
+// AppPasswordManager manages application passwords, named credentials
+// that a user can mint for sync and WebDAV clients instead of handing them the
+// real password. An app password can be restricted to read-only access and/or
+// to a path, the restriction is carried in the User returned on authentication.
+type AppPasswordManager interface {
+	// CreateAppPassword returns the new app password and its clear text secret,
+	// that is not possible to retrieve later.
+	CreateAppPassword(ctx context.Context, label string, readOnly bool, path string) (*AppPassword, string, error)
+	ListAppPasswords(ctx context.Context) ([]*AppPassword, error)
+	RevokeAppPassword(ctx context.Context, id string) error
+
+	AuthenticateAppPassword(ctx context.Context, accountID, password string) (*AppPassword, error)
+}
+
+// Throttler keeps track of failed authentication attempts per key,
+// like an account, a public link token or a client IP, to slow down brute-force attacks.
+// An attempt is recorded as failed before it is processed, so parallel attempts
+// are all counted, and released if it succeeds.
+type Throttler interface {
+	// Attempt records an attempt for key and returns 0, or returns how long to wait
+	// without recording it if the failed attempts for key impose a wait.
+	Attempt(ctx context.Context, key string) (time.Duration, error)
+	// Release forgets an attempt for key that succeeded.
+	Release(ctx context.Context, key string) error
+	// Wait returns how long to wait before a new attempt for key is processed.
+	Wait(ctx context.Context, key string) (time.Duration, error)
+	// Reset forgets the failed attempts for key.
+	Reset(ctx context.Context, key string) error
+}
+
+// ThrottlePolicy computes the wait imposed after a number of failed attempts:
+// the first FreeAttempts are not penalized, then the wait doubles on every failure
+// starting from BaseDelay up to MaxDelay, and after LockoutAttempts failures
+// the key is locked for LockoutDuration. Failures older than Window are forgotten.
+type ThrottlePolicy struct {
+	FreeAttempts    int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutAttempts int
+	LockoutDuration time.Duration
+	Window          time.Duration
+}
+
+// Delay returns the wait imposed after the given number of failures, counted from the last failure.
+func (p *ThrottlePolicy) Delay(failures int) time.Duration {
+	if failures <= p.FreeAttempts {
+		return 0
+	}
+	if p.LockoutAttempts > 0 && failures >= p.LockoutAttempts {
+		return p.LockoutDuration
+	}
+	n := uint(failures - p.FreeAttempts - 1)
+	if n > 30 {
+		return p.MaxDelay
+	}
+	d := p.BaseDelay << n
+	if d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// Wait returns how long to wait at now given the number of failures and the time of the last one.
+func (p *ThrottlePolicy) Wait(failures int, last, now time.Time) time.Duration {
+	if p.Expired(last, now) {
+		return 0
+	}
+	wait := last.Add(p.Delay(failures)).Sub(now)
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// Expired returns true if the failures recorded at last must be forgotten at now.
+func (p *ThrottlePolicy) Expired(last, now time.Time) bool {
+	return now.Sub(last) > p.Retention()
+}
+
+// Retention returns how long failures are remembered, that is Window
+// but never less than the longest wait, as a wait must not be lifted before its time.
+func (p *ThrottlePolicy) Retention() time.Duration {
+	window := p.Window
+	if p.LockoutDuration > window {
+		window = p.LockoutDuration
+	}
+	if p.MaxDelay > window {
+		window = p.MaxDelay
+	}
+	return window
+}
+
+// AuditSink receives the audit events, to store or forward them.
+type AuditSink interface {
+	Emit(ctx context.Context, e *AuditEvent) error
+}
+
+// AuditLog is implemented by the sinks that can be queried back.
+type AuditLog interface {
+	ListAuditEvents(ctx context.Context, filter *ListAuditEventsReq) ([]*AuditEvent, error)
+}
+
+// AuditSetResource sets the path and the file ID of the resource a call
+// acts on in the audit event of the context, if the call is audited.
+// It must be called before the resource is removed, else its ID is lost.
+func AuditSetResource(ctx context.Context, vs VirtualStorage, p string) {
+	e, ok := ContextGetAuditEvent(ctx)
+	if !ok {
+		return
+	}
+	e.Path = p
+	if md, err := vs.GetMetadata(ctx, p); err == nil {
+		e.FileId = md.Id
+	}
+}
+
+// AuditSetTarget sets the secondary resource of the call in the audit event
+// of the context, like the destination of a move or the recipient of a share.
+func AuditSetTarget(ctx context.Context, target string) {
+	if e, ok := ContextGetAuditEvent(ctx); ok {
+		e.Target = target
+	}
+}
+
+// Match returns true if the event satisfies all the conditions of the filter.
+func (f *ListAuditEventsReq) Match(e *AuditEvent) bool {
+	if f.AccountId != "" && e.AccountId != f.AccountId {
+		return false
+	}
+	if f.Path != "" {
+		root := strings.TrimSuffix(gopath.Clean(f.Path), "/")
+		if e.Path != root && !strings.HasPrefix(e.Path, root+"/") && e.Target != root && !strings.HasPrefix(e.Target, root+"/") {
+			return false
+		}
+	}
+	if f.FileId != "" && e.FileId != f.FileId {
+		return false
+	}
+	if f.Action != "" && e.Action != f.Action {
+		return false
+	}
+	if f.From != 0 && e.Time < f.From {
+		return false
+	}
+	if f.To != 0 && e.Time > f.To {
+		return false
+	}
+	return true
+}
+
+// EventBus delivers the file events published by the storage to the watchers.
+type EventBus interface {
+	// Publish delivers e to the subscriptions that match it.
+	Publish(e *FileEvent)
+	// PublishAsync runs complete on e then publishes it in the background, in the
+	// order of the calls, so that the operation that caused the event does not wait
+	// for its completion. If the events waiting to be completed overflow, e is
+	// dropped and the subscriptions it would have been delivered to are closed.
+	PublishAsync(e *FileEvent, complete func(e *FileEvent))
+	// Subscribe returns the channel where the events under p are delivered,
+	// and the function to call to stop the subscription. The channel is closed
+	// if the subscriber does not keep up with the events.
+	Subscribe(p string, recursive bool) (<-chan *FileEvent, func())
+	// SubscribeFunc is like Subscribe for the events match returns true for.
+	// The match is also asked by IsWatched, before the event is completed,
+	// so it must only look at the type and the paths of the event.
+	SubscribeFunc(match func(e *FileEvent) bool) (<-chan *FileEvent, func())
+	// HasSubscribers allows to skip the work of building events nobody watches.
+	HasSubscribers() bool
+	// IsWatched returns true if a subscription matches the type and the paths
+	// of e, it allows to skip the work of completing the events nobody watches.
+	IsWatched(e *FileEvent) bool
+}
+
+// PublishFileEvent completes the event with the user and the parent
+// of the path, as seen by the user in the context, and publishes it.
+// The metadata are looked up by the bus after the operation returns.
+func PublishFileEvent(ctx context.Context, bus EventBus, vs VirtualStorage, e *FileEvent) {
+	if bus == nil {
+		return
+	}
+	// the subscriptions can resolve the paths seen by the user
+	if u, ok := ContextGetUser(ctx); ok {
+		e.AccountId = u.AccountId
+	}
+	if !bus.IsWatched(e) {
+		return
+	}
+	e.Time = uint64(time.Now().Unix())
+	ctx = detachedContext{ctx}
+	bus.PublishAsync(e, func(e *FileEvent) {
+		if md, err := vs.GetMetadata(ctx, gopath.Dir(e.Path)); err == nil {
+			e.ParentId = md.Id
+		}
+		if e.Type != FileEvent_DELETED {
+			if md, err := vs.GetMetadata(ctx, e.Path); err == nil {
+				e.FileId = md.Id
+				e.Metadata = md
+			}
+		}
+	})
+}
+
+// detachedContext carries the values of its parent, like the user, but is
+// never done, to be used after the request of the parent is over.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// IsWatchedPath returns true if a change on p must be delivered
+// to the watchers of watched.
+func IsWatchedPath(watched string, recursive bool, p string) bool {
+	watched = gopath.Clean(watched)
+	p = gopath.Clean(p)
+	if p == watched {
+		return true
+	}
+	if recursive {
+		return strings.HasPrefix(p, strings.TrimSuffix(watched, "/")+"/")
+	}
+	return gopath.Dir(p) == watched
+}
+
+// WebhookManager stores the webhook subscription rules and the payloads
+// that could not be delivered.
+type WebhookManager interface {
+	AddWebhook(ctx context.Context, wh *Webhook, secret string) (*Webhook, error)
+	GetWebhook(ctx context.Context, id string) (*Webhook, error)
+	ListWebhooks(ctx context.Context) ([]*Webhook, error)
+	RemoveWebhook(ctx context.Context, id string) error
+	// GetWebhookSecret returns the secret to sign the payloads sent to the webhook.
+	GetWebhookSecret(ctx context.Context, id string) (string, error)
+
+	AddDeadLetter(ctx context.Context, dl *DeadLetter) error
+	GetDeadLetter(ctx context.Context, id string) (*DeadLetter, error)
+	ListDeadLetters(ctx context.Context, webhookID string) ([]*DeadLetter, error)
+	RemoveDeadLetter(ctx context.Context, id string) error
+}
+
+// OCMShareManager stores the shares exchanged with other providers with the
+// Open Cloud Mesh protocol: the outgoing ones, created by the local users, and
+// the received ones, created by remote users for the local users. A share is
+// identified on both sides by the secret the remote provider accesses the files
+// with, that is also required for the notifications about the share.
+type OCMShareManager interface {
+	// AddOCMShare stores an outgoing share of the user in the context, pending until the recipient accepts it.
+	AddOCMShare(ctx context.Context, share *OCMShare, secret string) (*OCMShare, error)
+	GetOCMShare(ctx context.Context, id string) (*OCMShare, error)
+	ListOCMShares(ctx context.Context) ([]*OCMShare, error)
+	RemoveOCMShare(ctx context.Context, id string) error
+	GetOCMShareSecret(ctx context.Context, id string) (string, error)
+	// SetOCMShareState records the answer of the recipient of the outgoing share id.
+	SetOCMShareState(ctx context.Context, id, secret string, state OCMShare_State) error
+
+	// AddReceivedOCMShare stores a share sent by a remote provider to share.Recipient, pending until accepted.
+	AddReceivedOCMShare(ctx context.Context, share *OCMShare, secret string) (*OCMShare, error)
+	GetReceivedOCMShare(ctx context.Context, id string) (*OCMShare, error)
+	ListReceivedOCMShares(ctx context.Context) ([]*OCMShare, error)
+	SetReceivedOCMShareState(ctx context.Context, id string, state OCMShare_State) (*OCMShare, error)
+	GetReceivedOCMShareSecret(ctx context.Context, id string) (string, error)
+	// RemoveReceivedOCMShare removes the received share when its owner unshares it.
+	RemoveReceivedOCMShare(ctx context.Context, providerID, secret string) error
+}
+
+// SearchDocument is the indexed information of a file. The text
+// content is only used to index the file and is not stored.
+// The readers are the accounts the file is searched for.
+type SearchDocument struct {
+	ID       string
+	ParentID string
+	Name     string
+	Mime     string
+	Size     uint64
+	Mtime    uint64
+	IsDir    bool
+	Content  string
+	Readers  []string
+}
+
+// SearchIndex indexes the files by file ID, so that the documents follow
+// the files when they are moved. The documents are scoped to their readers,
+// but not filtered by ACL, the caller checks that the user can still
+// access the files found.
+type SearchIndex interface {
+	// Index adds or replaces the document of a file, the readers
+	// of the previous document are kept.
+	Index(ctx context.Context, doc *SearchDocument) error
+	Remove(ctx context.Context, id string) error
+	// RemoveName removes the document of the file named name in the folder parentID.
+	RemoveName(ctx context.Context, parentID, name string) error
+	// Search returns the documents of any of the readers matching the query, name,
+	// mime, size and mtime of the request, the most recently modified first.
+	// The path and tags are ignored.
+	Search(ctx context.Context, readers []string, req *SearchReq) ([]*SearchDocument, error)
+}
+
+// PreviewCache stores the generated previews of the files. The previews
+// are stored by file ID and etag, so the entries of a previous version
+// of a file are replaced when the file changes.
+type PreviewCache interface {
+	// Get returns the preview of the variant (size, mode) of the file, if cached.
+	Get(ctx context.Context, fileID, etag, variant string) ([]byte, bool)
+	Put(ctx context.Context, fileID, etag, variant string, data []byte) error
+	// Purge removes the previews of the file, or of all files if fileID is empty.
+	Purge(ctx context.Context, fileID string) error
+}
+
 type TokenManager interface {
 	ForgeUserToken(ctx context.Context, user *User) (string, error)
 	DismantleUserToken(ctx context.Context, token string) (*User, error)
@@ -228,6 +893,40 @@ func GetStatus(err error) StatusCode {
 		return StatusCode_PUBLIC_LINK_INVALID_DATE
 	case PublicLinkNotFoundErrorCode:
 		return StatusCode_PUBLIC_LINK_NOT_FOUND
+	case PublicLinkDownloadLimitErrorCode:
+		return StatusCode_PUBLIC_LINK_DOWNLOAD_LIMIT
+	case PublicLinkPasswordTooShortErrorCode:
+		return StatusCode_PUBLIC_LINK_PASSWORD_TOO_SHORT
+	case PublicLinkPasswordTooWeakErrorCode:
+		return StatusCode_PUBLIC_LINK_PASSWORD_TOO_WEAK
+	case PublicLinkPasswordBannedErrorCode:
+		return StatusCode_PUBLIC_LINK_PASSWORD_BANNED
+	case PublicLinkPasswordRequiredErrorCode:
+		return StatusCode_PUBLIC_LINK_PASSWORD_REQUIRED
+	case AppPasswordNotFoundErrorCode:
+		return StatusCode_APP_PASSWORD_NOT_FOUND
+	case TooManyAttemptsErrorCode:
+		return StatusCode_TOO_MANY_ATTEMPTS
+	case WatchOverflowErrorCode:
+		return StatusCode_WATCH_OVERFLOW
+	case WebhookNotFoundErrorCode:
+		return StatusCode_WEBHOOK_NOT_FOUND
+	case DeadLetterNotFoundErrorCode:
+		return StatusCode_DEAD_LETTER_NOT_FOUND
+	case PreviewNotSupportedErrorCode:
+		return StatusCode_PREVIEW_NOT_SUPPORTED
+	case TagNotFoundErrorCode:
+		return StatusCode_TAG_NOT_FOUND
+	case FolderShareInvalidPermissionsErrorCode:
+		return StatusCode_FOLDER_SHARE_INVALID_PERMISSIONS
+	case OCMShareNotFoundErrorCode:
+		return StatusCode_OCM_SHARE_NOT_FOUND
+	case ProjectNotFoundErrorCode:
+		return StatusCode_PROJECT_NOT_FOUND
+	case ProjectAlreadyExistsErrorCode:
+		return StatusCode_PROJECT_ALREADY_EXISTS
+	case ProjectInvalidErrorCode:
+		return StatusCode_PROJECT_INVALID
 	default:
 		return StatusCode_UNKNOWN
 	}
@@ -248,6 +947,24 @@ func DetectMimeType(isDir bool, path string) string {
 
 	return mimeType
 }
+
+// textExtensions are the source code and text files that do not always have a text/ mime type.
+var textExtensions = map[string]bool{
+	".txt": true, ".log": true, ".csv": true, ".tsv": true, ".md": true, ".markdown": true, ".json": true, ".ipynb": true,
+	".ini": true, ".cfg": true, ".conf": true, ".toml": true, ".yaml": true, ".yml": true, ".xml": true,
+	".c": true, ".h": true, ".cc": true, ".cpp": true, ".cxx": true, ".hpp": true, ".C": true,
+	".go": true, ".py": true, ".java": true, ".js": true, ".ts": true, ".rs": true, ".rb": true,
+	".php": true, ".pl": true, ".sh": true, ".bash": true, ".zsh": true, ".sql": true,
+	".r": true, ".R": true, ".m": true, ".jl": true, ".f": true, ".f90": true, ".tex": true,
+	".html": true, ".css": true,
+}
+
+// IsTextFile returns true if the file at path is known to be text by its extension,
+// the previews render it as text and the search indexes its content.
+func IsTextFile(path string) bool {
+	return textExtensions[gopath.Ext(path)]
+}
+
 func getCustomMime(ext string) string {
 	switch ext {
 	case ".root":
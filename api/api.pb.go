@@ -25,20 +25,37 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type StatusCode int32
 
 const (
-	StatusCode_OK                           StatusCode = 0
-	StatusCode_UNKNOWN                      StatusCode = 1
-	StatusCode_STORAGE_NOT_FOUND            StatusCode = 2
-	StatusCode_STORAGE_ALREADY_EXISTS       StatusCode = 3
-	StatusCode_STORAGE_PERMISSIONDENIED     StatusCode = 4
-	StatusCode_CONTEXT_USER_REQUIRED        StatusCode = 5
-	StatusCode_PATH_INVALID                 StatusCode = 6
-	StatusCode_PUBLIC_LINK_NOT_FOUND        StatusCode = 7
-	StatusCode_PUBLIC_LINK_INVALID_DATE     StatusCode = 8
-	StatusCode_PUBLIC_LINK_INVALID_PASSWORD StatusCode = 9
-	StatusCode_STORAGE_NOT_SUPPORTED        StatusCode = 10
-	StatusCode_USER_NOT_FOUND               StatusCode = 11
-	StatusCode_TOKEN_INVALID                StatusCode = 12
-	StatusCode_FOLDER_SHARE_NOT_FOUND       StatusCode = 13
+	StatusCode_OK                               StatusCode = 0
+	StatusCode_UNKNOWN                          StatusCode = 1
+	StatusCode_STORAGE_NOT_FOUND                StatusCode = 2
+	StatusCode_STORAGE_ALREADY_EXISTS           StatusCode = 3
+	StatusCode_STORAGE_PERMISSIONDENIED         StatusCode = 4
+	StatusCode_CONTEXT_USER_REQUIRED            StatusCode = 5
+	StatusCode_PATH_INVALID                     StatusCode = 6
+	StatusCode_PUBLIC_LINK_NOT_FOUND            StatusCode = 7
+	StatusCode_PUBLIC_LINK_INVALID_DATE         StatusCode = 8
+	StatusCode_PUBLIC_LINK_INVALID_PASSWORD     StatusCode = 9
+	StatusCode_STORAGE_NOT_SUPPORTED            StatusCode = 10
+	StatusCode_USER_NOT_FOUND                   StatusCode = 11
+	StatusCode_TOKEN_INVALID                    StatusCode = 12
+	StatusCode_FOLDER_SHARE_NOT_FOUND           StatusCode = 13
+	StatusCode_APP_PASSWORD_NOT_FOUND           StatusCode = 14
+	StatusCode_TOO_MANY_ATTEMPTS                StatusCode = 15
+	StatusCode_WATCH_OVERFLOW                   StatusCode = 16
+	StatusCode_WEBHOOK_NOT_FOUND                StatusCode = 17
+	StatusCode_DEAD_LETTER_NOT_FOUND            StatusCode = 18
+	StatusCode_PREVIEW_NOT_SUPPORTED            StatusCode = 19
+	StatusCode_TAG_NOT_FOUND                    StatusCode = 20
+	StatusCode_FOLDER_SHARE_INVALID_PERMISSIONS StatusCode = 21
+	StatusCode_OCM_SHARE_NOT_FOUND              StatusCode = 22
+	StatusCode_PUBLIC_LINK_DOWNLOAD_LIMIT       StatusCode = 23
+	StatusCode_PUBLIC_LINK_PASSWORD_TOO_SHORT   StatusCode = 24
+	StatusCode_PUBLIC_LINK_PASSWORD_TOO_WEAK    StatusCode = 25
+	StatusCode_PUBLIC_LINK_PASSWORD_BANNED      StatusCode = 26
+	StatusCode_PUBLIC_LINK_PASSWORD_REQUIRED    StatusCode = 27
+	StatusCode_PROJECT_NOT_FOUND                StatusCode = 28
+	StatusCode_PROJECT_ALREADY_EXISTS           StatusCode = 29
+	StatusCode_PROJECT_INVALID                  StatusCode = 30
 )
 
 var StatusCode_name = map[int32]string{
@@ -56,23 +73,57 @@ var StatusCode_name = map[int32]string{
 	11: "USER_NOT_FOUND",
 	12: "TOKEN_INVALID",
 	13: "FOLDER_SHARE_NOT_FOUND",
+	14: "APP_PASSWORD_NOT_FOUND",
+	15: "TOO_MANY_ATTEMPTS",
+	16: "WATCH_OVERFLOW",
+	17: "WEBHOOK_NOT_FOUND",
+	18: "DEAD_LETTER_NOT_FOUND",
+	19: "PREVIEW_NOT_SUPPORTED",
+	20: "TAG_NOT_FOUND",
+	21: "FOLDER_SHARE_INVALID_PERMISSIONS",
+	22: "OCM_SHARE_NOT_FOUND",
+	23: "PUBLIC_LINK_DOWNLOAD_LIMIT",
+	24: "PUBLIC_LINK_PASSWORD_TOO_SHORT",
+	25: "PUBLIC_LINK_PASSWORD_TOO_WEAK",
+	26: "PUBLIC_LINK_PASSWORD_BANNED",
+	27: "PUBLIC_LINK_PASSWORD_REQUIRED",
+	28: "PROJECT_NOT_FOUND",
+	29: "PROJECT_ALREADY_EXISTS",
+	30: "PROJECT_INVALID",
 }
 
 var StatusCode_value = map[string]int32{
-	"OK":                           0,
-	"UNKNOWN":                      1,
-	"STORAGE_NOT_FOUND":            2,
-	"STORAGE_ALREADY_EXISTS":       3,
-	"STORAGE_PERMISSIONDENIED":     4,
-	"CONTEXT_USER_REQUIRED":        5,
-	"PATH_INVALID":                 6,
-	"PUBLIC_LINK_NOT_FOUND":        7,
-	"PUBLIC_LINK_INVALID_DATE":     8,
-	"PUBLIC_LINK_INVALID_PASSWORD": 9,
-	"STORAGE_NOT_SUPPORTED":        10,
-	"USER_NOT_FOUND":               11,
-	"TOKEN_INVALID":                12,
-	"FOLDER_SHARE_NOT_FOUND":       13,
+	"OK":                               0,
+	"UNKNOWN":                          1,
+	"STORAGE_NOT_FOUND":                2,
+	"STORAGE_ALREADY_EXISTS":           3,
+	"STORAGE_PERMISSIONDENIED":         4,
+	"CONTEXT_USER_REQUIRED":            5,
+	"PATH_INVALID":                     6,
+	"PUBLIC_LINK_NOT_FOUND":            7,
+	"PUBLIC_LINK_INVALID_DATE":         8,
+	"PUBLIC_LINK_INVALID_PASSWORD":     9,
+	"STORAGE_NOT_SUPPORTED":            10,
+	"USER_NOT_FOUND":                   11,
+	"TOKEN_INVALID":                    12,
+	"FOLDER_SHARE_NOT_FOUND":           13,
+	"APP_PASSWORD_NOT_FOUND":           14,
+	"TOO_MANY_ATTEMPTS":                15,
+	"WATCH_OVERFLOW":                   16,
+	"WEBHOOK_NOT_FOUND":                17,
+	"DEAD_LETTER_NOT_FOUND":            18,
+	"PREVIEW_NOT_SUPPORTED":            19,
+	"TAG_NOT_FOUND":                    20,
+	"FOLDER_SHARE_INVALID_PERMISSIONS": 21,
+	"OCM_SHARE_NOT_FOUND":              22,
+	"PUBLIC_LINK_DOWNLOAD_LIMIT":       23,
+	"PUBLIC_LINK_PASSWORD_TOO_SHORT":   24,
+	"PUBLIC_LINK_PASSWORD_TOO_WEAK":    25,
+	"PUBLIC_LINK_PASSWORD_BANNED":      26,
+	"PUBLIC_LINK_PASSWORD_REQUIRED":    27,
+	"PROJECT_NOT_FOUND":                28,
+	"PROJECT_ALREADY_EXISTS":           29,
+	"PROJECT_INVALID":                  30,
 }
 
 func (x StatusCode) String() string {
@@ -105,7 +156,32 @@ func (x Tag_ItemType) String() string {
 }
 
 func (Tag_ItemType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{1, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{2, 0}
+}
+
+type PreviewReq_Mode int32
+
+const (
+	PreviewReq_CROP PreviewReq_Mode = 0
+	PreviewReq_FIT  PreviewReq_Mode = 1
+)
+
+var PreviewReq_Mode_name = map[int32]string{
+	0: "CROP",
+	1: "FIT",
+}
+
+var PreviewReq_Mode_value = map[string]int32{
+	"CROP": 0,
+	"FIT":  1,
+}
+
+func (x PreviewReq_Mode) String() string {
+	return proto.EnumName(PreviewReq_Mode_name, int32(x))
+}
+
+func (PreviewReq_Mode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{30, 0}
 }
 
 type ShareRecipient_RecipientType int32
@@ -133,7 +209,7 @@ func (x ShareRecipient_RecipientType) String() string {
 }
 
 func (ShareRecipient_RecipientType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{39, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{42, 0}
 }
 
 type PublicLink_ItemType int32
@@ -158,7 +234,7 @@ func (x PublicLink_ItemType) String() string {
 }
 
 func (PublicLink_ItemType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{41, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{44, 0}
 }
 
 type FolderShare_State int32
@@ -186,13 +262,206 @@ func (x FolderShare_State) String() string {
 }
 
 func (FolderShare_State) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{45, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{48, 0}
+}
+
+type FolderShare_ItemType int32
+
+const (
+	FolderShare_FOLDER FolderShare_ItemType = 0
+	FolderShare_FILE   FolderShare_ItemType = 1
+)
+
+var FolderShare_ItemType_name = map[int32]string{
+	0: "FOLDER",
+	1: "FILE",
+}
+
+var FolderShare_ItemType_value = map[string]int32{
+	"FOLDER": 0,
+	"FILE":   1,
+}
+
+func (x FolderShare_ItemType) String() string {
+	return proto.EnumName(FolderShare_ItemType_name, int32(x))
+}
+
+func (FolderShare_ItemType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{48, 1}
+}
+
+type FsckEntry_Kind int32
+
+const (
+	FsckEntry_FOLDER_SHARE FsckEntry_Kind = 0
+	FsckEntry_PUBLIC_LINK  FsckEntry_Kind = 1
+)
+
+var FsckEntry_Kind_name = map[int32]string{
+	0: "FOLDER_SHARE",
+	1: "PUBLIC_LINK",
+}
+
+var FsckEntry_Kind_value = map[string]int32{
+	"FOLDER_SHARE": 0,
+	"PUBLIC_LINK":  1,
+}
+
+func (x FsckEntry_Kind) String() string {
+	return proto.EnumName(FsckEntry_Kind_name, int32(x))
+}
+
+func (FsckEntry_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{56, 0}
+}
+
+type FsckEntry_Problem int32
+
+const (
+	FsckEntry_ORPHAN    FsckEntry_Problem = 0
+	FsckEntry_RESTORED  FsckEntry_Problem = 1
+	FsckEntry_SUSPENDED FsckEntry_Problem = 2
+)
+
+var FsckEntry_Problem_name = map[int32]string{
+	0: "ORPHAN",
+	1: "RESTORED",
+	2: "SUSPENDED",
+}
+
+var FsckEntry_Problem_value = map[string]int32{
+	"ORPHAN":    0,
+	"RESTORED":  1,
+	"SUSPENDED": 2,
+}
+
+func (x FsckEntry_Problem) String() string {
+	return proto.EnumName(FsckEntry_Problem_name, int32(x))
+}
+
+func (FsckEntry_Problem) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{56, 1}
+}
+
+type TransferProgress_Step int32
+
+const (
+	TransferProgress_FILES       TransferProgress_Step = 0
+	TransferProgress_SHARE       TransferProgress_Step = 1
+	TransferProgress_PUBLIC_LINK TransferProgress_Step = 2
+	TransferProgress_ACL         TransferProgress_Step = 3
+	TransferProgress_DONE        TransferProgress_Step = 4
+)
+
+var TransferProgress_Step_name = map[int32]string{
+	0: "FILES",
+	1: "SHARE",
+	2: "PUBLIC_LINK",
+	3: "ACL",
+	4: "DONE",
+}
+
+var TransferProgress_Step_value = map[string]int32{
+	"FILES":       0,
+	"SHARE":       1,
+	"PUBLIC_LINK": 2,
+	"ACL":         3,
+	"DONE":        4,
+}
+
+func (x TransferProgress_Step) String() string {
+	return proto.EnumName(TransferProgress_Step_name, int32(x))
+}
+
+func (TransferProgress_Step) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{59, 0}
+}
+
+type FileEvent_Type int32
+
+const (
+	FileEvent_CREATED       FileEvent_Type = 0
+	FileEvent_WRITTEN       FileEvent_Type = 1
+	FileEvent_DELETED       FileEvent_Type = 2
+	FileEvent_MOVED         FileEvent_Type = 3
+	FileEvent_SHARE_ADDED   FileEvent_Type = 4
+	FileEvent_SHARE_UPDATED FileEvent_Type = 5
+	FileEvent_SHARE_REMOVED FileEvent_Type = 6
+	FileEvent_LINK_CREATED  FileEvent_Type = 7
+	FileEvent_LINK_UPDATED  FileEvent_Type = 8
+	FileEvent_LINK_REVOKED  FileEvent_Type = 9
+	FileEvent_SHARE_EXPIRED FileEvent_Type = 10
+)
+
+var FileEvent_Type_name = map[int32]string{
+	0:  "CREATED",
+	1:  "WRITTEN",
+	2:  "DELETED",
+	3:  "MOVED",
+	4:  "SHARE_ADDED",
+	5:  "SHARE_UPDATED",
+	6:  "SHARE_REMOVED",
+	7:  "LINK_CREATED",
+	8:  "LINK_UPDATED",
+	9:  "LINK_REVOKED",
+	10: "SHARE_EXPIRED",
+}
+
+var FileEvent_Type_value = map[string]int32{
+	"CREATED":       0,
+	"WRITTEN":       1,
+	"DELETED":       2,
+	"MOVED":         3,
+	"SHARE_ADDED":   4,
+	"SHARE_UPDATED": 5,
+	"SHARE_REMOVED": 6,
+	"LINK_CREATED":  7,
+	"LINK_UPDATED":  8,
+	"LINK_REVOKED":  9,
+	"SHARE_EXPIRED": 10,
+}
+
+func (x FileEvent_Type) String() string {
+	return proto.EnumName(FileEvent_Type_name, int32(x))
+}
+
+func (FileEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{70, 0}
+}
+
+type OCMShare_State int32
+
+const (
+	OCMShare_ACCEPTED OCMShare_State = 0
+	OCMShare_PENDING  OCMShare_State = 1
+	OCMShare_REJECTED OCMShare_State = 2
+)
+
+var OCMShare_State_name = map[int32]string{
+	0: "ACCEPTED",
+	1: "PENDING",
+	2: "REJECTED",
+}
+
+var OCMShare_State_value = map[string]int32{
+	"ACCEPTED": 0,
+	"PENDING":  1,
+	"REJECTED": 2,
+}
+
+func (x OCMShare_State) String() string {
+	return proto.EnumName(OCMShare_State_name, int32(x))
+}
+
+func (OCMShare_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{79, 0}
 }
 
 type TagReq struct {
 	TagKey               string   `protobuf:"bytes,1,opt,name=tag_key,json=tagKey,proto3" json:"tag_key,omitempty"`
 	TagVal               string   `protobuf:"bytes,2,opt,name=tag_val,json=tagVal,proto3" json:"tag_val,omitempty"`
 	Path                 string   `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Project              string   `protobuf:"bytes,4,opt,name=project,proto3" json:"project,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -244,6 +513,68 @@ func (m *TagReq) GetPath() string {
 	return ""
 }
 
+func (m *TagReq) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+type RenameTagReq struct {
+	TagKey               string   `protobuf:"bytes,1,opt,name=tag_key,json=tagKey,proto3" json:"tag_key,omitempty"`
+	NewTagKey            string   `protobuf:"bytes,2,opt,name=new_tag_key,json=newTagKey,proto3" json:"new_tag_key,omitempty"`
+	Project              string   `protobuf:"bytes,3,opt,name=project,proto3" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenameTagReq) Reset()         { *m = RenameTagReq{} }
+func (m *RenameTagReq) String() string { return proto.CompactTextString(m) }
+func (*RenameTagReq) ProtoMessage()    {}
+func (*RenameTagReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{1}
+}
+
+func (m *RenameTagReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameTagReq.Unmarshal(m, b)
+}
+func (m *RenameTagReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameTagReq.Marshal(b, m, deterministic)
+}
+func (m *RenameTagReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameTagReq.Merge(m, src)
+}
+func (m *RenameTagReq) XXX_Size() int {
+	return xxx_messageInfo_RenameTagReq.Size(m)
+}
+func (m *RenameTagReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameTagReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenameTagReq proto.InternalMessageInfo
+
+func (m *RenameTagReq) GetTagKey() string {
+	if m != nil {
+		return m.TagKey
+	}
+	return ""
+}
+
+func (m *RenameTagReq) GetNewTagKey() string {
+	if m != nil {
+		return m.NewTagKey
+	}
+	return ""
+}
+
+func (m *RenameTagReq) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
 type Tag struct {
 	Id                   int64        `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	ItemType             Tag_ItemType `protobuf:"varint,2,opt,name=item_type,json=itemType,proto3,enum=api.Tag_ItemType" json:"item_type,omitempty"`
@@ -252,6 +583,7 @@ type Tag struct {
 	FileId               string       `protobuf:"bytes,5,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
 	TagKey               string       `protobuf:"bytes,6,opt,name=tag_key,json=tagKey,proto3" json:"tag_key,omitempty"`
 	TagValue             string       `protobuf:"bytes,7,opt,name=tag_value,json=tagValue,proto3" json:"tag_value,omitempty"`
+	Project              string       `protobuf:"bytes,8,opt,name=project,proto3" json:"project,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -261,7 +593,7 @@ func (m *Tag) Reset()         { *m = Tag{} }
 func (m *Tag) String() string { return proto.CompactTextString(m) }
 func (*Tag) ProtoMessage()    {}
 func (*Tag) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{1}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
 }
 
 func (m *Tag) XXX_Unmarshal(b []byte) error {
@@ -331,6 +663,13 @@ func (m *Tag) GetTagValue() string {
 	return ""
 }
 
+func (m *Tag) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
 type TagResponse struct {
 	Status               StatusCode `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
 	Tag                  *Tag       `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
@@ -343,7 +682,7 @@ func (m *TagResponse) Reset()         { *m = TagResponse{} }
 func (m *TagResponse) String() string { return proto.CompactTextString(m) }
 func (*TagResponse) ProtoMessage()    {}
 func (*TagResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{3}
 }
 
 func (m *TagResponse) XXX_Unmarshal(b []byte) error {
@@ -390,7 +729,7 @@ func (m *IsPublicLinkProtectedResponse) Reset()         { *m = IsPublicLinkProte
 func (m *IsPublicLinkProtectedResponse) String() string { return proto.CompactTextString(m) }
 func (*IsPublicLinkProtectedResponse) ProtoMessage()    {}
 func (*IsPublicLinkProtectedResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{3}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{4}
 }
 
 func (m *IsPublicLinkProtectedResponse) XXX_Unmarshal(b []byte) error {
@@ -437,7 +776,7 @@ func (m *ForgePublicLinkTokenReq) Reset()         { *m = ForgePublicLinkTokenReq
 func (m *ForgePublicLinkTokenReq) String() string { return proto.CompactTextString(m) }
 func (*ForgePublicLinkTokenReq) ProtoMessage()    {}
 func (*ForgePublicLinkTokenReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{4}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{5}
 }
 
 func (m *ForgePublicLinkTokenReq) XXX_Unmarshal(b []byte) error {
@@ -484,7 +823,7 @@ func (m *ForgePublicLinkTokenResponse) Reset()         { *m = ForgePublicLinkTok
 func (m *ForgePublicLinkTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*ForgePublicLinkTokenResponse) ProtoMessage()    {}
 func (*ForgePublicLinkTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{5}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
 }
 
 func (m *ForgePublicLinkTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -530,7 +869,7 @@ func (m *VerifyPublicLinkTokenReq) Reset()         { *m = VerifyPublicLinkTokenR
 func (m *VerifyPublicLinkTokenReq) String() string { return proto.CompactTextString(m) }
 func (*VerifyPublicLinkTokenReq) ProtoMessage()    {}
 func (*VerifyPublicLinkTokenReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
 }
 
 func (m *VerifyPublicLinkTokenReq) XXX_Unmarshal(b []byte) error {
@@ -570,7 +909,7 @@ func (m *VerifyPublicLinkTokenResponse) Reset()         { *m = VerifyPublicLinkT
 func (m *VerifyPublicLinkTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*VerifyPublicLinkTokenResponse) ProtoMessage()    {}
 func (*VerifyPublicLinkTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
 }
 
 func (m *VerifyPublicLinkTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -616,7 +955,7 @@ func (m *EmptyResponse) Reset()         { *m = EmptyResponse{} }
 func (m *EmptyResponse) String() string { return proto.CompactTextString(m) }
 func (*EmptyResponse) ProtoMessage()    {}
 func (*EmptyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
 }
 
 func (m *EmptyResponse) XXX_Unmarshal(b []byte) error {
@@ -654,7 +993,7 @@ func (m *EmptyReq) Reset()         { *m = EmptyReq{} }
 func (m *EmptyReq) String() string { return proto.CompactTextString(m) }
 func (*EmptyReq) ProtoMessage()    {}
 func (*EmptyReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
 }
 
 func (m *EmptyReq) XXX_Unmarshal(b []byte) error {
@@ -686,7 +1025,7 @@ func (m *QuotaReq) Reset()         { *m = QuotaReq{} }
 func (m *QuotaReq) String() string { return proto.CompactTextString(m) }
 func (*QuotaReq) ProtoMessage()    {}
 func (*QuotaReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
 }
 
 func (m *QuotaReq) XXX_Unmarshal(b []byte) error {
@@ -727,7 +1066,7 @@ func (m *QuotaResponse) Reset()         { *m = QuotaResponse{} }
 func (m *QuotaResponse) String() string { return proto.CompactTextString(m) }
 func (*QuotaResponse) ProtoMessage()    {}
 func (*QuotaResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
 }
 
 func (m *QuotaResponse) XXX_Unmarshal(b []byte) error {
@@ -781,7 +1120,7 @@ func (m *UserResponse) Reset()         { *m = UserResponse{} }
 func (m *UserResponse) String() string { return proto.CompactTextString(m) }
 func (*UserResponse) ProtoMessage()    {}
 func (*UserResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
 }
 
 func (m *UserResponse) XXX_Unmarshal(b []byte) error {
@@ -820,6 +1159,9 @@ type User struct {
 	AccountId            string   `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
 	Groups               []string `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
 	DisplayName          string   `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	ReadOnly             bool     `protobuf:"varint,4,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	RestrictedPath       string   `protobuf:"bytes,5,opt,name=restricted_path,json=restrictedPath,proto3" json:"restricted_path,omitempty"`
+	AppPassword          bool     `protobuf:"varint,6,opt,name=app_password,json=appPassword,proto3" json:"app_password,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -829,7 +1171,7 @@ func (m *User) Reset()         { *m = User{} }
 func (m *User) String() string { return proto.CompactTextString(m) }
 func (*User) ProtoMessage()    {}
 func (*User) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
 }
 
 func (m *User) XXX_Unmarshal(b []byte) error {
@@ -871,6 +1213,27 @@ func (m *User) GetDisplayName() string {
 	return ""
 }
 
+func (m *User) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *User) GetRestrictedPath() string {
+	if m != nil {
+		return m.RestrictedPath
+	}
+	return ""
+}
+
+func (m *User) GetAppPassword() bool {
+	if m != nil {
+		return m.AppPassword
+	}
+	return false
+}
+
 type TxInfoResponse struct {
 	Status               StatusCode `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
 	TxInfo               *TxInfo    `protobuf:"bytes,2,opt,name=txInfo,proto3" json:"txInfo,omitempty"`
@@ -883,7 +1246,7 @@ func (m *TxInfoResponse) Reset()         { *m = TxInfoResponse{} }
 func (m *TxInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*TxInfoResponse) ProtoMessage()    {}
 func (*TxInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
 }
 
 func (m *TxInfoResponse) XXX_Unmarshal(b []byte) error {
@@ -929,7 +1292,7 @@ func (m *TxInfo) Reset()         { *m = TxInfo{} }
 func (m *TxInfo) String() string { return proto.CompactTextString(m) }
 func (*TxInfo) ProtoMessage()    {}
 func (*TxInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
 }
 
 func (m *TxInfo) XXX_Unmarshal(b []byte) error {
@@ -969,7 +1332,7 @@ func (m *ForgeUserTokenReq) Reset()         { *m = ForgeUserTokenReq{} }
 func (m *ForgeUserTokenReq) String() string { return proto.CompactTextString(m) }
 func (*ForgeUserTokenReq) ProtoMessage()    {}
 func (*ForgeUserTokenReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
 }
 
 func (m *ForgeUserTokenReq) XXX_Unmarshal(b []byte) error {
@@ -1005,18 +1368,20 @@ func (m *ForgeUserTokenReq) GetClientSecret() string {
 }
 
 type TokenResponse struct {
-	Status               StatusCode `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
-	Token                string     `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Status StatusCode `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
+	Token  string     `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// seconds to wait before a new attempt is accepted, set with TOO_MANY_ATTEMPTS.
+	RetryAfter           uint64   `protobuf:"varint,3,opt,name=retry_after,json=retryAfter,proto3" json:"retry_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TokenResponse) Reset()         { *m = TokenResponse{} }
 func (m *TokenResponse) String() string { return proto.CompactTextString(m) }
 func (*TokenResponse) ProtoMessage()    {}
 func (*TokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
 }
 
 func (m *TokenResponse) XXX_Unmarshal(b []byte) error {
@@ -1051,6 +1416,13 @@ func (m *TokenResponse) GetToken() string {
 	return ""
 }
 
+func (m *TokenResponse) GetRetryAfter() uint64 {
+	if m != nil {
+		return m.RetryAfter
+	}
+	return 0
+}
+
 type TokenReq struct {
 	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1062,7 +1434,7 @@ func (m *TokenReq) Reset()         { *m = TokenReq{} }
 func (m *TokenReq) String() string { return proto.CompactTextString(m) }
 func (*TokenReq) ProtoMessage()    {}
 func (*TokenReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
 }
 
 func (m *TokenReq) XXX_Unmarshal(b []byte) error {
@@ -1102,7 +1474,7 @@ func (m *MetadataResponse) Reset()         { *m = MetadataResponse{} }
 func (m *MetadataResponse) String() string { return proto.CompactTextString(m) }
 func (*MetadataResponse) ProtoMessage()    {}
 func (*MetadataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
 }
 
 func (m *MetadataResponse) XXX_Unmarshal(b []byte) error {
@@ -1152,10 +1524,15 @@ type Metadata struct {
 	Sys         []byte `protobuf:"bytes,12,opt,name=sys,proto3" json:"sys,omitempty"`
 	TreeCount   uint64 `protobuf:"varint,13,opt,name=tree_count,json=treeCount,proto3" json:"tree_count,omitempty"`
 	// EOS filesytem extended metadata records
-	EosFile     string `protobuf:"bytes,14,opt,name=eos_file,json=eosFile,proto3" json:"eos_file,omitempty"`
-	EosInstance string `protobuf:"bytes,15,opt,name=eos_instance,json=eosInstance,proto3" json:"eos_instance,omitempty"`
+	EosFile         string `protobuf:"bytes,14,opt,name=eos_file,json=eosFile,proto3" json:"eos_file,omitempty"`
+	EosInstance     string `protobuf:"bytes,15,opt,name=eos_instance,json=eosInstance,proto3" json:"eos_instance,omitempty"`
+	VersionFolderId string `protobuf:"bytes,23,opt,name=version_folder_id,json=versionFolderId,proto3" json:"version_folder_id,omitempty"`
 	// Share extended metadata records
-	ShareTarget string `protobuf:"bytes,16,opt,name=share_target,json=shareTarget,proto3" json:"share_target,omitempty"`
+	ShareTarget      string `protobuf:"bytes,16,opt,name=share_target,json=shareTarget,proto3" json:"share_target,omitempty"`
+	ShareId          string `protobuf:"bytes,19,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	ShareOwnerId     string `protobuf:"bytes,20,opt,name=share_owner_id,json=shareOwnerId,proto3" json:"share_owner_id,omitempty"`
+	ShareFileId      string `protobuf:"bytes,21,opt,name=share_file_id,json=shareFileId,proto3" json:"share_file_id,omitempty"`
+	SharePermissions uint32 `protobuf:"varint,22,opt,name=share_permissions,json=sharePermissions,proto3" json:"share_permissions,omitempty"`
 	// Migration extended metadata records
 	MigId                string   `protobuf:"bytes,17,opt,name=mig_id,json=migId,proto3" json:"mig_id,omitempty"`
 	MigPath              string   `protobuf:"bytes,18,opt,name=mig_path,json=migPath,proto3" json:"mig_path,omitempty"`
@@ -1168,7 +1545,7 @@ func (m *Metadata) Reset()         { *m = Metadata{} }
 func (m *Metadata) String() string { return proto.CompactTextString(m) }
 func (*Metadata) ProtoMessage()    {}
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
 }
 
 func (m *Metadata) XXX_Unmarshal(b []byte) error {
@@ -1294,6 +1671,13 @@ func (m *Metadata) GetEosInstance() string {
 	return ""
 }
 
+func (m *Metadata) GetVersionFolderId() string {
+	if m != nil {
+		return m.VersionFolderId
+	}
+	return ""
+}
+
 func (m *Metadata) GetShareTarget() string {
 	if m != nil {
 		return m.ShareTarget
@@ -1301,6 +1685,34 @@ func (m *Metadata) GetShareTarget() string {
 	return ""
 }
 
+func (m *Metadata) GetShareId() string {
+	if m != nil {
+		return m.ShareId
+	}
+	return ""
+}
+
+func (m *Metadata) GetShareOwnerId() string {
+	if m != nil {
+		return m.ShareOwnerId
+	}
+	return ""
+}
+
+func (m *Metadata) GetShareFileId() string {
+	if m != nil {
+		return m.ShareFileId
+	}
+	return ""
+}
+
+func (m *Metadata) GetSharePermissions() uint32 {
+	if m != nil {
+		return m.SharePermissions
+	}
+	return 0
+}
+
 func (m *Metadata) GetMigId() string {
 	if m != nil {
 		return m.MigId
@@ -1326,7 +1738,7 @@ func (m *PathReq) Reset()         { *m = PathReq{} }
 func (m *PathReq) String() string { return proto.CompactTextString(m) }
 func (*PathReq) ProtoMessage()    {}
 func (*PathReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
 }
 
 func (m *PathReq) XXX_Unmarshal(b []byte) error {
@@ -1366,7 +1778,7 @@ func (m *MoveReq) Reset()         { *m = MoveReq{} }
 func (m *MoveReq) String() string { return proto.CompactTextString(m) }
 func (*MoveReq) ProtoMessage()    {}
 func (*MoveReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
 }
 
 func (m *MoveReq) XXX_Unmarshal(b []byte) error {
@@ -1416,7 +1828,7 @@ func (m *TxChunk) Reset()         { *m = TxChunk{} }
 func (m *TxChunk) String() string { return proto.CompactTextString(m) }
 func (*TxChunk) ProtoMessage()    {}
 func (*TxChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
 }
 
 func (m *TxChunk) XXX_Unmarshal(b []byte) error {
@@ -1477,7 +1889,7 @@ func (m *WriteSummaryResponse) Reset()         { *m = WriteSummaryResponse{} }
 func (m *WriteSummaryResponse) String() string { return proto.CompactTextString(m) }
 func (*WriteSummaryResponse) ProtoMessage()    {}
 func (*WriteSummaryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
 }
 
 func (m *WriteSummaryResponse) XXX_Unmarshal(b []byte) error {
@@ -1524,7 +1936,7 @@ func (m *WriteSummary) Reset()         { *m = WriteSummary{} }
 func (m *WriteSummary) String() string { return proto.CompactTextString(m) }
 func (*WriteSummary) ProtoMessage()    {}
 func (*WriteSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
 }
 
 func (m *WriteSummary) XXX_Unmarshal(b []byte) error {
@@ -1572,7 +1984,7 @@ func (m *TxEnd) Reset()         { *m = TxEnd{} }
 func (m *TxEnd) String() string { return proto.CompactTextString(m) }
 func (*TxEnd) ProtoMessage()    {}
 func (*TxEnd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
 }
 
 func (m *TxEnd) XXX_Unmarshal(b []byte) error {
@@ -1626,7 +2038,7 @@ func (m *DataChunkResponse) Reset()         { *m = DataChunkResponse{} }
 func (m *DataChunkResponse) String() string { return proto.CompactTextString(m) }
 func (*DataChunkResponse) ProtoMessage()    {}
 func (*DataChunkResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
 }
 
 func (m *DataChunkResponse) XXX_Unmarshal(b []byte) error {
@@ -1661,23 +2073,197 @@ func (m *DataChunkResponse) GetDataChunk() *DataChunk {
 	return nil
 }
 
-type DataChunk struct {
-	Length               uint64   `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
-	Offset               uint64   `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+type SearchReq struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Query                string   `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Mime                 string   `protobuf:"bytes,4,opt,name=mime,proto3" json:"mime,omitempty"`
+	MinSize              uint64   `protobuf:"varint,5,opt,name=min_size,json=minSize,proto3" json:"min_size,omitempty"`
+	MaxSize              uint64   `protobuf:"varint,6,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	MinMtime             uint64   `protobuf:"varint,7,opt,name=min_mtime,json=minMtime,proto3" json:"min_mtime,omitempty"`
+	MaxMtime             uint64   `protobuf:"varint,8,opt,name=max_mtime,json=maxMtime,proto3" json:"max_mtime,omitempty"`
+	Tags                 []string `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	Limit                uint64   `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DataChunk) Reset()         { *m = DataChunk{} }
-func (m *DataChunk) String() string { return proto.CompactTextString(m) }
-func (*DataChunk) ProtoMessage()    {}
-func (*DataChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
+func (m *SearchReq) Reset()         { *m = SearchReq{} }
+func (m *SearchReq) String() string { return proto.CompactTextString(m) }
+func (*SearchReq) ProtoMessage()    {}
+func (*SearchReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
 }
 
-func (m *DataChunk) XXX_Unmarshal(b []byte) error {
+func (m *SearchReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchReq.Unmarshal(m, b)
+}
+func (m *SearchReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchReq.Marshal(b, m, deterministic)
+}
+func (m *SearchReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchReq.Merge(m, src)
+}
+func (m *SearchReq) XXX_Size() int {
+	return xxx_messageInfo_SearchReq.Size(m)
+}
+func (m *SearchReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchReq proto.InternalMessageInfo
+
+func (m *SearchReq) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *SearchReq) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SearchReq) GetMime() string {
+	if m != nil {
+		return m.Mime
+	}
+	return ""
+}
+
+func (m *SearchReq) GetMinSize() uint64 {
+	if m != nil {
+		return m.MinSize
+	}
+	return 0
+}
+
+func (m *SearchReq) GetMaxSize() uint64 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+func (m *SearchReq) GetMinMtime() uint64 {
+	if m != nil {
+		return m.MinMtime
+	}
+	return 0
+}
+
+func (m *SearchReq) GetMaxMtime() uint64 {
+	if m != nil {
+		return m.MaxMtime
+	}
+	return 0
+}
+
+func (m *SearchReq) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *SearchReq) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type PreviewReq struct {
+	Path                 string          `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Width                uint64          `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height               uint64          `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Mode                 PreviewReq_Mode `protobuf:"varint,4,opt,name=mode,proto3,enum=api.PreviewReq_Mode" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *PreviewReq) Reset()         { *m = PreviewReq{} }
+func (m *PreviewReq) String() string { return proto.CompactTextString(m) }
+func (*PreviewReq) ProtoMessage()    {}
+func (*PreviewReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
+}
+
+func (m *PreviewReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreviewReq.Unmarshal(m, b)
+}
+func (m *PreviewReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreviewReq.Marshal(b, m, deterministic)
+}
+func (m *PreviewReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreviewReq.Merge(m, src)
+}
+func (m *PreviewReq) XXX_Size() int {
+	return xxx_messageInfo_PreviewReq.Size(m)
+}
+func (m *PreviewReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreviewReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PreviewReq proto.InternalMessageInfo
+
+func (m *PreviewReq) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *PreviewReq) GetWidth() uint64 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *PreviewReq) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *PreviewReq) GetMode() PreviewReq_Mode {
+	if m != nil {
+		return m.Mode
+	}
+	return PreviewReq_CROP
+}
+
+type DataChunk struct {
+	Length               uint64   `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
+	Offset               uint64   `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataChunk) Reset()         { *m = DataChunk{} }
+func (m *DataChunk) String() string { return proto.CompactTextString(m) }
+func (*DataChunk) ProtoMessage()    {}
+func (*DataChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
+}
+
+func (m *DataChunk) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataChunk.Unmarshal(m, b)
 }
 func (m *DataChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
@@ -1728,7 +2314,7 @@ func (m *RevisionResponse) Reset()         { *m = RevisionResponse{} }
 func (m *RevisionResponse) String() string { return proto.CompactTextString(m) }
 func (*RevisionResponse) ProtoMessage()    {}
 func (*RevisionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
 }
 
 func (m *RevisionResponse) XXX_Unmarshal(b []byte) error {
@@ -1777,7 +2363,7 @@ func (m *Revision) Reset()         { *m = Revision{} }
 func (m *Revision) String() string { return proto.CompactTextString(m) }
 func (*Revision) ProtoMessage()    {}
 func (*Revision) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{33}
 }
 
 func (m *Revision) XXX_Unmarshal(b []byte) error {
@@ -1838,7 +2424,7 @@ func (m *RevisionReq) Reset()         { *m = RevisionReq{} }
 func (m *RevisionReq) String() string { return proto.CompactTextString(m) }
 func (*RevisionReq) ProtoMessage()    {}
 func (*RevisionReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{34}
 }
 
 func (m *RevisionReq) XXX_Unmarshal(b []byte) error {
@@ -1885,7 +2471,7 @@ func (m *RecycleEntryResponse) Reset()         { *m = RecycleEntryResponse{} }
 func (m *RecycleEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*RecycleEntryResponse) ProtoMessage()    {}
 func (*RecycleEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{35}
 }
 
 func (m *RecycleEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1926,6 +2512,7 @@ type RecycleEntry struct {
 	Size                 uint64   `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
 	DelMtime             uint64   `protobuf:"varint,4,opt,name=del_mtime,json=delMtime,proto3" json:"del_mtime,omitempty"`
 	IsDir                bool     `protobuf:"varint,5,opt,name=is_dir,json=isDir,proto3" json:"is_dir,omitempty"`
+	FileId               string   `protobuf:"bytes,6,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1935,7 +2522,7 @@ func (m *RecycleEntry) Reset()         { *m = RecycleEntry{} }
 func (m *RecycleEntry) String() string { return proto.CompactTextString(m) }
 func (*RecycleEntry) ProtoMessage()    {}
 func (*RecycleEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{33}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{36}
 }
 
 func (m *RecycleEntry) XXX_Unmarshal(b []byte) error {
@@ -1991,6 +2578,13 @@ func (m *RecycleEntry) GetIsDir() bool {
 	return false
 }
 
+func (m *RecycleEntry) GetFileId() string {
+	if m != nil {
+		return m.FileId
+	}
+	return ""
+}
+
 type RecycleEntryReq struct {
 	RestoreKey           string   `protobuf:"bytes,1,opt,name=restore_key,json=restoreKey,proto3" json:"restore_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2002,7 +2596,7 @@ func (m *RecycleEntryReq) Reset()         { *m = RecycleEntryReq{} }
 func (m *RecycleEntryReq) String() string { return proto.CompactTextString(m) }
 func (*RecycleEntryReq) ProtoMessage()    {}
 func (*RecycleEntryReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{34}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{37}
 }
 
 func (m *RecycleEntryReq) XXX_Unmarshal(b []byte) error {
@@ -2042,7 +2636,7 @@ func (m *LinkPermissions) Reset()         { *m = LinkPermissions{} }
 func (m *LinkPermissions) String() string { return proto.CompactTextString(m) }
 func (*LinkPermissions) ProtoMessage()    {}
 func (*LinkPermissions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{35}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{38}
 }
 
 func (m *LinkPermissions) XXX_Unmarshal(b []byte) error {
@@ -2083,6 +2677,8 @@ type NewLinkReq struct {
 	Password             string   `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
 	Expires              uint64   `protobuf:"varint,4,opt,name=expires,proto3" json:"expires,omitempty"`
 	DropOnly             bool     `protobuf:"varint,5,opt,name=drop_only,json=dropOnly,proto3" json:"drop_only,omitempty"`
+	NotifyUploads        bool     `protobuf:"varint,6,opt,name=notify_uploads,json=notifyUploads,proto3" json:"notify_uploads,omitempty"`
+	MaxDownloads         uint64   `protobuf:"varint,7,opt,name=max_downloads,json=maxDownloads,proto3" json:"max_downloads,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2092,7 +2688,7 @@ func (m *NewLinkReq) Reset()         { *m = NewLinkReq{} }
 func (m *NewLinkReq) String() string { return proto.CompactTextString(m) }
 func (*NewLinkReq) ProtoMessage()    {}
 func (*NewLinkReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{36}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{39}
 }
 
 func (m *NewLinkReq) XXX_Unmarshal(b []byte) error {
@@ -2148,6 +2744,20 @@ func (m *NewLinkReq) GetDropOnly() bool {
 	return false
 }
 
+func (m *NewLinkReq) GetNotifyUploads() bool {
+	if m != nil {
+		return m.NotifyUploads
+	}
+	return false
+}
+
+func (m *NewLinkReq) GetMaxDownloads() uint64 {
+	if m != nil {
+		return m.MaxDownloads
+	}
+	return 0
+}
+
 type UpdateLinkReq struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	UpdatePassword       bool     `protobuf:"varint,2,opt,name=update_password,json=updatePassword,proto3" json:"update_password,omitempty"`
@@ -2158,6 +2768,10 @@ type UpdateLinkReq struct {
 	UpdateReadOnly       bool     `protobuf:"varint,7,opt,name=update_read_only,json=updateReadOnly,proto3" json:"update_read_only,omitempty"`
 	DropOnly             bool     `protobuf:"varint,8,opt,name=drop_only,json=dropOnly,proto3" json:"drop_only,omitempty"`
 	UpdateDropOnly       bool     `protobuf:"varint,9,opt,name=update_drop_only,json=updateDropOnly,proto3" json:"update_drop_only,omitempty"`
+	NotifyUploads        bool     `protobuf:"varint,10,opt,name=notify_uploads,json=notifyUploads,proto3" json:"notify_uploads,omitempty"`
+	UpdateNotifyUploads  bool     `protobuf:"varint,11,opt,name=update_notify_uploads,json=updateNotifyUploads,proto3" json:"update_notify_uploads,omitempty"`
+	MaxDownloads         uint64   `protobuf:"varint,12,opt,name=max_downloads,json=maxDownloads,proto3" json:"max_downloads,omitempty"`
+	UpdateMaxDownloads   bool     `protobuf:"varint,13,opt,name=update_max_downloads,json=updateMaxDownloads,proto3" json:"update_max_downloads,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2167,7 +2781,7 @@ func (m *UpdateLinkReq) Reset()         { *m = UpdateLinkReq{} }
 func (m *UpdateLinkReq) String() string { return proto.CompactTextString(m) }
 func (*UpdateLinkReq) ProtoMessage()    {}
 func (*UpdateLinkReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{37}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{40}
 }
 
 func (m *UpdateLinkReq) XXX_Unmarshal(b []byte) error {
@@ -2251,6 +2865,34 @@ func (m *UpdateLinkReq) GetUpdateDropOnly() bool {
 	return false
 }
 
+func (m *UpdateLinkReq) GetNotifyUploads() bool {
+	if m != nil {
+		return m.NotifyUploads
+	}
+	return false
+}
+
+func (m *UpdateLinkReq) GetUpdateNotifyUploads() bool {
+	if m != nil {
+		return m.UpdateNotifyUploads
+	}
+	return false
+}
+
+func (m *UpdateLinkReq) GetMaxDownloads() uint64 {
+	if m != nil {
+		return m.MaxDownloads
+	}
+	return 0
+}
+
+func (m *UpdateLinkReq) GetUpdateMaxDownloads() bool {
+	if m != nil {
+		return m.UpdateMaxDownloads
+	}
+	return false
+}
+
 type PublicLinkResponse struct {
 	Status               StatusCode  `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
 	PublicLink           *PublicLink `protobuf:"bytes,2,opt,name=publicLink,proto3" json:"publicLink,omitempty"`
@@ -2263,7 +2905,7 @@ func (m *PublicLinkResponse) Reset()         { *m = PublicLinkResponse{} }
 func (m *PublicLinkResponse) String() string { return proto.CompactTextString(m) }
 func (*PublicLinkResponse) ProtoMessage()    {}
 func (*PublicLinkResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{38}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{41}
 }
 
 func (m *PublicLinkResponse) XXX_Unmarshal(b []byte) error {
@@ -2310,7 +2952,7 @@ func (m *ShareRecipient) Reset()         { *m = ShareRecipient{} }
 func (m *ShareRecipient) String() string { return proto.CompactTextString(m) }
 func (*ShareRecipient) ProtoMessage()    {}
 func (*ShareRecipient) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{39}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{42}
 }
 
 func (m *ShareRecipient) XXX_Unmarshal(b []byte) error {
@@ -2350,6 +2992,7 @@ type ACLReq struct {
 	Recipient            *ShareRecipient `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
 	ReadOnly             bool            `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
 	Shares               []*FolderShare  `protobuf:"bytes,4,rep,name=shares,proto3" json:"shares,omitempty"`
+	Permissions          uint32          `protobuf:"varint,5,opt,name=permissions,proto3" json:"permissions,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -2359,7 +3002,7 @@ func (m *ACLReq) Reset()         { *m = ACLReq{} }
 func (m *ACLReq) String() string { return proto.CompactTextString(m) }
 func (*ACLReq) ProtoMessage()    {}
 func (*ACLReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{40}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{43}
 }
 
 func (m *ACLReq) XXX_Unmarshal(b []byte) error {
@@ -2408,28 +3051,44 @@ func (m *ACLReq) GetShares() []*FolderShare {
 	return nil
 }
 
+func (m *ACLReq) GetPermissions() uint32 {
+	if m != nil {
+		return m.Permissions
+	}
+	return 0
+}
+
 type PublicLink struct {
-	Id                   string              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Token                string              `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
-	Path                 string              `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
-	Protected            bool                `protobuf:"varint,4,opt,name=protected,proto3" json:"protected,omitempty"`
-	Expires              uint64              `protobuf:"varint,5,opt,name=expires,proto3" json:"expires,omitempty"`
-	ReadOnly             bool                `protobuf:"varint,6,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
-	Mtime                uint64              `protobuf:"varint,7,opt,name=mtime,proto3" json:"mtime,omitempty"`
-	ItemType             PublicLink_ItemType `protobuf:"varint,8,opt,name=item_type,json=itemType,proto3,enum=api.PublicLink_ItemType" json:"item_type,omitempty"`
-	OwnerId              string              `protobuf:"bytes,9,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
-	Name                 string              `protobuf:"bytes,10,opt,name=name,proto3" json:"name,omitempty"`
-	DropOnly             bool                `protobuf:"varint,11,opt,name=drop_only,json=dropOnly,proto3" json:"drop_only,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	Id            string              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Token         string              `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Path          string              `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Protected     bool                `protobuf:"varint,4,opt,name=protected,proto3" json:"protected,omitempty"`
+	Expires       uint64              `protobuf:"varint,5,opt,name=expires,proto3" json:"expires,omitempty"`
+	ReadOnly      bool                `protobuf:"varint,6,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	Mtime         uint64              `protobuf:"varint,7,opt,name=mtime,proto3" json:"mtime,omitempty"`
+	ItemType      PublicLink_ItemType `protobuf:"varint,8,opt,name=item_type,json=itemType,proto3,enum=api.PublicLink_ItemType" json:"item_type,omitempty"`
+	OwnerId       string              `protobuf:"bytes,9,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Name          string              `protobuf:"bytes,10,opt,name=name,proto3" json:"name,omitempty"`
+	DropOnly      bool                `protobuf:"varint,11,opt,name=drop_only,json=dropOnly,proto3" json:"drop_only,omitempty"`
+	NotifyUploads bool                `protobuf:"varint,12,opt,name=notify_uploads,json=notifyUploads,proto3" json:"notify_uploads,omitempty"`
+	// access statistics, a view is an authentication to the link, that is
+	// done for every request to it through ocproxy
+	MaxDownloads         uint64   `protobuf:"varint,13,opt,name=max_downloads,json=maxDownloads,proto3" json:"max_downloads,omitempty"`
+	Downloads            uint64   `protobuf:"varint,14,opt,name=downloads,proto3" json:"downloads,omitempty"`
+	Views                uint64   `protobuf:"varint,15,opt,name=views,proto3" json:"views,omitempty"`
+	LastAccess           uint64   `protobuf:"varint,16,opt,name=last_access,json=lastAccess,proto3" json:"last_access,omitempty"`
+	LastAccessIpHash     string   `protobuf:"bytes,17,opt,name=last_access_ip_hash,json=lastAccessIpHash,proto3" json:"last_access_ip_hash,omitempty"`
+	Suspended            bool     `protobuf:"varint,18,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *PublicLink) Reset()         { *m = PublicLink{} }
 func (m *PublicLink) String() string { return proto.CompactTextString(m) }
 func (*PublicLink) ProtoMessage()    {}
 func (*PublicLink) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{41}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{44}
 }
 
 func (m *PublicLink) XXX_Unmarshal(b []byte) error {
@@ -2527,6 +3186,55 @@ func (m *PublicLink) GetDropOnly() bool {
 	return false
 }
 
+func (m *PublicLink) GetNotifyUploads() bool {
+	if m != nil {
+		return m.NotifyUploads
+	}
+	return false
+}
+
+func (m *PublicLink) GetMaxDownloads() uint64 {
+	if m != nil {
+		return m.MaxDownloads
+	}
+	return 0
+}
+
+func (m *PublicLink) GetDownloads() uint64 {
+	if m != nil {
+		return m.Downloads
+	}
+	return 0
+}
+
+func (m *PublicLink) GetViews() uint64 {
+	if m != nil {
+		return m.Views
+	}
+	return 0
+}
+
+func (m *PublicLink) GetLastAccess() uint64 {
+	if m != nil {
+		return m.LastAccess
+	}
+	return 0
+}
+
+func (m *PublicLink) GetLastAccessIpHash() string {
+	if m != nil {
+		return m.LastAccessIpHash
+	}
+	return ""
+}
+
+func (m *PublicLink) GetSuspended() bool {
+	if m != nil {
+		return m.Suspended
+	}
+	return false
+}
+
 type PublicLinkTokenReq struct {
 	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2538,7 +3246,7 @@ func (m *PublicLinkTokenReq) Reset()         { *m = PublicLinkTokenReq{} }
 func (m *PublicLinkTokenReq) String() string { return proto.CompactTextString(m) }
 func (*PublicLinkTokenReq) ProtoMessage()    {}
 func (*PublicLinkTokenReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{42}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{45}
 }
 
 func (m *PublicLinkTokenReq) XXX_Unmarshal(b []byte) error {
@@ -2577,7 +3285,7 @@ func (m *ShareIDReq) Reset()         { *m = ShareIDReq{} }
 func (m *ShareIDReq) String() string { return proto.CompactTextString(m) }
 func (*ShareIDReq) ProtoMessage()    {}
 func (*ShareIDReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{43}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{46}
 }
 
 func (m *ShareIDReq) XXX_Unmarshal(b []byte) error {
@@ -2617,7 +3325,7 @@ func (m *FolderShareResponse) Reset()         { *m = FolderShareResponse{} }
 func (m *FolderShareResponse) String() string { return proto.CompactTextString(m) }
 func (*FolderShareResponse) ProtoMessage()    {}
 func (*FolderShareResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{44}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{47}
 }
 
 func (m *FolderShareResponse) XXX_Unmarshal(b []byte) error {
@@ -2653,25 +3361,31 @@ func (m *FolderShareResponse) GetFolderShare() *FolderShare {
 }
 
 type FolderShare struct {
-	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Path                 string            `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
-	OwnerId              string            `protobuf:"bytes,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
-	Recipient            *ShareRecipient   `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty"`
-	ReadOnly             bool              `protobuf:"varint,5,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
-	Ctime                uint64            `protobuf:"varint,6,opt,name=ctime,proto3" json:"ctime,omitempty"`
-	Mtime                uint64            `protobuf:"varint,7,opt,name=mtime,proto3" json:"mtime,omitempty"`
-	Target               string            `protobuf:"bytes,8,opt,name=target,proto3" json:"target,omitempty"`
-	State                FolderShare_State `protobuf:"varint,9,opt,name=state,proto3,enum=api.FolderShare_State" json:"state,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Path                 string               `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	OwnerId              string               `protobuf:"bytes,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Recipient            *ShareRecipient      `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	ReadOnly             bool                 `protobuf:"varint,5,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	Ctime                uint64               `protobuf:"varint,6,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	Mtime                uint64               `protobuf:"varint,7,opt,name=mtime,proto3" json:"mtime,omitempty"`
+	Target               string               `protobuf:"bytes,8,opt,name=target,proto3" json:"target,omitempty"`
+	State                FolderShare_State    `protobuf:"varint,9,opt,name=state,proto3,enum=api.FolderShare_State" json:"state,omitempty"`
+	ItemType             FolderShare_ItemType `protobuf:"varint,10,opt,name=item_type,json=itemType,proto3,enum=api.FolderShare_ItemType" json:"item_type,omitempty"`
+	Expiration           uint64               `protobuf:"varint,11,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Permissions          uint32               `protobuf:"varint,12,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	ParentId             string               `protobuf:"bytes,13,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	InitiatorId          string               `protobuf:"bytes,14,opt,name=initiator_id,json=initiatorId,proto3" json:"initiator_id,omitempty"`
+	Suspended            bool                 `protobuf:"varint,15,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *FolderShare) Reset()         { *m = FolderShare{} }
 func (m *FolderShare) String() string { return proto.CompactTextString(m) }
 func (*FolderShare) ProtoMessage()    {}
 func (*FolderShare) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{45}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{48}
 }
 
 func (m *FolderShare) XXX_Unmarshal(b []byte) error {
@@ -2755,26 +3469,68 @@ func (m *FolderShare) GetState() FolderShare_State {
 	return FolderShare_ACCEPTED
 }
 
-type ReceivedShareResponse struct {
-	Status               StatusCode   `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
-	Share                *FolderShare `protobuf:"bytes,2,opt,name=share,proto3" json:"share,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+func (m *FolderShare) GetItemType() FolderShare_ItemType {
+	if m != nil {
+		return m.ItemType
+	}
+	return FolderShare_FOLDER
 }
 
-func (m *ReceivedShareResponse) Reset()         { *m = ReceivedShareResponse{} }
-func (m *ReceivedShareResponse) String() string { return proto.CompactTextString(m) }
-func (*ReceivedShareResponse) ProtoMessage()    {}
-func (*ReceivedShareResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{46}
+func (m *FolderShare) GetExpiration() uint64 {
+	if m != nil {
+		return m.Expiration
+	}
+	return 0
 }
 
-func (m *ReceivedShareResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReceivedShareResponse.Unmarshal(m, b)
-}
-func (m *ReceivedShareResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReceivedShareResponse.Marshal(b, m, deterministic)
+func (m *FolderShare) GetPermissions() uint32 {
+	if m != nil {
+		return m.Permissions
+	}
+	return 0
+}
+
+func (m *FolderShare) GetParentId() string {
+	if m != nil {
+		return m.ParentId
+	}
+	return ""
+}
+
+func (m *FolderShare) GetInitiatorId() string {
+	if m != nil {
+		return m.InitiatorId
+	}
+	return ""
+}
+
+func (m *FolderShare) GetSuspended() bool {
+	if m != nil {
+		return m.Suspended
+	}
+	return false
+}
+
+type ReceivedShareResponse struct {
+	Status               StatusCode   `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
+	Share                *FolderShare `protobuf:"bytes,2,opt,name=share,proto3" json:"share,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ReceivedShareResponse) Reset()         { *m = ReceivedShareResponse{} }
+func (m *ReceivedShareResponse) String() string { return proto.CompactTextString(m) }
+func (*ReceivedShareResponse) ProtoMessage()    {}
+func (*ReceivedShareResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{49}
+}
+
+func (m *ReceivedShareResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceivedShareResponse.Unmarshal(m, b)
+}
+func (m *ReceivedShareResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceivedShareResponse.Marshal(b, m, deterministic)
 }
 func (m *ReceivedShareResponse) XXX_Merge(src proto.Message) {
 	xxx_messageInfo_ReceivedShareResponse.Merge(m, src)
@@ -2806,6 +3562,8 @@ type NewFolderShareReq struct {
 	Path                 string          `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
 	Recipient            *ShareRecipient `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
 	ReadOnly             bool            `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	Expiration           uint64          `protobuf:"varint,4,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Permissions          uint32          `protobuf:"varint,5,opt,name=permissions,proto3" json:"permissions,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -2815,7 +3573,7 @@ func (m *NewFolderShareReq) Reset()         { *m = NewFolderShareReq{} }
 func (m *NewFolderShareReq) String() string { return proto.CompactTextString(m) }
 func (*NewFolderShareReq) ProtoMessage()    {}
 func (*NewFolderShareReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{47}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{50}
 }
 
 func (m *NewFolderShareReq) XXX_Unmarshal(b []byte) error {
@@ -2857,10 +3615,28 @@ func (m *NewFolderShareReq) GetReadOnly() bool {
 	return false
 }
 
+func (m *NewFolderShareReq) GetExpiration() uint64 {
+	if m != nil {
+		return m.Expiration
+	}
+	return 0
+}
+
+func (m *NewFolderShareReq) GetPermissions() uint32 {
+	if m != nil {
+		return m.Permissions
+	}
+	return 0
+}
+
 type UpdateFolderShareReq struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	UpdateReadOnly       bool     `protobuf:"varint,2,opt,name=update_read_only,json=updateReadOnly,proto3" json:"update_read_only,omitempty"`
 	ReadOnly             bool     `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	UpdateExpiration     bool     `protobuf:"varint,4,opt,name=update_expiration,json=updateExpiration,proto3" json:"update_expiration,omitempty"`
+	Expiration           uint64   `protobuf:"varint,5,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	UpdatePermissions    bool     `protobuf:"varint,6,opt,name=update_permissions,json=updatePermissions,proto3" json:"update_permissions,omitempty"`
+	Permissions          uint32   `protobuf:"varint,7,opt,name=permissions,proto3" json:"permissions,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2870,7 +3646,7 @@ func (m *UpdateFolderShareReq) Reset()         { *m = UpdateFolderShareReq{} }
 func (m *UpdateFolderShareReq) String() string { return proto.CompactTextString(m) }
 func (*UpdateFolderShareReq) ProtoMessage()    {}
 func (*UpdateFolderShareReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{48}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{51}
 }
 
 func (m *UpdateFolderShareReq) XXX_Unmarshal(b []byte) error {
@@ -2912,6 +3688,34 @@ func (m *UpdateFolderShareReq) GetReadOnly() bool {
 	return false
 }
 
+func (m *UpdateFolderShareReq) GetUpdateExpiration() bool {
+	if m != nil {
+		return m.UpdateExpiration
+	}
+	return false
+}
+
+func (m *UpdateFolderShareReq) GetExpiration() uint64 {
+	if m != nil {
+		return m.Expiration
+	}
+	return 0
+}
+
+func (m *UpdateFolderShareReq) GetUpdatePermissions() bool {
+	if m != nil {
+		return m.UpdatePermissions
+	}
+	return false
+}
+
+func (m *UpdateFolderShareReq) GetPermissions() uint32 {
+	if m != nil {
+		return m.Permissions
+	}
+	return 0
+}
+
 type UnshareFolderReq struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2923,7 +3727,7 @@ func (m *UnshareFolderReq) Reset()         { *m = UnshareFolderReq{} }
 func (m *UnshareFolderReq) String() string { return proto.CompactTextString(m) }
 func (*UnshareFolderReq) ProtoMessage()    {}
 func (*UnshareFolderReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{49}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{52}
 }
 
 func (m *UnshareFolderReq) XXX_Unmarshal(b []byte) error {
@@ -2962,7 +3766,7 @@ func (m *ListPublicLinksReq) Reset()         { *m = ListPublicLinksReq{} }
 func (m *ListPublicLinksReq) String() string { return proto.CompactTextString(m) }
 func (*ListPublicLinksReq) ProtoMessage()    {}
 func (*ListPublicLinksReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{50}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{53}
 }
 
 func (m *ListPublicLinksReq) XXX_Unmarshal(b []byte) error {
@@ -3001,7 +3805,7 @@ func (m *ListFolderSharesReq) Reset()         { *m = ListFolderSharesReq{} }
 func (m *ListFolderSharesReq) String() string { return proto.CompactTextString(m) }
 func (*ListFolderSharesReq) ProtoMessage()    {}
 func (*ListFolderSharesReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{51}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{54}
 }
 
 func (m *ListFolderSharesReq) XXX_Unmarshal(b []byte) error {
@@ -3029,530 +3833,4291 @@ func (m *ListFolderSharesReq) GetPath() string {
 	return ""
 }
 
-type ReceivedShareReq struct {
-	ShareId              string   `protobuf:"bytes,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+type FsckSharesReq struct {
+	Repair               bool     `protobuf:"varint,1,opt,name=repair,proto3" json:"repair,omitempty"`
+	PurgeSuspended       bool     `protobuf:"varint,2,opt,name=purge_suspended,json=purgeSuspended,proto3" json:"purge_suspended,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReceivedShareReq) Reset()         { *m = ReceivedShareReq{} }
-func (m *ReceivedShareReq) String() string { return proto.CompactTextString(m) }
-func (*ReceivedShareReq) ProtoMessage()    {}
-func (*ReceivedShareReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{52}
+func (m *FsckSharesReq) Reset()         { *m = FsckSharesReq{} }
+func (m *FsckSharesReq) String() string { return proto.CompactTextString(m) }
+func (*FsckSharesReq) ProtoMessage()    {}
+func (*FsckSharesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{55}
 }
 
-func (m *ReceivedShareReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReceivedShareReq.Unmarshal(m, b)
+func (m *FsckSharesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FsckSharesReq.Unmarshal(m, b)
 }
-func (m *ReceivedShareReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReceivedShareReq.Marshal(b, m, deterministic)
+func (m *FsckSharesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FsckSharesReq.Marshal(b, m, deterministic)
 }
-func (m *ReceivedShareReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReceivedShareReq.Merge(m, src)
+func (m *FsckSharesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FsckSharesReq.Merge(m, src)
 }
-func (m *ReceivedShareReq) XXX_Size() int {
-	return xxx_messageInfo_ReceivedShareReq.Size(m)
+func (m *FsckSharesReq) XXX_Size() int {
+	return xxx_messageInfo_FsckSharesReq.Size(m)
 }
-func (m *ReceivedShareReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReceivedShareReq.DiscardUnknown(m)
+func (m *FsckSharesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_FsckSharesReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReceivedShareReq proto.InternalMessageInfo
+var xxx_messageInfo_FsckSharesReq proto.InternalMessageInfo
 
-func (m *ReceivedShareReq) GetShareId() string {
+func (m *FsckSharesReq) GetRepair() bool {
 	if m != nil {
-		return m.ShareId
+		return m.Repair
 	}
-	return ""
+	return false
 }
 
-func init() {
-	proto.RegisterEnum("api.StatusCode", StatusCode_name, StatusCode_value)
-	proto.RegisterEnum("api.Tag_ItemType", Tag_ItemType_name, Tag_ItemType_value)
-	proto.RegisterEnum("api.ShareRecipient_RecipientType", ShareRecipient_RecipientType_name, ShareRecipient_RecipientType_value)
-	proto.RegisterEnum("api.PublicLink_ItemType", PublicLink_ItemType_name, PublicLink_ItemType_value)
-	proto.RegisterEnum("api.FolderShare_State", FolderShare_State_name, FolderShare_State_value)
-	proto.RegisterType((*TagReq)(nil), "api.TagReq")
-	proto.RegisterType((*Tag)(nil), "api.Tag")
-	proto.RegisterType((*TagResponse)(nil), "api.TagResponse")
-	proto.RegisterType((*IsPublicLinkProtectedResponse)(nil), "api.IsPublicLinkProtectedResponse")
-	proto.RegisterType((*ForgePublicLinkTokenReq)(nil), "api.ForgePublicLinkTokenReq")
-	proto.RegisterType((*ForgePublicLinkTokenResponse)(nil), "api.ForgePublicLinkTokenResponse")
-	proto.RegisterType((*VerifyPublicLinkTokenReq)(nil), "api.VerifyPublicLinkTokenReq")
-	proto.RegisterType((*VerifyPublicLinkTokenResponse)(nil), "api.VerifyPublicLinkTokenResponse")
-	proto.RegisterType((*EmptyResponse)(nil), "api.EmptyResponse")
-	proto.RegisterType((*EmptyReq)(nil), "api.EmptyReq")
-	proto.RegisterType((*QuotaReq)(nil), "api.QuotaReq")
-	proto.RegisterType((*QuotaResponse)(nil), "api.QuotaResponse")
-	proto.RegisterType((*UserResponse)(nil), "api.UserResponse")
-	proto.RegisterType((*User)(nil), "api.User")
-	proto.RegisterType((*TxInfoResponse)(nil), "api.TxInfoResponse")
-	proto.RegisterType((*TxInfo)(nil), "api.TxInfo")
-	proto.RegisterType((*ForgeUserTokenReq)(nil), "api.ForgeUserTokenReq")
-	proto.RegisterType((*TokenResponse)(nil), "api.TokenResponse")
-	proto.RegisterType((*TokenReq)(nil), "api.TokenReq")
-	proto.RegisterType((*MetadataResponse)(nil), "api.MetadataResponse")
-	proto.RegisterType((*Metadata)(nil), "api.Metadata")
-	proto.RegisterType((*PathReq)(nil), "api.PathReq")
-	proto.RegisterType((*MoveReq)(nil), "api.MoveReq")
-	proto.RegisterType((*TxChunk)(nil), "api.TxChunk")
-	proto.RegisterType((*WriteSummaryResponse)(nil), "api.WriteSummaryResponse")
-	proto.RegisterType((*WriteSummary)(nil), "api.WriteSummary")
-	proto.RegisterType((*TxEnd)(nil), "api.TxEnd")
-	proto.RegisterType((*DataChunkResponse)(nil), "api.DataChunkResponse")
-	proto.RegisterType((*DataChunk)(nil), "api.DataChunk")
-	proto.RegisterType((*RevisionResponse)(nil), "api.RevisionResponse")
-	proto.RegisterType((*Revision)(nil), "api.Revision")
-	proto.RegisterType((*RevisionReq)(nil), "api.RevisionReq")
-	proto.RegisterType((*RecycleEntryResponse)(nil), "api.RecycleEntryResponse")
-	proto.RegisterType((*RecycleEntry)(nil), "api.RecycleEntry")
-	proto.RegisterType((*RecycleEntryReq)(nil), "api.RecycleEntryReq")
-	proto.RegisterType((*LinkPermissions)(nil), "api.LinkPermissions")
-	proto.RegisterType((*NewLinkReq)(nil), "api.NewLinkReq")
-	proto.RegisterType((*UpdateLinkReq)(nil), "api.UpdateLinkReq")
-	proto.RegisterType((*PublicLinkResponse)(nil), "api.PublicLinkResponse")
-	proto.RegisterType((*ShareRecipient)(nil), "api.ShareRecipient")
-	proto.RegisterType((*ACLReq)(nil), "api.ACLReq")
-	proto.RegisterType((*PublicLink)(nil), "api.PublicLink")
-	proto.RegisterType((*PublicLinkTokenReq)(nil), "api.PublicLinkTokenReq")
-	proto.RegisterType((*ShareIDReq)(nil), "api.ShareIDReq")
-	proto.RegisterType((*FolderShareResponse)(nil), "api.FolderShareResponse")
-	proto.RegisterType((*FolderShare)(nil), "api.FolderShare")
-	proto.RegisterType((*ReceivedShareResponse)(nil), "api.ReceivedShareResponse")
-	proto.RegisterType((*NewFolderShareReq)(nil), "api.NewFolderShareReq")
-	proto.RegisterType((*UpdateFolderShareReq)(nil), "api.UpdateFolderShareReq")
-	proto.RegisterType((*UnshareFolderReq)(nil), "api.UnshareFolderReq")
-	proto.RegisterType((*ListPublicLinksReq)(nil), "api.ListPublicLinksReq")
-	proto.RegisterType((*ListFolderSharesReq)(nil), "api.ListFolderSharesReq")
-	proto.RegisterType((*ReceivedShareReq)(nil), "api.ReceivedShareReq")
+func (m *FsckSharesReq) GetPurgeSuspended() bool {
+	if m != nil {
+		return m.PurgeSuspended
+	}
+	return false
 }
 
-func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
+// FsckEntry is a share or a public link out of step with its file.
+type FsckEntry struct {
+	Kind                 FsckEntry_Kind    `protobuf:"varint,1,opt,name=kind,proto3,enum=api.FsckEntry_Kind" json:"kind,omitempty"`
+	Id                   string            `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId              string            `protobuf:"bytes,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	FileId               string            `protobuf:"bytes,4,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Problem              FsckEntry_Problem `protobuf:"varint,5,opt,name=problem,proto3,enum=api.FsckEntry_Problem" json:"problem,omitempty"`
+	Repaired             bool              `protobuf:"varint,6,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
 
-var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 2744 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x3a, 0x5b, 0x73, 0xdb, 0xc6,
-	0xd5, 0xe6, 0x1d, 0x3c, 0xbc, 0x18, 0x5a, 0xcb, 0x0e, 0x25, 0xdb, 0x89, 0x83, 0x7c, 0xf3, 0xc5,
-	0x49, 0x1c, 0xc5, 0x61, 0xe3, 0x69, 0x92, 0xb6, 0xc9, 0x30, 0x24, 0xa5, 0x30, 0x96, 0x48, 0x06,
-	0x24, 0x9d, 0xf4, 0xa1, 0x45, 0x61, 0x62, 0x45, 0x6d, 0x45, 0x02, 0x34, 0xb0, 0xd4, 0x25, 0xbf,
-	0xa2, 0x93, 0xce, 0xf4, 0xa1, 0x7f, 0xa6, 0xfd, 0x15, 0x9d, 0xce, 0xf4, 0x3f, 0xf4, 0xb5, 0x33,
-	0x7d, 0xea, 0xec, 0x05, 0xc4, 0x82, 0xb7, 0x88, 0xee, 0x4c, 0x9f, 0x84, 0x3d, 0x7b, 0xf6, 0xdc,
-	0xf6, 0x5c, 0x97, 0x82, 0xbc, 0x3d, 0x25, 0x07, 0x53, 0xdf, 0xa3, 0x1e, 0x4a, 0xd9, 0x53, 0x62,
-	0xb4, 0x21, 0xdb, 0xb7, 0x47, 0x26, 0x7e, 0x85, 0xde, 0x80, 0x1c, 0xb5, 0x47, 0xd6, 0x39, 0xbe,
-	0xae, 0x24, 0x1e, 0x25, 0x1e, 0xe7, 0xcd, 0x2c, 0xb5, 0x47, 0xcf, 0xf1, 0x75, 0xb8, 0x71, 0x61,
-	0x8f, 0x2b, 0xc9, 0xf9, 0xc6, 0x0b, 0x7b, 0x8c, 0x10, 0xa4, 0xa7, 0x36, 0x3d, 0xab, 0xa4, 0x38,
-	0x94, 0x7f, 0x1b, 0xff, 0x4c, 0x40, 0xaa, 0x6f, 0x8f, 0x50, 0x19, 0x92, 0xc4, 0xe1, 0x84, 0x52,
-	0x66, 0x92, 0x38, 0xe8, 0x00, 0xf2, 0x84, 0xe2, 0x89, 0x45, 0xaf, 0xa7, 0x98, 0x93, 0x29, 0x57,
-	0x77, 0x0e, 0x98, 0x2c, 0x7d, 0x7b, 0x74, 0xd0, 0xa2, 0x78, 0xd2, 0xbf, 0x9e, 0x62, 0x53, 0x23,
-	0xf2, 0x0b, 0xe9, 0x90, 0x9a, 0x11, 0x47, 0x92, 0x66, 0x9f, 0xe8, 0xff, 0xa0, 0x7c, 0x4a, 0xc6,
-	0xd8, 0x22, 0x8e, 0x35, 0xf5, 0xf1, 0x29, 0xb9, 0xaa, 0xa4, 0xf9, 0x66, 0x91, 0x41, 0x5b, 0x4e,
-	0x97, 0xc3, 0x98, 0xb0, 0x12, 0xab, 0x92, 0x11, 0xc2, 0x8a, 0x6d, 0x55, 0xbd, 0x6c, 0x4c, 0xbd,
-	0xfb, 0x90, 0x97, 0xea, 0xcd, 0x70, 0x25, 0xc7, 0xb7, 0x34, 0xa1, 0xe0, 0x0c, 0x1b, 0x8f, 0x40,
-	0x0b, 0x85, 0x43, 0x00, 0xd9, 0xc3, 0xce, 0x71, 0xa3, 0x69, 0xea, 0xb7, 0x90, 0x06, 0xe9, 0xc3,
-	0xd6, 0x71, 0x53, 0x4f, 0x18, 0x26, 0x14, 0xb8, 0x01, 0x83, 0xa9, 0xe7, 0x06, 0x18, 0xbd, 0x0b,
-	0xd9, 0x80, 0xda, 0x74, 0x16, 0x70, 0xdd, 0xcb, 0xd5, 0xdb, 0x5c, 0xc9, 0x1e, 0x07, 0xd5, 0x3d,
-	0x07, 0x9b, 0x72, 0x1b, 0xed, 0x43, 0x8a, 0xda, 0x23, 0x6e, 0x8a, 0x42, 0x55, 0x0b, 0x4d, 0x61,
-	0x32, 0xa0, 0x71, 0x0a, 0x0f, 0x5b, 0x41, 0x77, 0xf6, 0x72, 0x4c, 0x86, 0xc7, 0xc4, 0x3d, 0xef,
-	0xfa, 0x1e, 0xc5, 0x43, 0x8a, 0x9d, 0xed, 0xb9, 0x3c, 0x80, 0xfc, 0x34, 0x3c, 0xcd, 0x79, 0x69,
-	0x66, 0x04, 0x30, 0x9e, 0xc3, 0x1b, 0x87, 0x9e, 0x3f, 0xc2, 0x11, 0xab, 0xbe, 0x77, 0x8e, 0x5d,
-	0xe6, 0x0d, 0xbb, 0x90, 0xa1, 0xec, 0x5b, 0xfa, 0x82, 0x58, 0xa0, 0x7d, 0xd0, 0xa6, 0x76, 0x10,
-	0x5c, 0x7a, 0xbe, 0x23, 0x7d, 0x61, 0xbe, 0x36, 0x7e, 0x03, 0x0f, 0x56, 0x13, 0xdb, 0x56, 0xe6,
-	0x5d, 0xc8, 0x5c, 0xd8, 0x63, 0x12, 0xca, 0x2b, 0x16, 0xc6, 0x53, 0xa8, 0xbc, 0xc0, 0x3e, 0x39,
-	0xbd, 0xbe, 0xa9, 0xb0, 0xc6, 0x0f, 0xf0, 0x70, 0xcd, 0x89, 0x6d, 0x25, 0x7a, 0x0a, 0x85, 0x29,
-	0xa7, 0x61, 0x8d, 0x89, 0x7b, 0x2e, 0xef, 0x4c, 0x60, 0x47, 0xb4, 0x4d, 0x98, 0xce, 0xbf, 0x8d,
-	0x4f, 0xa1, 0xd4, 0x9c, 0x4c, 0xe9, 0xf5, 0xd6, 0xbc, 0x0c, 0x00, 0x4d, 0x9e, 0x7c, 0x65, 0xbc,
-	0x09, 0xda, 0xb7, 0x33, 0x8f, 0xda, 0x4c, 0xc7, 0x30, 0xd8, 0x12, 0x4a, 0xb0, 0x5d, 0x41, 0x49,
-	0xee, 0x6f, 0xab, 0xd1, 0x5b, 0x50, 0xa0, 0x1e, 0xb5, 0xc7, 0xd6, 0xcb, 0x6b, 0x8a, 0x03, 0xae,
-	0x51, 0xca, 0x04, 0x0e, 0xfa, 0x8a, 0x41, 0xd0, 0x43, 0x80, 0x59, 0x80, 0x1d, 0xb9, 0x9f, 0xe2,
-	0xfb, 0x79, 0x06, 0xe1, 0xdb, 0xc6, 0x0b, 0x28, 0x0e, 0x02, 0xec, 0x6f, 0xcf, 0xf8, 0x21, 0xa4,
-	0x67, 0x01, 0xf6, 0xa5, 0x0d, 0xf3, 0x1c, 0x8d, 0x53, 0xe2, 0x60, 0xe3, 0x77, 0x90, 0x66, 0x2b,
-	0xc6, 0xde, 0x1e, 0x0e, 0xbd, 0x99, 0x4b, 0x2d, 0x99, 0x46, 0xf2, 0x66, 0x5e, 0x42, 0x5a, 0x0e,
-	0xba, 0x07, 0xd9, 0x91, 0xef, 0xcd, 0xa6, 0x4c, 0xf2, 0x14, 0x8b, 0x65, 0xb1, 0x42, 0x6f, 0x43,
-	0xd1, 0x21, 0xc1, 0x74, 0x6c, 0x5f, 0x5b, 0xae, 0x3d, 0xc1, 0x32, 0x7d, 0x14, 0x24, 0xac, 0x6d,
-	0x4f, 0xb0, 0xf1, 0x5b, 0x28, 0xf7, 0xaf, 0x5a, 0xee, 0xa9, 0xb7, 0xbd, 0xec, 0xef, 0x40, 0x96,
-	0xf2, 0xa3, 0x52, 0xfa, 0x82, 0x88, 0x5a, 0x41, 0x4d, 0x6e, 0x19, 0x0f, 0x21, 0x2b, 0x20, 0xe8,
-	0x0e, 0x64, 0xe8, 0x55, 0x24, 0x7e, 0x9a, 0x5e, 0xb5, 0x1c, 0x63, 0x00, 0x3b, 0x3c, 0x4a, 0x98,
-	0x96, 0x73, 0xff, 0xbd, 0x0f, 0xf9, 0xe1, 0x98, 0x60, 0x55, 0x59, 0x4d, 0x00, 0x5a, 0x0e, 0x7a,
-	0x07, 0x4a, 0x72, 0x33, 0xc0, 0x43, 0x1f, 0x53, 0x19, 0x78, 0x45, 0x01, 0xec, 0x71, 0x98, 0xd1,
-	0x86, 0xd2, 0xeb, 0x47, 0x9b, 0x88, 0x9d, 0xa4, 0x1a, 0x3b, 0x8f, 0x40, 0xfb, 0x89, 0xe8, 0x3a,
-	0x05, 0xfd, 0x04, 0x53, 0xdb, 0xb1, 0x5f, 0xc7, 0xfd, 0xde, 0x03, 0x6d, 0x22, 0x0f, 0x4b, 0x5b,
-	0x96, 0x38, 0xea, 0x9c, 0xe2, 0x7c, 0xdb, 0xf8, 0x47, 0x0a, 0xb4, 0x10, 0xac, 0x54, 0x95, 0x3c,
-	0xaf, 0x2a, 0x61, 0x50, 0x24, 0xa3, 0xa0, 0x60, 0xb0, 0x80, 0xfc, 0x20, 0xee, 0x3e, 0x6d, 0xf2,
-	0x6f, 0xa6, 0xc2, 0x84, 0x92, 0x09, 0xe6, 0x25, 0x23, 0x6d, 0x8a, 0x05, 0xba, 0x0b, 0x59, 0x12,
-	0x58, 0x0e, 0xf1, 0x79, 0xa9, 0xd0, 0xcc, 0x0c, 0x09, 0x1a, 0xc4, 0x67, 0x04, 0x30, 0x4b, 0xcd,
-	0xa2, 0x4c, 0xf0, 0x6f, 0x96, 0xf8, 0x86, 0x67, 0x78, 0x78, 0x1e, 0xcc, 0x26, 0x61, 0x8d, 0x08,
-	0xd7, 0xcc, 0x57, 0x1d, 0xec, 0xe3, 0x53, 0x8b, 0x8b, 0xa2, 0x09, 0x5f, 0xe5, 0x90, 0x2e, 0x93,
-	0xe7, 0x11, 0x14, 0x49, 0x60, 0xf9, 0xd8, 0x76, 0x2c, 0xcf, 0x1d, 0x5f, 0x57, 0xf2, 0x9c, 0x17,
-	0x90, 0xc0, 0xc4, 0xb6, 0xd3, 0x71, 0xc7, 0xd7, 0xcc, 0x6b, 0x49, 0x60, 0x05, 0x67, 0xb6, 0x8f,
-	0xed, 0x97, 0x63, 0x5c, 0x01, 0x8e, 0x51, 0x20, 0x41, 0x2f, 0x04, 0x31, 0x99, 0x26, 0x4c, 0xfe,
-	0x82, 0x90, 0x89, 0x7d, 0xb3, 0x12, 0x19, 0x5c, 0x07, 0x95, 0xe2, 0xa3, 0xc4, 0xe3, 0xa2, 0xc9,
-	0x3e, 0x99, 0x24, 0xd4, 0xc7, 0xd8, 0xe2, 0x61, 0x52, 0x29, 0x71, 0x5d, 0xf3, 0x0c, 0x52, 0x67,
-	0x00, 0xb4, 0x07, 0x1a, 0xf6, 0x02, 0x8b, 0x15, 0xc4, 0x4a, 0x99, 0x13, 0xca, 0x61, 0x2f, 0x38,
-	0x24, 0x63, 0xcc, 0x44, 0x60, 0x5b, 0xc4, 0x0d, 0xa8, 0xed, 0x0e, 0x71, 0xe5, 0xb6, 0x08, 0x1c,
-	0xec, 0x05, 0x2d, 0x09, 0x62, 0x28, 0x5c, 0x44, 0x8b, 0xda, 0xfe, 0x08, 0xd3, 0x8a, 0x2e, 0x50,
-	0x38, 0xac, 0xcf, 0x41, 0xcc, 0xa0, 0x13, 0x32, 0x62, 0x4e, 0xbc, 0x23, 0x5c, 0x65, 0x42, 0x46,
-	0x2d, 0x87, 0xf1, 0x65, 0x60, 0x6e, 0x1e, 0x24, 0xf8, 0x4e, 0xc8, 0x88, 0x19, 0xc7, 0x78, 0x08,
-	0x39, 0xf6, 0x77, 0x5d, 0x82, 0xfb, 0x12, 0x72, 0x27, 0xde, 0x05, 0x66, 0xdb, 0x7b, 0xa0, 0x79,
-	0x63, 0xc7, 0x52, 0x50, 0x72, 0xde, 0xd8, 0xe1, 0x16, 0xde, 0x03, 0xcd, 0xc5, 0x97, 0x96, 0xe2,
-	0x09, 0x39, 0x17, 0x5f, 0x72, 0xfa, 0x2f, 0x21, 0xd7, 0xbf, 0xaa, 0x9f, 0xcd, 0xdc, 0xf3, 0x95,
-	0xe1, 0xc8, 0x12, 0xc9, 0x18, 0xbb, 0x23, 0x79, 0x30, 0x6d, 0xca, 0x15, 0x83, 0x7b, 0xa7, 0xa7,
-	0x01, 0xa6, 0xd2, 0x8d, 0xe4, 0x8a, 0x09, 0xc9, 0x9d, 0x36, 0xcd, 0x8d, 0xce, 0xbf, 0x8d, 0x0b,
-	0xd8, 0xfd, 0xce, 0x27, 0x14, 0xf7, 0x66, 0x93, 0x89, 0xed, 0x6f, 0x9f, 0xf2, 0xd1, 0x33, 0x28,
-	0x5e, 0x2a, 0x04, 0x64, 0x44, 0x88, 0xf6, 0x28, 0x46, 0x39, 0x86, 0x66, 0x1c, 0x41, 0x51, 0xdd,
-	0x45, 0x15, 0xc8, 0xb9, 0x43, 0xa6, 0xaa, 0x60, 0x98, 0x36, 0xc3, 0x25, 0xf7, 0x0b, 0x9e, 0xed,
-	0x79, 0x60, 0x24, 0xa5, 0x5f, 0x30, 0x48, 0x8f, 0xfc, 0x80, 0x8d, 0x63, 0xc8, 0xf4, 0xaf, 0x9a,
-	0xae, 0xb3, 0xda, 0x44, 0xab, 0x62, 0x4c, 0x0d, 0x87, 0x54, 0x3c, 0x1c, 0x8c, 0xdf, 0xc3, 0x4e,
-	0xc3, 0xa6, 0x36, 0x37, 0xfa, 0xf6, 0xb6, 0x78, 0x02, 0x79, 0x27, 0x3c, 0x2d, 0x0d, 0x51, 0xe6,
-	0xb8, 0x11, 0xcd, 0x08, 0xc1, 0xe8, 0x40, 0x7e, 0x0e, 0x57, 0xee, 0x32, 0xb1, 0xe6, 0x2e, 0x93,
-	0x2b, 0xef, 0x32, 0xa5, 0xdc, 0xe5, 0x29, 0xe8, 0x26, 0xbe, 0x20, 0x01, 0xf1, 0xdc, 0xd7, 0xca,
-	0x6a, 0xbe, 0x3c, 0x1c, 0xcb, 0x6a, 0x73, 0x8a, 0xf3, 0x6d, 0xc3, 0x01, 0x2d, 0x84, 0xb2, 0xce,
-	0xd4, 0xc7, 0x17, 0x6a, 0xe3, 0xed, 0xe3, 0x0b, 0xd6, 0x99, 0x86, 0x99, 0x2c, 0xb9, 0x2a, 0x93,
-	0xa5, 0x56, 0x67, 0xb2, 0xb4, 0x92, 0xc9, 0x8c, 0xcf, 0xa1, 0x10, 0x69, 0xb3, 0x32, 0xc2, 0x54,
-	0xe6, 0x49, 0x95, 0x39, 0xf3, 0x6a, 0x13, 0x0f, 0xaf, 0x87, 0x63, 0xdc, 0x74, 0xe9, 0x6b, 0x7a,
-	0xb5, 0xaf, 0x10, 0x88, 0x79, 0x75, 0x8c, 0x72, 0x0c, 0xcd, 0xf8, 0x73, 0x02, 0x8a, 0xea, 0x36,
-	0xcb, 0x3b, 0x3e, 0x0e, 0xa8, 0xe7, 0x63, 0x35, 0xf8, 0x0b, 0x12, 0xc6, 0x13, 0xc0, 0x5b, 0x10,
-	0x2e, 0x15, 0x45, 0x40, 0x82, 0x54, 0x4b, 0xaa, 0x35, 0xe1, 0x3e, 0xe4, 0x1d, 0x3c, 0xb6, 0xd4,
-	0xba, 0xa0, 0x39, 0x78, 0x7c, 0xb2, 0xa1, 0x34, 0x18, 0x55, 0xb8, 0x1d, 0x37, 0xca, 0xab, 0x45,
-	0xde, 0x89, 0x45, 0xde, 0xc6, 0x2f, 0xe0, 0x36, 0x6f, 0xe2, 0xb1, 0x3f, 0x21, 0x01, 0xbb, 0x8a,
-	0x80, 0x89, 0xc3, 0xea, 0x01, 0x47, 0xd6, 0x4c, 0xfe, 0xcd, 0x2e, 0x96, 0x47, 0x77, 0xd8, 0xf5,
-	0xf2, 0x85, 0xf1, 0x87, 0x04, 0x40, 0x1b, 0x5f, 0xf2, 0xfe, 0x72, 0xcd, 0x0d, 0xde, 0x87, 0x7c,
-	0x54, 0x5c, 0xc4, 0x61, 0xcd, 0x0f, 0x4b, 0x8b, 0xda, 0xb0, 0xa7, 0xe2, 0x0d, 0x3b, 0xcb, 0x17,
-	0xf8, 0x6a, 0x4a, 0x7c, 0x1c, 0x48, 0xf5, 0xc3, 0x25, 0x37, 0x8d, 0xef, 0x4d, 0x05, 0x49, 0x61,
-	0x00, 0x8d, 0x01, 0x18, 0x49, 0xe3, 0x2f, 0x49, 0x28, 0x0d, 0xa6, 0x8e, 0x4d, 0x71, 0x28, 0xd5,
-	0x62, 0x55, 0x7e, 0x17, 0x6e, 0xcf, 0x38, 0x82, 0x15, 0x1b, 0x16, 0x34, 0xb3, 0x2c, 0xc0, 0xdd,
-	0x50, 0x82, 0x4d, 0xd2, 0x7d, 0x00, 0x3b, 0x92, 0x08, 0x97, 0xca, 0xa6, 0x2c, 0xaa, 0x84, 0x77,
-	0xeb, 0x62, 0xa3, 0x39, 0x87, 0xa3, 0x37, 0x01, 0x14, 0xac, 0x0c, 0xd7, 0x46, 0x81, 0xc4, 0x6d,
-	0x94, 0x5d, 0xb0, 0xd1, 0x63, 0x90, 0x04, 0x95, 0x22, 0x9d, 0x53, 0xe5, 0x9d, 0x17, 0xea, 0x98,
-	0x5d, 0xb4, 0xb8, 0x5d, 0x14, 0x32, 0x11, 0x4e, 0x5e, 0x25, 0xd3, 0x08, 0x2d, 0xe8, 0x02, 0x52,
-	0xc6, 0x86, 0xad, 0x03, 0xeb, 0x23, 0x50, 0x26, 0x8d, 0x9b, 0x0c, 0x23, 0x7f, 0x4c, 0x40, 0x99,
-	0xb7, 0x12, 0x26, 0x1e, 0x92, 0x29, 0xeb, 0x1a, 0x99, 0xe5, 0x89, 0x83, 0x5d, 0x4a, 0x68, 0xe8,
-	0xb2, 0xf3, 0x35, 0x7a, 0x06, 0x69, 0x65, 0x4a, 0x7f, 0x5b, 0x88, 0x11, 0x3b, 0x7e, 0x30, 0xff,
-	0xe2, 0x53, 0x3b, 0x47, 0x37, 0x0e, 0xa0, 0x14, 0x03, 0xb3, 0x19, 0x79, 0xd0, 0xe3, 0xd3, 0x72,
-	0x1e, 0x32, 0x47, 0x66, 0x67, 0xd0, 0xd5, 0x13, 0x1c, 0xd8, 0x6e, 0x7d, 0xaf, 0x27, 0x8d, 0x3f,
-	0x25, 0x20, 0x5b, 0xab, 0x1f, 0xaf, 0x73, 0xeb, 0x8f, 0xd9, 0x95, 0x49, 0x72, 0x52, 0xc9, 0x3b,
-	0x2b, 0x44, 0x31, 0x23, 0xac, 0xf8, 0x2d, 0xa7, 0x96, 0x6e, 0x39, 0xcb, 0x5b, 0x15, 0xe6, 0xec,
-	0xa9, 0xc7, 0x85, 0xaa, 0xce, 0x89, 0x1d, 0x7a, 0x63, 0x07, 0xfb, 0x82, 0xa4, 0xdc, 0x37, 0xfe,
-	0x96, 0x04, 0x88, 0x2c, 0xb9, 0xe4, 0xdd, 0x2b, 0x1b, 0xe6, 0x55, 0x6f, 0x21, 0xf1, 0xe1, 0x3b,
-	0xbd, 0x30, 0x7c, 0xab, 0xe1, 0x97, 0x59, 0x0a, 0xbf, 0xf5, 0xde, 0x3a, 0x2f, 0x00, 0x39, 0xb5,
-	0x00, 0x3c, 0x53, 0x9f, 0x57, 0x34, 0x7e, 0x71, 0x95, 0x05, 0x97, 0x58, 0xf5, 0xca, 0xc2, 0x9a,
-	0xaa, 0x4b, 0x17, 0xfb, 0xac, 0xe6, 0xe7, 0x65, 0x53, 0xc5, 0xd6, 0xa2, 0xec, 0xf3, 0x11, 0x0a,
-	0x84, 0x42, 0xec, 0x3b, 0xee, 0xff, 0x85, 0x85, 0xbc, 0xa0, 0x3e, 0x95, 0x84, 0xcf, 0x23, 0xb7,
-	0x94, 0x47, 0x93, 0x84, 0xf1, 0xbe, 0xea, 0xf7, 0x3f, 0x31, 0x5e, 0x3c, 0x00, 0xe0, 0xb7, 0xd2,
-	0x6a, 0xac, 0xc8, 0x30, 0x86, 0x0f, 0x77, 0xd4, 0x9b, 0xdb, 0x3a, 0x84, 0xaa, 0x50, 0x38, 0x8d,
-	0xce, 0x4b, 0xf7, 0x5a, 0xf6, 0x08, 0x15, 0xc9, 0xf8, 0x6b, 0x12, 0x0a, 0xca, 0xe6, 0x8d, 0x66,
-	0x11, 0xd5, 0xbe, 0xa9, 0xb8, 0x7d, 0x63, 0xfe, 0x9d, 0xde, 0xde, 0xbf, 0x33, 0xcb, 0x7e, 0x31,
-	0xe4, 0x7e, 0x91, 0x15, 0x7e, 0xc1, 0x17, 0x6b, 0xbc, 0xe5, 0x1e, 0x64, 0x65, 0x13, 0xaf, 0x85,
-	0x4f, 0x61, 0xbc, 0x7f, 0x7f, 0x02, 0x19, 0x66, 0x20, 0xcc, 0x7d, 0xa1, 0x5c, 0xbd, 0xb7, 0x68,
-	0x10, 0x6e, 0x4a, 0x6c, 0x0a, 0x24, 0xe3, 0x29, 0x64, 0xf8, 0x1a, 0x15, 0x41, 0xab, 0xd5, 0xeb,
-	0xcd, 0x6e, 0xbf, 0xd9, 0xd0, 0x6f, 0xa1, 0x02, 0xe4, 0xba, 0xcd, 0x76, 0xa3, 0xd5, 0x3e, 0xd2,
-	0x13, 0x6c, 0xcb, 0x6c, 0x7e, 0xd3, 0xac, 0xb3, 0xad, 0xa4, 0x71, 0x06, 0x77, 0x4d, 0x3c, 0xc4,
-	0xe4, 0x02, 0x3b, 0xaf, 0x79, 0x71, 0xff, 0x0f, 0x99, 0x60, 0xe3, 0x95, 0x89, 0x6d, 0xe3, 0x12,
-	0x76, 0xda, 0xf8, 0x32, 0xe6, 0x23, 0xff, 0x93, 0x34, 0x63, 0x4c, 0x60, 0x57, 0x14, 0xc7, 0x05,
-	0xde, 0x8b, 0xde, 0xb2, 0xaa, 0xe8, 0x24, 0xd7, 0x15, 0x9d, 0xf5, 0xec, 0x0c, 0xd0, 0x07, 0x2e,
-	0x57, 0x59, 0xf0, 0x5b, 0x15, 0x2c, 0x8f, 0x01, 0x1d, 0x93, 0x80, 0x46, 0xa1, 0x17, 0xac, 0x1b,
-	0xb7, 0xde, 0x83, 0x3b, 0x0c, 0x53, 0x11, 0x7d, 0x2d, 0xea, 0x87, 0xac, 0x51, 0x8e, 0x5d, 0x25,
-	0x1f, 0xd1, 0xc4, 0x84, 0x38, 0x67, 0x9f, 0xe3, 0xeb, 0x96, 0xf3, 0xfe, 0xdf, 0x93, 0x00, 0xd1,
-	0x75, 0xa2, 0x2c, 0x24, 0x3b, 0xcf, 0x85, 0xaf, 0x0c, 0xda, 0xcf, 0xdb, 0x9d, 0xef, 0xda, 0x7a,
-	0x02, 0xdd, 0x85, 0x9d, 0x5e, 0xbf, 0x63, 0xd6, 0x8e, 0x9a, 0x56, 0xbb, 0xd3, 0xb7, 0x0e, 0x3b,
-	0x83, 0x76, 0x43, 0x4f, 0xa2, 0x7d, 0xb8, 0x17, 0x82, 0x6b, 0xc7, 0x66, 0xb3, 0xd6, 0xf8, 0xb5,
-	0xd5, 0xfc, 0xbe, 0xd5, 0xeb, 0xf7, 0xf4, 0x14, 0x7a, 0x00, 0x95, 0x70, 0xaf, 0xdb, 0x34, 0x4f,
-	0x5a, 0xbd, 0x5e, 0xab, 0xd3, 0x6e, 0x34, 0xdb, 0xad, 0x66, 0x43, 0x4f, 0xa3, 0x3d, 0xb8, 0x5b,
-	0xef, 0xb4, 0xfb, 0xcd, 0xef, 0xfb, 0x16, 0x2b, 0x44, 0x96, 0xd9, 0xfc, 0x76, 0xd0, 0x32, 0x9b,
-	0x0d, 0x3d, 0x83, 0x74, 0x28, 0x76, 0x6b, 0xfd, 0xaf, 0xad, 0x56, 0xfb, 0x45, 0xed, 0xb8, 0xd5,
-	0xd0, 0xb3, 0x0c, 0xb9, 0x3b, 0xf8, 0xea, 0xb8, 0x55, 0xb7, 0x8e, 0x5b, 0xed, 0xe7, 0x8a, 0x04,
-	0x39, 0xc6, 0x45, 0xdd, 0x92, 0x67, 0xac, 0x46, 0xad, 0xdf, 0xd4, 0x35, 0xf4, 0x08, 0x1e, 0xac,
-	0xda, 0xed, 0xd6, 0x7a, 0xbd, 0xef, 0x3a, 0x66, 0x43, 0xcf, 0x33, 0xd2, 0xaa, 0x62, 0xbd, 0x41,
-	0xb7, 0xdb, 0x31, 0x59, 0x44, 0x00, 0x42, 0x50, 0xe6, 0xa2, 0x45, 0xec, 0x0a, 0x68, 0x07, 0x4a,
-	0xfd, 0xce, 0xf3, 0x66, 0x7b, 0x2e, 0x5c, 0x91, 0xd9, 0x40, 0x64, 0x51, 0xab, 0xf7, 0x75, 0xcd,
-	0x54, 0xed, 0x53, 0xaa, 0xfe, 0x98, 0x84, 0x74, 0x6d, 0x46, 0xcf, 0xd0, 0x17, 0x50, 0x8e, 0x3f,
-	0x2d, 0xa1, 0x30, 0x80, 0x17, 0xde, 0x9b, 0xf6, 0x91, 0x78, 0xb8, 0x52, 0x1f, 0x8c, 0x8c, 0x5b,
-	0xe8, 0x53, 0x40, 0x0d, 0x12, 0x4c, 0x6c, 0x97, 0x8e, 0x15, 0x1a, 0x25, 0x15, 0xf7, 0xd5, 0xfe,
-	0x4e, 0xf4, 0x62, 0x17, 0x9d, 0xfc, 0x06, 0x76, 0x57, 0x3d, 0xfd, 0xa2, 0x07, 0x11, 0xff, 0xe5,
-	0xc4, 0xbf, 0x46, 0x8a, 0x06, 0x54, 0xe6, 0x52, 0x2c, 0xd2, 0x5b, 0x90, 0xe5, 0x8d, 0xc5, 0xa6,
-	0x67, 0x4e, 0xa5, 0xfa, 0xef, 0x1c, 0xe4, 0x7a, 0xd4, 0xf3, 0xed, 0x11, 0x46, 0x1f, 0x41, 0xbe,
-	0xee, 0x63, 0xd6, 0x80, 0x11, 0x1f, 0x15, 0xc5, 0x19, 0xf1, 0xe6, 0x20, 0x45, 0x88, 0xbd, 0xd4,
-	0x1a, 0xb7, 0xd0, 0x13, 0xc8, 0x36, 0xf0, 0x18, 0xb3, 0xcc, 0x76, 0x03, 0xec, 0xf7, 0x21, 0x7d,
-	0xe2, 0x5d, 0x84, 0xb8, 0xf2, 0xb9, 0x62, 0x0d, 0xee, 0x53, 0xc8, 0xb5, 0xdc, 0x60, 0x8a, 0x87,
-	0x74, 0x81, 0xf4, 0xdd, 0xf8, 0xf3, 0x57, 0x74, 0xe2, 0x19, 0x40, 0x14, 0x92, 0x37, 0x3c, 0xf4,
-	0x34, 0x81, 0x3e, 0x81, 0x62, 0x8f, 0xda, 0x3e, 0xe5, 0x0f, 0x04, 0xfd, 0x2b, 0x69, 0xb9, 0xf0,
-	0x61, 0x79, 0xff, 0x8e, 0xfa, 0x72, 0x19, 0x31, 0xfb, 0x0c, 0x80, 0x1f, 0x10, 0xf3, 0x74, 0x51,
-	0x22, 0xf1, 0xd5, 0xfe, 0xde, 0xf2, 0x73, 0xc4, 0xfc, 0xe0, 0xe3, 0x04, 0xfa, 0x18, 0x4a, 0x87,
-	0xc4, 0x25, 0xc1, 0x59, 0xc8, 0x11, 0xe4, 0xe9, 0xa6, 0xeb, 0xac, 0x31, 0xc6, 0x27, 0x6c, 0x06,
-	0xb6, 0x1d, 0xfe, 0xfe, 0x14, 0x57, 0xec, 0xde, 0xc2, 0xc4, 0xaf, 0x6a, 0xf6, 0x29, 0x94, 0x98,
-	0x41, 0xc2, 0xb9, 0x36, 0x58, 0x69, 0x93, 0xc5, 0x19, 0x9e, 0x9f, 0xfc, 0x25, 0x1b, 0x2c, 0x6d,
-	0x67, 0x3e, 0x77, 0xeb, 0x0b, 0xa8, 0x9b, 0xf9, 0x7e, 0xc6, 0x46, 0x3f, 0x3e, 0xd4, 0x6d, 0x20,
-	0xb0, 0x5a, 0xd1, 0xcf, 0xa1, 0x20, 0x44, 0xe6, 0x93, 0xe3, 0x82, 0xc0, 0x7b, 0xcb, 0x03, 0xb1,
-	0xca, 0xb6, 0x06, 0x77, 0xe6, 0x6c, 0x95, 0xa1, 0x78, 0x77, 0xc5, 0xa9, 0x75, 0xec, 0xab, 0x50,
-	0x94, 0xa0, 0x55, 0xfc, 0x57, 0x9f, 0xf9, 0x00, 0xb2, 0x3d, 0x4c, 0x6b, 0xf5, 0x63, 0x24, 0x1e,
-	0xb9, 0x45, 0xa3, 0xbe, 0x06, 0xf9, 0x00, 0xf2, 0xa2, 0xe6, 0xdd, 0x10, 0xff, 0x43, 0xd0, 0x06,
-	0x6e, 0x70, 0x63, 0xf2, 0x1f, 0x81, 0x76, 0x84, 0x29, 0xff, 0xa1, 0x43, 0xfa, 0x71, 0xf8, 0xa3,
-	0x88, 0x3c, 0x10, 0xfb, 0x0d, 0xc4, 0xb8, 0x55, 0xfd, 0x31, 0xc1, 0x7f, 0xd4, 0x1c, 0x61, 0x1f,
-	0x3d, 0x81, 0xdc, 0x11, 0xa6, 0x7d, 0x7b, 0x14, 0x48, 0x4e, 0xe2, 0xc7, 0xce, 0x7d, 0x3d, 0x5a,
-	0x28, 0xc6, 0x16, 0x5a, 0xf7, 0xed, 0x51, 0x1c, 0x79, 0x83, 0x16, 0x37, 0x46, 0xaf, 0xfe, 0x2b,
-	0x0b, 0x19, 0xd1, 0x38, 0x7e, 0x01, 0xba, 0xc8, 0x47, 0xca, 0x90, 0x21, 0x1a, 0x9e, 0x68, 0xd2,
-	0xdf, 0x90, 0xdb, 0x50, 0x0d, 0x74, 0x61, 0x6e, 0xe5, 0xbc, 0xe0, 0x19, 0x1b, 0xcb, 0x37, 0x91,
-	0xf8, 0x12, 0x76, 0x64, 0x1e, 0x5a, 0x92, 0x21, 0xea, 0xba, 0x37, 0x11, 0xf8, 0x8c, 0xbf, 0x93,
-	0x79, 0xe7, 0x78, 0xd3, 0xf9, 0xd5, 0x76, 0x3b, 0x82, 0xdb, 0x0b, 0xed, 0x08, 0x12, 0x8c, 0x96,
-	0x9b, 0x94, 0x0d, 0x12, 0x3c, 0x4d, 0xa0, 0x06, 0x94, 0x6b, 0x8e, 0xa3, 0xb6, 0xe4, 0xf7, 0x42,
-	0x2b, 0xc6, 0x9b, 0xaf, 0xfd, 0xca, 0x52, 0x9b, 0xa8, 0xd6, 0xae, 0x9d, 0xa5, 0x86, 0x0d, 0xed,
-	0x29, 0xe6, 0xdc, 0x8a, 0x96, 0xbe, 0xd8, 0x3f, 0xa1, 0xca, 0x5c, 0xb7, 0x85, 0xb6, 0x6a, 0x13,
-	0x25, 0x9e, 0xad, 0x4a, 0xb1, 0xce, 0x0e, 0x89, 0xcc, 0xb6, 0xd8, 0xed, 0xad, 0x31, 0xf2, 0xaf,
-	0xa0, 0x7c, 0x84, 0x55, 0x8e, 0xcb, 0xb7, 0xb3, 0x49, 0x91, 0xba, 0x68, 0x19, 0x63, 0x1d, 0x5e,
-	0xb0, 0x58, 0x44, 0xf6, 0xc3, 0x1c, 0xb4, 0xdc, 0xd0, 0xcb, 0xd4, 0x85, 0x4e, 0xbc, 0x99, 0x1b,
-	0xa7, 0x82, 0xee, 0xae, 0x3a, 0xb5, 0x4e, 0x8d, 0x3a, 0xec, 0x0e, 0xdc, 0xc9, 0x7f, 0x47, 0xa4,
-	0xfa, 0x15, 0xe4, 0xba, 0x3e, 0xbe, 0x20, 0xf8, 0x12, 0xfd, 0x1c, 0x0a, 0xac, 0x04, 0x84, 0xcb,
-	0x1b, 0x57, 0x9d, 0x97, 0x59, 0xfe, 0x2f, 0x13, 0x3f, 0xfb, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0xb7, 0x4c, 0x6b, 0xd8, 0x3f, 0x21, 0x00, 0x00,
+func (m *FsckEntry) Reset()         { *m = FsckEntry{} }
+func (m *FsckEntry) String() string { return proto.CompactTextString(m) }
+func (*FsckEntry) ProtoMessage()    {}
+func (*FsckEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{56}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *FsckEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FsckEntry.Unmarshal(m, b)
+}
+func (m *FsckEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FsckEntry.Marshal(b, m, deterministic)
+}
+func (m *FsckEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FsckEntry.Merge(m, src)
+}
+func (m *FsckEntry) XXX_Size() int {
+	return xxx_messageInfo_FsckEntry.Size(m)
+}
+func (m *FsckEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_FsckEntry.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_FsckEntry proto.InternalMessageInfo
 
-// AuthClient is the client API for Auth service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type AuthClient interface {
-	ForgeUserToken(ctx context.Context, in *ForgeUserTokenReq, opts ...grpc.CallOption) (*TokenResponse, error)
-	DismantleUserToken(ctx context.Context, in *TokenReq, opts ...grpc.CallOption) (*UserResponse, error)
-	ForgePublicLinkToken(ctx context.Context, in *ForgePublicLinkTokenReq, opts ...grpc.CallOption) (*TokenResponse, error)
-	DismantlePublicLinkToken(ctx context.Context, in *TokenReq, opts ...grpc.CallOption) (*PublicLinkResponse, error)
+func (m *FsckEntry) GetKind() FsckEntry_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return FsckEntry_FOLDER_SHARE
 }
 
-type authClient struct {
-	cc *grpc.ClientConn
+func (m *FsckEntry) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
 }
 
-func NewAuthClient(cc *grpc.ClientConn) AuthClient {
-	return &authClient{cc}
+func (m *FsckEntry) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
 }
 
-func (c *authClient) ForgeUserToken(ctx context.Context, in *ForgeUserTokenReq, opts ...grpc.CallOption) (*TokenResponse, error) {
-	out := new(TokenResponse)
-	err := c.cc.Invoke(ctx, "/api.Auth/ForgeUserToken", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *FsckEntry) GetFileId() string {
+	if m != nil {
+		return m.FileId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *authClient) DismantleUserToken(ctx context.Context, in *TokenReq, opts ...grpc.CallOption) (*UserResponse, error) {
-	out := new(UserResponse)
-	err := c.cc.Invoke(ctx, "/api.Auth/DismantleUserToken", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *FsckEntry) GetProblem() FsckEntry_Problem {
+	if m != nil {
+		return m.Problem
 	}
-	return out, nil
+	return FsckEntry_ORPHAN
 }
 
-func (c *authClient) ForgePublicLinkToken(ctx context.Context, in *ForgePublicLinkTokenReq, opts ...grpc.CallOption) (*TokenResponse, error) {
-	out := new(TokenResponse)
-	err := c.cc.Invoke(ctx, "/api.Auth/ForgePublicLinkToken", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *FsckEntry) GetRepaired() bool {
+	if m != nil {
+		return m.Repaired
 	}
-	return out, nil
+	return false
 }
 
-func (c *authClient) DismantlePublicLinkToken(ctx context.Context, in *TokenReq, opts ...grpc.CallOption) (*PublicLinkResponse, error) {
-	out := new(PublicLinkResponse)
-	err := c.cc.Invoke(ctx, "/api.Auth/DismantlePublicLinkToken", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type FsckSharesResponse struct {
+	Status               StatusCode `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
+	Entry                *FsckEntry `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
-// AuthServer is the server API for Auth service.
-type AuthServer interface {
-	ForgeUserToken(context.Context, *ForgeUserTokenReq) (*TokenResponse, error)
-	DismantleUserToken(context.Context, *TokenReq) (*UserResponse, error)
-	ForgePublicLinkToken(context.Context, *ForgePublicLinkTokenReq) (*TokenResponse, error)
-	DismantlePublicLinkToken(context.Context, *TokenReq) (*PublicLinkResponse, error)
+func (m *FsckSharesResponse) Reset()         { *m = FsckSharesResponse{} }
+func (m *FsckSharesResponse) String() string { return proto.CompactTextString(m) }
+func (*FsckSharesResponse) ProtoMessage()    {}
+func (*FsckSharesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{57}
 }
 
-func RegisterAuthServer(s *grpc.Server, srv AuthServer) {
-	s.RegisterService(&_Auth_serviceDesc, srv)
+func (m *FsckSharesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FsckSharesResponse.Unmarshal(m, b)
+}
+func (m *FsckSharesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FsckSharesResponse.Marshal(b, m, deterministic)
+}
+func (m *FsckSharesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FsckSharesResponse.Merge(m, src)
+}
+func (m *FsckSharesResponse) XXX_Size() int {
+	return xxx_messageInfo_FsckSharesResponse.Size(m)
+}
+func (m *FsckSharesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FsckSharesResponse.DiscardUnknown(m)
 }
 
-func _Auth_ForgeUserToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ForgeUserTokenReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
+var xxx_messageInfo_FsckSharesResponse proto.InternalMessageInfo
+
+func (m *FsckSharesResponse) GetStatus() StatusCode {
+	if m != nil {
+		return m.Status
+	}
+	return StatusCode_OK
+}
+
+func (m *FsckSharesResponse) GetEntry() *FsckEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+// The transfer can be run again with the same request to resume it,
+// the steps already done are skipped.
+type TransferOwnershipReq struct {
+	FromUser             string   `protobuf:"bytes,1,opt,name=from_user,json=fromUser,proto3" json:"from_user,omitempty"`
+	FromPath             string   `protobuf:"bytes,2,opt,name=from_path,json=fromPath,proto3" json:"from_path,omitempty"`
+	ToUser               string   `protobuf:"bytes,3,opt,name=to_user,json=toUser,proto3" json:"to_user,omitempty"`
+	ToPath               string   `protobuf:"bytes,4,opt,name=to_path,json=toPath,proto3" json:"to_path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferOwnershipReq) Reset()         { *m = TransferOwnershipReq{} }
+func (m *TransferOwnershipReq) String() string { return proto.CompactTextString(m) }
+func (*TransferOwnershipReq) ProtoMessage()    {}
+func (*TransferOwnershipReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{58}
+}
+
+func (m *TransferOwnershipReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferOwnershipReq.Unmarshal(m, b)
+}
+func (m *TransferOwnershipReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferOwnershipReq.Marshal(b, m, deterministic)
+}
+func (m *TransferOwnershipReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferOwnershipReq.Merge(m, src)
+}
+func (m *TransferOwnershipReq) XXX_Size() int {
+	return xxx_messageInfo_TransferOwnershipReq.Size(m)
+}
+func (m *TransferOwnershipReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferOwnershipReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferOwnershipReq proto.InternalMessageInfo
+
+func (m *TransferOwnershipReq) GetFromUser() string {
+	if m != nil {
+		return m.FromUser
+	}
+	return ""
+}
+
+func (m *TransferOwnershipReq) GetFromPath() string {
+	if m != nil {
+		return m.FromPath
+	}
+	return ""
+}
+
+func (m *TransferOwnershipReq) GetToUser() string {
+	if m != nil {
+		return m.ToUser
+	}
+	return ""
+}
+
+func (m *TransferOwnershipReq) GetToPath() string {
+	if m != nil {
+		return m.ToPath
+	}
+	return ""
+}
+
+type TransferProgress struct {
+	Step                 TransferProgress_Step `protobuf:"varint,1,opt,name=step,proto3,enum=api.TransferProgress_Step" json:"step,omitempty"`
+	Id                   string                `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Path                 string                `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Skipped              bool                  `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *TransferProgress) Reset()         { *m = TransferProgress{} }
+func (m *TransferProgress) String() string { return proto.CompactTextString(m) }
+func (*TransferProgress) ProtoMessage()    {}
+func (*TransferProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{59}
+}
+
+func (m *TransferProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferProgress.Unmarshal(m, b)
+}
+func (m *TransferProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferProgress.Marshal(b, m, deterministic)
+}
+func (m *TransferProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferProgress.Merge(m, src)
+}
+func (m *TransferProgress) XXX_Size() int {
+	return xxx_messageInfo_TransferProgress.Size(m)
+}
+func (m *TransferProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferProgress proto.InternalMessageInfo
+
+func (m *TransferProgress) GetStep() TransferProgress_Step {
+	if m != nil {
+		return m.Step
+	}
+	return TransferProgress_FILES
+}
+
+func (m *TransferProgress) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *TransferProgress) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *TransferProgress) GetSkipped() bool {
+	if m != nil {
+		return m.Skipped
+	}
+	return false
+}
+
+type TransferOwnershipResponse struct {
+	Status               StatusCode        `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
+	Progress             *TransferProgress `protobuf:"bytes,2,opt,name=progress,proto3" json:"progress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TransferOwnershipResponse) Reset()         { *m = TransferOwnershipResponse{} }
+func (m *TransferOwnershipResponse) String() string { return proto.CompactTextString(m) }
+func (*TransferOwnershipResponse) ProtoMessage()    {}
+func (*TransferOwnershipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{60}
+}
+
+func (m *TransferOwnershipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferOwnershipResponse.Unmarshal(m, b)
+}
+func (m *TransferOwnershipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferOwnershipResponse.Marshal(b, m, deterministic)
+}
+func (m *TransferOwnershipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferOwnershipResponse.Merge(m, src)
+}
+func (m *TransferOwnershipResponse) XXX_Size() int {
+	return xxx_messageInfo_TransferOwnershipResponse.Size(m)
+}
+func (m *TransferOwnershipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferOwnershipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferOwnershipResponse proto.InternalMessageInfo
+
+func (m *TransferOwnershipResponse) GetStatus() StatusCode {
+	if m != nil {
+		return m.Status
+	}
+	return StatusCode_OK
+}
+
+func (m *TransferOwnershipResponse) GetProgress() *TransferProgress {
+	if m != nil {
+		return m.Progress
+	}
+	return nil
+}
+
+type ReceivedShareReq struct {
+	ShareId              string   `protobuf:"bytes,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	Target               string   `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceivedShareReq) Reset()         { *m = ReceivedShareReq{} }
+func (m *ReceivedShareReq) String() string { return proto.CompactTextString(m) }
+func (*ReceivedShareReq) ProtoMessage()    {}
+func (*ReceivedShareReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{61}
+}
+
+func (m *ReceivedShareReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceivedShareReq.Unmarshal(m, b)
+}
+func (m *ReceivedShareReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceivedShareReq.Marshal(b, m, deterministic)
+}
+func (m *ReceivedShareReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceivedShareReq.Merge(m, src)
+}
+func (m *ReceivedShareReq) XXX_Size() int {
+	return xxx_messageInfo_ReceivedShareReq.Size(m)
+}
+func (m *ReceivedShareReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceivedShareReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceivedShareReq proto.InternalMessageInfo
+
+func (m *ReceivedShareReq) GetShareId() string {
+	if m != nil {
+		return m.ShareId
+	}
+	return ""
+}
+
+func (m *ReceivedShareReq) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+type AppPassword struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId              string   `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Label                string   `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	ReadOnly             bool     `protobuf:"varint,4,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	Path                 string   `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	Ctime                uint64   `protobuf:"varint,6,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	Atime                uint64   `protobuf:"varint,7,opt,name=atime,proto3" json:"atime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppPassword) Reset()         { *m = AppPassword{} }
+func (m *AppPassword) String() string { return proto.CompactTextString(m) }
+func (*AppPassword) ProtoMessage()    {}
+func (*AppPassword) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{62}
+}
+
+func (m *AppPassword) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppPassword.Unmarshal(m, b)
+}
+func (m *AppPassword) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppPassword.Marshal(b, m, deterministic)
+}
+func (m *AppPassword) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppPassword.Merge(m, src)
+}
+func (m *AppPassword) XXX_Size() int {
+	return xxx_messageInfo_AppPassword.Size(m)
+}
+func (m *AppPassword) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppPassword.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppPassword proto.InternalMessageInfo
+
+func (m *AppPassword) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AppPassword) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *AppPassword) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *AppPassword) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *AppPassword) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *AppPassword) GetCtime() uint64 {
+	if m != nil {
+		return m.Ctime
+	}
+	return 0
+}
+
+func (m *AppPassword) GetAtime() uint64 {
+	if m != nil {
+		return m.Atime
+	}
+	return 0
+}
+
+type NewAppPasswordReq struct {
+	Label                string   `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	ReadOnly             bool     `protobuf:"varint,2,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	Path                 string   `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewAppPasswordReq) Reset()         { *m = NewAppPasswordReq{} }
+func (m *NewAppPasswordReq) String() string { return proto.CompactTextString(m) }
+func (*NewAppPasswordReq) ProtoMessage()    {}
+func (*NewAppPasswordReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{63}
+}
+
+func (m *NewAppPasswordReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewAppPasswordReq.Unmarshal(m, b)
+}
+func (m *NewAppPasswordReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewAppPasswordReq.Marshal(b, m, deterministic)
+}
+func (m *NewAppPasswordReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewAppPasswordReq.Merge(m, src)
+}
+func (m *NewAppPasswordReq) XXX_Size() int {
+	return xxx_messageInfo_NewAppPasswordReq.Size(m)
+}
+func (m *NewAppPasswordReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewAppPasswordReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewAppPasswordReq proto.InternalMessageInfo
+
+func (m *NewAppPasswordReq) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *NewAppPasswordReq) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *NewAppPasswordReq) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type AppPasswordResponse struct {
+	Status      StatusCode   `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
+	AppPassword *AppPassword `protobuf:"bytes,2,opt,name=app_password,json=appPassword,proto3" json:"app_password,omitempty"`
+	// only set on creation, the password is not stored in clear text.
+	Password             string   `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppPasswordResponse) Reset()         { *m = AppPasswordResponse{} }
+func (m *AppPasswordResponse) String() string { return proto.CompactTextString(m) }
+func (*AppPasswordResponse) ProtoMessage()    {}
+func (*AppPasswordResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{64}
+}
+
+func (m *AppPasswordResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppPasswordResponse.Unmarshal(m, b)
+}
+func (m *AppPasswordResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppPasswordResponse.Marshal(b, m, deterministic)
+}
+func (m *AppPasswordResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppPasswordResponse.Merge(m, src)
+}
+func (m *AppPasswordResponse) XXX_Size() int {
+	return xxx_messageInfo_AppPasswordResponse.Size(m)
+}
+func (m *AppPasswordResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppPasswordResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppPasswordResponse proto.InternalMessageInfo
+
+func (m *AppPasswordResponse) GetStatus() StatusCode {
+	if m != nil {
+		return m.Status
+	}
+	return StatusCode_OK
+}
+
+func (m *AppPasswordResponse) GetAppPassword() *AppPassword {
+	if m != nil {
+		return m.AppPassword
+	}
+	return nil
+}
+
+func (m *AppPasswordResponse) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type AppPasswordIDReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppPasswordIDReq) Reset()         { *m = AppPasswordIDReq{} }
+func (m *AppPasswordIDReq) String() string { return proto.CompactTextString(m) }
+func (*AppPasswordIDReq) ProtoMessage()    {}
+func (*AppPasswordIDReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{65}
+}
+
+func (m *AppPasswordIDReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppPasswordIDReq.Unmarshal(m, b)
+}
+func (m *AppPasswordIDReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppPasswordIDReq.Marshal(b, m, deterministic)
+}
+func (m *AppPasswordIDReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppPasswordIDReq.Merge(m, src)
+}
+func (m *AppPasswordIDReq) XXX_Size() int {
+	return xxx_messageInfo_AppPasswordIDReq.Size(m)
+}
+func (m *AppPasswordIDReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppPasswordIDReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppPasswordIDReq proto.InternalMessageInfo
+
+func (m *AppPasswordIDReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// AuditEvent records who did what on which resource, it is emitted
+// once per mutating or access call to the storage and sharing services.
+type AuditEvent struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time                 uint64   `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	AccountId            string   `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	PublicLinkToken      string   `protobuf:"bytes,4,opt,name=public_link_token,json=publicLinkToken,proto3" json:"public_link_token,omitempty"`
+	Action               string   `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	Method               string   `protobuf:"bytes,6,opt,name=method,proto3" json:"method,omitempty"`
+	Path                 string   `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
+	FileId               string   `protobuf:"bytes,8,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Target               string   `protobuf:"bytes,9,opt,name=target,proto3" json:"target,omitempty"`
+	Result               string   `protobuf:"bytes,10,opt,name=result,proto3" json:"result,omitempty"`
+	ClientIp             string   `protobuf:"bytes,11,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	TraceId              string   `protobuf:"bytes,12,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditEvent) Reset()         { *m = AuditEvent{} }
+func (m *AuditEvent) String() string { return proto.CompactTextString(m) }
+func (*AuditEvent) ProtoMessage()    {}
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{66}
+}
+
+func (m *AuditEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEvent.Unmarshal(m, b)
+}
+func (m *AuditEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEvent.Marshal(b, m, deterministic)
+}
+func (m *AuditEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEvent.Merge(m, src)
+}
+func (m *AuditEvent) XXX_Size() int {
+	return xxx_messageInfo_AuditEvent.Size(m)
+}
+func (m *AuditEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEvent proto.InternalMessageInfo
+
+func (m *AuditEvent) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetTime() uint64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *AuditEvent) GetAccountId() string {
+	if m != nil {
+		return m.AccountId
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetPublicLinkToken() string {
+	if m != nil {
+		return m.PublicLinkToken
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetFileId() string {
+	if m != nil {
+		return m.FileId
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetClientIp() string {
+	if m != nil {
+		return m.ClientIp
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetTraceId() string {
+	if m != nil {
+		return m.TraceId
+	}
+	return ""
+}
+
+type ListAuditEventsReq struct {
+	AccountId            string   `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	FileId               string   `protobuf:"bytes,3,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Action               string   `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	From                 uint64   `protobuf:"varint,5,opt,name=from,proto3" json:"from,omitempty"`
+	To                   uint64   `protobuf:"varint,6,opt,name=to,proto3" json:"to,omitempty"`
+	Limit                uint64   `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEventsReq) Reset()         { *m = ListAuditEventsReq{} }
+func (m *ListAuditEventsReq) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsReq) ProtoMessage()    {}
+func (*ListAuditEventsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{67}
+}
+
+func (m *ListAuditEventsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsReq.Unmarshal(m, b)
+}
+func (m *ListAuditEventsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsReq.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsReq.Merge(m, src)
+}
+func (m *ListAuditEventsReq) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsReq.Size(m)
+}
+func (m *ListAuditEventsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsReq proto.InternalMessageInfo
+
+func (m *ListAuditEventsReq) GetAccountId() string {
+	if m != nil {
+		return m.AccountId
+	}
+	return ""
+}
+
+func (m *ListAuditEventsReq) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ListAuditEventsReq) GetFileId() string {
+	if m != nil {
+		return m.FileId
+	}
+	return ""
+}
+
+func (m *ListAuditEventsReq) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *ListAuditEventsReq) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *ListAuditEventsReq) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *ListAuditEventsReq) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type AuditEventResponse struct {
+	Status               StatusCode  `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
+	AuditEvent           *AuditEvent `protobuf:"bytes,2,opt,name=audit_event,json=auditEvent,proto3" json:"audit_event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *AuditEventResponse) Reset()         { *m = AuditEventResponse{} }
+func (m *AuditEventResponse) String() string { return proto.CompactTextString(m) }
+func (*AuditEventResponse) ProtoMessage()    {}
+func (*AuditEventResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{68}
+}
+
+func (m *AuditEventResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEventResponse.Unmarshal(m, b)
+}
+func (m *AuditEventResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEventResponse.Marshal(b, m, deterministic)
+}
+func (m *AuditEventResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEventResponse.Merge(m, src)
+}
+func (m *AuditEventResponse) XXX_Size() int {
+	return xxx_messageInfo_AuditEventResponse.Size(m)
+}
+func (m *AuditEventResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEventResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEventResponse proto.InternalMessageInfo
+
+func (m *AuditEventResponse) GetStatus() StatusCode {
+	if m != nil {
+		return m.Status
+	}
+	return StatusCode_OK
+}
+
+func (m *AuditEventResponse) GetAuditEvent() *AuditEvent {
+	if m != nil {
+		return m.AuditEvent
+	}
+	return nil
+}
+
+type WatchReq struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Recursive            bool     `protobuf:"varint,2,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchReq) Reset()         { *m = WatchReq{} }
+func (m *WatchReq) String() string { return proto.CompactTextString(m) }
+func (*WatchReq) ProtoMessage()    {}
+func (*WatchReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{69}
+}
+
+func (m *WatchReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchReq.Unmarshal(m, b)
+}
+func (m *WatchReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchReq.Marshal(b, m, deterministic)
+}
+func (m *WatchReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchReq.Merge(m, src)
+}
+func (m *WatchReq) XXX_Size() int {
+	return xxx_messageInfo_WatchReq.Size(m)
+}
+func (m *WatchReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchReq proto.InternalMessageInfo
+
+func (m *WatchReq) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *WatchReq) GetRecursive() bool {
+	if m != nil {
+		return m.Recursive
+	}
+	return false
+}
+
+// FileEvent reports a change in the storage, it is published by the virtual storage
+// and delivered to the watchers of the path.
+type FileEvent struct {
+	Type                 FileEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=api.FileEvent_Type" json:"type,omitempty"`
+	Path                 string         `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	OldPath              string         `protobuf:"bytes,3,opt,name=old_path,json=oldPath,proto3" json:"old_path,omitempty"`
+	FileId               string         `protobuf:"bytes,4,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	ParentId             string         `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	AccountId            string         `protobuf:"bytes,6,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Time                 uint64         `protobuf:"varint,7,opt,name=time,proto3" json:"time,omitempty"`
+	Target               string         `protobuf:"bytes,8,opt,name=target,proto3" json:"target,omitempty"`
+	Metadata             *Metadata      `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *FileEvent) Reset()         { *m = FileEvent{} }
+func (m *FileEvent) String() string { return proto.CompactTextString(m) }
+func (*FileEvent) ProtoMessage()    {}
+func (*FileEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{70}
+}
+
+func (m *FileEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileEvent.Unmarshal(m, b)
+}
+func (m *FileEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileEvent.Marshal(b, m, deterministic)
+}
+func (m *FileEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileEvent.Merge(m, src)
+}
+func (m *FileEvent) XXX_Size() int {
+	return xxx_messageInfo_FileEvent.Size(m)
+}
+func (m *FileEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileEvent proto.InternalMessageInfo
+
+func (m *FileEvent) GetType() FileEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return FileEvent_CREATED
+}
+
+func (m *FileEvent) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FileEvent) GetOldPath() string {
+	if m != nil {
+		return m.OldPath
+	}
+	return ""
+}
+
+func (m *FileEvent) GetFileId() string {
+	if m != nil {
+		return m.FileId
+	}
+	return ""
+}
+
+func (m *FileEvent) GetParentId() string {
+	if m != nil {
+		return m.ParentId
+	}
+	return ""
+}
+
+func (m *FileEvent) GetAccountId() string {
+	if m != nil {
+		return m.AccountId
+	}
+	return ""
+}
+
+func (m *FileEvent) GetTime() uint64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *FileEvent) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *FileEvent) GetMetadata() *Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+type FileEventResponse struct {
+	Status               StatusCode `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
+	FileEvent            *FileEvent `protobuf:"bytes,2,opt,name=file_event,json=fileEvent,proto3" json:"file_event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *FileEventResponse) Reset()         { *m = FileEventResponse{} }
+func (m *FileEventResponse) String() string { return proto.CompactTextString(m) }
+func (*FileEventResponse) ProtoMessage()    {}
+func (*FileEventResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{71}
+}
+
+func (m *FileEventResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileEventResponse.Unmarshal(m, b)
+}
+func (m *FileEventResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileEventResponse.Marshal(b, m, deterministic)
+}
+func (m *FileEventResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileEventResponse.Merge(m, src)
+}
+func (m *FileEventResponse) XXX_Size() int {
+	return xxx_messageInfo_FileEventResponse.Size(m)
+}
+func (m *FileEventResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileEventResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileEventResponse proto.InternalMessageInfo
+
+func (m *FileEventResponse) GetStatus() StatusCode {
+	if m != nil {
+		return m.Status
+	}
+	return StatusCode_OK
+}
+
+func (m *FileEventResponse) GetFileEvent() *FileEvent {
+	if m != nil {
+		return m.FileEvent
+	}
+	return nil
+}
+
+// Webhook is a subscription rule: the events on path, or under it if recursive,
+// are POSTed to url. If events is empty all the types of events are sent.
+type Webhook struct {
+	Id                   string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId              string           `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Path                 string           `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Recursive            bool             `protobuf:"varint,4,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	Url                  string           `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	Events               []FileEvent_Type `protobuf:"varint,6,rep,packed,name=events,proto3,enum=api.FileEvent_Type" json:"events,omitempty"`
+	Ctime                uint64           `protobuf:"varint,7,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Webhook) Reset()         { *m = Webhook{} }
+func (m *Webhook) String() string { return proto.CompactTextString(m) }
+func (*Webhook) ProtoMessage()    {}
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{72}
+}
+
+func (m *Webhook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Webhook.Unmarshal(m, b)
+}
+func (m *Webhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Webhook.Marshal(b, m, deterministic)
+}
+func (m *Webhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Webhook.Merge(m, src)
+}
+func (m *Webhook) XXX_Size() int {
+	return xxx_messageInfo_Webhook.Size(m)
+}
+func (m *Webhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_Webhook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Webhook proto.InternalMessageInfo
+
+func (m *Webhook) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Webhook) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *Webhook) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Webhook) GetRecursive() bool {
+	if m != nil {
+		return m.Recursive
+	}
+	return false
+}
+
+func (m *Webhook) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Webhook) GetEvents() []FileEvent_Type {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *Webhook) GetCtime() uint64 {
+	if m != nil {
+		return m.Ctime
+	}
+	return 0
+}
+
+type NewWebhookReq struct {
+	Path                 string           `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Recursive            bool             `protobuf:"varint,2,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	Url                  string           `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Events               []FileEvent_Type `protobuf:"varint,4,rep,packed,name=events,proto3,enum=api.FileEvent_Type" json:"events,omitempty"`
+	Secret               string           `protobuf:"bytes,5,opt,name=secret,proto3" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *NewWebhookReq) Reset()         { *m = NewWebhookReq{} }
+func (m *NewWebhookReq) String() string { return proto.CompactTextString(m) }
+func (*NewWebhookReq) ProtoMessage()    {}
+func (*NewWebhookReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{73}
+}
+
+func (m *NewWebhookReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewWebhookReq.Unmarshal(m, b)
+}
+func (m *NewWebhookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewWebhookReq.Marshal(b, m, deterministic)
+}
+func (m *NewWebhookReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewWebhookReq.Merge(m, src)
+}
+func (m *NewWebhookReq) XXX_Size() int {
+	return xxx_messageInfo_NewWebhookReq.Size(m)
+}
+func (m *NewWebhookReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewWebhookReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewWebhookReq proto.InternalMessageInfo
+
+func (m *NewWebhookReq) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *NewWebhookReq) GetRecursive() bool {
+	if m != nil {
+		return m.Recursive
+	}
+	return false
+}
+
+func (m *NewWebhookReq) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *NewWebhookReq) GetEvents() []FileEvent_Type {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *NewWebhookReq) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+type WebhookResponse struct {
+	Status               StatusCode `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
+	Webhook              *Webhook   `protobuf:"bytes,2,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *WebhookResponse) Reset()         { *m = WebhookResponse{} }
+func (m *WebhookResponse) String() string { return proto.CompactTextString(m) }
+func (*WebhookResponse) ProtoMessage()    {}
+func (*WebhookResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{74}
+}
+
+func (m *WebhookResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookResponse.Unmarshal(m, b)
+}
+func (m *WebhookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookResponse.Marshal(b, m, deterministic)
+}
+func (m *WebhookResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookResponse.Merge(m, src)
+}
+func (m *WebhookResponse) XXX_Size() int {
+	return xxx_messageInfo_WebhookResponse.Size(m)
+}
+func (m *WebhookResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookResponse proto.InternalMessageInfo
+
+func (m *WebhookResponse) GetStatus() StatusCode {
+	if m != nil {
+		return m.Status
+	}
+	return StatusCode_OK
+}
+
+func (m *WebhookResponse) GetWebhook() *Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+type WebhookIDReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WebhookIDReq) Reset()         { *m = WebhookIDReq{} }
+func (m *WebhookIDReq) String() string { return proto.CompactTextString(m) }
+func (*WebhookIDReq) ProtoMessage()    {}
+func (*WebhookIDReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{75}
+}
+
+func (m *WebhookIDReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookIDReq.Unmarshal(m, b)
+}
+func (m *WebhookIDReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookIDReq.Marshal(b, m, deterministic)
+}
+func (m *WebhookIDReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookIDReq.Merge(m, src)
+}
+func (m *WebhookIDReq) XXX_Size() int {
+	return xxx_messageInfo_WebhookIDReq.Size(m)
+}
+func (m *WebhookIDReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookIDReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookIDReq proto.InternalMessageInfo
+
+func (m *WebhookIDReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// DeadLetter is a payload that could not be delivered after all the retries.
+type DeadLetter struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId            string   `protobuf:"bytes,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Payload              string   `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Attempts             uint64   `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	Ctime                uint64   `protobuf:"varint,6,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeadLetter) Reset()         { *m = DeadLetter{} }
+func (m *DeadLetter) String() string { return proto.CompactTextString(m) }
+func (*DeadLetter) ProtoMessage()    {}
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{76}
+}
+
+func (m *DeadLetter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetter.Unmarshal(m, b)
+}
+func (m *DeadLetter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetter.Marshal(b, m, deterministic)
+}
+func (m *DeadLetter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetter.Merge(m, src)
+}
+func (m *DeadLetter) XXX_Size() int {
+	return xxx_messageInfo_DeadLetter.Size(m)
+}
+func (m *DeadLetter) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetter proto.InternalMessageInfo
+
+func (m *DeadLetter) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetWebhookId() string {
+	if m != nil {
+		return m.WebhookId
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetAttempts() uint64 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetCtime() uint64 {
+	if m != nil {
+		return m.Ctime
+	}
+	return 0
+}
+
+type DeadLetterResponse struct {
+	Status               StatusCode  `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
+	DeadLetter           *DeadLetter `protobuf:"bytes,2,opt,name=dead_letter,json=deadLetter,proto3" json:"dead_letter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *DeadLetterResponse) Reset()         { *m = DeadLetterResponse{} }
+func (m *DeadLetterResponse) String() string { return proto.CompactTextString(m) }
+func (*DeadLetterResponse) ProtoMessage()    {}
+func (*DeadLetterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{77}
+}
+
+func (m *DeadLetterResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetterResponse.Unmarshal(m, b)
+}
+func (m *DeadLetterResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetterResponse.Marshal(b, m, deterministic)
+}
+func (m *DeadLetterResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetterResponse.Merge(m, src)
+}
+func (m *DeadLetterResponse) XXX_Size() int {
+	return xxx_messageInfo_DeadLetterResponse.Size(m)
+}
+func (m *DeadLetterResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetterResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetterResponse proto.InternalMessageInfo
+
+func (m *DeadLetterResponse) GetStatus() StatusCode {
+	if m != nil {
+		return m.Status
+	}
+	return StatusCode_OK
+}
+
+func (m *DeadLetterResponse) GetDeadLetter() *DeadLetter {
+	if m != nil {
+		return m.DeadLetter
+	}
+	return nil
+}
+
+type DeadLetterIDReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeadLetterIDReq) Reset()         { *m = DeadLetterIDReq{} }
+func (m *DeadLetterIDReq) String() string { return proto.CompactTextString(m) }
+func (*DeadLetterIDReq) ProtoMessage()    {}
+func (*DeadLetterIDReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{78}
+}
+
+func (m *DeadLetterIDReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetterIDReq.Unmarshal(m, b)
+}
+func (m *DeadLetterIDReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetterIDReq.Marshal(b, m, deterministic)
+}
+func (m *DeadLetterIDReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetterIDReq.Merge(m, src)
+}
+func (m *DeadLetterIDReq) XXX_Size() int {
+	return xxx_messageInfo_DeadLetterIDReq.Size(m)
+}
+func (m *DeadLetterIDReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetterIDReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetterIDReq proto.InternalMessageInfo
+
+func (m *DeadLetterIDReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// OCMShare is a share with a user of another provider, or received from one.
+// The users of the other providers are identified by their federated
+// address, like alice@https://cloud.example.org.
+type OCMShare struct {
+	Id                   string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId              string         `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Recipient            string         `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Path                 string         `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	Name                 string         `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Permissions          uint32         `protobuf:"varint,6,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	State                OCMShare_State `protobuf:"varint,7,opt,name=state,proto3,enum=api.OCMShare_State" json:"state,omitempty"`
+	Ctime                uint64         `protobuf:"varint,8,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	ProviderId           string         `protobuf:"bytes,9,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	Endpoint             string         `protobuf:"bytes,10,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	WebdavUrl            string         `protobuf:"bytes,11,opt,name=webdav_url,json=webdavUrl,proto3" json:"webdav_url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *OCMShare) Reset()         { *m = OCMShare{} }
+func (m *OCMShare) String() string { return proto.CompactTextString(m) }
+func (*OCMShare) ProtoMessage()    {}
+func (*OCMShare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{79}
+}
+
+func (m *OCMShare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OCMShare.Unmarshal(m, b)
+}
+func (m *OCMShare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OCMShare.Marshal(b, m, deterministic)
+}
+func (m *OCMShare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OCMShare.Merge(m, src)
+}
+func (m *OCMShare) XXX_Size() int {
+	return xxx_messageInfo_OCMShare.Size(m)
+}
+func (m *OCMShare) XXX_DiscardUnknown() {
+	xxx_messageInfo_OCMShare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OCMShare proto.InternalMessageInfo
+
+func (m *OCMShare) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *OCMShare) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *OCMShare) GetRecipient() string {
+	if m != nil {
+		return m.Recipient
+	}
+	return ""
+}
+
+func (m *OCMShare) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *OCMShare) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *OCMShare) GetPermissions() uint32 {
+	if m != nil {
+		return m.Permissions
+	}
+	return 0
+}
+
+func (m *OCMShare) GetState() OCMShare_State {
+	if m != nil {
+		return m.State
+	}
+	return OCMShare_ACCEPTED
+}
+
+func (m *OCMShare) GetCtime() uint64 {
+	if m != nil {
+		return m.Ctime
+	}
+	return 0
+}
+
+func (m *OCMShare) GetProviderId() string {
+	if m != nil {
+		return m.ProviderId
+	}
+	return ""
+}
+
+func (m *OCMShare) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *OCMShare) GetWebdavUrl() string {
+	if m != nil {
+		return m.WebdavUrl
+	}
+	return ""
+}
+
+type NewOCMShareReq struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Recipient            string   `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Permissions          uint32   `protobuf:"varint,3,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewOCMShareReq) Reset()         { *m = NewOCMShareReq{} }
+func (m *NewOCMShareReq) String() string { return proto.CompactTextString(m) }
+func (*NewOCMShareReq) ProtoMessage()    {}
+func (*NewOCMShareReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{80}
+}
+
+func (m *NewOCMShareReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewOCMShareReq.Unmarshal(m, b)
+}
+func (m *NewOCMShareReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewOCMShareReq.Marshal(b, m, deterministic)
+}
+func (m *NewOCMShareReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewOCMShareReq.Merge(m, src)
+}
+func (m *NewOCMShareReq) XXX_Size() int {
+	return xxx_messageInfo_NewOCMShareReq.Size(m)
+}
+func (m *NewOCMShareReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewOCMShareReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewOCMShareReq proto.InternalMessageInfo
+
+func (m *NewOCMShareReq) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *NewOCMShareReq) GetRecipient() string {
+	if m != nil {
+		return m.Recipient
+	}
+	return ""
+}
+
+func (m *NewOCMShareReq) GetPermissions() uint32 {
+	if m != nil {
+		return m.Permissions
+	}
+	return 0
+}
+
+type OCMShareResponse struct {
+	Status               StatusCode `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
+	Share                *OCMShare  `protobuf:"bytes,2,opt,name=share,proto3" json:"share,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *OCMShareResponse) Reset()         { *m = OCMShareResponse{} }
+func (m *OCMShareResponse) String() string { return proto.CompactTextString(m) }
+func (*OCMShareResponse) ProtoMessage()    {}
+func (*OCMShareResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{81}
+}
+
+func (m *OCMShareResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OCMShareResponse.Unmarshal(m, b)
+}
+func (m *OCMShareResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OCMShareResponse.Marshal(b, m, deterministic)
+}
+func (m *OCMShareResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OCMShareResponse.Merge(m, src)
+}
+func (m *OCMShareResponse) XXX_Size() int {
+	return xxx_messageInfo_OCMShareResponse.Size(m)
+}
+func (m *OCMShareResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OCMShareResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OCMShareResponse proto.InternalMessageInfo
+
+func (m *OCMShareResponse) GetStatus() StatusCode {
+	if m != nil {
+		return m.Status
+	}
+	return StatusCode_OK
+}
+
+func (m *OCMShareResponse) GetShare() *OCMShare {
+	if m != nil {
+		return m.Share
+	}
+	return nil
+}
+
+type OCMShareIDReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OCMShareIDReq) Reset()         { *m = OCMShareIDReq{} }
+func (m *OCMShareIDReq) String() string { return proto.CompactTextString(m) }
+func (*OCMShareIDReq) ProtoMessage()    {}
+func (*OCMShareIDReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{82}
+}
+
+func (m *OCMShareIDReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OCMShareIDReq.Unmarshal(m, b)
+}
+func (m *OCMShareIDReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OCMShareIDReq.Marshal(b, m, deterministic)
+}
+func (m *OCMShareIDReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OCMShareIDReq.Merge(m, src)
+}
+func (m *OCMShareIDReq) XXX_Size() int {
+	return xxx_messageInfo_OCMShareIDReq.Size(m)
+}
+func (m *OCMShareIDReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_OCMShareIDReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OCMShareIDReq proto.InternalMessageInfo
+
+func (m *OCMShareIDReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type IncomingOCMShareReq struct {
+	ShareWith            string   `protobuf:"bytes,1,opt,name=share_with,json=shareWith,proto3" json:"share_with,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ProviderId           string   `protobuf:"bytes,3,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	Owner                string   `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	SharedSecret         string   `protobuf:"bytes,5,opt,name=shared_secret,json=sharedSecret,proto3" json:"shared_secret,omitempty"`
+	Permissions          uint32   `protobuf:"varint,6,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IncomingOCMShareReq) Reset()         { *m = IncomingOCMShareReq{} }
+func (m *IncomingOCMShareReq) String() string { return proto.CompactTextString(m) }
+func (*IncomingOCMShareReq) ProtoMessage()    {}
+func (*IncomingOCMShareReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{83}
+}
+
+func (m *IncomingOCMShareReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IncomingOCMShareReq.Unmarshal(m, b)
+}
+func (m *IncomingOCMShareReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IncomingOCMShareReq.Marshal(b, m, deterministic)
+}
+func (m *IncomingOCMShareReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IncomingOCMShareReq.Merge(m, src)
+}
+func (m *IncomingOCMShareReq) XXX_Size() int {
+	return xxx_messageInfo_IncomingOCMShareReq.Size(m)
+}
+func (m *IncomingOCMShareReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_IncomingOCMShareReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IncomingOCMShareReq proto.InternalMessageInfo
+
+func (m *IncomingOCMShareReq) GetShareWith() string {
+	if m != nil {
+		return m.ShareWith
+	}
+	return ""
+}
+
+func (m *IncomingOCMShareReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *IncomingOCMShareReq) GetProviderId() string {
+	if m != nil {
+		return m.ProviderId
+	}
+	return ""
+}
+
+func (m *IncomingOCMShareReq) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *IncomingOCMShareReq) GetSharedSecret() string {
+	if m != nil {
+		return m.SharedSecret
+	}
+	return ""
+}
+
+func (m *IncomingOCMShareReq) GetPermissions() uint32 {
+	if m != nil {
+		return m.Permissions
+	}
+	return 0
+}
+
+type OCMNotificationReq struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	ProviderId           string   `protobuf:"bytes,2,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	SharedSecret         string   `protobuf:"bytes,3,opt,name=shared_secret,json=sharedSecret,proto3" json:"shared_secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OCMNotificationReq) Reset()         { *m = OCMNotificationReq{} }
+func (m *OCMNotificationReq) String() string { return proto.CompactTextString(m) }
+func (*OCMNotificationReq) ProtoMessage()    {}
+func (*OCMNotificationReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{84}
+}
+
+func (m *OCMNotificationReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OCMNotificationReq.Unmarshal(m, b)
+}
+func (m *OCMNotificationReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OCMNotificationReq.Marshal(b, m, deterministic)
+}
+func (m *OCMNotificationReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OCMNotificationReq.Merge(m, src)
+}
+func (m *OCMNotificationReq) XXX_Size() int {
+	return xxx_messageInfo_OCMNotificationReq.Size(m)
+}
+func (m *OCMNotificationReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_OCMNotificationReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OCMNotificationReq proto.InternalMessageInfo
+
+func (m *OCMNotificationReq) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *OCMNotificationReq) GetProviderId() string {
+	if m != nil {
+		return m.ProviderId
+	}
+	return ""
+}
+
+func (m *OCMNotificationReq) GetSharedSecret() string {
+	if m != nil {
+		return m.SharedSecret
+	}
+	return ""
+}
+
+// ProjectSpace is a folder shared by the members of the groups of a project.
+type ProjectSpace struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Owner                string   `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	AdminGroup           string   `protobuf:"bytes,4,opt,name=admin_group,json=adminGroup,proto3" json:"admin_group,omitempty"`
+	WritersGroup         string   `protobuf:"bytes,5,opt,name=writers_group,json=writersGroup,proto3" json:"writers_group,omitempty"`
+	ReadersGroup         string   `protobuf:"bytes,6,opt,name=readers_group,json=readersGroup,proto3" json:"readers_group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProjectSpace) Reset()         { *m = ProjectSpace{} }
+func (m *ProjectSpace) String() string { return proto.CompactTextString(m) }
+func (*ProjectSpace) ProtoMessage()    {}
+func (*ProjectSpace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{85}
+}
+
+func (m *ProjectSpace) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProjectSpace.Unmarshal(m, b)
+}
+func (m *ProjectSpace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProjectSpace.Marshal(b, m, deterministic)
+}
+func (m *ProjectSpace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProjectSpace.Merge(m, src)
+}
+func (m *ProjectSpace) XXX_Size() int {
+	return xxx_messageInfo_ProjectSpace.Size(m)
+}
+func (m *ProjectSpace) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProjectSpace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProjectSpace proto.InternalMessageInfo
+
+func (m *ProjectSpace) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ProjectSpace) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ProjectSpace) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *ProjectSpace) GetAdminGroup() string {
+	if m != nil {
+		return m.AdminGroup
+	}
+	return ""
+}
+
+func (m *ProjectSpace) GetWritersGroup() string {
+	if m != nil {
+		return m.WritersGroup
+	}
+	return ""
+}
+
+func (m *ProjectSpace) GetReadersGroup() string {
+	if m != nil {
+		return m.ReadersGroup
+	}
+	return ""
+}
+
+type NewProjectReq struct {
+	Project              *ProjectSpace `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Quota                uint64        `protobuf:"varint,2,opt,name=quota,proto3" json:"quota,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *NewProjectReq) Reset()         { *m = NewProjectReq{} }
+func (m *NewProjectReq) String() string { return proto.CompactTextString(m) }
+func (*NewProjectReq) ProtoMessage()    {}
+func (*NewProjectReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{86}
+}
+
+func (m *NewProjectReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewProjectReq.Unmarshal(m, b)
+}
+func (m *NewProjectReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewProjectReq.Marshal(b, m, deterministic)
+}
+func (m *NewProjectReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewProjectReq.Merge(m, src)
+}
+func (m *NewProjectReq) XXX_Size() int {
+	return xxx_messageInfo_NewProjectReq.Size(m)
+}
+func (m *NewProjectReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewProjectReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewProjectReq proto.InternalMessageInfo
+
+func (m *NewProjectReq) GetProject() *ProjectSpace {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+func (m *NewProjectReq) GetQuota() uint64 {
+	if m != nil {
+		return m.Quota
+	}
+	return 0
+}
+
+// UpdateProjectReq changes the owner and the groups that are set,
+// and the quota if update_quota is set.
+type UpdateProjectReq struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Owner                string   `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	AdminGroup           string   `protobuf:"bytes,3,opt,name=admin_group,json=adminGroup,proto3" json:"admin_group,omitempty"`
+	WritersGroup         string   `protobuf:"bytes,4,opt,name=writers_group,json=writersGroup,proto3" json:"writers_group,omitempty"`
+	ReadersGroup         string   `protobuf:"bytes,5,opt,name=readers_group,json=readersGroup,proto3" json:"readers_group,omitempty"`
+	Quota                uint64   `protobuf:"varint,6,opt,name=quota,proto3" json:"quota,omitempty"`
+	UpdateQuota          bool     `protobuf:"varint,7,opt,name=update_quota,json=updateQuota,proto3" json:"update_quota,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateProjectReq) Reset()         { *m = UpdateProjectReq{} }
+func (m *UpdateProjectReq) String() string { return proto.CompactTextString(m) }
+func (*UpdateProjectReq) ProtoMessage()    {}
+func (*UpdateProjectReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{87}
+}
+
+func (m *UpdateProjectReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateProjectReq.Unmarshal(m, b)
+}
+func (m *UpdateProjectReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateProjectReq.Marshal(b, m, deterministic)
+}
+func (m *UpdateProjectReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateProjectReq.Merge(m, src)
+}
+func (m *UpdateProjectReq) XXX_Size() int {
+	return xxx_messageInfo_UpdateProjectReq.Size(m)
+}
+func (m *UpdateProjectReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateProjectReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateProjectReq proto.InternalMessageInfo
+
+func (m *UpdateProjectReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateProjectReq) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *UpdateProjectReq) GetAdminGroup() string {
+	if m != nil {
+		return m.AdminGroup
+	}
+	return ""
+}
+
+func (m *UpdateProjectReq) GetWritersGroup() string {
+	if m != nil {
+		return m.WritersGroup
+	}
+	return ""
+}
+
+func (m *UpdateProjectReq) GetReadersGroup() string {
+	if m != nil {
+		return m.ReadersGroup
+	}
+	return ""
+}
+
+func (m *UpdateProjectReq) GetQuota() uint64 {
+	if m != nil {
+		return m.Quota
+	}
+	return 0
+}
+
+func (m *UpdateProjectReq) GetUpdateQuota() bool {
+	if m != nil {
+		return m.UpdateQuota
+	}
+	return false
+}
+
+type DeleteProjectReq struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DeleteFiles          bool     `protobuf:"varint,2,opt,name=delete_files,json=deleteFiles,proto3" json:"delete_files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteProjectReq) Reset()         { *m = DeleteProjectReq{} }
+func (m *DeleteProjectReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteProjectReq) ProtoMessage()    {}
+func (*DeleteProjectReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{88}
+}
+
+func (m *DeleteProjectReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteProjectReq.Unmarshal(m, b)
+}
+func (m *DeleteProjectReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteProjectReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteProjectReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteProjectReq.Merge(m, src)
+}
+func (m *DeleteProjectReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteProjectReq.Size(m)
+}
+func (m *DeleteProjectReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteProjectReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteProjectReq proto.InternalMessageInfo
+
+func (m *DeleteProjectReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeleteProjectReq) GetDeleteFiles() bool {
+	if m != nil {
+		return m.DeleteFiles
+	}
+	return false
+}
+
+type ProjectReq struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProjectReq) Reset()         { *m = ProjectReq{} }
+func (m *ProjectReq) String() string { return proto.CompactTextString(m) }
+func (*ProjectReq) ProtoMessage()    {}
+func (*ProjectReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{89}
+}
+
+func (m *ProjectReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProjectReq.Unmarshal(m, b)
+}
+func (m *ProjectReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProjectReq.Marshal(b, m, deterministic)
+}
+func (m *ProjectReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProjectReq.Merge(m, src)
+}
+func (m *ProjectReq) XXX_Size() int {
+	return xxx_messageInfo_ProjectReq.Size(m)
+}
+func (m *ProjectReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProjectReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProjectReq proto.InternalMessageInfo
+
+func (m *ProjectReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ProjectResponse struct {
+	Status               StatusCode    `protobuf:"varint,1,opt,name=status,proto3,enum=api.StatusCode" json:"status,omitempty"`
+	Project              *ProjectSpace `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ProjectResponse) Reset()         { *m = ProjectResponse{} }
+func (m *ProjectResponse) String() string { return proto.CompactTextString(m) }
+func (*ProjectResponse) ProtoMessage()    {}
+func (*ProjectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{90}
+}
+
+func (m *ProjectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProjectResponse.Unmarshal(m, b)
+}
+func (m *ProjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProjectResponse.Marshal(b, m, deterministic)
+}
+func (m *ProjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProjectResponse.Merge(m, src)
+}
+func (m *ProjectResponse) XXX_Size() int {
+	return xxx_messageInfo_ProjectResponse.Size(m)
+}
+func (m *ProjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProjectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProjectResponse proto.InternalMessageInfo
+
+func (m *ProjectResponse) GetStatus() StatusCode {
+	if m != nil {
+		return m.Status
+	}
+	return StatusCode_OK
+}
+
+func (m *ProjectResponse) GetProject() *ProjectSpace {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("api.StatusCode", StatusCode_name, StatusCode_value)
+	proto.RegisterEnum("api.Tag_ItemType", Tag_ItemType_name, Tag_ItemType_value)
+	proto.RegisterEnum("api.PreviewReq_Mode", PreviewReq_Mode_name, PreviewReq_Mode_value)
+	proto.RegisterEnum("api.ShareRecipient_RecipientType", ShareRecipient_RecipientType_name, ShareRecipient_RecipientType_value)
+	proto.RegisterEnum("api.PublicLink_ItemType", PublicLink_ItemType_name, PublicLink_ItemType_value)
+	proto.RegisterEnum("api.FolderShare_State", FolderShare_State_name, FolderShare_State_value)
+	proto.RegisterEnum("api.FolderShare_ItemType", FolderShare_ItemType_name, FolderShare_ItemType_value)
+	proto.RegisterEnum("api.FsckEntry_Kind", FsckEntry_Kind_name, FsckEntry_Kind_value)
+	proto.RegisterEnum("api.FsckEntry_Problem", FsckEntry_Problem_name, FsckEntry_Problem_value)
+	proto.RegisterEnum("api.TransferProgress_Step", TransferProgress_Step_name, TransferProgress_Step_value)
+	proto.RegisterEnum("api.FileEvent_Type", FileEvent_Type_name, FileEvent_Type_value)
+	proto.RegisterEnum("api.OCMShare_State", OCMShare_State_name, OCMShare_State_value)
+	proto.RegisterType((*TagReq)(nil), "api.TagReq")
+	proto.RegisterType((*RenameTagReq)(nil), "api.RenameTagReq")
+	proto.RegisterType((*Tag)(nil), "api.Tag")
+	proto.RegisterType((*TagResponse)(nil), "api.TagResponse")
+	proto.RegisterType((*IsPublicLinkProtectedResponse)(nil), "api.IsPublicLinkProtectedResponse")
+	proto.RegisterType((*ForgePublicLinkTokenReq)(nil), "api.ForgePublicLinkTokenReq")
+	proto.RegisterType((*ForgePublicLinkTokenResponse)(nil), "api.ForgePublicLinkTokenResponse")
+	proto.RegisterType((*VerifyPublicLinkTokenReq)(nil), "api.VerifyPublicLinkTokenReq")
+	proto.RegisterType((*VerifyPublicLinkTokenResponse)(nil), "api.VerifyPublicLinkTokenResponse")
+	proto.RegisterType((*EmptyResponse)(nil), "api.EmptyResponse")
+	proto.RegisterType((*EmptyReq)(nil), "api.EmptyReq")
+	proto.RegisterType((*QuotaReq)(nil), "api.QuotaReq")
+	proto.RegisterType((*QuotaResponse)(nil), "api.QuotaResponse")
+	proto.RegisterType((*UserResponse)(nil), "api.UserResponse")
+	proto.RegisterType((*User)(nil), "api.User")
+	proto.RegisterType((*TxInfoResponse)(nil), "api.TxInfoResponse")
+	proto.RegisterType((*TxInfo)(nil), "api.TxInfo")
+	proto.RegisterType((*ForgeUserTokenReq)(nil), "api.ForgeUserTokenReq")
+	proto.RegisterType((*TokenResponse)(nil), "api.TokenResponse")
+	proto.RegisterType((*TokenReq)(nil), "api.TokenReq")
+	proto.RegisterType((*MetadataResponse)(nil), "api.MetadataResponse")
+	proto.RegisterType((*Metadata)(nil), "api.Metadata")
+	proto.RegisterType((*PathReq)(nil), "api.PathReq")
+	proto.RegisterType((*MoveReq)(nil), "api.MoveReq")
+	proto.RegisterType((*TxChunk)(nil), "api.TxChunk")
+	proto.RegisterType((*WriteSummaryResponse)(nil), "api.WriteSummaryResponse")
+	proto.RegisterType((*WriteSummary)(nil), "api.WriteSummary")
+	proto.RegisterType((*TxEnd)(nil), "api.TxEnd")
+	proto.RegisterType((*DataChunkResponse)(nil), "api.DataChunkResponse")
+	proto.RegisterType((*SearchReq)(nil), "api.SearchReq")
+	proto.RegisterType((*PreviewReq)(nil), "api.PreviewReq")
+	proto.RegisterType((*DataChunk)(nil), "api.DataChunk")
+	proto.RegisterType((*RevisionResponse)(nil), "api.RevisionResponse")
+	proto.RegisterType((*Revision)(nil), "api.Revision")
+	proto.RegisterType((*RevisionReq)(nil), "api.RevisionReq")
+	proto.RegisterType((*RecycleEntryResponse)(nil), "api.RecycleEntryResponse")
+	proto.RegisterType((*RecycleEntry)(nil), "api.RecycleEntry")
+	proto.RegisterType((*RecycleEntryReq)(nil), "api.RecycleEntryReq")
+	proto.RegisterType((*LinkPermissions)(nil), "api.LinkPermissions")
+	proto.RegisterType((*NewLinkReq)(nil), "api.NewLinkReq")
+	proto.RegisterType((*UpdateLinkReq)(nil), "api.UpdateLinkReq")
+	proto.RegisterType((*PublicLinkResponse)(nil), "api.PublicLinkResponse")
+	proto.RegisterType((*ShareRecipient)(nil), "api.ShareRecipient")
+	proto.RegisterType((*ACLReq)(nil), "api.ACLReq")
+	proto.RegisterType((*PublicLink)(nil), "api.PublicLink")
+	proto.RegisterType((*PublicLinkTokenReq)(nil), "api.PublicLinkTokenReq")
+	proto.RegisterType((*ShareIDReq)(nil), "api.ShareIDReq")
+	proto.RegisterType((*FolderShareResponse)(nil), "api.FolderShareResponse")
+	proto.RegisterType((*FolderShare)(nil), "api.FolderShare")
+	proto.RegisterType((*ReceivedShareResponse)(nil), "api.ReceivedShareResponse")
+	proto.RegisterType((*NewFolderShareReq)(nil), "api.NewFolderShareReq")
+	proto.RegisterType((*UpdateFolderShareReq)(nil), "api.UpdateFolderShareReq")
+	proto.RegisterType((*UnshareFolderReq)(nil), "api.UnshareFolderReq")
+	proto.RegisterType((*ListPublicLinksReq)(nil), "api.ListPublicLinksReq")
+	proto.RegisterType((*ListFolderSharesReq)(nil), "api.ListFolderSharesReq")
+	proto.RegisterType((*FsckSharesReq)(nil), "api.FsckSharesReq")
+	proto.RegisterType((*FsckEntry)(nil), "api.FsckEntry")
+	proto.RegisterType((*FsckSharesResponse)(nil), "api.FsckSharesResponse")
+	proto.RegisterType((*TransferOwnershipReq)(nil), "api.TransferOwnershipReq")
+	proto.RegisterType((*TransferProgress)(nil), "api.TransferProgress")
+	proto.RegisterType((*TransferOwnershipResponse)(nil), "api.TransferOwnershipResponse")
+	proto.RegisterType((*ReceivedShareReq)(nil), "api.ReceivedShareReq")
+	proto.RegisterType((*AppPassword)(nil), "api.AppPassword")
+	proto.RegisterType((*NewAppPasswordReq)(nil), "api.NewAppPasswordReq")
+	proto.RegisterType((*AppPasswordResponse)(nil), "api.AppPasswordResponse")
+	proto.RegisterType((*AppPasswordIDReq)(nil), "api.AppPasswordIDReq")
+	proto.RegisterType((*AuditEvent)(nil), "api.AuditEvent")
+	proto.RegisterType((*ListAuditEventsReq)(nil), "api.ListAuditEventsReq")
+	proto.RegisterType((*AuditEventResponse)(nil), "api.AuditEventResponse")
+	proto.RegisterType((*WatchReq)(nil), "api.WatchReq")
+	proto.RegisterType((*FileEvent)(nil), "api.FileEvent")
+	proto.RegisterType((*FileEventResponse)(nil), "api.FileEventResponse")
+	proto.RegisterType((*Webhook)(nil), "api.Webhook")
+	proto.RegisterType((*NewWebhookReq)(nil), "api.NewWebhookReq")
+	proto.RegisterType((*WebhookResponse)(nil), "api.WebhookResponse")
+	proto.RegisterType((*WebhookIDReq)(nil), "api.WebhookIDReq")
+	proto.RegisterType((*DeadLetter)(nil), "api.DeadLetter")
+	proto.RegisterType((*DeadLetterResponse)(nil), "api.DeadLetterResponse")
+	proto.RegisterType((*DeadLetterIDReq)(nil), "api.DeadLetterIDReq")
+	proto.RegisterType((*OCMShare)(nil), "api.OCMShare")
+	proto.RegisterType((*NewOCMShareReq)(nil), "api.NewOCMShareReq")
+	proto.RegisterType((*OCMShareResponse)(nil), "api.OCMShareResponse")
+	proto.RegisterType((*OCMShareIDReq)(nil), "api.OCMShareIDReq")
+	proto.RegisterType((*IncomingOCMShareReq)(nil), "api.IncomingOCMShareReq")
+	proto.RegisterType((*OCMNotificationReq)(nil), "api.OCMNotificationReq")
+	proto.RegisterType((*ProjectSpace)(nil), "api.ProjectSpace")
+	proto.RegisterType((*NewProjectReq)(nil), "api.NewProjectReq")
+	proto.RegisterType((*UpdateProjectReq)(nil), "api.UpdateProjectReq")
+	proto.RegisterType((*DeleteProjectReq)(nil), "api.DeleteProjectReq")
+	proto.RegisterType((*ProjectReq)(nil), "api.ProjectReq")
+	proto.RegisterType((*ProjectResponse)(nil), "api.ProjectResponse")
+}
+
+func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
+
+var fileDescriptor_00212fb1f9d3bf1c = []byte{
+	// 5473 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x3c, 0xcb, 0x6e, 0x23, 0x49,
+	0x72, 0xcd, 0x37, 0x19, 0x7c, 0x88, 0x4a, 0x49, 0xdd, 0x94, 0xfa, 0x5d, 0x3b, 0xf6, 0xf4, 0xbc,
+	0x34, 0x3d, 0x9a, 0x19, 0x7b, 0x66, 0x76, 0x1e, 0xcb, 0x11, 0xd9, 0x6a, 0x6e, 0x4b, 0x24, 0xb7,
+	0x48, 0xb5, 0x66, 0x0d, 0xd8, 0xe5, 0x6a, 0x56, 0x4a, 0xaa, 0x15, 0xc9, 0xaa, 0xae, 0x2a, 0xbd,
+	0xe6, 0xe8, 0x93, 0x01, 0x1f, 0x0c, 0x78, 0x61, 0xc0, 0x3e, 0xf8, 0xe8, 0x93, 0x01, 0xc3, 0x2f,
+	0xc0, 0xbb, 0xf6, 0xc5, 0x30, 0x0c, 0x1f, 0xfc, 0x0d, 0x86, 0xe1, 0x0f, 0xd8, 0x8b, 0x4f, 0xbe,
+	0x1a, 0x91, 0x99, 0x55, 0x95, 0x55, 0x2c, 0x52, 0x52, 0xc3, 0xd8, 0x93, 0x2a, 0x23, 0x23, 0x23,
+	0x23, 0x22, 0x23, 0x23, 0x22, 0x23, 0x93, 0x82, 0x92, 0x6e, 0x9b, 0x9b, 0xb6, 0x63, 0x79, 0x16,
+	0xc9, 0xe8, 0xb6, 0xa9, 0x1c, 0x43, 0x7e, 0xa8, 0x1f, 0xa9, 0xf4, 0x35, 0xb9, 0x03, 0x05, 0x4f,
+	0x3f, 0xd2, 0x4e, 0xe8, 0x65, 0x23, 0xf5, 0x28, 0xf5, 0xa4, 0xa4, 0xe6, 0x3d, 0xfd, 0xe8, 0x05,
+	0xbd, 0xf4, 0x3b, 0xce, 0xf4, 0x71, 0x23, 0x1d, 0x74, 0xbc, 0xd4, 0xc7, 0x84, 0x40, 0xd6, 0xd6,
+	0xbd, 0xe3, 0x46, 0x86, 0x41, 0xd9, 0x37, 0x69, 0x40, 0xc1, 0x76, 0xac, 0x9f, 0xd1, 0x91, 0xd7,
+	0xc8, 0x32, 0xb0, 0xdf, 0x54, 0x74, 0xa8, 0xa8, 0x74, 0xaa, 0x4f, 0xe8, 0x55, 0xf3, 0x3d, 0x80,
+	0xf2, 0x94, 0x9e, 0x6b, 0x7e, 0x27, 0x9f, 0xb3, 0x34, 0xa5, 0xe7, 0x43, 0xde, 0x2f, 0x4d, 0x91,
+	0x89, 0x4e, 0xf1, 0x87, 0x69, 0xc8, 0x0c, 0xf5, 0x23, 0x52, 0x83, 0xb4, 0x69, 0x30, 0xaa, 0x19,
+	0x35, 0x6d, 0x1a, 0x64, 0x13, 0x4a, 0xa6, 0x47, 0x27, 0x9a, 0x77, 0x69, 0x53, 0x46, 0xaf, 0xb6,
+	0xb5, 0xbc, 0x89, 0x8a, 0x18, 0xea, 0x47, 0x9b, 0x1d, 0x8f, 0x4e, 0x86, 0x97, 0x36, 0x55, 0x8b,
+	0xa6, 0xf8, 0x22, 0x75, 0xc8, 0x9c, 0x9a, 0x86, 0xa0, 0x8e, 0x9f, 0xe4, 0x2d, 0xa8, 0x1d, 0x9a,
+	0x63, 0xaa, 0x99, 0x86, 0x66, 0x3b, 0xf4, 0xd0, 0xbc, 0x10, 0xd2, 0x55, 0x10, 0xda, 0x31, 0xfa,
+	0x0c, 0x86, 0x22, 0x09, 0xac, 0x46, 0x8e, 0x8b, 0xc4, 0xbb, 0x65, 0x59, 0xf3, 0x11, 0x59, 0xef,
+	0x42, 0x49, 0xe8, 0xf6, 0x94, 0x36, 0x0a, 0xac, 0xab, 0xc8, 0xb5, 0x7b, 0x4a, 0x65, 0x41, 0x8b,
+	0x51, 0x41, 0x1f, 0x41, 0xd1, 0x67, 0x9b, 0x00, 0xe4, 0x9f, 0xf5, 0x76, 0x5b, 0x6d, 0xb5, 0x7e,
+	0x8b, 0x14, 0x21, 0xfb, 0xac, 0xb3, 0xdb, 0xae, 0xa7, 0x14, 0x15, 0xca, 0x4c, 0xcf, 0xae, 0x6d,
+	0x4d, 0x5d, 0x4a, 0xde, 0x86, 0xbc, 0xeb, 0xe9, 0xde, 0xa9, 0xcb, 0xb4, 0x52, 0xdb, 0x5a, 0x62,
+	0xe2, 0x0f, 0x18, 0x68, 0xdb, 0x32, 0xa8, 0x2a, 0xba, 0xc9, 0x06, 0x64, 0x3c, 0xfd, 0x88, 0x29,
+	0xa9, 0xbc, 0x55, 0xf4, 0x95, 0xa4, 0x22, 0x50, 0x39, 0x84, 0xfb, 0x1d, 0xb7, 0x7f, 0xfa, 0x6a,
+	0x6c, 0x8e, 0x76, 0xcd, 0xe9, 0x49, 0xdf, 0xb1, 0x3c, 0x3a, 0xf2, 0xa8, 0x71, 0xf3, 0x59, 0xee,
+	0x41, 0xc9, 0xf6, 0x47, 0xb3, 0xb9, 0x8a, 0x6a, 0x08, 0x50, 0x5e, 0xc0, 0x9d, 0x67, 0x96, 0x73,
+	0x44, 0xc3, 0xa9, 0x86, 0xd6, 0x09, 0x9d, 0xa2, 0xd1, 0xac, 0x42, 0xce, 0xc3, 0x6f, 0x61, 0x32,
+	0xbc, 0x41, 0x36, 0xa0, 0x68, 0xeb, 0xae, 0x7b, 0x6e, 0x39, 0x86, 0x30, 0x97, 0xa0, 0xad, 0xfc,
+	0x2e, 0xdc, 0x4b, 0x26, 0x76, 0x53, 0x9e, 0x57, 0x21, 0x77, 0xa6, 0x8f, 0x4d, 0x9f, 0x5f, 0xde,
+	0x50, 0x9e, 0x42, 0xe3, 0x25, 0x75, 0xcc, 0xc3, 0xcb, 0xeb, 0x32, 0xab, 0x7c, 0x0f, 0xf7, 0xe7,
+	0x8c, 0xb8, 0x29, 0x47, 0x4f, 0xa1, 0x6c, 0x33, 0x1a, 0xda, 0xd8, 0x9c, 0x9e, 0x88, 0x35, 0xe3,
+	0xd8, 0x21, 0x6d, 0x15, 0xec, 0xe0, 0x5b, 0xf9, 0x0c, 0xaa, 0xed, 0x89, 0xed, 0x5d, 0xde, 0x78,
+	0x2e, 0x05, 0xa0, 0x28, 0x46, 0xbe, 0x56, 0x1e, 0x40, 0xf1, 0x27, 0xa7, 0x96, 0xa7, 0xa3, 0x8c,
+	0xbe, 0x0f, 0x48, 0x85, 0x3e, 0x40, 0xb9, 0x80, 0xaa, 0xe8, 0xbf, 0xa9, 0x44, 0x0f, 0xa1, 0xec,
+	0x59, 0x9e, 0x3e, 0xd6, 0x5e, 0x5d, 0x7a, 0xd4, 0x65, 0x12, 0x65, 0x54, 0x60, 0xa0, 0x6f, 0x11,
+	0x42, 0xee, 0x03, 0x9c, 0xba, 0xd4, 0x10, 0xfd, 0x19, 0xd6, 0x5f, 0x42, 0x08, 0xeb, 0x56, 0x5e,
+	0x42, 0x65, 0xdf, 0xa5, 0xce, 0xcd, 0x27, 0xbe, 0x0f, 0xd9, 0x53, 0x97, 0x3a, 0x42, 0x87, 0x25,
+	0x86, 0xc6, 0x28, 0x31, 0xb0, 0xf2, 0xab, 0x14, 0x64, 0xb1, 0x89, 0xf3, 0xeb, 0xa3, 0x91, 0x75,
+	0x3a, 0xf5, 0x34, 0xe1, 0x61, 0x4a, 0x6a, 0x49, 0x40, 0x3a, 0x06, 0xb9, 0x0d, 0xf9, 0x23, 0xc7,
+	0x3a, 0xb5, 0x91, 0xf5, 0x0c, 0x6e, 0x73, 0xde, 0x22, 0x8f, 0xa1, 0x62, 0x98, 0xae, 0x3d, 0xd6,
+	0x2f, 0x35, 0xf4, 0x80, 0xc2, 0xb3, 0x94, 0x05, 0xac, 0xab, 0x4f, 0x28, 0x7a, 0x02, 0x87, 0xea,
+	0x86, 0x66, 0x4d, 0xc7, 0x97, 0xcc, 0xb9, 0x14, 0xd5, 0x22, 0x02, 0x7a, 0xd3, 0xf1, 0x25, 0x79,
+	0x1b, 0x96, 0x1c, 0xea, 0x7a, 0x8e, 0x89, 0xfb, 0x43, 0x63, 0x0a, 0xe7, 0x0e, 0xa6, 0x16, 0x82,
+	0xfb, 0xe8, 0x7e, 0x1f, 0x43, 0x45, 0xb7, 0x6d, 0x2d, 0xd8, 0x0d, 0x79, 0x46, 0xa8, 0xac, 0xdb,
+	0x76, 0x5f, 0x80, 0xae, 0x81, 0xa2, 0xfc, 0x1e, 0xd4, 0x86, 0x17, 0x9d, 0xe9, 0xa1, 0x75, 0x73,
+	0x45, 0xfe, 0x00, 0xf2, 0x1e, 0x1b, 0x2a, 0x54, 0x59, 0xe6, 0x2e, 0x84, 0x53, 0x13, 0x5d, 0xca,
+	0x7d, 0xc8, 0x73, 0x08, 0x59, 0x81, 0x9c, 0x77, 0x11, 0xaa, 0x32, 0xeb, 0x5d, 0x74, 0x0c, 0x65,
+	0x1f, 0x96, 0xd9, 0x96, 0x45, 0x8d, 0x07, 0x9b, 0xe9, 0x2e, 0x94, 0x46, 0x63, 0x93, 0xca, 0x8a,
+	0x2f, 0x72, 0x40, 0xc7, 0x20, 0x3f, 0x80, 0xaa, 0xe8, 0x74, 0xe9, 0xc8, 0xa1, 0x9e, 0xf0, 0x02,
+	0x15, 0x0e, 0x1c, 0x30, 0x98, 0x62, 0x41, 0xf5, 0xcd, 0xb7, 0x3e, 0xdf, 0xc8, 0x69, 0xd9, 0xeb,
+	0x3c, 0x84, 0xb2, 0x43, 0x3d, 0xe7, 0x52, 0xd3, 0x0f, 0x3d, 0xea, 0xb0, 0x35, 0xcd, 0xaa, 0xc0,
+	0x40, 0x4d, 0x84, 0xa0, 0x97, 0xbe, 0xc2, 0x17, 0x1c, 0x42, 0x7d, 0x8f, 0x7a, 0xba, 0xa1, 0xbf,
+	0xc9, 0x66, 0x79, 0x07, 0x8a, 0x13, 0x31, 0x58, 0x28, 0xbb, 0xca, 0x50, 0x03, 0x8a, 0x41, 0xb7,
+	0xf2, 0xe7, 0x39, 0x28, 0xfa, 0x60, 0x29, 0x3a, 0x96, 0x58, 0x74, 0xf4, 0xb7, 0x70, 0x5a, 0x0a,
+	0xe3, 0x04, 0xb2, 0xae, 0xf9, 0x3d, 0x15, 0x42, 0xb1, 0x6f, 0x14, 0x61, 0xe2, 0x99, 0x13, 0xca,
+	0xac, 0x33, 0xab, 0xf2, 0x06, 0x59, 0x83, 0xbc, 0xe9, 0x6a, 0x86, 0xe9, 0x30, 0x8b, 0x2c, 0xaa,
+	0x39, 0xd3, 0x6d, 0x99, 0x0e, 0x12, 0xa0, 0x18, 0x48, 0x78, 0xb8, 0x63, 0xdf, 0xe8, 0xa6, 0x47,
+	0xc7, 0x74, 0x74, 0xe2, 0x9e, 0x4e, 0xfc, 0x58, 0xe7, 0xb7, 0x71, 0x63, 0x19, 0xd4, 0xa1, 0x87,
+	0xdc, 0xb8, 0x79, 0xb8, 0x2b, 0x31, 0x08, 0xb3, 0xeb, 0x47, 0x50, 0x31, 0x5d, 0x2d, 0xdc, 0x20,
+	0x25, 0x36, 0x17, 0x98, 0xae, 0xea, 0x6f, 0x91, 0xc7, 0x0c, 0xc3, 0x3d, 0xd6, 0x1d, 0xaa, 0xbf,
+	0x1a, 0xd3, 0x06, 0x70, 0xb3, 0x36, 0xdd, 0x81, 0x0f, 0x42, 0x9e, 0x26, 0xc8, 0x7f, 0x99, 0xf3,
+	0x84, 0xdf, 0x18, 0xea, 0xdd, 0x4b, 0xb7, 0x51, 0x79, 0x94, 0x7a, 0x52, 0x51, 0xf1, 0x13, 0x39,
+	0xf1, 0x1c, 0x4a, 0x35, 0xb6, 0xa7, 0x1b, 0x55, 0x26, 0x6b, 0x09, 0x21, 0xdb, 0x08, 0x20, 0xeb,
+	0x50, 0xa4, 0x96, 0xab, 0x61, 0x60, 0x6f, 0xd4, 0x78, 0x54, 0xa6, 0x96, 0xfb, 0xcc, 0x1c, 0x53,
+	0x64, 0x01, 0xbb, 0xcc, 0xa9, 0xeb, 0xe9, 0xd3, 0x11, 0x6d, 0x2c, 0xf1, 0x5d, 0x4e, 0x2d, 0xb7,
+	0x23, 0x40, 0xe4, 0x5d, 0x58, 0x3e, 0xa3, 0x8e, 0x6b, 0x5a, 0x53, 0xed, 0xd0, 0x1a, 0x1b, 0xd4,
+	0x41, 0x6b, 0xbe, 0xc3, 0xf0, 0x96, 0x44, 0xc7, 0x33, 0x06, 0xef, 0xb0, 0x8d, 0xca, 0xc4, 0xd1,
+	0x3c, 0xdd, 0x39, 0xa2, 0x5e, 0xa3, 0xce, 0xc9, 0x31, 0xd8, 0x90, 0x81, 0x90, 0x19, 0x8e, 0x62,
+	0x1a, 0x8d, 0x15, 0xce, 0x0c, 0x6b, 0x77, 0x58, 0xc6, 0xc2, 0xbb, 0xac, 0xf3, 0x29, 0x9f, 0x66,
+	0x95, 0xef, 0x09, 0x06, 0xed, 0x21, 0xb0, 0x63, 0x10, 0x05, 0xaa, 0x1c, 0xcb, 0xcf, 0x5b, 0xd6,
+	0xa4, 0x49, 0x9e, 0xf1, 0xe4, 0xe5, 0x3d, 0x58, 0xe6, 0x38, 0x36, 0x75, 0x26, 0xa6, 0x8b, 0x3c,
+	0xba, 0x8d, 0xdb, 0x8f, 0x52, 0x4f, 0xaa, 0x6a, 0x9d, 0x75, 0xf4, 0x43, 0x38, 0x9a, 0xc3, 0xc4,
+	0x3c, 0x42, 0x4a, 0xcb, 0xdc, 0xd0, 0x27, 0xe6, 0x51, 0xc7, 0x40, 0x46, 0x11, 0xcc, 0x16, 0x97,
+	0x70, 0x46, 0x27, 0xe6, 0x11, 0x2e, 0xad, 0x72, 0x1f, 0x0a, 0xf8, 0x77, 0x5e, 0x30, 0xf9, 0x06,
+	0x0a, 0x7b, 0xd6, 0x19, 0xc5, 0xee, 0x75, 0x28, 0x5a, 0x63, 0x43, 0x93, 0x50, 0x0a, 0xd6, 0x98,
+	0xfb, 0xbd, 0x75, 0x28, 0x62, 0xce, 0x28, 0xd9, 0x71, 0x61, 0x4a, 0xcf, 0x19, 0xfd, 0x57, 0x50,
+	0x18, 0x5e, 0x6c, 0x1f, 0x9f, 0x4e, 0x4f, 0x12, 0xbd, 0x0d, 0xfa, 0xec, 0x31, 0x9d, 0x1e, 0x89,
+	0x81, 0x59, 0x55, 0xb4, 0x10, 0x6e, 0x1d, 0x1e, 0xba, 0xd4, 0x13, 0x9b, 0x40, 0xb4, 0x90, 0x49,
+	0xb6, 0xe5, 0xb2, 0xcc, 0x64, 0xd8, 0xb7, 0x72, 0x06, 0xab, 0x07, 0x8e, 0xe9, 0xd1, 0xc1, 0xe9,
+	0x64, 0xa2, 0x3b, 0x37, 0x0f, 0xaf, 0xe4, 0x53, 0xa8, 0x9c, 0x4b, 0x04, 0xc4, 0x7e, 0xe6, 0x49,
+	0x6a, 0x84, 0x72, 0x04, 0x4d, 0xd9, 0x81, 0x8a, 0xdc, 0x8b, 0x19, 0xe3, 0x74, 0x84, 0xa2, 0xf2,
+	0x09, 0xb3, 0xaa, 0xdf, 0x64, 0x56, 0xcd, 0x22, 0x2b, 0xdb, 0xd6, 0x69, 0x61, 0xd5, 0x08, 0x19,
+	0x98, 0xdf, 0x53, 0x65, 0x17, 0x72, 0xc3, 0x8b, 0xf6, 0xd4, 0x48, 0x56, 0x51, 0x92, 0x87, 0x90,
+	0x37, 0x73, 0x26, 0xba, 0x99, 0x95, 0x9f, 0xc1, 0x72, 0x4b, 0xf7, 0x74, 0xa6, 0xf4, 0x9b, 0xeb,
+	0xe2, 0x7d, 0x28, 0x19, 0xfe, 0x68, 0xa1, 0x88, 0x1a, 0xc3, 0x0d, 0x69, 0x86, 0x08, 0xca, 0xff,
+	0xa6, 0xa0, 0x34, 0xa0, 0xba, 0x33, 0x9a, 0x67, 0x41, 0xe8, 0xb7, 0x5e, 0x9f, 0x52, 0xc7, 0x3f,
+	0x49, 0xf0, 0x06, 0x62, 0x4a, 0xa1, 0x98, 0x7d, 0x07, 0x0e, 0x22, 0x2b, 0x39, 0x08, 0x66, 0xb9,
+	0x53, 0xae, 0xb6, 0x1c, 0xd7, 0xe9, 0xc4, 0x9c, 0xa2, 0xd2, 0x58, 0x97, 0x7e, 0xc1, 0xbb, 0xf2,
+	0xa2, 0x4b, 0xbf, 0x60, 0x5d, 0x77, 0xa1, 0x84, 0xa3, 0xb8, 0xbf, 0x2c, 0xb0, 0x3e, 0x24, 0xb3,
+	0x87, 0x6d, 0xd6, 0xa9, 0x5f, 0x88, 0xce, 0xa2, 0xe8, 0xd4, 0x2f, 0x78, 0x27, 0x81, 0xac, 0xa7,
+	0x1f, 0xb9, 0x8d, 0x12, 0x4b, 0x20, 0xd8, 0x37, 0x4a, 0x30, 0x36, 0x27, 0xa6, 0xc7, 0x9c, 0x5a,
+	0x56, 0xe5, 0x0d, 0xe5, 0xe7, 0x29, 0x80, 0xbe, 0x43, 0xcf, 0x4c, 0x7a, 0xbe, 0x40, 0xf4, 0x73,
+	0xd3, 0x08, 0x4c, 0x9b, 0x37, 0xd0, 0xb2, 0x8f, 0xa9, 0x79, 0x74, 0x1c, 0x58, 0x36, 0x6f, 0x91,
+	0x27, 0x90, 0x9d, 0x58, 0x06, 0x17, 0xbf, 0xb6, 0xb5, 0xca, 0x13, 0xc9, 0x60, 0x82, 0xcd, 0x3d,
+	0x5c, 0x24, 0x86, 0xa1, 0xac, 0x43, 0x16, 0x5b, 0x78, 0xde, 0xd8, 0x56, 0x7b, 0xfd, 0xfa, 0x2d,
+	0x52, 0x80, 0xcc, 0xb3, 0xce, 0xb0, 0x9e, 0x52, 0x7a, 0x50, 0x0a, 0xd6, 0x49, 0xda, 0x5b, 0xa9,
+	0x39, 0x7b, 0x2b, 0x9d, 0xb8, 0xb7, 0x32, 0xd2, 0xde, 0x3a, 0x84, 0xba, 0x4a, 0xcf, 0x4c, 0x74,
+	0x2f, 0x6f, 0x14, 0x23, 0x1d, 0x31, 0x38, 0x12, 0x23, 0x03, 0x8a, 0x41, 0xb7, 0x62, 0x40, 0xd1,
+	0x87, 0xe2, 0x79, 0xcd, 0xa1, 0x67, 0xf2, 0xd9, 0xd4, 0xa1, 0x67, 0x78, 0x5e, 0xf3, 0xe3, 0x62,
+	0x3a, 0x29, 0x2e, 0x66, 0x92, 0xe3, 0x62, 0x56, 0x8a, 0x8b, 0xca, 0x17, 0x50, 0x0e, 0xa5, 0x49,
+	0x5e, 0x34, 0x69, 0xf2, 0xb4, 0x3c, 0x39, 0x7a, 0x19, 0x95, 0x8e, 0x2e, 0x47, 0x63, 0xda, 0x9e,
+	0x7a, 0x6f, 0xe8, 0x65, 0x1c, 0x89, 0x40, 0xc4, 0xcb, 0x44, 0x28, 0x47, 0xd0, 0x94, 0xbf, 0x4f,
+	0xe1, 0xd1, 0x3d, 0x04, 0x60, 0x64, 0xc2, 0xbc, 0xd3, 0xc2, 0x98, 0x10, 0x72, 0x5f, 0x16, 0x30,
+	0xe6, 0x90, 0x1f, 0x82, 0xdf, 0x94, 0x04, 0x01, 0x01, 0x92, 0x35, 0x29, 0x67, 0x18, 0x77, 0xa1,
+	0x64, 0xd0, 0xb1, 0x26, 0x67, 0x19, 0x45, 0x83, 0x8e, 0xf7, 0x16, 0x25, 0x1a, 0xd2, 0x99, 0x3b,
+	0x2f, 0x9f, 0xb9, 0x95, 0x2d, 0x58, 0x8a, 0x6a, 0xeb, 0x75, 0x9c, 0xa9, 0x54, 0x9c, 0x29, 0xe5,
+	0x87, 0xb0, 0xc4, 0x4e, 0xb6, 0x52, 0x40, 0x23, 0x90, 0xc5, 0xb4, 0x83, 0x21, 0x17, 0x55, 0xf6,
+	0xcd, 0xb6, 0x15, 0xba, 0x5d, 0xff, 0x28, 0xc8, 0x1a, 0xca, 0x7f, 0xa6, 0x00, 0xba, 0xf4, 0x1c,
+	0x09, 0xcc, 0x5b, 0xda, 0x48, 0x92, 0x9f, 0x8e, 0x25, 0xf9, 0xf2, 0x29, 0x36, 0x13, 0x3d, 0xc5,
+	0xa2, 0x63, 0xa7, 0x17, 0xb6, 0xe9, 0x50, 0x57, 0xe8, 0xc5, 0x6f, 0x32, 0x9d, 0x39, 0x96, 0xcd,
+	0x49, 0x72, 0xcd, 0x14, 0x11, 0xc0, 0x48, 0xfe, 0x06, 0xd4, 0xa6, 0x96, 0x67, 0x1e, 0x5e, 0x6a,
+	0xa7, 0xf6, 0xd8, 0xd2, 0x0d, 0x57, 0x64, 0xfb, 0x55, 0x0e, 0xdd, 0xe7, 0x40, 0x4c, 0x9f, 0xd1,
+	0x21, 0x19, 0xd6, 0xf9, 0x94, 0x63, 0x71, 0x8f, 0x55, 0x99, 0xe8, 0x17, 0x2d, 0x1f, 0xa6, 0xfc,
+	0x4f, 0x06, 0xaa, 0xfb, 0xb6, 0xa1, 0x7b, 0xd4, 0x97, 0x30, 0x9e, 0x48, 0xbe, 0x0d, 0x4b, 0xa7,
+	0x0c, 0x41, 0x8b, 0x9c, 0xc6, 0x8b, 0x6a, 0x8d, 0x83, 0x83, 0x23, 0xc8, 0x22, 0x49, 0xdf, 0x83,
+	0x65, 0x41, 0x84, 0x49, 0xa8, 0x7b, 0xb8, 0x75, 0xf9, 0x16, 0xaa, 0xf3, 0x8e, 0x76, 0x00, 0x27,
+	0x0f, 0x00, 0x24, 0x2c, 0xee, 0x9e, 0x25, 0x48, 0x54, 0xdf, 0xf9, 0x98, 0xbe, 0x9f, 0x80, 0x20,
+	0x28, 0xe5, 0x95, 0x05, 0x99, 0xdf, 0x20, 0xb7, 0x8c, 0xe8, 0xb8, 0x18, 0xd3, 0x71, 0x48, 0x26,
+	0xc4, 0x29, 0xc9, 0x64, 0x5a, 0xf3, 0x57, 0x03, 0x92, 0x56, 0x63, 0x0b, 0xd6, 0x04, 0xc1, 0x18,
+	0x76, 0x99, 0x61, 0xaf, 0xf0, 0xce, 0xee, 0xe2, 0x15, 0xac, 0xcc, 0xae, 0x20, 0x79, 0x0a, 0xab,
+	0x82, 0x70, 0x14, 0xb7, 0xca, 0xe8, 0x12, 0xde, 0xb7, 0x27, 0xaf, 0xf9, 0x14, 0x88, 0x54, 0x49,
+	0xb8, 0xb1, 0xbf, 0xf9, 0x10, 0xa4, 0xe2, 0xc3, 0x75, 0xea, 0x13, 0x3f, 0x4f, 0x41, 0x8d, 0xe5,
+	0xeb, 0x2a, 0x1d, 0x99, 0x36, 0x9e, 0xdd, 0xd0, 0x56, 0x4c, 0x83, 0x4e, 0x3d, 0xd3, 0xf3, 0x37,
+	0x6c, 0xd0, 0x26, 0x9f, 0x42, 0x56, 0x2a, 0xe9, 0x3d, 0xe6, 0x6c, 0x44, 0x86, 0x6f, 0x06, 0x5f,
+	0xac, 0xc4, 0xc7, 0xd0, 0x95, 0x4d, 0xa8, 0x46, 0xc0, 0x18, 0xc6, 0xf6, 0x07, 0xac, 0x80, 0x56,
+	0x82, 0xdc, 0x8e, 0xda, 0xdb, 0xef, 0xd7, 0x53, 0x0c, 0xd8, 0xed, 0x7c, 0x57, 0x4f, 0x2b, 0xbf,
+	0x48, 0x41, 0xbe, 0xb9, 0xbd, 0x3b, 0x6f, 0x53, 0x7f, 0x84, 0x46, 0x26, 0xc8, 0x09, 0x21, 0x57,
+	0x12, 0x58, 0x51, 0x43, 0xac, 0xa8, 0x5d, 0x66, 0x66, 0xec, 0x32, 0xcf, 0xd2, 0x6a, 0xdc, 0xea,
+	0x99, 0x27, 0xe5, 0xad, 0x3a, 0x23, 0xc6, 0x8f, 0x05, 0x9c, 0xa4, 0xe8, 0x27, 0x8f, 0xa0, 0x2c,
+	0xe7, 0xe4, 0x39, 0x96, 0x93, 0xcb, 0x20, 0xe5, 0xdf, 0xb2, 0x00, 0xa1, 0xae, 0x67, 0x76, 0x6c,
+	0xf2, 0xc1, 0x36, 0xa9, 0xae, 0x1b, 0xa9, 0xd8, 0x65, 0x63, 0x15, 0x3b, 0xd9, 0x3d, 0xe5, 0x66,
+	0xdc, 0xd3, 0xfc, 0x1d, 0x18, 0x44, 0xce, 0x82, 0x1c, 0x39, 0x3f, 0x95, 0xab, 0xb5, 0x45, 0xb6,
+	0xb4, 0x8d, 0x98, 0xd1, 0x24, 0x15, 0x6d, 0xf1, 0x74, 0xe0, 0x1f, 0x75, 0x4a, 0xe2, 0x74, 0x20,
+	0x4e, 0x39, 0x7e, 0xae, 0x07, 0x52, 0xae, 0x17, 0xd9, 0xd3, 0xe5, 0x2b, 0xfd, 0x66, 0xe5, 0x5a,
+	0x7e, 0xb3, 0x9a, 0xb0, 0xeb, 0xee, 0x41, 0x29, 0x44, 0xa8, 0x31, 0x84, 0x10, 0xc0, 0xaa, 0x8a,
+	0x26, 0x3d, 0x77, 0xd9, 0x61, 0x31, 0xab, 0xf2, 0x06, 0x06, 0xaa, 0xb1, 0xee, 0x7a, 0x9a, 0x3e,
+	0x1a, 0x51, 0xd7, 0x65, 0x27, 0xbf, 0xac, 0x0a, 0x08, 0x6a, 0x32, 0x08, 0xf9, 0x00, 0x56, 0x24,
+	0x04, 0xcd, 0xb4, 0xb5, 0x63, 0xdd, 0x3d, 0x16, 0x67, 0xae, 0x7a, 0x88, 0xd8, 0xb1, 0x9f, 0xeb,
+	0x2e, 0x5b, 0x3d, 0xf7, 0xd4, 0xb5, 0xe9, 0xd4, 0xa0, 0x06, 0x3b, 0x7f, 0x15, 0xd5, 0x10, 0x10,
+	0xa9, 0x26, 0xfb, 0x15, 0xe4, 0x5b, 0x52, 0x5d, 0x39, 0xa5, 0xbc, 0x2b, 0xfb, 0x81, 0x2b, 0x6a,
+	0x1a, 0xf7, 0x00, 0x98, 0x95, 0x76, 0x5a, 0x09, 0x31, 0x42, 0x71, 0x60, 0x45, 0xb6, 0xe4, 0x1b,
+	0xbb, 0x94, 0x2d, 0x28, 0x1f, 0x86, 0xe3, 0xc5, 0x76, 0x9b, 0xdd, 0x21, 0x32, 0x92, 0xf2, 0x4f,
+	0x59, 0x28, 0x4b, 0x9d, 0xd7, 0x2a, 0x80, 0xc8, 0xd6, 0x94, 0x89, 0x5a, 0x53, 0x64, 0xbf, 0x67,
+	0x6f, 0xbe, 0xdf, 0x73, 0xb3, 0xbb, 0x60, 0xc4, 0x76, 0x01, 0x3f, 0x43, 0xf0, 0xc6, 0x9c, 0xbd,
+	0x71, 0x1b, 0xf2, 0xa2, 0x1a, 0x50, 0xf4, 0xef, 0x11, 0xb0, 0x45, 0xde, 0x87, 0x1c, 0x2a, 0x88,
+	0x32, 0xcb, 0xaf, 0x6d, 0xdd, 0x8e, 0x2b, 0x84, 0xa9, 0x92, 0xaa, 0x1c, 0x89, 0xfc, 0x96, 0xbc,
+	0xc3, 0x80, 0x8d, 0x58, 0x9f, 0x19, 0x91, 0xb0, 0xc5, 0xa2, 0xe1, 0xb6, 0x3c, 0x13, 0x6e, 0x63,
+	0xfe, 0xa8, 0x32, 0xe3, 0x8f, 0x50, 0x11, 0xb6, 0xee, 0x88, 0x2a, 0x5e, 0xd5, 0x0f, 0xfd, 0x0e,
+	0xaf, 0xe2, 0x61, 0x09, 0x67, 0x6a, 0x7a, 0xa6, 0xee, 0x59, 0x4c, 0xef, 0xbc, 0xbc, 0x52, 0x0e,
+	0x60, 0x1d, 0x23, 0x6a, 0xc8, 0x4b, 0x71, 0x43, 0x7e, 0x0a, 0x39, 0x26, 0x27, 0xa9, 0x40, 0xb1,
+	0xb9, 0xbd, 0xdd, 0xee, 0x0f, 0xdb, 0xad, 0xfa, 0x2d, 0x52, 0x86, 0x42, 0xbf, 0xdd, 0x6d, 0x75,
+	0xba, 0x3b, 0xf5, 0x14, 0x76, 0xa9, 0xed, 0x1f, 0xb7, 0xb7, 0xb1, 0x2b, 0x7d, 0x8d, 0x8b, 0x94,
+	0x63, 0x58, 0x53, 0xe9, 0x88, 0x9a, 0x67, 0xd4, 0x78, 0x43, 0x93, 0xfd, 0x4d, 0xc8, 0xb9, 0x0b,
+	0x8d, 0x95, 0x77, 0x2b, 0xbf, 0x4c, 0xc1, 0x72, 0x97, 0x9e, 0xcb, 0x3d, 0xbf, 0xa6, 0x88, 0x13,
+	0x5d, 0xd7, 0xec, 0x55, 0xeb, 0x9a, 0x10, 0x67, 0xfe, 0x28, 0x0d, 0xab, 0x3c, 0x39, 0x8c, 0xb1,
+	0x1f, 0xdf, 0x6b, 0x49, 0x49, 0x57, 0x7a, 0x5e, 0xd2, 0x35, 0x9f, 0xe3, 0xff, 0xd7, 0x2c, 0xf1,
+	0x03, 0x10, 0xb9, 0x4f, 0xa4, 0xc2, 0xc5, 0x83, 0x95, 0x98, 0x46, 0x3e, 0x11, 0xc4, 0xb4, 0x51,
+	0x98, 0xd5, 0x86, 0x02, 0xf5, 0xfd, 0x29, 0x2f, 0xa1, 0x31, 0x6d, 0x24, 0x39, 0xc2, 0x27, 0x40,
+	0x76, 0x4d, 0xd7, 0x0b, 0xdd, 0xaa, 0x3b, 0xaf, 0x02, 0xf6, 0x0e, 0xac, 0x20, 0xa6, 0xa4, 0xd8,
+	0xb9, 0xa8, 0x7d, 0xa8, 0x3e, 0x73, 0x47, 0x27, 0x21, 0xd2, 0x6d, 0xc8, 0x3b, 0xd4, 0xd6, 0x4d,
+	0x47, 0x9c, 0x5f, 0x44, 0x0b, 0x53, 0x75, 0xfb, 0xd4, 0x39, 0xa2, 0x5a, 0xb8, 0x9b, 0xc4, 0x2a,
+	0x30, 0xf0, 0x20, 0xd8, 0x52, 0x7f, 0x99, 0x86, 0x12, 0x92, 0xe4, 0x07, 0xbf, 0xb7, 0x21, 0x7b,
+	0x62, 0x4e, 0x0d, 0x61, 0xf1, 0xdc, 0xe6, 0x82, 0xde, 0xcd, 0x17, 0xe6, 0xd4, 0x50, 0x19, 0x82,
+	0x90, 0x36, 0x1d, 0x2c, 0xfb, 0x02, 0x77, 0x2a, 0x1d, 0xe0, 0xb2, 0x91, 0x4b, 0xd3, 0xa7, 0xec,
+	0xfa, 0xf3, 0xd5, 0x98, 0x4e, 0x1a, 0x39, 0xd9, 0xab, 0x05, 0xf3, 0xf5, 0x79, 0xaf, 0xea, 0xa3,
+	0x61, 0xae, 0xc8, 0xe5, 0xa3, 0x46, 0x98, 0x6b, 0xf0, 0xb6, 0xf2, 0x0e, 0x64, 0x91, 0x3f, 0x52,
+	0x87, 0x0a, 0xdf, 0xe5, 0xda, 0xe0, 0x79, 0x53, 0xc5, 0x40, 0xb7, 0x04, 0xe5, 0xfe, 0xfe, 0xb7,
+	0xbb, 0x9d, 0x6d, 0x6d, 0xb7, 0xd3, 0x7d, 0x51, 0x4f, 0x29, 0x5b, 0x50, 0x10, 0xa4, 0xd1, 0x27,
+	0xf4, 0xd4, 0xfe, 0xf3, 0x66, 0xb7, 0x7e, 0x8b, 0x7b, 0x8e, 0xc1, 0xb0, 0xa7, 0xb6, 0x5b, 0xf5,
+	0x14, 0xa9, 0x42, 0x69, 0xb0, 0x3f, 0x40, 0xbf, 0xc2, 0x1c, 0xc9, 0x08, 0x88, 0xac, 0xf9, 0x9b,
+	0xfa, 0x88, 0xb7, 0x20, 0x47, 0xa5, 0x23, 0x79, 0x2d, 0x2a, 0xa9, 0xca, 0x3b, 0x95, 0x3f, 0x48,
+	0xc1, 0xea, 0xd0, 0xd1, 0xa7, 0xee, 0x21, 0x75, 0x58, 0x05, 0xd7, 0x3d, 0x36, 0x6d, 0x71, 0x39,
+	0x72, 0xe8, 0x58, 0x13, 0x8d, 0xdd, 0x61, 0x89, 0x2c, 0x19, 0x01, 0xec, 0xce, 0xca, 0xef, 0x94,
+	0x62, 0x1c, 0xeb, 0xec, 0x8b, 0x62, 0x83, 0x67, 0xf1, 0x71, 0x19, 0x11, 0x51, 0x2c, 0x36, 0x8a,
+	0x77, 0xb0, 0x31, 0x59, 0xbf, 0x03, 0x47, 0x28, 0xff, 0x9a, 0x82, 0xba, 0xcf, 0x44, 0xdf, 0xb1,
+	0x8e, 0x1c, 0xcc, 0x47, 0x36, 0x21, 0xeb, 0x7a, 0xd4, 0x16, 0x62, 0x6e, 0xf0, 0x4b, 0x9f, 0x18,
+	0xd2, 0xe6, 0xc0, 0xa3, 0xb6, 0xca, 0xf0, 0x66, 0xec, 0x63, 0xce, 0x53, 0x02, 0xf7, 0xc4, 0xb4,
+	0xed, 0x20, 0xe1, 0xf4, 0x9b, 0xca, 0x8f, 0x20, 0x8b, 0xb4, 0x30, 0x5b, 0x47, 0x2f, 0x3d, 0xe0,
+	0x89, 0x3b, 0x5f, 0xcf, 0x54, 0x7c, 0x3d, 0xd3, 0x58, 0x9b, 0x6a, 0x6e, 0xef, 0xd6, 0x33, 0xe8,
+	0xd5, 0x5b, 0xbd, 0x6e, 0xbb, 0x9e, 0x55, 0xce, 0x61, 0x3d, 0x41, 0x91, 0x37, 0x5d, 0xb5, 0x8f,
+	0xa0, 0x68, 0x0b, 0xe1, 0xc4, 0xc2, 0xad, 0x25, 0x4a, 0xae, 0x06, 0x68, 0x4a, 0x1b, 0xea, 0xb1,
+	0x70, 0xf2, 0x3a, 0x52, 0xc5, 0x4f, 0x45, 0xab, 0xf8, 0x61, 0xbc, 0x4f, 0xcb, 0xf1, 0x5e, 0xf9,
+	0xab, 0x14, 0x94, 0x9b, 0xd2, 0xa5, 0x5e, 0xdc, 0xcd, 0xca, 0xfb, 0x2d, 0x1d, 0xdd, 0x6f, 0x58,
+	0x4c, 0xd4, 0x5f, 0xd1, 0xb1, 0xd0, 0x35, 0x6f, 0x2c, 0xbe, 0x7e, 0xf4, 0x57, 0x27, 0x17, 0x2d,
+	0x2d, 0x26, 0x67, 0x2d, 0xba, 0x9c, 0xb5, 0xb0, 0x86, 0xf2, 0x3b, 0x2c, 0xb0, 0x49, 0xfc, 0x8a,
+	0xec, 0x91, 0xf3, 0x91, 0x9a, 0xcb, 0x47, 0x7a, 0x0e, 0x1f, 0x92, 0x95, 0x28, 0x7f, 0x9c, 0x82,
+	0x95, 0x08, 0xe5, 0x9b, 0x2e, 0xe2, 0xc7, 0xb1, 0xfb, 0x50, 0x39, 0x4a, 0xcb, 0x84, 0x23, 0x97,
+	0xa8, 0x0b, 0x2a, 0x18, 0xe8, 0xfd, 0xa5, 0x71, 0xc9, 0x69, 0xf0, 0xbf, 0xa4, 0x01, 0x9a, 0xa7,
+	0x86, 0xe9, 0xb5, 0xcf, 0x30, 0x3a, 0x27, 0x64, 0xa4, 0x4c, 0x8b, 0xa2, 0xcc, 0x88, 0xdf, 0xb1,
+	0xab, 0xe7, 0x4c, 0xfc, 0xea, 0xf9, 0x5d, 0x58, 0x96, 0x1e, 0x03, 0x68, 0x3c, 0x31, 0xe7, 0x3b,
+	0x77, 0xc9, 0x8e, 0xe6, 0xee, 0x68, 0x55, 0xfa, 0x28, 0x08, 0x86, 0x25, 0x55, 0xb4, 0x10, 0x3e,
+	0xa1, 0xde, 0xb1, 0x15, 0x94, 0xd2, 0x78, 0x2b, 0xd0, 0x7b, 0x21, 0x5a, 0xa5, 0xf4, 0xdd, 0x76,
+	0x31, 0xe2, 0xb6, 0x43, 0x93, 0x2d, 0x45, 0x52, 0x54, 0x16, 0x8a, 0xdc, 0xd3, 0xb1, 0x27, 0x8e,
+	0x61, 0xa2, 0x25, 0x5f, 0xec, 0xda, 0x8d, 0x72, 0xe4, 0x62, 0xd7, 0x46, 0x3b, 0xf6, 0x1c, 0x7d,
+	0xc4, 0xa6, 0xa9, 0x70, 0x3b, 0x66, 0xed, 0x8e, 0xa1, 0xfc, 0x5d, 0x8a, 0x47, 0xd0, 0x50, 0x8d,
+	0x2c, 0xe2, 0x5d, 0x71, 0x43, 0x9f, 0x94, 0xeb, 0x4b, 0xa2, 0x64, 0xe2, 0xa2, 0x08, 0x3d, 0x65,
+	0x23, 0x7a, 0x22, 0x90, 0x45, 0x07, 0x2a, 0x52, 0x09, 0xf6, 0x8d, 0x4b, 0xe8, 0x59, 0x62, 0x33,
+	0xa4, 0x3d, 0x2b, 0xac, 0xd9, 0x17, 0xe4, 0x9a, 0xbd, 0x05, 0x24, 0xe4, 0xf7, 0x8d, 0x5e, 0x7c,
+	0xe8, 0x38, 0x5c, 0xa3, 0x67, 0x61, 0xea, 0xc7, 0xb1, 0x25, 0xb2, 0xa0, 0x07, 0xdf, 0xca, 0x97,
+	0x50, 0x3c, 0xd0, 0xbd, 0xf9, 0x97, 0x23, 0xf7, 0x58, 0x2a, 0x79, 0xea, 0xb8, 0xe6, 0x99, 0x5f,
+	0xce, 0x0c, 0x01, 0xca, 0x7f, 0x64, 0xa0, 0x84, 0xb7, 0x80, 0xdc, 0x4a, 0xdf, 0x16, 0xe5, 0x96,
+	0x48, 0xf4, 0xf7, 0x7b, 0x37, 0xc3, 0x02, 0xcb, 0xdc, 0x03, 0x95, 0x7f, 0x79, 0x97, 0x89, 0x5e,
+	0xde, 0xcd, 0xcd, 0x00, 0x22, 0xa7, 0x85, 0x5c, 0xec, 0xb4, 0x10, 0x5d, 0xe8, 0x7c, 0xc2, 0x42,
+	0x4b, 0x8e, 0x28, 0xbb, 0xf0, 0xf4, 0x24, 0xdf, 0xa4, 0x97, 0x16, 0xdf, 0xa4, 0xff, 0x22, 0x05,
+	0x59, 0x76, 0x5a, 0x28, 0x43, 0x61, 0x5b, 0x6d, 0x37, 0x83, 0x13, 0xc6, 0x81, 0xda, 0x19, 0x0e,
+	0xdb, 0xdd, 0x7a, 0x0a, 0x1b, 0xad, 0xf6, 0x6e, 0x1b, 0x7b, 0xd2, 0x18, 0x97, 0xf6, 0x7a, 0x2f,
+	0xdb, 0xad, 0x7a, 0x06, 0xe3, 0x12, 0x0b, 0x51, 0x5a, 0xb3, 0x85, 0x39, 0x43, 0x96, 0x2c, 0x43,
+	0x95, 0x03, 0xf6, 0xfb, 0x2d, 0x46, 0x28, 0x17, 0x82, 0xd4, 0x36, 0x1f, 0x96, 0xc7, 0x84, 0x05,
+	0xe3, 0x98, 0xe6, 0xcf, 0x56, 0x08, 0x20, 0xfe, 0xb0, 0x62, 0x00, 0x51, 0xdb, 0x2f, 0x7b, 0x2f,
+	0xda, 0xad, 0x7a, 0x29, 0x24, 0xd4, 0xfe, 0xae, 0xdf, 0xc1, 0x8c, 0x05, 0x94, 0x13, 0x58, 0x0e,
+	0x56, 0xeb, 0xe6, 0xa6, 0xf7, 0x01, 0x00, 0x5b, 0x24, 0xd9, 0xf2, 0x6a, 0x51, 0x13, 0x50, 0x4b,
+	0x87, 0xfe, 0xa7, 0xf2, 0xcf, 0x29, 0x28, 0x1c, 0xd0, 0x57, 0xc7, 0x96, 0x75, 0x72, 0x93, 0xe0,
+	0x34, 0xa7, 0xf4, 0x14, 0x9a, 0x68, 0x36, 0x66, 0xa2, 0xec, 0xad, 0x9e, 0x33, 0x16, 0xd6, 0x81,
+	0x9f, 0xe4, 0x3d, 0xc8, 0x33, 0x26, 0x31, 0x85, 0xcf, 0xcc, 0x33, 0x54, 0x81, 0x12, 0x86, 0xb1,
+	0x82, 0x14, 0xc6, 0x94, 0x3f, 0x4b, 0x41, 0xb5, 0x4b, 0xcf, 0x85, 0x00, 0x6f, 0xb4, 0x77, 0x7c,
+	0xc6, 0x32, 0x49, 0x8c, 0x65, 0xaf, 0x66, 0xec, 0x36, 0xe4, 0xc5, 0x5b, 0x16, 0xe1, 0xa3, 0x79,
+	0x4b, 0x79, 0x05, 0x4b, 0x01, 0x5b, 0x37, 0x3f, 0x89, 0x16, 0xce, 0xf9, 0x58, 0xb1, 0x80, 0x15,
+	0x7e, 0xc1, 0x2c, 0xe8, 0xf9, 0x9d, 0xca, 0x03, 0xa8, 0x08, 0x58, 0x72, 0xf4, 0xfa, 0x8b, 0x14,
+	0x40, 0x8b, 0xea, 0xc6, 0x2e, 0xf5, 0x3c, 0xea, 0xcc, 0xac, 0xef, 0x7d, 0x00, 0x41, 0x29, 0x5c,
+	0xe1, 0x92, 0x80, 0x74, 0x58, 0xb1, 0xd0, 0xd6, 0x2f, 0xb1, 0xfc, 0x15, 0xbc, 0xdf, 0xe4, 0x4d,
+	0x5c, 0x0c, 0xea, 0x38, 0x96, 0x23, 0xdc, 0x00, 0x6f, 0x60, 0xac, 0xd5, 0x3d, 0x8f, 0x4e, 0x6c,
+	0xcf, 0xaf, 0x2e, 0x06, 0xed, 0xe4, 0x2c, 0x04, 0xbd, 0x6c, 0xc8, 0xde, 0x1b, 0x79, 0x59, 0x03,
+	0x73, 0x90, 0x31, 0x1b, 0x1f, 0xf1, 0xb2, 0x12, 0x59, 0x30, 0x82, 0x6f, 0xe5, 0x31, 0x2c, 0x85,
+	0x3d, 0xc9, 0x3a, 0xfb, 0x55, 0x1a, 0x8a, 0xbd, 0xed, 0xbd, 0xe4, 0x0a, 0xd4, 0x82, 0x1d, 0x71,
+	0x4f, 0x3e, 0xeb, 0x8b, 0xa8, 0x1f, 0x00, 0x02, 0xb3, 0xcc, 0x46, 0xdf, 0xee, 0xb0, 0x6a, 0x67,
+	0x4e, 0xaa, 0x76, 0xc6, 0xce, 0xac, 0xf9, 0xd9, 0xca, 0xcc, 0x3b, 0x7e, 0x05, 0xa9, 0x20, 0x79,
+	0x77, 0x9f, 0xe1, 0x68, 0xf9, 0x28, 0x50, 0x7a, 0x51, 0x4e, 0xfd, 0x1e, 0x42, 0xd9, 0x76, 0xac,
+	0x33, 0xd3, 0x90, 0x4b, 0xb0, 0xe0, 0x83, 0x3a, 0x2c, 0x67, 0xa2, 0x53, 0xc3, 0xb6, 0xcc, 0xa9,
+	0x9f, 0x02, 0x04, 0x6d, 0x61, 0x32, 0x86, 0x7e, 0xa6, 0xe1, 0x9e, 0x29, 0x07, 0x26, 0x63, 0xe8,
+	0x67, 0xfb, 0xce, 0xf8, 0x0d, 0x0a, 0x3b, 0x06, 0xd4, 0xba, 0xf4, 0xdc, 0xe7, 0x7f, 0xf1, 0x0e,
+	0x96, 0x0a, 0x29, 0x11, 0xe5, 0xc6, 0x94, 0x96, 0x99, 0x3d, 0xe8, 0xff, 0x3e, 0xd4, 0xc3, 0x29,
+	0x6e, 0xfe, 0x54, 0x2e, 0x52, 0x17, 0xaa, 0x46, 0x34, 0xee, 0x17, 0x85, 0x1e, 0x42, 0xd5, 0x07,
+	0x25, 0xdb, 0xd5, 0xbf, 0xa7, 0x60, 0xa5, 0x33, 0x1d, 0x59, 0x13, 0x73, 0x7a, 0x24, 0x8b, 0x7b,
+	0x1f, 0x80, 0x1f, 0x2a, 0xce, 0xcd, 0x40, 0xe8, 0x12, 0x83, 0x1c, 0x98, 0x92, 0x91, 0xa4, 0x25,
+	0x23, 0x89, 0xad, 0x60, 0x66, 0x66, 0x05, 0x57, 0x21, 0xc7, 0xcc, 0xd2, 0xdf, 0x9f, 0xac, 0x81,
+	0x55, 0x70, 0x46, 0xd7, 0xd0, 0x22, 0x0e, 0x8b, 0x3f, 0x34, 0x32, 0xf8, 0xe3, 0xbb, 0xab, 0x0d,
+	0x10, 0xef, 0x9a, 0x7a, 0xdb, 0x7b, 0xec, 0x5a, 0xcb, 0x1c, 0xb1, 0xc2, 0x8c, 0x58, 0xb5, 0x20,
+	0xe7, 0x28, 0x89, 0xf4, 0x22, 0xc6, 0x67, 0x7a, 0x86, 0xcf, 0x19, 0x8e, 0x32, 0xb3, 0x1c, 0x29,
+	0xff, 0x98, 0x82, 0x4a, 0x9f, 0xbf, 0xa7, 0x1e, 0xd8, 0xfa, 0x88, 0x06, 0x2a, 0x49, 0x45, 0x5f,
+	0x84, 0xcc, 0x64, 0x32, 0x81, 0x16, 0x32, 0xb2, 0x16, 0x1e, 0x42, 0x59, 0x37, 0xf0, 0xcd, 0x07,
+	0x7b, 0xf2, 0x29, 0x34, 0x04, 0x0c, 0xb4, 0x83, 0x10, 0x64, 0x8a, 0xdd, 0x13, 0x3b, 0xae, 0x40,
+	0x11, 0x6a, 0x12, 0xc0, 0x00, 0x09, 0x4f, 0x3b, 0x21, 0x12, 0xcf, 0x6b, 0x2a, 0x02, 0xc8, 0x90,
+	0x14, 0x95, 0x05, 0x27, 0xc1, 0x3b, 0x2a, 0xe9, 0xbd, 0xf0, 0xa1, 0x78, 0x4a, 0xba, 0xd2, 0x97,
+	0xa5, 0x0b, 0xde, 0x8e, 0xf3, 0xe7, 0x30, 0x96, 0x78, 0x33, 0x98, 0x55, 0x79, 0x43, 0xf9, 0xef,
+	0x14, 0xd4, 0x79, 0x01, 0x4f, 0xa2, 0x9b, 0xa4, 0x91, 0x40, 0xfa, 0xf4, 0x02, 0xe9, 0x33, 0x57,
+	0x4b, 0x9f, 0xbd, 0x8e, 0xf4, 0xb9, 0x59, 0xe9, 0x43, 0xfe, 0xf3, 0x12, 0xff, 0x58, 0x3b, 0x16,
+	0x35, 0x3c, 0xde, 0xc9, 0x2f, 0x72, 0xcb, 0x1c, 0xc6, 0x5e, 0x23, 0x2b, 0x1d, 0xa8, 0xb7, 0xe8,
+	0x98, 0x5e, 0x29, 0x21, 0x3e, 0xd6, 0x65, 0x78, 0xec, 0x51, 0x9c, 0x2b, 0x22, 0x7b, 0x99, 0xc3,
+	0x30, 0x5a, 0xbb, 0xca, 0x23, 0x80, 0xc5, 0x44, 0x94, 0x23, 0x58, 0x0a, 0x30, 0x6e, 0xea, 0x18,
+	0xa4, 0xe5, 0x4c, 0x5f, 0xb5, 0x9c, 0xef, 0xfe, 0x6d, 0x1e, 0x20, 0xa4, 0x41, 0xf2, 0x90, 0xee,
+	0xbd, 0xe0, 0xae, 0x71, 0xbf, 0xfb, 0xa2, 0xdb, 0x3b, 0xc0, 0x8c, 0x74, 0x0d, 0x96, 0xb1, 0x6e,
+	0xd5, 0xdc, 0x69, 0x6b, 0xdd, 0xde, 0x50, 0x7b, 0xd6, 0xdb, 0xef, 0x62, 0x6e, 0xba, 0x01, 0xb7,
+	0x7d, 0x70, 0x73, 0x57, 0x6d, 0x37, 0x5b, 0x3f, 0xd5, 0xda, 0xdf, 0x75, 0x06, 0xc3, 0x41, 0x3d,
+	0x43, 0xee, 0x41, 0xc3, 0xef, 0xeb, 0xb7, 0xd5, 0xbd, 0xce, 0x60, 0xd0, 0xe9, 0x75, 0x5b, 0xed,
+	0x6e, 0x87, 0x65, 0xae, 0xeb, 0xb0, 0xb6, 0xdd, 0xeb, 0x0e, 0xdb, 0xdf, 0x0d, 0x35, 0xbc, 0x38,
+	0xd5, 0xd4, 0xf6, 0x4f, 0xf6, 0x59, 0x96, 0x99, 0xc3, 0x54, 0xb4, 0xdf, 0x1c, 0x3e, 0xd7, 0x3a,
+	0xdd, 0x97, 0xcd, 0xdd, 0x0e, 0x26, 0xb0, 0xeb, 0xb0, 0x26, 0xd5, 0x63, 0x24, 0x0e, 0x0a, 0x38,
+	0x8b, 0xdc, 0x25, 0xc6, 0x68, 0x98, 0xd6, 0xd6, 0x8b, 0xe4, 0x11, 0xdc, 0x4b, 0xea, 0xed, 0x37,
+	0x07, 0x83, 0x83, 0x9e, 0x8a, 0x59, 0xee, 0x3a, 0xac, 0xc9, 0x82, 0x0d, 0xf6, 0xfb, 0xfd, 0x9e,
+	0x8a, 0x01, 0x00, 0x08, 0x81, 0x1a, 0x63, 0x2d, 0x9c, 0xae, 0x8c, 0x49, 0xf1, 0xb0, 0xf7, 0xa2,
+	0xdd, 0x0d, 0x98, 0xab, 0xa0, 0x0e, 0xe4, 0x72, 0xa0, 0x84, 0x5e, 0xc5, 0xbe, 0x66, 0xbf, 0x1f,
+	0xcc, 0x27, 0xf5, 0xd5, 0x50, 0xa5, 0xc3, 0x5e, 0x4f, 0xdb, 0x6b, 0x76, 0x7f, 0xaa, 0x35, 0x87,
+	0xc3, 0xf6, 0x5e, 0x7f, 0x38, 0xa8, 0x2f, 0xe1, 0xac, 0x07, 0xcd, 0xe1, 0xf6, 0x73, 0xad, 0xf7,
+	0xb2, 0xad, 0x3e, 0xdb, 0xed, 0x1d, 0xd4, 0xeb, 0x88, 0x7a, 0xd0, 0xfe, 0xf6, 0x79, 0xaf, 0x27,
+	0xcb, 0xbe, 0x8c, 0xbc, 0xb7, 0xda, 0xcd, 0x96, 0xb6, 0xdb, 0x1e, 0x0e, 0x23, 0x7c, 0x12, 0xa6,
+	0x31, 0xb5, 0xfd, 0xb2, 0xd3, 0x3e, 0x88, 0x89, 0xb5, 0xc2, 0x44, 0x68, 0xee, 0x48, 0xd8, 0xab,
+	0xe4, 0x2d, 0x78, 0x14, 0x11, 0x21, 0xd0, 0x53, 0xb0, 0x6e, 0x83, 0xfa, 0x1a, 0xb9, 0x03, 0x2b,
+	0xbd, 0xed, 0xbd, 0x19, 0x29, 0x6f, 0x93, 0x07, 0xb0, 0x21, 0x6b, 0xb9, 0xd5, 0x3b, 0xe8, 0xee,
+	0xf6, 0x90, 0xaf, 0xce, 0x5e, 0x67, 0x58, 0xbf, 0x43, 0x14, 0x78, 0x20, 0xf7, 0x07, 0xda, 0x40,
+	0xf1, 0x07, 0xcf, 0x7b, 0xea, 0xb0, 0xde, 0x20, 0x8f, 0xe1, 0xfe, 0x5c, 0x9c, 0x83, 0x76, 0xf3,
+	0x45, 0x7d, 0x9d, 0x3c, 0x84, 0xbb, 0x89, 0x28, 0xdf, 0x36, 0xbb, 0xdd, 0x76, 0xab, 0xbe, 0x31,
+	0x97, 0x46, 0x60, 0x5b, 0x77, 0x51, 0x93, 0x7d, 0xb5, 0x87, 0x31, 0x5e, 0x92, 0xe0, 0x1e, 0xae,
+	0x93, 0x0f, 0x8e, 0xd9, 0xf1, 0x7d, 0xb2, 0x02, 0x4b, 0x7e, 0x9f, 0xbf, 0xe8, 0x0f, 0xb6, 0xfe,
+	0x2b, 0x03, 0xd9, 0xe6, 0xa9, 0x77, 0x4c, 0xbe, 0x86, 0x5a, 0xf4, 0xa5, 0x39, 0xf1, 0x6f, 0xce,
+	0x62, 0xcf, 0xcf, 0x37, 0x08, 0x83, 0x47, 0xde, 0x8f, 0x2b, 0xb7, 0xc8, 0x67, 0x40, 0x5a, 0xa6,
+	0x3b, 0xd1, 0xa7, 0xde, 0x58, 0xa2, 0x51, 0x95, 0x71, 0x5f, 0x6f, 0x2c, 0x87, 0xbf, 0x26, 0x08,
+	0x47, 0xfe, 0x18, 0x56, 0x93, 0x7e, 0x96, 0x42, 0xee, 0x85, 0xf3, 0xcf, 0xde, 0xb8, 0xce, 0xe1,
+	0xa2, 0x05, 0x8d, 0x80, 0x8b, 0x38, 0xbd, 0x18, 0x2f, 0x77, 0xe2, 0xaf, 0x2f, 0x42, 0x2a, 0x3b,
+	0xb0, 0xbc, 0xed, 0x50, 0xdd, 0xa3, 0x72, 0x5d, 0x91, 0xab, 0x63, 0xa6, 0x78, 0xb7, 0xd1, 0x98,
+	0x29, 0x8f, 0x85, 0x84, 0xbe, 0x81, 0x3a, 0xab, 0xcb, 0x84, 0x9d, 0xae, 0x60, 0xc3, 0xff, 0x05,
+	0xc9, 0xa2, 0xe1, 0x4f, 0x53, 0xe4, 0x47, 0xb0, 0xac, 0xd2, 0x33, 0xeb, 0x24, 0xc2, 0xc9, 0x5a,
+	0x7c, 0x48, 0xa7, 0x15, 0x6a, 0x24, 0xf2, 0xa3, 0x16, 0xe5, 0xd6, 0xd6, 0x5f, 0x17, 0xa1, 0x30,
+	0xf0, 0x2c, 0x47, 0x3f, 0xa2, 0xe4, 0x43, 0x28, 0x71, 0xb9, 0xf0, 0xb5, 0x18, 0x3f, 0xf0, 0x88,
+	0xf7, 0xc6, 0xc9, 0x83, 0xc9, 0xfb, 0x90, 0xe7, 0x71, 0xe2, 0x5a, 0xd8, 0xef, 0xe2, 0x53, 0xc8,
+	0x33, 0x1f, 0x57, 0x3c, 0x55, 0x9e, 0x83, 0xfb, 0x14, 0x0a, 0x9d, 0xa9, 0x6b, 0x63, 0x14, 0x8e,
+	0x92, 0x5e, 0x8b, 0x96, 0x1b, 0xc2, 0x11, 0x9f, 0x02, 0x84, 0x77, 0x3f, 0xd7, 0x1c, 0xf4, 0x34,
+	0x45, 0x3e, 0x81, 0xca, 0xc0, 0xd3, 0x1d, 0x8f, 0x3d, 0x0e, 0x1e, 0x5e, 0xc4, 0xd5, 0xbf, 0x22,
+	0xff, 0x28, 0x23, 0x9c, 0xec, 0x73, 0x00, 0x36, 0x80, 0xbf, 0xdd, 0xac, 0x08, 0x24, 0xd6, 0xda,
+	0x58, 0x9f, 0x7d, 0x8a, 0x1c, 0x0c, 0x7c, 0x92, 0x22, 0x1f, 0x41, 0xf5, 0x99, 0x39, 0x35, 0xdd,
+	0x63, 0x7f, 0x46, 0x10, 0xa3, 0xdb, 0x53, 0x63, 0x8e, 0x32, 0x3e, 0xc1, 0xf7, 0x96, 0xba, 0xc1,
+	0x5e, 0xce, 0x47, 0x05, 0xbb, 0x1d, 0x7b, 0xed, 0x2b, 0x4b, 0xf6, 0x19, 0x54, 0x51, 0x21, 0xfe,
+	0x1b, 0x4a, 0x37, 0x51, 0x27, 0xf1, 0xf7, 0xa2, 0x6c, 0xe4, 0x97, 0xf8, 0x88, 0x51, 0x37, 0xfc,
+	0x3e, 0x52, 0x8f, 0xa1, 0x2e, 0x9e, 0xf7, 0x73, 0x7c, 0x4d, 0xc8, 0xde, 0x09, 0x2e, 0x20, 0x90,
+	0x2c, 0xe8, 0x17, 0x50, 0xe6, 0x2c, 0xb3, 0xc7, 0x88, 0x31, 0x86, 0xd7, 0x67, 0x1f, 0x5f, 0xca,
+	0xd3, 0x36, 0x61, 0x25, 0x98, 0x36, 0x44, 0x21, 0xab, 0x09, 0xa3, 0xe6, 0x4d, 0xbf, 0x05, 0x15,
+	0x01, 0x4a, 0x9a, 0x3f, 0x79, 0xcc, 0x7b, 0x90, 0x1f, 0x50, 0xaf, 0xb9, 0xbd, 0x4b, 0xf8, 0xef,
+	0x77, 0xf8, 0xeb, 0xa7, 0x39, 0xc8, 0x9b, 0x50, 0xe2, 0x99, 0xe3, 0x35, 0xf1, 0x3f, 0x80, 0xe2,
+	0xfe, 0xd4, 0xbd, 0x36, 0xf9, 0x0f, 0xa1, 0xb8, 0x43, 0x3d, 0x96, 0xc2, 0x09, 0x3b, 0xf6, 0x7f,
+	0x7c, 0xb6, 0x41, 0xe4, 0xa6, 0x24, 0x70, 0x8e, 0x95, 0x3c, 0x05, 0xb6, 0x5f, 0xfe, 0x14, 0xcb,
+	0x3b, 0x53, 0x02, 0x43, 0x3d, 0x6f, 0xfd, 0x4d, 0x9a, 0xfd, 0x0e, 0xf6, 0x88, 0x3a, 0xe4, 0x7d,
+	0x28, 0xec, 0x50, 0x6f, 0x88, 0xef, 0xae, 0xcb, 0xc1, 0xef, 0x1f, 0xe9, 0xeb, 0x8d, 0x7a, 0xd8,
+	0x90, 0x16, 0x88, 0x6b, 0x0a, 0x7f, 0x74, 0x1a, 0x41, 0x5e, 0x20, 0xf9, 0xf5, 0xd1, 0x3f, 0x82,
+	0xf2, 0x0e, 0xf5, 0x90, 0x61, 0xc6, 0x4d, 0x74, 0xe1, 0x92, 0xd9, 0xf9, 0x18, 0xaa, 0x3b, 0x54,
+	0xb8, 0x8b, 0x6b, 0x0f, 0xfa, 0x04, 0x4a, 0xc1, 0x2f, 0x73, 0x89, 0xff, 0x1a, 0x38, 0xfc, 0xa5,
+	0xee, 0x1c, 0x1f, 0xfb, 0x27, 0x45, 0xc8, 0xf1, 0x6a, 0xc6, 0xd7, 0x50, 0xe7, 0x1e, 0x56, 0x7a,
+	0x69, 0xb6, 0xe4, 0x07, 0x0e, 0xf1, 0x58, 0x74, 0x51, 0xe4, 0x69, 0x06, 0x47, 0x8f, 0x70, 0x3c,
+	0x9f, 0x33, 0xf2, 0xde, 0x74, 0x11, 0x89, 0x6f, 0x60, 0x59, 0x78, 0xd6, 0x19, 0x1e, 0xc2, 0xb3,
+	0xf3, 0x22, 0x02, 0x9f, 0xb3, 0x57, 0xe6, 0xd6, 0x09, 0x5d, 0x34, 0x3e, 0x79, 0x99, 0x76, 0x60,
+	0x29, 0x76, 0x93, 0x4f, 0xf8, 0x44, 0xb3, 0xf7, 0xfb, 0x0b, 0x38, 0x78, 0x9a, 0x22, 0x2d, 0xa8,
+	0x35, 0x0d, 0x43, 0x7e, 0xa9, 0x14, 0x84, 0xdf, 0xe8, 0xab, 0x0a, 0x11, 0x3f, 0x13, 0x1e, 0x52,
+	0xb1, 0xcc, 0x62, 0x79, 0xe6, 0x25, 0x06, 0x59, 0x97, 0xd4, 0x79, 0x23, 0x5a, 0xf5, 0xf8, 0xd3,
+	0x03, 0xd2, 0x08, 0x64, 0x8b, 0xbd, 0x48, 0x58, 0x44, 0x89, 0xf9, 0xdf, 0x6a, 0xe4, 0x51, 0x84,
+	0x88, 0xe8, 0xf1, 0x87, 0x12, 0x73, 0x94, 0xfc, 0x15, 0xd4, 0x02, 0xc3, 0xe6, 0x22, 0xcd, 0xac,
+	0xce, 0x22, 0x41, 0xb6, 0xf9, 0x5d, 0x51, 0xe4, 0xea, 0x75, 0x26, 0x2b, 0xd9, 0xf0, 0xbd, 0xea,
+	0xec, 0x6b, 0x1f, 0x26, 0x41, 0x07, 0xc8, 0x1e, 0xde, 0x2e, 0x44, 0x30, 0xc8, 0x5a, 0xd2, 0xa8,
+	0x2b, 0x88, 0x91, 0x6d, 0x58, 0xdd, 0x9f, 0x4e, 0xae, 0x4d, 0x6c, 0x9e, 0x4e, 0x20, 0x7c, 0x73,
+	0x20, 0x76, 0x4c, 0xe4, 0xf9, 0xc7, 0xc6, 0x9d, 0x19, 0x98, 0x24, 0x8e, 0x0a, 0xcb, 0x33, 0x77,
+	0xe0, 0xc2, 0x50, 0x92, 0x1e, 0x19, 0x6c, 0x3c, 0x98, 0xd7, 0x25, 0xf9, 0xd1, 0xef, 0xa1, 0x20,
+	0x7e, 0x31, 0x42, 0xd8, 0x2f, 0x1d, 0x74, 0xc3, 0x6f, 0x2e, 0xc5, 0x7e, 0x4e, 0xb2, 0x30, 0xda,
+	0x7e, 0x0c, 0xd5, 0x3e, 0xbe, 0x43, 0x11, 0xe8, 0xee, 0x75, 0x82, 0xd6, 0xd6, 0x57, 0x90, 0xe7,
+	0x3f, 0x04, 0x22, 0x1f, 0x07, 0x5f, 0xfc, 0x86, 0x22, 0xf8, 0x7d, 0xd0, 0x82, 0x9c, 0x69, 0xeb,
+	0x97, 0x69, 0x28, 0x8a, 0xaa, 0xb7, 0x4b, 0x3e, 0x03, 0x68, 0x1a, 0x86, 0x68, 0x0a, 0xd5, 0x46,
+	0x2e, 0x04, 0x36, 0x56, 0x23, 0xa5, 0xf3, 0x70, 0x51, 0x7e, 0x1b, 0x2a, 0x68, 0x69, 0x01, 0xa5,
+	0x98, 0x8d, 0xcd, 0x19, 0xc6, 0x33, 0x1b, 0x95, 0x4e, 0xac, 0x33, 0xea, 0xcf, 0xba, 0x2c, 0xa3,
+	0x2e, 0x72, 0x40, 0x4d, 0xee, 0x80, 0xc2, 0x0a, 0xb4, 0x9b, 0x34, 0xf6, 0x4e, 0xbc, 0x80, 0x2d,
+	0x4f, 0xfe, 0x15, 0xa6, 0x37, 0x9e, 0x73, 0x19, 0x76, 0x8b, 0x1c, 0x23, 0x56, 0xd6, 0x9e, 0xa3,
+	0xfa, 0x3e, 0xe4, 0xd8, 0xfd, 0xa3, 0xef, 0x0b, 0xa5, 0x3b, 0x59, 0xc9, 0x17, 0x46, 0x6f, 0x6a,
+	0x05, 0x43, 0xb3, 0xd7, 0xa1, 0x6c, 0x35, 0xfe, 0x34, 0x0b, 0x99, 0xde, 0xf6, 0x1e, 0x9e, 0xd0,
+	0x78, 0x6c, 0x09, 0x6a, 0xe7, 0x2b, 0xfe, 0x62, 0x48, 0xd5, 0x4e, 0xb1, 0xae, 0xf1, 0x5a, 0x2c,
+	0xf3, 0xeb, 0x2c, 0x5f, 0xf4, 0x7b, 0x66, 0xd6, 0x63, 0xde, 0xc0, 0xa7, 0x29, 0xf2, 0x05, 0xd4,
+	0xf8, 0x82, 0x04, 0x53, 0x93, 0x08, 0xf2, 0xe2, 0x25, 0x59, 0x93, 0xfd, 0xcd, 0x9b, 0x4c, 0xdf,
+	0x86, 0xdb, 0xf8, 0x86, 0xd7, 0x9e, 0x21, 0x92, 0xc8, 0xc6, 0x5c, 0x05, 0x7c, 0x0b, 0xb7, 0x55,
+	0xca, 0xeb, 0x50, 0xd7, 0x20, 0x93, 0x2c, 0xcd, 0x73, 0x58, 0x69, 0x1a, 0xc6, 0x0c, 0x01, 0xee,
+	0x70, 0x13, 0x8a, 0xcf, 0xf3, 0xb9, 0xf9, 0x06, 0x6a, 0xfc, 0x87, 0x0b, 0x01, 0x91, 0x3b, 0x3e,
+	0x6a, 0xac, 0xf2, 0x3b, 0xc7, 0xd2, 0xfe, 0x21, 0xcd, 0x1e, 0x67, 0xa1, 0x40, 0xe4, 0x87, 0x50,
+	0x15, 0x79, 0x87, 0x00, 0x04, 0xfb, 0x34, 0x2c, 0xce, 0x6d, 0xac, 0xca, 0xb5, 0x33, 0x89, 0x93,
+	0xaf, 0xfd, 0x5f, 0xb3, 0xf8, 0x83, 0xd7, 0xa4, 0x10, 0x79, 0x8d, 0xf1, 0x5f, 0x42, 0x35, 0x52,
+	0x4d, 0x14, 0xe3, 0xe3, 0x15, 0xc6, 0x39, 0x1a, 0xfd, 0x14, 0x60, 0x87, 0x7a, 0xfe, 0xd0, 0xa5,
+	0xe8, 0x1c, 0xf3, 0x27, 0x15, 0xce, 0x45, 0x74, 0xcc, 0x71, 0x2e, 0x33, 0xc3, 0x9e, 0xa6, 0x5e,
+	0xe5, 0xd9, 0xbf, 0x7c, 0xf9, 0xf8, 0xff, 0x06, 0x00, 0xdf, 0x65, 0x3e, 0x89, 0xff, 0x45, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AuthClient is the client API for Auth service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AuthClient interface {
+	ForgeUserToken(ctx context.Context, in *ForgeUserTokenReq, opts ...grpc.CallOption) (*TokenResponse, error)
+	DismantleUserToken(ctx context.Context, in *TokenReq, opts ...grpc.CallOption) (*UserResponse, error)
+	ForgePublicLinkToken(ctx context.Context, in *ForgePublicLinkTokenReq, opts ...grpc.CallOption) (*TokenResponse, error)
+	DismantlePublicLinkToken(ctx context.Context, in *TokenReq, opts ...grpc.CallOption) (*PublicLinkResponse, error)
+	// with user context, relative to the user logged in
+	CreateAppPassword(ctx context.Context, in *NewAppPasswordReq, opts ...grpc.CallOption) (*AppPasswordResponse, error)
+	ListAppPasswords(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (Auth_ListAppPasswordsClient, error)
+	RevokeAppPassword(ctx context.Context, in *AppPasswordIDReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+}
+
+type authClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAuthClient(cc *grpc.ClientConn) AuthClient {
+	return &authClient{cc}
+}
+
+func (c *authClient) ForgeUserToken(ctx context.Context, in *ForgeUserTokenReq, opts ...grpc.CallOption) (*TokenResponse, error) {
+	out := new(TokenResponse)
+	err := c.cc.Invoke(ctx, "/api.Auth/ForgeUserToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) DismantleUserToken(ctx context.Context, in *TokenReq, opts ...grpc.CallOption) (*UserResponse, error) {
+	out := new(UserResponse)
+	err := c.cc.Invoke(ctx, "/api.Auth/DismantleUserToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) ForgePublicLinkToken(ctx context.Context, in *ForgePublicLinkTokenReq, opts ...grpc.CallOption) (*TokenResponse, error) {
+	out := new(TokenResponse)
+	err := c.cc.Invoke(ctx, "/api.Auth/ForgePublicLinkToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) DismantlePublicLinkToken(ctx context.Context, in *TokenReq, opts ...grpc.CallOption) (*PublicLinkResponse, error) {
+	out := new(PublicLinkResponse)
+	err := c.cc.Invoke(ctx, "/api.Auth/DismantlePublicLinkToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) CreateAppPassword(ctx context.Context, in *NewAppPasswordReq, opts ...grpc.CallOption) (*AppPasswordResponse, error) {
+	out := new(AppPasswordResponse)
+	err := c.cc.Invoke(ctx, "/api.Auth/CreateAppPassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) ListAppPasswords(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (Auth_ListAppPasswordsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Auth_serviceDesc.Streams[0], "/api.Auth/ListAppPasswords", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &authListAppPasswordsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Auth_ListAppPasswordsClient interface {
+	Recv() (*AppPasswordResponse, error)
+	grpc.ClientStream
+}
+
+type authListAppPasswordsClient struct {
+	grpc.ClientStream
+}
+
+func (x *authListAppPasswordsClient) Recv() (*AppPasswordResponse, error) {
+	m := new(AppPasswordResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *authClient) RevokeAppPassword(ctx context.Context, in *AppPasswordIDReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Auth/RevokeAppPassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServer is the server API for Auth service.
+type AuthServer interface {
+	ForgeUserToken(context.Context, *ForgeUserTokenReq) (*TokenResponse, error)
+	DismantleUserToken(context.Context, *TokenReq) (*UserResponse, error)
+	ForgePublicLinkToken(context.Context, *ForgePublicLinkTokenReq) (*TokenResponse, error)
+	DismantlePublicLinkToken(context.Context, *TokenReq) (*PublicLinkResponse, error)
+	// with user context, relative to the user logged in
+	CreateAppPassword(context.Context, *NewAppPasswordReq) (*AppPasswordResponse, error)
+	ListAppPasswords(*EmptyReq, Auth_ListAppPasswordsServer) error
+	RevokeAppPassword(context.Context, *AppPasswordIDReq) (*EmptyResponse, error)
+}
+
+func RegisterAuthServer(s *grpc.Server, srv AuthServer) {
+	s.RegisterService(&_Auth_serviceDesc, srv)
+}
+
+func _Auth_ForgeUserToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForgeUserTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).ForgeUserToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Auth/ForgeUserToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).ForgeUserToken(ctx, req.(*ForgeUserTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_DismantleUserToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).DismantleUserToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Auth/DismantleUserToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).DismantleUserToken(ctx, req.(*TokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_ForgePublicLinkToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForgePublicLinkTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).ForgePublicLinkToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Auth/ForgePublicLinkToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).ForgePublicLinkToken(ctx, req.(*ForgePublicLinkTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_DismantlePublicLinkToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).DismantlePublicLinkToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Auth/DismantlePublicLinkToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).DismantlePublicLinkToken(ctx, req.(*TokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_CreateAppPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewAppPasswordReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).CreateAppPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Auth/CreateAppPassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).CreateAppPassword(ctx, req.(*NewAppPasswordReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_ListAppPasswords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EmptyReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AuthServer).ListAppPasswords(m, &authListAppPasswordsServer{stream})
+}
+
+type Auth_ListAppPasswordsServer interface {
+	Send(*AppPasswordResponse) error
+	grpc.ServerStream
+}
+
+type authListAppPasswordsServer struct {
+	grpc.ServerStream
+}
+
+func (x *authListAppPasswordsServer) Send(m *AppPasswordResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Auth_RevokeAppPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppPasswordIDReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).RevokeAppPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Auth/RevokeAppPassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).RevokeAppPassword(ctx, req.(*AppPasswordIDReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Auth_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.Auth",
+	HandlerType: (*AuthServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ForgeUserToken",
+			Handler:    _Auth_ForgeUserToken_Handler,
+		},
+		{
+			MethodName: "DismantleUserToken",
+			Handler:    _Auth_DismantleUserToken_Handler,
+		},
+		{
+			MethodName: "ForgePublicLinkToken",
+			Handler:    _Auth_ForgePublicLinkToken_Handler,
+		},
+		{
+			MethodName: "DismantlePublicLinkToken",
+			Handler:    _Auth_DismantlePublicLinkToken_Handler,
+		},
+		{
+			MethodName: "CreateAppPassword",
+			Handler:    _Auth_CreateAppPassword_Handler,
+		},
+		{
+			MethodName: "RevokeAppPassword",
+			Handler:    _Auth_RevokeAppPassword_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListAppPasswords",
+			Handler:       _Auth_ListAppPasswords_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.proto",
+}
+
+// StorageClient is the client API for Storage service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type StorageClient interface {
+	CreateDir(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	Delete(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	Move(ctx context.Context, in *MoveReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	Inspect(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*MetadataResponse, error)
+	ListFolder(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ListFolderClient, error)
+	StartWriteTx(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (*TxInfoResponse, error)
+	WriteChunk(ctx context.Context, opts ...grpc.CallOption) (Storage_WriteChunkClient, error)
+	FinishWriteTx(ctx context.Context, in *TxEnd, opts ...grpc.CallOption) (*EmptyResponse, error)
+	ReadFile(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ReadFileClient, error)
+	ListRevisions(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ListRevisionsClient, error)
+	ReadRevision(ctx context.Context, in *RevisionReq, opts ...grpc.CallOption) (Storage_ReadRevisionClient, error)
+	RestoreRevision(ctx context.Context, in *RevisionReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	ListRecycle(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ListRecycleClient, error)
+	RestoreRecycleEntry(ctx context.Context, in *RecycleEntryReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	EmptyRecycle(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	SetACL(ctx context.Context, in *ACLReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UpdateACL(ctx context.Context, in *ACLReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UnsetACL(ctx context.Context, in *ACLReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	GetQuota(ctx context.Context, in *QuotaReq, opts ...grpc.CallOption) (*QuotaResponse, error)
+	Watch(ctx context.Context, in *WatchReq, opts ...grpc.CallOption) (Storage_WatchClient, error)
+}
+
+type storageClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewStorageClient(cc *grpc.ClientConn) StorageClient {
+	return &storageClient{cc}
+}
+
+func (c *storageClient) CreateDir(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Storage/CreateDir", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) Delete(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Storage/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) Move(ctx context.Context, in *MoveReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Storage/Move", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) Inspect(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*MetadataResponse, error) {
+	out := new(MetadataResponse)
+	err := c.cc.Invoke(ctx, "/api.Storage/Inspect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) ListFolder(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ListFolderClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[0], "/api.Storage/ListFolder", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageListFolderClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Storage_ListFolderClient interface {
+	Recv() (*MetadataResponse, error)
+	grpc.ClientStream
+}
+
+type storageListFolderClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageListFolderClient) Recv() (*MetadataResponse, error) {
+	m := new(MetadataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageClient) StartWriteTx(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (*TxInfoResponse, error) {
+	out := new(TxInfoResponse)
+	err := c.cc.Invoke(ctx, "/api.Storage/StartWriteTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) WriteChunk(ctx context.Context, opts ...grpc.CallOption) (Storage_WriteChunkClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[1], "/api.Storage/WriteChunk", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageWriteChunkClient{stream}
+	return x, nil
+}
+
+type Storage_WriteChunkClient interface {
+	Send(*TxChunk) error
+	CloseAndRecv() (*WriteSummaryResponse, error)
+	grpc.ClientStream
+}
+
+type storageWriteChunkClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageWriteChunkClient) Send(m *TxChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *storageWriteChunkClient) CloseAndRecv() (*WriteSummaryResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(WriteSummaryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageClient) FinishWriteTx(ctx context.Context, in *TxEnd, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Storage/FinishWriteTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) ReadFile(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ReadFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[2], "/api.Storage/ReadFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageReadFileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Storage_ReadFileClient interface {
+	Recv() (*DataChunkResponse, error)
+	grpc.ClientStream
+}
+
+type storageReadFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageReadFileClient) Recv() (*DataChunkResponse, error) {
+	m := new(DataChunkResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageClient) ListRevisions(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ListRevisionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[3], "/api.Storage/ListRevisions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageListRevisionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Storage_ListRevisionsClient interface {
+	Recv() (*RevisionResponse, error)
+	grpc.ClientStream
+}
+
+type storageListRevisionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageListRevisionsClient) Recv() (*RevisionResponse, error) {
+	m := new(RevisionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageClient) ReadRevision(ctx context.Context, in *RevisionReq, opts ...grpc.CallOption) (Storage_ReadRevisionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[4], "/api.Storage/ReadRevision", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageReadRevisionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Storage_ReadRevisionClient interface {
+	Recv() (*DataChunkResponse, error)
+	grpc.ClientStream
+}
+
+type storageReadRevisionClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageReadRevisionClient) Recv() (*DataChunkResponse, error) {
+	m := new(DataChunkResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageClient) RestoreRevision(ctx context.Context, in *RevisionReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Storage/RestoreRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) ListRecycle(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ListRecycleClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[5], "/api.Storage/ListRecycle", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageListRecycleClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Storage_ListRecycleClient interface {
+	Recv() (*RecycleEntryResponse, error)
+	grpc.ClientStream
+}
+
+type storageListRecycleClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageListRecycleClient) Recv() (*RecycleEntryResponse, error) {
+	m := new(RecycleEntryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageClient) RestoreRecycleEntry(ctx context.Context, in *RecycleEntryReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Storage/RestoreRecycleEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) EmptyRecycle(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Storage/EmptyRecycle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) SetACL(ctx context.Context, in *ACLReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Storage/SetACL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) UpdateACL(ctx context.Context, in *ACLReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Storage/UpdateACL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) UnsetACL(ctx context.Context, in *ACLReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Storage/UnsetACL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) GetQuota(ctx context.Context, in *QuotaReq, opts ...grpc.CallOption) (*QuotaResponse, error) {
+	out := new(QuotaResponse)
+	err := c.cc.Invoke(ctx, "/api.Storage/GetQuota", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) Watch(ctx context.Context, in *WatchReq, opts ...grpc.CallOption) (Storage_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[6], "/api.Storage/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Storage_WatchClient interface {
+	Recv() (*FileEventResponse, error)
+	grpc.ClientStream
+}
+
+type storageWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageWatchClient) Recv() (*FileEventResponse, error) {
+	m := new(FileEventResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StorageServer is the server API for Storage service.
+type StorageServer interface {
+	CreateDir(context.Context, *PathReq) (*EmptyResponse, error)
+	Delete(context.Context, *PathReq) (*EmptyResponse, error)
+	Move(context.Context, *MoveReq) (*EmptyResponse, error)
+	Inspect(context.Context, *PathReq) (*MetadataResponse, error)
+	ListFolder(*PathReq, Storage_ListFolderServer) error
+	StartWriteTx(context.Context, *EmptyReq) (*TxInfoResponse, error)
+	WriteChunk(Storage_WriteChunkServer) error
+	FinishWriteTx(context.Context, *TxEnd) (*EmptyResponse, error)
+	ReadFile(*PathReq, Storage_ReadFileServer) error
+	ListRevisions(*PathReq, Storage_ListRevisionsServer) error
+	ReadRevision(*RevisionReq, Storage_ReadRevisionServer) error
+	RestoreRevision(context.Context, *RevisionReq) (*EmptyResponse, error)
+	ListRecycle(*PathReq, Storage_ListRecycleServer) error
+	RestoreRecycleEntry(context.Context, *RecycleEntryReq) (*EmptyResponse, error)
+	EmptyRecycle(context.Context, *PathReq) (*EmptyResponse, error)
+	SetACL(context.Context, *ACLReq) (*EmptyResponse, error)
+	UpdateACL(context.Context, *ACLReq) (*EmptyResponse, error)
+	UnsetACL(context.Context, *ACLReq) (*EmptyResponse, error)
+	GetQuota(context.Context, *QuotaReq) (*QuotaResponse, error)
+	Watch(*WatchReq, Storage_WatchServer) error
+}
+
+func RegisterStorageServer(s *grpc.Server, srv StorageServer) {
+	s.RegisterService(&_Storage_serviceDesc, srv)
+}
+
+func _Storage_CreateDir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PathReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).CreateDir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Storage/CreateDir",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).CreateDir(ctx, req.(*PathReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PathReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Storage/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).Delete(ctx, req.(*PathReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_Move_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).Move(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Storage/Move",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).Move(ctx, req.(*MoveReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PathReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).Inspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Storage/Inspect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).Inspect(ctx, req.(*PathReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_ListFolder_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PathReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServer).ListFolder(m, &storageListFolderServer{stream})
+}
+
+type Storage_ListFolderServer interface {
+	Send(*MetadataResponse) error
+	grpc.ServerStream
+}
+
+type storageListFolderServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageListFolderServer) Send(m *MetadataResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Storage_StartWriteTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).StartWriteTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Storage/StartWriteTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).StartWriteTx(ctx, req.(*EmptyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_WriteChunk_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StorageServer).WriteChunk(&storageWriteChunkServer{stream})
+}
+
+type Storage_WriteChunkServer interface {
+	SendAndClose(*WriteSummaryResponse) error
+	Recv() (*TxChunk, error)
+	grpc.ServerStream
+}
+
+type storageWriteChunkServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageWriteChunkServer) SendAndClose(m *WriteSummaryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *storageWriteChunkServer) Recv() (*TxChunk, error) {
+	m := new(TxChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Storage_FinishWriteTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxEnd)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
 	if interceptor == nil {
-		return srv.(AuthServer).ForgeUserToken(ctx, in)
+		return srv.(StorageServer).FinishWriteTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Storage/FinishWriteTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).FinishWriteTx(ctx, req.(*TxEnd))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_ReadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PathReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServer).ReadFile(m, &storageReadFileServer{stream})
+}
+
+type Storage_ReadFileServer interface {
+	Send(*DataChunkResponse) error
+	grpc.ServerStream
+}
+
+type storageReadFileServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageReadFileServer) Send(m *DataChunkResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Storage_ListRevisions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PathReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServer).ListRevisions(m, &storageListRevisionsServer{stream})
+}
+
+type Storage_ListRevisionsServer interface {
+	Send(*RevisionResponse) error
+	grpc.ServerStream
+}
+
+type storageListRevisionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageListRevisionsServer) Send(m *RevisionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Storage_ReadRevision_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RevisionReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServer).ReadRevision(m, &storageReadRevisionServer{stream})
+}
+
+type Storage_ReadRevisionServer interface {
+	Send(*DataChunkResponse) error
+	grpc.ServerStream
+}
+
+type storageReadRevisionServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageReadRevisionServer) Send(m *DataChunkResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Storage_RestoreRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevisionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).RestoreRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Storage/RestoreRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).RestoreRevision(ctx, req.(*RevisionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_ListRecycle_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PathReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServer).ListRecycle(m, &storageListRecycleServer{stream})
+}
+
+type Storage_ListRecycleServer interface {
+	Send(*RecycleEntryResponse) error
+	grpc.ServerStream
+}
+
+type storageListRecycleServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageListRecycleServer) Send(m *RecycleEntryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Storage_RestoreRecycleEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecycleEntryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).RestoreRecycleEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Storage/RestoreRecycleEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).RestoreRecycleEntry(ctx, req.(*RecycleEntryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_EmptyRecycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PathReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).EmptyRecycle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Storage/EmptyRecycle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).EmptyRecycle(ctx, req.(*PathReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_SetACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ACLReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).SetACL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Storage/SetACL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).SetACL(ctx, req.(*ACLReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_UpdateACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ACLReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).UpdateACL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Storage/UpdateACL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).UpdateACL(ctx, req.(*ACLReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_UnsetACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ACLReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).UnsetACL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Storage/UnsetACL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).UnsetACL(ctx, req.(*ACLReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_GetQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuotaReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).GetQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Storage/GetQuota",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).GetQuota(ctx, req.(*QuotaReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServer).Watch(m, &storageWatchServer{stream})
+}
+
+type Storage_WatchServer interface {
+	Send(*FileEventResponse) error
+	grpc.ServerStream
+}
+
+type storageWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageWatchServer) Send(m *FileEventResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Storage_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.Storage",
+	HandlerType: (*StorageServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateDir",
+			Handler:    _Storage_CreateDir_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Storage_Delete_Handler,
+		},
+		{
+			MethodName: "Move",
+			Handler:    _Storage_Move_Handler,
+		},
+		{
+			MethodName: "Inspect",
+			Handler:    _Storage_Inspect_Handler,
+		},
+		{
+			MethodName: "StartWriteTx",
+			Handler:    _Storage_StartWriteTx_Handler,
+		},
+		{
+			MethodName: "FinishWriteTx",
+			Handler:    _Storage_FinishWriteTx_Handler,
+		},
+		{
+			MethodName: "RestoreRevision",
+			Handler:    _Storage_RestoreRevision_Handler,
+		},
+		{
+			MethodName: "RestoreRecycleEntry",
+			Handler:    _Storage_RestoreRecycleEntry_Handler,
+		},
+		{
+			MethodName: "EmptyRecycle",
+			Handler:    _Storage_EmptyRecycle_Handler,
+		},
+		{
+			MethodName: "SetACL",
+			Handler:    _Storage_SetACL_Handler,
+		},
+		{
+			MethodName: "UpdateACL",
+			Handler:    _Storage_UpdateACL_Handler,
+		},
+		{
+			MethodName: "UnsetACL",
+			Handler:    _Storage_UnsetACL_Handler,
+		},
+		{
+			MethodName: "GetQuota",
+			Handler:    _Storage_GetQuota_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListFolder",
+			Handler:       _Storage_ListFolder_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WriteChunk",
+			Handler:       _Storage_WriteChunk_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ReadFile",
+			Handler:       _Storage_ReadFile_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListRevisions",
+			Handler:       _Storage_ListRevisions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReadRevision",
+			Handler:       _Storage_ReadRevision_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListRecycle",
+			Handler:       _Storage_ListRecycle_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Storage_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.proto",
+}
+
+// TaggerClient is the client API for Tagger service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TaggerClient interface {
+	GetTags(ctx context.Context, in *TagReq, opts ...grpc.CallOption) (Tagger_GetTagsClient, error)
+	SetTag(ctx context.Context, in *TagReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UnSetTag(ctx context.Context, in *TagReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	GetFileTags(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Tagger_GetFileTagsClient, error)
+	// GetFolderTags returns the tags of a folder and of its entries.
+	GetFolderTags(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Tagger_GetFolderTagsClient, error)
+	RenameTag(ctx context.Context, in *RenameTagReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+}
+
+type taggerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTaggerClient(cc *grpc.ClientConn) TaggerClient {
+	return &taggerClient{cc}
+}
+
+func (c *taggerClient) GetTags(ctx context.Context, in *TagReq, opts ...grpc.CallOption) (Tagger_GetTagsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Tagger_serviceDesc.Streams[0], "/api.Tagger/GetTags", opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.Auth/ForgeUserToken",
+	x := &taggerGetTagsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServer).ForgeUserToken(ctx, req.(*ForgeUserTokenReq))
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return x, nil
 }
 
-func _Auth_DismantleUserToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TokenReq)
+type Tagger_GetTagsClient interface {
+	Recv() (*TagResponse, error)
+	grpc.ClientStream
+}
+
+type taggerGetTagsClient struct {
+	grpc.ClientStream
+}
+
+func (x *taggerGetTagsClient) Recv() (*TagResponse, error) {
+	m := new(TagResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taggerClient) SetTag(ctx context.Context, in *TagReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Tagger/SetTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taggerClient) UnSetTag(ctx context.Context, in *TagReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Tagger/UnSetTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taggerClient) GetFileTags(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Tagger_GetFileTagsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Tagger_serviceDesc.Streams[1], "/api.Tagger/GetFileTags", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taggerGetFileTagsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Tagger_GetFileTagsClient interface {
+	Recv() (*TagResponse, error)
+	grpc.ClientStream
+}
+
+type taggerGetFileTagsClient struct {
+	grpc.ClientStream
+}
+
+func (x *taggerGetFileTagsClient) Recv() (*TagResponse, error) {
+	m := new(TagResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taggerClient) GetFolderTags(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Tagger_GetFolderTagsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Tagger_serviceDesc.Streams[2], "/api.Tagger/GetFolderTags", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taggerGetFolderTagsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Tagger_GetFolderTagsClient interface {
+	Recv() (*TagResponse, error)
+	grpc.ClientStream
+}
+
+type taggerGetFolderTagsClient struct {
+	grpc.ClientStream
+}
+
+func (x *taggerGetFolderTagsClient) Recv() (*TagResponse, error) {
+	m := new(TagResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taggerClient) RenameTag(ctx context.Context, in *RenameTagReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Tagger/RenameTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TaggerServer is the server API for Tagger service.
+type TaggerServer interface {
+	GetTags(*TagReq, Tagger_GetTagsServer) error
+	SetTag(context.Context, *TagReq) (*EmptyResponse, error)
+	UnSetTag(context.Context, *TagReq) (*EmptyResponse, error)
+	GetFileTags(*PathReq, Tagger_GetFileTagsServer) error
+	// GetFolderTags returns the tags of a folder and of its entries.
+	GetFolderTags(*PathReq, Tagger_GetFolderTagsServer) error
+	RenameTag(context.Context, *RenameTagReq) (*EmptyResponse, error)
+}
+
+func RegisterTaggerServer(s *grpc.Server, srv TaggerServer) {
+	s.RegisterService(&_Tagger_serviceDesc, srv)
+}
+
+func _Tagger_GetTags_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TagReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaggerServer).GetTags(m, &taggerGetTagsServer{stream})
+}
+
+type Tagger_GetTagsServer interface {
+	Send(*TagResponse) error
+	grpc.ServerStream
+}
+
+type taggerGetTagsServer struct {
+	grpc.ServerStream
+}
+
+func (x *taggerGetTagsServer) Send(m *TagResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Tagger_SetTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServer).DismantleUserToken(ctx, in)
+		return srv.(TaggerServer).SetTag(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Auth/DismantleUserToken",
+		FullMethod: "/api.Tagger/SetTag",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServer).DismantleUserToken(ctx, req.(*TokenReq))
+		return srv.(TaggerServer).SetTag(ctx, req.(*TagReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Auth_ForgePublicLinkToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ForgePublicLinkTokenReq)
+func _Tagger_UnSetTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServer).ForgePublicLinkToken(ctx, in)
+		return srv.(TaggerServer).UnSetTag(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Auth/ForgePublicLinkToken",
+		FullMethod: "/api.Tagger/UnSetTag",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServer).ForgePublicLinkToken(ctx, req.(*ForgePublicLinkTokenReq))
+		return srv.(TaggerServer).UnSetTag(ctx, req.(*TagReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Auth_DismantlePublicLinkToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TokenReq)
+func _Tagger_GetFileTags_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PathReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaggerServer).GetFileTags(m, &taggerGetFileTagsServer{stream})
+}
+
+type Tagger_GetFileTagsServer interface {
+	Send(*TagResponse) error
+	grpc.ServerStream
+}
+
+type taggerGetFileTagsServer struct {
+	grpc.ServerStream
+}
+
+func (x *taggerGetFileTagsServer) Send(m *TagResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Tagger_GetFolderTags_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PathReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaggerServer).GetFolderTags(m, &taggerGetFolderTagsServer{stream})
+}
+
+type Tagger_GetFolderTagsServer interface {
+	Send(*TagResponse) error
+	grpc.ServerStream
+}
+
+type taggerGetFolderTagsServer struct {
+	grpc.ServerStream
+}
+
+func (x *taggerGetFolderTagsServer) Send(m *TagResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Tagger_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServer).DismantlePublicLinkToken(ctx, in)
+		return srv.(TaggerServer).RenameTag(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Auth/DismantlePublicLinkToken",
+		FullMethod: "/api.Tagger/RenameTag",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServer).DismantlePublicLinkToken(ctx, req.(*TokenReq))
+		return srv.(TaggerServer).RenameTag(ctx, req.(*RenameTagReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Auth_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "api.Auth",
-	HandlerType: (*AuthServer)(nil),
+var _Tagger_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.Tagger",
+	HandlerType: (*TaggerServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "ForgeUserToken",
-			Handler:    _Auth_ForgeUserToken_Handler,
+			MethodName: "SetTag",
+			Handler:    _Tagger_SetTag_Handler,
 		},
 		{
-			MethodName: "DismantleUserToken",
-			Handler:    _Auth_DismantleUserToken_Handler,
+			MethodName: "UnSetTag",
+			Handler:    _Tagger_UnSetTag_Handler,
 		},
 		{
-			MethodName: "ForgePublicLinkToken",
-			Handler:    _Auth_ForgePublicLinkToken_Handler,
+			MethodName: "RenameTag",
+			Handler:    _Tagger_RenameTag_Handler,
 		},
+	},
+	Streams: []grpc.StreamDesc{
 		{
-			MethodName: "DismantlePublicLinkToken",
-			Handler:    _Auth_DismantlePublicLinkToken_Handler,
+			StreamName:    "GetTags",
+			Handler:       _Tagger_GetTags_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetFileTags",
+			Handler:       _Tagger_GetFileTags_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetFolderTags",
+			Handler:       _Tagger_GetFolderTags_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
 }
 
-// StorageClient is the client API for Storage service.
+// ShareClient is the client API for Share service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type StorageClient interface {
-	CreateDir(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*EmptyResponse, error)
-	Delete(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*EmptyResponse, error)
-	Move(ctx context.Context, in *MoveReq, opts ...grpc.CallOption) (*EmptyResponse, error)
-	Inspect(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*MetadataResponse, error)
-	ListFolder(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ListFolderClient, error)
-	StartWriteTx(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (*TxInfoResponse, error)
-	WriteChunk(ctx context.Context, opts ...grpc.CallOption) (Storage_WriteChunkClient, error)
-	FinishWriteTx(ctx context.Context, in *TxEnd, opts ...grpc.CallOption) (*EmptyResponse, error)
-	ReadFile(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ReadFileClient, error)
-	ListRevisions(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ListRevisionsClient, error)
-	ReadRevision(ctx context.Context, in *RevisionReq, opts ...grpc.CallOption) (Storage_ReadRevisionClient, error)
-	RestoreRevision(ctx context.Context, in *RevisionReq, opts ...grpc.CallOption) (*EmptyResponse, error)
-	ListRecycle(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ListRecycleClient, error)
-	RestoreRecycleEntry(ctx context.Context, in *RecycleEntryReq, opts ...grpc.CallOption) (*EmptyResponse, error)
-	EmptyRecycle(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*EmptyResponse, error)
-	SetACL(ctx context.Context, in *ACLReq, opts ...grpc.CallOption) (*EmptyResponse, error)
-	UpdateACL(ctx context.Context, in *ACLReq, opts ...grpc.CallOption) (*EmptyResponse, error)
-	UnsetACL(ctx context.Context, in *ACLReq, opts ...grpc.CallOption) (*EmptyResponse, error)
-	GetQuota(ctx context.Context, in *QuotaReq, opts ...grpc.CallOption) (*QuotaResponse, error)
+type ShareClient interface {
+	// with user context, relative to the user logged in
+	CreatePublicLink(ctx context.Context, in *NewLinkReq, opts ...grpc.CallOption) (*PublicLinkResponse, error)
+	UpdatePublicLink(ctx context.Context, in *UpdateLinkReq, opts ...grpc.CallOption) (*PublicLinkResponse, error)
+	InspectPublicLink(ctx context.Context, in *ShareIDReq, opts ...grpc.CallOption) (*PublicLinkResponse, error)
+	RevokePublicLink(ctx context.Context, in *ShareIDReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	ListPublicLinks(ctx context.Context, in *ListPublicLinksReq, opts ...grpc.CallOption) (Share_ListPublicLinksClient, error)
+	// with user context, relative to user logged in
+	AddFolderShare(ctx context.Context, in *NewFolderShareReq, opts ...grpc.CallOption) (*FolderShareResponse, error)
+	UpdateFolderShare(ctx context.Context, in *UpdateFolderShareReq, opts ...grpc.CallOption) (*FolderShareResponse, error)
+	ListFolderShares(ctx context.Context, in *ListFolderSharesReq, opts ...grpc.CallOption) (Share_ListFolderSharesClient, error)
+	UnshareFolder(ctx context.Context, in *UnshareFolderReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	GetFolderShare(ctx context.Context, in *ShareIDReq, opts ...grpc.CallOption) (*FolderShareResponse, error)
+	// with user context, relative to the user logged in, in this case, the receiver
+	ListReceivedShares(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (Share_ListReceivedSharesClient, error)
+	MountReceivedShare(ctx context.Context, in *ReceivedShareReq, opts ...grpc.CallOption) (*ReceivedShareResponse, error)
+	UnmountReceivedShare(ctx context.Context, in *ReceivedShareReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// for the admins, the shares and public links of all the users out of step with their files
+	FsckShares(ctx context.Context, in *FsckSharesReq, opts ...grpc.CallOption) (Share_FsckSharesClient, error)
+	// for the admins, hands files over to another user with their shares and public links
+	TransferOwnership(ctx context.Context, in *TransferOwnershipReq, opts ...grpc.CallOption) (Share_TransferOwnershipClient, error)
 }
 
-type storageClient struct {
+type shareClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewStorageClient(cc *grpc.ClientConn) StorageClient {
-	return &storageClient{cc}
+func NewShareClient(cc *grpc.ClientConn) ShareClient {
+	return &shareClient{cc}
 }
 
-func (c *storageClient) CreateDir(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
-	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Storage/CreateDir", in, out, opts...)
+func (c *shareClient) CreatePublicLink(ctx context.Context, in *NewLinkReq, opts ...grpc.CallOption) (*PublicLinkResponse, error) {
+	out := new(PublicLinkResponse)
+	err := c.cc.Invoke(ctx, "/api.Share/CreatePublicLink", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *storageClient) Delete(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
-	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Storage/Delete", in, out, opts...)
+func (c *shareClient) UpdatePublicLink(ctx context.Context, in *UpdateLinkReq, opts ...grpc.CallOption) (*PublicLinkResponse, error) {
+	out := new(PublicLinkResponse)
+	err := c.cc.Invoke(ctx, "/api.Share/UpdatePublicLink", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *storageClient) Move(ctx context.Context, in *MoveReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
-	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Storage/Move", in, out, opts...)
+func (c *shareClient) InspectPublicLink(ctx context.Context, in *ShareIDReq, opts ...grpc.CallOption) (*PublicLinkResponse, error) {
+	out := new(PublicLinkResponse)
+	err := c.cc.Invoke(ctx, "/api.Share/InspectPublicLink", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *storageClient) Inspect(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*MetadataResponse, error) {
-	out := new(MetadataResponse)
-	err := c.cc.Invoke(ctx, "/api.Storage/Inspect", in, out, opts...)
+func (c *shareClient) RevokePublicLink(ctx context.Context, in *ShareIDReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Share/RevokePublicLink", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *storageClient) ListFolder(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ListFolderClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[0], "/api.Storage/ListFolder", opts...)
+func (c *shareClient) ListPublicLinks(ctx context.Context, in *ListPublicLinksReq, opts ...grpc.CallOption) (Share_ListPublicLinksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Share_serviceDesc.Streams[0], "/api.Share/ListPublicLinks", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &storageListFolderClient{stream}
+	x := &shareListPublicLinksClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -3562,81 +8127,47 @@ func (c *storageClient) ListFolder(ctx context.Context, in *PathReq, opts ...grp
 	return x, nil
 }
 
-type Storage_ListFolderClient interface {
-	Recv() (*MetadataResponse, error)
+type Share_ListPublicLinksClient interface {
+	Recv() (*PublicLinkResponse, error)
 	grpc.ClientStream
 }
 
-type storageListFolderClient struct {
+type shareListPublicLinksClient struct {
 	grpc.ClientStream
 }
 
-func (x *storageListFolderClient) Recv() (*MetadataResponse, error) {
-	m := new(MetadataResponse)
+func (x *shareListPublicLinksClient) Recv() (*PublicLinkResponse, error) {
+	m := new(PublicLinkResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *storageClient) StartWriteTx(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (*TxInfoResponse, error) {
-	out := new(TxInfoResponse)
-	err := c.cc.Invoke(ctx, "/api.Storage/StartWriteTx", in, out, opts...)
+func (c *shareClient) AddFolderShare(ctx context.Context, in *NewFolderShareReq, opts ...grpc.CallOption) (*FolderShareResponse, error) {
+	out := new(FolderShareResponse)
+	err := c.cc.Invoke(ctx, "/api.Share/AddFolderShare", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *storageClient) WriteChunk(ctx context.Context, opts ...grpc.CallOption) (Storage_WriteChunkClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[1], "/api.Storage/WriteChunk", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &storageWriteChunkClient{stream}
-	return x, nil
-}
-
-type Storage_WriteChunkClient interface {
-	Send(*TxChunk) error
-	CloseAndRecv() (*WriteSummaryResponse, error)
-	grpc.ClientStream
-}
-
-type storageWriteChunkClient struct {
-	grpc.ClientStream
-}
-
-func (x *storageWriteChunkClient) Send(m *TxChunk) error {
-	return x.ClientStream.SendMsg(m)
-}
-
-func (x *storageWriteChunkClient) CloseAndRecv() (*WriteSummaryResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(WriteSummaryResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
-}
-
-func (c *storageClient) FinishWriteTx(ctx context.Context, in *TxEnd, opts ...grpc.CallOption) (*EmptyResponse, error) {
-	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Storage/FinishWriteTx", in, out, opts...)
+func (c *shareClient) UpdateFolderShare(ctx context.Context, in *UpdateFolderShareReq, opts ...grpc.CallOption) (*FolderShareResponse, error) {
+	out := new(FolderShareResponse)
+	err := c.cc.Invoke(ctx, "/api.Share/UpdateFolderShare", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *storageClient) ReadFile(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ReadFileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[2], "/api.Storage/ReadFile", opts...)
+func (c *shareClient) ListFolderShares(ctx context.Context, in *ListFolderSharesReq, opts ...grpc.CallOption) (Share_ListFolderSharesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Share_serviceDesc.Streams[1], "/api.Share/ListFolderShares", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &storageReadFileClient{stream}
+	x := &shareListFolderSharesClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -3646,61 +8177,47 @@ func (c *storageClient) ReadFile(ctx context.Context, in *PathReq, opts ...grpc.
 	return x, nil
 }
 
-type Storage_ReadFileClient interface {
-	Recv() (*DataChunkResponse, error)
+type Share_ListFolderSharesClient interface {
+	Recv() (*FolderShareResponse, error)
 	grpc.ClientStream
 }
 
-type storageReadFileClient struct {
+type shareListFolderSharesClient struct {
 	grpc.ClientStream
 }
 
-func (x *storageReadFileClient) Recv() (*DataChunkResponse, error) {
-	m := new(DataChunkResponse)
+func (x *shareListFolderSharesClient) Recv() (*FolderShareResponse, error) {
+	m := new(FolderShareResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *storageClient) ListRevisions(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ListRevisionsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[3], "/api.Storage/ListRevisions", opts...)
+func (c *shareClient) UnshareFolder(ctx context.Context, in *UnshareFolderReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Share/UnshareFolder", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &storageListRevisionsClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
-}
-
-type Storage_ListRevisionsClient interface {
-	Recv() (*RevisionResponse, error)
-	grpc.ClientStream
-}
-
-type storageListRevisionsClient struct {
-	grpc.ClientStream
+	return out, nil
 }
 
-func (x *storageListRevisionsClient) Recv() (*RevisionResponse, error) {
-	m := new(RevisionResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func (c *shareClient) GetFolderShare(ctx context.Context, in *ShareIDReq, opts ...grpc.CallOption) (*FolderShareResponse, error) {
+	out := new(FolderShareResponse)
+	err := c.cc.Invoke(ctx, "/api.Share/GetFolderShare", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return m, nil
+	return out, nil
 }
 
-func (c *storageClient) ReadRevision(ctx context.Context, in *RevisionReq, opts ...grpc.CallOption) (Storage_ReadRevisionClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[4], "/api.Storage/ReadRevision", opts...)
+func (c *shareClient) ListReceivedShares(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (Share_ListReceivedSharesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Share_serviceDesc.Streams[2], "/api.Share/ListReceivedShares", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &storageReadRevisionClient{stream}
+	x := &shareListReceivedSharesClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -3710,38 +8227,47 @@ func (c *storageClient) ReadRevision(ctx context.Context, in *RevisionReq, opts
 	return x, nil
 }
 
-type Storage_ReadRevisionClient interface {
-	Recv() (*DataChunkResponse, error)
+type Share_ListReceivedSharesClient interface {
+	Recv() (*ReceivedShareResponse, error)
 	grpc.ClientStream
 }
 
-type storageReadRevisionClient struct {
+type shareListReceivedSharesClient struct {
 	grpc.ClientStream
 }
 
-func (x *storageReadRevisionClient) Recv() (*DataChunkResponse, error) {
-	m := new(DataChunkResponse)
+func (x *shareListReceivedSharesClient) Recv() (*ReceivedShareResponse, error) {
+	m := new(ReceivedShareResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *storageClient) RestoreRevision(ctx context.Context, in *RevisionReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+func (c *shareClient) MountReceivedShare(ctx context.Context, in *ReceivedShareReq, opts ...grpc.CallOption) (*ReceivedShareResponse, error) {
+	out := new(ReceivedShareResponse)
+	err := c.cc.Invoke(ctx, "/api.Share/MountReceivedShare", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shareClient) UnmountReceivedShare(ctx context.Context, in *ReceivedShareReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
 	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Storage/RestoreRevision", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/api.Share/UnmountReceivedShare", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *storageClient) ListRecycle(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Storage_ListRecycleClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[5], "/api.Storage/ListRecycle", opts...)
+func (c *shareClient) FsckShares(ctx context.Context, in *FsckSharesReq, opts ...grpc.CallOption) (Share_FsckSharesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Share_serviceDesc.Streams[3], "/api.Share/FsckShares", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &storageListRecycleClient{stream}
+	x := &shareFsckSharesClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -3751,584 +8277,695 @@ func (c *storageClient) ListRecycle(ctx context.Context, in *PathReq, opts ...gr
 	return x, nil
 }
 
-type Storage_ListRecycleClient interface {
-	Recv() (*RecycleEntryResponse, error)
+type Share_FsckSharesClient interface {
+	Recv() (*FsckSharesResponse, error)
 	grpc.ClientStream
 }
 
-type storageListRecycleClient struct {
+type shareFsckSharesClient struct {
 	grpc.ClientStream
 }
 
-func (x *storageListRecycleClient) Recv() (*RecycleEntryResponse, error) {
-	m := new(RecycleEntryResponse)
+func (x *shareFsckSharesClient) Recv() (*FsckSharesResponse, error) {
+	m := new(FsckSharesResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *storageClient) RestoreRecycleEntry(ctx context.Context, in *RecycleEntryReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
-	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Storage/RestoreRecycleEntry", in, out, opts...)
+func (c *shareClient) TransferOwnership(ctx context.Context, in *TransferOwnershipReq, opts ...grpc.CallOption) (Share_TransferOwnershipClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Share_serviceDesc.Streams[4], "/api.Share/TransferOwnership", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *storageClient) EmptyRecycle(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
-	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Storage/EmptyRecycle", in, out, opts...)
-	if err != nil {
+	x := &shareTransferOwnershipClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *storageClient) SetACL(ctx context.Context, in *ACLReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
-	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Storage/SetACL", in, out, opts...)
-	if err != nil {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return x, nil
 }
 
-func (c *storageClient) UpdateACL(ctx context.Context, in *ACLReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
-	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Storage/UpdateACL", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type Share_TransferOwnershipClient interface {
+	Recv() (*TransferOwnershipResponse, error)
+	grpc.ClientStream
 }
 
-func (c *storageClient) UnsetACL(ctx context.Context, in *ACLReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
-	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Storage/UnsetACL", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type shareTransferOwnershipClient struct {
+	grpc.ClientStream
 }
 
-func (c *storageClient) GetQuota(ctx context.Context, in *QuotaReq, opts ...grpc.CallOption) (*QuotaResponse, error) {
-	out := new(QuotaResponse)
-	err := c.cc.Invoke(ctx, "/api.Storage/GetQuota", in, out, opts...)
-	if err != nil {
+func (x *shareTransferOwnershipClient) Recv() (*TransferOwnershipResponse, error) {
+	m := new(TransferOwnershipResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-// StorageServer is the server API for Storage service.
-type StorageServer interface {
-	CreateDir(context.Context, *PathReq) (*EmptyResponse, error)
-	Delete(context.Context, *PathReq) (*EmptyResponse, error)
-	Move(context.Context, *MoveReq) (*EmptyResponse, error)
-	Inspect(context.Context, *PathReq) (*MetadataResponse, error)
-	ListFolder(*PathReq, Storage_ListFolderServer) error
-	StartWriteTx(context.Context, *EmptyReq) (*TxInfoResponse, error)
-	WriteChunk(Storage_WriteChunkServer) error
-	FinishWriteTx(context.Context, *TxEnd) (*EmptyResponse, error)
-	ReadFile(*PathReq, Storage_ReadFileServer) error
-	ListRevisions(*PathReq, Storage_ListRevisionsServer) error
-	ReadRevision(*RevisionReq, Storage_ReadRevisionServer) error
-	RestoreRevision(context.Context, *RevisionReq) (*EmptyResponse, error)
-	ListRecycle(*PathReq, Storage_ListRecycleServer) error
-	RestoreRecycleEntry(context.Context, *RecycleEntryReq) (*EmptyResponse, error)
-	EmptyRecycle(context.Context, *PathReq) (*EmptyResponse, error)
-	SetACL(context.Context, *ACLReq) (*EmptyResponse, error)
-	UpdateACL(context.Context, *ACLReq) (*EmptyResponse, error)
-	UnsetACL(context.Context, *ACLReq) (*EmptyResponse, error)
-	GetQuota(context.Context, *QuotaReq) (*QuotaResponse, error)
+// ShareServer is the server API for Share service.
+type ShareServer interface {
+	// with user context, relative to the user logged in
+	CreatePublicLink(context.Context, *NewLinkReq) (*PublicLinkResponse, error)
+	UpdatePublicLink(context.Context, *UpdateLinkReq) (*PublicLinkResponse, error)
+	InspectPublicLink(context.Context, *ShareIDReq) (*PublicLinkResponse, error)
+	RevokePublicLink(context.Context, *ShareIDReq) (*EmptyResponse, error)
+	ListPublicLinks(*ListPublicLinksReq, Share_ListPublicLinksServer) error
+	// with user context, relative to user logged in
+	AddFolderShare(context.Context, *NewFolderShareReq) (*FolderShareResponse, error)
+	UpdateFolderShare(context.Context, *UpdateFolderShareReq) (*FolderShareResponse, error)
+	ListFolderShares(*ListFolderSharesReq, Share_ListFolderSharesServer) error
+	UnshareFolder(context.Context, *UnshareFolderReq) (*EmptyResponse, error)
+	GetFolderShare(context.Context, *ShareIDReq) (*FolderShareResponse, error)
+	// with user context, relative to the user logged in, in this case, the receiver
+	ListReceivedShares(*EmptyReq, Share_ListReceivedSharesServer) error
+	MountReceivedShare(context.Context, *ReceivedShareReq) (*ReceivedShareResponse, error)
+	UnmountReceivedShare(context.Context, *ReceivedShareReq) (*EmptyResponse, error)
+	// for the admins, the shares and public links of all the users out of step with their files
+	FsckShares(*FsckSharesReq, Share_FsckSharesServer) error
+	// for the admins, hands files over to another user with their shares and public links
+	TransferOwnership(*TransferOwnershipReq, Share_TransferOwnershipServer) error
 }
 
-func RegisterStorageServer(s *grpc.Server, srv StorageServer) {
-	s.RegisterService(&_Storage_serviceDesc, srv)
+func RegisterShareServer(s *grpc.Server, srv ShareServer) {
+	s.RegisterService(&_Share_serviceDesc, srv)
 }
 
-func _Storage_CreateDir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PathReq)
+func _Share_CreatePublicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewLinkReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(StorageServer).CreateDir(ctx, in)
+		return srv.(ShareServer).CreatePublicLink(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Storage/CreateDir",
+		FullMethod: "/api.Share/CreatePublicLink",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServer).CreateDir(ctx, req.(*PathReq))
+		return srv.(ShareServer).CreatePublicLink(ctx, req.(*NewLinkReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Storage_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PathReq)
+func _Share_UpdatePublicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLinkReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(StorageServer).Delete(ctx, in)
+		return srv.(ShareServer).UpdatePublicLink(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Storage/Delete",
+		FullMethod: "/api.Share/UpdatePublicLink",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServer).Delete(ctx, req.(*PathReq))
+		return srv.(ShareServer).UpdatePublicLink(ctx, req.(*UpdateLinkReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Storage_Move_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MoveReq)
+func _Share_InspectPublicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareIDReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(StorageServer).Move(ctx, in)
+		return srv.(ShareServer).InspectPublicLink(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Storage/Move",
+		FullMethod: "/api.Share/InspectPublicLink",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServer).Move(ctx, req.(*MoveReq))
+		return srv.(ShareServer).InspectPublicLink(ctx, req.(*ShareIDReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Storage_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PathReq)
+func _Share_RevokePublicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareIDReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(StorageServer).Inspect(ctx, in)
+		return srv.(ShareServer).RevokePublicLink(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Storage/Inspect",
+		FullMethod: "/api.Share/RevokePublicLink",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServer).Inspect(ctx, req.(*PathReq))
+		return srv.(ShareServer).RevokePublicLink(ctx, req.(*ShareIDReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Storage_ListFolder_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PathReq)
+func _Share_ListPublicLinks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListPublicLinksReq)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(StorageServer).ListFolder(m, &storageListFolderServer{stream})
+	return srv.(ShareServer).ListPublicLinks(m, &shareListPublicLinksServer{stream})
 }
 
-type Storage_ListFolderServer interface {
-	Send(*MetadataResponse) error
+type Share_ListPublicLinksServer interface {
+	Send(*PublicLinkResponse) error
 	grpc.ServerStream
 }
 
-type storageListFolderServer struct {
+type shareListPublicLinksServer struct {
 	grpc.ServerStream
 }
 
-func (x *storageListFolderServer) Send(m *MetadataResponse) error {
+func (x *shareListPublicLinksServer) Send(m *PublicLinkResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Storage_StartWriteTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyReq)
+func _Share_AddFolderShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewFolderShareReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShareServer).AddFolderShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Share/AddFolderShare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShareServer).AddFolderShare(ctx, req.(*NewFolderShareReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Share_UpdateFolderShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFolderShareReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShareServer).UpdateFolderShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Share/UpdateFolderShare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShareServer).UpdateFolderShare(ctx, req.(*UpdateFolderShareReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Share_ListFolderShares_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListFolderSharesReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShareServer).ListFolderShares(m, &shareListFolderSharesServer{stream})
+}
+
+type Share_ListFolderSharesServer interface {
+	Send(*FolderShareResponse) error
+	grpc.ServerStream
+}
+
+type shareListFolderSharesServer struct {
+	grpc.ServerStream
+}
+
+func (x *shareListFolderSharesServer) Send(m *FolderShareResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Share_UnshareFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareFolderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShareServer).UnshareFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Share/UnshareFolder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShareServer).UnshareFolder(ctx, req.(*UnshareFolderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Share_GetFolderShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareIDReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(StorageServer).StartWriteTx(ctx, in)
+		return srv.(ShareServer).GetFolderShare(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Storage/StartWriteTx",
+		FullMethod: "/api.Share/GetFolderShare",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServer).StartWriteTx(ctx, req.(*EmptyReq))
+		return srv.(ShareServer).GetFolderShare(ctx, req.(*ShareIDReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Storage_WriteChunk_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(StorageServer).WriteChunk(&storageWriteChunkServer{stream})
+func _Share_ListReceivedShares_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EmptyReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShareServer).ListReceivedShares(m, &shareListReceivedSharesServer{stream})
 }
 
-type Storage_WriteChunkServer interface {
-	SendAndClose(*WriteSummaryResponse) error
-	Recv() (*TxChunk, error)
+type Share_ListReceivedSharesServer interface {
+	Send(*ReceivedShareResponse) error
 	grpc.ServerStream
 }
 
-type storageWriteChunkServer struct {
+type shareListReceivedSharesServer struct {
 	grpc.ServerStream
 }
 
-func (x *storageWriteChunkServer) SendAndClose(m *WriteSummaryResponse) error {
+func (x *shareListReceivedSharesServer) Send(m *ReceivedShareResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *storageWriteChunkServer) Recv() (*TxChunk, error) {
-	m := new(TxChunk)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func _Share_MountReceivedShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceivedShareReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(ShareServer).MountReceivedShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Share/MountReceivedShare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShareServer).MountReceivedShare(ctx, req.(*ReceivedShareReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Storage_FinishWriteTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TxEnd)
+func _Share_UnmountReceivedShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceivedShareReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(StorageServer).FinishWriteTx(ctx, in)
+		return srv.(ShareServer).UnmountReceivedShare(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Storage/FinishWriteTx",
+		FullMethod: "/api.Share/UnmountReceivedShare",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServer).FinishWriteTx(ctx, req.(*TxEnd))
+		return srv.(ShareServer).UnmountReceivedShare(ctx, req.(*ReceivedShareReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Storage_ReadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PathReq)
+func _Share_FsckShares_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FsckSharesReq)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(StorageServer).ReadFile(m, &storageReadFileServer{stream})
+	return srv.(ShareServer).FsckShares(m, &shareFsckSharesServer{stream})
 }
 
-type Storage_ReadFileServer interface {
-	Send(*DataChunkResponse) error
+type Share_FsckSharesServer interface {
+	Send(*FsckSharesResponse) error
 	grpc.ServerStream
 }
 
-type storageReadFileServer struct {
+type shareFsckSharesServer struct {
 	grpc.ServerStream
 }
 
-func (x *storageReadFileServer) Send(m *DataChunkResponse) error {
+func (x *shareFsckSharesServer) Send(m *FsckSharesResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Storage_ListRevisions_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PathReq)
+func _Share_TransferOwnership_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TransferOwnershipReq)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(StorageServer).ListRevisions(m, &storageListRevisionsServer{stream})
+	return srv.(ShareServer).TransferOwnership(m, &shareTransferOwnershipServer{stream})
 }
 
-type Storage_ListRevisionsServer interface {
-	Send(*RevisionResponse) error
+type Share_TransferOwnershipServer interface {
+	Send(*TransferOwnershipResponse) error
 	grpc.ServerStream
 }
 
-type storageListRevisionsServer struct {
+type shareTransferOwnershipServer struct {
 	grpc.ServerStream
 }
 
-func (x *storageListRevisionsServer) Send(m *RevisionResponse) error {
+func (x *shareTransferOwnershipServer) Send(m *TransferOwnershipResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Storage_ReadRevision_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(RevisionReq)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(StorageServer).ReadRevision(m, &storageReadRevisionServer{stream})
+var _Share_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.Share",
+	HandlerType: (*ShareServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePublicLink",
+			Handler:    _Share_CreatePublicLink_Handler,
+		},
+		{
+			MethodName: "UpdatePublicLink",
+			Handler:    _Share_UpdatePublicLink_Handler,
+		},
+		{
+			MethodName: "InspectPublicLink",
+			Handler:    _Share_InspectPublicLink_Handler,
+		},
+		{
+			MethodName: "RevokePublicLink",
+			Handler:    _Share_RevokePublicLink_Handler,
+		},
+		{
+			MethodName: "AddFolderShare",
+			Handler:    _Share_AddFolderShare_Handler,
+		},
+		{
+			MethodName: "UpdateFolderShare",
+			Handler:    _Share_UpdateFolderShare_Handler,
+		},
+		{
+			MethodName: "UnshareFolder",
+			Handler:    _Share_UnshareFolder_Handler,
+		},
+		{
+			MethodName: "GetFolderShare",
+			Handler:    _Share_GetFolderShare_Handler,
+		},
+		{
+			MethodName: "MountReceivedShare",
+			Handler:    _Share_MountReceivedShare_Handler,
+		},
+		{
+			MethodName: "UnmountReceivedShare",
+			Handler:    _Share_UnmountReceivedShare_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListPublicLinks",
+			Handler:       _Share_ListPublicLinks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListFolderShares",
+			Handler:       _Share_ListFolderShares_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListReceivedShares",
+			Handler:       _Share_ListReceivedShares_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FsckShares",
+			Handler:       _Share_FsckShares_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TransferOwnership",
+			Handler:       _Share_TransferOwnership_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.proto",
 }
 
-type Storage_ReadRevisionServer interface {
-	Send(*DataChunkResponse) error
-	grpc.ServerStream
+// PreviewClient is the client API for Preview service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PreviewClient interface {
+	ReadPreview(ctx context.Context, in *PreviewReq, opts ...grpc.CallOption) (Preview_ReadPreviewClient, error)
+	// PurgePreviews removes the cached previews of the path, or all of them if the path is empty.
+	PurgePreviews(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*EmptyResponse, error)
 }
 
-type storageReadRevisionServer struct {
-	grpc.ServerStream
+type previewClient struct {
+	cc *grpc.ClientConn
 }
 
-func (x *storageReadRevisionServer) Send(m *DataChunkResponse) error {
-	return x.ServerStream.SendMsg(m)
+func NewPreviewClient(cc *grpc.ClientConn) PreviewClient {
+	return &previewClient{cc}
 }
 
-func _Storage_RestoreRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RevisionReq)
-	if err := dec(in); err != nil {
+func (c *previewClient) ReadPreview(ctx context.Context, in *PreviewReq, opts ...grpc.CallOption) (Preview_ReadPreviewClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Preview_serviceDesc.Streams[0], "/api.Preview/ReadPreview", opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(StorageServer).RestoreRevision(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.Storage/RestoreRevision",
+	x := &previewReadPreviewClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServer).RestoreRevision(ctx, req.(*RevisionReq))
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return x, nil
 }
 
-func _Storage_ListRecycle_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PathReq)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+type Preview_ReadPreviewClient interface {
+	Recv() (*DataChunkResponse, error)
+	grpc.ClientStream
+}
+
+type previewReadPreviewClient struct {
+	grpc.ClientStream
+}
+
+func (x *previewReadPreviewClient) Recv() (*DataChunkResponse, error) {
+	m := new(DataChunkResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return srv.(StorageServer).ListRecycle(m, &storageListRecycleServer{stream})
+	return m, nil
 }
 
-type Storage_ListRecycleServer interface {
-	Send(*RecycleEntryResponse) error
-	grpc.ServerStream
+func (c *previewClient) PurgePreviews(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Preview/PurgePreviews", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type storageListRecycleServer struct {
-	grpc.ServerStream
+// PreviewServer is the server API for Preview service.
+type PreviewServer interface {
+	ReadPreview(*PreviewReq, Preview_ReadPreviewServer) error
+	// PurgePreviews removes the cached previews of the path, or all of them if the path is empty.
+	PurgePreviews(context.Context, *PathReq) (*EmptyResponse, error)
 }
 
-func (x *storageListRecycleServer) Send(m *RecycleEntryResponse) error {
-	return x.ServerStream.SendMsg(m)
+func RegisterPreviewServer(s *grpc.Server, srv PreviewServer) {
+	s.RegisterService(&_Preview_serviceDesc, srv)
 }
 
-func _Storage_RestoreRecycleEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RecycleEntryReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(StorageServer).RestoreRecycleEntry(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.Storage/RestoreRecycleEntry",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServer).RestoreRecycleEntry(ctx, req.(*RecycleEntryReq))
+func _Preview_ReadPreview_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PreviewReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(PreviewServer).ReadPreview(m, &previewReadPreviewServer{stream})
 }
 
-func _Storage_EmptyRecycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PathReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(StorageServer).EmptyRecycle(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.Storage/EmptyRecycle",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServer).EmptyRecycle(ctx, req.(*PathReq))
-	}
-	return interceptor(ctx, in, info, handler)
+type Preview_ReadPreviewServer interface {
+	Send(*DataChunkResponse) error
+	grpc.ServerStream
+}
+
+type previewReadPreviewServer struct {
+	grpc.ServerStream
+}
+
+func (x *previewReadPreviewServer) Send(m *DataChunkResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func _Storage_SetACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ACLReq)
+func _Preview_PurgePreviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PathReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(StorageServer).SetACL(ctx, in)
+		return srv.(PreviewServer).PurgePreviews(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Storage/SetACL",
+		FullMethod: "/api.Preview/PurgePreviews",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServer).SetACL(ctx, req.(*ACLReq))
+		return srv.(PreviewServer).PurgePreviews(ctx, req.(*PathReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Storage_UpdateACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ACLReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(StorageServer).UpdateACL(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.Storage/UpdateACL",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServer).UpdateACL(ctx, req.(*ACLReq))
-	}
-	return interceptor(ctx, in, info, handler)
+var _Preview_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.Preview",
+	HandlerType: (*PreviewServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PurgePreviews",
+			Handler:    _Preview_PurgePreviews_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReadPreview",
+			Handler:       _Preview_ReadPreview_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.proto",
 }
 
-func _Storage_UnsetACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ACLReq)
-	if err := dec(in); err != nil {
+// SearchClient is the client API for Search service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SearchClient interface {
+	Search(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (Search_SearchClient, error)
+}
+
+type searchClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSearchClient(cc *grpc.ClientConn) SearchClient {
+	return &searchClient{cc}
+}
+
+func (c *searchClient) Search(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (Search_SearchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Search_serviceDesc.Streams[0], "/api.Search/Search", opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(StorageServer).UnsetACL(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.Storage/UnsetACL",
+	x := &searchSearchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServer).UnsetACL(ctx, req.(*ACLReq))
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return x, nil
 }
 
-func _Storage_GetQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QuotaReq)
-	if err := dec(in); err != nil {
+type Search_SearchClient interface {
+	Recv() (*MetadataResponse, error)
+	grpc.ClientStream
+}
+
+type searchSearchClient struct {
+	grpc.ClientStream
+}
+
+func (x *searchSearchClient) Recv() (*MetadataResponse, error) {
+	m := new(MetadataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(StorageServer).GetQuota(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.Storage/GetQuota",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServer).GetQuota(ctx, req.(*QuotaReq))
+	return m, nil
+}
+
+// SearchServer is the server API for Search service.
+type SearchServer interface {
+	Search(*SearchReq, Search_SearchServer) error
+}
+
+func RegisterSearchServer(s *grpc.Server, srv SearchServer) {
+	s.RegisterService(&_Search_serviceDesc, srv)
+}
+
+func _Search_Search_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(SearchServer).Search(m, &searchSearchServer{stream})
 }
 
-var _Storage_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "api.Storage",
-	HandlerType: (*StorageServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateDir",
-			Handler:    _Storage_CreateDir_Handler,
-		},
-		{
-			MethodName: "Delete",
-			Handler:    _Storage_Delete_Handler,
-		},
-		{
-			MethodName: "Move",
-			Handler:    _Storage_Move_Handler,
-		},
-		{
-			MethodName: "Inspect",
-			Handler:    _Storage_Inspect_Handler,
-		},
-		{
-			MethodName: "StartWriteTx",
-			Handler:    _Storage_StartWriteTx_Handler,
-		},
-		{
-			MethodName: "FinishWriteTx",
-			Handler:    _Storage_FinishWriteTx_Handler,
-		},
-		{
-			MethodName: "RestoreRevision",
-			Handler:    _Storage_RestoreRevision_Handler,
-		},
-		{
-			MethodName: "RestoreRecycleEntry",
-			Handler:    _Storage_RestoreRecycleEntry_Handler,
-		},
-		{
-			MethodName: "EmptyRecycle",
-			Handler:    _Storage_EmptyRecycle_Handler,
-		},
-		{
-			MethodName: "SetACL",
-			Handler:    _Storage_SetACL_Handler,
-		},
-		{
-			MethodName: "UpdateACL",
-			Handler:    _Storage_UpdateACL_Handler,
-		},
-		{
-			MethodName: "UnsetACL",
-			Handler:    _Storage_UnsetACL_Handler,
-		},
-		{
-			MethodName: "GetQuota",
-			Handler:    _Storage_GetQuota_Handler,
-		},
-	},
+type Search_SearchServer interface {
+	Send(*MetadataResponse) error
+	grpc.ServerStream
+}
+
+type searchSearchServer struct {
+	grpc.ServerStream
+}
+
+func (x *searchSearchServer) Send(m *MetadataResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Search_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.Search",
+	HandlerType: (*SearchServer)(nil),
+	Methods:     []grpc.MethodDesc{},
 	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "ListFolder",
-			Handler:       _Storage_ListFolder_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "WriteChunk",
-			Handler:       _Storage_WriteChunk_Handler,
-			ClientStreams: true,
-		},
-		{
-			StreamName:    "ReadFile",
-			Handler:       _Storage_ReadFile_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "ListRevisions",
-			Handler:       _Storage_ListRevisions_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "ReadRevision",
-			Handler:       _Storage_ReadRevision_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "ListRecycle",
-			Handler:       _Storage_ListRecycle_Handler,
+			StreamName:    "Search",
+			Handler:       _Search_Search_Handler,
 			ServerStreams: true,
 		},
 	},
 	Metadata: "api.proto",
 }
 
-// TaggerClient is the client API for Tagger service.
+// WebhooksClient is the client API for Webhooks service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type TaggerClient interface {
-	GetTags(ctx context.Context, in *TagReq, opts ...grpc.CallOption) (Tagger_GetTagsClient, error)
-	SetTag(ctx context.Context, in *TagReq, opts ...grpc.CallOption) (*EmptyResponse, error)
-	UnSetTag(ctx context.Context, in *TagReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+type WebhooksClient interface {
+	AddWebhook(ctx context.Context, in *NewWebhookReq, opts ...grpc.CallOption) (*WebhookResponse, error)
+	ListWebhooks(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (Webhooks_ListWebhooksClient, error)
+	RemoveWebhook(ctx context.Context, in *WebhookIDReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	ListDeadLetters(ctx context.Context, in *WebhookIDReq, opts ...grpc.CallOption) (Webhooks_ListDeadLettersClient, error)
+	RetryDeadLetter(ctx context.Context, in *DeadLetterIDReq, opts ...grpc.CallOption) (*EmptyResponse, error)
 }
 
-type taggerClient struct {
+type webhooksClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewTaggerClient(cc *grpc.ClientConn) TaggerClient {
-	return &taggerClient{cc}
+func NewWebhooksClient(cc *grpc.ClientConn) WebhooksClient {
+	return &webhooksClient{cc}
 }
 
-func (c *taggerClient) GetTags(ctx context.Context, in *TagReq, opts ...grpc.CallOption) (Tagger_GetTagsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Tagger_serviceDesc.Streams[0], "/api.Tagger/GetTags", opts...)
+func (c *webhooksClient) AddWebhook(ctx context.Context, in *NewWebhookReq, opts ...grpc.CallOption) (*WebhookResponse, error) {
+	out := new(WebhookResponse)
+	err := c.cc.Invoke(ctx, "/api.Webhooks/AddWebhook", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &taggerGetTagsClient{stream}
+	return out, nil
+}
+
+func (c *webhooksClient) ListWebhooks(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (Webhooks_ListWebhooksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Webhooks_serviceDesc.Streams[0], "/api.Webhooks/ListWebhooks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &webhooksListWebhooksClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -4338,204 +8975,235 @@ func (c *taggerClient) GetTags(ctx context.Context, in *TagReq, opts ...grpc.Cal
 	return x, nil
 }
 
-type Tagger_GetTagsClient interface {
-	Recv() (*TagResponse, error)
+type Webhooks_ListWebhooksClient interface {
+	Recv() (*WebhookResponse, error)
 	grpc.ClientStream
 }
 
-type taggerGetTagsClient struct {
+type webhooksListWebhooksClient struct {
 	grpc.ClientStream
 }
 
-func (x *taggerGetTagsClient) Recv() (*TagResponse, error) {
-	m := new(TagResponse)
+func (x *webhooksListWebhooksClient) Recv() (*WebhookResponse, error) {
+	m := new(WebhookResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *taggerClient) SetTag(ctx context.Context, in *TagReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+func (c *webhooksClient) RemoveWebhook(ctx context.Context, in *WebhookIDReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
 	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Tagger/SetTag", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/api.Webhooks/RemoveWebhook", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *taggerClient) UnSetTag(ctx context.Context, in *TagReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+func (c *webhooksClient) ListDeadLetters(ctx context.Context, in *WebhookIDReq, opts ...grpc.CallOption) (Webhooks_ListDeadLettersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Webhooks_serviceDesc.Streams[1], "/api.Webhooks/ListDeadLetters", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &webhooksListDeadLettersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Webhooks_ListDeadLettersClient interface {
+	Recv() (*DeadLetterResponse, error)
+	grpc.ClientStream
+}
+
+type webhooksListDeadLettersClient struct {
+	grpc.ClientStream
+}
+
+func (x *webhooksListDeadLettersClient) Recv() (*DeadLetterResponse, error) {
+	m := new(DeadLetterResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *webhooksClient) RetryDeadLetter(ctx context.Context, in *DeadLetterIDReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
 	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Tagger/UnSetTag", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/api.Webhooks/RetryDeadLetter", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// TaggerServer is the server API for Tagger service.
-type TaggerServer interface {
-	GetTags(*TagReq, Tagger_GetTagsServer) error
-	SetTag(context.Context, *TagReq) (*EmptyResponse, error)
-	UnSetTag(context.Context, *TagReq) (*EmptyResponse, error)
+// WebhooksServer is the server API for Webhooks service.
+type WebhooksServer interface {
+	AddWebhook(context.Context, *NewWebhookReq) (*WebhookResponse, error)
+	ListWebhooks(*EmptyReq, Webhooks_ListWebhooksServer) error
+	RemoveWebhook(context.Context, *WebhookIDReq) (*EmptyResponse, error)
+	ListDeadLetters(*WebhookIDReq, Webhooks_ListDeadLettersServer) error
+	RetryDeadLetter(context.Context, *DeadLetterIDReq) (*EmptyResponse, error)
 }
 
-func RegisterTaggerServer(s *grpc.Server, srv TaggerServer) {
-	s.RegisterService(&_Tagger_serviceDesc, srv)
+func RegisterWebhooksServer(s *grpc.Server, srv WebhooksServer) {
+	s.RegisterService(&_Webhooks_serviceDesc, srv)
+}
+
+func _Webhooks_AddWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewWebhookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhooksServer).AddWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Webhooks/AddWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhooksServer).AddWebhook(ctx, req.(*NewWebhookReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Tagger_GetTags_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(TagReq)
+func _Webhooks_ListWebhooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EmptyReq)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(TaggerServer).GetTags(m, &taggerGetTagsServer{stream})
+	return srv.(WebhooksServer).ListWebhooks(m, &webhooksListWebhooksServer{stream})
 }
 
-type Tagger_GetTagsServer interface {
-	Send(*TagResponse) error
+type Webhooks_ListWebhooksServer interface {
+	Send(*WebhookResponse) error
 	grpc.ServerStream
 }
 
-type taggerGetTagsServer struct {
+type webhooksListWebhooksServer struct {
 	grpc.ServerStream
 }
 
-func (x *taggerGetTagsServer) Send(m *TagResponse) error {
+func (x *webhooksListWebhooksServer) Send(m *WebhookResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Tagger_SetTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TagReq)
+func _Webhooks_RemoveWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookIDReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TaggerServer).SetTag(ctx, in)
+		return srv.(WebhooksServer).RemoveWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Tagger/SetTag",
+		FullMethod: "/api.Webhooks/RemoveWebhook",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TaggerServer).SetTag(ctx, req.(*TagReq))
+		return srv.(WebhooksServer).RemoveWebhook(ctx, req.(*WebhookIDReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Tagger_UnSetTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TagReq)
+func _Webhooks_ListDeadLetters_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WebhookIDReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WebhooksServer).ListDeadLetters(m, &webhooksListDeadLettersServer{stream})
+}
+
+type Webhooks_ListDeadLettersServer interface {
+	Send(*DeadLetterResponse) error
+	grpc.ServerStream
+}
+
+type webhooksListDeadLettersServer struct {
+	grpc.ServerStream
+}
+
+func (x *webhooksListDeadLettersServer) Send(m *DeadLetterResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Webhooks_RetryDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeadLetterIDReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TaggerServer).UnSetTag(ctx, in)
+		return srv.(WebhooksServer).RetryDeadLetter(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Tagger/UnSetTag",
+		FullMethod: "/api.Webhooks/RetryDeadLetter",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TaggerServer).UnSetTag(ctx, req.(*TagReq))
+		return srv.(WebhooksServer).RetryDeadLetter(ctx, req.(*DeadLetterIDReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Tagger_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "api.Tagger",
-	HandlerType: (*TaggerServer)(nil),
+var _Webhooks_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.Webhooks",
+	HandlerType: (*WebhooksServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "SetTag",
-			Handler:    _Tagger_SetTag_Handler,
+			MethodName: "AddWebhook",
+			Handler:    _Webhooks_AddWebhook_Handler,
 		},
 		{
-			MethodName: "UnSetTag",
-			Handler:    _Tagger_UnSetTag_Handler,
+			MethodName: "RemoveWebhook",
+			Handler:    _Webhooks_RemoveWebhook_Handler,
+		},
+		{
+			MethodName: "RetryDeadLetter",
+			Handler:    _Webhooks_RetryDeadLetter_Handler,
 		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "GetTags",
-			Handler:       _Tagger_GetTags_Handler,
+			StreamName:    "ListWebhooks",
+			Handler:       _Webhooks_ListWebhooks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListDeadLetters",
+			Handler:       _Webhooks_ListDeadLetters_Handler,
 			ServerStreams: true,
 		},
 	},
 	Metadata: "api.proto",
 }
 
-// ShareClient is the client API for Share service.
+// AuditClient is the client API for Audit service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ShareClient interface {
-	// with user context, relative to the user logged in
-	CreatePublicLink(ctx context.Context, in *NewLinkReq, opts ...grpc.CallOption) (*PublicLinkResponse, error)
-	UpdatePublicLink(ctx context.Context, in *UpdateLinkReq, opts ...grpc.CallOption) (*PublicLinkResponse, error)
-	InspectPublicLink(ctx context.Context, in *ShareIDReq, opts ...grpc.CallOption) (*PublicLinkResponse, error)
-	RevokePublicLink(ctx context.Context, in *ShareIDReq, opts ...grpc.CallOption) (*EmptyResponse, error)
-	ListPublicLinks(ctx context.Context, in *ListPublicLinksReq, opts ...grpc.CallOption) (Share_ListPublicLinksClient, error)
-	// with user context, relative to user logged in
-	AddFolderShare(ctx context.Context, in *NewFolderShareReq, opts ...grpc.CallOption) (*FolderShareResponse, error)
-	UpdateFolderShare(ctx context.Context, in *UpdateFolderShareReq, opts ...grpc.CallOption) (*FolderShareResponse, error)
-	ListFolderShares(ctx context.Context, in *ListFolderSharesReq, opts ...grpc.CallOption) (Share_ListFolderSharesClient, error)
-	UnshareFolder(ctx context.Context, in *UnshareFolderReq, opts ...grpc.CallOption) (*EmptyResponse, error)
-	GetFolderShare(ctx context.Context, in *ShareIDReq, opts ...grpc.CallOption) (*FolderShareResponse, error)
-	// with user context, relative to the user logged in, in this case, the receiver
-	ListReceivedShares(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (Share_ListReceivedSharesClient, error)
-	MountReceivedShare(ctx context.Context, in *ReceivedShareReq, opts ...grpc.CallOption) (*EmptyResponse, error)
-	UnmountReceivedShare(ctx context.Context, in *ReceivedShareReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+type AuditClient interface {
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsReq, opts ...grpc.CallOption) (Audit_ListAuditEventsClient, error)
 }
 
-type shareClient struct {
+type auditClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewShareClient(cc *grpc.ClientConn) ShareClient {
-	return &shareClient{cc}
-}
-
-func (c *shareClient) CreatePublicLink(ctx context.Context, in *NewLinkReq, opts ...grpc.CallOption) (*PublicLinkResponse, error) {
-	out := new(PublicLinkResponse)
-	err := c.cc.Invoke(ctx, "/api.Share/CreatePublicLink", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *shareClient) UpdatePublicLink(ctx context.Context, in *UpdateLinkReq, opts ...grpc.CallOption) (*PublicLinkResponse, error) {
-	out := new(PublicLinkResponse)
-	err := c.cc.Invoke(ctx, "/api.Share/UpdatePublicLink", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *shareClient) InspectPublicLink(ctx context.Context, in *ShareIDReq, opts ...grpc.CallOption) (*PublicLinkResponse, error) {
-	out := new(PublicLinkResponse)
-	err := c.cc.Invoke(ctx, "/api.Share/InspectPublicLink", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *shareClient) RevokePublicLink(ctx context.Context, in *ShareIDReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
-	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Share/RevokePublicLink", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func NewAuditClient(cc *grpc.ClientConn) AuditClient {
+	return &auditClient{cc}
 }
 
-func (c *shareClient) ListPublicLinks(ctx context.Context, in *ListPublicLinksReq, opts ...grpc.CallOption) (Share_ListPublicLinksClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Share_serviceDesc.Streams[0], "/api.Share/ListPublicLinks", opts...)
+func (c *auditClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsReq, opts ...grpc.CallOption) (Audit_ListAuditEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Audit_serviceDesc.Streams[0], "/api.Audit/ListAuditEvents", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &shareListPublicLinksClient{stream}
+	x := &auditListAuditEventsClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -4545,47 +9213,107 @@ func (c *shareClient) ListPublicLinks(ctx context.Context, in *ListPublicLinksRe
 	return x, nil
 }
 
-type Share_ListPublicLinksClient interface {
-	Recv() (*PublicLinkResponse, error)
+type Audit_ListAuditEventsClient interface {
+	Recv() (*AuditEventResponse, error)
 	grpc.ClientStream
 }
 
-type shareListPublicLinksClient struct {
+type auditListAuditEventsClient struct {
 	grpc.ClientStream
 }
 
-func (x *shareListPublicLinksClient) Recv() (*PublicLinkResponse, error) {
-	m := new(PublicLinkResponse)
+func (x *auditListAuditEventsClient) Recv() (*AuditEventResponse, error) {
+	m := new(AuditEventResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *shareClient) AddFolderShare(ctx context.Context, in *NewFolderShareReq, opts ...grpc.CallOption) (*FolderShareResponse, error) {
-	out := new(FolderShareResponse)
-	err := c.cc.Invoke(ctx, "/api.Share/AddFolderShare", in, out, opts...)
-	if err != nil {
-		return nil, err
+// AuditServer is the server API for Audit service.
+type AuditServer interface {
+	ListAuditEvents(*ListAuditEventsReq, Audit_ListAuditEventsServer) error
+}
+
+func RegisterAuditServer(s *grpc.Server, srv AuditServer) {
+	s.RegisterService(&_Audit_serviceDesc, srv)
+}
+
+func _Audit_ListAuditEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAuditEventsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return out, nil
+	return srv.(AuditServer).ListAuditEvents(m, &auditListAuditEventsServer{stream})
 }
 
-func (c *shareClient) UpdateFolderShare(ctx context.Context, in *UpdateFolderShareReq, opts ...grpc.CallOption) (*FolderShareResponse, error) {
-	out := new(FolderShareResponse)
-	err := c.cc.Invoke(ctx, "/api.Share/UpdateFolderShare", in, out, opts...)
+type Audit_ListAuditEventsServer interface {
+	Send(*AuditEventResponse) error
+	grpc.ServerStream
+}
+
+type auditListAuditEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *auditListAuditEventsServer) Send(m *AuditEventResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Audit_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.Audit",
+	HandlerType: (*AuditServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListAuditEvents",
+			Handler:       _Audit_ListAuditEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.proto",
+}
+
+// OCMClient is the client API for OCM service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OCMClient interface {
+	// outgoing shares
+	CreateOCMShare(ctx context.Context, in *NewOCMShareReq, opts ...grpc.CallOption) (*OCMShareResponse, error)
+	ListOCMShares(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (OCM_ListOCMSharesClient, error)
+	RemoveOCMShare(ctx context.Context, in *OCMShareIDReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// received shares
+	ListReceivedOCMShares(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (OCM_ListReceivedOCMSharesClient, error)
+	AcceptReceivedOCMShare(ctx context.Context, in *OCMShareIDReq, opts ...grpc.CallOption) (*OCMShareResponse, error)
+	RejectReceivedOCMShare(ctx context.Context, in *OCMShareIDReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// requests of the remote providers, they are not authenticated with a user token
+	AddReceivedOCMShare(ctx context.Context, in *IncomingOCMShareReq, opts ...grpc.CallOption) (*OCMShareResponse, error)
+	NotifyOCMShare(ctx context.Context, in *OCMNotificationReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+}
+
+type oCMClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOCMClient(cc *grpc.ClientConn) OCMClient {
+	return &oCMClient{cc}
+}
+
+func (c *oCMClient) CreateOCMShare(ctx context.Context, in *NewOCMShareReq, opts ...grpc.CallOption) (*OCMShareResponse, error) {
+	out := new(OCMShareResponse)
+	err := c.cc.Invoke(ctx, "/api.OCM/CreateOCMShare", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *shareClient) ListFolderShares(ctx context.Context, in *ListFolderSharesReq, opts ...grpc.CallOption) (Share_ListFolderSharesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Share_serviceDesc.Streams[1], "/api.Share/ListFolderShares", opts...)
+func (c *oCMClient) ListOCMShares(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (OCM_ListOCMSharesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_OCM_serviceDesc.Streams[0], "/api.OCM/ListOCMShares", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &shareListFolderSharesClient{stream}
+	x := &oCMListOCMSharesClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -4595,47 +9323,38 @@ func (c *shareClient) ListFolderShares(ctx context.Context, in *ListFolderShares
 	return x, nil
 }
 
-type Share_ListFolderSharesClient interface {
-	Recv() (*FolderShareResponse, error)
+type OCM_ListOCMSharesClient interface {
+	Recv() (*OCMShareResponse, error)
 	grpc.ClientStream
 }
 
-type shareListFolderSharesClient struct {
+type oCMListOCMSharesClient struct {
 	grpc.ClientStream
 }
 
-func (x *shareListFolderSharesClient) Recv() (*FolderShareResponse, error) {
-	m := new(FolderShareResponse)
+func (x *oCMListOCMSharesClient) Recv() (*OCMShareResponse, error) {
+	m := new(OCMShareResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *shareClient) UnshareFolder(ctx context.Context, in *UnshareFolderReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+func (c *oCMClient) RemoveOCMShare(ctx context.Context, in *OCMShareIDReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
 	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Share/UnshareFolder", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *shareClient) GetFolderShare(ctx context.Context, in *ShareIDReq, opts ...grpc.CallOption) (*FolderShareResponse, error) {
-	out := new(FolderShareResponse)
-	err := c.cc.Invoke(ctx, "/api.Share/GetFolderShare", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/api.OCM/RemoveOCMShare", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *shareClient) ListReceivedShares(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (Share_ListReceivedSharesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Share_serviceDesc.Streams[2], "/api.Share/ListReceivedShares", opts...)
+func (c *oCMClient) ListReceivedOCMShares(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (OCM_ListReceivedOCMSharesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_OCM_serviceDesc.Streams[1], "/api.OCM/ListReceivedOCMShares", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &shareListReceivedSharesClient{stream}
+	x := &oCMListReceivedOCMSharesClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -4645,394 +9364,333 @@ func (c *shareClient) ListReceivedShares(ctx context.Context, in *EmptyReq, opts
 	return x, nil
 }
 
-type Share_ListReceivedSharesClient interface {
-	Recv() (*ReceivedShareResponse, error)
+type OCM_ListReceivedOCMSharesClient interface {
+	Recv() (*OCMShareResponse, error)
 	grpc.ClientStream
 }
 
-type shareListReceivedSharesClient struct {
+type oCMListReceivedOCMSharesClient struct {
 	grpc.ClientStream
 }
 
-func (x *shareListReceivedSharesClient) Recv() (*ReceivedShareResponse, error) {
-	m := new(ReceivedShareResponse)
+func (x *oCMListReceivedOCMSharesClient) Recv() (*OCMShareResponse, error) {
+	m := new(OCMShareResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *shareClient) MountReceivedShare(ctx context.Context, in *ReceivedShareReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
-	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Share/MountReceivedShare", in, out, opts...)
+func (c *oCMClient) AcceptReceivedOCMShare(ctx context.Context, in *OCMShareIDReq, opts ...grpc.CallOption) (*OCMShareResponse, error) {
+	out := new(OCMShareResponse)
+	err := c.cc.Invoke(ctx, "/api.OCM/AcceptReceivedOCMShare", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *shareClient) UnmountReceivedShare(ctx context.Context, in *ReceivedShareReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+func (c *oCMClient) RejectReceivedOCMShare(ctx context.Context, in *OCMShareIDReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
 	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, "/api.Share/UnmountReceivedShare", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/api.OCM/RejectReceivedOCMShare", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// ShareServer is the server API for Share service.
-type ShareServer interface {
-	// with user context, relative to the user logged in
-	CreatePublicLink(context.Context, *NewLinkReq) (*PublicLinkResponse, error)
-	UpdatePublicLink(context.Context, *UpdateLinkReq) (*PublicLinkResponse, error)
-	InspectPublicLink(context.Context, *ShareIDReq) (*PublicLinkResponse, error)
-	RevokePublicLink(context.Context, *ShareIDReq) (*EmptyResponse, error)
-	ListPublicLinks(*ListPublicLinksReq, Share_ListPublicLinksServer) error
-	// with user context, relative to user logged in
-	AddFolderShare(context.Context, *NewFolderShareReq) (*FolderShareResponse, error)
-	UpdateFolderShare(context.Context, *UpdateFolderShareReq) (*FolderShareResponse, error)
-	ListFolderShares(*ListFolderSharesReq, Share_ListFolderSharesServer) error
-	UnshareFolder(context.Context, *UnshareFolderReq) (*EmptyResponse, error)
-	GetFolderShare(context.Context, *ShareIDReq) (*FolderShareResponse, error)
-	// with user context, relative to the user logged in, in this case, the receiver
-	ListReceivedShares(*EmptyReq, Share_ListReceivedSharesServer) error
-	MountReceivedShare(context.Context, *ReceivedShareReq) (*EmptyResponse, error)
-	UnmountReceivedShare(context.Context, *ReceivedShareReq) (*EmptyResponse, error)
-}
-
-func RegisterShareServer(s *grpc.Server, srv ShareServer) {
-	s.RegisterService(&_Share_serviceDesc, srv)
-}
-
-func _Share_CreatePublicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewLinkReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ShareServer).CreatePublicLink(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.Share/CreatePublicLink",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShareServer).CreatePublicLink(ctx, req.(*NewLinkReq))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Share_UpdatePublicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateLinkReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ShareServer).UpdatePublicLink(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.Share/UpdatePublicLink",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShareServer).UpdatePublicLink(ctx, req.(*UpdateLinkReq))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Share_InspectPublicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ShareIDReq)
-	if err := dec(in); err != nil {
+func (c *oCMClient) AddReceivedOCMShare(ctx context.Context, in *IncomingOCMShareReq, opts ...grpc.CallOption) (*OCMShareResponse, error) {
+	out := new(OCMShareResponse)
+	err := c.cc.Invoke(ctx, "/api.OCM/AddReceivedOCMShare", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ShareServer).InspectPublicLink(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.Share/InspectPublicLink",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShareServer).InspectPublicLink(ctx, req.(*ShareIDReq))
+	return out, nil
+}
+
+func (c *oCMClient) NotifyOCMShare(ctx context.Context, in *OCMNotificationReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.OCM/NotifyOCMShare", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Share_RevokePublicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ShareIDReq)
+// OCMServer is the server API for OCM service.
+type OCMServer interface {
+	// outgoing shares
+	CreateOCMShare(context.Context, *NewOCMShareReq) (*OCMShareResponse, error)
+	ListOCMShares(*EmptyReq, OCM_ListOCMSharesServer) error
+	RemoveOCMShare(context.Context, *OCMShareIDReq) (*EmptyResponse, error)
+	// received shares
+	ListReceivedOCMShares(*EmptyReq, OCM_ListReceivedOCMSharesServer) error
+	AcceptReceivedOCMShare(context.Context, *OCMShareIDReq) (*OCMShareResponse, error)
+	RejectReceivedOCMShare(context.Context, *OCMShareIDReq) (*EmptyResponse, error)
+	// requests of the remote providers, they are not authenticated with a user token
+	AddReceivedOCMShare(context.Context, *IncomingOCMShareReq) (*OCMShareResponse, error)
+	NotifyOCMShare(context.Context, *OCMNotificationReq) (*EmptyResponse, error)
+}
+
+func RegisterOCMServer(s *grpc.Server, srv OCMServer) {
+	s.RegisterService(&_OCM_serviceDesc, srv)
+}
+
+func _OCM_CreateOCMShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewOCMShareReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShareServer).RevokePublicLink(ctx, in)
+		return srv.(OCMServer).CreateOCMShare(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Share/RevokePublicLink",
+		FullMethod: "/api.OCM/CreateOCMShare",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShareServer).RevokePublicLink(ctx, req.(*ShareIDReq))
+		return srv.(OCMServer).CreateOCMShare(ctx, req.(*NewOCMShareReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Share_ListPublicLinks_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ListPublicLinksReq)
+func _OCM_ListOCMShares_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EmptyReq)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ShareServer).ListPublicLinks(m, &shareListPublicLinksServer{stream})
+	return srv.(OCMServer).ListOCMShares(m, &oCMListOCMSharesServer{stream})
 }
 
-type Share_ListPublicLinksServer interface {
-	Send(*PublicLinkResponse) error
+type OCM_ListOCMSharesServer interface {
+	Send(*OCMShareResponse) error
 	grpc.ServerStream
 }
 
-type shareListPublicLinksServer struct {
+type oCMListOCMSharesServer struct {
 	grpc.ServerStream
 }
 
-func (x *shareListPublicLinksServer) Send(m *PublicLinkResponse) error {
+func (x *oCMListOCMSharesServer) Send(m *OCMShareResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Share_AddFolderShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewFolderShareReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ShareServer).AddFolderShare(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.Share/AddFolderShare",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShareServer).AddFolderShare(ctx, req.(*NewFolderShareReq))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Share_UpdateFolderShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateFolderShareReq)
+func _OCM_RemoveOCMShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OCMShareIDReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShareServer).UpdateFolderShare(ctx, in)
+		return srv.(OCMServer).RemoveOCMShare(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Share/UpdateFolderShare",
+		FullMethod: "/api.OCM/RemoveOCMShare",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShareServer).UpdateFolderShare(ctx, req.(*UpdateFolderShareReq))
+		return srv.(OCMServer).RemoveOCMShare(ctx, req.(*OCMShareIDReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Share_ListFolderShares_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ListFolderSharesReq)
+func _OCM_ListReceivedOCMShares_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EmptyReq)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ShareServer).ListFolderShares(m, &shareListFolderSharesServer{stream})
+	return srv.(OCMServer).ListReceivedOCMShares(m, &oCMListReceivedOCMSharesServer{stream})
 }
 
-type Share_ListFolderSharesServer interface {
-	Send(*FolderShareResponse) error
+type OCM_ListReceivedOCMSharesServer interface {
+	Send(*OCMShareResponse) error
 	grpc.ServerStream
 }
 
-type shareListFolderSharesServer struct {
+type oCMListReceivedOCMSharesServer struct {
 	grpc.ServerStream
 }
 
-func (x *shareListFolderSharesServer) Send(m *FolderShareResponse) error {
+func (x *oCMListReceivedOCMSharesServer) Send(m *OCMShareResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Share_UnshareFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UnshareFolderReq)
+func _OCM_AcceptReceivedOCMShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OCMShareIDReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShareServer).UnshareFolder(ctx, in)
+		return srv.(OCMServer).AcceptReceivedOCMShare(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Share/UnshareFolder",
+		FullMethod: "/api.OCM/AcceptReceivedOCMShare",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShareServer).UnshareFolder(ctx, req.(*UnshareFolderReq))
+		return srv.(OCMServer).AcceptReceivedOCMShare(ctx, req.(*OCMShareIDReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Share_GetFolderShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ShareIDReq)
+func _OCM_RejectReceivedOCMShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OCMShareIDReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShareServer).GetFolderShare(ctx, in)
+		return srv.(OCMServer).RejectReceivedOCMShare(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Share/GetFolderShare",
+		FullMethod: "/api.OCM/RejectReceivedOCMShare",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShareServer).GetFolderShare(ctx, req.(*ShareIDReq))
+		return srv.(OCMServer).RejectReceivedOCMShare(ctx, req.(*OCMShareIDReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Share_ListReceivedShares_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(EmptyReq)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(ShareServer).ListReceivedShares(m, &shareListReceivedSharesServer{stream})
-}
-
-type Share_ListReceivedSharesServer interface {
-	Send(*ReceivedShareResponse) error
-	grpc.ServerStream
-}
-
-type shareListReceivedSharesServer struct {
-	grpc.ServerStream
-}
-
-func (x *shareListReceivedSharesServer) Send(m *ReceivedShareResponse) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func _Share_MountReceivedShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReceivedShareReq)
+func _OCM_AddReceivedOCMShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncomingOCMShareReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShareServer).MountReceivedShare(ctx, in)
+		return srv.(OCMServer).AddReceivedOCMShare(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Share/MountReceivedShare",
+		FullMethod: "/api.OCM/AddReceivedOCMShare",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShareServer).MountReceivedShare(ctx, req.(*ReceivedShareReq))
+		return srv.(OCMServer).AddReceivedOCMShare(ctx, req.(*IncomingOCMShareReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Share_UnmountReceivedShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReceivedShareReq)
+func _OCM_NotifyOCMShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OCMNotificationReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShareServer).UnmountReceivedShare(ctx, in)
+		return srv.(OCMServer).NotifyOCMShare(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Share/UnmountReceivedShare",
+		FullMethod: "/api.OCM/NotifyOCMShare",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShareServer).UnmountReceivedShare(ctx, req.(*ReceivedShareReq))
+		return srv.(OCMServer).NotifyOCMShare(ctx, req.(*OCMNotificationReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Share_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "api.Share",
-	HandlerType: (*ShareServer)(nil),
+var _OCM_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.OCM",
+	HandlerType: (*OCMServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "CreatePublicLink",
-			Handler:    _Share_CreatePublicLink_Handler,
-		},
-		{
-			MethodName: "UpdatePublicLink",
-			Handler:    _Share_UpdatePublicLink_Handler,
-		},
-		{
-			MethodName: "InspectPublicLink",
-			Handler:    _Share_InspectPublicLink_Handler,
-		},
-		{
-			MethodName: "RevokePublicLink",
-			Handler:    _Share_RevokePublicLink_Handler,
-		},
-		{
-			MethodName: "AddFolderShare",
-			Handler:    _Share_AddFolderShare_Handler,
+			MethodName: "CreateOCMShare",
+			Handler:    _OCM_CreateOCMShare_Handler,
 		},
 		{
-			MethodName: "UpdateFolderShare",
-			Handler:    _Share_UpdateFolderShare_Handler,
+			MethodName: "RemoveOCMShare",
+			Handler:    _OCM_RemoveOCMShare_Handler,
 		},
 		{
-			MethodName: "UnshareFolder",
-			Handler:    _Share_UnshareFolder_Handler,
+			MethodName: "AcceptReceivedOCMShare",
+			Handler:    _OCM_AcceptReceivedOCMShare_Handler,
 		},
 		{
-			MethodName: "GetFolderShare",
-			Handler:    _Share_GetFolderShare_Handler,
+			MethodName: "RejectReceivedOCMShare",
+			Handler:    _OCM_RejectReceivedOCMShare_Handler,
 		},
 		{
-			MethodName: "MountReceivedShare",
-			Handler:    _Share_MountReceivedShare_Handler,
+			MethodName: "AddReceivedOCMShare",
+			Handler:    _OCM_AddReceivedOCMShare_Handler,
 		},
 		{
-			MethodName: "UnmountReceivedShare",
-			Handler:    _Share_UnmountReceivedShare_Handler,
+			MethodName: "NotifyOCMShare",
+			Handler:    _OCM_NotifyOCMShare_Handler,
 		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "ListPublicLinks",
-			Handler:       _Share_ListPublicLinks_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "ListFolderShares",
-			Handler:       _Share_ListFolderShares_Handler,
+			StreamName:    "ListOCMShares",
+			Handler:       _OCM_ListOCMShares_Handler,
 			ServerStreams: true,
 		},
 		{
-			StreamName:    "ListReceivedShares",
-			Handler:       _Share_ListReceivedShares_Handler,
+			StreamName:    "ListReceivedOCMShares",
+			Handler:       _OCM_ListReceivedOCMShares_Handler,
 			ServerStreams: true,
 		},
 	},
 	Metadata: "api.proto",
 }
 
-// PreviewClient is the client API for Preview service.
+// ProjectClient is the client API for Project service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type PreviewClient interface {
-	ReadPreview(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Preview_ReadPreviewClient, error)
+type ProjectClient interface {
+	CreateProject(ctx context.Context, in *NewProjectReq, opts ...grpc.CallOption) (*ProjectResponse, error)
+	UpdateProject(ctx context.Context, in *UpdateProjectReq, opts ...grpc.CallOption) (*ProjectResponse, error)
+	DeleteProject(ctx context.Context, in *DeleteProjectReq, opts ...grpc.CallOption) (*EmptyResponse, error)
+	GetProject(ctx context.Context, in *ProjectReq, opts ...grpc.CallOption) (*ProjectResponse, error)
+	ListProjects(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (Project_ListProjectsClient, error)
 }
 
-type previewClient struct {
+type projectClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewPreviewClient(cc *grpc.ClientConn) PreviewClient {
-	return &previewClient{cc}
+func NewProjectClient(cc *grpc.ClientConn) ProjectClient {
+	return &projectClient{cc}
 }
 
-func (c *previewClient) ReadPreview(ctx context.Context, in *PathReq, opts ...grpc.CallOption) (Preview_ReadPreviewClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Preview_serviceDesc.Streams[0], "/api.Preview/ReadPreview", opts...)
+func (c *projectClient) CreateProject(ctx context.Context, in *NewProjectReq, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, "/api.Project/CreateProject", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &previewReadPreviewClient{stream}
+	return out, nil
+}
+
+func (c *projectClient) UpdateProject(ctx context.Context, in *UpdateProjectReq, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, "/api.Project/UpdateProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectClient) DeleteProject(ctx context.Context, in *DeleteProjectReq, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/api.Project/DeleteProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectClient) GetProject(ctx context.Context, in *ProjectReq, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, "/api.Project/GetProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectClient) ListProjects(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (Project_ListProjectsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Project_serviceDesc.Streams[0], "/api.Project/ListProjects", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &projectListProjectsClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -5042,61 +9700,154 @@ func (c *previewClient) ReadPreview(ctx context.Context, in *PathReq, opts ...gr
 	return x, nil
 }
 
-type Preview_ReadPreviewClient interface {
-	Recv() (*DataChunkResponse, error)
+type Project_ListProjectsClient interface {
+	Recv() (*ProjectResponse, error)
 	grpc.ClientStream
 }
 
-type previewReadPreviewClient struct {
+type projectListProjectsClient struct {
 	grpc.ClientStream
 }
 
-func (x *previewReadPreviewClient) Recv() (*DataChunkResponse, error) {
-	m := new(DataChunkResponse)
+func (x *projectListProjectsClient) Recv() (*ProjectResponse, error) {
+	m := new(ProjectResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-// PreviewServer is the server API for Preview service.
-type PreviewServer interface {
-	ReadPreview(*PathReq, Preview_ReadPreviewServer) error
+// ProjectServer is the server API for Project service.
+type ProjectServer interface {
+	CreateProject(context.Context, *NewProjectReq) (*ProjectResponse, error)
+	UpdateProject(context.Context, *UpdateProjectReq) (*ProjectResponse, error)
+	DeleteProject(context.Context, *DeleteProjectReq) (*EmptyResponse, error)
+	GetProject(context.Context, *ProjectReq) (*ProjectResponse, error)
+	ListProjects(*EmptyReq, Project_ListProjectsServer) error
 }
 
-func RegisterPreviewServer(s *grpc.Server, srv PreviewServer) {
-	s.RegisterService(&_Preview_serviceDesc, srv)
+func RegisterProjectServer(s *grpc.Server, srv ProjectServer) {
+	s.RegisterService(&_Project_serviceDesc, srv)
 }
 
-func _Preview_ReadPreview_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PathReq)
+func _Project_CreateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewProjectReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServer).CreateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Project/CreateProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServer).CreateProject(ctx, req.(*NewProjectReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Project_UpdateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProjectReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServer).UpdateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Project/UpdateProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServer).UpdateProject(ctx, req.(*UpdateProjectReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Project_DeleteProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProjectReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServer).DeleteProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Project/DeleteProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServer).DeleteProject(ctx, req.(*DeleteProjectReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Project_GetProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServer).GetProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Project/GetProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServer).GetProject(ctx, req.(*ProjectReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Project_ListProjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EmptyReq)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(PreviewServer).ReadPreview(m, &previewReadPreviewServer{stream})
+	return srv.(ProjectServer).ListProjects(m, &projectListProjectsServer{stream})
 }
 
-type Preview_ReadPreviewServer interface {
-	Send(*DataChunkResponse) error
+type Project_ListProjectsServer interface {
+	Send(*ProjectResponse) error
 	grpc.ServerStream
 }
 
-type previewReadPreviewServer struct {
+type projectListProjectsServer struct {
 	grpc.ServerStream
 }
 
-func (x *previewReadPreviewServer) Send(m *DataChunkResponse) error {
+func (x *projectListProjectsServer) Send(m *ProjectResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-var _Preview_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "api.Preview",
-	HandlerType: (*PreviewServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+var _Project_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.Project",
+	HandlerType: (*ProjectServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateProject",
+			Handler:    _Project_CreateProject_Handler,
+		},
+		{
+			MethodName: "UpdateProject",
+			Handler:    _Project_UpdateProject_Handler,
+		},
+		{
+			MethodName: "DeleteProject",
+			Handler:    _Project_DeleteProject_Handler,
+		},
+		{
+			MethodName: "GetProject",
+			Handler:    _Project_GetProject_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "ReadPreview",
-			Handler:       _Preview_ReadPreview_Handler,
+			StreamName:    "ListProjects",
+			Handler:       _Project_ListProjects_Handler,
 			ServerStreams: true,
 		},
 	},
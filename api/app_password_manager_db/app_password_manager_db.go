@@ -0,0 +1,221 @@
+package app_password_manager_db
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	gopath "path"
+	"time"
+
+	"github.com/cernbox/reva/api"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+)
+
+const passwordLength = 32
+const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+/*
+create table cbox_app_passwords (
+	id int not null auto_increment primary key,
+	owner varchar(64) not null,
+	label varchar(255) not null,
+	password char(64) not null,
+	read_only tinyint not null default 0,
+	path varchar(4096) not null default '',
+	ctime bigint not null,
+	atime bigint not null default 0,
+	index (owner, password)
+);
+*/
+
+type appPasswordManager struct {
+	db *sql.DB
+}
+
+func New(dbUsername, dbPassword, dbHost string, dbPort int, dbName string) (api.AppPasswordManager, error) {
+	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", dbUsername, dbPassword, dbHost, dbPort, dbName))
+	if err != nil {
+		return nil, err
+	}
+
+	return &appPasswordManager{db: db}, nil
+}
+
+func (m *appPasswordManager) CreateAppPassword(ctx context.Context, label string, readOnly bool, path string) (*api.AppPassword, string, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, "", err
+	}
+
+	if path != "" {
+		path = gopath.Clean(path)
+		if !gopath.IsAbs(path) {
+			err := api.NewError(api.PathInvalidError).WithMessage("app password path must be absolute: " + path)
+			l.Error("", zap.Error(err))
+			return nil, "", err
+		}
+	}
+
+	password, err := genPassword()
+	if err != nil {
+		l.Error("error generating password", zap.Error(err))
+		return nil, "", err
+	}
+
+	ctime := time.Now().Unix()
+	stmt, err := m.db.Prepare("insert into cbox_app_passwords set owner=?,label=?,password=?,read_only=?,path=?,ctime=?")
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, "", err
+	}
+
+	result, err := stmt.Exec(u.AccountId, label, hashPassword(password), readOnly, path, ctime)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, "", err
+	}
+
+	lastID, err := result.LastInsertId()
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, "", err
+	}
+
+	ap := &api.AppPassword{
+		Id:       fmt.Sprintf("%d", lastID),
+		OwnerId:  u.AccountId,
+		Label:    label,
+		ReadOnly: readOnly,
+		Path:     path,
+		Ctime:    uint64(ctime),
+	}
+	l.Info("app password created", zap.String("id", ap.Id), zap.String("owner", u.AccountId), zap.String("label", label))
+	return ap, password, nil
+}
+
+func (m *appPasswordManager) ListAppPasswords(ctx context.Context) ([]*api.AppPassword, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	query := "select id, owner, label, read_only, path, ctime, atime from cbox_app_passwords where owner=?"
+	rows, err := m.db.Query(query, u.AccountId)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	aps := []*api.AppPassword{}
+	for rows.Next() {
+		ap := &api.AppPassword{}
+		if err := rows.Scan(&ap.Id, &ap.OwnerId, &ap.Label, &ap.ReadOnly, &ap.Path, &ap.Ctime, &ap.Atime); err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		aps = append(aps, ap)
+	}
+	if err := rows.Err(); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	return aps, nil
+}
+
+func (m *appPasswordManager) RevokeAppPassword(ctx context.Context, id string) error {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	stmt, err := m.db.Prepare("delete from cbox_app_passwords where owner=? and id=?")
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	res, err := stmt.Exec(u.AccountId, id)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	rowCnt, err := res.RowsAffected()
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	if rowCnt == 0 {
+		err := api.NewError(api.AppPasswordNotFoundErrorCode)
+		l.Error("", zap.Error(err), zap.String("id", id))
+		return err
+	}
+	return nil
+}
+
+func (m *appPasswordManager) AuthenticateAppPassword(ctx context.Context, accountID, password string) (*api.AppPassword, error) {
+	l := ctx_zap.Extract(ctx)
+
+	ap := &api.AppPassword{}
+	query := "select id, owner, label, read_only, path, ctime, atime from cbox_app_passwords where owner=? and password=?"
+	if err := m.db.QueryRow(query, accountID, hashPassword(password)).Scan(&ap.Id, &ap.OwnerId, &ap.Label, &ap.ReadOnly, &ap.Path, &ap.Ctime, &ap.Atime); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, api.NewError(api.AppPasswordNotFoundErrorCode)
+		}
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	// the last access time is informative, failing to update it must not block the login.
+	atime := time.Now().Unix()
+	if _, err := m.db.Exec("update cbox_app_passwords set atime=? where id=?", atime, ap.Id); err != nil {
+		l.Warn("error updating app password access time", zap.Error(err), zap.String("id", ap.Id))
+	} else {
+		ap.Atime = uint64(atime)
+	}
+	return ap, nil
+}
+
+func getUserFromContext(ctx context.Context) (*api.User, error) {
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		return nil, api.NewError(api.ContextUserRequiredError)
+	}
+	return u, nil
+}
+
+func genPassword() (string, error) {
+	b := make([]byte, passwordLength)
+	max := big.NewInt(int64(len(letterBytes)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = letterBytes[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// hashPassword uses sha256 instead of bcrypt: app passwords are long random
+// secrets generated by us, so a slow hash does not add any protection and it would
+// force us to compare every password of the user on each login.
+func hashPassword(password string) string {
+	sum := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,28 @@
+package audit_sink_chain
+
+import (
+	"context"
+
+	"github.com/cernbox/reva/api"
+)
+
+type auditSink struct {
+	sinks []api.AuditSink
+}
+
+// New returns an audit sink that emits the events to all the given sinks.
+func New(sinks ...api.AuditSink) api.AuditSink {
+	return &auditSink{sinks: sinks}
+}
+
+// Emit emits the event to every sink, even if some of them fail,
+// and returns the last error.
+func (as *auditSink) Emit(ctx context.Context, e *api.AuditEvent) error {
+	var err error
+	for _, s := range as.sinks {
+		if sinkErr := s.Emit(ctx, e); sinkErr != nil {
+			err = sinkErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package audit_sink_file
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/cernbox/reva/api"
+)
+
+type auditSink struct {
+	sync.Mutex
+	file string
+	fd   *os.File
+}
+
+// New returns an audit sink that appends the events as JSON lines to file.
+// The file is opened in append mode, so it can be rotated with copytruncate.
+// The sink is also an api.AuditLog, the events are queried by scanning the file.
+func New(file string) (api.AuditSink, error) {
+	fd, err := os.OpenFile(file, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &auditSink{file: file, fd: fd}, nil
+}
+
+func (as *auditSink) Emit(ctx context.Context, e *api.AuditEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	as.Lock()
+	defer as.Unlock()
+	_, err = as.fd.Write(data)
+	return err
+}
+
+// ListAuditEvents returns the events matching the filter in chronological order,
+// if the filter has a limit only the most recent ones are returned.
+func (as *auditSink) ListAuditEvents(ctx context.Context, filter *api.ListAuditEventsReq) ([]*api.AuditEvent, error) {
+	fd, err := os.Open(as.file)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	events := []*api.AuditEvent{}
+	scanner := bufio.NewScanner(fd)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		e := &api.AuditEvent{}
+		if err := json.Unmarshal(scanner.Bytes(), e); err != nil {
+			// skip lines truncated by a crash or a rotation
+			continue
+		}
+		if !filter.Match(e) {
+			continue
+		}
+		events = append(events, e)
+		if filter.Limit > 0 && uint64(len(events)) > filter.Limit {
+			events = events[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,67 @@
+package audit_sink_file
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+)
+
+func TestListAuditEvents(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	sink, err := New(filepath.Join(dir, "audit.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	events := []*api.AuditEvent{
+		{Id: "1", Time: 100, AccountId: "alice", Action: "upload", Path: "/home/docs/a.txt"},
+		{Id: "2", Time: 200, AccountId: "bob", Action: "download", Path: "/home/docs/a.txt"},
+		{Id: "3", Time: 300, AccountId: "alice", Action: "move", Path: "/home/tmp/b.txt", Target: "/home/docs/b.txt"},
+		{Id: "4", Time: 400, AccountId: "alice", Action: "delete", Path: "/home/docsold"},
+	}
+	for _, e := range events {
+		if err := sink.Emit(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		filter   *api.ListAuditEventsReq
+		expected []string
+	}{
+		{&api.ListAuditEventsReq{}, []string{"1", "2", "3", "4"}},
+		{&api.ListAuditEventsReq{AccountId: "alice"}, []string{"1", "3", "4"}},
+		{&api.ListAuditEventsReq{Path: "/home/docs"}, []string{"1", "2", "3"}},
+		{&api.ListAuditEventsReq{Action: "download"}, []string{"2"}},
+		{&api.ListAuditEventsReq{From: 200, To: 300}, []string{"2", "3"}},
+		{&api.ListAuditEventsReq{AccountId: "alice", Limit: 2}, []string{"3", "4"}},
+	}
+	for i, test := range tests {
+		got, err := sink.(api.AuditLog).ListAuditEvents(ctx, test.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []string{}
+		for _, e := range got {
+			ids = append(ids, e.Id)
+		}
+		if len(ids) != len(test.expected) {
+			t.Fatalf("test %d: expected %v, got %v", i, test.expected, ids)
+		}
+		for j := range ids {
+			if ids[j] != test.expected[j] {
+				t.Fatalf("test %d: expected %v, got %v", i, test.expected, ids)
+			}
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package audit_sink_syslog
+
+import (
+	"context"
+	"encoding/json"
+	"log/syslog"
+
+	"github.com/cernbox/reva/api"
+)
+
+type auditSink struct {
+	writer *syslog.Writer
+}
+
+// New returns an audit sink that sends the events as JSON to syslog
+// with the authpriv facility. If network and raddr are empty
+// the local syslog daemon is used.
+func New(network, raddr, tag string) (api.AuditSink, error) {
+	writer, err := syslog.Dial(network, raddr, syslog.LOG_INFO|syslog.LOG_AUTHPRIV, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &auditSink{writer: writer}, nil
+}
+
+func (as *auditSink) Emit(ctx context.Context, e *api.AuditEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return as.writer.Info(string(data))
+}
@@ -0,0 +1,84 @@
+package audit_sink_webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"go.uber.org/zap"
+)
+
+type auditSink struct {
+	url    string
+	secret string
+	client *http.Client
+	queue  chan []byte
+	logger *zap.Logger
+}
+
+// New returns an audit sink that POSTs every event as JSON to url.
+// The events are sent in the background so a slow endpoint does not slow down
+// the calls, if the queue is full the new events are dropped.
+// If secret is set, the body is signed with HMAC-SHA256 in the X-Reva-Signature header.
+func New(url, secret string, timeout, queueSize int, logger *zap.Logger) api.AuditSink {
+	as := &auditSink{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: time.Duration(timeout) * time.Second},
+		queue:  make(chan []byte, queueSize),
+		logger: logger,
+	}
+	go as.send()
+	return as
+}
+
+func (as *auditSink) Emit(ctx context.Context, e *api.AuditEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	select {
+	case as.queue <- data:
+		return nil
+	default:
+		return fmt.Errorf("audit webhook queue is full, event dropped")
+	}
+}
+
+func (as *auditSink) send() {
+	for data := range as.queue {
+		if err := as.post(data); err != nil {
+			as.logger.Error("error sending audit event to webhook", zap.Error(err), zap.String("url", as.url))
+		}
+	}
+}
+
+func (as *auditSink) post(data []byte) error {
+	req, err := http.NewRequest("POST", as.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if as.secret != "" {
+		mac := hmac.New(sha256.New, []byte(as.secret))
+		mac.Write(data)
+		req.Header.Set("X-Reva-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	res, err := as.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package auth_manager_app_password
+
+import (
+	"context"
+
+	"github.com/cernbox/reva/api"
+)
+
+type authManager struct {
+	apm api.AppPasswordManager
+}
+
+// New returns an auth manager that authenticates users with their app passwords.
+// The returned user carries the restrictions of the app password.
+func New(apm api.AppPasswordManager) api.AuthManager {
+	return &authManager{apm: apm}
+}
+
+func (am *authManager) Authenticate(ctx context.Context, clientID, clientSecret string) (*api.User, error) {
+	ap, err := am.apm.AuthenticateAppPassword(ctx, clientID, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+	return &api.User{AccountId: ap.OwnerId, Groups: []string{}, ReadOnly: ap.ReadOnly, RestrictedPath: ap.Path, AppPassword: true}, nil
+}
@@ -0,0 +1,94 @@
+package auth_manager_app_password
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/app_password_manager_db"
+	"github.com/cernbox/reva/api/conformance"
+)
+
+type appPasswordManager struct {
+	ap     *api.AppPassword
+	secret string
+}
+
+func (apm *appPasswordManager) CreateAppPassword(ctx context.Context, label string, readOnly bool, path string) (*api.AppPassword, string, error) {
+	return nil, "", api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (apm *appPasswordManager) ListAppPasswords(ctx context.Context) ([]*api.AppPassword, error) {
+	return []*api.AppPassword{apm.ap}, nil
+}
+
+func (apm *appPasswordManager) RevokeAppPassword(ctx context.Context, id string) error {
+	return api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (apm *appPasswordManager) AuthenticateAppPassword(ctx context.Context, accountID, password string) (*api.AppPassword, error) {
+	if accountID != apm.ap.OwnerId || password != apm.secret {
+		return nil, api.NewError(api.AppPasswordNotFoundErrorCode)
+	}
+	return apm.ap, nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	apm := &appPasswordManager{ap: &api.AppPassword{Id: "1", OwnerId: "alice", Label: "laptop", ReadOnly: true, Path: "/home/photos"}, secret: "secret"}
+	am := New(apm)
+	ctx := context.Background()
+
+	// the user carries the restrictions of the app password
+	u, err := am.Authenticate(ctx, "alice", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.AccountId != "alice" || !u.ReadOnly || u.RestrictedPath != "/home/photos" {
+		t.Fatalf("expected read-only user restricted to /home/photos, got %+v", u)
+	}
+	if !api.IsUserRestricted(u) {
+		t.Fatal("expected user to be restricted")
+	}
+
+	if _, err := am.Authenticate(ctx, "alice", "other"); err == nil {
+		t.Fatal("expected wrong secret to be rejected")
+	}
+	if _, err := am.Authenticate(ctx, "bob", "secret"); err == nil {
+		t.Fatal("expected secret of another user to be rejected")
+	}
+}
+
+func TestUnrestrictedAppPassword(t *testing.T) {
+	apm := &appPasswordManager{ap: &api.AppPassword{Id: "1", OwnerId: "alice", Label: "laptop"}, secret: "secret"}
+	u, err := New(apm).Authenticate(context.Background(), "alice", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.ReadOnly || u.RestrictedPath != "" || !u.AppPassword {
+		t.Fatalf("expected app password user without restrictions, got %+v", u)
+	}
+
+	// it cannot act in place of the real login
+	ctx := api.ContextSetUser(context.Background(), u)
+	if _, err := api.CheckUnrestrictedUser(ctx, "app passwords cannot be managed"); !api.IsErrorCode(err, api.StoragePermissionDeniedErrorCode) {
+		t.Fatalf("expected permission denied, got %v", err)
+	}
+	if err := api.CheckUserScope(ctx, "/alice/dir", true); err != nil {
+		t.Fatalf("expected files to be writable, got %v", err)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	db := conformance.GetMySQL(t)
+	apm, err := app_password_manager_db.New(db.Username, db.Password, db.Host, db.Port, db.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := api.ContextSetUser(context.Background(), &api.User{AccountId: "alice"})
+	ap, secret, err := apm.CreateAppPassword(ctx, "conformance", false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer apm.RevokeAppPassword(ctx, ap.Id)
+	conformance.TestAuthManager(t, New(apm), "alice", secret, "not-"+secret)
+}
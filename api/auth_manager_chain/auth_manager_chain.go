@@ -0,0 +1,33 @@
+package auth_manager_chain
+
+import (
+	"context"
+
+	"github.com/cernbox/reva/api"
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+)
+
+type authManager struct {
+	managers []api.AuthManager
+}
+
+// New returns an auth manager that tries the given managers in order
+// and returns the user from the first one that accepts the credentials.
+func New(managers ...api.AuthManager) api.AuthManager {
+	return &authManager{managers: managers}
+}
+
+func (am *authManager) Authenticate(ctx context.Context, clientID, clientSecret string) (*api.User, error) {
+	l := ctx_zap.Extract(ctx)
+	err := error(api.NewError(api.UserNotFoundErrorCode))
+	for i, m := range am.managers {
+		var user *api.User
+		user, err = m.Authenticate(ctx, clientID, clientSecret)
+		if err == nil {
+			return user, nil
+		}
+		l.Debug("auth manager rejected credentials", zap.Int("index", i), zap.String("client_id", clientID), zap.Error(err))
+	}
+	return nil, err
+}
@@ -0,0 +1,27 @@
+package auth_manager_chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+)
+
+// passwordManager only accepts one password of one account.
+type passwordManager struct {
+	accountID, password string
+}
+
+func (am *passwordManager) Authenticate(ctx context.Context, clientID, clientSecret string) (*api.User, error) {
+	if clientID != am.accountID || clientSecret != am.password {
+		return nil, api.NewError(api.UserNotFoundErrorCode)
+	}
+	return &api.User{AccountId: clientID, Groups: []string{}}, nil
+}
+
+func TestConformance(t *testing.T) {
+	// the password of the second manager is accepted after the first one refused it
+	am := New(&passwordManager{"alice", "first"}, &passwordManager{"alice", "second"})
+	conformance.TestAuthManager(t, am, "alice", "second", "third")
+}
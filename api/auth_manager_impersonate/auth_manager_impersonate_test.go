@@ -0,0 +1,12 @@
+package auth_manager_impersonate
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	// any password is accepted
+	conformance.TestAuthManager(t, New(), "alice", "whatever", "")
+}
@@ -0,0 +1,21 @@
+package auth_manager_ldap
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/cernbox/reva/api/conformance"
+)
+
+// TestConformance authenticates the account REVA_TEST_LDAP_USERNAME with
+// REVA_TEST_LDAP_PASSWORD on the LDAP server at REVA_TEST_LDAP_HOSTNAME.
+func TestConformance(t *testing.T) {
+	env := conformance.GetEnv(t, "REVA_TEST_LDAP_HOSTNAME", "REVA_TEST_LDAP_PORT", "REVA_TEST_LDAP_BASEDN", "REVA_TEST_LDAP_FILTER",
+		"REVA_TEST_LDAP_BIND_USERNAME", "REVA_TEST_LDAP_BIND_PASSWORD", "REVA_TEST_LDAP_USERNAME", "REVA_TEST_LDAP_PASSWORD")
+	port, err := strconv.Atoi(env[1])
+	if err != nil {
+		t.Fatalf("invalid REVA_TEST_LDAP_PORT: %v", err)
+	}
+	am := New(env[0], port, env[2], env[3], env[4], env[5])
+	conformance.TestAuthManager(t, am, env[6], env[7], "not-"+env[7])
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// TrustedProxies are the networks of the proxies whose X-Forwarded-For is
+// believed. The header is set by the clients as they like, so it only tells
+// the IP of the client when it comes from one of our proxies.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies parses a list of IPs and CIDR networks,
+// the empty entries are skipped.
+func ParseTrustedProxies(list []string) (TrustedProxies, error) {
+	proxies := TrustedProxies{}
+	for _, entry := range list {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// Contains returns true if ip is the IP of a trusted proxy.
+func (t TrustedProxies) Contains(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range t {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the IP of the client of a request coming from remoteAddr,
+// an IP with or without port, with the X-Forwarded-For forwarded. The hops
+// of the header are followed back from the right as long as they are
+// trusted proxies, the first one that is not is the client.
+func (t TrustedProxies) ClientIP(remoteAddr, forwarded string) string {
+	ip := remoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		ip = host
+	}
+	if forwarded == "" || !t.Contains(ip) {
+		return ip
+	}
+	hops := strings.Split(forwarded, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		ip = hop
+		if !t.Contains(hop) {
+			break
+		}
+	}
+	return ip
+}
+
+// ClientIPHasher hashes the IPs of the clients, to tell them apart in the
+// statistics without keeping their IPs. The hash is keyed with a secret of
+// the server, as there are few enough IPs to find them back from a plain hash.
+type ClientIPHasher struct {
+	secret []byte
+}
+
+// NewClientIPHasher returns the hasher keyed with secret,
+// or nil if secret is empty.
+func NewClientIPHasher(secret string) *ClientIPHasher {
+	if secret == "" {
+		return nil
+	}
+	return &ClientIPHasher{secret: []byte(secret)}
+}
+
+// Hash returns the hash of ip, or an empty string if ip is empty
+// or h is nil, so no IP is recorded without a secret.
+func (h *ClientIPHasher) Hash(ip string) string {
+	if h == nil || ip == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// clientIPContext puts in ctx the IP of the client of the gRPC call, as
+// forwarded in the x-forwarded-for metadata by a trusted proxy like ocproxy,
+// or the address of the peer.
+func (t TrustedProxies) clientIPContext(ctx context.Context) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	forwarded := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		forwarded = strings.Join(md["x-forwarded-for"], ",")
+	}
+	return ContextSetClientIP(ctx, t.ClientIP(p.Addr.String(), forwarded))
+}
+
+// UnaryServerInterceptor resolves the IP of the client of the calls, see GetClientIP.
+func (t TrustedProxies) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(t.clientIPContext(ctx), req)
+	}
+}
+
+// StreamServerInterceptor resolves the IP of the client of the calls, see GetClientIP.
+func (t TrustedProxies) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = t.clientIPContext(stream.Context())
+		return handler(srv, wrapped)
+	}
+}
@@ -0,0 +1,196 @@
+// Package conformance checks that the implementations of the api interfaces
+// follow their documented semantics. Every driver runs the suite of its
+// interface in its own tests, giving its constructor:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.TestShareManager(t, func(t *testing.T, vfs api.VirtualStorage, um api.UserManager) api.ShareManager {
+//			return New(vfs, um)
+//		})
+//	}
+//
+// The managers are exercised on a MemoryStorage mounted in a virtual storage,
+// on behalf of the users alice, bob, carol and dave. Bob and dave are members
+// of the group team, and the project proj is owned by alice, administered by nobody else,
+// written by carol and read by bob.
+//
+// The helpers Check, ExpectCode and UserContext are shared
+// with the other tests of the drivers.
+package conformance
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/mount"
+	"github.com/cernbox/reva/api/virtual_storage"
+
+	"go.uber.org/zap"
+)
+
+const (
+	alice = "alice"
+	bob   = "bob"
+	carol = "carol"
+	dave  = "dave"
+
+	team    = "team"
+	project = "proj"
+)
+
+// UserContext returns a context carrying the user with its groups
+// in the suite, if any.
+func UserContext(accountID string) context.Context {
+	groups, _ := NewUserManager().GetUserGroups(context.Background(), accountID)
+	return api.ContextSetUser(context.Background(), &api.User{AccountId: accountID, Groups: groups})
+}
+
+// hasCode returns true if err is an api error with the code.
+func hasCode(err error, code api.ErrorCode) bool {
+	appError, ok := err.(api.AppError)
+	return ok && appError.Code == code
+}
+
+// ExpectCode fails the test if err is not an api error with the code.
+func ExpectCode(t *testing.T, err error, code api.ErrorCode) {
+	t.Helper()
+	if !hasCode(err, code) {
+		t.Fatalf("expected error %s, got %v", code, err)
+	}
+}
+
+// Check fails the test if err is not nil.
+func Check(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// env is the virtual storage the managers work on, with the
+// home folders of the users already created.
+type env struct {
+	vfs     api.VirtualStorage
+	storage *MemoryStorage
+	um      api.UserManager
+	pm      api.ProjectManager
+}
+
+func newEnv(t *testing.T) *env {
+	s := NewMemoryStorage()
+	vfs := virtual_storage.NewVFS(zap.NewNop(), nil)
+	Check(t, vfs.AddMount(context.Background(), mount.New("home", "/", nil, s)))
+	for _, u := range []string{alice, bob, carol} {
+		Check(t, vfs.CreateDir(context.Background(), "/"+u))
+	}
+	return &env{vfs: vfs, storage: s, um: NewUserManager(), pm: NewProjectManager()}
+}
+
+func (e *env) createDir(t *testing.T, p string) *api.Metadata {
+	t.Helper()
+	Check(t, e.vfs.CreateDir(context.Background(), p))
+	md, err := e.vfs.GetMetadata(context.Background(), p)
+	Check(t, err)
+	return md
+}
+
+func (e *env) upload(t *testing.T, p, content string) *api.Metadata {
+	t.Helper()
+	Check(t, e.vfs.Upload(context.Background(), p, ioutil.NopCloser(strings.NewReader(content))))
+	md, err := e.vfs.GetMetadata(context.Background(), p)
+	Check(t, err)
+	return md
+}
+
+// NewUserManager returns the user manager with the users of the suite.
+func NewUserManager() api.UserManager {
+	return &userManager{groups: map[string][]string{
+		alice: {},
+		bob:   {team, "proj-readers"},
+		carol: {"proj-writers"},
+		dave:  {team},
+	}}
+}
+
+type userManager struct {
+	groups map[string][]string
+}
+
+func (um *userManager) GetUserGroups(ctx context.Context, username string) ([]string, error) {
+	groups, ok := um.groups[username]
+	if !ok {
+		return nil, api.NewError(api.UserNotFoundErrorCode).WithMessage(username)
+	}
+	return groups, nil
+}
+
+func (um *userManager) IsInGroup(ctx context.Context, username, group string) (bool, error) {
+	groups, err := um.GetUserGroups(ctx, username)
+	if err != nil {
+		return false, err
+	}
+	for _, g := range groups {
+		if g == group {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NewProjectManager returns the project manager with the project of the suite.
+func NewProjectManager() api.ProjectManager {
+	return &projectManager{projects: []*api.Project{
+		{Name: project, Path: "/" + alice + "/" + project, Owner: alice, AdminGroup: "proj-admins", WritersGroup: "proj-writers", ReadersGroup: "proj-readers"},
+	}}
+}
+
+type projectManager struct {
+	projects []*api.Project
+}
+
+func (pm *projectManager) GetAllProjects(ctx context.Context) ([]*api.Project, error) {
+	return pm.projects, nil
+}
+
+func (pm *projectManager) GetProject(ctx context.Context, name string) (*api.Project, error) {
+	for _, p := range pm.projects {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return nil, api.NewError(api.ProjectNotFoundErrorCode).WithMessage(name)
+}
+
+func (pm *projectManager) CreateProject(ctx context.Context, project *api.Project) (*api.Project, error) {
+	if _, err := pm.GetProject(ctx, project.Name); err == nil {
+		return nil, api.NewError(api.ProjectAlreadyExistsErrorCode).WithMessage(project.Name)
+	}
+	newProject := *project
+	api.SetProjectDefaults(&newProject)
+	if err := api.CheckProjectOverlap(&newProject, pm.projects); err != nil {
+		return nil, err
+	}
+	pm.projects = append(pm.projects, &newProject)
+	return &newProject, nil
+}
+
+func (pm *projectManager) UpdateProject(ctx context.Context, project *api.Project) (*api.Project, error) {
+	p, err := pm.GetProject(ctx, project.Name)
+	if err != nil {
+		return nil, err
+	}
+	*p = *project
+	return p, nil
+}
+
+func (pm *projectManager) DeleteProject(ctx context.Context, name string) error {
+	for i, p := range pm.projects {
+		if p.Name == name {
+			pm.projects = append(pm.projects[:i], pm.projects[i+1:]...)
+			return nil
+		}
+	}
+	return api.NewError(api.ProjectNotFoundErrorCode).WithMessage(name)
+}
@@ -0,0 +1,13 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api"
+)
+
+func TestMemoryStorage(t *testing.T) {
+	TestStorage(t, func(t *testing.T) api.Storage {
+		return NewMemoryStorage()
+	})
+}
@@ -0,0 +1,50 @@
+package conformance
+
+import (
+	"os"
+	"strconv"
+	"testing"
+)
+
+// The drivers of external services, like EOS, MySQL or LDAP, run their suite
+// on the service configured with REVA_TEST_* environment variables, and skip
+// it when the service is not configured. The services must be dedicated to
+// the tests, as the suites write to them.
+
+// GetEnv returns the values of the environment variables,
+// the test is skipped if one of them is not set.
+func GetEnv(t *testing.T, names ...string) []string {
+	t.Helper()
+	values := []string{}
+	for _, name := range names {
+		v := os.Getenv(name)
+		if v == "" {
+			t.Skip(name + " is not set")
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+// MySQL is the database the drivers storing in MySQL are tested on,
+// their tables must already exist.
+type MySQL struct {
+	Username string
+	Password string
+	Host     string
+	Port     int
+	Name     string
+}
+
+// GetMySQL returns the database configured with REVA_TEST_MYSQL_HOST,
+// REVA_TEST_MYSQL_PORT, REVA_TEST_MYSQL_USERNAME, REVA_TEST_MYSQL_PASSWORD
+// and REVA_TEST_MYSQL_NAME, the test is skipped if they are not set.
+func GetMySQL(t *testing.T) *MySQL {
+	t.Helper()
+	env := GetEnv(t, "REVA_TEST_MYSQL_HOST", "REVA_TEST_MYSQL_PORT", "REVA_TEST_MYSQL_USERNAME", "REVA_TEST_MYSQL_PASSWORD", "REVA_TEST_MYSQL_NAME")
+	port, err := strconv.Atoi(env[1])
+	if err != nil {
+		t.Fatalf("invalid REVA_TEST_MYSQL_PORT: %v", err)
+	}
+	return &MySQL{Host: env[0], Port: port, Username: env[2], Password: env[3], Name: env[4]}
+}
@@ -0,0 +1,407 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	gopath "path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cernbox/reva/api"
+)
+
+// MemoryStorage is a storage keeping the files in memory, with revisions,
+// a recycle bin, file IDs that follow the moves and ACLs that can be inspected.
+// It is the storage the managers are tested on.
+type MemoryStorage struct {
+	sync.Mutex
+	nodes   map[string]*node
+	ids     map[string]*node
+	recycle []*recycleEntry
+	lastID  uint64
+	lastKey uint64
+}
+
+type node struct {
+	id        string
+	path      string
+	isDir     bool
+	data      []byte
+	mtime     int64
+	version   uint64
+	revisions []*revision
+	acl       map[string]api.SharePermissions
+}
+
+type revision struct {
+	key   string
+	data  []byte
+	mtime int64
+}
+
+type recycleEntry struct {
+	key   string
+	path  string
+	nodes []*node
+	mtime int64
+}
+
+// NewMemoryStorage returns an empty storage.
+func NewMemoryStorage() *MemoryStorage {
+	s := &MemoryStorage{nodes: map[string]*node{}, ids: map[string]*node{}}
+	s.add(&node{path: "/", isDir: true})
+	return s
+}
+
+// ACL returns the permissions granted on the path, by recipient like
+// USER:bob or GROUP:team.
+func (s *MemoryStorage) ACL(p string) map[string]api.SharePermissions {
+	s.Lock()
+	defer s.Unlock()
+	acl := map[string]api.SharePermissions{}
+	if n, ok := s.nodes[cleanPath(p)]; ok {
+		for k, v := range n.acl {
+			acl[k] = v
+		}
+	}
+	return acl
+}
+
+func cleanPath(p string) string {
+	return gopath.Join("/", p)
+}
+
+func (s *MemoryStorage) add(n *node) {
+	if n.id == "" {
+		s.lastID++
+		n.id = fmt.Sprintf("%d", s.lastID)
+	}
+	if n.acl == nil {
+		n.acl = map[string]api.SharePermissions{}
+	}
+	s.touch(n)
+	s.nodes[n.path] = n
+	s.ids[n.id] = n
+}
+
+func (s *MemoryStorage) touch(n *node) {
+	s.lastKey++
+	n.version = s.lastKey
+	n.mtime = time.Now().Unix()
+}
+
+// children returns the node at p and all the nodes below it.
+func (s *MemoryStorage) children(p string) []*node {
+	nodes := []*node{}
+	for np, n := range s.nodes {
+		if np == p || strings.HasPrefix(np, strings.TrimSuffix(p, "/")+"/") {
+			nodes = append(nodes, n)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].path < nodes[j].path })
+	return nodes
+}
+
+func (s *MemoryStorage) getNode(p string) (*node, error) {
+	n, ok := s.nodes[cleanPath(p)]
+	if !ok {
+		return nil, api.NewError(api.StorageNotFoundErrorCode).WithMessage(p)
+	}
+	return n, nil
+}
+
+// checkParent checks that the parent of p exists and p does not.
+func (s *MemoryStorage) checkParent(p string) error {
+	if parent, ok := s.nodes[gopath.Dir(p)]; !ok || !parent.isDir {
+		return api.NewError(api.StorageNotFoundErrorCode).WithMessage(gopath.Dir(p))
+	}
+	if _, ok := s.nodes[p]; ok {
+		return api.NewError(api.StorageAlreadyExistsErrorCode).WithMessage(p)
+	}
+	return nil
+}
+
+func (n *node) toMetadata() *api.Metadata {
+	md := &api.Metadata{
+		Id:          n.id,
+		Path:        n.path,
+		Size:        uint64(len(n.data)),
+		Mtime:       uint64(n.mtime),
+		IsDir:       n.isDir,
+		Etag:        fmt.Sprintf("%d", n.version),
+		IsShareable: true,
+	}
+	return md
+}
+
+func (s *MemoryStorage) CreateDir(ctx context.Context, name string) error {
+	s.Lock()
+	defer s.Unlock()
+	name = cleanPath(name)
+	if err := s.checkParent(name); err != nil {
+		return err
+	}
+	s.add(&node{path: name, isDir: true})
+	return nil
+}
+
+func (s *MemoryStorage) Delete(ctx context.Context, name string) error {
+	s.Lock()
+	defer s.Unlock()
+	n, err := s.getNode(name)
+	if err != nil {
+		return err
+	}
+	if n.path == "/" {
+		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("the root can not be deleted")
+	}
+
+	nodes := s.children(n.path)
+	for _, c := range nodes {
+		delete(s.nodes, c.path)
+		delete(s.ids, c.id)
+	}
+	s.lastKey++
+	s.recycle = append(s.recycle, &recycleEntry{key: fmt.Sprintf("%d", s.lastKey), path: n.path, nodes: nodes, mtime: time.Now().Unix()})
+	return nil
+}
+
+func (s *MemoryStorage) Move(ctx context.Context, oldName, newName string) error {
+	s.Lock()
+	defer s.Unlock()
+	n, err := s.getNode(oldName)
+	if err != nil {
+		return err
+	}
+	newName = cleanPath(newName)
+	if err := s.checkParent(newName); err != nil {
+		return err
+	}
+	if newName == n.path || strings.HasPrefix(newName, n.path+"/") {
+		return api.NewError(api.PathInvalidError).WithMessage("can not move a folder inside itself")
+	}
+
+	oldName = n.path
+	for _, c := range s.children(oldName) {
+		delete(s.nodes, c.path)
+		c.path = newName + strings.TrimPrefix(c.path, oldName)
+		s.nodes[c.path] = c
+	}
+	return nil
+}
+
+func (s *MemoryStorage) GetMetadata(ctx context.Context, name string) (*api.Metadata, error) {
+	s.Lock()
+	defer s.Unlock()
+	n, err := s.getNode(name)
+	if err != nil {
+		return nil, err
+	}
+	return n.toMetadata(), nil
+}
+
+func (s *MemoryStorage) ListFolder(ctx context.Context, name string) ([]*api.Metadata, error) {
+	s.Lock()
+	defer s.Unlock()
+	n, err := s.getNode(name)
+	if err != nil {
+		return nil, err
+	}
+	if !n.isDir {
+		return nil, api.NewError(api.PathInvalidError).WithMessage("not a folder: " + name)
+	}
+
+	mds := []*api.Metadata{}
+	for _, c := range s.children(n.path) {
+		if c != n && gopath.Dir(c.path) == n.path {
+			mds = append(mds, c.toMetadata())
+		}
+	}
+	return mds, nil
+}
+
+func (s *MemoryStorage) Upload(ctx context.Context, name string, r io.ReadCloser) error {
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	name = cleanPath(name)
+	if n, ok := s.nodes[name]; ok {
+		if n.isDir {
+			return api.NewError(api.StorageAlreadyExistsErrorCode).WithMessage("folder exists: " + name)
+		}
+		// the previous content becomes a revision
+		s.lastKey++
+		n.revisions = append(n.revisions, &revision{key: fmt.Sprintf("%d", s.lastKey), data: n.data, mtime: n.mtime})
+		n.data = data
+		s.touch(n)
+		return nil
+	}
+
+	if err := s.checkParent(name); err != nil {
+		return err
+	}
+	s.add(&node{path: name, data: data})
+	return nil
+}
+
+func (s *MemoryStorage) Download(ctx context.Context, name string) (io.ReadCloser, error) {
+	s.Lock()
+	defer s.Unlock()
+	n, err := s.getNode(name)
+	if err != nil {
+		return nil, err
+	}
+	if n.isDir {
+		return nil, api.NewError(api.PathInvalidError).WithMessage("not a file: " + name)
+	}
+	return ioutil.NopCloser(bytes.NewReader(n.data)), nil
+}
+
+func (s *MemoryStorage) getRevision(path, revisionKey string) (*node, *revision, error) {
+	n, err := s.getNode(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, rev := range n.revisions {
+		if rev.key == revisionKey {
+			return n, rev, nil
+		}
+	}
+	return nil, nil, api.NewError(api.StorageNotFoundErrorCode).WithMessage("revision " + revisionKey)
+}
+
+func (s *MemoryStorage) ListRevisions(ctx context.Context, path string) ([]*api.Revision, error) {
+	s.Lock()
+	defer s.Unlock()
+	n, err := s.getNode(path)
+	if err != nil {
+		return nil, err
+	}
+	revisions := []*api.Revision{}
+	for _, rev := range n.revisions {
+		revisions = append(revisions, &api.Revision{RevKey: rev.key, Size: uint64(len(rev.data)), Mtime: uint64(rev.mtime)})
+	}
+	return revisions, nil
+}
+
+func (s *MemoryStorage) DownloadRevision(ctx context.Context, path, revisionKey string) (io.ReadCloser, error) {
+	s.Lock()
+	defer s.Unlock()
+	_, rev, err := s.getRevision(path, revisionKey)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(rev.data)), nil
+}
+
+func (s *MemoryStorage) RestoreRevision(ctx context.Context, path, revisionKey string) error {
+	s.Lock()
+	defer s.Unlock()
+	n, rev, err := s.getRevision(path, revisionKey)
+	if err != nil {
+		return err
+	}
+	// the current content becomes a revision, like a new upload
+	s.lastKey++
+	n.revisions = append(n.revisions, &revision{key: fmt.Sprintf("%d", s.lastKey), data: n.data, mtime: n.mtime})
+	n.data = rev.data
+	s.touch(n)
+	return nil
+}
+
+func (s *MemoryStorage) ListRecycle(ctx context.Context, path string) ([]*api.RecycleEntry, error) {
+	s.Lock()
+	defer s.Unlock()
+	entries := []*api.RecycleEntry{}
+	for _, e := range s.recycle {
+		entries = append(entries, &api.RecycleEntry{RestorePath: e.path, RestoreKey: e.key, Size: uint64(len(e.nodes[0].data)), DelMtime: uint64(e.mtime), IsDir: e.nodes[0].isDir, FileId: e.nodes[0].id})
+	}
+	return entries, nil
+}
+
+func (s *MemoryStorage) RestoreRecycleEntry(ctx context.Context, restoreKey string) error {
+	s.Lock()
+	defer s.Unlock()
+	for i, e := range s.recycle {
+		if e.key != restoreKey {
+			continue
+		}
+		if err := s.checkParent(e.path); err != nil {
+			return err
+		}
+		for _, n := range e.nodes {
+			s.nodes[n.path] = n
+			s.ids[n.id] = n
+		}
+		s.recycle = append(s.recycle[:i], s.recycle[i+1:]...)
+		return nil
+	}
+	return api.NewError(api.StorageNotFoundErrorCode).WithMessage("recycle entry " + restoreKey)
+}
+
+func (s *MemoryStorage) EmptyRecycle(ctx context.Context, path string) error {
+	s.Lock()
+	defer s.Unlock()
+	s.recycle = nil
+	return nil
+}
+
+func (s *MemoryStorage) GetPathByID(ctx context.Context, id string) (string, error) {
+	s.Lock()
+	defer s.Unlock()
+	n, ok := s.ids[id]
+	if !ok {
+		return "", api.NewError(api.StorageNotFoundErrorCode).WithMessage("id " + id)
+	}
+	return n.path, nil
+}
+
+func aclKey(recipient *api.ShareRecipient) string {
+	return recipient.Type.String() + ":" + recipient.Identity
+}
+
+func (s *MemoryStorage) SetACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+	s.Lock()
+	defer s.Unlock()
+	n, err := s.getNode(path)
+	if err != nil {
+		return err
+	}
+	n.acl[aclKey(recipient)] = permissions
+	return nil
+}
+
+func (s *MemoryStorage) UnsetACL(ctx context.Context, path string, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+	s.Lock()
+	defer s.Unlock()
+	n, err := s.getNode(path)
+	if err != nil {
+		return err
+	}
+	delete(n.acl, aclKey(recipient))
+	return nil
+}
+
+func (s *MemoryStorage) UpdateACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+	return s.SetACL(ctx, path, permissions, recipient, shareList)
+}
+
+func (s *MemoryStorage) GetQuota(ctx context.Context, path string) (int, int, error) {
+	s.Lock()
+	defer s.Unlock()
+	used := 0
+	for _, n := range s.nodes {
+		used += len(n.data)
+	}
+	return 1 << 30, used, nil
+}
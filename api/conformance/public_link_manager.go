@@ -0,0 +1,374 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cernbox/reva/api"
+)
+
+// TestPublicLinkManager runs the suite of api.PublicLinkManager on the managers returned by newManager,
+// which must enforce the password policy it is given and hash the client IPs with ipHasher.
+func TestPublicLinkManager(t *testing.T, newManager func(t *testing.T, vfs api.VirtualStorage, policy *api.PasswordPolicy, ipHasher *api.ClientIPHasher) api.PublicLinkManager) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, e *env, lm api.PublicLinkManager)
+	}{
+		{"CreatePublicLink", testCreatePublicLink},
+		{"AuthenticatePublicLink", testAuthenticatePublicLink},
+		{"UpdatePublicLink", testUpdatePublicLink},
+		{"RevokePublicLink", testRevokePublicLink},
+		{"PublicLinkStatistics", testPublicLinkStatistics},
+		{"PublicLinkLifecycle", testPublicLinkLifecycle},
+		{"PublicLinkTransfer", testPublicLinkTransfer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv(t)
+			tt.test(t, e, newManager(t, e.vfs, nil, testIPHasher))
+		})
+	}
+	t.Run("PasswordPolicy", func(t *testing.T) {
+		e := newEnv(t)
+		policy := &api.PasswordPolicy{MinLength: 8, MinClasses: 3, Banned: []string{"Password123!"}, RequiredForWritable: true, HashCost: 4}
+		testPasswordPolicy(t, e, newManager(t, e.vfs, policy, testIPHasher), policy)
+	})
+}
+
+var testIPHasher = api.NewClientIPHasher("secret")
+
+func expectLinkIDs(t *testing.T, links []*api.PublicLink, ids ...string) {
+	t.Helper()
+	got := []string{}
+	for _, pl := range links {
+		got = append(got, pl.Id)
+	}
+	expectIDs(t, got, ids)
+}
+
+func testCreatePublicLink(t *testing.T, e *env, lm api.PublicLinkManager) {
+	ctx := UserContext(alice)
+	md := e.createDir(t, "/alice/dir")
+	e.upload(t, "/alice/file.txt", "hello")
+
+	pl, err := lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{ReadOnly: true})
+	Check(t, err)
+	if pl.Id == "" || pl.Token == "" || pl.OwnerId != alice || pl.Path != md.Id || pl.Name != "dir" {
+		t.Fatalf("expected link to %s owned by alice, got %+v", md.Id, pl)
+	}
+	if !pl.ReadOnly || pl.DropOnly || pl.Protected || pl.ItemType != api.PublicLink_FOLDER {
+		t.Fatalf("expected unprotected read-only link to a folder, got %+v", pl)
+	}
+
+	filePL, err := lm.CreatePublicLink(ctx, "/alice/file.txt", &api.PublicLinkOptions{ReadOnly: true})
+	Check(t, err)
+	if filePL.ItemType != api.PublicLink_FILE || filePL.Name != "file.txt" || filePL.Token == pl.Token {
+		t.Fatalf("expected a new link to a file, got %+v", filePL)
+	}
+
+	dropPL, err := lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{DropOnly: true, NotifyUploads: true})
+	Check(t, err)
+	if dropPL.ReadOnly || !dropPL.DropOnly || !dropPL.NotifyUploads {
+		t.Fatalf("expected drop-only link notifying the uploads, got %+v", dropPL)
+	}
+
+	_, err = lm.CreatePublicLink(ctx, "/alice/missing", &api.PublicLinkOptions{})
+	ExpectCode(t, err, api.StorageNotFoundErrorCode)
+	_, err = lm.CreatePublicLink(context.Background(), "/alice/dir", &api.PublicLinkOptions{})
+	ExpectCode(t, err, api.ContextUserRequiredError)
+
+	got, err := lm.InspectPublicLink(ctx, pl.Id)
+	Check(t, err)
+	if got.Token != pl.Token || got.Path != pl.Path {
+		t.Fatalf("expected link %+v, got %+v", pl, got)
+	}
+	got, err = lm.InspectPublicLinkByToken(UserContext(bob), pl.Token)
+	Check(t, err)
+	if got.Id != pl.Id {
+		t.Fatalf("expected link %+v, got %+v", pl, got)
+	}
+
+	// links are only visible to their owner, but anybody can use their token
+	_, err = lm.InspectPublicLink(UserContext(bob), pl.Id)
+	ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+	_, err = lm.InspectPublicLink(ctx, "999999")
+	ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+	_, err = lm.InspectPublicLinkByToken(ctx, "missing")
+	ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+
+	links, err := lm.ListPublicLinks(ctx, "")
+	Check(t, err)
+	expectLinkIDs(t, links, pl.Id, filePL.Id, dropPL.Id)
+	links, err = lm.ListPublicLinks(ctx, "/alice/dir")
+	Check(t, err)
+	expectLinkIDs(t, links, pl.Id, dropPL.Id)
+	links, err = lm.ListPublicLinks(UserContext(bob), "")
+	Check(t, err)
+	expectLinkIDs(t, links)
+}
+
+func testAuthenticatePublicLink(t *testing.T, e *env, lm api.PublicLinkManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/dir")
+
+	pl, err := lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{ReadOnly: true, Password: "secret"})
+	Check(t, err)
+	if !pl.Protected {
+		t.Fatalf("expected protected link, got %+v", pl)
+	}
+	protected, err := lm.IsPublicLinkProtected(context.Background(), pl.Token)
+	Check(t, err)
+	if !protected {
+		t.Fatal("expected link to be protected")
+	}
+
+	_, err = lm.AuthenticatePublicLink(context.Background(), pl.Token, "wrong")
+	ExpectCode(t, err, api.PublicLinkInvalidPasswordErrorCode)
+	got, err := lm.AuthenticatePublicLink(context.Background(), pl.Token, "secret")
+	Check(t, err)
+	if got.Id != pl.Id {
+		t.Fatalf("expected link %+v, got %+v", pl, got)
+	}
+	_, err = lm.AuthenticatePublicLink(context.Background(), "missing", "secret")
+	ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+	_, err = lm.IsPublicLinkProtected(context.Background(), "missing")
+	ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+
+	open, err := lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{ReadOnly: true})
+	Check(t, err)
+	_, err = lm.AuthenticatePublicLink(context.Background(), open.Token, "")
+	Check(t, err)
+
+	expired, err := lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{ReadOnly: true, Expiration: uint64(time.Now().Unix() - 3600)})
+	Check(t, err)
+	_, err = lm.AuthenticatePublicLink(context.Background(), expired.Token, "")
+	ExpectCode(t, err, api.PublicLinkInvalidExpireDateErrorCode)
+}
+
+func testUpdatePublicLink(t *testing.T, e *env, lm api.PublicLinkManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/dir")
+	pl, err := lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{ReadOnly: true, Password: "secret"})
+	Check(t, err)
+
+	expiration := uint64(time.Now().Unix() + 3600)
+	updated, err := lm.UpdatePublicLink(ctx, pl.Id, &api.PublicLinkOptions{UpdateReadOnly: true, ReadOnly: false, UpdateExpiration: true, Expiration: expiration})
+	Check(t, err)
+	if updated.ReadOnly || updated.Expires != expiration || !updated.Protected || updated.Token != pl.Token {
+		t.Fatalf("expected read-write protected link expiring at %d, got %+v", expiration, updated)
+	}
+
+	// the password is kept unless updated
+	_, err = lm.AuthenticatePublicLink(context.Background(), pl.Token, "secret")
+	Check(t, err)
+	_, err = lm.UpdatePublicLink(ctx, pl.Id, &api.PublicLinkOptions{UpdatePassword: true, Password: "other"})
+	Check(t, err)
+	_, err = lm.AuthenticatePublicLink(context.Background(), pl.Token, "secret")
+	ExpectCode(t, err, api.PublicLinkInvalidPasswordErrorCode)
+	_, err = lm.AuthenticatePublicLink(context.Background(), pl.Token, "other")
+	Check(t, err)
+
+	// an empty password removes the protection
+	updated, err = lm.UpdatePublicLink(ctx, pl.Id, &api.PublicLinkOptions{UpdatePassword: true})
+	Check(t, err)
+	if updated.Protected {
+		t.Fatalf("expected unprotected link, got %+v", updated)
+	}
+
+	// read-write links can be turned into drop-only ones notifying the uploads
+	updated, err = lm.UpdatePublicLink(ctx, pl.Id, &api.PublicLinkOptions{UpdateDropOnly: true, DropOnly: true, UpdateNotifyUploads: true, NotifyUploads: true})
+	Check(t, err)
+	if updated.ReadOnly || !updated.DropOnly || !updated.NotifyUploads {
+		t.Fatalf("expected drop-only link notifying the uploads, got %+v", updated)
+	}
+	updated, err = lm.UpdatePublicLink(ctx, pl.Id, &api.PublicLinkOptions{UpdateNotifyUploads: true})
+	Check(t, err)
+	if !updated.DropOnly || updated.NotifyUploads {
+		t.Fatalf("expected drop-only link not notifying the uploads, got %+v", updated)
+	}
+
+	_, err = lm.UpdatePublicLink(UserContext(bob), pl.Id, &api.PublicLinkOptions{UpdateReadOnly: true, ReadOnly: true})
+	ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+}
+
+func testRevokePublicLink(t *testing.T, e *env, lm api.PublicLinkManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/dir")
+	pl, err := lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{ReadOnly: true})
+	Check(t, err)
+
+	ExpectCode(t, lm.RevokePublicLink(UserContext(bob), pl.Id), api.PublicLinkNotFoundErrorCode)
+	Check(t, lm.RevokePublicLink(ctx, pl.Id))
+	_, err = lm.InspectPublicLink(ctx, pl.Id)
+	ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+	_, err = lm.InspectPublicLinkByToken(ctx, pl.Token)
+	ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+	_, err = lm.AuthenticatePublicLink(context.Background(), pl.Token, "")
+	ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+	ExpectCode(t, lm.RevokePublicLink(ctx, pl.Id), api.PublicLinkNotFoundErrorCode)
+}
+
+func testPublicLinkStatistics(t *testing.T, e *env, lm api.PublicLinkManager) {
+	ctx := UserContext(alice)
+	e.upload(t, "/alice/file.txt", "hello")
+	pl, err := lm.CreatePublicLink(ctx, "/alice/file.txt", &api.PublicLinkOptions{ReadOnly: true, MaxDownloads: 2})
+	Check(t, err)
+	if pl.MaxDownloads != 2 || pl.Downloads != 0 || pl.Views != 0 || pl.LastAccess != 0 {
+		t.Fatalf("expected link without accesses limited to 2 downloads, got %+v", pl)
+	}
+
+	// the accesses are recorded with the hash of the IP of the client
+	clientCtx := api.ContextSetClientIP(context.Background(), "192.0.2.1")
+	got, err := lm.AuthenticatePublicLink(clientCtx, pl.Token, "")
+	Check(t, err)
+	if got.Views != 1 || got.LastAccess == 0 || got.LastAccessIpHash == "" || got.LastAccessIpHash != testIPHasher.Hash("192.0.2.1") {
+		t.Fatalf("expected one view from 192.0.2.1, got %+v", got)
+	}
+	Check(t, lm.RecordPublicLinkDownload(clientCtx, pl.Token))
+	Check(t, lm.RecordPublicLinkDownload(context.Background(), pl.Token))
+	got, err = lm.InspectPublicLink(ctx, pl.Id)
+	Check(t, err)
+	if got.Views != 1 || got.Downloads != 2 || got.LastAccessIpHash != "" {
+		t.Fatalf("expected one view and two downloads, got %+v", got)
+	}
+
+	// the link stops working once downloaded as many times as allowed
+	ExpectCode(t, lm.RecordPublicLinkDownload(clientCtx, pl.Token), api.PublicLinkDownloadLimitErrorCode)
+	_, err = lm.AuthenticatePublicLink(clientCtx, pl.Token, "")
+	ExpectCode(t, err, api.PublicLinkDownloadLimitErrorCode)
+	got, err = lm.InspectPublicLink(ctx, pl.Id)
+	Check(t, err)
+	if got.Views != 1 || got.Downloads != 2 {
+		t.Fatalf("expected the refused accesses not to be counted, got %+v", got)
+	}
+
+	// raising or removing the limit makes it work again
+	_, err = lm.UpdatePublicLink(ctx, pl.Id, &api.PublicLinkOptions{UpdateMaxDownloads: true})
+	Check(t, err)
+	Check(t, lm.RecordPublicLinkDownload(clientCtx, pl.Token))
+	_, err = lm.AuthenticatePublicLink(clientCtx, pl.Token, "")
+	Check(t, err)
+	ExpectCode(t, lm.RecordPublicLinkDownload(clientCtx, "missing"), api.PublicLinkNotFoundErrorCode)
+}
+
+func testPasswordPolicy(t *testing.T, e *env, lm api.PublicLinkManager, policy *api.PasswordPolicy) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/dir")
+
+	_, err := lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{ReadOnly: true, Password: "Ab1!"})
+	ExpectCode(t, err, api.PublicLinkPasswordTooShortErrorCode)
+	_, err = lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{ReadOnly: true, Password: "onlylowercase"})
+	ExpectCode(t, err, api.PublicLinkPasswordTooWeakErrorCode)
+	_, err = lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{ReadOnly: true, Password: "PASSWORD123!"})
+	ExpectCode(t, err, api.PublicLinkPasswordBannedErrorCode)
+	_, err = lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{})
+	ExpectCode(t, err, api.PublicLinkPasswordRequiredErrorCode)
+	_, err = lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{DropOnly: true})
+	ExpectCode(t, err, api.PublicLinkPasswordRequiredErrorCode)
+	links, err := lm.ListPublicLinks(ctx, "")
+	Check(t, err)
+	expectLinkIDs(t, links)
+
+	// read-only links can stay unprotected, but not become writable
+	open, err := lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{ReadOnly: true})
+	Check(t, err)
+	_, err = lm.UpdatePublicLink(ctx, open.Id, &api.PublicLinkOptions{UpdateReadOnly: true})
+	ExpectCode(t, err, api.PublicLinkPasswordRequiredErrorCode)
+	_, err = lm.UpdatePublicLink(ctx, open.Id, &api.PublicLinkOptions{UpdateReadOnly: true, UpdatePassword: true, Password: "weak"})
+	ExpectCode(t, err, api.PublicLinkPasswordTooShortErrorCode)
+	updated, err := lm.UpdatePublicLink(ctx, open.Id, &api.PublicLinkOptions{UpdateReadOnly: true, UpdatePassword: true, Password: "Correct-Horse-1"})
+	Check(t, err)
+	if updated.ReadOnly || !updated.Protected {
+		t.Fatalf("expected protected read-write link, got %+v", updated)
+	}
+	_, err = lm.UpdatePublicLink(ctx, open.Id, &api.PublicLinkOptions{UpdatePassword: true})
+	ExpectCode(t, err, api.PublicLinkPasswordRequiredErrorCode)
+
+	// the passwords are still accepted after their hashes are upgraded
+	pl, err := lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{Password: "Battery-Staple-2"})
+	Check(t, err)
+	policy.HashCost = 5
+	for i := 0; i < 2; i++ {
+		_, err = lm.AuthenticatePublicLink(context.Background(), pl.Token, "Battery-Staple-2")
+		Check(t, err)
+		_, err = lm.AuthenticatePublicLink(context.Background(), pl.Token, "Battery-Staple-3")
+		ExpectCode(t, err, api.PublicLinkInvalidPasswordErrorCode)
+	}
+}
+
+func testPublicLinkLifecycle(t *testing.T, e *env, lm api.PublicLinkManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/dir")
+	e.createDir(t, "/alice/other")
+	pl, err := lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{ReadOnly: true})
+	Check(t, err)
+	other, err := lm.CreatePublicLink(ctx, "/alice/other", &api.PublicLinkOptions{ReadOnly: true})
+	Check(t, err)
+
+	// the suspended links are listed but cannot be accessed
+	suspended, err := lm.SuspendPublicLinks(context.Background(), []string{pl.Path}, true)
+	Check(t, err)
+	expectLinkIDs(t, suspended, pl.Id)
+	got, err := lm.InspectPublicLink(ctx, pl.Id)
+	Check(t, err)
+	if !got.Suspended {
+		t.Fatalf("expected suspended link, got %+v", got)
+	}
+	_, err = lm.AuthenticatePublicLink(context.Background(), pl.Token, "")
+	ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+	_, err = lm.InspectPublicLinkByToken(context.Background(), pl.Token)
+	ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+	_, err = lm.IsPublicLinkProtected(context.Background(), pl.Token)
+	ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+	ExpectCode(t, lm.RecordPublicLinkDownload(context.Background(), pl.Token), api.PublicLinkNotFoundErrorCode)
+
+	all, err := lm.ListAllPublicLinks(context.Background(), "")
+	Check(t, err)
+	expectLinkIDs(t, all, pl.Id, other.Id)
+
+	reactivated, err := lm.SuspendPublicLinks(context.Background(), []string{pl.Path}, false)
+	Check(t, err)
+	expectLinkIDs(t, reactivated, pl.Id)
+	_, err = lm.AuthenticatePublicLink(context.Background(), pl.Token, "")
+	Check(t, err)
+
+	purged, err := lm.PurgePublicLinks(context.Background(), []string{pl.Path})
+	Check(t, err)
+	expectLinkIDs(t, purged, pl.Id)
+	_, err = lm.InspectPublicLink(ctx, pl.Id)
+	ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+	all, err = lm.ListAllPublicLinks(context.Background(), "")
+	Check(t, err)
+	expectLinkIDs(t, all, other.Id)
+}
+
+func testPublicLinkTransfer(t *testing.T, e *env, lm api.PublicLinkManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/dir")
+	e.createDir(t, "/alice/other")
+	pl, err := lm.CreatePublicLink(ctx, "/alice/dir", &api.PublicLinkOptions{ReadOnly: true})
+	Check(t, err)
+	other, err := lm.CreatePublicLink(ctx, "/alice/other", &api.PublicLinkOptions{ReadOnly: true})
+	Check(t, err)
+
+	transferred, err := lm.TransferPublicLinks(context.Background(), []string{pl.Path}, alice, bob)
+	Check(t, err)
+	expectLinkIDs(t, transferred, pl.Id)
+	if transferred[0].OwnerId != bob {
+		t.Fatalf("expected link owned by bob, got %+v", transferred[0])
+	}
+	links, err := lm.ListPublicLinks(UserContext(bob), "")
+	Check(t, err)
+	expectLinkIDs(t, links, pl.Id)
+	links, err = lm.ListPublicLinks(ctx, "")
+	Check(t, err)
+	expectLinkIDs(t, links, other.Id)
+	links, err = lm.ListAllPublicLinks(context.Background(), alice)
+	Check(t, err)
+	expectLinkIDs(t, links, other.Id)
+
+	// the token keeps working
+	_, err = lm.AuthenticatePublicLink(context.Background(), pl.Token, "")
+	Check(t, err)
+}
@@ -0,0 +1,554 @@
+package conformance
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/mount"
+	"github.com/cernbox/reva/api/storage_share"
+
+	"go.uber.org/zap"
+)
+
+// TestShareManager runs the suite of api.ShareManager on the managers returned by newManager.
+func TestShareManager(t *testing.T, newManager func(t *testing.T, vfs api.VirtualStorage, um api.UserManager) api.ShareManager) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, e *env, sm api.ShareManager)
+	}{
+		{"AddFolderShare", testAddFolderShare},
+		{"FileShares", testFileShares},
+		{"ReceivedShares", testReceivedShares},
+		{"UpdateFolderShare", testUpdateFolderShare},
+		{"Permissions", testSharePermissions},
+		{"Unshare", testUnshare},
+		{"Reshares", testReshares},
+		{"Expiration", testShareExpiration},
+		{"Lifecycle", testShareLifecycle},
+		{"Transfer", testShareTransfer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv(t)
+			sm := newManager(t, e.vfs, e.um)
+			// the received shares are mounted in /shared, to be re-shared
+			Check(t, e.vfs.AddMount(context.Background(), mount.New("shared", "/shared", nil, storage_share.New(&storage_share.Options{}, e.vfs, sm, zap.NewNop()))))
+			tt.test(t, e, sm)
+		})
+	}
+}
+
+func userRecipient(accountID string) *api.ShareRecipient {
+	return &api.ShareRecipient{Identity: accountID, Type: api.ShareRecipient_USER}
+}
+
+// expectACL checks the permissions granted to the recipient on p, 0 if none.
+func expectACL(t *testing.T, e *env, p, recipient string, permissions api.SharePermissions) {
+	t.Helper()
+	if got := e.storage.ACL(p)[recipient]; got != permissions {
+		t.Fatalf("expected grant of %d to %s on %s, got %v", permissions, recipient, p, e.storage.ACL(p))
+	}
+}
+
+// expectIDs compares the IDs regardless of their order.
+func expectIDs(t *testing.T, got, expected []string) {
+	t.Helper()
+	sort.Strings(got)
+	sort.Strings(expected)
+	expectNames(t, got, expected...)
+}
+
+func expectShareIDs(t *testing.T, shares []*api.FolderShare, ids ...string) {
+	t.Helper()
+	got := []string{}
+	for _, s := range shares {
+		got = append(got, s.Id)
+	}
+	expectIDs(t, got, ids)
+}
+
+func testAddFolderShare(t *testing.T, e *env, sm api.ShareManager) {
+	ctx := UserContext(alice)
+	md := e.createDir(t, "/alice/shared")
+
+	share, err := sm.AddFolderShare(ctx, "/alice/shared", userRecipient(bob), &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	Check(t, err)
+	if share.Id == "" || share.OwnerId != alice || share.Path != md.Id || !share.ReadOnly || share.ItemType != api.FolderShare_FOLDER {
+		t.Fatalf("expected read-only share of folder %s owned by alice, got %+v", md.Id, share)
+	}
+	if share.Recipient.Identity != bob || share.Recipient.Type != api.ShareRecipient_USER {
+		t.Fatalf("expected share with bob, got %+v", share.Recipient)
+	}
+	expectACL(t, e, "/alice/shared", "USER:bob", api.SharePermissionsReadOnly)
+
+	got, err := sm.GetFolderShare(ctx, share.Id)
+	Check(t, err)
+	if got.Id != share.Id || got.Path != share.Path || got.ReadOnly != share.ReadOnly {
+		t.Fatalf("expected share %+v, got %+v", share, got)
+	}
+
+	_, err = sm.AddFolderShare(ctx, "/alice/missing", userRecipient(bob), &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	ExpectCode(t, err, api.StorageNotFoundErrorCode)
+	_, err = sm.AddFolderShare(context.Background(), "/alice/shared", userRecipient(bob), &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	ExpectCode(t, err, api.ContextUserRequiredError)
+
+	// shares are only visible to their owner
+	_, err = sm.GetFolderShare(UserContext(bob), share.Id)
+	ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+	_, err = sm.GetFolderShare(ctx, "999999")
+	ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+
+	e.createDir(t, "/alice/other")
+	other, err := sm.AddFolderShare(ctx, "/alice/other", &api.ShareRecipient{Identity: team, Type: api.ShareRecipient_GROUP}, &api.FolderShareOptions{Permissions: api.SharePermissionsReadWrite})
+	Check(t, err)
+	expectACL(t, e, "/alice/other", "GROUP:team", api.SharePermissionsReadWrite)
+
+	shares, err := sm.ListFolderShares(ctx, "")
+	Check(t, err)
+	expectShareIDs(t, shares, share.Id, other.Id)
+	shares, err = sm.ListFolderShares(ctx, "/alice/other")
+	Check(t, err)
+	expectShareIDs(t, shares, other.Id)
+	shares, err = sm.ListFolderShares(UserContext(bob), "")
+	Check(t, err)
+	expectShareIDs(t, shares)
+}
+
+func testFileShares(t *testing.T, e *env, sm api.ShareManager) {
+	ctx := UserContext(alice)
+	md := e.upload(t, "/alice/file.txt", "hello")
+
+	share, err := sm.AddFolderShare(ctx, "/alice/file.txt", userRecipient(bob), &api.FolderShareOptions{Permissions: api.SharePermissionsReadWrite})
+	Check(t, err)
+	if share.Path != md.Id || share.ReadOnly || share.ItemType != api.FolderShare_FILE {
+		t.Fatalf("expected read-write share of file %s, got %+v", md.Id, share)
+	}
+	expectACL(t, e, "/alice/file.txt", "USER:bob", api.SharePermissionRead|api.SharePermissionUpdate)
+
+	received, err := sm.GetReceivedFolderShare(UserContext(bob), share.Id)
+	Check(t, err)
+	if received.Target != "/file.txt" || received.ItemType != api.FolderShare_FILE {
+		t.Fatalf("expected received file share mounted in /file.txt, got %+v", received)
+	}
+
+	updated, err := sm.UpdateFolderShare(ctx, share.Id, &api.FolderShareOptions{UpdatePermissions: true, Permissions: api.SharePermissionsReadOnly})
+	Check(t, err)
+	if !updated.ReadOnly || updated.ItemType != api.FolderShare_FILE {
+		t.Fatalf("expected read-only file share, got %+v", updated)
+	}
+	expectACL(t, e, "/alice/file.txt", "USER:bob", api.SharePermissionsReadOnly)
+
+	shares, err := sm.ListFolderShares(ctx, "/alice/file.txt")
+	Check(t, err)
+	expectShareIDs(t, shares, share.Id)
+
+	Check(t, sm.Unshare(ctx, share.Id))
+	expectACL(t, e, "/alice/file.txt", "USER:bob", 0)
+}
+
+func testReceivedShares(t *testing.T, e *env, sm api.ShareManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/shared")
+	e.createDir(t, "/alice/team")
+
+	share, err := sm.AddFolderShare(ctx, "/alice/shared", userRecipient(bob), &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	Check(t, err)
+	groupShare, err := sm.AddFolderShare(ctx, "/alice/team", &api.ShareRecipient{Identity: team, Type: api.ShareRecipient_GROUP}, &api.FolderShareOptions{Permissions: api.SharePermissionsReadWrite})
+	Check(t, err)
+
+	// bob receives the share with him and the one with his group
+	bobCtx := UserContext(bob)
+	shares, err := sm.ListReceivedShares(bobCtx)
+	Check(t, err)
+	expectShareIDs(t, shares, share.Id, groupShare.Id)
+
+	received, err := sm.GetReceivedFolderShare(bobCtx, share.Id)
+	Check(t, err)
+	if received.Target != "/shared" || received.OwnerId != alice || received.State != api.FolderShare_PENDING {
+		t.Fatalf("expected pending share from alice to mount in /shared, got %+v", received)
+	}
+	received, err = sm.GetReceivedFolderShare(bobCtx, groupShare.Id)
+	Check(t, err)
+	if received.Path != groupShare.Path || received.ReadOnly {
+		t.Fatalf("expected received share %+v, got %+v", groupShare, received)
+	}
+
+	for _, u := range []string{alice, carol} {
+		shares, err := sm.ListReceivedShares(UserContext(u))
+		Check(t, err)
+		expectShareIDs(t, shares)
+		_, err = sm.GetReceivedFolderShare(UserContext(u), share.Id)
+		ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+	}
+
+	// unmounting only rejects the share for the recipient
+	Check(t, sm.UnmountReceivedShare(bobCtx, share.Id))
+	expectReceivedState(t, sm, bob, share.Id, api.FolderShare_REJECTED, "/shared")
+	shares, err = sm.ListReceivedShares(bobCtx)
+	Check(t, err)
+	expectShareIDs(t, shares, share.Id, groupShare.Id)
+	_, err = sm.GetFolderShare(ctx, share.Id)
+	Check(t, err)
+	ExpectCode(t, sm.UnmountReceivedShare(UserContext(carol), groupShare.Id), api.FolderShareNotFoundErrorCode)
+
+	// a rejected share can still be accepted, under another name
+	mounted, err := sm.MountReceivedShare(bobCtx, share.Id, "from alice")
+	Check(t, err)
+	if mounted.State != api.FolderShare_ACCEPTED || mounted.Target != "/from alice" {
+		t.Fatalf("expected share accepted in /from alice, got %+v", mounted)
+	}
+	expectReceivedState(t, sm, bob, share.Id, api.FolderShare_ACCEPTED, "/from alice")
+	_, err = sm.MountReceivedShare(bobCtx, share.Id, "from/alice")
+	ExpectCode(t, err, api.PathInvalidError)
+	_, err = sm.MountReceivedShare(UserContext(carol), share.Id, "")
+	ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+
+	// the members of a group accept the share on their own
+	_, err = sm.MountReceivedShare(bobCtx, groupShare.Id, "")
+	Check(t, err)
+	expectReceivedState(t, sm, bob, groupShare.Id, api.FolderShare_ACCEPTED, "/team")
+	expectReceivedState(t, sm, dave, groupShare.Id, api.FolderShare_PENDING, "/team")
+	Check(t, sm.UnmountReceivedShare(UserContext(dave), groupShare.Id))
+	expectReceivedState(t, sm, bob, groupShare.Id, api.FolderShare_ACCEPTED, "/team")
+	expectReceivedState(t, sm, dave, groupShare.Id, api.FolderShare_REJECTED, "/team")
+}
+
+// expectReceivedState checks the state and the target of the share received by the user.
+func expectReceivedState(t *testing.T, sm api.ShareManager, accountID, id string, state api.FolderShare_State, target string) {
+	t.Helper()
+	received, err := sm.GetReceivedFolderShare(UserContext(accountID), id)
+	Check(t, err)
+	if received.State != state || received.Target != target {
+		t.Fatalf("expected share %s %s in %s for %s, got %+v", id, state, target, accountID, received)
+	}
+}
+
+func testUpdateFolderShare(t *testing.T, e *env, sm api.ShareManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/shared")
+	share, err := sm.AddFolderShare(ctx, "/alice/shared", userRecipient(bob), &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	Check(t, err)
+
+	updated, err := sm.UpdateFolderShare(ctx, share.Id, &api.FolderShareOptions{UpdatePermissions: true, Permissions: api.SharePermissionsReadWrite})
+	Check(t, err)
+	if updated.ReadOnly {
+		t.Fatalf("expected read-write share, got %+v", updated)
+	}
+	expectACL(t, e, "/alice/shared", "USER:bob", api.SharePermissionsReadWrite)
+
+	// nothing to update
+	updated, err = sm.UpdateFolderShare(ctx, share.Id, &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	Check(t, err)
+	if updated.ReadOnly {
+		t.Fatalf("expected share to stay read-write, got %+v", updated)
+	}
+
+	_, err = sm.UpdateFolderShare(UserContext(bob), share.Id, &api.FolderShareOptions{UpdatePermissions: true, Permissions: api.SharePermissionsReadOnly})
+	ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+}
+
+func testSharePermissions(t *testing.T, e *env, sm api.ShareManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/shared")
+	e.upload(t, "/alice/file.txt", "hello")
+
+	dropOff := api.SharePermissionRead | api.SharePermissionCreate | api.SharePermissionShare
+	share, err := sm.AddFolderShare(ctx, "/alice/shared", userRecipient(bob), &api.FolderShareOptions{Permissions: dropOff})
+	Check(t, err)
+	if api.SharePermissions(share.Permissions) != dropOff || share.ReadOnly {
+		t.Fatalf("expected share with permissions %d, got %+v", dropOff, share)
+	}
+	expectACL(t, e, "/alice/shared", "USER:bob", dropOff)
+
+	received, err := sm.GetReceivedFolderShare(UserContext(bob), share.Id)
+	Check(t, err)
+	if api.GetSharePermissions(received) != dropOff {
+		t.Fatalf("expected received share with permissions %d, got %+v", dropOff, received)
+	}
+
+	noDelete := api.SharePermissionsReadWrite &^ api.SharePermissionDelete
+	updated, err := sm.UpdateFolderShare(ctx, share.Id, &api.FolderShareOptions{UpdatePermissions: true, Permissions: noDelete})
+	Check(t, err)
+	if api.GetSharePermissions(updated) != noDelete {
+		t.Fatalf("expected share with permissions %d, got %+v", noDelete, updated)
+	}
+	expectACL(t, e, "/alice/shared", "USER:bob", noDelete)
+
+	// files cannot grant creations nor deletions
+	fileShare, err := sm.AddFolderShare(ctx, "/alice/file.txt", userRecipient(bob), &api.FolderShareOptions{Permissions: api.SharePermissionsAll})
+	Check(t, err)
+	fileAll := api.SharePermissionRead | api.SharePermissionUpdate | api.SharePermissionShare
+	if api.GetSharePermissions(fileShare) != fileAll {
+		t.Fatalf("expected file share with permissions %d, got %+v", fileAll, fileShare)
+	}
+	expectACL(t, e, "/alice/file.txt", "USER:bob", fileAll)
+
+	// files can grant updates without creations, folders cannot
+	fileShare, err = sm.UpdateFolderShare(ctx, fileShare.Id, &api.FolderShareOptions{UpdatePermissions: true, Permissions: api.SharePermissionRead | api.SharePermissionUpdate})
+	Check(t, err)
+	expectACL(t, e, "/alice/file.txt", "USER:bob", api.SharePermissionRead|api.SharePermissionUpdate)
+
+	updateOnly := api.SharePermissionRead | api.SharePermissionUpdate | api.SharePermissionDelete
+	for _, p := range []api.SharePermissions{0, api.SharePermissionUpdate, updateOnly, api.SharePermissionsAll << 1} {
+		_, err = sm.AddFolderShare(ctx, "/alice/shared", userRecipient(carol), &api.FolderShareOptions{Permissions: p})
+		ExpectCode(t, err, api.FolderShareInvalidPermissionsErrorCode)
+		_, err = sm.UpdateFolderShare(ctx, share.Id, &api.FolderShareOptions{UpdatePermissions: true, Permissions: p})
+		ExpectCode(t, err, api.FolderShareInvalidPermissionsErrorCode)
+	}
+	expectACL(t, e, "/alice/shared", "USER:carol", 0)
+	expectACL(t, e, "/alice/shared", "USER:bob", noDelete)
+}
+
+func testUnshare(t *testing.T, e *env, sm api.ShareManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/shared")
+	share, err := sm.AddFolderShare(ctx, "/alice/shared", userRecipient(bob), &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	Check(t, err)
+
+	ExpectCode(t, sm.Unshare(UserContext(bob), share.Id), api.FolderShareNotFoundErrorCode)
+	Check(t, sm.Unshare(ctx, share.Id))
+	expectACL(t, e, "/alice/shared", "USER:bob", 0)
+
+	_, err = sm.GetFolderShare(ctx, share.Id)
+	ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+	shares, err := sm.ListReceivedShares(UserContext(bob))
+	Check(t, err)
+	expectShareIDs(t, shares)
+	ExpectCode(t, sm.Unshare(ctx, share.Id), api.FolderShareNotFoundErrorCode)
+}
+
+func testReshares(t *testing.T, e *env, sm api.ShareManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/shared")
+	sub := e.createDir(t, "/alice/shared/sub")
+	granted := api.SharePermissionsAll &^ api.SharePermissionDelete
+	share, err := sm.AddFolderShare(ctx, "/alice/shared", userRecipient(bob), &api.FolderShareOptions{Permissions: granted})
+	Check(t, err)
+	if share.OwnerId != alice || api.GetShareInitiator(share) != alice || share.ParentId != "" {
+		t.Fatalf("expected share created by alice, got %+v", share)
+	}
+
+	// bob re-shares a folder of the received share, without the permissions he lacks
+	bobCtx := UserContext(bob)
+	_, err = sm.MountReceivedShare(bobCtx, share.Id, "")
+	Check(t, err)
+	subPath := "/shared/" + share.Id + "/sub"
+	reshare, err := sm.AddFolderShare(bobCtx, subPath, userRecipient(carol), &api.FolderShareOptions{Permissions: api.SharePermissionsAll})
+	Check(t, err)
+	if reshare.OwnerId != alice || reshare.InitiatorId != bob || reshare.ParentId != share.Id || reshare.Path != sub.Id || api.GetSharePermissions(reshare) != granted {
+		t.Fatalf("expected re-share of %s by bob with permissions %d, got %+v", sub.Id, granted, reshare)
+	}
+	expectACL(t, e, "/alice/shared/sub", "USER:carol", granted)
+
+	received, err := sm.GetReceivedFolderShare(UserContext(carol), reshare.Id)
+	Check(t, err)
+	if received.OwnerId != alice || received.Target != "/sub" {
+		t.Fatalf("expected share of alice mounted in /sub, got %+v", received)
+	}
+
+	// the owner sees the whole share tree, the re-sharer the shares he created
+	shares, err := sm.ListFolderShares(ctx, "")
+	Check(t, err)
+	expectShareIDs(t, shares, share.Id, reshare.Id)
+	shares, err = sm.ListFolderShares(bobCtx, "")
+	Check(t, err)
+	expectShareIDs(t, shares, reshare.Id)
+	shares, err = sm.ListFolderShares(bobCtx, subPath)
+	Check(t, err)
+	expectShareIDs(t, shares, reshare.Id)
+	_, err = sm.GetFolderShare(bobCtx, share.Id)
+	ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+
+	// only the shares with the share permission can be re-shared
+	e.createDir(t, "/alice/private")
+	private, err := sm.AddFolderShare(ctx, "/alice/private", userRecipient(bob), &api.FolderShareOptions{Permissions: api.SharePermissionsReadWrite})
+	Check(t, err)
+	_, err = sm.MountReceivedShare(bobCtx, private.Id, "")
+	Check(t, err)
+	_, err = sm.AddFolderShare(bobCtx, "/shared/"+private.Id, userRecipient(carol), &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	ExpectCode(t, err, api.StoragePermissionDeniedErrorCode)
+	expectACL(t, e, "/alice/private", "USER:carol", 0)
+
+	// restricting the share restricts the re-shares
+	restricted := api.SharePermissionsReadOnly | api.SharePermissionShare
+	_, err = sm.UpdateFolderShare(ctx, share.Id, &api.FolderShareOptions{UpdatePermissions: true, Permissions: restricted})
+	Check(t, err)
+	expectACL(t, e, "/alice/shared/sub", "USER:carol", restricted)
+	reshare, err = sm.GetFolderShare(bobCtx, reshare.Id)
+	Check(t, err)
+	if api.GetSharePermissions(reshare) != restricted {
+		t.Fatalf("expected re-share restricted to %d, got %+v", restricted, reshare)
+	}
+
+	// revoking the share revokes the re-shares
+	Check(t, sm.Unshare(ctx, share.Id))
+	expectACL(t, e, "/alice/shared", "USER:bob", 0)
+	expectACL(t, e, "/alice/shared/sub", "USER:carol", 0)
+	_, err = sm.GetFolderShare(ctx, reshare.Id)
+	ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+	shares, err = sm.ListReceivedShares(UserContext(carol))
+	Check(t, err)
+	expectShareIDs(t, shares)
+}
+
+func testShareExpiration(t *testing.T, e *env, sm api.ShareManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/shared")
+	e.createDir(t, "/alice/old")
+
+	expiration := uint64(time.Now().Unix() + 3600)
+	share, err := sm.AddFolderShare(ctx, "/alice/shared", userRecipient(bob), &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly, Expiration: expiration})
+	Check(t, err)
+	if share.Expiration != expiration {
+		t.Fatalf("expected share expiring at %d, got %+v", expiration, share)
+	}
+	old, err := sm.AddFolderShare(ctx, "/alice/old", userRecipient(bob), &api.FolderShareOptions{Permissions: api.SharePermissionsReadWrite, Expiration: uint64(time.Now().Unix() - 10)})
+	Check(t, err)
+
+	// expired shares are not received anymore, even before being removed
+	bobCtx := UserContext(bob)
+	shares, err := sm.ListReceivedShares(bobCtx)
+	Check(t, err)
+	expectShareIDs(t, shares, share.Id)
+	_, err = sm.GetReceivedFolderShare(bobCtx, old.Id)
+	ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+	shares, err = sm.ListFolderShares(ctx, "")
+	Check(t, err)
+	expectShareIDs(t, shares, share.Id, old.Id)
+
+	expired, err := sm.ExpireFolderShares(context.Background())
+	Check(t, err)
+	expectShareIDs(t, expired, old.Id)
+	expectACL(t, e, "/alice/old", "USER:bob", 0)
+	expectACL(t, e, "/alice/shared", "USER:bob", api.SharePermissionsReadOnly)
+	_, err = sm.GetFolderShare(ctx, old.Id)
+	ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+
+	// updating the expiration keeps the permissions
+	updated, err := sm.UpdateFolderShare(ctx, share.Id, &api.FolderShareOptions{UpdateExpiration: true, Expiration: uint64(time.Now().Unix() - 10)})
+	Check(t, err)
+	if !updated.ReadOnly {
+		t.Fatalf("expected share to stay read-only, got %+v", updated)
+	}
+	expired, err = sm.ExpireFolderShares(context.Background())
+	Check(t, err)
+	expectShareIDs(t, expired, share.Id)
+	expectACL(t, e, "/alice/shared", "USER:bob", 0)
+
+	expired, err = sm.ExpireFolderShares(context.Background())
+	Check(t, err)
+	expectShareIDs(t, expired)
+}
+
+func testShareLifecycle(t *testing.T, e *env, sm api.ShareManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/shared")
+	sub := e.createDir(t, "/alice/shared/sub")
+	e.createDir(t, "/alice/other")
+	e.createDir(t, "/alice/old")
+	share, err := sm.AddFolderShare(ctx, "/alice/shared", userRecipient(bob), &api.FolderShareOptions{Permissions: api.SharePermissionsAll})
+	Check(t, err)
+	bobCtx := UserContext(bob)
+	_, err = sm.MountReceivedShare(bobCtx, share.Id, "")
+	Check(t, err)
+	reshare, err := sm.AddFolderShare(bobCtx, "/shared/"+share.Id+"/sub", userRecipient(carol), &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	Check(t, err)
+	other, err := sm.AddFolderShare(ctx, "/alice/other", userRecipient(carol), &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	Check(t, err)
+	old, err := sm.AddFolderShare(ctx, "/alice/old", userRecipient(carol), &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly, Expiration: uint64(time.Now().Unix() - 10)})
+	Check(t, err)
+
+	// the suspended shares are listed but neither received nor expired
+	suspended, err := sm.SuspendFolderShares(context.Background(), []string{share.Path, old.Path}, true)
+	Check(t, err)
+	expectShareIDs(t, suspended, share.Id, old.Id)
+	got, err := sm.GetFolderShare(ctx, share.Id)
+	Check(t, err)
+	if !got.Suspended {
+		t.Fatalf("expected suspended share, got %+v", got)
+	}
+	_, err = sm.GetReceivedFolderShare(bobCtx, share.Id)
+	ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+	expired, err := sm.ExpireFolderShares(context.Background())
+	Check(t, err)
+	expectShareIDs(t, expired)
+	suspended, err = sm.SuspendFolderShares(context.Background(), []string{share.Path}, true)
+	Check(t, err)
+	expectShareIDs(t, suspended)
+
+	all, err := sm.ListAllFolderShares(context.Background(), "")
+	Check(t, err)
+	expectShareIDs(t, all, share.Id, reshare.Id, other.Id, old.Id)
+
+	// reactivated, they are received again with their state
+	reactivated, err := sm.SuspendFolderShares(context.Background(), []string{share.Path}, false)
+	Check(t, err)
+	expectShareIDs(t, reactivated, share.Id)
+	received, err := sm.ListReceivedShares(UserContext(carol))
+	Check(t, err)
+	expectShareIDs(t, received, reshare.Id, other.Id)
+
+	// the purge removes the re-shares with the shares
+	purged, err := sm.PurgeFolderShares(context.Background(), []string{share.Path, sub.Id})
+	Check(t, err)
+	expectShareIDs(t, purged, share.Id, reshare.Id)
+	all, err = sm.ListAllFolderShares(context.Background(), "")
+	Check(t, err)
+	expectShareIDs(t, all, other.Id, old.Id)
+	_, err = sm.GetFolderShare(ctx, share.Id)
+	ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+}
+
+func testShareTransfer(t *testing.T, e *env, sm api.ShareManager) {
+	ctx := UserContext(alice)
+	dir := e.createDir(t, "/alice/shared")
+	sub := e.createDir(t, "/alice/shared/sub")
+	e.createDir(t, "/alice/other")
+	share, err := sm.AddFolderShare(ctx, "/alice/shared", userRecipient(bob), &api.FolderShareOptions{Permissions: api.SharePermissionsAll})
+	Check(t, err)
+	bobCtx := UserContext(bob)
+	_, err = sm.MountReceivedShare(bobCtx, share.Id, "")
+	Check(t, err)
+	reshare, err := sm.AddFolderShare(bobCtx, "/shared/"+share.Id+"/sub", userRecipient(carol), &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	Check(t, err)
+	other, err := sm.AddFolderShare(ctx, "/alice/other", userRecipient(carol), &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	Check(t, err)
+
+	// the re-shares keep their initiators
+	transferred, err := sm.TransferFolderShares(context.Background(), []string{dir.Id, sub.Id}, alice, dave)
+	Check(t, err)
+	expectShareIDs(t, transferred, share.Id, reshare.Id)
+	for _, s := range transferred {
+		initiator := dave
+		if s.Id == reshare.Id {
+			initiator = bob
+		}
+		if s.OwnerId != dave || api.GetShareInitiator(s) != initiator {
+			t.Fatalf("expected share owned by dave and created by %s, got %+v", initiator, s)
+		}
+	}
+	shares, err := sm.ListFolderShares(UserContext(dave), "")
+	Check(t, err)
+	expectShareIDs(t, shares, share.Id, reshare.Id)
+	shares, err = sm.ListFolderShares(ctx, "")
+	Check(t, err)
+	expectShareIDs(t, shares, other.Id)
+	shares, err = sm.ListAllFolderShares(context.Background(), dave)
+	Check(t, err)
+	expectShareIDs(t, shares, share.Id, reshare.Id)
+
+	// the recipients keep their shares
+	received, err := sm.GetReceivedFolderShare(bobCtx, share.Id)
+	Check(t, err)
+	if received.OwnerId != dave || received.State != api.FolderShare_ACCEPTED {
+		t.Fatalf("expected accepted share owned by dave, got %+v", received)
+	}
+
+	// nothing is left to transfer
+	transferred, err = sm.TransferFolderShares(context.Background(), []string{dir.Id, sub.Id}, alice, dave)
+	Check(t, err)
+	expectShareIDs(t, transferred)
+}
@@ -0,0 +1,246 @@
+package conformance
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+)
+
+// TestStorage runs the suite of api.Storage on the storages returned by
+// newStorage, that must be empty and writable by alice. The revisions,
+// the recycle bin, the file IDs and the ACLs are only checked if the
+// storage supports them, that is, if it does not return StorageNotSupportedErrorCode.
+func TestStorage(t *testing.T, newStorage func(t *testing.T) api.Storage) {
+	ctx := UserContext(alice)
+	tests := []struct {
+		name string
+		test func(t *testing.T, ctx context.Context, s api.Storage)
+	}{
+		{"NotFound", testStorageNotFound},
+		{"CreateDir", testStorageCreateDir},
+		{"UploadDownload", testStorageUploadDownload},
+		{"Move", testStorageMove},
+		{"Delete", testStorageDelete},
+		{"Revisions", testStorageRevisions},
+		{"Recycle", testStorageRecycle},
+		{"ACL", testStorageACL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, ctx, newStorage(t))
+		})
+	}
+}
+
+// skipNotSupported skips the test if the storage does not support the operation.
+func skipNotSupported(t *testing.T, err error) {
+	t.Helper()
+	if hasCode(err, api.StorageNotSupportedErrorCode) {
+		t.Skip("not supported by the storage")
+	}
+}
+
+func upload(t *testing.T, ctx context.Context, s api.Storage, p, content string) {
+	t.Helper()
+	Check(t, s.Upload(ctx, p, ioutil.NopCloser(strings.NewReader(content))))
+}
+
+func expectContent(t *testing.T, ctx context.Context, s api.Storage, p, content string) {
+	t.Helper()
+	r, err := s.Download(ctx, p)
+	Check(t, err)
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	Check(t, err)
+	if string(data) != content {
+		t.Fatalf("expected content %q in %s, got %q", content, p, data)
+	}
+}
+
+func listNames(t *testing.T, ctx context.Context, s api.Storage, p string) []string {
+	t.Helper()
+	mds, err := s.ListFolder(ctx, p)
+	Check(t, err)
+	names := []string{}
+	for _, md := range mds {
+		names = append(names, md.Path)
+	}
+	return names
+}
+
+func expectNames(t *testing.T, names []string, expected ...string) {
+	t.Helper()
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+}
+
+func testStorageNotFound(t *testing.T, ctx context.Context, s api.Storage) {
+	_, err := s.GetMetadata(ctx, "/missing")
+	ExpectCode(t, err, api.StorageNotFoundErrorCode)
+	_, err = s.ListFolder(ctx, "/missing")
+	ExpectCode(t, err, api.StorageNotFoundErrorCode)
+	_, err = s.Download(ctx, "/missing")
+	ExpectCode(t, err, api.StorageNotFoundErrorCode)
+	ExpectCode(t, s.Delete(ctx, "/missing"), api.StorageNotFoundErrorCode)
+	ExpectCode(t, s.Move(ctx, "/missing", "/other"), api.StorageNotFoundErrorCode)
+	ExpectCode(t, s.CreateDir(ctx, "/missing/dir"), api.StorageNotFoundErrorCode)
+	ExpectCode(t, s.Upload(ctx, "/missing/file", ioutil.NopCloser(strings.NewReader("a"))), api.StorageNotFoundErrorCode)
+}
+
+func testStorageCreateDir(t *testing.T, ctx context.Context, s api.Storage) {
+	Check(t, s.CreateDir(ctx, "/dir"))
+	Check(t, s.CreateDir(ctx, "/dir/sub"))
+	ExpectCode(t, s.CreateDir(ctx, "/dir"), api.StorageAlreadyExistsErrorCode)
+
+	md, err := s.GetMetadata(ctx, "/dir")
+	Check(t, err)
+	if !md.IsDir || md.Path != "/dir" {
+		t.Fatalf("expected folder /dir, got %+v", md)
+	}
+	expectNames(t, listNames(t, ctx, s, "/dir"), "/dir/sub")
+	expectNames(t, listNames(t, ctx, s, "/dir/sub"))
+}
+
+func testStorageUploadDownload(t *testing.T, ctx context.Context, s api.Storage) {
+	upload(t, ctx, s, "/file.txt", "hello")
+	md, err := s.GetMetadata(ctx, "/file.txt")
+	Check(t, err)
+	if md.IsDir || md.Path != "/file.txt" || md.Size != 5 {
+		t.Fatalf("expected file /file.txt of 5 bytes, got %+v", md)
+	}
+	expectContent(t, ctx, s, "/file.txt", "hello")
+
+	// an upload replaces the content
+	upload(t, ctx, s, "/file.txt", "hello world")
+	expectContent(t, ctx, s, "/file.txt", "hello world")
+	md, err = s.GetMetadata(ctx, "/file.txt")
+	Check(t, err)
+	if md.Size != 11 {
+		t.Fatalf("expected size 11 after the new upload, got %d", md.Size)
+	}
+	expectNames(t, listNames(t, ctx, s, "/"), "/file.txt")
+}
+
+func testStorageMove(t *testing.T, ctx context.Context, s api.Storage) {
+	Check(t, s.CreateDir(ctx, "/dir"))
+	upload(t, ctx, s, "/dir/file.txt", "hello")
+	before, err := s.GetMetadata(ctx, "/dir")
+	Check(t, err)
+
+	Check(t, s.Move(ctx, "/dir", "/moved"))
+	_, err = s.GetMetadata(ctx, "/dir")
+	ExpectCode(t, err, api.StorageNotFoundErrorCode)
+	expectContent(t, ctx, s, "/moved/file.txt", "hello")
+
+	// the file IDs follow the moves
+	p, err := s.GetPathByID(ctx, before.Id)
+	skipNotSupported(t, err)
+	Check(t, err)
+	if p != "/moved" {
+		t.Fatalf("expected the ID of /dir to point to /moved, got %s", p)
+	}
+}
+
+func testStorageDelete(t *testing.T, ctx context.Context, s api.Storage) {
+	Check(t, s.CreateDir(ctx, "/dir"))
+	upload(t, ctx, s, "/file.txt", "hello")
+
+	Check(t, s.Delete(ctx, "/file.txt"))
+	Check(t, s.Delete(ctx, "/dir"))
+	_, err := s.GetMetadata(ctx, "/file.txt")
+	ExpectCode(t, err, api.StorageNotFoundErrorCode)
+	_, err = s.GetMetadata(ctx, "/dir")
+	ExpectCode(t, err, api.StorageNotFoundErrorCode)
+	expectNames(t, listNames(t, ctx, s, "/"))
+}
+
+func testStorageRevisions(t *testing.T, ctx context.Context, s api.Storage) {
+	upload(t, ctx, s, "/file.txt", "v1")
+	upload(t, ctx, s, "/file.txt", "v2")
+
+	revisions, err := s.ListRevisions(ctx, "/file.txt")
+	skipNotSupported(t, err)
+	Check(t, err)
+	if len(revisions) == 0 {
+		t.Fatal("expected a revision after overwriting the file")
+	}
+	var key string
+	for _, rev := range revisions {
+		r, err := s.DownloadRevision(ctx, "/file.txt", rev.RevKey)
+		Check(t, err)
+		data, err := ioutil.ReadAll(r)
+		r.Close()
+		Check(t, err)
+		if string(data) == "v1" {
+			key = rev.RevKey
+		}
+	}
+	if key == "" {
+		t.Fatalf("expected a revision with the first content, got %+v", revisions)
+	}
+
+	Check(t, s.RestoreRevision(ctx, "/file.txt", key))
+	expectContent(t, ctx, s, "/file.txt", "v1")
+
+	_, err = s.DownloadRevision(ctx, "/file.txt", "missing")
+	if err == nil {
+		t.Fatal("expected error downloading a missing revision")
+	}
+	_, err = s.ListRevisions(ctx, "/missing")
+	ExpectCode(t, err, api.StorageNotFoundErrorCode)
+}
+
+func testStorageRecycle(t *testing.T, ctx context.Context, s api.Storage) {
+	_, err := s.ListRecycle(ctx, "/")
+	skipNotSupported(t, err)
+	Check(t, err)
+
+	upload(t, ctx, s, "/file.txt", "hello")
+	Check(t, s.Delete(ctx, "/file.txt"))
+
+	entries, err := s.ListRecycle(ctx, "/")
+	Check(t, err)
+	var entry *api.RecycleEntry
+	for _, e := range entries {
+		if e.RestorePath == "/file.txt" {
+			entry = e
+		}
+	}
+	if entry == nil {
+		t.Fatalf("expected /file.txt in the recycle bin, got %+v", entries)
+	}
+	if entry.IsDir {
+		t.Fatalf("expected a file in the recycle bin, got %+v", entry)
+	}
+
+	Check(t, s.RestoreRecycleEntry(ctx, entry.RestoreKey))
+	expectContent(t, ctx, s, "/file.txt", "hello")
+	if err := s.RestoreRecycleEntry(ctx, entry.RestoreKey); err == nil {
+		t.Fatal("expected error restoring an entry twice")
+	}
+
+	Check(t, s.Delete(ctx, "/file.txt"))
+	Check(t, s.EmptyRecycle(ctx, "/"))
+	entries, err = s.ListRecycle(ctx, "/")
+	Check(t, err)
+	if len(entries) != 0 {
+		t.Fatalf("expected an empty recycle bin, got %+v", entries)
+	}
+}
+
+func testStorageACL(t *testing.T, ctx context.Context, s api.Storage) {
+	Check(t, s.CreateDir(ctx, "/dir"))
+	recipient := &api.ShareRecipient{Identity: bob, Type: api.ShareRecipient_USER}
+
+	err := s.SetACL(ctx, "/dir", api.SharePermissionsReadOnly, recipient, []*api.FolderShare{})
+	skipNotSupported(t, err)
+	Check(t, err)
+	Check(t, s.UpdateACL(ctx, "/dir", api.SharePermissionsReadWrite, recipient, []*api.FolderShare{}))
+	Check(t, s.UnsetACL(ctx, "/dir", recipient, []*api.FolderShare{}))
+
+	ExpectCode(t, s.SetACL(ctx, "/missing", api.SharePermissionsReadOnly, recipient, []*api.FolderShare{}), api.StorageNotFoundErrorCode)
+}
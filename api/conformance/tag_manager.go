@@ -0,0 +1,199 @@
+package conformance
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+)
+
+// TestTagManager runs the suite of api.TagManager on the managers returned by newManager.
+func TestTagManager(t *testing.T, newManager func(t *testing.T, vfs api.VirtualStorage, pm api.ProjectManager, um api.UserManager) api.TagManager) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, e *env, tm api.TagManager)
+	}{
+		{"SetTag", testSetTag},
+		{"RenameTag", testRenameTag},
+		{"SystemTags", testSystemTags},
+		{"FolderTags", testFolderTags},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv(t)
+			tt.test(t, e, newManager(t, e.vfs, e.pm, e.um))
+		})
+	}
+}
+
+// tagKeys returns the sorted keys of the tags, prefixed by their project if any.
+func tagKeys(tags []*api.Tag) []string {
+	keys := []string{}
+	for _, tag := range tags {
+		if tag.Project != "" {
+			keys = append(keys, tag.Project+":"+tag.TagKey)
+		} else {
+			keys = append(keys, tag.TagKey)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func expectPathTags(t *testing.T, ctx context.Context, tm api.TagManager, p string, keys ...string) []*api.Tag {
+	t.Helper()
+	tags, err := tm.GetTagsForPath(ctx, p)
+	Check(t, err)
+	expectNames(t, tagKeys(tags), keys...)
+	return tags
+}
+
+func expectKeyTags(t *testing.T, ctx context.Context, tm api.TagManager, key string, keys ...string) []*api.Tag {
+	t.Helper()
+	tags, err := tm.GetTagsForKey(ctx, key)
+	Check(t, err)
+	expectNames(t, tagKeys(tags), keys...)
+	return tags
+}
+
+func testSetTag(t *testing.T, e *env, tm api.TagManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/dir")
+	e.upload(t, "/alice/file.txt", "hello")
+
+	Check(t, tm.SetTag(ctx, "fav", "", "/alice/file.txt"))
+	// setting a tag twice keeps a single one
+	Check(t, tm.SetTag(ctx, "fav", "", "/alice/file.txt"))
+	Check(t, tm.SetTag(ctx, "work", "x", "/alice/dir"))
+
+	tags := expectPathTags(t, ctx, tm, "/alice/file.txt", "fav")
+	if tags[0].Uid != alice || tags[0].ItemType != api.Tag_FILE {
+		t.Fatalf("expected tag of alice on a file, got %+v", tags[0])
+	}
+	tags = expectPathTags(t, ctx, tm, "/alice/dir", "work")
+	if tags[0].TagValue != "x" || tags[0].ItemType != api.Tag_FOLDER {
+		t.Fatalf("expected tag with value x on a folder, got %+v", tags[0])
+	}
+	expectKeyTags(t, ctx, tm, "fav", "fav")
+	expectKeyTags(t, ctx, tm, "", "fav", "work")
+
+	// tags are personal
+	bobCtx := UserContext(bob)
+	expectPathTags(t, bobCtx, tm, "/alice/file.txt")
+	expectKeyTags(t, bobCtx, tm, "")
+
+	// tags follow the folders when they are moved
+	Check(t, e.vfs.Move(context.Background(), "/alice/dir", "/alice/moved"))
+	expectPathTags(t, ctx, tm, "/alice/moved", "work")
+
+	Check(t, tm.UnSetTag(ctx, "fav", "", "/alice/file.txt"))
+	Check(t, tm.UnSetTag(ctx, "fav", "", "/alice/file.txt"))
+	expectPathTags(t, ctx, tm, "/alice/file.txt")
+	expectKeyTags(t, ctx, tm, "fav")
+
+	ExpectCode(t, tm.SetTag(ctx, "fav", "", "/alice/missing"), api.StorageNotFoundErrorCode)
+	ExpectCode(t, tm.SetTag(context.Background(), "fav", "", "/alice/file.txt"), api.ContextUserRequiredError)
+	_, err := tm.GetTagsForKey(context.Background(), "")
+	ExpectCode(t, err, api.ContextUserRequiredError)
+}
+
+func testRenameTag(t *testing.T, e *env, tm api.TagManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/a")
+	e.createDir(t, "/alice/b")
+
+	Check(t, tm.SetTag(ctx, "work", "", "/alice/a"))
+	Check(t, tm.SetTag(ctx, "work", "", "/alice/b"))
+	Check(t, tm.SetTag(ctx, "job", "", "/alice/b"))
+	Check(t, tm.SetTag(UserContext(bob), "work", "", "/alice/a"))
+
+	// the files already tagged with the new key keep a single tag
+	Check(t, tm.RenameTag(ctx, "work", "job"))
+	expectPathTags(t, ctx, tm, "/alice/a", "job")
+	expectPathTags(t, ctx, tm, "/alice/b", "job")
+	expectKeyTags(t, ctx, tm, "", "job", "job")
+
+	// the tags of other users are not renamed
+	expectPathTags(t, UserContext(bob), tm, "/alice/a", "work")
+
+	ExpectCode(t, tm.RenameTag(ctx, "missing", "other"), api.TagNotFoundErrorCode)
+	ExpectCode(t, tm.RenameTag(ctx, "job", ""), api.PathInvalidError)
+}
+
+func testSystemTags(t *testing.T, e *env, tm api.TagManager) {
+	e.createDir(t, "/alice/proj")
+	e.upload(t, "/alice/proj/file.txt", "hello")
+
+	// writers set the system tags, that every member sees
+	carolCtx := UserContext(carol)
+	Check(t, tm.SetSystemTag(carolCtx, project, "status", "draft", "/alice/proj/file.txt"))
+	Check(t, tm.SetTag(carolCtx, "fav", "", "/alice/proj/file.txt"))
+	tags := expectPathTags(t, carolCtx, tm, "/alice/proj/file.txt", "fav", "proj:status")
+	for _, tag := range tags {
+		if tag.Project == project && tag.TagValue != "draft" {
+			t.Fatalf("expected system tag with value draft, got %+v", tag)
+		}
+	}
+	bobCtx := UserContext(bob)
+	expectPathTags(t, bobCtx, tm, "/alice/proj/file.txt", "proj:status")
+	expectKeyTags(t, bobCtx, tm, "status", "proj:status")
+	expectKeyTags(t, UserContext(alice), tm, "", "proj:status")
+
+	// readers can not change them
+	ExpectCode(t, tm.SetSystemTag(bobCtx, project, "status", "final", "/alice/proj/file.txt"), api.StoragePermissionDeniedErrorCode)
+	ExpectCode(t, tm.UnSetSystemTag(bobCtx, project, "status", "", "/alice/proj/file.txt"), api.StoragePermissionDeniedErrorCode)
+	if err := tm.SetSystemTag(carolCtx, "missing", "status", "", "/alice/proj/file.txt"); err == nil {
+		t.Fatal("expected error setting a system tag of a missing project")
+	}
+
+	// only the owner and the admins rename them
+	ExpectCode(t, tm.RenameSystemTag(carolCtx, project, "status", "state"), api.StoragePermissionDeniedErrorCode)
+	Check(t, tm.RenameSystemTag(UserContext(alice), project, "status", "state"))
+	expectPathTags(t, bobCtx, tm, "/alice/proj/file.txt", "proj:state")
+	ExpectCode(t, tm.RenameSystemTag(UserContext(alice), project, "status", "state"), api.TagNotFoundErrorCode)
+
+	Check(t, tm.UnSetSystemTag(carolCtx, project, "state", "", "/alice/proj/file.txt"))
+	expectPathTags(t, carolCtx, tm, "/alice/proj/file.txt", "fav")
+}
+
+func testFolderTags(t *testing.T, e *env, tm api.TagManager) {
+	ctx := UserContext(alice)
+	e.createDir(t, "/alice/dir")
+	e.createDir(t, "/alice/dir/sub")
+	e.upload(t, "/alice/dir/a.txt", "hello")
+	e.upload(t, "/alice/dir/b.txt", "hello")
+	e.upload(t, "/alice/other.txt", "hello")
+
+	Check(t, tm.SetTag(ctx, "dir", "", "/alice/dir"))
+	Check(t, tm.SetTag(ctx, "sub", "", "/alice/dir/sub"))
+	Check(t, tm.SetTag(ctx, "fav", "", "/alice/dir/a.txt"))
+	Check(t, tm.SetTag(ctx, "out", "", "/alice/other.txt"))
+	Check(t, tm.SetTag(UserContext(bob), "bob", "", "/alice/dir/b.txt"))
+
+	// the tags are bound to the IDs of the entries of the listing
+	tags, err := tm.GetTagsForFolder(ctx, "/alice/dir")
+	Check(t, err)
+	expectNames(t, tagKeys(tags), "dir", "fav", "sub")
+	paths := map[string]string{"dir": "/alice/dir", "sub": "/alice/dir/sub", "fav": "/alice/dir/a.txt"}
+	for _, tag := range tags {
+		md, err := e.vfs.GetMetadata(ctx, paths[tag.TagKey])
+		Check(t, err)
+		if tag.FileIdPrefix+":"+tag.FileId != md.Id {
+			t.Fatalf("expected tag %s on %s, got %s:%s", tag.TagKey, md.Id, tag.FileIdPrefix, tag.FileId)
+		}
+	}
+
+	// a file has only its own tags
+	tags, err = tm.GetTagsForFolder(ctx, "/alice/dir/a.txt")
+	Check(t, err)
+	expectNames(t, tagKeys(tags), "fav")
+	tags, err = tm.GetTagsForFolder(UserContext(bob), "/alice/dir")
+	Check(t, err)
+	expectNames(t, tagKeys(tags), "bob")
+
+	_, err = tm.GetTagsForFolder(ctx, "/alice/missing")
+	ExpectCode(t, err, api.StorageNotFoundErrorCode)
+	_, err = tm.GetTagsForFolder(context.Background(), "/alice/dir")
+	ExpectCode(t, err, api.ContextUserRequiredError)
+}
@@ -0,0 +1,78 @@
+package conformance
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// TestTokenManager runs the suite of api.TokenManager on tm.
+func TestTokenManager(t *testing.T, tm api.TokenManager) {
+	ctx := context.Background()
+
+	t.Run("UserToken", func(t *testing.T) {
+		user := &api.User{AccountId: alice, Groups: []string{team, "other"}, DisplayName: "Alice", ReadOnly: true, RestrictedPath: "/alice/dir", AppPassword: true}
+		token, err := tm.ForgeUserToken(ctx, user)
+		Check(t, err)
+		got, err := tm.DismantleUserToken(ctx, token)
+		Check(t, err)
+		if got.AccountId != user.AccountId || got.DisplayName != user.DisplayName || got.ReadOnly != user.ReadOnly ||
+			got.RestrictedPath != user.RestrictedPath || got.AppPassword != user.AppPassword || strings.Join(got.Groups, ",") != strings.Join(user.Groups, ",") {
+			t.Fatalf("expected user %+v, got %+v", user, got)
+		}
+
+		// users without groups
+		token, err = tm.ForgeUserToken(ctx, &api.User{AccountId: bob})
+		Check(t, err)
+		got, err = tm.DismantleUserToken(ctx, token)
+		Check(t, err)
+		if got.AccountId != bob || len(got.Groups) != 0 {
+			t.Fatalf("expected bob without groups, got %+v", got)
+		}
+	})
+
+	t.Run("PublicLinkToken", func(t *testing.T) {
+		pl := &api.PublicLink{Id: "1", Token: "abc", Path: "home:1", Protected: true, Expires: 1000, ReadOnly: true, Mtime: 10, ItemType: api.PublicLink_FOLDER, OwnerId: alice, Name: "dir"}
+		token, err := tm.ForgePublicLinkToken(ctx, pl)
+		Check(t, err)
+		got, err := tm.DismantlePublicLinkToken(ctx, token)
+		Check(t, err)
+		if !proto.Equal(got, pl) {
+			t.Fatalf("expected public link %+v, got %+v", pl, got)
+		}
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		_, err := tm.DismantleUserToken(ctx, "invalid")
+		ExpectCode(t, err, api.TokenInvalidErrorCode)
+		_, err = tm.DismantlePublicLinkToken(ctx, "invalid")
+		ExpectCode(t, err, api.TokenInvalidErrorCode)
+
+		// a user token is not a public link token
+		token, err := tm.ForgeUserToken(ctx, &api.User{AccountId: alice})
+		Check(t, err)
+		_, err = tm.DismantlePublicLinkToken(ctx, token)
+		ExpectCode(t, err, api.TokenInvalidErrorCode)
+	})
+}
+
+// TestAuthManager runs the suite of api.AuthManager on am, that must accept
+// the password of accountID. If wrongPassword is not empty, am must refuse it.
+func TestAuthManager(t *testing.T, am api.AuthManager, accountID, password, wrongPassword string) {
+	ctx := context.Background()
+	user, err := am.Authenticate(ctx, accountID, password)
+	Check(t, err)
+	if user.AccountId != accountID {
+		t.Fatalf("expected user %s, got %+v", accountID, user)
+	}
+
+	if wrongPassword != "" {
+		if _, err := am.Authenticate(ctx, accountID, wrongPassword); err == nil {
+			t.Fatal("expected error authenticating with a wrong password")
+		}
+	}
+}
@@ -29,9 +29,33 @@ const (
 
 	PublicLinkInvalidPasswordErrorCode ErrorCode = "PUBLIC_LINK_INVALID_PASSWORD"
 
+	// PublicLinkDownloadLimitErrorCode is used when a link has been
+	// downloaded as many times as allowed.
+	PublicLinkDownloadLimitErrorCode ErrorCode = "PUBLIC_LINK_DOWNLOAD_LIMIT"
+
+	// PublicLinkPasswordTooShortErrorCode is used when a password is shorter
+	// than the password policy allows.
+	PublicLinkPasswordTooShortErrorCode ErrorCode = "PUBLIC_LINK_PASSWORD_TOO_SHORT"
+
+	// PublicLinkPasswordTooWeakErrorCode is used when a password does not mix
+	// enough kinds of characters.
+	PublicLinkPasswordTooWeakErrorCode ErrorCode = "PUBLIC_LINK_PASSWORD_TOO_WEAK"
+
+	// PublicLinkPasswordBannedErrorCode is used when a password is in the
+	// banned list of the password policy.
+	PublicLinkPasswordBannedErrorCode ErrorCode = "PUBLIC_LINK_PASSWORD_BANNED"
+
+	// PublicLinkPasswordRequiredErrorCode is used when a writable link
+	// has no password but the password policy requires one.
+	PublicLinkPasswordRequiredErrorCode ErrorCode = "PUBLIC_LINK_PASSWORD_REQUIRED"
+
 	// FolderShareNotFoundErrorCode is used when a resource is not found.
 	FolderShareNotFoundErrorCode ErrorCode = "FOLDER_SHARE_NOT_FOUND"
 
+	// FolderShareInvalidPermissionsErrorCode is used when the permissions
+	// of a share cannot be granted.
+	FolderShareInvalidPermissionsErrorCode ErrorCode = "FOLDER_SHARE_INVALID_PERMISSIONS"
+
 	// StorageOperationNotSupported is used when some operation is not available on
 	// the storage, like emptying the recycle bin
 	StorageNotSupportedErrorCode ErrorCode = "STORAGE_NOT_SUPPORTED"
@@ -40,9 +64,42 @@ const (
 
 	TokenInvalidErrorCode ErrorCode = "TOKEN_INVALID"
 
+	// AppPasswordNotFoundErrorCode is used when a resource is not found.
+	AppPasswordNotFoundErrorCode ErrorCode = "APP_PASSWORD_NOT_FOUND"
+
+	// TooManyAttemptsErrorCode is used when an authentication is refused
+	// because of too many failed attempts.
+	TooManyAttemptsErrorCode ErrorCode = "TOO_MANY_ATTEMPTS"
+
+	// WatchOverflowErrorCode is used when a watcher did not keep up with the events,
+	// it has to list the content again as some changes were lost.
+	WatchOverflowErrorCode ErrorCode = "WATCH_OVERFLOW"
+
+	// WebhookNotFoundErrorCode is used when a resource is not found.
+	WebhookNotFoundErrorCode ErrorCode = "WEBHOOK_NOT_FOUND"
+
+	// DeadLetterNotFoundErrorCode is used when a resource is not found.
+	DeadLetterNotFoundErrorCode ErrorCode = "DEAD_LETTER_NOT_FOUND"
+
+	// PreviewNotSupportedErrorCode is used when no preview can be generated
+	// for the type of the file.
+	PreviewNotSupportedErrorCode ErrorCode = "PREVIEW_NOT_SUPPORTED"
+
+	// TagNotFoundErrorCode is used when a resource is not found.
+	TagNotFoundErrorCode ErrorCode = "TAG_NOT_FOUND"
+
+	// OCMShareNotFoundErrorCode is used when a resource is not found.
+	OCMShareNotFoundErrorCode ErrorCode = "OCM_SHARE_NOT_FOUND"
+
 	// ProjectNotFoundErrorCode is used when a resource is not found.
 	ProjectNotFoundErrorCode ErrorCode = "PROJECT_NOT_FOUND"
 
+	// ProjectAlreadyExistsErrorCode is used when a project with the same name exists.
+	ProjectAlreadyExistsErrorCode ErrorCode = "PROJECT_ALREADY_EXISTS"
+
+	// ProjectInvalidErrorCode is used when a project has a bad name, path or groups.
+	ProjectInvalidErrorCode ErrorCode = "PROJECT_INVALID"
+
 	UnknownError ErrorCode = "UNKNOWN"
 )
 
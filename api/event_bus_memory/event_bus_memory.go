@@ -0,0 +1,114 @@
+package event_bus_memory
+
+import (
+	"sync"
+
+	"github.com/cernbox/reva/api"
+)
+
+type subscription struct {
+	match  func(e *api.FileEvent) bool
+	events chan *api.FileEvent
+}
+
+type pendingEvent struct {
+	e        *api.FileEvent
+	complete func(e *api.FileEvent)
+}
+
+type eventBus struct {
+	sync.RWMutex
+	bufferSize    int
+	subscriptions map[*subscription]bool
+	pending       chan *pendingEvent
+}
+
+// New returns an event bus that delivers the events to the watchers connected
+// to this daemon. Every subscription buffers up to bufferSize events,
+// a subscription that overflows is closed. Up to bufferSize events wait
+// to be completed.
+func New(bufferSize int) api.EventBus {
+	b := &eventBus{bufferSize: bufferSize, subscriptions: map[*subscription]bool{}, pending: make(chan *pendingEvent, bufferSize)}
+	go b.complete()
+	return b
+}
+
+func (b *eventBus) complete() {
+	for p := range b.pending {
+		p.complete(p.e)
+		b.Publish(p.e)
+	}
+}
+
+func (b *eventBus) PublishAsync(e *api.FileEvent, complete func(e *api.FileEvent)) {
+	select {
+	case b.pending <- &pendingEvent{e: e, complete: complete}:
+	default:
+		// the subscribers are told that they missed the event, like on their own overflow
+		b.Lock()
+		defer b.Unlock()
+		for s := range b.subscriptions {
+			if s.match(e) {
+				delete(b.subscriptions, s)
+				close(s.events)
+			}
+		}
+	}
+}
+
+func (b *eventBus) Publish(e *api.FileEvent) {
+	b.Lock()
+	defer b.Unlock()
+	for s := range b.subscriptions {
+		if !s.match(e) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+			// the publisher must never block on a slow watcher
+			delete(b.subscriptions, s)
+			close(s.events)
+		}
+	}
+}
+
+func (b *eventBus) Subscribe(p string, recursive bool) (<-chan *api.FileEvent, func()) {
+	return b.SubscribeFunc(func(e *api.FileEvent) bool {
+		return api.IsWatchedPath(p, recursive, e.Path) || (e.OldPath != "" && api.IsWatchedPath(p, recursive, e.OldPath))
+	})
+}
+
+func (b *eventBus) SubscribeFunc(match func(e *api.FileEvent) bool) (<-chan *api.FileEvent, func()) {
+	s := &subscription{match: match, events: make(chan *api.FileEvent, b.bufferSize)}
+	b.Lock()
+	b.subscriptions[s] = true
+	b.Unlock()
+
+	cancel := func() {
+		b.Lock()
+		defer b.Unlock()
+		if b.subscriptions[s] {
+			delete(b.subscriptions, s)
+			close(s.events)
+		}
+	}
+	return s.events, cancel
+}
+
+func (b *eventBus) HasSubscribers() bool {
+	b.RLock()
+	defer b.RUnlock()
+	return len(b.subscriptions) > 0
+}
+
+func (b *eventBus) IsWatched(e *api.FileEvent) bool {
+	b.RLock()
+	defer b.RUnlock()
+	for s := range b.subscriptions {
+		if s.match(e) {
+			return true
+		}
+	}
+	return false
+}
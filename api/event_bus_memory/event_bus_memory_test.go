@@ -0,0 +1,145 @@
+package event_bus_memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cernbox/reva/api"
+)
+
+func TestSubscribe(t *testing.T) {
+	bus := New(10)
+	if bus.HasSubscribers() {
+		t.Fatal("expected no subscribers")
+	}
+
+	recursive, cancelRecursive := bus.Subscribe("/eos/project/a", true)
+	direct, cancelDirect := bus.Subscribe("/eos/project/a", false)
+	defer cancelRecursive()
+	defer cancelDirect()
+
+	bus.Publish(&api.FileEvent{Type: api.FileEvent_WRITTEN, Path: "/eos/project/a/file.txt"})
+	bus.Publish(&api.FileEvent{Type: api.FileEvent_WRITTEN, Path: "/eos/project/a/docs/file.txt"})
+	bus.Publish(&api.FileEvent{Type: api.FileEvent_WRITTEN, Path: "/eos/project/ab/file.txt"})
+	bus.Publish(&api.FileEvent{Type: api.FileEvent_MOVED, Path: "/eos/project/b/file.txt", OldPath: "/eos/project/a/moved.txt"})
+
+	expectPaths(t, recursive, "/eos/project/a/file.txt", "/eos/project/a/docs/file.txt", "/eos/project/b/file.txt")
+	expectPaths(t, direct, "/eos/project/a/file.txt", "/eos/project/b/file.txt")
+}
+
+func TestIsWatched(t *testing.T) {
+	bus := New(10)
+	events, cancel := bus.SubscribeFunc(func(e *api.FileEvent) bool {
+		return e.Type == api.FileEvent_DELETED && api.IsWatchedPath("/eos/project/a", true, e.Path)
+	})
+	defer cancel()
+
+	if bus.IsWatched(&api.FileEvent{Type: api.FileEvent_WRITTEN, Path: "/eos/project/a/file.txt"}) {
+		t.Fatal("expected the write not to be watched")
+	}
+	if bus.IsWatched(&api.FileEvent{Type: api.FileEvent_DELETED, Path: "/eos/project/b/file.txt"}) {
+		t.Fatal("expected the deletion outside of the tree not to be watched")
+	}
+	e := &api.FileEvent{Type: api.FileEvent_DELETED, Path: "/eos/project/a/file.txt"}
+	if !bus.IsWatched(e) {
+		t.Fatal("expected the deletion to be watched")
+	}
+	bus.Publish(&api.FileEvent{Type: api.FileEvent_WRITTEN, Path: "/eos/project/a/file.txt"})
+	bus.Publish(e)
+	expectPaths(t, events, "/eos/project/a/file.txt")
+}
+
+func TestOverflow(t *testing.T) {
+	bus := New(2)
+	events, cancel := bus.Subscribe("/", true)
+	defer cancel()
+
+	for i := 0; i < 3; i++ {
+		bus.Publish(&api.FileEvent{Type: api.FileEvent_CREATED, Path: "/dir"})
+	}
+	n := 0
+	for range events {
+		n++
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 buffered events before the channel is closed, got %d", n)
+	}
+	if bus.HasSubscribers() {
+		t.Fatal("expected the overflowed subscription to be removed")
+	}
+}
+
+func TestPublishAsync(t *testing.T) {
+	bus := New(10)
+	events, cancel := bus.Subscribe("/eos/project/a", true)
+	defer cancel()
+
+	// the events are completed in the background and published in order
+	release := make(chan bool)
+	for _, name := range []string{"first", "second"} {
+		name := name
+		bus.PublishAsync(&api.FileEvent{Type: api.FileEvent_CREATED, Path: "/eos/project/a/" + name}, func(e *api.FileEvent) {
+			<-release
+			e.FileId = name
+		})
+	}
+	release <- true
+	release <- true
+	for _, name := range []string{"first", "second"} {
+		select {
+		case e := <-events:
+			if e.FileId != name {
+				t.Fatalf("expected completed event %s, got %+v", name, e)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected event %s, got none", name)
+		}
+	}
+}
+
+func TestPublishAsyncOverflow(t *testing.T) {
+	bus := New(1)
+	events, cancel := bus.Subscribe("/eos/project/a", true)
+	defer cancel()
+	other, cancelOther := bus.Subscribe("/eos/project/b", true)
+	defer cancelOther()
+
+	// the first event blocks the completion, the second waits, the third overflows
+	release := make(chan bool)
+	defer close(release)
+	for i := 0; i < 3; i++ {
+		bus.PublishAsync(&api.FileEvent{Type: api.FileEvent_CREATED, Path: "/eos/project/a/file"}, func(e *api.FileEvent) { <-release })
+		if i == 0 {
+			// wait for the worker to take the first event
+			for len(bus.(*eventBus).pending) > 0 {
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}
+	if _, ok := <-events; ok {
+		t.Fatal("expected the subscription missing the event to be closed")
+	}
+	select {
+	case _, ok := <-other:
+		t.Fatalf("expected the other subscription to be kept open, got closed=%v", !ok)
+	default:
+	}
+}
+
+func expectPaths(t *testing.T, events <-chan *api.FileEvent, paths ...string) {
+	for _, p := range paths {
+		select {
+		case e := <-events:
+			if e.Path != p {
+				t.Fatalf("expected event on %s, got %s", p, e.Path)
+			}
+		default:
+			t.Fatalf("expected event on %s, got none", p)
+		}
+	}
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected event on %s", e.Path)
+	default:
+	}
+}
@@ -75,7 +75,7 @@ func (m *mount) GetPathByID(ctx context.Context, id string) (string, error) {
 	return path.Join(m.GetMountPoint(), p), nil
 }
 
-func (m *mount) SetACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (m *mount) SetACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	if !m.isSharingEnabled() {
 		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("sharing-disabled mount")
 	}
@@ -83,10 +83,10 @@ func (m *mount) SetACL(ctx context.Context, path string, readOnly bool, recipien
 	if err != nil {
 		return err
 	}
-	return m.storage.SetACL(ctx, p, readOnly, recipient, shareList)
+	return m.storage.SetACL(ctx, p, permissions, recipient, shareList)
 }
 
-func (m *mount) UpdateACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (m *mount) UpdateACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	if !m.isSharingEnabled() {
 		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("sharing-disabled mount")
 	}
@@ -94,7 +94,7 @@ func (m *mount) UpdateACL(ctx context.Context, path string, readOnly bool, recip
 	if err != nil {
 		return err
 	}
-	return m.storage.UpdateACL(ctx, p, readOnly, recipient, shareList)
+	return m.storage.UpdateACL(ctx, p, permissions, recipient, shareList)
 }
 
 func (m *mount) UnsetACL(ctx context.Context, path string, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
@@ -144,6 +144,53 @@ func (m *mount) Move(ctx context.Context, oldPath, newPath string) error {
 	}
 	return m.storage.Move(ctx, op, np)
 }
+func (m *mount) TransferOwnership(ctx context.Context, from, fromPath, to, toPath string) error {
+	if m.isReadOnly() {
+		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("read-only mount")
+	}
+	ts, ok := m.storage.(api.OwnershipTransferStorage)
+	if !ok {
+		return api.NewError(api.StorageNotSupportedErrorCode).WithMessage("ownership transfer not supported by mount " + m.mountPoint)
+	}
+	fp, _, err := m.getInternalPath(ctx, fromPath)
+	if err != nil {
+		return err
+	}
+	tp, _, err := m.getInternalPath(ctx, toPath)
+	if err != nil {
+		return err
+	}
+	return ts.TransferOwnership(ctx, from, fp, to, tp)
+}
+func (m *mount) ProvisionDir(ctx context.Context, owner, p string) error {
+	if m.isReadOnly() {
+		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("read-only mount")
+	}
+	ps, ok := m.storage.(api.ProvisioningStorage)
+	if !ok {
+		return api.NewError(api.StorageNotSupportedErrorCode).WithMessage("provisioning not supported by mount " + m.mountPoint)
+	}
+	internalPath, _, err := m.getInternalPath(ctx, p)
+	if err != nil {
+		return err
+	}
+	return ps.ProvisionDir(ctx, owner, internalPath)
+}
+func (m *mount) SetQuota(ctx context.Context, owner, p string, maxBytes uint64) error {
+	if m.isReadOnly() {
+		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("read-only mount")
+	}
+	ps, ok := m.storage.(api.ProvisioningStorage)
+	if !ok {
+		return api.NewError(api.StorageNotSupportedErrorCode).WithMessage("quota not supported by mount " + m.mountPoint)
+	}
+	internalPath, _, err := m.getInternalPath(ctx, p)
+	if err != nil {
+		return err
+	}
+	return ps.SetQuota(ctx, owner, internalPath, maxBytes)
+}
+
 func (m *mount) GetMetadata(ctx context.Context, p string) (*api.Metadata, error) {
 	l := ctx_zap.Extract(ctx)
 	l.Debug("GetMetadata", zap.String("path", p))
@@ -195,6 +242,9 @@ func (m *mount) ListFolder(ctx context.Context, p string) ([]*api.Metadata, erro
 		f.Path = path.Join(mountPrefix, internalPath)
 		l.Debug("path conversion: internal => external", zap.String("external", f.Path), zap.String("internal", internalPath))
 		f.Id = m.GetMountPointId() + f.Id
+		if f.VersionFolderId != "" {
+			f.VersionFolderId = m.GetMountPointId() + f.VersionFolderId
+		}
 		if f.IsShareable {
 			f.IsShareable = m.isSharingEnabled()
 		}
@@ -267,6 +317,9 @@ func (m *mount) ListRecycle(ctx context.Context, p string) ([]*api.RecycleEntry,
 	for _, e := range entries {
 		e.RestoreKey = fmt.Sprintf("%s%s", m.mountPointId, e.RestoreKey)
 		e.RestorePath = path.Join(m.mountPoint, e.RestorePath)
+		if e.FileId != "" {
+			e.FileId = m.mountPointId + e.FileId
+		}
 	}
 	return entries, nil
 }
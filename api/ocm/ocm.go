@@ -0,0 +1,285 @@
+// Package ocm implements the messages of the Open Cloud Mesh protocol, used to
+// share files with the users of other providers, and a client to send them.
+package ocm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cernbox/reva/api"
+)
+
+const (
+	// APIVersion is the version of the protocol spoken by this implementation.
+	APIVersion = "1.0-proposal1"
+
+	// ProtocolWebDAV is the only protocol offered to access the shared files.
+	ProtocolWebDAV = "webdav"
+
+	// NotificationShareAccepted is sent by the recipient when it accepts a share.
+	NotificationShareAccepted = "SHARE_ACCEPTED"
+	// NotificationShareDeclined is sent by the recipient when it rejects a share.
+	NotificationShareDeclined = "SHARE_DECLINED"
+	// NotificationShareUnshared is sent by the owner when it removes a share.
+	NotificationShareUnshared = "SHARE_UNSHARED"
+)
+
+// Provider is the discovery document a provider serves at /ocm-provider/.
+type Provider struct {
+	Enabled       bool            `json:"enabled"`
+	APIVersion    string          `json:"apiVersion"`
+	EndPoint      string          `json:"endPoint"`
+	Provider      string          `json:"provider"`
+	ResourceTypes []*ResourceType `json:"resourceTypes"`
+}
+
+// ResourceType lists the share types and the protocols offered for a type of resource.
+// The protocols map the protocol name to its path on the provider.
+type ResourceType struct {
+	Name       string            `json:"name"`
+	ShareTypes []string          `json:"shareTypes"`
+	Protocols  map[string]string `json:"protocols"`
+}
+
+// Share is the body POSTed to {endPoint}/shares to create a share
+// on the provider of its recipient.
+type Share struct {
+	ShareWith    string    `json:"shareWith"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description,omitempty"`
+	ProviderID   string    `json:"providerId"`
+	Owner        string    `json:"owner"`
+	Sender       string    `json:"sender"`
+	ShareType    string    `json:"shareType"`
+	ResourceType string    `json:"resourceType"`
+	Protocol     *Protocol `json:"protocol"`
+}
+
+// Protocol describes how the recipient accesses the shared files.
+type Protocol struct {
+	Name    string           `json:"name"`
+	Options *ProtocolOptions `json:"options"`
+}
+
+// ProtocolOptions are the options of the webdav protocol: the secret sent
+// as basic auth user name and the permissions granted.
+type ProtocolOptions struct {
+	SharedSecret string   `json:"sharedSecret"`
+	Permissions  []string `json:"permissions,omitempty"`
+}
+
+// Notification is the body POSTed to {endPoint}/notifications to tell
+// the other side of a share about its changes.
+type Notification struct {
+	NotificationType string            `json:"notificationType"`
+	ResourceType     string            `json:"resourceType"`
+	ProviderID       string            `json:"providerId"`
+	Notification     *NotificationData `json:"notification"`
+}
+
+// NotificationData authenticates the notification with the secret of the share.
+type NotificationData struct {
+	SharedSecret string `json:"sharedSecret"`
+	Message      string `json:"message,omitempty"`
+}
+
+// ParseAddress splits the federated address user@https://provider.example.org
+// into the user and the URL of its provider.
+func ParseAddress(address string) (string, string, error) {
+	i := strings.LastIndex(address, "@")
+	if i <= 0 {
+		return "", "", api.NewError(api.PathInvalidError).WithMessage("invalid federated address: " + address)
+	}
+	user, provider := address[:i], strings.TrimSuffix(address[i+1:], "/")
+	u, err := url.Parse(provider)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", "", api.NewError(api.PathInvalidError).WithMessage("invalid federated address: " + address)
+	}
+	return user, provider, nil
+}
+
+// FormatAddress returns the federated address of user on provider.
+func FormatAddress(user, provider string) string {
+	return user + "@" + strings.TrimSuffix(provider, "/")
+}
+
+// GetProtocolPermissions returns the permissions of the webdav protocol granting p.
+func GetProtocolPermissions(p api.SharePermissions) []string {
+	permissions := []string{}
+	if p.Has(api.SharePermissionRead) {
+		permissions = append(permissions, "read")
+	}
+	if p.Has(api.SharePermissionUpdate) {
+		permissions = append(permissions, "write")
+	}
+	if p.Has(api.SharePermissionShare) {
+		permissions = append(permissions, "share")
+	}
+	return permissions
+}
+
+// GetSharePermissions returns the permissions granted by the permissions of the
+// webdav protocol, the share is read-only if they are missing.
+func GetSharePermissions(permissions []string) api.SharePermissions {
+	p := api.SharePermissionsReadOnly
+	for _, perm := range permissions {
+		switch perm {
+		case "write":
+			p |= api.SharePermissionsReadWrite
+		case "share":
+			p |= api.SharePermissionShare
+		}
+	}
+	return p
+}
+
+// WebDAVURL returns the URL to access the files shared by the provider,
+// whose URL is base, with the webdav protocol.
+func (p *Provider) WebDAVURL(base string) (string, error) {
+	for _, rt := range p.ResourceTypes {
+		if rt.Name != "file" {
+			continue
+		}
+		if path, ok := rt.Protocols[ProtocolWebDAV]; ok {
+			b, err := url.Parse(strings.TrimSuffix(base, "/") + "/")
+			if err != nil {
+				return "", err
+			}
+			u, err := b.Parse(strings.TrimPrefix(path, "/"))
+			if err != nil {
+				return "", err
+			}
+			if !isSameOrigin(base, u.String()) {
+				return "", fmt.Errorf("webdav url of provider %s is on another host: %s", base, u)
+			}
+			return strings.TrimSuffix(u.String(), "/"), nil
+		}
+	}
+	return "", fmt.Errorf("provider %s does not offer files over webdav", base)
+}
+
+// Client sends the requests of the protocol to the other providers.
+type Client struct {
+	client *http.Client
+}
+
+// NewClient returns a client whose requests time out after timeout.
+// The redirects are not followed, so that the requests only reach
+// the trusted providers.
+func NewClient(timeout time.Duration) *Client {
+	return &Client{client: &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+// isSameOrigin returns true if u has the scheme and the host of base.
+func isSameOrigin(base, u string) bool {
+	b, err := url.Parse(base)
+	if err != nil {
+		return false
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return false
+	}
+	return parsed.Scheme == b.Scheme && parsed.Host == b.Host
+}
+
+// Discover returns the discovery document of the provider, whose URL is base.
+// The endpoint must be on the provider, as the shares and the notifications
+// are sent to it.
+func (c *Client) Discover(ctx context.Context, base string) (*Provider, error) {
+	req, err := http.NewRequest("GET", strings.TrimSuffix(base, "/")+"/ocm-provider/", nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Provider{}
+	if err := json.Unmarshal(body, p); err != nil {
+		return nil, fmt.Errorf("invalid discovery document of %s: %v", base, err)
+	}
+	if !p.Enabled || p.EndPoint == "" {
+		return nil, fmt.Errorf("provider %s does not support ocm", base)
+	}
+	if !isSameOrigin(base, p.EndPoint) {
+		return nil, fmt.Errorf("endpoint of provider %s is on another host: %s", base, p.EndPoint)
+	}
+	return p, nil
+}
+
+// SendShare creates the share on the provider of its recipient, whose endpoint is endPoint.
+func (c *Client) SendShare(ctx context.Context, endPoint string, s *Share) error {
+	return c.post(ctx, strings.TrimSuffix(endPoint, "/")+"/shares", s)
+}
+
+// SendNotification notifies the provider on the other side of a share, whose endpoint is endPoint.
+func (c *Client) SendNotification(ctx context.Context, endPoint string, n *Notification) error {
+	return c.post(ctx, strings.TrimSuffix(endPoint, "/")+"/notifications", n)
+}
+
+// CheckSecret checks that the secret of a share grants access to the shared
+// files at webdavURL, with a PROPFIND authenticated by the secret as the
+// files are accessed afterwards.
+func (c *Client) CheckSecret(ctx context.Context, webdavURL, secret string) error {
+	req, err := http.NewRequest("PROPFIND", strings.TrimSuffix(webdavURL, "/")+"/", nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(secret, "")
+	req.Header.Set("Depth", "0")
+	res, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusMultiStatus {
+		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage(fmt.Sprintf("secret of share rejected by %s with status %d", webdavURL, res.StatusCode))
+	}
+	return nil
+}
+
+func (c *Client) post(ctx context.Context, url string, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	_, err = c.do(ctx, req)
+	return err
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request) ([]byte, error) {
+	req.Header.Set("Accept", "application/json")
+	res, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s returned status %d: %s", req.Method, req.URL, res.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
@@ -0,0 +1,122 @@
+package ocm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cernbox/reva/api"
+)
+
+func TestParseAddress(t *testing.T) {
+	user, provider, err := ParseAddress("alice@example.org@https://cloud.example.org/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user != "alice@example.org" || provider != "https://cloud.example.org" {
+		t.Fatalf("unexpected user %q and provider %q", user, provider)
+	}
+	if FormatAddress(user, provider) != "alice@example.org@https://cloud.example.org" {
+		t.Fatalf("unexpected address %q", FormatAddress(user, provider))
+	}
+
+	for _, address := range []string{"alice", "@https://cloud.example.org", "alice@cloud.example.org", "alice@ftp://cloud.example.org"} {
+		if _, _, err := ParseAddress(address); !api.IsErrorCode(err, api.PathInvalidError) {
+			t.Fatalf("expected %q to be invalid, got %v", address, err)
+		}
+	}
+}
+
+func TestPermissions(t *testing.T) {
+	for _, p := range []api.SharePermissions{api.SharePermissionsReadOnly, api.SharePermissionsReadWrite, api.SharePermissionsAll} {
+		if got := GetSharePermissions(GetProtocolPermissions(p)); got != p {
+			t.Fatalf("expected permissions %d, got %d", p, got)
+		}
+	}
+}
+
+func TestClient(t *testing.T) {
+	shares := make(chan *Share, 1)
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/ocm-provider/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&Provider{
+			Enabled:       true,
+			APIVersion:    APIVersion,
+			EndPoint:      server.URL + "/ocm",
+			ResourceTypes: []*ResourceType{{Name: "file", ShareTypes: []string{"user"}, Protocols: map[string]string{ProtocolWebDAV: "/ocm/webdav/"}}},
+		})
+	})
+	mux.HandleFunc("/other/ocm-provider/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&Provider{Enabled: true, APIVersion: APIVersion, EndPoint: "http://169.254.169.254/ocm"})
+	})
+	mux.HandleFunc("/redirect/ocm-provider/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, server.URL+"/ocm-provider/", http.StatusFound)
+	})
+	mux.HandleFunc("/ocm/shares", func(w http.ResponseWriter, r *http.Request) {
+		s := &Share{}
+		if err := json.NewDecoder(r.Body).Decode(s); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		shares <- s
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/ocm/notifications", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/ocm/webdav/", func(w http.ResponseWriter, r *http.Request) {
+		if secret, _, _ := r.BasicAuth(); r.Method != "PROPFIND" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+	})
+
+	ctx := context.Background()
+	c := NewClient(time.Second)
+	p, err := c.Discover(ctx, server.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	webdav, err := p.WebDAVURL(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if webdav != server.URL+"/ocm/webdav" {
+		t.Fatalf("unexpected webdav url %q", webdav)
+	}
+
+	s := &Share{ShareWith: "bob", Name: "docs", ProviderID: "1", Owner: "alice@http://localhost", Protocol: &Protocol{Name: ProtocolWebDAV, Options: &ProtocolOptions{SharedSecret: "secret"}}}
+	if err := c.SendShare(ctx, p.EndPoint, s); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-shares; got.ShareWith != "bob" || got.Protocol.Options.SharedSecret != "secret" {
+		t.Fatalf("unexpected share received: %+v", got)
+	}
+
+	if err := c.CheckSecret(ctx, webdav, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CheckSecret(ctx, webdav, "forged"); !api.IsErrorCode(err, api.StoragePermissionDeniedErrorCode) {
+		t.Fatalf("expected forged secret to be rejected, got %v", err)
+	}
+
+	if err := c.SendNotification(ctx, p.EndPoint, &Notification{NotificationType: NotificationShareUnshared}); err == nil {
+		t.Fatal("expected the notification to fail")
+	}
+	if _, err := c.Discover(ctx, server.URL+"/ocm"); err == nil {
+		t.Fatal("expected discovery of a provider without ocm to fail")
+	}
+	// the requests never leave the provider
+	if _, err := c.Discover(ctx, server.URL+"/other"); err == nil {
+		t.Fatal("expected discovery of a provider with an endpoint on another host to fail")
+	}
+	if _, err := c.Discover(ctx, server.URL+"/redirect"); err == nil {
+		t.Fatal("expected the redirect not to be followed")
+	}
+}
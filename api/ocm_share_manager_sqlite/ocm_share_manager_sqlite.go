@@ -0,0 +1,373 @@
+package ocm_share_manager_sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/sqlite_db"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+)
+
+// migrations of the schema, they are only ever appended.
+var migrations = []string{
+	`create table ocm_shares (
+		id integer primary key autoincrement,
+		owner text not null,
+		recipient text not null,
+		path text not null,
+		name text not null,
+		permissions integer not null,
+		state integer not null,
+		ctime integer not null,
+		endpoint text not null,
+		secret text not null unique
+	);
+	create index ocm_shares_owner on ocm_shares (owner);
+	create table ocm_received_shares (
+		id integer primary key autoincrement,
+		owner text not null,
+		recipient text not null,
+		name text not null,
+		permissions integer not null,
+		state integer not null,
+		ctime integer not null,
+		provider_id text not null,
+		endpoint text not null,
+		webdav_url text not null,
+		secret text not null
+	);
+	create index ocm_received_shares_recipient on ocm_received_shares (recipient);
+	create unique index ocm_received_shares_provider on ocm_received_shares (provider_id, secret)`,
+}
+
+const (
+	shareColumns         = "id, owner, recipient, path, name, permissions, state, ctime, endpoint"
+	receivedShareColumns = "id, owner, recipient, name, permissions, state, ctime, provider_id, endpoint, webdav_url"
+)
+
+// New returns an OCM share manager that keeps the shares in the SQLite database in file.
+func New(file string) (api.OCMShareManager, error) {
+	db, err := sqlite_db.Open(file, "ocm_share_manager", migrations)
+	if err != nil {
+		return nil, err
+	}
+	return &shareManager{db: db}, nil
+}
+
+type shareManager struct {
+	db *sql.DB
+}
+
+func (sm *shareManager) AddOCMShare(ctx context.Context, share *api.OCMShare, secret string) (*api.OCMShare, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	ctime := time.Now().Unix()
+	query := "insert into ocm_shares (owner, recipient, path, name, permissions, state, ctime, endpoint, secret) values (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := sm.db.Exec(query, u.AccountId, share.Recipient, share.Path, share.Name, share.Permissions, api.OCMShare_PENDING, ctime, share.Endpoint, secret)
+	if err != nil {
+		l.Error("error inserting ocm share", zap.Error(err))
+		return nil, err
+	}
+	lastID, err := result.LastInsertId()
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	newShare := &api.OCMShare{
+		Id:          fmt.Sprintf("%d", lastID),
+		OwnerId:     u.AccountId,
+		Recipient:   share.Recipient,
+		Path:        share.Path,
+		Name:        share.Name,
+		Permissions: share.Permissions,
+		State:       api.OCMShare_PENDING,
+		Ctime:       uint64(ctime),
+		ProviderId:  fmt.Sprintf("%d", lastID),
+		Endpoint:    share.Endpoint,
+	}
+	l.Info("ocm share created", zap.String("id", newShare.Id), zap.String("path", share.Path), zap.String("recipient", share.Recipient))
+	return newShare, nil
+}
+
+func (sm *shareManager) GetOCMShare(ctx context.Context, id string) (*api.OCMShare, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	query := "select " + shareColumns + " from ocm_shares where id=? and owner=?"
+	share, err := scanShare(sm.db.QueryRow(query, id, u.AccountId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, api.NewError(api.OCMShareNotFoundErrorCode)
+		}
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	return share, nil
+}
+
+func (sm *shareManager) ListOCMShares(ctx context.Context) ([]*api.OCMShare, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	rows, err := sm.db.Query("select "+shareColumns+" from ocm_shares where owner=? order by id", u.AccountId)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	shares := []*api.OCMShare{}
+	for rows.Next() {
+		share, err := scanShare(rows)
+		if err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+	return shares, rows.Err()
+}
+
+func (sm *shareManager) RemoveOCMShare(ctx context.Context, id string) error {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	res, err := sm.db.Exec("delete from ocm_shares where id=? and owner=?", id, u.AccountId)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	return checkRowsAffected(res)
+}
+
+func (sm *shareManager) GetOCMShareSecret(ctx context.Context, id string) (string, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return "", err
+	}
+
+	var secret string
+	if err := sm.db.QueryRow("select secret from ocm_shares where id=? and owner=?", id, u.AccountId).Scan(&secret); err != nil {
+		if err == sql.ErrNoRows {
+			return "", api.NewError(api.OCMShareNotFoundErrorCode)
+		}
+		l.Error("", zap.Error(err))
+		return "", err
+	}
+	return secret, nil
+}
+
+func (sm *shareManager) SetOCMShareState(ctx context.Context, id, secret string, state api.OCMShare_State) error {
+	l := ctx_zap.Extract(ctx)
+	res, err := sm.db.Exec("update ocm_shares set state=? where id=? and secret=?", state, id, secret)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	return checkRowsAffected(res)
+}
+
+func (sm *shareManager) AddReceivedOCMShare(ctx context.Context, share *api.OCMShare, secret string) (*api.OCMShare, error) {
+	l := ctx_zap.Extract(ctx)
+	ctime := time.Now().Unix()
+	query := "insert into ocm_received_shares (owner, recipient, name, permissions, state, ctime, provider_id, endpoint, webdav_url, secret) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := sm.db.Exec(query, share.OwnerId, share.Recipient, share.Name, share.Permissions, api.OCMShare_PENDING, ctime, share.ProviderId, share.Endpoint, share.WebdavUrl, secret)
+	if err != nil {
+		l.Error("error inserting received ocm share", zap.Error(err))
+		return nil, err
+	}
+	lastID, err := result.LastInsertId()
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	newShare := &api.OCMShare{
+		Id:          fmt.Sprintf("%d", lastID),
+		OwnerId:     share.OwnerId,
+		Recipient:   share.Recipient,
+		Name:        share.Name,
+		Permissions: share.Permissions,
+		State:       api.OCMShare_PENDING,
+		Ctime:       uint64(ctime),
+		ProviderId:  share.ProviderId,
+		Endpoint:    share.Endpoint,
+		WebdavUrl:   share.WebdavUrl,
+	}
+	l.Info("ocm share received", zap.String("id", newShare.Id), zap.String("owner", share.OwnerId), zap.String("recipient", share.Recipient))
+	return newShare, nil
+}
+
+func (sm *shareManager) GetReceivedOCMShare(ctx context.Context, id string) (*api.OCMShare, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	query := "select " + receivedShareColumns + " from ocm_received_shares where id=? and recipient=?"
+	share, err := scanReceivedShare(sm.db.QueryRow(query, id, u.AccountId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, api.NewError(api.OCMShareNotFoundErrorCode)
+		}
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	return share, nil
+}
+
+func (sm *shareManager) ListReceivedOCMShares(ctx context.Context) ([]*api.OCMShare, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	rows, err := sm.db.Query("select "+receivedShareColumns+" from ocm_received_shares where recipient=? order by id", u.AccountId)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	shares := []*api.OCMShare{}
+	for rows.Next() {
+		share, err := scanReceivedShare(rows)
+		if err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+	return shares, rows.Err()
+}
+
+func (sm *shareManager) SetReceivedOCMShareState(ctx context.Context, id string, state api.OCMShare_State) (*api.OCMShare, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	res, err := sm.db.Exec("update ocm_received_shares set state=? where id=? and recipient=?", state, id, u.AccountId)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	if err := checkRowsAffected(res); err != nil {
+		return nil, err
+	}
+	return sm.GetReceivedOCMShare(ctx, id)
+}
+
+func (sm *shareManager) GetReceivedOCMShareSecret(ctx context.Context, id string) (string, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return "", err
+	}
+
+	var secret string
+	if err := sm.db.QueryRow("select secret from ocm_received_shares where id=? and recipient=?", id, u.AccountId).Scan(&secret); err != nil {
+		if err == sql.ErrNoRows {
+			return "", api.NewError(api.OCMShareNotFoundErrorCode)
+		}
+		l.Error("", zap.Error(err))
+		return "", err
+	}
+	return secret, nil
+}
+
+func (sm *shareManager) RemoveReceivedOCMShare(ctx context.Context, providerID, secret string) error {
+	l := ctx_zap.Extract(ctx)
+	res, err := sm.db.Exec("delete from ocm_received_shares where provider_id=? and secret=?", providerID, secret)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	return checkRowsAffected(res)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanShare(row scanner) (*api.OCMShare, error) {
+	var id, ctime int64
+	var permissions uint32
+	var state int32
+	share := &api.OCMShare{}
+	if err := row.Scan(&id, &share.OwnerId, &share.Recipient, &share.Path, &share.Name, &permissions, &state, &ctime, &share.Endpoint); err != nil {
+		return nil, err
+	}
+	share.Id = fmt.Sprintf("%d", id)
+	share.ProviderId = share.Id
+	share.Permissions = permissions
+	share.State = api.OCMShare_State(state)
+	share.Ctime = uint64(ctime)
+	return share, nil
+}
+
+func scanReceivedShare(row scanner) (*api.OCMShare, error) {
+	var id, ctime int64
+	var permissions uint32
+	var state int32
+	share := &api.OCMShare{}
+	if err := row.Scan(&id, &share.OwnerId, &share.Recipient, &share.Name, &permissions, &state, &ctime, &share.ProviderId, &share.Endpoint, &share.WebdavUrl); err != nil {
+		return nil, err
+	}
+	share.Id = fmt.Sprintf("%d", id)
+	share.Permissions = permissions
+	share.State = api.OCMShare_State(state)
+	share.Ctime = uint64(ctime)
+	return share, nil
+}
+
+func checkRowsAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return api.NewError(api.OCMShareNotFoundErrorCode)
+	}
+	return nil
+}
+
+func getUserFromContext(ctx context.Context) (*api.User, error) {
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		return nil, api.NewError(api.ContextUserRequiredError)
+	}
+	return u, nil
+}
@@ -0,0 +1,99 @@
+package ocm_share_manager_sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+)
+
+func TestOCMShares(t *testing.T) {
+	sm, err := New("")
+	conformance.Check(t, err)
+	aliceCtx := conformance.UserContext("alice")
+	bobCtx := conformance.UserContext("bob")
+
+	share, err := sm.AddOCMShare(aliceCtx, &api.OCMShare{Path: "/home/docs", Name: "docs", Recipient: "bob@https://cloud.example.org", Permissions: uint32(api.SharePermissionsReadOnly), Endpoint: "https://cloud.example.org/ocm"}, "secret")
+	conformance.Check(t, err)
+	if share.OwnerId != "alice" || share.State != api.OCMShare_PENDING || share.ProviderId != share.Id {
+		t.Fatalf("unexpected share: %+v", share)
+	}
+
+	// the shares are only visible to their owner
+	_, err = sm.GetOCMShare(bobCtx, share.Id)
+	conformance.ExpectCode(t, err, api.OCMShareNotFoundErrorCode)
+	shares, err := sm.ListOCMShares(bobCtx)
+	conformance.Check(t, err)
+	if len(shares) != 0 {
+		t.Fatalf("expected no shares for bob, got %d", len(shares))
+	}
+	secret, err := sm.GetOCMShareSecret(aliceCtx, share.Id)
+	conformance.Check(t, err)
+	if secret != "secret" {
+		t.Fatalf("unexpected secret %q", secret)
+	}
+
+	// the answer of the recipient needs the secret
+	conformance.ExpectCode(t, sm.SetOCMShareState(context.Background(), share.Id, "wrong", api.OCMShare_ACCEPTED), api.OCMShareNotFoundErrorCode)
+	conformance.Check(t, sm.SetOCMShareState(context.Background(), share.Id, "secret", api.OCMShare_ACCEPTED))
+	got, err := sm.GetOCMShare(aliceCtx, share.Id)
+	conformance.Check(t, err)
+	if got.State != api.OCMShare_ACCEPTED || got.Path != "/home/docs" || got.Recipient != share.Recipient {
+		t.Fatalf("unexpected share: %+v", got)
+	}
+
+	conformance.ExpectCode(t, sm.RemoveOCMShare(bobCtx, share.Id), api.OCMShareNotFoundErrorCode)
+	conformance.Check(t, sm.RemoveOCMShare(aliceCtx, share.Id))
+	shares, err = sm.ListOCMShares(aliceCtx)
+	conformance.Check(t, err)
+	if len(shares) != 0 {
+		t.Fatalf("expected no shares for alice, got %d", len(shares))
+	}
+}
+
+func TestReceivedOCMShares(t *testing.T) {
+	sm, err := New("")
+	conformance.Check(t, err)
+	bobCtx := conformance.UserContext("bob")
+	carolCtx := conformance.UserContext("carol")
+
+	// the received shares are added without user in the context
+	share, err := sm.AddReceivedOCMShare(context.Background(), &api.OCMShare{OwnerId: "alice@https://cloud.example.org", Recipient: "bob", Name: "docs", ProviderId: "42", Permissions: uint32(api.SharePermissionsReadWrite), Endpoint: "https://cloud.example.org/ocm", WebdavUrl: "https://cloud.example.org/ocm/webdav"}, "secret")
+	conformance.Check(t, err)
+	if share.State != api.OCMShare_PENDING {
+		t.Fatalf("expected the received share to be pending, got %s", share.State)
+	}
+
+	shares, err := sm.ListReceivedOCMShares(bobCtx)
+	conformance.Check(t, err)
+	if len(shares) != 1 || shares[0].WebdavUrl != share.WebdavUrl || shares[0].ProviderId != "42" {
+		t.Fatalf("unexpected received shares: %+v", shares)
+	}
+	shares, err = sm.ListReceivedOCMShares(carolCtx)
+	conformance.Check(t, err)
+	if len(shares) != 0 {
+		t.Fatalf("expected no received shares for carol, got %d", len(shares))
+	}
+
+	_, err = sm.SetReceivedOCMShareState(carolCtx, share.Id, api.OCMShare_ACCEPTED)
+	conformance.ExpectCode(t, err, api.OCMShareNotFoundErrorCode)
+	accepted, err := sm.SetReceivedOCMShareState(bobCtx, share.Id, api.OCMShare_ACCEPTED)
+	conformance.Check(t, err)
+	if accepted.State != api.OCMShare_ACCEPTED {
+		t.Fatalf("expected the share to be accepted, got %s", accepted.State)
+	}
+	secret, err := sm.GetReceivedOCMShareSecret(bobCtx, share.Id)
+	conformance.Check(t, err)
+	if secret != "secret" {
+		t.Fatalf("unexpected secret %q", secret)
+	}
+	_, err = sm.GetReceivedOCMShareSecret(carolCtx, share.Id)
+	conformance.ExpectCode(t, err, api.OCMShareNotFoundErrorCode)
+
+	// the owner unshares with the secret of the share
+	conformance.ExpectCode(t, sm.RemoveReceivedOCMShare(context.Background(), "42", "wrong"), api.OCMShareNotFoundErrorCode)
+	conformance.Check(t, sm.RemoveReceivedOCMShare(context.Background(), "42", "secret"))
+	_, err = sm.GetReceivedOCMShare(bobCtx, share.Id)
+	conformance.ExpectCode(t, err, api.OCMShareNotFoundErrorCode)
+}
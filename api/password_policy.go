@@ -0,0 +1,113 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordPolicy is the policy of the passwords protecting the public links,
+// enforced by the public link managers when the links are created and updated.
+// A nil policy accepts any password.
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters of the passwords.
+	MinLength int `json:"min_length"`
+
+	// MinClasses is the minimum number of classes of characters, among
+	// lower case letters, upper case letters, digits and the others.
+	MinClasses int `json:"min_classes"`
+
+	// Banned are the passwords refused whatever their strength,
+	// compared ignoring the case.
+	Banned []string `json:"banned"`
+
+	// RequiredForWritable forces a password on the links that are not read-only,
+	// drop-only links included.
+	RequiredForWritable bool `json:"required_for_writable"`
+
+	// HashCost is the bcrypt cost of the hashes of the passwords, the managers
+	// use their own default if 0. The hashes of a lower cost are upgraded when the
+	// links are authenticated, the only moment the passwords are known.
+	HashCost int `json:"hash_cost"`
+}
+
+// CheckPassword returns the error with the first rule the password breaks.
+func (p *PasswordPolicy) CheckPassword(password string) error {
+	if p == nil {
+		return nil
+	}
+	if len([]rune(password)) < p.MinLength {
+		return NewError(PublicLinkPasswordTooShortErrorCode).WithMessage(fmt.Sprintf("password must have at least %d characters", p.MinLength))
+	}
+	if classes := countCharacterClasses(password); classes < p.MinClasses {
+		return NewError(PublicLinkPasswordTooWeakErrorCode).WithMessage(fmt.Sprintf("password must mix at least %d kinds of characters", p.MinClasses))
+	}
+	for _, banned := range p.Banned {
+		if strings.EqualFold(password, banned) {
+			return NewError(PublicLinkPasswordBannedErrorCode).WithMessage("password is too common")
+		}
+	}
+	return nil
+}
+
+// CheckPublicLink checks the password of the link that results from applying opt,
+// to the link current or to a new link if current is nil.
+func (p *PasswordPolicy) CheckPublicLink(opt *PublicLinkOptions, current *PublicLink) error {
+	if p == nil {
+		return nil
+	}
+
+	readOnly, protected := opt.ReadOnly, opt.Password != ""
+	if current != nil {
+		if !opt.UpdateReadOnly && !opt.UpdateDropOnly {
+			readOnly = current.ReadOnly
+		}
+		if !opt.UpdatePassword {
+			protected = current.Protected
+		}
+	}
+
+	// the passwords already set have been checked when they were set
+	if (current == nil || opt.UpdatePassword) && opt.Password != "" {
+		if err := p.CheckPassword(opt.Password); err != nil {
+			return err
+		}
+	}
+	if p.RequiredForWritable && !readOnly && !protected {
+		return NewError(PublicLinkPasswordRequiredErrorCode).WithMessage("writable links must be protected by a password")
+	}
+	return nil
+}
+
+// GetHashCost returns the bcrypt cost of the new hashes, defaultCost if not set.
+func (p *PasswordPolicy) GetHashCost(defaultCost int) int {
+	if p == nil || p.HashCost == 0 {
+		return defaultCost
+	}
+	return p.HashCost
+}
+
+// NeedsRehash returns true if the bcrypt hash is weaker than the hashes of cost.
+func NeedsRehash(hash string, cost int) bool {
+	hashCost, err := bcrypt.Cost([]byte(hash))
+	return err == nil && hashCost < cost
+}
+
+func countCharacterClasses(password string) int {
+	var lower, upper, digit, other int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
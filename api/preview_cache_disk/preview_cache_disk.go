@@ -0,0 +1,281 @@
+package preview_cache_disk
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+var (
+	cacheSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "reva_preview_cache_size_bytes",
+		Help: "Size of the previews stored in the cache.",
+	})
+
+	cacheEntries = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "reva_preview_cache_entries",
+		Help: "Number of previews stored in the cache.",
+	})
+
+	cacheEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "reva_preview_cache_evictions_total",
+		Help: "Number of previews removed from the cache.",
+	}, []string{"reason"})
+)
+
+func init() {
+	prometheus.MustRegister(cacheSize, cacheEntries, cacheEvictions)
+}
+
+// The previews of a file are stored in a folder named after the hash of
+// its file ID, and every preview in a file named after the hash of the
+// etag followed by the variant:
+//
+//	<folder>/<sha256(file id)>/<sha256(etag)>-<variant>
+type cache struct {
+	folder  string
+	maxSize int64
+
+	sync.Mutex
+	size    int64
+	lru     *list.List                 // front is the most recently used
+	entries map[string]*list.Element   // by relative path
+	files   map[string]map[string]bool // relative paths of the previews by folder of the file
+}
+
+type entry struct {
+	path string
+	size int64
+}
+
+// New returns a cache storing the previews in folder, removing
+// the least recently used ones when they exceed maxSize bytes.
+// The previews already in folder are kept, so the cache survives restarts.
+// The size is enforced by every daemon on its own, when several daemons
+// share the folder each one only evicts the previews it knows about.
+func New(folder string, maxSize int64) (api.PreviewCache, error) {
+	if err := os.MkdirAll(folder, 0700); err != nil {
+		return nil, err
+	}
+	c := &cache{folder: folder, maxSize: maxSize, lru: list.New(), entries: map[string]*list.Element{}, files: map[string]map[string]bool{}}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// load indexes the previews in the folder from the oldest to the newest used.
+func (c *cache) load() error {
+	type file struct {
+		path  string
+		size  int64
+		atime time.Time
+	}
+	files := []*file{}
+	err := filepath.Walk(c.folder, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(c.folder, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, &file{path: rel, size: info.Size(), atime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].atime.Before(files[j].atime) })
+	c.Lock()
+	defer c.Unlock()
+	for _, f := range files {
+		c.add(f.path, f.size)
+	}
+	c.evict()
+	c.updateMetrics()
+	return nil
+}
+
+func (c *cache) Get(ctx context.Context, fileID, etag, variant string) ([]byte, bool) {
+	l := ctx_zap.Extract(ctx)
+	p := getPath(fileID, etag, variant)
+
+	c.Lock()
+	el, ok := c.entries[p]
+	if ok {
+		c.lru.MoveToFront(el)
+	}
+	c.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(c.folder, p))
+	if err != nil {
+		l.Warn("error reading cached preview", zap.Error(err), zap.String("path", p))
+		c.Lock()
+		c.remove(p, "error")
+		c.updateMetrics()
+		c.Unlock()
+		return nil, false
+	}
+	// the modification time keeps the last use across restarts
+	now := time.Now()
+	os.Chtimes(filepath.Join(c.folder, p), now, now)
+	return data, true
+}
+
+func (c *cache) Put(ctx context.Context, fileID, etag, variant string, data []byte) error {
+	p := getPath(fileID, etag, variant)
+	dir := filepath.Join(c.folder, filepath.Dir(p))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	// write to a hidden temporary file first so that
+	// readers never see a preview partially written.
+	fd, err := ioutil.TempFile(dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := fd.Write(data); err != nil {
+		fd.Close()
+		os.Remove(fd.Name())
+		return err
+	}
+	if err := fd.Close(); err != nil {
+		os.Remove(fd.Name())
+		return err
+	}
+	if err := os.Rename(fd.Name(), filepath.Join(c.folder, p)); err != nil {
+		os.Remove(fd.Name())
+		return err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	// previews of previous versions of the file are stale
+	current := filepath.Join(filepath.Dir(p), hash(etag)+"-")
+	for k := range c.files[filepath.Dir(p)] {
+		if !strings.HasPrefix(k, current) {
+			c.remove(k, "stale")
+		}
+	}
+	c.add(p, int64(len(data)))
+	c.evict()
+	c.updateMetrics()
+	return nil
+}
+
+func (c *cache) Purge(ctx context.Context, fileID string) error {
+	l := ctx_zap.Extract(ctx)
+	c.Lock()
+	defer c.Unlock()
+	defer c.updateMetrics()
+
+	if fileID == "" {
+		for k := range c.entries {
+			c.remove(k, "purge")
+		}
+		files, err := ioutil.ReadDir(c.folder)
+		if err != nil {
+			l.Error("", zap.Error(err))
+			return err
+		}
+		for _, f := range files {
+			if err := os.RemoveAll(filepath.Join(c.folder, f.Name())); err != nil {
+				l.Error("", zap.Error(err))
+				return err
+			}
+		}
+		l.Info("preview cache purged")
+		return nil
+	}
+
+	for k := range c.files[hash(fileID)] {
+		c.remove(k, "purge")
+	}
+	if err := os.RemoveAll(filepath.Join(c.folder, hash(fileID))); err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	l.Info("previews of file purged", zap.String("id", fileID))
+	return nil
+}
+
+// evict removes the least recently used previews until the cache fits
+// in its maximum size. It must be called with the lock held.
+func (c *cache) evict() {
+	for c.size > c.maxSize && c.lru.Len() > 0 {
+		c.remove(c.lru.Back().Value.(*entry).path, "size")
+	}
+}
+
+// add records the preview p as the most recently used, replacing
+// its previous entry. It must be called with the lock held.
+func (c *cache) add(p string, size int64) {
+	if el, ok := c.entries[p]; ok {
+		c.size -= el.Value.(*entry).size
+		c.lru.Remove(el)
+	}
+	c.entries[p] = c.lru.PushFront(&entry{path: p, size: size})
+	c.size += size
+	dir := filepath.Dir(p)
+	if c.files[dir] == nil {
+		c.files[dir] = map[string]bool{}
+	}
+	c.files[dir][p] = true
+}
+
+// remove must be called with the lock held.
+func (c *cache) remove(p, reason string) {
+	el, ok := c.entries[p]
+	if !ok {
+		return
+	}
+	c.size -= el.Value.(*entry).size
+	c.lru.Remove(el)
+	delete(c.entries, p)
+	dir := filepath.Dir(p)
+	delete(c.files[dir], p)
+	if len(c.files[dir]) == 0 {
+		delete(c.files, dir)
+	}
+	os.Remove(filepath.Join(c.folder, p))
+	cacheEvictions.WithLabelValues(reason).Inc()
+}
+
+func (c *cache) updateMetrics() {
+	cacheSize.Set(float64(c.size))
+	cacheEntries.Set(float64(c.lru.Len()))
+}
+
+// getPath returns the path of the preview relative to the folder,
+// the variant is chosen by the caller and is safe to use in a name.
+func getPath(fileID, etag, variant string) string {
+	return filepath.Join(hash(fileID), hash(etag)+"-"+variant)
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
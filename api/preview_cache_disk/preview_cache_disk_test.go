@@ -0,0 +1,75 @@
+package preview_cache_disk
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	folder, err := ioutil.TempDir("", "preview-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(folder)
+
+	ctx := context.Background()
+	c, err := New(folder, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Put(ctx, "a", "1", "32x32-CROP", []byte("aaaa"))
+	if data, ok := c.Get(ctx, "a", "1", "32x32-CROP"); !ok || string(data) != "aaaa" {
+		t.Fatalf("expected cached preview, got %q %t", data, ok)
+	}
+	if _, ok := c.Get(ctx, "a", "1", "64x64-CROP"); ok {
+		t.Fatal("expected miss for another variant")
+	}
+
+	// a new etag replaces the previews of the previous version
+	c.Put(ctx, "a", "2", "64x64-CROP", []byte("bb"))
+	if _, ok := c.Get(ctx, "a", "1", "32x32-CROP"); ok {
+		t.Fatal("expected stale preview to be removed")
+	}
+
+	// b is the least recently used and is evicted when exceeding the size
+	c.Put(ctx, "b", "1", "32x32-CROP", []byte("bbbb"))
+	c.Get(ctx, "a", "2", "64x64-CROP")
+	c.Put(ctx, "c", "1", "32x32-CROP", []byte("cccccc"))
+	if _, ok := c.Get(ctx, "b", "1", "32x32-CROP"); ok {
+		t.Fatal("expected least recently used preview to be evicted")
+	}
+	if _, ok := c.Get(ctx, "a", "2", "64x64-CROP"); !ok {
+		t.Fatal("expected recently used preview to be kept")
+	}
+
+	// the previews survive a restart
+	c, err = New(folder, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, ok := c.Get(ctx, "c", "1", "32x32-CROP"); !ok || string(data) != "cccccc" {
+		t.Fatalf("expected preview to be loaded from disk, got %q %t", data, ok)
+	}
+
+	if err := c.Purge(ctx, "c"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get(ctx, "c", "1", "32x32-CROP"); ok {
+		t.Fatal("expected purged preview to be removed")
+	}
+	if _, ok := c.Get(ctx, "a", "2", "64x64-CROP"); !ok {
+		t.Fatal("expected previews of other files to be kept")
+	}
+	if err := c.Purge(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get(ctx, "a", "2", "64x64-CROP"); ok {
+		t.Fatal("expected all previews to be purged")
+	}
+	if files := c.(*cache).files; len(files) != 0 {
+		t.Fatalf("expected the previews of the files to be forgotten, got %v", files)
+	}
+}
@@ -10,72 +10,60 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 )
 
+// createGroupsTable creates the table keeping the groups of the projects,
+// that cernbox_project_mapping has no columns for. A project without a row
+// has the cernbox-project-<name>-* e-groups.
+const createGroupsTable = `create table if not exists cernbox_project_groups (
+	project_name varchar(255) not null primary key,
+	admin_group varchar(255) not null default '',
+	writers_group varchar(255) not null default '',
+	readers_group varchar(255) not null default ''
+)`
+
+const projectQuery = "select m.project_name, m.project_owner, m.eos_relative_path, coalesce(g.admin_group, ''), coalesce(g.writers_group, ''), coalesce(g.readers_group, '') " +
+	"from cernbox_project_mapping m left join cernbox_project_groups g on g.project_name=m.project_name"
+
 type projectManager struct {
 	db *sql.DB
 }
 
-func New(dbUsername, dbPassword, dbHost string, dbPort int, dbName string, vfs api.VirtualStorage) api.ProjectManager {
+func New(dbUsername, dbPassword, dbHost string, dbPort int, dbName string, vfs api.VirtualStorage) (api.ProjectManager, error) {
 	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", dbUsername, dbPassword, dbHost, dbPort, dbName))
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+	if _, err := db.Exec(createGroupsTable); err != nil {
+		return nil, err
 	}
 
-	return &projectManager{db: db}
+	return &projectManager{db: db}, nil
 }
 
 func (pm *projectManager) GetProject(ctx context.Context, projectName string) (*api.Project, error) {
-	var (
-		owner string
-		path  string
-	)
-
-	query := "select eos_relative_path, project_owner from cernbox_project_mapping where project_name=?"
-	if err := pm.db.QueryRow(query, projectName).Scan(&path, &owner); err != nil {
+	project, err := scanProject(pm.db.QueryRow(projectQuery+" where m.project_name=?", projectName))
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, api.NewError(api.ProjectNotFoundErrorCode)
 		}
 		return nil, err
 	}
-
-	adminGroup := getAdminGroup(projectName)
-	writersGroup := getWritersGroup(projectName)
-	readersGroup := getReadersGroup(projectName)
-
-	project := &api.Project{Name: projectName, Owner: owner, Path: path, AdminGroup: adminGroup, ReadersGroup: readersGroup, WritersGroup: writersGroup}
 	return project, nil
 
 }
 
-func getAdminGroup(name string) string   { return "cernbox-project-" + name + "-admins" }
-func getReadersGroup(name string) string { return "cernbox-project-" + name + "-readers" }
-func getWritersGroup(name string) string { return "cernbox-project-" + name + "-writers" }
-
 func (pm *projectManager) GetAllProjects(ctx context.Context) ([]*api.Project, error) {
-	query := "select project_name, project_owner, eos_relative_path from cernbox_project_mapping"
-	rows, err := pm.db.Query(query)
+	rows, err := pm.db.Query(projectQuery)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var (
-		name  string
-		owner string
-		path  string
-	)
-
 	projects := []*api.Project{}
 	for rows.Next() {
-		err := rows.Scan(&name, &owner, &path)
+		project, err := scanProject(rows)
 		if err != nil {
 			return nil, err
 		}
-
-		adminGroup := getAdminGroup(name)
-		writersGroup := getWritersGroup(name)
-		readersGroup := getReadersGroup(name)
-
-		project := &api.Project{Owner: owner, Path: path, Name: name, AdminGroup: adminGroup, ReadersGroup: readersGroup, WritersGroup: writersGroup}
 		projects = append(projects, project)
 
 	}
@@ -87,3 +75,132 @@ func (pm *projectManager) GetAllProjects(ctx context.Context) ([]*api.Project, e
 
 	return projects, nil
 }
+
+func (pm *projectManager) CreateProject(ctx context.Context, project *api.Project) (*api.Project, error) {
+	newProject := *project
+	api.SetProjectDefaults(&newProject)
+
+	tx, err := pm.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// the locking read of the whole mapping makes concurrent creations wait
+	// for the commit of this one, they see its project in their checks
+	projects, err := lockProjects(tx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range projects {
+		if p.Name == newProject.Name {
+			return nil, api.NewError(api.ProjectAlreadyExistsErrorCode).WithMessage(newProject.Name)
+		}
+	}
+	if err := api.CheckProjectOverlap(&newProject, projects); err != nil {
+		return nil, err
+	}
+
+	query := "insert into cernbox_project_mapping (project_name, project_owner, eos_relative_path) values (?, ?, ?)"
+	if _, err := tx.Exec(query, newProject.Name, newProject.Owner, newProject.Path); err != nil {
+		return nil, err
+	}
+	if err := setGroups(tx, &newProject); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &newProject, nil
+}
+
+func (pm *projectManager) UpdateProject(ctx context.Context, project *api.Project) (*api.Project, error) {
+	// the project is read first, the groups of a missing project are not kept
+	if _, err := pm.GetProject(ctx, project.Name); err != nil {
+		return nil, err
+	}
+
+	tx, err := pm.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	query := "update cernbox_project_mapping set project_owner=? where project_name=?"
+	if _, err := tx.Exec(query, project.Owner, project.Name); err != nil {
+		return nil, err
+	}
+	if err := setGroups(tx, project); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return pm.GetProject(ctx, project.Name)
+}
+
+func (pm *projectManager) DeleteProject(ctx context.Context, name string) error {
+	tx, err := pm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	query := "delete from cernbox_project_mapping where project_name=?"
+	result, err := tx.Exec(query, name)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return api.NewError(api.ProjectNotFoundErrorCode).WithMessage(name)
+	}
+	if _, err := tx.Exec("delete from cernbox_project_groups where project_name=?", name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockProjects returns the names and paths of the projects, locking the
+// mapping until the end of tx.
+func lockProjects(tx *sql.Tx) ([]*api.Project, error) {
+	rows, err := tx.Query("select project_name, eos_relative_path from cernbox_project_mapping for update")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	projects := []*api.Project{}
+	for rows.Next() {
+		project := &api.Project{}
+		if err := rows.Scan(&project.Name, &project.Path); err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
+
+// setGroups keeps the groups of the project.
+func setGroups(tx *sql.Tx, project *api.Project) error {
+	query := "insert into cernbox_project_groups (project_name, admin_group, writers_group, readers_group) values (?, ?, ?, ?) " +
+		"on duplicate key update admin_group=values(admin_group), writers_group=values(writers_group), readers_group=values(readers_group)"
+	_, err := tx.Exec(query, project.Name, project.AdminGroup, project.WritersGroup, project.ReadersGroup)
+	return err
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanProject reads a row of projectQuery, the groups not kept
+// are the e-groups named after the project.
+func scanProject(row scanner) (*api.Project, error) {
+	project := &api.Project{}
+	if err := row.Scan(&project.Name, &project.Owner, &project.Path, &project.AdminGroup, &project.WritersGroup, &project.ReadersGroup); err != nil {
+		return nil, err
+	}
+	api.SetProjectDefaults(project)
+	return project, nil
+}
@@ -0,0 +1,45 @@
+package project_manager_db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+)
+
+func TestGroups(t *testing.T) {
+	db := conformance.GetMySQL(t)
+	ctx := context.Background()
+	pm, err := New(db.Username, db.Password, db.Host, db.Port, db.Name, nil)
+	conformance.Check(t, err)
+	name := fmt.Sprintf("test%d", time.Now().UnixNano())
+
+	// the groups left empty are named after the project
+	project, err := pm.CreateProject(ctx, &api.Project{Name: name, Owner: "alice", ReadersGroup: name + "-all"})
+	conformance.Check(t, err)
+	defer pm.DeleteProject(ctx, name)
+	got, err := pm.GetProject(ctx, name)
+	conformance.Check(t, err)
+	if got.AdminGroup != "cernbox-project-"+name+"-admins" || got.ReadersGroup != name+"-all" {
+		t.Fatalf("unexpected project: %+v", got)
+	}
+
+	project.Owner = "bob"
+	project.AdminGroup = name + "-admins"
+	_, err = pm.UpdateProject(ctx, project)
+	conformance.Check(t, err)
+	got, err = pm.GetProject(ctx, name)
+	conformance.Check(t, err)
+	if got.Owner != "bob" || got.AdminGroup != name+"-admins" || got.ReadersGroup != name+"-all" {
+		t.Fatalf("unexpected project: %+v", got)
+	}
+
+	conformance.Check(t, pm.DeleteProject(ctx, name))
+	_, err = pm.GetProject(ctx, name)
+	conformance.ExpectCode(t, err, api.ProjectNotFoundErrorCode)
+	_, err = pm.UpdateProject(ctx, project)
+	conformance.ExpectCode(t, err, api.ProjectNotFoundErrorCode)
+}
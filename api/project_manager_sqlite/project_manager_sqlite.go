@@ -0,0 +1,177 @@
+package project_manager_sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/sqlite_db"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+)
+
+// migrations of the schema, they are only ever appended.
+var migrations = []string{
+	`create table projects (
+		name text primary key,
+		path text not null unique,
+		owner text not null,
+		admin_group text not null,
+		writers_group text not null,
+		readers_group text not null
+	)`,
+}
+
+const projectColumns = "name, path, owner, admin_group, writers_group, readers_group"
+
+// New returns a project manager that keeps the projects in the SQLite database in file.
+func New(file string) (api.ProjectManager, error) {
+	db, err := sqlite_db.Open(file, "project_manager", migrations)
+	if err != nil {
+		return nil, err
+	}
+	return &projectManager{db: db}, nil
+}
+
+type projectManager struct {
+	db *sql.DB
+}
+
+func (pm *projectManager) GetProject(ctx context.Context, name string) (*api.Project, error) {
+	l := ctx_zap.Extract(ctx)
+	query := "select " + projectColumns + " from projects where name=?"
+	project, err := scanProject(pm.db.QueryRow(query, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, api.NewError(api.ProjectNotFoundErrorCode).WithMessage(name)
+		}
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	return project, nil
+}
+
+func (pm *projectManager) GetAllProjects(ctx context.Context) ([]*api.Project, error) {
+	l := ctx_zap.Extract(ctx)
+	rows, err := pm.db.Query("select " + projectColumns + " from projects order by name")
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	projects, err := scanProjects(rows)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	return projects, nil
+}
+
+func (pm *projectManager) CreateProject(ctx context.Context, project *api.Project) (*api.Project, error) {
+	l := ctx_zap.Extract(ctx)
+	newProject := *project
+	api.SetProjectDefaults(&newProject)
+
+	// the transaction holds the only connection to the database, the
+	// projects cannot change between the checks and the insert
+	tx, err := pm.db.Begin()
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query("select " + projectColumns + " from projects")
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	projects, err := scanProjects(rows)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	for _, p := range projects {
+		if p.Name == newProject.Name {
+			return nil, api.NewError(api.ProjectAlreadyExistsErrorCode).WithMessage(newProject.Name)
+		}
+	}
+	if err := api.CheckProjectOverlap(&newProject, projects); err != nil {
+		return nil, err
+	}
+
+	query := "insert into projects (" + projectColumns + ") values (?, ?, ?, ?, ?, ?)"
+	if _, err := tx.Exec(query, newProject.Name, newProject.Path, newProject.Owner, newProject.AdminGroup, newProject.WritersGroup, newProject.ReadersGroup); err != nil {
+		l.Error("error inserting project", zap.Error(err))
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	l.Info("project created", zap.String("name", newProject.Name), zap.String("path", newProject.Path), zap.String("owner", newProject.Owner))
+	return &newProject, nil
+}
+
+func (pm *projectManager) UpdateProject(ctx context.Context, project *api.Project) (*api.Project, error) {
+	l := ctx_zap.Extract(ctx)
+	query := "update projects set owner=?, admin_group=?, writers_group=?, readers_group=? where name=?"
+	result, err := pm.db.Exec(query, project.Owner, project.AdminGroup, project.WritersGroup, project.ReadersGroup, project.Name)
+	if err != nil {
+		l.Error("error updating project", zap.Error(err))
+		return nil, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, api.NewError(api.ProjectNotFoundErrorCode).WithMessage(project.Name)
+	}
+	return pm.GetProject(ctx, project.Name)
+}
+
+func (pm *projectManager) DeleteProject(ctx context.Context, name string) error {
+	l := ctx_zap.Extract(ctx)
+	result, err := pm.db.Exec("delete from projects where name=?", name)
+	if err != nil {
+		l.Error("error deleting project", zap.Error(err))
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	if rows == 0 {
+		return api.NewError(api.ProjectNotFoundErrorCode).WithMessage(name)
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProject(row scanner) (*api.Project, error) {
+	project := &api.Project{}
+	if err := row.Scan(&project.Name, &project.Path, &project.Owner, &project.AdminGroup, &project.WritersGroup, &project.ReadersGroup); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// scanProjects reads and closes rows.
+func scanProjects(rows *sql.Rows) ([]*api.Project, error) {
+	defer rows.Close()
+	projects := []*api.Project{}
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
@@ -0,0 +1,53 @@
+package project_manager_sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+)
+
+func TestProjects(t *testing.T) {
+	ctx := context.Background()
+	pm, err := New("")
+	conformance.Check(t, err)
+
+	// the path and the groups left empty are named after the project
+	project, err := pm.CreateProject(ctx, &api.Project{Name: "atlas", Owner: "alice", ReadersGroup: "atlas-all"})
+	conformance.Check(t, err)
+	if project.Path != "a/atlas" || project.AdminGroup != "cernbox-project-atlas-admins" || project.WritersGroup != "cernbox-project-atlas-writers" || project.ReadersGroup != "atlas-all" {
+		t.Fatalf("unexpected project: %+v", project)
+	}
+	_, err = pm.CreateProject(ctx, &api.Project{Name: "atlas", Owner: "bob"})
+	conformance.ExpectCode(t, err, api.ProjectAlreadyExistsErrorCode)
+
+	// the paths of the projects do not overlap
+	for _, p := range []string{"a/atlas", "a", "a/atlas/sub"} {
+		_, err = pm.CreateProject(ctx, &api.Project{Name: "other", Path: p, Owner: "bob"})
+		conformance.ExpectCode(t, err, api.ProjectInvalidErrorCode)
+	}
+	_, err = pm.CreateProject(ctx, &api.Project{Name: "cms", Owner: "bob"})
+	conformance.Check(t, err)
+
+	project.Owner = "carol"
+	project.AdminGroup = "atlas-admins"
+	updated, err := pm.UpdateProject(ctx, project)
+	conformance.Check(t, err)
+	if updated.Owner != "carol" || updated.AdminGroup != "atlas-admins" || updated.Path != "a/atlas" {
+		t.Fatalf("unexpected project: %+v", updated)
+	}
+	_, err = pm.UpdateProject(ctx, &api.Project{Name: "missing"})
+	conformance.ExpectCode(t, err, api.ProjectNotFoundErrorCode)
+
+	projects, err := pm.GetAllProjects(ctx)
+	conformance.Check(t, err)
+	if len(projects) != 2 || projects[0].Name != "atlas" || projects[1].Name != "cms" {
+		t.Fatalf("unexpected projects: %+v", projects)
+	}
+
+	conformance.Check(t, pm.DeleteProject(ctx, "atlas"))
+	conformance.ExpectCode(t, pm.DeleteProject(ctx, "atlas"), api.ProjectNotFoundErrorCode)
+	_, err = pm.GetProject(ctx, "atlas")
+	conformance.ExpectCode(t, err, api.ProjectNotFoundErrorCode)
+}
@@ -0,0 +1,488 @@
+package public_link_manager_memory
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	gopath "path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	tokenLength = 15
+	letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+// New returns a public link manager that keeps the links in memory, so they
+// are neither shared between several daemons nor kept across restarts.
+// The passwords of the links follow policy, and the IPs of the clients are
+// hashed with ipHasher, both may be nil.
+func New(vfs api.VirtualStorage, policy *api.PasswordPolicy, ipHasher *api.ClientIPHasher) api.PublicLinkManager {
+	return &linkManager{vfs: vfs, policy: policy, ipHasher: ipHasher, links: map[int64]*link{}, tokens: map[string]*link{}}
+}
+
+type linkManager struct {
+	sync.Mutex
+	vfs      api.VirtualStorage
+	policy   *api.PasswordPolicy
+	ipHasher *api.ClientIPHasher
+	links    map[int64]*link
+	tokens   map[string]*link
+	lastID   int64
+}
+
+type link struct {
+	id         int64
+	token      string
+	owner      string
+	fileID     string
+	isDir      bool
+	readOnly   bool
+	dropOnly   bool
+	notify     bool
+	password   string
+	expiration uint64
+	stime      int64
+	name       string
+	suspended  bool
+
+	// access statistics
+	maxDownloads uint64
+	downloads    uint64
+	views        uint64
+	lastAccess   int64
+	lastAccessIP string
+}
+
+func (lm *linkManager) CreatePublicLink(ctx context.Context, path string, opt *api.PublicLinkOptions) (*api.PublicLink, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	if err := lm.policy.CheckPublicLink(opt, nil); err != nil {
+		l.Warn("password refused by the policy", zap.Error(err))
+		return nil, err
+	}
+
+	md, err := lm.vfs.GetMetadata(ctx, path)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	pl := &link{
+		owner:        u.AccountId,
+		fileID:       getFileID(md),
+		isDir:        md.IsDir,
+		readOnly:     opt.ReadOnly,
+		dropOnly:     !opt.ReadOnly && opt.DropOnly,
+		notify:       opt.NotifyUploads,
+		expiration:   opt.Expiration,
+		stime:        time.Now().Unix(),
+		name:         gopath.Base(path),
+		maxDownloads: opt.MaxDownloads,
+	}
+	if opt.Password != "" {
+		pl.password, err = lm.hashPassword(opt.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	lm.Lock()
+	defer lm.Unlock()
+	for {
+		pl.token, err = genToken()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := lm.tokens[pl.token]; !ok {
+			break
+		}
+	}
+	lm.lastID++
+	pl.id = lm.lastID
+	lm.links[pl.id] = pl
+	lm.tokens[pl.token] = pl
+	l.Info("created public link", zap.Int64("id", pl.id))
+	return pl.toPublicLink(), nil
+}
+
+func (lm *linkManager) UpdatePublicLink(ctx context.Context, id string, opt *api.PublicLinkOptions) (*api.PublicLink, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var password string
+	if opt.UpdatePassword && opt.Password != "" {
+		password, err = lm.hashPassword(opt.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	lm.Lock()
+	defer lm.Unlock()
+	pl, err := lm.getOwnedLink(u.AccountId, id)
+	if err != nil {
+		l.Error("error getting link before update", zap.Error(err))
+		return nil, err
+	}
+	if err := lm.policy.CheckPublicLink(opt, pl.toPublicLink()); err != nil {
+		l.Warn("password refused by the policy", zap.Error(err))
+		return nil, err
+	}
+
+	if opt.UpdatePassword {
+		pl.password = password
+	}
+	if opt.UpdateExpiration {
+		pl.expiration = opt.Expiration
+	}
+	if opt.UpdateReadOnly || opt.UpdateDropOnly {
+		pl.readOnly = opt.ReadOnly
+		pl.dropOnly = !opt.ReadOnly && opt.DropOnly
+	}
+	if opt.UpdateNotifyUploads {
+		pl.notify = opt.NotifyUploads
+	}
+	if opt.UpdateMaxDownloads {
+		pl.maxDownloads = opt.MaxDownloads
+	}
+	return pl.toPublicLink(), nil
+}
+
+func (lm *linkManager) InspectPublicLink(ctx context.Context, id string) (*api.PublicLink, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lm.Lock()
+	defer lm.Unlock()
+	pl, err := lm.getOwnedLink(u.AccountId, id)
+	if err != nil {
+		return nil, err
+	}
+	return pl.toPublicLink(), nil
+}
+
+func (lm *linkManager) getOwnedLink(owner, id string) (*link, error) {
+	intID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, api.NewError(api.PublicLinkNotFoundErrorCode).WithMessage(id)
+	}
+	pl, ok := lm.links[intID]
+	if !ok || pl.owner != owner {
+		return nil, api.NewError(api.PublicLinkNotFoundErrorCode).WithMessage(id)
+	}
+	return pl, nil
+}
+
+func (lm *linkManager) InspectPublicLinkByToken(ctx context.Context, token string) (*api.PublicLink, error) {
+	lm.Lock()
+	defer lm.Unlock()
+	pl, ok := lm.getActiveLink(token)
+	if !ok {
+		return nil, api.NewError(api.PublicLinkNotFoundErrorCode)
+	}
+	return pl.toPublicLink(), nil
+}
+
+func (lm *linkManager) ListPublicLinks(ctx context.Context, filterByPath string) ([]*api.PublicLink, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileID string
+	if filterByPath != "" {
+		md, err := lm.vfs.GetMetadata(ctx, filterByPath)
+		if err != nil {
+			return nil, err
+		}
+		fileID = getFileID(md)
+	}
+
+	lm.Lock()
+	defer lm.Unlock()
+	publicLinks := []*api.PublicLink{}
+	for _, pl := range lm.sortedLinks() {
+		if pl.owner == u.AccountId && (fileID == "" || pl.fileID == fileID) {
+			publicLinks = append(publicLinks, pl.toPublicLink())
+		}
+	}
+	return publicLinks, nil
+}
+
+func (lm *linkManager) RevokePublicLink(ctx context.Context, id string) error {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	lm.Lock()
+	defer lm.Unlock()
+	pl, err := lm.getOwnedLink(u.AccountId, id)
+	if err != nil {
+		l.Error("", zap.Error(err), zap.String("id", id))
+		return err
+	}
+	delete(lm.links, pl.id)
+	delete(lm.tokens, pl.token)
+	return nil
+}
+
+func (lm *linkManager) AuthenticatePublicLink(ctx context.Context, token, password string) (*api.PublicLink, error) {
+	l := ctx_zap.Extract(ctx)
+	lm.Lock()
+	pl, ok := lm.getActiveLink(token)
+	var hash string
+	var pb *api.PublicLink
+	if ok {
+		hash = pl.password
+		pb = pl.toPublicLink()
+	}
+	lm.Unlock()
+	if !ok {
+		return nil, api.NewError(api.PublicLinkNotFoundErrorCode)
+	}
+
+	// check expiration time
+	if pb.Expires != 0 && uint64(time.Now().Unix()) > pb.Expires {
+		l.Warn("public link has expired", zap.String("id", pb.Id))
+		return nil, api.NewError(api.PublicLinkInvalidExpireDateErrorCode)
+	}
+
+	if pb.Protected && !checkPasswordHash(password, hash) {
+		return nil, api.NewError(api.PublicLinkInvalidPasswordErrorCode)
+	}
+
+	// upgrade the hash now that the password is known
+	var rehashed string
+	if pb.Protected && api.NeedsRehash(hash, lm.policy.GetHashCost(bcrypt.DefaultCost)) {
+		if h, err := lm.hashPassword(password); err == nil {
+			rehashed = h
+		} else {
+			l.Error("error rehashing public link password", zap.Error(err))
+		}
+	}
+
+	lm.Lock()
+	defer lm.Unlock()
+	// the link may have been revoked while checking the password
+	pl, ok = lm.getActiveLink(token)
+	if !ok {
+		return nil, api.NewError(api.PublicLinkNotFoundErrorCode)
+	}
+	// unless the password has been changed meanwhile
+	if rehashed != "" && pl.password == hash {
+		pl.password = rehashed
+		l.Info("upgraded public link password hash", zap.String("id", pb.Id))
+	}
+	if pl.limitReached() {
+		l.Warn("public link has reached its download limit", zap.String("id", pb.Id))
+		return nil, api.NewError(api.PublicLinkDownloadLimitErrorCode)
+	}
+	pl.views++
+	pl.recordAccess(lm.ipHasher.Hash(api.GetClientIP(ctx)))
+	return pl.toPublicLink(), nil
+}
+
+func (lm *linkManager) RecordPublicLinkDownload(ctx context.Context, token string) error {
+	lm.Lock()
+	defer lm.Unlock()
+	pl, ok := lm.getActiveLink(token)
+	if !ok {
+		return api.NewError(api.PublicLinkNotFoundErrorCode)
+	}
+	if pl.limitReached() {
+		return api.NewError(api.PublicLinkDownloadLimitErrorCode)
+	}
+	pl.downloads++
+	pl.recordAccess(lm.ipHasher.Hash(api.GetClientIP(ctx)))
+	return nil
+}
+
+func (lm *linkManager) IsPublicLinkProtected(ctx context.Context, token string) (bool, error) {
+	lm.Lock()
+	defer lm.Unlock()
+	pl, ok := lm.getActiveLink(token)
+	if !ok {
+		return false, api.NewError(api.PublicLinkNotFoundErrorCode)
+	}
+	return pl.password != "", nil
+}
+
+func (lm *linkManager) SuspendPublicLinks(ctx context.Context, fileIDs []string, suspended bool) ([]*api.PublicLink, error) {
+	ids := fileIDSet(fileIDs)
+	lm.Lock()
+	defer lm.Unlock()
+	publicLinks := []*api.PublicLink{}
+	for _, pl := range lm.sortedLinks() {
+		if ids[pl.fileID] && pl.suspended != suspended {
+			pl.suspended = suspended
+			publicLinks = append(publicLinks, pl.toPublicLink())
+		}
+	}
+	return publicLinks, nil
+}
+
+func (lm *linkManager) PurgePublicLinks(ctx context.Context, fileIDs []string) ([]*api.PublicLink, error) {
+	ids := fileIDSet(fileIDs)
+	lm.Lock()
+	defer lm.Unlock()
+	publicLinks := []*api.PublicLink{}
+	for _, pl := range lm.sortedLinks() {
+		if ids[pl.fileID] {
+			delete(lm.links, pl.id)
+			delete(lm.tokens, pl.token)
+			publicLinks = append(publicLinks, pl.toPublicLink())
+		}
+	}
+	return publicLinks, nil
+}
+
+func (lm *linkManager) ListAllPublicLinks(ctx context.Context, owner string) ([]*api.PublicLink, error) {
+	lm.Lock()
+	defer lm.Unlock()
+	publicLinks := []*api.PublicLink{}
+	for _, pl := range lm.sortedLinks() {
+		if owner == "" || pl.owner == owner {
+			publicLinks = append(publicLinks, pl.toPublicLink())
+		}
+	}
+	return publicLinks, nil
+}
+
+func (lm *linkManager) TransferPublicLinks(ctx context.Context, fileIDs []string, from, to string) ([]*api.PublicLink, error) {
+	ids := fileIDSet(fileIDs)
+	lm.Lock()
+	defer lm.Unlock()
+	publicLinks := []*api.PublicLink{}
+	for _, pl := range lm.sortedLinks() {
+		if pl.owner == from && ids[pl.fileID] {
+			pl.owner = to
+			publicLinks = append(publicLinks, pl.toPublicLink())
+		}
+	}
+	return publicLinks, nil
+}
+
+// getActiveLink returns the link of the token, unless it is suspended.
+func (lm *linkManager) getActiveLink(token string) (*link, bool) {
+	pl, ok := lm.tokens[token]
+	if !ok || pl.suspended {
+		return nil, false
+	}
+	return pl, true
+}
+
+// sortedLinks returns the links in creation order, like the databases do.
+func (lm *linkManager) sortedLinks() []*link {
+	links := make([]*link, 0, len(lm.links))
+	for _, pl := range lm.links {
+		links = append(links, pl)
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].id < links[j].id })
+	return links
+}
+
+func (pl *link) limitReached() bool {
+	return pl.maxDownloads != 0 && pl.downloads >= pl.maxDownloads
+}
+
+func (pl *link) recordAccess(ipHash string) {
+	pl.lastAccess = time.Now().Unix()
+	pl.lastAccessIP = ipHash
+}
+
+func (pl *link) toPublicLink() *api.PublicLink {
+	itemType := api.PublicLink_FILE
+	if pl.isDir {
+		itemType = api.PublicLink_FOLDER
+	}
+	return &api.PublicLink{
+		Id:            fmt.Sprintf("%d", pl.id),
+		Token:         pl.token,
+		Mtime:         uint64(pl.stime),
+		Protected:     pl.password != "",
+		Path:          pl.fileID,
+		Expires:       pl.expiration,
+		ReadOnly:      pl.readOnly,
+		DropOnly:      pl.dropOnly,
+		NotifyUploads: pl.notify,
+		ItemType:      itemType,
+		OwnerId:       pl.owner,
+		Name:          pl.name,
+		Suspended:     pl.suspended,
+
+		MaxDownloads:     pl.maxDownloads,
+		Downloads:        pl.downloads,
+		Views:            pl.views,
+		LastAccess:       uint64(pl.lastAccess),
+		LastAccessIpHash: pl.lastAccessIP,
+	}
+}
+
+func fileIDSet(fileIDs []string) map[string]bool {
+	ids := map[string]bool{}
+	for _, id := range fileIDs {
+		ids[id] = true
+	}
+	return ids
+}
+
+func getFileID(md *api.Metadata) string {
+	if md.MigId != "" {
+		return md.MigId
+	}
+	return md.Id
+}
+
+func getUserFromContext(ctx context.Context) (*api.User, error) {
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		return nil, api.NewError(api.ContextUserRequiredError)
+	}
+	return u, nil
+}
+
+func genToken() (string, error) {
+	b := make([]byte, tokenLength)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(letterBytes))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = letterBytes[n.Int64()]
+	}
+	return string(b), nil
+}
+
+func (lm *linkManager) hashPassword(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), lm.policy.GetHashCost(bcrypt.DefaultCost))
+	return string(bytes), err
+}
+
+func checkPasswordHash(password, hash string) bool {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	return err == nil
+}
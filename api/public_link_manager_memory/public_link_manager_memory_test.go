@@ -0,0 +1,14 @@
+package public_link_manager_memory
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.TestPublicLinkManager(t, func(t *testing.T, vfs api.VirtualStorage, policy *api.PasswordPolicy, ipHasher *api.ClientIPHasher) api.PublicLinkManager {
+		return New(vfs, policy, ipHasher)
+	})
+}
@@ -29,19 +29,51 @@ const tokenLength = 15
 const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 const versionPrefix = ".sys.v#."
 
-func New(dbUsername, dbPassword, dbHost string, dbPort int, dbName string, cacheSize, cacheEviction int, vfs api.VirtualStorage) (api.PublicLinkManager, error) {
+// hashCost is the bcrypt cost of the hashes when the password policy does not set one.
+const hashCost = 14
+
+// createStatsTable creates the table keeping the download limits and the
+// access statistics of the links, keyed by the id in oc_share, that has no
+// columns for them. A link without a row has never been accessed.
+const createStatsTable = `create table if not exists oc_share_link_stats (
+	id int not null primary key,
+	max_downloads bigint unsigned not null default 0,
+	downloads bigint unsigned not null default 0,
+	views bigint unsigned not null default 0,
+	last_access bigint unsigned not null default 0,
+	last_access_ip varchar(64) not null default ''
+)`
+
+// createSuspendedTable creates the table keeping the ids of the suspended
+// shares and public links, that oc_share has no column for.
+const createSuspendedTable = `create table if not exists oc_share_suspended (
+	id int not null primary key
+)`
+
+// New returns a link manager that keeps the links in the oc_share table of the
+// ownCloud database. The IPs of the clients accessing the links are hashed
+// with ipHasher, that may be nil.
+func New(dbUsername, dbPassword, dbHost string, dbPort int, dbName string, cacheSize, cacheEviction int, vfs api.VirtualStorage, policy *api.PasswordPolicy, ipHasher *api.ClientIPHasher) (api.PublicLinkManager, error) {
 	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", dbUsername, dbPassword, dbHost, dbPort, dbName))
 	if err != nil {
 		return nil, err
 	}
+	if _, err := db.Exec(createStatsTable); err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(createSuspendedTable); err != nil {
+		return nil, err
+	}
 
 	cache := gcache.New(cacheSize).LFU().Build()
-	return &linkManager{db: db, vfs: vfs, cache: cache, cacheEviction: time.Second * time.Duration(cacheEviction)}, nil
+	return &linkManager{db: db, vfs: vfs, policy: policy, ipHasher: ipHasher, cache: cache, cacheEviction: time.Second * time.Duration(cacheEviction)}, nil
 }
 
 type linkManager struct {
 	db            *sql.DB
 	vfs           api.VirtualStorage
+	policy        *api.PasswordPolicy
+	ipHasher      *api.ClientIPHasher
 	cache         gcache.Cache
 	cacheSize     int
 	cacheEviction time.Duration
@@ -88,8 +120,29 @@ func (lm *linkManager) AuthenticatePublicLink(ctx context.Context, token, passwo
 		if !ok {
 			return nil, api.NewError(api.PublicLinkInvalidPasswordErrorCode)
 		}
+
+		// upgrade the hash now that the password is known
+		if api.NeedsRehash(hashedPassword, lm.policy.GetHashCost(hashCost)) {
+			if err := lm.rehashPassword(dbShare.ID, password, dbShare.ShareWith); err != nil {
+				l.Error("error rehashing public link password", zap.Error(err))
+			} else {
+				l.Info("upgraded public link password hash", zap.String("id", pb.Id))
+			}
+		}
 	}
 
+	if err := lm.recordAccess(ctx, dbShare.ID, "views"); err != nil {
+		if api.IsErrorCode(err, api.PublicLinkDownloadLimitErrorCode) {
+			l.Warn("public link has reached its download limit", zap.String("id", pb.Id))
+		}
+		return nil, err
+	}
+	stats, err := lm.getLinkStats(dbShare.ID)
+	if err != nil {
+		l.Error("error getting public link statistics", zap.Error(err))
+		return nil, err
+	}
+	stats.setOn(pb)
 	return pb, nil
 }
 
@@ -108,6 +161,68 @@ func (lm *linkManager) IsPublicLinkProtected(ctx context.Context, token string)
 	return pb.Protected, nil
 }
 
+func (lm *linkManager) RecordPublicLinkDownload(ctx context.Context, token string) error {
+	dbShare, err := lm.getDBShareByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	return lm.recordAccess(ctx, dbShare.ID, "downloads")
+}
+
+// recordAccess increments the counter of the link with the id,
+// unless the link has reached its download limit.
+func (lm *linkManager) recordAccess(ctx context.Context, id int, counter string) error {
+	if _, err := lm.db.Exec("insert ignore into oc_share_link_stats (id) values (?)", id); err != nil {
+		return err
+	}
+	query := "update oc_share_link_stats set " + counter + "=" + counter + "+1, last_access=?, last_access_ip=? where id=? and (max_downloads=0 or downloads<max_downloads)"
+	res, err := lm.db.Exec(query, time.Now().Unix(), lm.ipHasher.Hash(api.GetClientIP(ctx)), id)
+	if err != nil {
+		return err
+	}
+	rowCnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowCnt == 0 {
+		return api.NewError(api.PublicLinkDownloadLimitErrorCode)
+	}
+	return nil
+}
+
+// setMaxDownloads sets the download limit of the link with the id, 0 for unlimited.
+func (lm *linkManager) setMaxDownloads(id int64, maxDownloads uint64) error {
+	_, err := lm.db.Exec("insert into oc_share_link_stats (id, max_downloads) values (?, ?) on duplicate key update max_downloads=?", id, maxDownloads, maxDownloads)
+	return err
+}
+
+// getLinkStats returns the download limit and the access statistics of the link with the id.
+func (lm *linkManager) getLinkStats(id int) (*linkStats, error) {
+	stats := &linkStats{}
+	query := "select max_downloads, downloads, views, last_access, last_access_ip from oc_share_link_stats where id=?"
+	err := lm.db.QueryRow(query, id).Scan(&stats.MaxDownloads, &stats.Downloads, &stats.Views, &stats.LastAccess, &stats.LastAccessIP)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	return stats, nil
+}
+
+type linkStats struct {
+	MaxDownloads uint64
+	Downloads    uint64
+	Views        uint64
+	LastAccess   uint64
+	LastAccessIP string
+}
+
+func (s *linkStats) setOn(pb *api.PublicLink) {
+	pb.MaxDownloads = s.MaxDownloads
+	pb.Downloads = s.Downloads
+	pb.Views = s.Views
+	pb.LastAccess = s.LastAccess
+	pb.LastAccessIpHash = s.LastAccessIP
+}
+
 func (lm *linkManager) InspectPublicLinkByToken(ctx context.Context, token string) (*api.PublicLink, error) {
 	l := ctx_zap.Extract(ctx)
 	dbShare, err := lm.getDBShareByToken(ctx, token)
@@ -147,6 +262,16 @@ func (lm *linkManager) CreatePublicLink(ctx context.Context, path string, opt *a
 		return nil, err
 	}
 
+	if err := lm.policy.CheckPublicLink(opt, nil); err != nil {
+		l.Warn("password refused by the policy", zap.Error(err))
+		return nil, err
+	}
+
+	// oc_share has no column to keep the upload notifications
+	if opt.NotifyUploads {
+		return nil, api.NewError(api.StorageNotSupportedErrorCode).WithMessage("upload notifications are not supported by the ownCloud link manager")
+	}
+
 	md, err := lm.vfs.GetMetadata(ctx, path)
 	if err != nil {
 		l.Error("", zap.Error(err))
@@ -207,7 +332,7 @@ func (lm *linkManager) CreatePublicLink(ctx context.Context, path string, opt *a
 	stmtValues := []interface{}{3, u.AccountId, u.AccountId, itemType, prefix, itemSource, fileSource, permissions, time.Now().Unix(), token, shareName}
 
 	if opt.Password != "" {
-		hashedPassword, err := hashPassword(opt.Password)
+		hashedPassword, err := lm.hashPassword(opt.Password)
 		if err != nil {
 			return nil, err
 		}
@@ -240,6 +365,13 @@ func (lm *linkManager) CreatePublicLink(ctx context.Context, path string, opt *a
 	}
 	l.Info("created oc share", zap.Int64("share_id", lastId))
 
+	if opt.MaxDownloads != 0 {
+		if err := lm.setMaxDownloads(lastId, opt.MaxDownloads); err != nil {
+			l.Error("error setting download limit of public link", zap.Error(err))
+			return nil, err
+		}
+	}
+
 	pb, err := lm.InspectPublicLink(ctx, fmt.Sprintf("%d", lastId))
 	if err != nil {
 		l.Error("error inspecting public link", zap.Error(err))
@@ -263,6 +395,23 @@ func (lm *linkManager) UpdatePublicLink(ctx context.Context, id string, opt *api
 		return nil, err
 	}
 
+	if err := lm.policy.CheckPublicLink(opt, pb); err != nil {
+		l.Warn("password refused by the policy", zap.Error(err))
+		return nil, err
+	}
+
+	if opt.UpdateNotifyUploads && opt.NotifyUploads {
+		return nil, api.NewError(api.StorageNotSupportedErrorCode).WithMessage("upload notifications are not supported by the ownCloud link manager")
+	}
+	if opt.UpdateMaxDownloads {
+		// the link is owned by the user, as checked by InspectPublicLink
+		intID, _ := strconv.ParseInt(pb.Id, 10, 64)
+		if err := lm.setMaxDownloads(intID, opt.MaxDownloads); err != nil {
+			l.Error("error setting download limit of public link", zap.Error(err))
+			return nil, err
+		}
+	}
+
 	stmtString := "update oc_share set "
 	stmtPairs := map[string]interface{}{}
 
@@ -271,7 +420,7 @@ func (lm *linkManager) UpdatePublicLink(ctx context.Context, id string, opt *api
 			stmtPairs["share_with"] = ""
 
 		} else {
-			hashedPassword, err := hashPassword(opt.Password)
+			hashedPassword, err := lm.hashPassword(opt.Password)
 			if err != nil {
 				return nil, err
 			}
@@ -295,7 +444,10 @@ func (lm *linkManager) UpdatePublicLink(ctx context.Context, id string, opt *api
 		}
 	}
 
-	if len(stmtPairs) == 0 { // nothing to update
+	if len(stmtPairs) == 0 { // nothing to update in oc_share
+		if opt.UpdateMaxDownloads {
+			pb.MaxDownloads = opt.MaxDownloads
+		}
 		return pb, nil
 	}
 
@@ -400,6 +552,12 @@ func (lm *linkManager) ListPublicLinks(ctx context.Context, filterByPath string)
 	for _, dbShare := range dbShares {
 		pb, err := lm.convertToPublicLink(ctx, dbShare)
 		if err != nil {
+			// the files of the suspended links are in the recycle bin,
+			// they are listed as stored to be reactivated or purged with them
+			if dbShare.Suspended {
+				publicLinks = append(publicLinks, convertToStoredPublicLink(dbShare))
+				continue
+			}
 			l.Error("", zap.Error(err))
 			//TODO(labkode): log error and continue
 			continue
@@ -441,9 +599,194 @@ func (lm *linkManager) RevokePublicLink(ctx context.Context, id string) error {
 		l.Error("", zap.Error(err), zap.String("id", id))
 		return err
 	}
+
+	if _, err := lm.db.Exec("delete from oc_share_link_stats where id=?", id); err != nil {
+		l.Error("", zap.Error(err), zap.String("id", id))
+		return err
+	}
+	if _, err := lm.db.Exec("delete from oc_share_suspended where id=?", id); err != nil {
+		l.Error("", zap.Error(err), zap.String("id", id))
+		return err
+	}
 	return nil
 }
 
+// SuspendPublicLinks keeps the ids of the suspended links in oc_share_suspended.
+// The links are matched by the ids of the files, that are the ids of the
+// version folders for the files as stored, or by the ids of the files that can
+// still be resolved to their version folders.
+func (lm *linkManager) SuspendPublicLinks(ctx context.Context, fileIDs []string, suspended bool) ([]*api.PublicLink, error) {
+	l := ctx_zap.Extract(ctx)
+	publicLinks := []*api.PublicLink{}
+	for _, fileID := range lm.getStoredFileIDs(ctx, fileIDs) {
+		prefix, itemSource := splitFileID(fileID)
+		dbShares, err := lm.queryAllDBShares("and fileid_prefix=? and item_source=?", prefix, itemSource)
+		if err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		for _, dbShare := range dbShares {
+			if dbShare.Suspended == suspended {
+				continue
+			}
+			if err := setSuspended(lm.db, dbShare.ID, suspended); err != nil {
+				l.Error("error changing public link suspension", zap.Error(err), zap.Int("id", dbShare.ID))
+				return nil, err
+			}
+			dbShare.Suspended = suspended
+			publicLinks = append(publicLinks, convertToStoredPublicLink(dbShare))
+		}
+	}
+	return publicLinks, nil
+}
+
+// getStoredFileIDs returns the ids with the ids of the version folders
+// of the files found with them.
+func (lm *linkManager) getStoredFileIDs(ctx context.Context, fileIDs []string) []string {
+	storedIDs := []string{}
+	for _, fileID := range fileIDs {
+		storedIDs = append(storedIDs, fileID)
+		md, err := lm.vfs.GetMetadata(ctx, fileID)
+		if err != nil || md.IsDir {
+			continue
+		}
+		if mdVersion, err := lm.vfs.GetMetadata(ctx, getVersionFolder(md.Path)); err == nil {
+			if mdVersion.MigId != "" {
+				storedIDs = append(storedIDs, mdVersion.MigId)
+			} else {
+				storedIDs = append(storedIDs, mdVersion.Id)
+			}
+		}
+	}
+	return storedIDs
+}
+
+// setSuspended suspends, or reactivates if suspended is false, the link with the id.
+func setSuspended(db *sql.DB, id int, suspended bool) error {
+	if suspended {
+		_, err := db.Exec("insert ignore into oc_share_suspended (id) values (?)", id)
+		return err
+	}
+	_, err := db.Exec("delete from oc_share_suspended where id=?", id)
+	return err
+}
+
+// PurgePublicLinks removes the links of the files fileIDs. The links to files
+// are kept on their version folders, they are only matched by the ids of these,
+// as returned by ListAllPublicLinks.
+func (lm *linkManager) PurgePublicLinks(ctx context.Context, fileIDs []string) ([]*api.PublicLink, error) {
+	l := ctx_zap.Extract(ctx)
+	publicLinks := []*api.PublicLink{}
+	for _, fileID := range fileIDs {
+		prefix, itemSource := splitFileID(fileID)
+		dbShares, err := lm.queryAllDBShares("and fileid_prefix=? and item_source=?", prefix, itemSource)
+		if err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		for _, dbShare := range dbShares {
+			if _, err := lm.db.Exec("delete from oc_share where id=?", dbShare.ID); err != nil {
+				l.Error("", zap.Error(err), zap.Int("id", dbShare.ID))
+				return nil, err
+			}
+			if _, err := lm.db.Exec("delete from oc_share_link_stats where id=?", dbShare.ID); err != nil {
+				l.Error("", zap.Error(err), zap.Int("id", dbShare.ID))
+				return nil, err
+			}
+			if _, err := lm.db.Exec("delete from oc_share_suspended where id=?", dbShare.ID); err != nil {
+				l.Error("", zap.Error(err), zap.Int("id", dbShare.ID))
+				return nil, err
+			}
+			publicLinks = append(publicLinks, convertToStoredPublicLink(dbShare))
+		}
+	}
+	return publicLinks, nil
+}
+
+// ListAllPublicLinks returns the links as stored, the links to files point to
+// the ids of their version folders, as the files may not be found anymore.
+func (lm *linkManager) ListAllPublicLinks(ctx context.Context, owner string) ([]*api.PublicLink, error) {
+	cond, args := "", []interface{}{}
+	if owner != "" {
+		cond, args = "and uid_owner=?", append(args, owner)
+	}
+	dbShares, err := lm.queryAllDBShares(cond, args...)
+	if err != nil {
+		return nil, err
+	}
+	publicLinks := []*api.PublicLink{}
+	for _, dbShare := range dbShares {
+		publicLinks = append(publicLinks, convertToStoredPublicLink(dbShare))
+	}
+	return publicLinks, nil
+}
+
+// TransferPublicLinks hands the links over, matched by the ids of the version
+// folders of the files like in PurgePublicLinks.
+func (lm *linkManager) TransferPublicLinks(ctx context.Context, fileIDs []string, from, to string) ([]*api.PublicLink, error) {
+	l := ctx_zap.Extract(ctx)
+	publicLinks := []*api.PublicLink{}
+	for _, fileID := range fileIDs {
+		prefix, itemSource := splitFileID(fileID)
+		dbShares, err := lm.queryAllDBShares("and uid_owner=? and fileid_prefix=? and item_source=?", from, prefix, itemSource)
+		if err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		for _, dbShare := range dbShares {
+			if _, err := lm.db.Exec("update oc_share set uid_owner=?, uid_initiator=? where id=?", to, to, dbShare.ID); err != nil {
+				l.Error("", zap.Error(err), zap.Int("id", dbShare.ID))
+				return nil, err
+			}
+			dbShare.Owner = to
+			publicLinks = append(publicLinks, convertToStoredPublicLink(dbShare))
+		}
+	}
+	return publicLinks, nil
+}
+
+// queryAllDBShares returns the links of all the users matching the condition.
+func (lm *linkManager) queryAllDBShares(cond string, args ...interface{}) ([]*dbShare, error) {
+	query := "select id, coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, coalesce(token,'') as token, coalesce(expiration, '') as expiration, stime, permissions, item_type, uid_owner, coalesce(share_name, '') as share_name, id in (select id from oc_share_suspended) as suspended from oc_share where share_type=? " + cond + " order by id"
+	rows, err := lm.db.Query(query, append([]interface{}{3}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dbShares := []*dbShare{}
+	for rows.Next() {
+		s := &dbShare{}
+		if err := rows.Scan(&s.ID, &s.ShareWith, &s.Prefix, &s.ItemSource, &s.Token, &s.Expiration, &s.STime, &s.Permissions, &s.ItemType, &s.Owner, &s.ShareName, &s.Suspended); err != nil {
+			return nil, err
+		}
+		dbShares = append(dbShares, s)
+	}
+	return dbShares, rows.Err()
+}
+
+// convertToStoredPublicLink converts an entry from the db to a public link
+// without resolving the version folders of the files.
+func convertToStoredPublicLink(dbShare *dbShare) *api.PublicLink {
+	itemType := api.PublicLink_FILE
+	if dbShare.ItemType == "folder" {
+		itemType = api.PublicLink_FOLDER
+	}
+	return &api.PublicLink{
+		Id:        fmt.Sprintf("%d", dbShare.ID),
+		Token:     dbShare.Token,
+		Mtime:     uint64(dbShare.STime),
+		Protected: dbShare.ShareWith != "",
+		Path:      joinFileID(dbShare.Prefix, dbShare.ItemSource),
+		ReadOnly:  dbShare.Permissions == 1,
+		DropOnly:  dbShare.Permissions == 4,
+		ItemType:  itemType,
+		OwnerId:   dbShare.Owner,
+		Name:      dbShare.ShareName,
+		Suspended: dbShare.Suspended,
+	}
+}
+
 /*
 type ocShare struct {
 	ID          int64          `db:"id"`
@@ -477,6 +820,7 @@ type dbShare struct {
 	Permissions int
 	Owner       string
 	ShareName   string
+	Suspended   bool
 }
 
 func (lm *linkManager) getDBShareByToken(ctx context.Context, token string) (*dbShare, error) {
@@ -493,7 +837,7 @@ func (lm *linkManager) getDBShareByToken(ctx context.Context, token string) (*db
 		shareName   string
 	)
 
-	query := "select id, coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, coalesce(token,'') as token, coalesce(expiration, '') as expiration, stime, permissions, item_type, uid_owner, coalesce(share_name, '') as share_name from oc_share where share_type=? and token=?"
+	query := "select id, coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, coalesce(token,'') as token, coalesce(expiration, '') as expiration, stime, permissions, item_type, uid_owner, coalesce(share_name, '') as share_name from oc_share where share_type=? and token=? and id not in (select id from oc_share_suspended)"
 	if err := lm.db.QueryRow(query, 3, token).Scan(&id, &shareWith, &prefix, &itemSource, &token, &expiration, &stime, &permissions, &itemType, &uidOwner, &shareName); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, api.NewError(api.PublicLinkNotFoundErrorCode)
@@ -523,22 +867,23 @@ func (lm *linkManager) getDBShare(ctx context.Context, accountID, id string) (*d
 		itemType    string
 		token       string
 		shareName   string
+		suspended   bool
 	)
 
-	query := "select coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, coalesce(token,'') as token, coalesce(expiration, '') as expiration, stime, permissions, item_type, coalesce(share_name, '') as share_name from oc_share where share_type=? and uid_owner=? and id=?"
-	if err := lm.db.QueryRow(query, 3, accountID, id).Scan(&shareWith, &prefix, &itemSource, &token, &expiration, &stime, &permissions, &itemType, &shareName); err != nil {
+	query := "select coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, coalesce(token,'') as token, coalesce(expiration, '') as expiration, stime, permissions, item_type, coalesce(share_name, '') as share_name, id in (select id from oc_share_suspended) as suspended from oc_share where share_type=? and uid_owner=? and id=?"
+	if err := lm.db.QueryRow(query, 3, accountID, id).Scan(&shareWith, &prefix, &itemSource, &token, &expiration, &stime, &permissions, &itemType, &shareName, &suspended); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, api.NewError(api.PublicLinkNotFoundErrorCode)
 		}
 
 		return nil, err
 	}
-	dbShare := &dbShare{ID: int(intID), Prefix: prefix, ItemSource: itemSource, ShareWith: shareWith, Token: token, Expiration: expiration, STime: stime, Permissions: permissions, ItemType: itemType, Owner: accountID, ShareName: shareName}
+	dbShare := &dbShare{ID: int(intID), Prefix: prefix, ItemSource: itemSource, ShareWith: shareWith, Token: token, Expiration: expiration, STime: stime, Permissions: permissions, ItemType: itemType, Owner: accountID, ShareName: shareName, Suspended: suspended}
 	return dbShare, nil
 
 }
 func (lm *linkManager) getDBShares(ctx context.Context, accountID, fileID string) ([]*dbShare, error) {
-	query := "select id, coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, coalesce(token,'') as token, coalesce(expiration, '') as expiration, stime, permissions, item_type, coalesce(share_name, '') as share_name from oc_share where share_type=? and uid_owner=? "
+	query := "select id, coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, coalesce(token,'') as token, coalesce(expiration, '') as expiration, stime, permissions, item_type, coalesce(share_name, '') as share_name, id in (select id from oc_share_suspended) as suspended from oc_share where share_type=? and uid_owner=? "
 	params := []interface{}{3, accountID}
 
 	if fileID != "" {
@@ -565,15 +910,16 @@ func (lm *linkManager) getDBShares(ctx context.Context, accountID, fileID string
 		permissions int
 		itemType    string
 		shareName   string
+		suspended   bool
 	)
 
 	dbShares := []*dbShare{}
 	for rows.Next() {
-		err := rows.Scan(&id, &shareWith, &prefix, &itemSource, &token, &expiration, &stime, &permissions, &itemType, &shareName)
+		err := rows.Scan(&id, &shareWith, &prefix, &itemSource, &token, &expiration, &stime, &permissions, &itemType, &shareName, &suspended)
 		if err != nil {
 			return nil, err
 		}
-		dbShare := &dbShare{ID: id, Prefix: prefix, ItemSource: itemSource, ShareWith: shareWith, Token: token, Expiration: expiration, STime: stime, Permissions: permissions, ItemType: itemType, Owner: accountID, ShareName: shareName}
+		dbShare := &dbShare{ID: id, Prefix: prefix, ItemSource: itemSource, ShareWith: shareWith, Token: token, Expiration: expiration, STime: stime, Permissions: permissions, ItemType: itemType, Owner: accountID, ShareName: shareName, Suspended: suspended}
 		dbShares = append(dbShares, dbShare)
 
 	}
@@ -633,6 +979,11 @@ func (lm *linkManager) convertToPublicLink(ctx context.Context, dbShare *dbShare
 		fileID = joinFileID(dbShare.Prefix, id)
 	}
 
+	stats, err := lm.getLinkStats(dbShare.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	publicLink := &api.PublicLink{
 		Id:        fmt.Sprintf("%d", dbShare.ID),
 		Token:     dbShare.Token,
@@ -645,7 +996,9 @@ func (lm *linkManager) convertToPublicLink(ctx context.Context, dbShare *dbShare
 		ItemType:  itemType,
 		OwnerId:   dbShare.Owner,
 		Name:      dbShare.ShareName,
+		Suspended: dbShare.Suspended,
 	}
+	stats.setOn(publicLink)
 
 	return publicLink, nil
 
@@ -701,11 +1054,22 @@ func genToken() string {
 	return string(b)
 }
 
-func hashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
+func (lm *linkManager) hashPassword(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), lm.policy.GetHashCost(hashCost))
 	return string(bytes), err
 }
 
+// rehashPassword replaces the hash of the password of the share, unless
+// the password has been changed since oldShareWith was read.
+func (lm *linkManager) rehashPassword(id int, password, oldShareWith string) error {
+	hash, err := lm.hashPassword(password)
+	if err != nil {
+		return err
+	}
+	_, err = lm.db.Exec("update oc_share set share_with=? where id=? and share_with=?", "1|"+hash, id, oldShareWith)
+	return err
+}
+
 func checkPasswordHash(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
@@ -0,0 +1,20 @@
+package public_link_manager_owncloud
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	db := conformance.GetMySQL(t)
+	conformance.TestPublicLinkManager(t, func(t *testing.T, vfs api.VirtualStorage, policy *api.PasswordPolicy, ipHasher *api.ClientIPHasher) api.PublicLinkManager {
+		// the cached metadata expire at once, the suite checks the links right after changing them
+		lm, err := New(db.Username, db.Password, db.Host, db.Port, db.Name, 1000, 0, vfs, policy, ipHasher)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return lm
+	})
+}
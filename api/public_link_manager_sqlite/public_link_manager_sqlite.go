@@ -0,0 +1,591 @@
+package public_link_manager_sqlite
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"math/big"
+	gopath "path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/sqlite_db"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// migrations of the schema, they are only ever appended.
+var migrations = []string{
+	`create table public_links (
+		id integer primary key autoincrement,
+		token text not null unique,
+		owner text not null,
+		item_type text not null,
+		fileid_prefix text not null,
+		item_source text not null,
+		permissions integer not null,
+		password text not null default '',
+		expiration integer not null default 0,
+		stime integer not null,
+		share_name text not null
+	);
+	create index public_links_owner on public_links (owner, fileid_prefix, item_source)`,
+	`alter table public_links add column notify_uploads integer not null default 0`,
+	`alter table public_links add column max_downloads integer not null default 0;
+	alter table public_links add column downloads integer not null default 0;
+	alter table public_links add column views integer not null default 0;
+	alter table public_links add column last_access integer not null default 0;
+	alter table public_links add column last_access_ip text not null default ''`,
+	`alter table public_links add column suspended integer not null default 0`,
+}
+
+const (
+	tokenLength = 15
+	letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+	permissionsReadOnly  = 1
+	permissionsDropOnly  = 4
+	permissionsReadWrite = 15
+)
+
+// New returns a public link manager that keeps the links in the SQLite
+// database in file, with the same semantics as the ownCloud link manager.
+// Unlike the ownCloud one, links to files point to the file ID itself.
+// The passwords of the links follow policy, and the IPs of the clients are
+// hashed with ipHasher, both may be nil.
+func New(file string, vfs api.VirtualStorage, policy *api.PasswordPolicy, ipHasher *api.ClientIPHasher) (api.PublicLinkManager, error) {
+	db, err := sqlite_db.Open(file, "public_link_manager", migrations)
+	if err != nil {
+		return nil, err
+	}
+	return &linkManager{db: db, vfs: vfs, policy: policy, ipHasher: ipHasher}, nil
+}
+
+type linkManager struct {
+	db       *sql.DB
+	vfs      api.VirtualStorage
+	policy   *api.PasswordPolicy
+	ipHasher *api.ClientIPHasher
+}
+
+type dbLink struct {
+	ID          int64
+	Token       string
+	Owner       string
+	ItemType    string
+	Prefix      string
+	ItemSource  string
+	Permissions int
+	Password    string
+	Expiration  int64
+	STime       int64
+	ShareName   string
+	Notify      bool
+
+	MaxDownloads int64
+	Downloads    int64
+	Views        int64
+	LastAccess   int64
+	LastAccessIP string
+	Suspended    bool
+}
+
+const linkColumns = "id, token, owner, item_type, fileid_prefix, item_source, permissions, password, expiration, stime, share_name, notify_uploads, max_downloads, downloads, views, last_access, last_access_ip, suspended"
+
+func (lm *linkManager) CreatePublicLink(ctx context.Context, path string, opt *api.PublicLinkOptions) (*api.PublicLink, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	if err := lm.policy.CheckPublicLink(opt, nil); err != nil {
+		l.Warn("password refused by the policy", zap.Error(err))
+		return nil, err
+	}
+
+	md, err := lm.vfs.GetMetadata(ctx, path)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	prefix, itemSource := splitFileID(getFileID(md))
+	itemType := "file"
+	if md.IsDir {
+		itemType = "folder"
+	}
+
+	var password string
+	if opt.Password != "" {
+		password, err = lm.hashPassword(opt.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	token, err := genToken()
+	if err != nil {
+		return nil, err
+	}
+
+	query := "insert into public_links (token, owner, item_type, fileid_prefix, item_source, permissions, password, expiration, stime, share_name, notify_uploads, max_downloads) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := lm.db.Exec(query, token, u.AccountId, itemType, prefix, itemSource, getPermissions(opt), password, opt.Expiration, time.Now().Unix(), gopath.Base(path), opt.NotifyUploads, opt.MaxDownloads)
+	if err != nil {
+		l.Error("error inserting public link", zap.Error(err))
+		return nil, err
+	}
+	lastID, err := result.LastInsertId()
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	l.Info("created public link", zap.Int64("id", lastID))
+
+	return lm.InspectPublicLink(ctx, fmt.Sprintf("%d", lastID))
+}
+
+func (lm *linkManager) UpdatePublicLink(ctx context.Context, id string, opt *api.PublicLinkOptions) (*api.PublicLink, error) {
+	l := ctx_zap.Extract(ctx)
+	pb, err := lm.InspectPublicLink(ctx, id)
+	if err != nil {
+		l.Error("error getting link before update", zap.Error(err))
+		return nil, err
+	}
+	if err := lm.policy.CheckPublicLink(opt, pb); err != nil {
+		l.Warn("password refused by the policy", zap.Error(err))
+		return nil, err
+	}
+
+	stmtTail := []string{}
+	stmtValues := []interface{}{}
+
+	if opt.UpdatePassword {
+		var password string
+		if opt.Password != "" {
+			password, err = lm.hashPassword(opt.Password)
+			if err != nil {
+				return nil, err
+			}
+		}
+		stmtTail = append(stmtTail, "password=?")
+		stmtValues = append(stmtValues, password)
+	}
+
+	if opt.UpdateExpiration {
+		stmtTail = append(stmtTail, "expiration=?")
+		stmtValues = append(stmtValues, opt.Expiration)
+	}
+
+	if opt.UpdateReadOnly || opt.UpdateDropOnly {
+		stmtTail = append(stmtTail, "permissions=?")
+		stmtValues = append(stmtValues, getPermissions(opt))
+	}
+
+	if opt.UpdateNotifyUploads {
+		stmtTail = append(stmtTail, "notify_uploads=?")
+		stmtValues = append(stmtValues, opt.NotifyUploads)
+	}
+
+	if opt.UpdateMaxDownloads {
+		stmtTail = append(stmtTail, "max_downloads=?")
+		stmtValues = append(stmtValues, opt.MaxDownloads)
+	}
+
+	if len(stmtTail) == 0 { // nothing to update
+		return pb, nil
+	}
+
+	stmtValues = append(stmtValues, pb.Id)
+	if _, err := lm.db.Exec("update public_links set "+strings.Join(stmtTail, ",")+" where id=?", stmtValues...); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	l.Info("updated public link", zap.String("id", pb.Id))
+
+	return lm.InspectPublicLink(ctx, id)
+}
+
+func (lm *linkManager) InspectPublicLink(ctx context.Context, id string) (*api.PublicLink, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	intID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, api.NewError(api.PublicLinkNotFoundErrorCode).WithMessage(id)
+	}
+
+	links, err := lm.queryLinks("select "+linkColumns+" from public_links where owner=? and id=?", u.AccountId, intID)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return nil, api.NewError(api.PublicLinkNotFoundErrorCode).WithMessage(id)
+	}
+	return convertToPublicLink(links[0]), nil
+}
+
+func (lm *linkManager) InspectPublicLinkByToken(ctx context.Context, token string) (*api.PublicLink, error) {
+	link, err := lm.getLinkByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return convertToPublicLink(link), nil
+}
+
+func (lm *linkManager) ListPublicLinks(ctx context.Context, filterByPath string) ([]*api.PublicLink, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "select " + linkColumns + " from public_links where owner=?"
+	args := []interface{}{u.AccountId}
+	if filterByPath != "" {
+		md, err := lm.vfs.GetMetadata(ctx, filterByPath)
+		if err != nil {
+			return nil, err
+		}
+		prefix, itemSource := splitFileID(getFileID(md))
+		query += " and fileid_prefix=? and item_source=?"
+		args = append(args, prefix, itemSource)
+	}
+
+	links, err := lm.queryLinks(query+" order by id", args...)
+	if err != nil {
+		return nil, err
+	}
+	publicLinks := []*api.PublicLink{}
+	for _, link := range links {
+		publicLinks = append(publicLinks, convertToPublicLink(link))
+	}
+	return publicLinks, nil
+}
+
+func (lm *linkManager) RevokePublicLink(ctx context.Context, id string) error {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	res, err := lm.db.Exec("delete from public_links where owner=? and id=?", u.AccountId, id)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	rowCnt, err := res.RowsAffected()
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	if rowCnt == 0 {
+		err := api.NewError(api.PublicLinkNotFoundErrorCode)
+		l.Error("", zap.Error(err), zap.String("id", id))
+		return err
+	}
+	return nil
+}
+
+func (lm *linkManager) AuthenticatePublicLink(ctx context.Context, token, password string) (*api.PublicLink, error) {
+	l := ctx_zap.Extract(ctx)
+	link, err := lm.getLinkByToken(token)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	pb := convertToPublicLink(link)
+
+	// check expiration time
+	if pb.Expires != 0 && uint64(time.Now().Unix()) > pb.Expires {
+		l.Warn("public link has expired", zap.String("id", pb.Id))
+		return nil, api.NewError(api.PublicLinkInvalidExpireDateErrorCode)
+	}
+
+	if pb.Protected && !checkPasswordHash(password, link.Password) {
+		return nil, api.NewError(api.PublicLinkInvalidPasswordErrorCode)
+	}
+
+	// upgrade the hash now that the password is known
+	if pb.Protected && api.NeedsRehash(link.Password, lm.policy.GetHashCost(bcrypt.DefaultCost)) {
+		if err := lm.rehashPassword(token, password, link.Password); err != nil {
+			l.Error("error rehashing public link password", zap.Error(err))
+		} else {
+			l.Info("upgraded public link password hash", zap.String("id", pb.Id))
+		}
+	}
+
+	if err := lm.recordAccess(ctx, token, "views"); err != nil {
+		if api.IsErrorCode(err, api.PublicLinkDownloadLimitErrorCode) {
+			l.Warn("public link has reached its download limit", zap.String("id", pb.Id))
+		}
+		return nil, err
+	}
+	link, err = lm.getLinkByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return convertToPublicLink(link), nil
+}
+
+func (lm *linkManager) RecordPublicLinkDownload(ctx context.Context, token string) error {
+	return lm.recordAccess(ctx, token, "downloads")
+}
+
+// recordAccess increments the counter of the link with the token,
+// unless the link has reached its download limit.
+func (lm *linkManager) recordAccess(ctx context.Context, token, counter string) error {
+	query := "update public_links set " + counter + "=" + counter + "+1, last_access=?, last_access_ip=? where token=? and suspended=0 and (max_downloads=0 or downloads<max_downloads)"
+	res, err := lm.db.Exec(query, time.Now().Unix(), lm.ipHasher.Hash(api.GetClientIP(ctx)), token)
+	if err != nil {
+		return err
+	}
+	rowCnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowCnt == 0 {
+		// either the link is gone or it cannot be downloaded anymore
+		if _, err := lm.getLinkByToken(token); err != nil {
+			return err
+		}
+		return api.NewError(api.PublicLinkDownloadLimitErrorCode)
+	}
+	return nil
+}
+
+func (lm *linkManager) IsPublicLinkProtected(ctx context.Context, token string) (bool, error) {
+	link, err := lm.getLinkByToken(token)
+	if err != nil {
+		return false, err
+	}
+	return link.Password != "", nil
+}
+
+func (lm *linkManager) SuspendPublicLinks(ctx context.Context, fileIDs []string, suspended bool) ([]*api.PublicLink, error) {
+	if len(fileIDs) == 0 {
+		return []*api.PublicLink{}, nil
+	}
+	cond, args := fileIDsCondition(fileIDs)
+	links, err := lm.queryLinks("select "+linkColumns+" from public_links where suspended=? and "+cond+" order by id", append([]interface{}{!suspended}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	publicLinks := []*api.PublicLink{}
+	for _, link := range links {
+		if _, err := lm.db.Exec("update public_links set suspended=? where id=?", suspended, link.ID); err != nil {
+			return nil, err
+		}
+		link.Suspended = suspended
+		publicLinks = append(publicLinks, convertToPublicLink(link))
+	}
+	return publicLinks, nil
+}
+
+func (lm *linkManager) PurgePublicLinks(ctx context.Context, fileIDs []string) ([]*api.PublicLink, error) {
+	if len(fileIDs) == 0 {
+		return []*api.PublicLink{}, nil
+	}
+	cond, args := fileIDsCondition(fileIDs)
+	links, err := lm.queryLinks("select "+linkColumns+" from public_links where "+cond+" order by id", args...)
+	if err != nil {
+		return nil, err
+	}
+	publicLinks := []*api.PublicLink{}
+	for _, link := range links {
+		if _, err := lm.db.Exec("delete from public_links where id=?", link.ID); err != nil {
+			return nil, err
+		}
+		publicLinks = append(publicLinks, convertToPublicLink(link))
+	}
+	return publicLinks, nil
+}
+
+func (lm *linkManager) ListAllPublicLinks(ctx context.Context, owner string) ([]*api.PublicLink, error) {
+	query := "select " + linkColumns + " from public_links "
+	args := []interface{}{}
+	if owner != "" {
+		query += "where owner=? "
+		args = append(args, owner)
+	}
+	links, err := lm.queryLinks(query+"order by id", args...)
+	if err != nil {
+		return nil, err
+	}
+	publicLinks := []*api.PublicLink{}
+	for _, link := range links {
+		publicLinks = append(publicLinks, convertToPublicLink(link))
+	}
+	return publicLinks, nil
+}
+
+func (lm *linkManager) TransferPublicLinks(ctx context.Context, fileIDs []string, from, to string) ([]*api.PublicLink, error) {
+	if len(fileIDs) == 0 {
+		return []*api.PublicLink{}, nil
+	}
+	cond, args := fileIDsCondition(fileIDs)
+	links, err := lm.queryLinks("select "+linkColumns+" from public_links where owner=? and "+cond+" order by id", append([]interface{}{from}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	publicLinks := []*api.PublicLink{}
+	for _, link := range links {
+		if _, err := lm.db.Exec("update public_links set owner=? where id=?", to, link.ID); err != nil {
+			return nil, err
+		}
+		link.Owner = to
+		publicLinks = append(publicLinks, convertToPublicLink(link))
+	}
+	return publicLinks, nil
+}
+
+// getLinkByToken returns the link of the token, unless it is suspended.
+func (lm *linkManager) getLinkByToken(token string) (*dbLink, error) {
+	links, err := lm.queryLinks("select "+linkColumns+" from public_links where token=? and suspended=0", token)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return nil, api.NewError(api.PublicLinkNotFoundErrorCode)
+	}
+	return links[0], nil
+}
+
+func (lm *linkManager) queryLinks(query string, args ...interface{}) ([]*dbLink, error) {
+	rows, err := lm.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []*dbLink{}
+	for rows.Next() {
+		link := &dbLink{}
+		if err := rows.Scan(&link.ID, &link.Token, &link.Owner, &link.ItemType, &link.Prefix, &link.ItemSource, &link.Permissions, &link.Password, &link.Expiration, &link.STime, &link.ShareName, &link.Notify, &link.MaxDownloads, &link.Downloads, &link.Views, &link.LastAccess, &link.LastAccessIP, &link.Suspended); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+func convertToPublicLink(link *dbLink) *api.PublicLink {
+	itemType := api.PublicLink_FILE
+	if link.ItemType == "folder" {
+		itemType = api.PublicLink_FOLDER
+	}
+	return &api.PublicLink{
+		Id:            fmt.Sprintf("%d", link.ID),
+		Token:         link.Token,
+		Mtime:         uint64(link.STime),
+		Protected:     link.Password != "",
+		Path:          joinFileID(link.Prefix, link.ItemSource),
+		Expires:       uint64(link.Expiration),
+		ReadOnly:      link.Permissions == permissionsReadOnly,
+		DropOnly:      link.Permissions == permissionsDropOnly,
+		NotifyUploads: link.Notify,
+		ItemType:      itemType,
+		OwnerId:       link.Owner,
+		Name:          link.ShareName,
+
+		MaxDownloads:     uint64(link.MaxDownloads),
+		Downloads:        uint64(link.Downloads),
+		Views:            uint64(link.Views),
+		LastAccess:       uint64(link.LastAccess),
+		LastAccessIpHash: link.LastAccessIP,
+		Suspended:        link.Suspended,
+	}
+}
+
+func getPermissions(opt *api.PublicLinkOptions) int {
+	if opt.ReadOnly {
+		return permissionsReadOnly
+	} else if opt.DropOnly {
+		return permissionsDropOnly
+	}
+	return permissionsReadWrite
+}
+
+func getFileID(md *api.Metadata) string {
+	if md.MigId != "" {
+		return md.MigId
+	}
+	return md.Id
+}
+
+func getUserFromContext(ctx context.Context) (*api.User, error) {
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		return nil, api.NewError(api.ContextUserRequiredError)
+	}
+	return u, nil
+}
+
+// splitFileID returns the two parts of a fileID.
+// A fileID like home:1234 will be separated into the prefix (home) and the inode(1234).
+func splitFileID(fileID string) (string, string) {
+	tokens := strings.SplitN(fileID, ":", 2)
+	if len(tokens) == 1 {
+		return "", tokens[0]
+	}
+	return tokens[0], tokens[1]
+}
+
+// joinFileID concatenates the prefix and the inode to form a valid fileID.
+// fileIDsCondition returns the condition matching the links of the files fileIDs.
+func fileIDsCondition(fileIDs []string) (string, []interface{}) {
+	conds := []string{}
+	args := []interface{}{}
+	for _, fileID := range fileIDs {
+		prefix, itemSource := splitFileID(fileID)
+		conds = append(conds, "(fileid_prefix=? and item_source=?)")
+		args = append(args, prefix, itemSource)
+	}
+	return "(" + strings.Join(conds, " or ") + ")", args
+}
+
+func joinFileID(prefix, inode string) string {
+	return strings.Join([]string{prefix, inode}, ":")
+}
+
+func genToken() (string, error) {
+	b := make([]byte, tokenLength)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(letterBytes))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = letterBytes[n.Int64()]
+	}
+	return string(b), nil
+}
+
+func (lm *linkManager) hashPassword(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), lm.policy.GetHashCost(bcrypt.DefaultCost))
+	return string(bytes), err
+}
+
+// rehashPassword replaces the hash of the password of the link, unless
+// the password has been changed since oldHash was read.
+func (lm *linkManager) rehashPassword(token, password, oldHash string) error {
+	hash, err := lm.hashPassword(password)
+	if err != nil {
+		return err
+	}
+	_, err = lm.db.Exec("update public_links set password=? where token=? and password=?", hash, token, oldHash)
+	return err
+}
+
+func checkPasswordHash(password, hash string) bool {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	return err == nil
+}
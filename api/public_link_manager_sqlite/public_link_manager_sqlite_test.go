@@ -0,0 +1,18 @@
+package public_link_manager_sqlite
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.TestPublicLinkManager(t, func(t *testing.T, vfs api.VirtualStorage, policy *api.PasswordPolicy, ipHasher *api.ClientIPHasher) api.PublicLinkManager {
+		lm, err := New("", vfs, policy, ipHasher)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return lm
+	})
+}
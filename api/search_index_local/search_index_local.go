@@ -0,0 +1,239 @@
+package search_index_local
+
+import (
+	"context"
+	"encoding/gob"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+)
+
+// maxTerms is the number of distinct words indexed from the content of a file.
+const maxTerms = 10000
+
+type index struct {
+	file   string
+	logger *zap.Logger
+
+	sync.RWMutex
+	docs  map[string]*document
+	dirty bool
+}
+
+// document is stored with gob, so the fields are exported.
+type document struct {
+	ID       string
+	ParentID string
+	Name     string
+	Mime     string
+	Size     uint64
+	Mtime    uint64
+	IsDir    bool
+	Terms    map[string]bool
+	Readers  map[string]bool
+}
+
+// New returns an index kept in memory and saved to file every
+// saveInterval when it changed, so that it survives restarts.
+// The index is only kept in memory if file is empty.
+func New(file string, saveInterval time.Duration, logger *zap.Logger) (api.SearchIndex, error) {
+	idx := &index{file: file, logger: logger, docs: map[string]*document{}}
+	if file == "" {
+		return idx, nil
+	}
+
+	if err := idx.load(); err != nil {
+		return nil, err
+	}
+	go func() {
+		for range time.Tick(saveInterval) {
+			if err := idx.save(); err != nil {
+				logger.Error("error saving search index", zap.Error(err), zap.String("file", file))
+			}
+		}
+	}()
+	return idx, nil
+}
+
+func (idx *index) load() error {
+	fd, err := os.Open(idx.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer fd.Close()
+	return gob.NewDecoder(fd).Decode(&idx.docs)
+}
+
+func (idx *index) save() error {
+	idx.Lock()
+	if !idx.dirty {
+		idx.Unlock()
+		return nil
+	}
+	idx.dirty = false
+	// the documents are replaced and never modified in place,
+	// so a copy of the map can be encoded without the lock.
+	docs := make(map[string]*document, len(idx.docs))
+	for k, v := range idx.docs {
+		docs[k] = v
+	}
+	idx.Unlock()
+
+	fd, err := ioutil.TempFile(filepath.Dir(idx.file), ".search-index-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(fd.Name())
+	if err := gob.NewEncoder(fd).Encode(docs); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	return os.Rename(fd.Name(), idx.file)
+}
+
+func (idx *index) Index(ctx context.Context, doc *api.SearchDocument) error {
+	d := &document{
+		ID:       doc.ID,
+		ParentID: doc.ParentID,
+		Name:     doc.Name,
+		Mime:     doc.Mime,
+		Size:     doc.Size,
+		Mtime:    doc.Mtime,
+		IsDir:    doc.IsDir,
+		Terms:    map[string]bool{},
+		Readers:  map[string]bool{},
+	}
+	for _, t := range tokenize(doc.Content) {
+		if len(d.Terms) >= maxTerms {
+			break
+		}
+		d.Terms[t] = true
+	}
+	for _, r := range doc.Readers {
+		d.Readers[r] = true
+	}
+
+	idx.Lock()
+	defer idx.Unlock()
+	// a moved or rewritten file keeps its ID, so it replaces its previous document,
+	// the readers are kept as the file can be changed by every one of them.
+	if prev, ok := idx.docs[doc.ID]; ok {
+		for r := range prev.Readers {
+			d.Readers[r] = true
+		}
+	}
+	idx.docs[doc.ID] = d
+	idx.dirty = true
+	return nil
+}
+
+func (idx *index) Remove(ctx context.Context, id string) error {
+	idx.Lock()
+	defer idx.Unlock()
+	if _, ok := idx.docs[id]; ok {
+		delete(idx.docs, id)
+		idx.dirty = true
+	}
+	return nil
+}
+
+func (idx *index) RemoveName(ctx context.Context, parentID, name string) error {
+	l := ctx_zap.Extract(ctx)
+	idx.Lock()
+	defer idx.Unlock()
+	for id, d := range idx.docs {
+		if d.ParentID == parentID && d.Name == name {
+			l.Debug("document removed from search index", zap.String("id", id))
+			delete(idx.docs, id)
+			idx.dirty = true
+		}
+	}
+	return nil
+}
+
+func (idx *index) Search(ctx context.Context, readers []string, req *api.SearchReq) ([]*api.SearchDocument, error) {
+	terms := tokenize(req.Query)
+	idx.RLock()
+	docs := []*api.SearchDocument{}
+	for _, d := range idx.docs {
+		if isReader(d, readers) && match(d, req, terms) {
+			docs = append(docs, &api.SearchDocument{ID: d.ID, ParentID: d.ParentID, Name: d.Name, Mime: d.Mime, Size: d.Size, Mtime: d.Mtime, IsDir: d.IsDir})
+		}
+	}
+	idx.RUnlock()
+
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Mtime > docs[j].Mtime })
+	return docs, nil
+}
+
+func isReader(d *document, readers []string) bool {
+	for _, r := range readers {
+		if d.Readers[r] {
+			return true
+		}
+	}
+	return false
+}
+
+func match(d *document, req *api.SearchReq, terms []string) bool {
+	name := strings.ToLower(d.Name)
+	if req.Name != "" {
+		if ok, _ := path.Match(strings.ToLower(req.Name), name); !ok {
+			return false
+		}
+	}
+	if req.Mime != "" {
+		if strings.HasSuffix(req.Mime, "/") {
+			if !strings.HasPrefix(d.Mime, req.Mime) {
+				return false
+			}
+		} else if d.Mime != req.Mime {
+			return false
+		}
+	}
+	if d.Size < req.MinSize || (req.MaxSize > 0 && d.Size > req.MaxSize) {
+		return false
+	}
+	if d.Mtime < req.MinMtime || (req.MaxMtime > 0 && d.Mtime > req.MaxMtime) {
+		return false
+	}
+	// every word must be in the name or in the content
+	for _, t := range terms {
+		if !strings.Contains(name, t) && !d.Terms[t] {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenize returns the distinct lower case words of s.
+func tokenize(s string) []string {
+	seen := map[string]bool{}
+	terms := []string{}
+	for _, t := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !seen[t] {
+			seen[t] = true
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
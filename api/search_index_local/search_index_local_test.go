@@ -0,0 +1,110 @@
+package search_index_local
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/cernbox/reva/api"
+
+	"go.uber.org/zap"
+)
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	idx, err := New("", time.Hour, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idx.Index(ctx, &api.SearchDocument{ID: "1", ParentID: "0", Name: "Analysis.py", Mime: "text/x-python", Size: 100, Mtime: 10, Readers: []string{"alice"}, Content: "import ROOT\nhist = ROOT.TH1F()"})
+	idx.Index(ctx, &api.SearchDocument{ID: "2", ParentID: "0", Name: "photo.jpg", Mime: "image/jpeg", Size: 5000, Mtime: 20, Readers: []string{"alice"}})
+	idx.Index(ctx, &api.SearchDocument{ID: "3", ParentID: "0", Name: "data.root", Mime: "application/root", Size: 100000, Mtime: 30, Readers: []string{"alice"}})
+
+	tests := []struct {
+		req *api.SearchReq
+		ids []string
+	}{
+		{&api.SearchReq{}, []string{"3", "2", "1"}},
+		{&api.SearchReq{Query: "root"}, []string{"3", "1"}},
+		{&api.SearchReq{Query: "analysis hist"}, []string{"1"}},
+		{&api.SearchReq{Name: "*.ROOT"}, []string{"3"}},
+		{&api.SearchReq{Mime: "image/"}, []string{"2"}},
+		{&api.SearchReq{Mime: "image/png"}, []string{}},
+		{&api.SearchReq{MinSize: 1000, MaxSize: 10000}, []string{"2"}},
+		{&api.SearchReq{MinMtime: 15, MaxMtime: 25}, []string{"2"}},
+	}
+	for _, test := range tests {
+		docs, err := idx.Search(ctx, []string{"alice"}, test.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []string{}
+		for _, d := range docs {
+			ids = append(ids, d.ID)
+		}
+		if len(ids) != len(test.ids) {
+			t.Errorf("%+v: got %v, expected %v", test.req, ids, test.ids)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != test.ids[i] {
+				t.Errorf("%+v: got %v, expected %v", test.req, ids, test.ids)
+				break
+			}
+		}
+	}
+
+	// the documents are only found by their readers
+	if docs, _ := idx.Search(ctx, []string{"bob"}, &api.SearchReq{}); len(docs) != 0 {
+		t.Errorf("expected no document for bob, got %v", docs)
+	}
+	// a user searches as the owners of the files shared with it too
+	if docs, _ := idx.Search(ctx, []string{"bob", "alice"}, &api.SearchReq{}); len(docs) != 3 {
+		t.Errorf("expected the documents of alice for bob searching as alice, got %v", docs)
+	}
+
+	// a moved file keeps its ID and replaces its document, with its readers
+	idx.Index(ctx, &api.SearchDocument{ID: "2", ParentID: "9", Name: "holidays.jpg", Mime: "image/jpeg", Size: 5000, Mtime: 20, Readers: []string{"bob"}})
+	idx.RemoveName(ctx, "0", "photo.jpg")
+	for _, reader := range []string{"alice", "bob"} {
+		if docs, _ := idx.Search(ctx, []string{reader}, &api.SearchReq{Query: "holidays"}); len(docs) != 1 {
+			t.Errorf("expected moved file to be found by %s, got %v", reader, docs)
+		}
+	}
+	idx.RemoveName(ctx, "9", "holidays.jpg")
+	idx.Remove(ctx, "3")
+	if docs, _ := idx.Search(ctx, []string{"alice"}, &api.SearchReq{}); len(docs) != 1 || docs[0].ID != "1" {
+		t.Errorf("expected only file 1 to be left, got %v", docs)
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "search-index")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	file := path.Join(dir, "index")
+	idx, err := New(file, time.Hour, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx.Index(ctx, &api.SearchDocument{ID: "1", Name: "notes.txt", Content: "meeting minutes", Readers: []string{"alice"}})
+	if err := idx.(*index).save(); err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err = New(file, time.Hour, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if docs, _ := idx.Search(ctx, []string{"alice"}, &api.SearchReq{Query: "minutes"}); len(docs) != 1 {
+		t.Errorf("expected the index to be loaded from file, got %v", docs)
+	}
+}
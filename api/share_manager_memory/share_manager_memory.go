@@ -0,0 +1,609 @@
+package share_manager_memory
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+)
+
+// New returns a share manager that keeps the shares in memory, so they
+// are neither shared between several daemons nor kept across restarts.
+func New(vfs api.VirtualStorage, um api.UserManager) api.ShareManager {
+	return &shareManager{vfs: vfs, um: um, shares: map[int64]*share{}}
+}
+
+type shareManager struct {
+	sync.Mutex
+	vfs    api.VirtualStorage
+	um     api.UserManager
+	shares map[int64]*share
+	lastID int64
+}
+
+type share struct {
+	id          int64
+	owner       string
+	initiator   string
+	parent      int64 // 0 if the share is not a re-share
+	recipient   api.ShareRecipient
+	fileID      string
+	itemType    api.FolderShare_ItemType
+	permissions api.SharePermissions
+	expiration  uint64
+	stime       int64
+	target      string
+	suspended   bool
+	mounts      map[string]*mount // by recipient, pending if absent
+}
+
+// mount is the state of a share for one of its recipients.
+type mount struct {
+	state  api.FolderShare_State
+	target string
+}
+
+func (sm *shareManager) AddFolderShare(ctx context.Context, p string, recipient *api.ShareRecipient, opt *api.FolderShareOptions) (*api.FolderShare, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	md, err := sm.vfs.GetMetadata(ctx, p)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	itemType := getItemType(md)
+	if err := api.CheckSharePermissions(opt.Permissions, itemType); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	s := &share{
+		owner:       u.AccountId,
+		initiator:   u.AccountId,
+		recipient:   api.ShareRecipient{Identity: recipient.Identity, Type: recipient.Type},
+		fileID:      getFileID(md),
+		itemType:    itemType,
+		permissions: opt.Permissions.ForItemType(itemType),
+		expiration:  opt.Expiration,
+		stime:       time.Now().Unix(),
+		target:      path.Join("/", path.Base(p)),
+		mounts:      map[string]*mount{},
+	}
+	if s.recipient.Type != api.ShareRecipient_GROUP {
+		s.recipient.Type = api.ShareRecipient_USER
+	}
+
+	if md.ShareId != "" { // re-share of a received share
+		parent, err := sm.GetReceivedFolderShare(ctx, md.ShareId)
+		if err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		if s.permissions, err = api.GetResharePermissions(parent, s.permissions); err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		s.parent, _ = strconv.ParseInt(parent.Id, 10, 64)
+		s.owner = parent.OwnerId
+	}
+
+	sm.Lock()
+	sm.lastID++
+	s.id = sm.lastID
+	sm.shares[s.id] = s
+	folderShare := s.toFolderShare()
+	sm.Unlock()
+	l.Info("created share", zap.Int64("share_id", s.id))
+
+	// set acl on the storage
+	if err := sm.vfs.SetACL(ownerContext(ctx, s.owner), s.fileID, s.permissions, recipient, []*api.FolderShare{}); err != nil {
+		l.Error("error setting acl on storage, rollbacking operation", zap.Error(err))
+		sm.Lock()
+		delete(sm.shares, s.id)
+		sm.Unlock()
+		return nil, err
+	}
+
+	l.Info("share commited on storage acl", zap.String("share_id", folderShare.Id))
+	return folderShare, nil
+}
+
+func (sm *shareManager) GetFolderShare(ctx context.Context, id string) (*api.FolderShare, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sm.Lock()
+	defer sm.Unlock()
+	s, err := sm.getManagedShare(u.AccountId, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toFolderShare(), nil
+}
+
+// getManagedShare returns the share if the user is its owner or its initiator.
+func (sm *shareManager) getManagedShare(accountID, id string) (*share, error) {
+	intID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, api.NewError(api.FolderShareNotFoundErrorCode).WithMessage(id)
+	}
+	s, ok := sm.shares[intID]
+	if !ok || (s.owner != accountID && s.initiator != accountID) {
+		return nil, api.NewError(api.FolderShareNotFoundErrorCode).WithMessage(id)
+	}
+	return s, nil
+}
+
+// getShareTree returns the share followed by its re-shares, recursively.
+func (sm *shareManager) getShareTree(s *share) []*share {
+	tree := []*share{s}
+	for _, child := range sm.sortedShares() {
+		if child.parent == s.id {
+			tree = append(tree, sm.getShareTree(child)...)
+		}
+	}
+	return tree
+}
+
+func (sm *shareManager) Unshare(ctx context.Context, id string) error {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sm.Lock()
+	s, err := sm.getManagedShare(u.AccountId, id)
+	if err != nil {
+		sm.Unlock()
+		l.Error("", zap.Error(err))
+		return err
+	}
+	removed := []*api.FolderShare{}
+	for _, s := range sm.getShareTree(s) {
+		delete(sm.shares, s.id)
+		removed = append(removed, s.toFolderShare())
+	}
+	sm.Unlock()
+
+	// re-set acl on the storage, the re-shares go with the share
+	var firstErr error
+	for _, folderShare := range removed {
+		if err := sm.vfs.UnsetACL(ownerContext(ctx, folderShare.OwnerId), folderShare.Path, folderShare.Recipient, []*api.FolderShare{}); err != nil {
+			l.Error("error removing acl on storage, fix manually", zap.Error(err), zap.String("share_id", folderShare.Id))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		l.Info("share removed from storage acl", zap.String("share_id", folderShare.Id))
+	}
+	return firstErr
+}
+
+func (sm *shareManager) UpdateFolderShare(ctx context.Context, id string, opt *api.FolderShareOptions) (*api.FolderShare, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	folderShare, err := sm.GetFolderShare(ctx, id)
+	if err != nil {
+		l.Error("error getting share before update", zap.Error(err))
+		return nil, err
+	}
+
+	if !opt.UpdatePermissions && !opt.UpdateExpiration { // nothing to update
+		return folderShare, nil
+	}
+	if opt.UpdatePermissions {
+		if err := api.CheckSharePermissions(opt.Permissions, folderShare.ItemType); err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	sm.Lock()
+	s, err := sm.getManagedShare(u.AccountId, id)
+	if err != nil {
+		sm.Unlock()
+		return nil, err
+	}
+	if opt.UpdateExpiration {
+		s.expiration = opt.Expiration
+	}
+	updated := []*api.FolderShare{}
+	if opt.UpdatePermissions {
+		s.permissions = opt.Permissions.ForItemType(s.itemType)
+		if parent, ok := sm.shares[s.parent]; ok {
+			s.permissions &= parent.permissions
+		}
+		// the re-shares cannot grant more than the share
+		for _, child := range sm.getShareTree(s) {
+			if parent, ok := sm.shares[child.parent]; ok && child != s {
+				child.permissions &= parent.permissions
+			}
+			updated = append(updated, child.toFolderShare())
+		}
+	}
+	folderShare = s.toFolderShare()
+	sm.Unlock()
+	l.Info("updated share")
+
+	//  update the acls of the share and its re-shares on the storage
+	for _, updatedShare := range updated {
+		if err := sm.vfs.SetACL(ownerContext(ctx, updatedShare.OwnerId), updatedShare.Path, api.GetSharePermissions(updatedShare), updatedShare.Recipient, []*api.FolderShare{}); err != nil {
+			l.Error("error setting acl on storage", zap.Error(err), zap.String("share_id", updatedShare.Id))
+			return nil, err
+		}
+		l.Info("share commited on storage acl", zap.String("share_id", updatedShare.Id))
+	}
+	return folderShare, nil
+}
+
+func (sm *shareManager) ListFolderShares(ctx context.Context, filterByPath string) ([]*api.FolderShare, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileID string
+	if filterByPath != "" {
+		md, err := sm.vfs.GetMetadata(ctx, filterByPath)
+		if err != nil {
+			return nil, err
+		}
+		fileID = getFileID(md)
+	}
+
+	sm.Lock()
+	defer sm.Unlock()
+	shares := []*api.FolderShare{}
+	for _, s := range sm.sortedShares() {
+		if (s.owner == u.AccountId || s.initiator == u.AccountId) && (fileID == "" || s.fileID == fileID) {
+			shares = append(shares, s.toFolderShare())
+		}
+	}
+	return shares, nil
+}
+
+func (sm *shareManager) ListReceivedShares(ctx context.Context) ([]*api.FolderShare, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := sm.getUserGroups(ctx, u.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	sm.Lock()
+	defer sm.Unlock()
+	shares := []*api.FolderShare{}
+	for _, s := range sm.sortedShares() {
+		if s.isReceivedBy(u.AccountId, groups, time.Now()) {
+			shares = append(shares, s.toReceivedFolderShare(u.AccountId))
+		}
+	}
+	return shares, nil
+}
+
+func (sm *shareManager) GetReceivedFolderShare(ctx context.Context, id string) (*api.FolderShare, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := sm.getUserGroups(ctx, u.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	sm.Lock()
+	defer sm.Unlock()
+	s, err := sm.getReceivedShare(u.AccountId, groups, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toReceivedFolderShare(u.AccountId), nil
+}
+
+func (sm *shareManager) UnmountReceivedShare(ctx context.Context, id string) error {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	groups, err := sm.getUserGroups(ctx, u.AccountId)
+	if err != nil {
+		return err
+	}
+
+	sm.Lock()
+	defer sm.Unlock()
+	s, err := sm.getReceivedShare(u.AccountId, groups, id)
+	if err != nil {
+		return err
+	}
+	s.mounts[u.AccountId] = &mount{state: api.FolderShare_REJECTED, target: s.getTarget(u.AccountId)}
+	return nil
+}
+
+func (sm *shareManager) MountReceivedShare(ctx context.Context, id, target string) (*api.FolderShare, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := sm.getUserGroups(ctx, u.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	sm.Lock()
+	defer sm.Unlock()
+	s, err := sm.getReceivedShare(u.AccountId, groups, id)
+	if err != nil {
+		return nil, err
+	}
+	if target == "" {
+		target = s.getTarget(u.AccountId)
+	} else if target, err = api.GetShareTarget(target); err != nil {
+		return nil, err
+	}
+	s.mounts[u.AccountId] = &mount{state: api.FolderShare_ACCEPTED, target: target}
+	return s.toReceivedFolderShare(u.AccountId), nil
+}
+
+func (sm *shareManager) ExpireFolderShares(ctx context.Context) ([]*api.FolderShare, error) {
+	l := ctx_zap.Extract(ctx)
+	now := time.Now()
+	sm.Lock()
+	expired := []*share{}
+	for _, s := range sm.sortedShares() {
+		if s.isExpired(now) && !s.suspended {
+			expired = append(expired, s)
+		}
+	}
+	sm.Unlock()
+
+	shares := []*api.FolderShare{}
+	for _, s := range expired {
+		sm.Lock()
+		if _, ok := sm.shares[s.id]; !ok { // removed with its parent
+			sm.Unlock()
+			continue
+		}
+		tree := sm.getShareTree(s)
+		sm.Unlock()
+
+		// the acls are removed first, so the share is expired again
+		// at the next sweep if it fails
+		removed, failed := []*api.FolderShare{}, false
+		for _, s := range tree {
+			folderShare := s.toFolderShare()
+			if err := sm.vfs.UnsetACL(ownerContext(ctx, s.owner), folderShare.Path, folderShare.Recipient, []*api.FolderShare{}); err != nil {
+				l.Error("error removing acl of expired share on storage", zap.Error(err), zap.String("share_id", folderShare.Id))
+				failed = true
+				break
+			}
+			removed = append(removed, folderShare)
+		}
+		if failed {
+			continue
+		}
+		sm.Lock()
+		for _, s := range tree {
+			delete(sm.shares, s.id)
+		}
+		sm.Unlock()
+		for _, folderShare := range removed {
+			l.Info("expired share removed from storage acl", zap.String("share_id", folderShare.Id))
+		}
+		shares = append(shares, removed...)
+	}
+	return shares, nil
+}
+
+func (sm *shareManager) SuspendFolderShares(ctx context.Context, fileIDs []string, suspended bool) ([]*api.FolderShare, error) {
+	ids := fileIDSet(fileIDs)
+	sm.Lock()
+	defer sm.Unlock()
+	shares := []*api.FolderShare{}
+	for _, s := range sm.sortedShares() {
+		if ids[s.fileID] && s.suspended != suspended {
+			s.suspended = suspended
+			shares = append(shares, s.toFolderShare())
+		}
+	}
+	return shares, nil
+}
+
+func (sm *shareManager) PurgeFolderShares(ctx context.Context, fileIDs []string) ([]*api.FolderShare, error) {
+	ids := fileIDSet(fileIDs)
+	sm.Lock()
+	defer sm.Unlock()
+	shares := []*api.FolderShare{}
+	for _, s := range sm.sortedShares() {
+		if _, ok := sm.shares[s.id]; !ok || !ids[s.fileID] { // removed with its parent
+			continue
+		}
+		// the re-shares are on the file or under it, so gone with it
+		for _, s := range sm.getShareTree(s) {
+			delete(sm.shares, s.id)
+			shares = append(shares, s.toFolderShare())
+		}
+	}
+	return shares, nil
+}
+
+func (sm *shareManager) ListAllFolderShares(ctx context.Context, owner string) ([]*api.FolderShare, error) {
+	sm.Lock()
+	defer sm.Unlock()
+	shares := []*api.FolderShare{}
+	for _, s := range sm.sortedShares() {
+		if owner == "" || s.owner == owner {
+			shares = append(shares, s.toFolderShare())
+		}
+	}
+	return shares, nil
+}
+
+func (sm *shareManager) TransferFolderShares(ctx context.Context, fileIDs []string, from, to string) ([]*api.FolderShare, error) {
+	ids := fileIDSet(fileIDs)
+	sm.Lock()
+	defer sm.Unlock()
+	shares := []*api.FolderShare{}
+	for _, s := range sm.sortedShares() {
+		if s.owner != from || !ids[s.fileID] {
+			continue
+		}
+		s.owner = to
+		if s.initiator == from {
+			s.initiator = to
+		}
+		shares = append(shares, s.toFolderShare())
+	}
+	return shares, nil
+}
+
+func (sm *shareManager) getReceivedShare(accountID string, groups map[string]bool, id string) (*share, error) {
+	intID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, api.NewError(api.FolderShareNotFoundErrorCode).WithMessage(id)
+	}
+	s, ok := sm.shares[intID]
+	if !ok || !s.isReceivedBy(accountID, groups, time.Now()) {
+		return nil, api.NewError(api.FolderShareNotFoundErrorCode).WithMessage(id)
+	}
+	return s, nil
+}
+
+func (sm *shareManager) getUserGroups(ctx context.Context, accountID string) (map[string]bool, error) {
+	groups, err := sm.um.GetUserGroups(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]bool{}
+	for _, g := range groups {
+		m[g] = true
+	}
+	return m, nil
+}
+
+// sortedShares returns the shares in creation order, like the databases do.
+func (sm *shareManager) sortedShares() []*share {
+	shares := make([]*share, 0, len(sm.shares))
+	for _, s := range sm.shares {
+		shares = append(shares, s)
+	}
+	sort.Slice(shares, func(i, j int) bool { return shares[i].id < shares[j].id })
+	return shares
+}
+
+func (s *share) isExpired(now time.Time) bool {
+	return s.expiration != 0 && s.expiration <= uint64(now.Unix())
+}
+
+func (s *share) isReceivedBy(accountID string, groups map[string]bool, now time.Time) bool {
+	if s.owner == accountID || s.initiator == accountID || s.isExpired(now) || s.suspended {
+		return false
+	}
+	if s.recipient.Type == api.ShareRecipient_GROUP {
+		return groups[s.recipient.Identity]
+	}
+	return s.recipient.Identity == accountID
+}
+
+// getTarget returns the target the share is mounted under for the recipient.
+func (s *share) getTarget(accountID string) string {
+	if m, ok := s.mounts[accountID]; ok {
+		return m.target
+	}
+	return s.target
+}
+
+func (s *share) toFolderShare() *api.FolderShare {
+	share := &api.FolderShare{
+		OwnerId:     s.owner,
+		Id:          fmt.Sprintf("%d", s.id),
+		Mtime:       uint64(s.stime),
+		Path:        s.fileID,
+		ItemType:    s.itemType,
+		Expiration:  s.expiration,
+		Recipient:   &api.ShareRecipient{Identity: s.recipient.Identity, Type: s.recipient.Type},
+		InitiatorId: s.initiator,
+		Suspended:   s.suspended,
+	}
+	if s.parent != 0 {
+		share.ParentId = fmt.Sprintf("%d", s.parent)
+	}
+	api.SetSharePermissions(share, s.permissions)
+	return share
+}
+
+func (s *share) toReceivedFolderShare(accountID string) *api.FolderShare {
+	share := s.toFolderShare()
+	share.Target = s.getTarget(accountID)
+	share.State = api.FolderShare_PENDING
+	if m, ok := s.mounts[accountID]; ok {
+		share.State = m.state
+	}
+	return share
+}
+
+// ownerContext returns the context to change the ACLs of the files of owner.
+func ownerContext(ctx context.Context, owner string) context.Context {
+	return api.ContextSetUser(ctx, &api.User{AccountId: owner})
+}
+
+func fileIDSet(fileIDs []string) map[string]bool {
+	ids := map[string]bool{}
+	for _, id := range fileIDs {
+		ids[id] = true
+	}
+	return ids
+}
+
+// getFileID returns the id of the file for its owner, the same for all
+// the users receiving the file.
+func getFileID(md *api.Metadata) string {
+	if md.ShareFileId != "" {
+		return md.ShareFileId
+	}
+	if md.MigId != "" {
+		return md.MigId
+	}
+	return md.Id
+}
+
+func getItemType(md *api.Metadata) api.FolderShare_ItemType {
+	if md.IsDir {
+		return api.FolderShare_FOLDER
+	}
+	return api.FolderShare_FILE
+}
+
+func getUserFromContext(ctx context.Context) (*api.User, error) {
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		return nil, api.NewError(api.ContextUserRequiredError)
+	}
+	return u, nil
+}
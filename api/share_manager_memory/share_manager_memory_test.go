@@ -0,0 +1,14 @@
+package share_manager_memory
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.TestShareManager(t, func(t *testing.T, vfs api.VirtualStorage, um api.UserManager) api.ShareManager {
+		return New(vfs, um)
+	})
+}
@@ -17,11 +17,20 @@ import (
 	"go.uber.org/zap"
 )
 
+// createSuspendedTable creates the table keeping the ids of the suspended
+// shares and public links, that oc_share has no column for.
+const createSuspendedTable = `create table if not exists oc_share_suspended (
+	id int not null primary key
+)`
+
 func New(dbUsername, dbPassword, dbHost string, dbPort int, dbName string, vfs api.VirtualStorage, um api.UserManager) (api.ShareManager, error) {
 	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", dbUsername, dbPassword, dbHost, dbPort, dbName))
 	if err != nil {
 		return nil, err
 	}
+	if _, err := db.Exec(createSuspendedTable); err != nil {
+		return nil, err
+	}
 
 	return &shareManager{db: db, vfs: vfs, um: um}, nil
 }
@@ -32,6 +41,14 @@ type shareManager struct {
 	um  api.UserManager
 }
 
+const (
+	shareTypeUser  = 0
+	shareTypeGroup = 1
+	// shareTypeUserGroup is the share type of the state of a group share
+	// for one of the members of the group, its parent is the group share.
+	shareTypeUserGroup = 2
+)
+
 func (sm *shareManager) UnmountReceivedShare(ctx context.Context, id string) error {
 	u, err := getUserFromContext(ctx)
 	if err != nil {
@@ -49,31 +66,87 @@ func (sm *shareManager) UnmountReceivedShare(ctx context.Context, id string) err
 }
 
 func (sm *shareManager) rejectShare(ctx context.Context, receiver, id string) error {
-	intID, err := strconv.ParseInt(id, 10, 64)
+	dbShare, err := sm.getDBShareWithMe(ctx, receiver, id)
 	if err != nil {
-		err = errors.Wrapf(err, "cannot parse id to int64: id=%s", id)
+		err = errors.Wrapf(err, "error getting share: id=%s user=%s", id, receiver)
 		return err
 	}
 
-	_, err = sm.getDBShareWithMe(ctx, receiver, id)
+	err = sm.setShareState(receiver, dbShare, api.FolderShare_REJECTED, dbShare.FileTarget)
 	if err != nil {
-		err = errors.Wrapf(err, "error getting share: id=%s user=%s", id, receiver)
+		err = errors.Wrapf(err, "error updating db: id=%s", id)
 		return err
 	}
+	return nil
+}
 
-	query := "insert into oc_share_acl(id, rejected_by) values(?, ?)"
-	stmt, err := sm.db.Prepare(query)
+func (sm *shareManager) MountReceivedShare(ctx context.Context, id, target string) (*api.FolderShare, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
 	if err != nil {
-		err = errors.Wrapf(err, "error preparing statement: id=%s", id)
-		return err
+		l.Error("", zap.Error(err))
+		return nil, err
 	}
 
-	_, err = stmt.Exec(intID, receiver)
+	dbShare, err := sm.getDBShareWithMe(ctx, u.AccountId, id)
+	if err != nil {
+		l.Error("cannot get db share", zap.Error(err), zap.String("id", id), zap.String("user", u.AccountId))
+		return nil, err
+	}
+
+	if target == "" {
+		target = dbShare.FileTarget
+	} else if target, err = api.GetShareTarget(target); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	if err := sm.setShareState(u.AccountId, dbShare, api.FolderShare_ACCEPTED, target); err != nil {
+		l.Error("error updating db", zap.Error(err), zap.String("id", id))
+		return nil, err
+	}
+	l.Info("mounted received share", zap.String("share_id", id), zap.String("target", target))
+
+	return sm.GetReceivedFolderShare(ctx, id)
+}
+
+// setShareState records the state and the target of the share for the recipient,
+// on the share itself for a user and on a usergroup share for a member of a group,
+// like ownCloud does. The rejections made before the states are dropped.
+func (sm *shareManager) setShareState(accountID string, dbShare *dbShare, state api.FolderShare_State, target string) error {
+	tx, err := sm.db.Begin()
 	if err != nil {
-		err = errors.Wrapf(err, "error updating db: id=%s", id)
 		return err
 	}
-	return nil
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("delete from oc_share_acl where id=? and rejected_by=?", dbShare.ID, accountID); err != nil {
+		return err
+	}
+
+	if dbShare.ShareType == shareTypeUser {
+		if _, err := tx.Exec("update oc_share set accepted=?, file_target=? where id=?", int(state), target, dbShare.ID); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	var count int
+	if err := tx.QueryRow("select count(*) from oc_share where parent=? and share_type=? and share_with=?", dbShare.ID, shareTypeUserGroup, accountID).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		if _, err := tx.Exec("update oc_share set accepted=?, file_target=? where parent=? and share_type=? and share_with=?", int(state), target, dbShare.ID, shareTypeUserGroup, accountID); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	stmtString := "insert into oc_share set share_type=?,parent=?,share_with=?,uid_owner=?,uid_initiator=?,item_type=?,fileid_prefix=?,item_source=?,file_source=?,permissions=?,stime=?,file_target=?,accepted=?"
+	if _, err := tx.Exec(stmtString, shareTypeUserGroup, dbShare.ID, accountID, dbShare.UIDOwner, dbShare.UIDInitiator, dbShare.ItemType, dbShare.Prefix, dbShare.ItemSource, dbShare.ItemSource, dbShare.Permissions, time.Now().Unix(), target, int(state)); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (sm *shareManager) GetReceivedFolderShare(ctx context.Context, id string) (*api.FolderShare, error) {
@@ -137,11 +210,7 @@ func (sm *shareManager) ListFolderShares(ctx context.Context, filterByPath strin
 			return nil, err
 		}
 
-		if md.MigId != "" {
-			shareID = md.MigId
-		} else {
-			shareID = md.Id
-		}
+		shareID = getFileID(md)
 	}
 
 	dbShares, err := sm.getDBShares(ctx, u.AccountId, shareID)
@@ -162,7 +231,7 @@ func (sm *shareManager) ListFolderShares(ctx context.Context, filterByPath strin
 	return shares, nil
 }
 
-func (sm *shareManager) UpdateFolderShare(ctx context.Context, id string, updateReadOnly, readOnly bool) (*api.FolderShare, error) {
+func (sm *shareManager) UpdateFolderShare(ctx context.Context, id string, opt *api.FolderShareOptions) (*api.FolderShare, error) {
 	l := ctx_zap.Extract(ctx)
 	u, err := getUserFromContext(ctx)
 	if err != nil {
@@ -176,21 +245,30 @@ func (sm *shareManager) UpdateFolderShare(ctx context.Context, id string, update
 		return nil, err
 	}
 
-	md, err := sm.vfs.GetMetadata(ctx, share.Path)
-	if err != nil {
-		l.Error("", zap.Error(err))
-		return nil, err
-	}
-
 	stmtString := "update oc_share set "
 	stmtPairs := map[string]interface{}{}
 
-	if updateReadOnly {
-		if readOnly {
-			stmtPairs["permissions"] = 1
-		} else {
-			stmtPairs["permissions"] = 15
+	if opt.UpdatePermissions {
+		if err := api.CheckSharePermissions(opt.Permissions, share.ItemType); err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
 		}
+		permissions := opt.Permissions.ForItemType(share.ItemType)
+		if share.ParentId != "" {
+			parent, err := sm.getDBShareByID(ctx, share.ParentId)
+			if err != nil && !api.IsErrorCode(err, api.FolderShareNotFoundErrorCode) {
+				l.Error("", zap.Error(err))
+				return nil, err
+			}
+			if parent != nil {
+				permissions &= api.SharePermissions(parent.Permissions)
+			}
+		}
+		stmtPairs["permissions"] = uint32(permissions)
+	}
+
+	if opt.UpdateExpiration {
+		stmtPairs["expiration"] = getExpiration(opt.Expiration)
 	}
 
 	if len(stmtPairs) == 0 { // nothing to update
@@ -205,43 +283,79 @@ func (sm *shareManager) UpdateFolderShare(ctx context.Context, id string, update
 		stmtValues = append(stmtValues, v)
 	}
 
-	stmtString += strings.Join(stmtTail, ",") + " where uid_owner=? and id=?"
-	stmtValues = append(stmtValues, u.AccountId, id)
+	stmtString += strings.Join(stmtTail, ",") + " where (uid_owner=? or uid_initiator=?) and id=?"
+	stmtValues = append(stmtValues, u.AccountId, u.AccountId, id)
 
-	stmt, err := sm.db.Prepare(stmtString)
-	if err != nil {
-		l.Error("", zap.Error(err))
-		return nil, err
+	if !opt.UpdatePermissions { // the acl does not change
+		if _, err := sm.db.Exec(stmtString, stmtValues...); err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		l.Info("updated oc share")
+		return sm.GetFolderShare(ctx, id)
 	}
 
-	_, err = stmt.Exec(stmtValues...)
+	tree, err := sm.getShareTree(ctx, share)
 	if err != nil {
 		l.Error("", zap.Error(err))
 		return nil, err
 	}
-	l.Info("updated oc share")
 
-	share, err = sm.GetFolderShare(ctx, id)
-	if err != nil {
-		l.Error("", zap.Error(err))
+	// the re-shares cannot grant more than the share
+	granted := map[string]api.SharePermissions{share.Id: api.SharePermissions(stmtPairs["permissions"].(uint32))}
+	for _, s := range tree[1:] {
+		granted[s.Id] = api.GetSharePermissions(s) & granted[s.ParentId]
+	}
+
+	// the acls are set first and restored if any fails, the db is only
+	// changed once the storage has all of them
+	for i, s := range tree {
+		if err := sm.vfs.SetACL(ownerContext(ctx, s.OwnerId), s.Path, granted[s.Id], s.Recipient, []*api.FolderShare{}); err != nil {
+			l.Error("error setting acl on storage, restoring the previous ones", zap.Error(err), zap.String("share_id", s.Id))
+			sm.restoreACLs(ctx, tree[:i])
+			return nil, err
+		}
+		l.Info("share commited on storage acl", zap.String("share_id", s.Id))
+	}
+
+	if err := sm.updatePermissions(stmtString, stmtValues, tree[1:], granted); err != nil {
+		l.Error("error updating share permissions, restoring the previous acls", zap.Error(err), zap.String("share_id", share.Id))
+		sm.restoreACLs(ctx, tree)
 		return nil, err
 	}
+	l.Info("updated oc share")
+	return sm.GetFolderShare(ctx, id)
+}
 
-	//  update acl on the storage
-	err = sm.vfs.SetACL(ctx, md.Path, share.ReadOnly, share.Recipient, []*api.FolderShare{})
+// updatePermissions runs the update of the share and sets the permissions
+// granted to its re-shares, all or none of them.
+func (sm *shareManager) updatePermissions(stmtString string, stmtValues []interface{}, reshares []*api.FolderShare, granted map[string]api.SharePermissions) error {
+	tx, err := sm.db.Begin()
 	if err != nil {
-		l.Error("error setting acl on storage, rollbacking operation", zap.Error(err))
-		err2 := sm.Unshare(ctx, share.Id)
-		if err2 != nil {
-			l.Error("cannot remove non commited share, fix manually", zap.Error(err2), zap.String("share_id", share.Id))
-			return nil, err2
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(stmtString, stmtValues...); err != nil {
+		return err
+	}
+	for _, s := range reshares {
+		if _, err := tx.Exec("update oc_share set permissions=? where id=?", uint32(granted[s.Id]), s.Id); err != nil {
+			return err
 		}
-		return nil, err
 	}
+	return tx.Commit()
+}
 
-	l.Info("share commited on storage acl", zap.String("share_id", share.Id))
-	return share, nil
+// restoreACLs sets again the acls of the shares as they were before an update.
+func (sm *shareManager) restoreACLs(ctx context.Context, shares []*api.FolderShare) {
+	l := ctx_zap.Extract(ctx)
+	for _, s := range shares {
+		if err := sm.vfs.SetACL(ownerContext(ctx, s.OwnerId), s.Path, api.GetSharePermissions(s), s.Recipient, []*api.FolderShare{}); err != nil {
+			l.Error("cannot restore acl of share on storage, fix manually", zap.Error(err), zap.String("share_id", s.Id))
+		}
+	}
 }
+
 func (sm *shareManager) Unshare(ctx context.Context, id string) error {
 	l := ctx_zap.Extract(ctx)
 	u, err := getUserFromContext(ctx)
@@ -256,13 +370,19 @@ func (sm *shareManager) Unshare(ctx context.Context, id string) error {
 		return err
 	}
 
-	stmt, err := sm.db.Prepare("delete from oc_share where uid_owner=? and id=?")
+	tree, err := sm.getShareTree(ctx, share)
 	if err != nil {
 		l.Error("", zap.Error(err))
 		return err
 	}
 
-	res, err := stmt.Exec(u.AccountId, id)
+	stmt, err := sm.db.Prepare("delete from oc_share where (uid_owner=? or uid_initiator=?) and id=?")
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	res, err := stmt.Exec(u.AccountId, u.AccountId, id)
 	if err != nil {
 		l.Error("", zap.Error(err))
 		return err
@@ -280,16 +400,277 @@ func (sm *shareManager) Unshare(ctx context.Context, id string) error {
 		return err
 	}
 
+	// the re-shares and the states of the members of a group go with the share
+	for _, s := range tree {
+		if err := sm.deleteDBShare(s.Id); err != nil {
+			l.Error("", zap.Error(err), zap.String("share_id", s.Id))
+			return err
+		}
+	}
+
 	// re-set acl on the storage
-	err = sm.vfs.UnsetACL(ctx, share.Path, share.Recipient, []*api.FolderShare{})
+	var firstErr error
+	for _, s := range tree {
+		err = sm.vfs.UnsetACL(ownerContext(ctx, s.OwnerId), s.Path, s.Recipient, []*api.FolderShare{})
+		if err != nil {
+			l.Error("error removing acl on storage, fix manually", zap.Error(err), zap.String("share_id", s.Id))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		l.Info("share removed from storage acl", zap.String("share_id", s.Id))
+	}
+
+	return firstErr
+}
+
+func (sm *shareManager) ExpireFolderShares(ctx context.Context) ([]*api.FolderShare, error) {
+	l := ctx_zap.Extract(ctx)
+	rows, err := sm.db.Query("select id, uid_owner from oc_share where (share_type=? or share_type=?) and expiration is not null and expiration<=? and id not in (select id from oc_share_suspended) order by id", 0, 1, time.Now())
 	if err != nil {
-		l.Error("error removing acl on storage, fix manually", zap.Error(err))
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	// the re-shares come after their shares, so an expired re-share of an
+	// expired share is removed with it
+	type expiredShare struct{ id, owner string }
+	expired := []expiredShare{}
+	for rows.Next() {
+		var e expiredShare
+		if err := rows.Scan(&e.id, &e.owner); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		expired = append(expired, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	shares := []*api.FolderShare{}
+	removed := map[string]bool{}
+	for _, e := range expired {
+		id := e.id
+		if removed[id] { // removed with its parent
+			continue
+		}
+		share, err := sm.GetFolderShare(ownerContext(ctx, e.owner), id)
+		if err != nil {
+			l.Error("", zap.Error(err), zap.String("share_id", id))
+			continue
+		}
+		tree, err := sm.getShareTree(ctx, share)
+		if err != nil {
+			l.Error("", zap.Error(err), zap.String("share_id", id))
+			continue
+		}
+
+		// the acls are removed first, so the share is expired again
+		// at the next sweep if it fails
+		failed := false
+		for _, s := range tree {
+			if err := sm.vfs.UnsetACL(ownerContext(ctx, s.OwnerId), s.Path, s.Recipient, []*api.FolderShare{}); err != nil {
+				l.Error("error removing acl of expired share on storage", zap.Error(err), zap.String("share_id", s.Id))
+				failed = true
+				break
+			}
+		}
+		if failed {
+			continue
+		}
+		for _, s := range tree {
+			if err := sm.deleteDBShare(s.Id); err != nil {
+				l.Error("error removing expired share", zap.Error(err), zap.String("share_id", s.Id))
+				return nil, err
+			}
+			removed[s.Id] = true
+			l.Info("expired share removed from storage acl", zap.String("share_id", s.Id))
+		}
+		shares = append(shares, tree...)
+	}
+	return shares, nil
+}
+
+// SuspendFolderShares keeps the ids of the suspended shares in oc_share_suspended.
+func (sm *shareManager) SuspendFolderShares(ctx context.Context, fileIDs []string, suspended bool) ([]*api.FolderShare, error) {
+	l := ctx_zap.Extract(ctx)
+	shares := []*api.FolderShare{}
+	for _, fileID := range fileIDs {
+		prefix, itemSource := splitFileID(fileID)
+		dbShares, err := sm.queryAllDBShares("and fileid_prefix=? and item_source=?", prefix, itemSource)
+		if err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		for _, dbShare := range dbShares {
+			if dbShare.Suspended == suspended {
+				continue
+			}
+			if err := setSuspended(sm.db, dbShare.ID, suspended); err != nil {
+				l.Error("error changing share suspension", zap.Error(err), zap.Int("share_id", dbShare.ID))
+				return nil, err
+			}
+			dbShare.Suspended = suspended
+			share, err := sm.convertToFolderShare(ctx, dbShare)
+			if err != nil {
+				return nil, err
+			}
+			shares = append(shares, share)
+		}
+	}
+	return shares, nil
+}
+
+// setSuspended suspends, or reactivates if suspended is false, the share with the id.
+func setSuspended(db *sql.DB, id int, suspended bool) error {
+	if suspended {
+		_, err := db.Exec("insert ignore into oc_share_suspended (id) values (?)", id)
 		return err
 	}
+	_, err := db.Exec("delete from oc_share_suspended where id=?", id)
+	return err
+}
 
-	l.Info("share removed from storage acl", zap.String("share_id", share.Id))
+func (sm *shareManager) PurgeFolderShares(ctx context.Context, fileIDs []string) ([]*api.FolderShare, error) {
+	l := ctx_zap.Extract(ctx)
+	shares := []*api.FolderShare{}
+	removed := map[string]bool{}
+	for _, fileID := range fileIDs {
+		prefix, itemSource := splitFileID(fileID)
+		dbShares, err := sm.queryAllDBShares("and fileid_prefix=? and item_source=?", prefix, itemSource)
+		if err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		for _, dbShare := range dbShares {
+			share, err := sm.convertToFolderShare(ctx, dbShare)
+			if err != nil {
+				return nil, err
+			}
+			if removed[share.Id] { // removed with its parent
+				continue
+			}
+			// the re-shares are on the file or under it, so gone with it
+			tree, err := sm.getShareTree(ctx, share)
+			if err != nil {
+				l.Error("", zap.Error(err), zap.String("share_id", share.Id))
+				return nil, err
+			}
+			for _, s := range tree {
+				if err := sm.deleteDBShare(s.Id); err != nil {
+					l.Error("error purging share", zap.Error(err), zap.String("share_id", s.Id))
+					return nil, err
+				}
+				removed[s.Id] = true
+			}
+			shares = append(shares, tree...)
+		}
+	}
+	return shares, nil
+}
 
-	return nil
+func (sm *shareManager) ListAllFolderShares(ctx context.Context, owner string) ([]*api.FolderShare, error) {
+	cond, args := "", []interface{}{}
+	if owner != "" {
+		cond, args = "and uid_owner=?", append(args, owner)
+	}
+	dbShares, err := sm.queryAllDBShares(cond, args...)
+	if err != nil {
+		return nil, err
+	}
+	shares := []*api.FolderShare{}
+	for _, dbShare := range dbShares {
+		share, err := sm.convertToFolderShare(ctx, dbShare)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+	return shares, nil
+}
+
+func (sm *shareManager) TransferFolderShares(ctx context.Context, fileIDs []string, from, to string) ([]*api.FolderShare, error) {
+	l := ctx_zap.Extract(ctx)
+	shares := []*api.FolderShare{}
+	for _, fileID := range fileIDs {
+		prefix, itemSource := splitFileID(fileID)
+		dbShares, err := sm.queryAllDBShares("and uid_owner=? and fileid_prefix=? and item_source=?", from, prefix, itemSource)
+		if err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		for _, dbShare := range dbShares {
+			if dbShare.UIDInitiator == from {
+				dbShare.UIDInitiator = to
+			}
+			dbShare.UIDOwner = to
+			// the usergroup shares copy the owner and the initiator of their share
+			stmtString := "update oc_share set uid_owner=?, uid_initiator=? where id=? or (parent=? and share_type=?)"
+			if _, err := sm.db.Exec(stmtString, dbShare.UIDOwner, dbShare.UIDInitiator, dbShare.ID, dbShare.ID, shareTypeUserGroup); err != nil {
+				l.Error("error transferring share", zap.Error(err), zap.Int("share_id", dbShare.ID))
+				return nil, err
+			}
+			share, err := sm.convertToFolderShare(ctx, dbShare)
+			if err != nil {
+				return nil, err
+			}
+			shares = append(shares, share)
+		}
+	}
+	return shares, nil
+}
+
+// queryAllDBShares returns the shares of all the users matching the condition,
+// without the usergroup shares keeping the states of the members of the groups.
+func (sm *shareManager) queryAllDBShares(cond string, args ...interface{}) ([]*dbShare, error) {
+	query := "select id, coalesce(uid_owner, '') as uid_owner, coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, stime, permissions, share_type, coalesce(item_type, '') as item_type, coalesce(unix_timestamp(expiration), 0) as expiration, coalesce(uid_initiator, '') as uid_initiator, coalesce(parent, 0) as parent, id in (select id from oc_share_suspended) as suspended from oc_share where (share_type=? or share_type=?) " + cond + " order by id"
+	rows, err := sm.db.Query(query, append([]interface{}{shareTypeUser, shareTypeGroup}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dbShares := []*dbShare{}
+	for rows.Next() {
+		s := &dbShare{}
+		if err := rows.Scan(&s.ID, &s.UIDOwner, &s.ShareWith, &s.Prefix, &s.ItemSource, &s.STime, &s.Permissions, &s.ShareType, &s.ItemType, &s.Expiration, &s.UIDInitiator, &s.Parent, &s.Suspended); err != nil {
+			return nil, err
+		}
+		dbShares = append(dbShares, s)
+	}
+	return dbShares, rows.Err()
+}
+
+// deleteDBShare removes the share with its usergroup shares and its suspension.
+func (sm *shareManager) deleteDBShare(id string) error {
+	if _, err := sm.db.Exec("delete from oc_share where id=? or (parent=? and share_type=?)", id, id, shareTypeUserGroup); err != nil {
+		return err
+	}
+	_, err := sm.db.Exec("delete from oc_share_suspended where id=?", id)
+	return err
+}
+
+// getShareTree returns the share followed by its re-shares, recursively.
+func (sm *shareManager) getShareTree(ctx context.Context, share *api.FolderShare) ([]*api.FolderShare, error) {
+	tree := []*api.FolderShare{share}
+	children, err := sm.getDBChildShares(ctx, share.Id)
+	if err != nil {
+		return nil, err
+	}
+	for _, dbShare := range children {
+		child, err := sm.convertToFolderShare(ctx, dbShare)
+		if err != nil {
+			return nil, err
+		}
+		subtree, err := sm.getShareTree(ctx, child)
+		if err != nil {
+			return nil, err
+		}
+		tree = append(tree, subtree...)
+	}
+	return tree, nil
 }
 
 func (sm *shareManager) GetFolderShare(ctx context.Context, id string) (*api.FolderShare, error) {
@@ -314,7 +695,7 @@ func (sm *shareManager) GetFolderShare(ctx context.Context, id string) (*api.Fol
 	return share, nil
 }
 
-func (sm *shareManager) AddFolderShare(ctx context.Context, p string, recipient *api.ShareRecipient, readOnly bool) (*api.FolderShare, error) {
+func (sm *shareManager) AddFolderShare(ctx context.Context, p string, recipient *api.ShareRecipient, opt *api.FolderShareOptions) (*api.FolderShare, error) {
 	l := ctx_zap.Extract(ctx)
 	u, err := getUserFromContext(ctx)
 	if err != nil {
@@ -327,25 +708,17 @@ func (sm *shareManager) AddFolderShare(ctx context.Context, p string, recipient
 		return nil, err
 	}
 
-	// TODO(labkode): use another error cde
+	itemType := "folder"
 	if !md.IsDir {
-		return nil, api.NewError(api.StorageNotSupportedErrorCode)
+		itemType = "file"
 	}
-
-	itemType := "folder"
-
-	permissions := 15
-	if readOnly {
-		permissions = 1
+	if err := api.CheckSharePermissions(opt.Permissions, getItemType(itemType)); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
 	}
+	permissions := opt.Permissions.ForItemType(getItemType(itemType))
 
-	var prefix string
-	var itemSource string
-	if md.MigId != "" {
-		prefix, itemSource = splitFileID(md.MigId)
-	} else {
-		prefix, itemSource = splitFileID(md.Id)
-	}
+	prefix, itemSource := splitFileID(getFileID(md))
 
 	fileSource, err := strconv.ParseUint(itemSource, 10, 64)
 	if err != nil {
@@ -353,15 +726,41 @@ func (sm *shareManager) AddFolderShare(ctx context.Context, p string, recipient
 		return nil, err
 	}
 
-	shareType := 0 // user
+	shareType := shareTypeUser
 	if recipient.Type == api.ShareRecipient_GROUP {
-		shareType = 1
+		shareType = shareTypeGroup
 	}
 
 	targetPath := path.Join("/", path.Base(p))
 
-	stmtString := "insert into oc_share set share_type=?,uid_owner=?,uid_initiator=?,item_type=?,fileid_prefix=?,item_source=?,file_source=?,permissions=?,stime=?,share_with=?,file_target=?"
-	stmtValues := []interface{}{shareType, u.AccountId, u.AccountId, itemType, prefix, itemSource, fileSource, permissions, time.Now().Unix(), recipient.Identity, targetPath}
+	owner := u.AccountId
+	var parent *api.FolderShare
+	if md.ShareId != "" { // re-share of a received share
+		parent, err = sm.GetReceivedFolderShare(ctx, md.ShareId)
+		if err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		permissions, err = api.GetResharePermissions(parent, permissions)
+		if err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		owner = parent.OwnerId
+	}
+
+	stmtString := "insert into oc_share set share_type=?,uid_owner=?,uid_initiator=?,item_type=?,fileid_prefix=?,item_source=?,file_source=?,permissions=?,stime=?,share_with=?,file_target=?,accepted=?"
+	stmtValues := []interface{}{shareType, owner, u.AccountId, itemType, prefix, itemSource, fileSource, uint32(permissions), time.Now().Unix(), recipient.Identity, targetPath, int(api.FolderShare_PENDING)}
+
+	if parent != nil {
+		stmtString += ",parent=?"
+		stmtValues = append(stmtValues, parent.Id)
+	}
+
+	if opt.Expiration != 0 {
+		stmtString += ",expiration=?"
+		stmtValues = append(stmtValues, getExpiration(opt.Expiration))
+	}
 
 	stmt, err := sm.db.Prepare(stmtString)
 	if err != nil {
@@ -388,7 +787,7 @@ func (sm *shareManager) AddFolderShare(ctx context.Context, p string, recipient
 	}
 
 	// set acl on the storage
-	err = sm.vfs.SetACL(ctx, p, readOnly, recipient, []*api.FolderShare{})
+	err = sm.vfs.SetACL(ownerContext(ctx, owner), share.Path, permissions, recipient, []*api.FolderShare{})
 	if err != nil {
 		l.Error("error setting acl on storage, rollbacking operation", zap.Error(err))
 		err2 := sm.Unshare(ctx, share.Id)
@@ -426,129 +825,87 @@ type ocShare struct {
 */
 
 type dbShare struct {
-	ID          int
-	UIDOwner    string
-	Prefix      string
-	ItemSource  string
-	ShareWith   string
-	Permissions int
-	ShareType   int
-	STime       int
-	FileTarget  string
-	State       int
+	ID           int
+	UIDOwner     string
+	Prefix       string
+	ItemSource   string
+	ShareWith    string
+	Permissions  int
+	ShareType    int
+	STime        int
+	FileTarget   string
+	State        int
+	ItemType     string
+	Expiration   int64
+	UIDInitiator string
+	Parent       int // 0 if the share is not a re-share
+	Suspended    bool
 }
 
 func (sm *shareManager) getDBShareWithMe(ctx context.Context, accountID, id string) (*dbShare, error) {
-	l := ctx_zap.Extract(ctx)
-	intID, err := strconv.ParseInt(id, 10, 64)
-	if err != nil {
-		l.Error("cannot parse id to int64", zap.Error(err))
-		return nil, err
-	}
-
-	var (
-		uidOwner    string
-		shareWith   string
-		prefix      string
-		itemSource  string
-		shareType   int
-		stime       int
-		permissions int
-		fileTarget  string
-		state       int
-	)
-
-	groups, err := sm.um.GetUserGroups(ctx, accountID)
+	query, queryArgs, err := sm.getReceivedQuery(ctx, accountID)
 	if err != nil {
-		l.Error("", zap.Error(err))
 		return nil, err
 	}
 
-	queryArgs := []interface{}{id, accountID}
-	groupArgs := []interface{}{}
-	for _, v := range groups {
-		groupArgs = append(groupArgs, v)
-	}
-
-	var query string
-
-	if len(groups) > 1 {
-		query = "select coalesce(uid_owner, '') as uid_owner, coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, stime, permissions, share_type, file_target, accepted from oc_share where id=? and (accepted=0 or accepted=1) and (share_with=? or share_with in (?" + strings.Repeat(",?", len(groups)-1) + ")) and id not in (select distinct(id) from oc_share_acl where rejected_by=?)"
-		queryArgs = append(queryArgs, groupArgs...)
-		queryArgs = append(queryArgs, accountID)
-	} else {
-		query = "select coalesce(uid_owner, '') as uid_owner, coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, stime, permissions, share_type, file_target, accepted from oc_share where id=? and (accepted=0 or accepted=1) and (share_with=?) and id not in (select distinct(id) from oc_share_acl where rejected_by=?)"
-		queryArgs = append(queryArgs, accountID)
-	}
-
-	if err := sm.db.QueryRow(query, queryArgs...).Scan(&uidOwner, &shareWith, &prefix, &itemSource, &stime, &permissions, &shareType, &fileTarget, &state); err != nil {
+	s := &dbShare{}
+	if err := sm.db.QueryRow(query+" and s.id=?", append(queryArgs, id)...).Scan(&s.ID, &s.UIDOwner, &s.ShareWith, &s.Prefix, &s.ItemSource, &s.STime, &s.Permissions, &s.ShareType, &s.FileTarget, &s.State, &s.ItemType, &s.Expiration, &s.UIDInitiator, &s.Parent); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, api.NewError(api.FolderShareNotFoundErrorCode)
 		}
 		return nil, err
 	}
-	dbShare := &dbShare{ID: int(intID), UIDOwner: uidOwner, Prefix: prefix, ItemSource: itemSource, ShareWith: shareWith, STime: stime, Permissions: permissions, ShareType: shareType, FileTarget: fileTarget, State: state}
-	return dbShare, nil
-
+	return s, nil
 }
 
 func (sm *shareManager) getDBSharesWithMe(ctx context.Context, accountID string) ([]*dbShare, error) {
-	l := ctx_zap.Extract(ctx)
-	groups, err := sm.um.GetUserGroups(ctx, accountID)
+	query, queryArgs, err := sm.getReceivedQuery(ctx, accountID)
 	if err != nil {
-		l.Error("", zap.Error(err))
 		return nil, err
 	}
-	queryArgs := []interface{}{0, 1, accountID, accountID}
-	groupArgs := []interface{}{}
-	for _, v := range groups {
-		groupArgs = append(groupArgs, v)
-	}
-
-	var query string
 
-	if len(groups) > 1 {
-		query = "select id, coalesce(uid_owner, '') as uid_owner, coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, stime, permissions, share_type, file_target from oc_share where (accepted=0 or accepted=1) and (share_type=? or share_type=?) and uid_owner!=? and (share_with=? or share_with in (?" + strings.Repeat(",?", len(groups)-1) + ")) and id not in (select distinct(id) from oc_share_acl where rejected_by=?)"
-		queryArgs = append(queryArgs, groupArgs...)
-		queryArgs = append(queryArgs, accountID)
-	} else {
-		query = "select id, coalesce(uid_owner, '') as uid_owner, coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, stime, permissions, share_type, file_target from oc_share where (accepted=0 or accepted=1) and (share_type=? or share_type=?) and uid_owner!=? and (share_with=?) and id not in (select distinct(id) from oc_share_acl where rejected_by=?)"
-		queryArgs = append(queryArgs, accountID)
-	}
-	rows, err := sm.db.Query(query, queryArgs...)
+	rows, err := sm.db.Query(query+" order by s.id", queryArgs...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var (
-		id          int
-		uidOwner    string
-		shareWith   string
-		prefix      string
-		itemSource  string
-		shareType   int
-		stime       int
-		permissions int
-		fileTarget  string
-	)
-
 	dbShares := []*dbShare{}
 	for rows.Next() {
-		err := rows.Scan(&id, &uidOwner, &shareWith, &prefix, &itemSource, &stime, &permissions, &shareType, &fileTarget)
-		if err != nil {
+		s := &dbShare{}
+		if err := rows.Scan(&s.ID, &s.UIDOwner, &s.ShareWith, &s.Prefix, &s.ItemSource, &s.STime, &s.Permissions, &s.ShareType, &s.FileTarget, &s.State, &s.ItemType, &s.Expiration, &s.UIDInitiator, &s.Parent); err != nil {
 			return nil, err
 		}
-		dbShare := &dbShare{ID: id, UIDOwner: uidOwner, Prefix: prefix, ItemSource: itemSource, ShareWith: shareWith, STime: stime, Permissions: permissions, ShareType: shareType, FileTarget: fileTarget}
-		dbShares = append(dbShares, dbShare)
-
+		dbShares = append(dbShares, s)
 	}
-	err = rows.Err()
+	return dbShares, rows.Err()
+}
+
+// getReceivedQuery returns the query of the shares with the user or its groups,
+// with their state and target for the user. The state of a group share for a
+// member is in its usergroup share, and the share is rejected if the member
+// rejected it before the states.
+func (sm *shareManager) getReceivedQuery(ctx context.Context, accountID string) (string, []interface{}, error) {
+	groups, err := sm.um.GetUserGroups(ctx, accountID)
 	if err != nil {
-		return nil, err
+		return "", nil, err
+	}
+
+	query := "select s.id, coalesce(s.uid_owner, ''), coalesce(s.share_with, ''), coalesce(s.fileid_prefix, ''), coalesce(s.item_source, ''), s.stime, s.permissions, s.share_type, coalesce(us.file_target, s.file_target), " +
+		"case when s.id in (select id from oc_share_acl where rejected_by=?) then 2 else coalesce(us.accepted, s.accepted) end, " +
+		"coalesce(s.item_type, ''), coalesce(unix_timestamp(s.expiration), 0), coalesce(s.uid_initiator, ''), coalesce(s.parent, 0) " +
+		"from oc_share s left join oc_share us on us.parent=s.id and us.share_type=? and us.share_with=? " +
+		"where (s.share_type=? or s.share_type=?) and s.uid_owner!=? and coalesce(s.uid_initiator, '')!=? and (s.share_with=?"
+	queryArgs := []interface{}{accountID, shareTypeUserGroup, accountID, shareTypeUser, shareTypeGroup, accountID, accountID, accountID}
+	if len(groups) > 0 {
+		query += " or s.share_with in (?" + strings.Repeat(",?", len(groups)-1) + ")"
+		for _, g := range groups {
+			queryArgs = append(queryArgs, g)
+		}
 	}
-
-	return dbShares, nil
+	query += ") and (s.expiration is null or s.expiration>?) and s.id not in (select id from oc_share_suspended)"
+	queryArgs = append(queryArgs, time.Now())
+	return query, queryArgs, nil
 }
 
 func (sm *shareManager) getDBShare(ctx context.Context, accountID, id string) (*dbShare, error) {
@@ -567,23 +924,28 @@ func (sm *shareManager) getDBShare(ctx context.Context, accountID, id string) (*
 		shareType   int
 		stime       int
 		permissions int
+		itemType    string
+		expiration  int64
+		initiator   string
+		parent      int
+		suspended   bool
 	)
 
-	query := "select coalesce(uid_owner, '') as uid_owner, coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, stime, permissions, share_type from oc_share where uid_owner=? and id=?"
-	if err := sm.db.QueryRow(query, accountID, id).Scan(&uidOwner, &shareWith, &prefix, &itemSource, &stime, &permissions, &shareType); err != nil {
+	query := "select coalesce(uid_owner, '') as uid_owner, coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, stime, permissions, share_type, coalesce(item_type, '') as item_type, coalesce(unix_timestamp(expiration), 0) as expiration, coalesce(uid_initiator, '') as uid_initiator, coalesce(parent, 0) as parent, id in (select id from oc_share_suspended) as suspended from oc_share where (uid_owner=? or uid_initiator=?) and id=?"
+	if err := sm.db.QueryRow(query, accountID, accountID, id).Scan(&uidOwner, &shareWith, &prefix, &itemSource, &stime, &permissions, &shareType, &itemType, &expiration, &initiator, &parent, &suspended); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, api.NewError(api.FolderShareNotFoundErrorCode)
 		}
 		return nil, err
 	}
-	dbShare := &dbShare{ID: int(intID), UIDOwner: uidOwner, Prefix: prefix, ItemSource: itemSource, ShareWith: shareWith, STime: stime, Permissions: permissions, ShareType: shareType}
+	dbShare := &dbShare{ID: int(intID), UIDOwner: uidOwner, Prefix: prefix, ItemSource: itemSource, ShareWith: shareWith, STime: stime, Permissions: permissions, ShareType: shareType, ItemType: itemType, Expiration: expiration, UIDInitiator: initiator, Parent: parent, Suspended: suspended}
 	return dbShare, nil
 
 }
 
 func (sm *shareManager) getDBShares(ctx context.Context, accountID, filterByFileID string) ([]*dbShare, error) {
-	query := "select id, coalesce(uid_owner, '') as uid_owner,  coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, stime, permissions, share_type from oc_share where uid_owner=? and (share_type=? or share_type=?) "
-	params := []interface{}{accountID, 0, 1}
+	query := "select id, coalesce(uid_owner, '') as uid_owner,  coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, stime, permissions, share_type, coalesce(item_type, '') as item_type, coalesce(unix_timestamp(expiration), 0) as expiration, coalesce(uid_initiator, '') as uid_initiator, coalesce(parent, 0) as parent, id in (select id from oc_share_suspended) as suspended from oc_share where (uid_owner=? or uid_initiator=?) and (share_type=? or share_type=?) "
+	params := []interface{}{accountID, accountID, 0, 1}
 	if filterByFileID != "" {
 		prefix, itemSource := splitFileID(filterByFileID)
 		query += "and fileid_prefix=? and item_source=?"
@@ -605,15 +967,20 @@ func (sm *shareManager) getDBShares(ctx context.Context, accountID, filterByFile
 		shareType   int
 		stime       int
 		permissions int
+		itemType    string
+		expiration  int64
+		initiator   string
+		parent      int
+		suspended   bool
 	)
 
 	dbShares := []*dbShare{}
 	for rows.Next() {
-		err := rows.Scan(&id, &uidOwner, &shareWith, &prefix, &itemSource, &stime, &permissions, &shareType)
+		err := rows.Scan(&id, &uidOwner, &shareWith, &prefix, &itemSource, &stime, &permissions, &shareType, &itemType, &expiration, &initiator, &parent, &suspended)
 		if err != nil {
 			return nil, err
 		}
-		dbShare := &dbShare{ID: id, UIDOwner: uidOwner, Prefix: prefix, ItemSource: itemSource, ShareWith: shareWith, STime: stime, Permissions: permissions, ShareType: shareType}
+		dbShare := &dbShare{ID: id, UIDOwner: uidOwner, Prefix: prefix, ItemSource: itemSource, ShareWith: shareWith, STime: stime, Permissions: permissions, ShareType: shareType, ItemType: itemType, Expiration: expiration, UIDInitiator: initiator, Parent: parent, Suspended: suspended}
 		dbShares = append(dbShares, dbShare)
 
 	}
@@ -625,6 +992,39 @@ func (sm *shareManager) getDBShares(ctx context.Context, accountID, filterByFile
 	return dbShares, nil
 }
 
+// getDBShareByID returns the share with the given id, whoever manages it.
+func (sm *shareManager) getDBShareByID(ctx context.Context, id string) (*dbShare, error) {
+	s := &dbShare{}
+	query := "select id, coalesce(uid_owner, '') as uid_owner, coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, stime, permissions, share_type, coalesce(item_type, '') as item_type, coalesce(unix_timestamp(expiration), 0) as expiration, coalesce(uid_initiator, '') as uid_initiator, coalesce(parent, 0) as parent, id in (select id from oc_share_suspended) as suspended from oc_share where id=?"
+	if err := sm.db.QueryRow(query, id).Scan(&s.ID, &s.UIDOwner, &s.ShareWith, &s.Prefix, &s.ItemSource, &s.STime, &s.Permissions, &s.ShareType, &s.ItemType, &s.Expiration, &s.UIDInitiator, &s.Parent, &s.Suspended); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, api.NewError(api.FolderShareNotFoundErrorCode)
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// getDBChildShares returns the re-shares of the share with the given id.
+func (sm *shareManager) getDBChildShares(ctx context.Context, id string) ([]*dbShare, error) {
+	query := "select id, coalesce(uid_owner, '') as uid_owner, coalesce(share_with, '') as share_with, coalesce(fileid_prefix, '') as fileid_prefix, coalesce(item_source, '') as item_source, stime, permissions, share_type, coalesce(item_type, '') as item_type, coalesce(unix_timestamp(expiration), 0) as expiration, coalesce(uid_initiator, '') as uid_initiator, coalesce(parent, 0) as parent, id in (select id from oc_share_suspended) as suspended from oc_share where parent=? and (share_type=? or share_type=?) order by id"
+	rows, err := sm.db.Query(query, id, shareTypeUser, shareTypeGroup)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dbShares := []*dbShare{}
+	for rows.Next() {
+		s := &dbShare{}
+		if err := rows.Scan(&s.ID, &s.UIDOwner, &s.ShareWith, &s.Prefix, &s.ItemSource, &s.STime, &s.Permissions, &s.ShareType, &s.ItemType, &s.Expiration, &s.UIDInitiator, &s.Parent, &s.Suspended); err != nil {
+			return nil, err
+		}
+		dbShares = append(dbShares, s)
+	}
+	return dbShares, rows.Err()
+}
+
 func (sm *shareManager) convertToReceivedFolderShare(ctx context.Context, dbShare *dbShare) (*api.FolderShare, error) {
 	var recipientType api.ShareRecipient_RecipientType
 	if dbShare.ShareType == 0 {
@@ -634,17 +1034,24 @@ func (sm *shareManager) convertToReceivedFolderShare(ctx context.Context, dbShar
 	}
 	path := joinFileID(dbShare.Prefix, dbShare.ItemSource)
 	share := &api.FolderShare{
-		OwnerId:  dbShare.UIDOwner,
-		Id:       fmt.Sprintf("%d", dbShare.ID),
-		Mtime:    uint64(dbShare.STime),
-		Path:     path,
-		ReadOnly: dbShare.Permissions == 1,
+		OwnerId:    dbShare.UIDOwner,
+		Id:         fmt.Sprintf("%d", dbShare.ID),
+		Mtime:      uint64(dbShare.STime),
+		Path:       path,
+		ItemType:   getItemType(dbShare.ItemType),
+		Expiration: uint64(dbShare.Expiration),
 		Recipient: &api.ShareRecipient{
 			Identity: dbShare.ShareWith,
 			Type:     recipientType,
 		},
-		Target: dbShare.FileTarget,
+		Target:      dbShare.FileTarget,
+		State:       api.FolderShare_State(dbShare.State),
+		InitiatorId: dbShare.UIDInitiator,
 	}
+	if dbShare.Parent != 0 {
+		share.ParentId = fmt.Sprintf("%d", dbShare.Parent)
+	}
+	api.SetSharePermissions(share, api.SharePermissions(dbShare.Permissions))
 	return share, nil
 
 }
@@ -659,20 +1066,60 @@ func (sm *shareManager) convertToFolderShare(ctx context.Context, dbShare *dbSha
 
 	path := joinFileID(dbShare.Prefix, dbShare.ItemSource)
 	share := &api.FolderShare{
-		OwnerId:  dbShare.UIDOwner,
-		Id:       fmt.Sprintf("%d", dbShare.ID),
-		Mtime:    uint64(dbShare.STime),
-		Path:     path,
-		ReadOnly: dbShare.Permissions == 1,
+		OwnerId:    dbShare.UIDOwner,
+		Id:         fmt.Sprintf("%d", dbShare.ID),
+		Mtime:      uint64(dbShare.STime),
+		Path:       path,
+		ItemType:   getItemType(dbShare.ItemType),
+		Expiration: uint64(dbShare.Expiration),
 		Recipient: &api.ShareRecipient{
 			Identity: dbShare.ShareWith,
 			Type:     recipientType,
 		},
+		InitiatorId: dbShare.UIDInitiator,
+		Suspended:   dbShare.Suspended,
+	}
+	if dbShare.Parent != 0 {
+		share.ParentId = fmt.Sprintf("%d", dbShare.Parent)
 	}
+	api.SetSharePermissions(share, api.SharePermissions(dbShare.Permissions))
 	return share, nil
 
 }
 
+// getExpiration returns the value of the expiration column,
+// that is null for the shares that do not expire.
+func getExpiration(expiration uint64) interface{} {
+	if expiration == 0 {
+		return nil
+	}
+	return time.Unix(int64(expiration), 0)
+}
+
+func getItemType(itemType string) api.FolderShare_ItemType {
+	if itemType == "file" {
+		return api.FolderShare_FILE
+	}
+	return api.FolderShare_FOLDER
+}
+
+// ownerContext returns the context to change the ACLs of the files of owner.
+func ownerContext(ctx context.Context, owner string) context.Context {
+	return api.ContextSetUser(ctx, &api.User{AccountId: owner})
+}
+
+// getFileID returns the id of the file for its owner, the same for all
+// the users receiving the file.
+func getFileID(md *api.Metadata) string {
+	if md.ShareFileId != "" {
+		return md.ShareFileId
+	}
+	if md.MigId != "" {
+		return md.MigId
+	}
+	return md.Id
+}
+
 func getUserFromContext(ctx context.Context) (*api.User, error) {
 	u, ok := api.ContextGetUser(ctx)
 	if !ok {
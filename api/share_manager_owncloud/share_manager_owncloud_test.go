@@ -0,0 +1,19 @@
+package share_manager_owncloud
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	db := conformance.GetMySQL(t)
+	conformance.TestShareManager(t, func(t *testing.T, vfs api.VirtualStorage, um api.UserManager) api.ShareManager {
+		sm, err := New(db.Username, db.Password, db.Host, db.Port, db.Name, vfs, um)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sm
+	})
+}
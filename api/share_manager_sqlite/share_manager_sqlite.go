@@ -0,0 +1,728 @@
+package share_manager_sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/sqlite_db"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+)
+
+// migrations of the schema, they are only ever appended.
+var migrations = []string{
+	`create table folder_shares (
+		id integer primary key autoincrement,
+		owner text not null,
+		share_type integer not null,
+		share_with text not null,
+		fileid_prefix text not null,
+		item_source text not null,
+		permissions integer not null,
+		stime integer not null,
+		file_target text not null
+	);
+	create index folder_shares_owner on folder_shares (owner, fileid_prefix, item_source);
+	create index folder_shares_share_with on folder_shares (share_with);
+	create table folder_share_rejections (
+		share_id integer not null,
+		rejected_by text not null,
+		primary key (share_id, rejected_by)
+	)`,
+	`alter table folder_shares add column item_type integer not null default 0`,
+	`alter table folder_shares add column expiration integer not null default 0;
+	create index folder_shares_expiration on folder_shares (expiration)`,
+	`alter table folder_shares add column initiator text not null default '';
+	alter table folder_shares add column parent integer not null default 0;
+	update folder_shares set initiator=owner;
+	create index folder_shares_initiator on folder_shares (initiator);
+	create index folder_shares_parent on folder_shares (parent)`,
+	`alter table folder_shares add column state integer not null default 0;
+	create table folder_share_mounts (
+		share_id integer not null,
+		account_id text not null,
+		share_state integer not null,
+		target text not null,
+		primary key (share_id, account_id)
+	);
+	insert into folder_share_mounts (share_id, account_id, share_state, target)
+		select share_id, rejected_by, 2, file_target from folder_share_rejections join folder_shares on id=share_id;
+	drop table folder_share_rejections`,
+	`alter table folder_shares add column suspended integer not null default 0`,
+}
+
+const (
+	shareTypeUser  = 0
+	shareTypeGroup = 1
+)
+
+// New returns a share manager that keeps the shares in the SQLite
+// database in file, with the same semantics as the ownCloud share manager.
+func New(file string, vfs api.VirtualStorage, um api.UserManager) (api.ShareManager, error) {
+	db, err := sqlite_db.Open(file, "share_manager", migrations)
+	if err != nil {
+		return nil, err
+	}
+	return &shareManager{db: db, vfs: vfs, um: um}, nil
+}
+
+type shareManager struct {
+	db  *sql.DB
+	vfs api.VirtualStorage
+	um  api.UserManager
+}
+
+type dbShare struct {
+	ID          int64
+	Owner       string
+	ShareType   int
+	ShareWith   string
+	Prefix      string
+	ItemSource  string
+	Permissions int
+	STime       int64
+	FileTarget  string
+	ItemType    int
+	Expiration  int64
+	Initiator   string
+	Parent      int64 // 0 if the share is not a re-share
+	State       int
+	Suspended   bool
+}
+
+const shareColumns = "id, owner, share_type, share_with, fileid_prefix, item_source, permissions, stime, file_target, item_type, expiration, initiator, parent, state, suspended"
+
+// receivedColumns are the columns of the shares for a recipient, the state
+// of the share is the default of the recipients that did not mount it.
+const receivedColumns = "id, owner, share_type, share_with, fileid_prefix, item_source, permissions, stime, coalesce(target, file_target), item_type, expiration, initiator, parent, coalesce(share_state, state), suspended"
+
+func (sm *shareManager) AddFolderShare(ctx context.Context, p string, recipient *api.ShareRecipient, opt *api.FolderShareOptions) (*api.FolderShare, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	md, err := sm.vfs.GetMetadata(ctx, p)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	prefix, itemSource := splitFileID(getFileID(md))
+	shareType := shareTypeUser
+	if recipient.Type == api.ShareRecipient_GROUP {
+		shareType = shareTypeGroup
+	}
+	itemType := api.FolderShare_FOLDER
+	if !md.IsDir {
+		itemType = api.FolderShare_FILE
+	}
+	if err := api.CheckSharePermissions(opt.Permissions, itemType); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	permissions := opt.Permissions.ForItemType(itemType)
+
+	owner, parentID := u.AccountId, int64(0)
+	if md.ShareId != "" { // re-share of a received share
+		parent, err := sm.GetReceivedFolderShare(ctx, md.ShareId)
+		if err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		if permissions, err = api.GetResharePermissions(parent, permissions); err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		parentID, _ = strconv.ParseInt(parent.Id, 10, 64)
+		owner = parent.OwnerId
+	}
+
+	query := "insert into folder_shares (owner, share_type, share_with, fileid_prefix, item_source, permissions, stime, file_target, item_type, expiration, initiator, parent, state) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := sm.db.Exec(query, owner, shareType, recipient.Identity, prefix, itemSource, uint32(permissions), time.Now().Unix(), path.Join("/", path.Base(p)), int(itemType), int64(opt.Expiration), u.AccountId, parentID, int(api.FolderShare_PENDING))
+	if err != nil {
+		l.Error("error inserting share", zap.Error(err))
+		return nil, err
+	}
+	lastID, err := result.LastInsertId()
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	l.Info("created share", zap.Int64("share_id", lastID))
+
+	share, err := sm.GetFolderShare(ctx, fmt.Sprintf("%d", lastID))
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	// set acl on the storage
+	if err := sm.vfs.SetACL(ownerContext(ctx, owner), share.Path, permissions, recipient, []*api.FolderShare{}); err != nil {
+		l.Error("error setting acl on storage, rollbacking operation", zap.Error(err))
+		if _, err2 := sm.db.Exec("delete from folder_shares where id=?", lastID); err2 != nil {
+			l.Error("cannot remove non commited share, fix manually", zap.Error(err2), zap.String("share_id", share.Id))
+			return nil, err2
+		}
+		return nil, err
+	}
+
+	l.Info("share commited on storage acl", zap.String("share_id", share.Id))
+	return share, nil
+}
+
+func (sm *shareManager) GetFolderShare(ctx context.Context, id string) (*api.FolderShare, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	intID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, api.NewError(api.FolderShareNotFoundErrorCode).WithMessage(id)
+	}
+
+	// the share is managed by its owner and by its initiator
+	shares, err := sm.queryShares("select "+shareColumns+" from folder_shares where (owner=? or initiator=?) and id=?", u.AccountId, u.AccountId, intID)
+	if err != nil {
+		return nil, err
+	}
+	if len(shares) == 0 {
+		return nil, api.NewError(api.FolderShareNotFoundErrorCode).WithMessage(id)
+	}
+	return convertToFolderShare(shares[0], false), nil
+}
+
+// getShareTree returns the share followed by its re-shares, recursively.
+func (sm *shareManager) getShareTree(share *api.FolderShare) ([]*api.FolderShare, error) {
+	tree := []*api.FolderShare{share}
+	children, err := sm.queryShares("select "+shareColumns+" from folder_shares where parent=? order by id", share.Id)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range children {
+		subtree, err := sm.getShareTree(convertToFolderShare(c, false))
+		if err != nil {
+			return nil, err
+		}
+		tree = append(tree, subtree...)
+	}
+	return tree, nil
+}
+
+func (sm *shareManager) Unshare(ctx context.Context, id string) error {
+	l := ctx_zap.Extract(ctx)
+	share, err := sm.GetFolderShare(ctx, id)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	tree, err := sm.getShareTree(share)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	for _, s := range tree {
+		if err := sm.deleteShare(s.Id); err != nil {
+			l.Error("", zap.Error(err))
+			return err
+		}
+	}
+
+	// re-set acl on the storage, the re-shares go with the share
+	var firstErr error
+	for _, s := range tree {
+		if err := sm.vfs.UnsetACL(ownerContext(ctx, s.OwnerId), s.Path, s.Recipient, []*api.FolderShare{}); err != nil {
+			l.Error("error removing acl on storage, fix manually", zap.Error(err), zap.String("share_id", s.Id))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		l.Info("share removed from storage acl", zap.String("share_id", s.Id))
+	}
+	return firstErr
+}
+
+func (sm *shareManager) UpdateFolderShare(ctx context.Context, id string, opt *api.FolderShareOptions) (*api.FolderShare, error) {
+	l := ctx_zap.Extract(ctx)
+	share, err := sm.GetFolderShare(ctx, id)
+	if err != nil {
+		l.Error("error getting share before update", zap.Error(err))
+		return nil, err
+	}
+
+	if !opt.UpdatePermissions && !opt.UpdateExpiration { // nothing to update
+		return share, nil
+	}
+	if opt.UpdatePermissions {
+		if err := api.CheckSharePermissions(opt.Permissions, share.ItemType); err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	permissions := api.GetSharePermissions(share)
+	if opt.UpdatePermissions {
+		permissions = opt.Permissions.ForItemType(share.ItemType)
+		if share.ParentId != "" {
+			parents, err := sm.queryShares("select "+shareColumns+" from folder_shares where id=?", share.ParentId)
+			if err != nil {
+				l.Error("", zap.Error(err))
+				return nil, err
+			}
+			if len(parents) > 0 {
+				permissions &= api.SharePermissions(parents[0].Permissions)
+			}
+		}
+	}
+	expiration := share.Expiration
+	if opt.UpdateExpiration {
+		expiration = opt.Expiration
+	}
+	if _, err := sm.db.Exec("update folder_shares set permissions=?, expiration=? where id=?", uint32(permissions), int64(expiration), share.Id); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	l.Info("updated share")
+
+	share, err = sm.GetFolderShare(ctx, id)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	if !opt.UpdatePermissions { // the acl does not change
+		return share, nil
+	}
+
+	tree, err := sm.getShareTree(share)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	// the re-shares cannot grant more than the share
+	granted := map[string]api.SharePermissions{share.Id: permissions}
+	for _, s := range tree[1:] {
+		p := api.GetSharePermissions(s) & granted[s.ParentId]
+		if _, err := sm.db.Exec("update folder_shares set permissions=? where id=?", uint32(p), s.Id); err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		api.SetSharePermissions(s, p)
+		granted[s.Id] = p
+	}
+
+	//  update the acls of the share and its re-shares on the storage
+	for _, s := range tree {
+		if err := sm.vfs.SetACL(ownerContext(ctx, s.OwnerId), s.Path, granted[s.Id], s.Recipient, []*api.FolderShare{}); err != nil {
+			l.Error("error setting acl on storage", zap.Error(err), zap.String("share_id", s.Id))
+			return nil, err
+		}
+		l.Info("share commited on storage acl", zap.String("share_id", s.Id))
+	}
+	return share, nil
+}
+
+func (sm *shareManager) ListFolderShares(ctx context.Context, filterByPath string) ([]*api.FolderShare, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "select " + shareColumns + " from folder_shares where (owner=? or initiator=?)"
+	args := []interface{}{u.AccountId, u.AccountId}
+	if filterByPath != "" {
+		md, err := sm.vfs.GetMetadata(ctx, filterByPath)
+		if err != nil {
+			return nil, err
+		}
+		prefix, itemSource := splitFileID(getFileID(md))
+		query += " and fileid_prefix=? and item_source=?"
+		args = append(args, prefix, itemSource)
+	}
+
+	dbShares, err := sm.queryShares(query+" order by id", args...)
+	if err != nil {
+		return nil, err
+	}
+	shares := []*api.FolderShare{}
+	for _, s := range dbShares {
+		shares = append(shares, convertToFolderShare(s, false))
+	}
+	return shares, nil
+}
+
+func (sm *shareManager) ListReceivedShares(ctx context.Context) ([]*api.FolderShare, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := sm.getReceivedQuery(ctx, u.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	dbShares, err := sm.queryShares(query+" order by id", args...)
+	if err != nil {
+		return nil, err
+	}
+	shares := []*api.FolderShare{}
+	for _, s := range dbShares {
+		shares = append(shares, convertToFolderShare(s, true))
+	}
+	return shares, nil
+}
+
+func (sm *shareManager) GetReceivedFolderShare(ctx context.Context, id string) (*api.FolderShare, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	intID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, api.NewError(api.FolderShareNotFoundErrorCode).WithMessage(id)
+	}
+
+	query, args, err := sm.getReceivedQuery(ctx, u.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	dbShares, err := sm.queryShares(query+" and id=?", append(args, intID)...)
+	if err != nil {
+		return nil, err
+	}
+	if len(dbShares) == 0 {
+		return nil, api.NewError(api.FolderShareNotFoundErrorCode).WithMessage(id)
+	}
+	return convertToFolderShare(dbShares[0], true), nil
+}
+
+func (sm *shareManager) UnmountReceivedShare(ctx context.Context, id string) error {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	share, err := sm.GetReceivedFolderShare(ctx, id)
+	if err != nil {
+		return err
+	}
+	return sm.setMount(share.Id, u.AccountId, api.FolderShare_REJECTED, share.Target)
+}
+
+func (sm *shareManager) MountReceivedShare(ctx context.Context, id, target string) (*api.FolderShare, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	share, err := sm.GetReceivedFolderShare(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if target == "" {
+		target = share.Target
+	} else if target, err = api.GetShareTarget(target); err != nil {
+		return nil, err
+	}
+	if err := sm.setMount(share.Id, u.AccountId, api.FolderShare_ACCEPTED, target); err != nil {
+		return nil, err
+	}
+	return sm.GetReceivedFolderShare(ctx, id)
+}
+
+// setMount records the state and target of the share for the recipient.
+func (sm *shareManager) setMount(id, accountID string, state api.FolderShare_State, target string) error {
+	_, err := sm.db.Exec("insert or replace into folder_share_mounts (share_id, account_id, share_state, target) values (?, ?, ?, ?)", id, accountID, int(state), target)
+	return err
+}
+
+// getReceivedQuery returns the query of the shares with the user or its groups,
+// with their state and target for the user.
+func (sm *shareManager) getReceivedQuery(ctx context.Context, accountID string) (string, []interface{}, error) {
+	groups, err := sm.um.GetUserGroups(ctx, accountID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	query := "select " + receivedColumns + " from folder_shares left join folder_share_mounts on share_id=id and account_id=? where owner!=? and initiator!=? and ((share_type=? and share_with=?)"
+	args := []interface{}{accountID, accountID, accountID, shareTypeUser, accountID}
+	if len(groups) > 0 {
+		query += " or (share_type=? and share_with in (?" + strings.Repeat(",?", len(groups)-1) + "))"
+		args = append(args, shareTypeGroup)
+		for _, g := range groups {
+			args = append(args, g)
+		}
+	}
+	query += ") and (expiration=0 or expiration>?) and suspended=0"
+	args = append(args, time.Now().Unix())
+	return query, args, nil
+}
+
+func (sm *shareManager) ExpireFolderShares(ctx context.Context) ([]*api.FolderShare, error) {
+	l := ctx_zap.Extract(ctx)
+	dbShares, err := sm.queryShares("select "+shareColumns+" from folder_shares where expiration!=0 and expiration<=? and suspended=0 order by id", time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	shares := []*api.FolderShare{}
+	removed := map[string]bool{}
+	for _, s := range dbShares {
+		share := convertToFolderShare(s, false)
+		if removed[share.Id] { // removed with its parent
+			continue
+		}
+		tree, err := sm.getShareTree(share)
+		if err != nil {
+			return nil, err
+		}
+
+		// the acls are removed first, so the share is expired again
+		// at the next sweep if it fails
+		failed := false
+		for _, s := range tree {
+			if err := sm.vfs.UnsetACL(ownerContext(ctx, s.OwnerId), s.Path, s.Recipient, []*api.FolderShare{}); err != nil {
+				l.Error("error removing acl of expired share on storage", zap.Error(err), zap.String("share_id", s.Id))
+				failed = true
+				break
+			}
+		}
+		if failed {
+			continue
+		}
+		for _, s := range tree {
+			if err := sm.deleteShare(s.Id); err != nil {
+				l.Error("error removing expired share", zap.Error(err), zap.String("share_id", s.Id))
+				return nil, err
+			}
+			removed[s.Id] = true
+			l.Info("expired share removed from storage acl", zap.String("share_id", s.Id))
+		}
+		shares = append(shares, tree...)
+	}
+	return shares, nil
+}
+
+func (sm *shareManager) SuspendFolderShares(ctx context.Context, fileIDs []string, suspended bool) ([]*api.FolderShare, error) {
+	if len(fileIDs) == 0 {
+		return []*api.FolderShare{}, nil
+	}
+	cond, args := fileIDsCondition(fileIDs)
+	dbShares, err := sm.queryShares("select "+shareColumns+" from folder_shares where suspended=? and "+cond+" order by id", append([]interface{}{!suspended}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	shares := []*api.FolderShare{}
+	for _, s := range dbShares {
+		if _, err := sm.db.Exec("update folder_shares set suspended=? where id=?", suspended, s.ID); err != nil {
+			return nil, err
+		}
+		s.Suspended = suspended
+		shares = append(shares, convertToFolderShare(s, false))
+	}
+	return shares, nil
+}
+
+func (sm *shareManager) PurgeFolderShares(ctx context.Context, fileIDs []string) ([]*api.FolderShare, error) {
+	l := ctx_zap.Extract(ctx)
+	if len(fileIDs) == 0 {
+		return []*api.FolderShare{}, nil
+	}
+	cond, args := fileIDsCondition(fileIDs)
+	dbShares, err := sm.queryShares("select "+shareColumns+" from folder_shares where "+cond+" order by id", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := []*api.FolderShare{}
+	removed := map[string]bool{}
+	for _, s := range dbShares {
+		share := convertToFolderShare(s, false)
+		if removed[share.Id] { // removed with its parent
+			continue
+		}
+		// the re-shares are on the file or under it, so gone with it
+		tree, err := sm.getShareTree(share)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range tree {
+			if err := sm.deleteShare(s.Id); err != nil {
+				l.Error("error purging share", zap.Error(err), zap.String("share_id", s.Id))
+				return nil, err
+			}
+			removed[s.Id] = true
+		}
+		shares = append(shares, tree...)
+	}
+	return shares, nil
+}
+
+func (sm *shareManager) ListAllFolderShares(ctx context.Context, owner string) ([]*api.FolderShare, error) {
+	query := "select " + shareColumns + " from folder_shares "
+	args := []interface{}{}
+	if owner != "" {
+		query += "where owner=? "
+		args = append(args, owner)
+	}
+	dbShares, err := sm.queryShares(query+"order by id", args...)
+	if err != nil {
+		return nil, err
+	}
+	shares := []*api.FolderShare{}
+	for _, s := range dbShares {
+		shares = append(shares, convertToFolderShare(s, false))
+	}
+	return shares, nil
+}
+
+func (sm *shareManager) TransferFolderShares(ctx context.Context, fileIDs []string, from, to string) ([]*api.FolderShare, error) {
+	if len(fileIDs) == 0 {
+		return []*api.FolderShare{}, nil
+	}
+	cond, args := fileIDsCondition(fileIDs)
+	dbShares, err := sm.queryShares("select "+shareColumns+" from folder_shares where owner=? and "+cond+" order by id", append([]interface{}{from}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	shares := []*api.FolderShare{}
+	for _, s := range dbShares {
+		if s.Initiator == from {
+			s.Initiator = to
+		}
+		if _, err := sm.db.Exec("update folder_shares set owner=?, initiator=? where id=?", to, s.Initiator, s.ID); err != nil {
+			return nil, err
+		}
+		s.Owner = to
+		shares = append(shares, convertToFolderShare(s, false))
+	}
+	return shares, nil
+}
+
+// deleteShare removes the share with its mounts.
+func (sm *shareManager) deleteShare(id string) error {
+	tx, err := sm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec("delete from folder_shares where id=?", id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("delete from folder_share_mounts where share_id=?", id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (sm *shareManager) queryShares(query string, args ...interface{}) ([]*dbShare, error) {
+	rows, err := sm.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shares := []*dbShare{}
+	for rows.Next() {
+		s := &dbShare{}
+		if err := rows.Scan(&s.ID, &s.Owner, &s.ShareType, &s.ShareWith, &s.Prefix, &s.ItemSource, &s.Permissions, &s.STime, &s.FileTarget, &s.ItemType, &s.Expiration, &s.Initiator, &s.Parent, &s.State, &s.Suspended); err != nil {
+			return nil, err
+		}
+		shares = append(shares, s)
+	}
+	return shares, rows.Err()
+}
+
+func convertToFolderShare(s *dbShare, received bool) *api.FolderShare {
+	recipientType := api.ShareRecipient_USER
+	if s.ShareType == shareTypeGroup {
+		recipientType = api.ShareRecipient_GROUP
+	}
+	share := &api.FolderShare{
+		OwnerId:    s.Owner,
+		Id:         fmt.Sprintf("%d", s.ID),
+		Mtime:      uint64(s.STime),
+		Path:       joinFileID(s.Prefix, s.ItemSource),
+		ItemType:   api.FolderShare_ItemType(s.ItemType),
+		Expiration: uint64(s.Expiration),
+		Recipient: &api.ShareRecipient{
+			Identity: s.ShareWith,
+			Type:     recipientType,
+		},
+		InitiatorId: s.Initiator,
+		Suspended:   s.Suspended,
+	}
+	if s.Parent != 0 {
+		share.ParentId = fmt.Sprintf("%d", s.Parent)
+	}
+	api.SetSharePermissions(share, api.SharePermissions(s.Permissions))
+	if received {
+		share.Target = s.FileTarget
+		share.State = api.FolderShare_State(s.State)
+	}
+	return share
+}
+
+// ownerContext returns the context to change the ACLs of the files of owner.
+func ownerContext(ctx context.Context, owner string) context.Context {
+	return api.ContextSetUser(ctx, &api.User{AccountId: owner})
+}
+
+// getFileID returns the id of the file for its owner, the same for all
+// the users receiving the file.
+func getFileID(md *api.Metadata) string {
+	if md.ShareFileId != "" {
+		return md.ShareFileId
+	}
+	if md.MigId != "" {
+		return md.MigId
+	}
+	return md.Id
+}
+
+func getUserFromContext(ctx context.Context) (*api.User, error) {
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		return nil, api.NewError(api.ContextUserRequiredError)
+	}
+	return u, nil
+}
+
+// splitFileID returns the two parts of a fileID.
+// A fileID like home:1234 will be separated into the prefix (home) and the inode(1234).
+func splitFileID(fileID string) (string, string) {
+	tokens := strings.SplitN(fileID, ":", 2)
+	if len(tokens) == 1 {
+		return "", tokens[0]
+	}
+	return tokens[0], tokens[1]
+}
+
+// fileIDsCondition returns the condition matching the shares of the files fileIDs.
+func fileIDsCondition(fileIDs []string) (string, []interface{}) {
+	conds := []string{}
+	args := []interface{}{}
+	for _, fileID := range fileIDs {
+		prefix, itemSource := splitFileID(fileID)
+		conds = append(conds, "(fileid_prefix=? and item_source=?)")
+		args = append(args, prefix, itemSource)
+	}
+	return "(" + strings.Join(conds, " or ") + ")", args
+}
+
+// joinFileID concatenates the prefix and the inode to form a valid fileID.
+func joinFileID(prefix, inode string) string {
+	return strings.Join([]string{prefix, inode}, ":")
+}
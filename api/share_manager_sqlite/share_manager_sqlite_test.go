@@ -0,0 +1,18 @@
+package share_manager_sqlite
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.TestShareManager(t, func(t *testing.T, vfs api.VirtualStorage, um api.UserManager) api.ShareManager {
+		sm, err := New("", vfs, um)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sm
+	})
+}
@@ -0,0 +1,78 @@
+// Package sqlite_db opens the embedded SQLite databases used by the managers
+// that run without an external database, and keeps their schemas up to date.
+package sqlite_db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Open opens the SQLite database in file, or a private in-memory database if
+// file is empty, and applies the migrations of component that were not applied
+// yet. Several components can share the same file, the version of the schema
+// of each one is kept in the schema_migrations table.
+//
+// Migrations are only ever appended, a released migration must not change.
+func Open(file, component string, migrations []string) (*sql.DB, error) {
+	dsn := "file::memory:"
+	if file != "" {
+		dsn = "file:" + file + "?_busy_timeout=5000&_journal_mode=WAL"
+	}
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+	// a single connection serializes the writes, that SQLite
+	// does anyway, and keeps an in-memory database alive.
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db, component, migrations); err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "error migrating schema of %s in %s", component, file)
+	}
+	return db, nil
+}
+
+func migrate(db *sql.DB, component string, migrations []string) error {
+	if _, err := db.Exec("create table if not exists schema_migrations (component text primary key, version integer not null)"); err != nil {
+		return err
+	}
+
+	var version int
+	err := db.QueryRow("select version from schema_migrations where component=?", component).Scan(&version)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("schema version %d is newer than the %d known migrations", version, len(migrations))
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		for _, stmt := range strings.Split(migrations[i], ";") {
+			if strings.TrimSpace(stmt) == "" {
+				continue
+			}
+			if _, err := tx.Exec(stmt); err != nil {
+				tx.Rollback()
+				return errors.Wrapf(err, "error applying migration %d", i+1)
+			}
+		}
+		if _, err := tx.Exec("insert or replace into schema_migrations (component, version) values (?, ?)", component, i+1); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package sqlite_db
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestMigrations(t *testing.T) {
+	folder, err := ioutil.TempDir("", "sqlite-db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(folder)
+	file := path.Join(folder, "reva.db")
+
+	migrations := []string{"create table a (id integer primary key)"}
+	db, err := Open(file, "a", migrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("insert into a (id) values (1)"); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	// applied migrations are skipped and the new ones applied on top
+	migrations = append(migrations, "alter table a add column name text not null default 'x'; create index a_name on a (name)")
+	db, err = Open(file, "a", migrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var name string
+	if err := db.QueryRow("select name from a where id=1").Scan(&name); err != nil || name != "x" {
+		t.Fatalf("expected migrated row, got %q %v", name, err)
+	}
+	db.Close()
+
+	// other components keep their own version in the same file
+	db, err = Open(file, "b", []string{"create table b (id integer primary key)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	if _, err := Open(file, "a", migrations[:1]); err == nil {
+		t.Fatal("expected error opening a schema newer than the known migrations")
+	}
+}
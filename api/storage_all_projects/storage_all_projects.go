@@ -179,7 +179,7 @@ func (fs *allProjectsStorage) getProjectMetadata(ctx context.Context, project *a
 	return md, nil
 }
 
-func (fs *allProjectsStorage) SetACL(ctx context.Context, name string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (fs *allProjectsStorage) SetACL(ctx context.Context, name string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	project, relPath, err := fs.getProject(ctx, name)
 	if err != nil {
 		return err
@@ -200,7 +200,7 @@ func (fs *allProjectsStorage) SetACL(ctx context.Context, name string, readOnly
 
 	newCtx := api.ContextSetUser(ctx, &api.User{AccountId: project.Owner})
 	targetPath := path.Join(md.Path, relPath)
-	return fs.vs.SetACL(newCtx, targetPath, readOnly, recipient, shareList)
+	return fs.vs.SetACL(newCtx, targetPath, permissions, recipient, shareList)
 }
 
 func (fs *allProjectsStorage) UnsetACL(ctx context.Context, name string, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
@@ -227,7 +227,7 @@ func (fs *allProjectsStorage) UnsetACL(ctx context.Context, name string, recipie
 	return fs.vs.UnsetACL(newCtx, targetPath, recipient, shareList)
 }
 
-func (fs *allProjectsStorage) UpdateACL(ctx context.Context, name string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (fs *allProjectsStorage) UpdateACL(ctx context.Context, name string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	project, relPath, err := fs.getProject(ctx, name)
 	if err != nil {
 		return err
@@ -248,7 +248,7 @@ func (fs *allProjectsStorage) UpdateACL(ctx context.Context, name string, readOn
 
 	newCtx := api.ContextSetUser(ctx, &api.User{AccountId: project.Owner})
 	targetPath := path.Join(md.Path, relPath)
-	return fs.vs.UpdateACL(newCtx, targetPath, readOnly, recipient, shareList)
+	return fs.vs.UpdateACL(newCtx, targetPath, permissions, recipient, shareList)
 }
 
 func (fs *allProjectsStorage) getProjectPath(ctx context.Context, project *api.Project, relPath string) string {
@@ -184,7 +184,12 @@ func (c *Client) parseVersion(ctx context.Context, raw string) eosVersion {
 //This is followed by the rule definition.
 //Every ACL flag can be added with + or removed with -, or in case
 //of setting new ACL permission just enter the ACL flag.
-func (c *Client) addACLCitrine(ctx context.Context, username, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (c *Client) addACLCitrine(ctx context.Context, username, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+	finfo, err := c.GetFileInfoByPath(ctx, username, path)
+	if err != nil {
+		return err
+	}
+
 	var target = recipient.Identity
 	if recipient.Type == api.ShareRecipient_USER {
 		unixUser, err := getUnixUser(target)
@@ -195,7 +200,7 @@ func (c *Client) addACLCitrine(ctx context.Context, username, path string, readO
 	}
 
 	aclType := getAclType(recipient.Type)
-	perm := getEosPerm(readOnly)
+	perm := getEosPerm(permissions)
 
 	// setting of the sys.acl is only possible from root user
 	unixUser, err := getUnixUser(rootUser)
@@ -203,37 +208,42 @@ func (c *Client) addACLCitrine(ctx context.Context, username, path string, readO
 		return err
 	}
 
-	cmd := exec.CommandContext(ctx, "/usr/bin/eos", "-r", unixUser.Uid, unixUser.Gid, "acl", "--sys", "--recursive", fmt.Sprintf("%s:%s=%s", aclType, target, perm), path)
+	args := []string{"-r", unixUser.Uid, unixUser.Gid, "acl", "--sys"}
+	if finfo.IsDir {
+		args = append(args, "--recursive")
+	}
+	args = append(args, fmt.Sprintf("%s:%s=%s", aclType, target, perm), path)
+	cmd := exec.CommandContext(ctx, "/usr/bin/eos", args...)
 	_, _, err = c.execute(cmd)
 	return err
 }
 
-func (c *Client) AddACL(ctx context.Context, username, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (c *Client) AddACL(ctx context.Context, username, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	version, err := c.getVersion(ctx)
 	if err != nil {
 		return err
 	}
 
 	if version == versionCitrine {
-		return c.addACLCitrine(ctx, username, path, readOnly, recipient, shareList)
+		return c.addACLCitrine(ctx, username, path, permissions, recipient, shareList)
 	}
 
-	aclManager, err := c.getACLForPath(ctx, username, path)
+	aclManager, finfo, err := c.getACLForPath(ctx, username, path)
 	if err != nil {
 		return err
 	}
 
 	switch recipient.Type {
 	case api.ShareRecipient_USER:
-		if err := aclManager.addUser(ctx, recipient.Identity, readOnly); err != nil {
+		if err := aclManager.addUser(ctx, recipient.Identity, permissions); err != nil {
 			return err
 		}
 	case api.ShareRecipient_GROUP:
-		if err := aclManager.addGroup(ctx, recipient.Identity, readOnly); err != nil {
+		if err := aclManager.addGroup(ctx, recipient.Identity, permissions); err != nil {
 			return err
 		}
 	case api.ShareRecipient_UNIX:
-		if err := aclManager.addUnixGroup(ctx, recipient.Identity, readOnly); err != nil {
+		if err := aclManager.addUnixGroup(ctx, recipient.Identity, permissions); err != nil {
 			return err
 		}
 	}
@@ -246,7 +256,12 @@ func (c *Client) AddACL(ctx context.Context, username, path string, readOnly boo
 		return err
 	}
 
-	cmd := exec.CommandContext(ctx, "/usr/bin/eos", "-r", unixUser.Uid, unixUser.Gid, "attr", "-r", "set", fmt.Sprintf("sys.acl=%s", sysAcl), path)
+	args := []string{"-r", unixUser.Uid, unixUser.Gid, "attr"}
+	if finfo.IsDir {
+		args = append(args, "-r")
+	}
+	args = append(args, "set", fmt.Sprintf("sys.acl=%s", sysAcl), path)
+	cmd := exec.CommandContext(ctx, "/usr/bin/eos", args...)
 	_, _, err = c.execute(cmd)
 	return err
 
@@ -262,7 +277,7 @@ func (c *Client) RemoveACL(ctx context.Context, username, path string, recipient
 		return c.removeACLCitrine(ctx, username, path, recipient, shareList)
 	}
 
-	aclManager, err := c.getACLForPath(ctx, username, path)
+	aclManager, finfo, err := c.getACLForPath(ctx, username, path)
 	if err != nil {
 		return err
 	}
@@ -284,13 +299,23 @@ func (c *Client) RemoveACL(ctx context.Context, username, path string, recipient
 		return err
 	}
 
-	cmd := exec.CommandContext(ctx, "/usr/bin/eos", "-r", unixUser.Uid, unixUser.Gid, "attr", "-r", "set", fmt.Sprintf("sys.acl=%s", sysAcl), path)
+	args := []string{"-r", unixUser.Uid, unixUser.Gid, "attr"}
+	if finfo.IsDir {
+		args = append(args, "-r")
+	}
+	args = append(args, "set", fmt.Sprintf("sys.acl=%s", sysAcl), path)
+	cmd := exec.CommandContext(ctx, "/usr/bin/eos", args...)
 	_, _, err = c.execute(cmd)
 	return err
 
 }
 
 func (c *Client) removeACLCitrine(ctx context.Context, username, path string, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+	finfo, err := c.GetFileInfoByPath(ctx, username, path)
+	if err != nil {
+		return err
+	}
+
 	var target = recipient.Identity
 	if recipient.Type == api.ShareRecipient_USER {
 		unixUser, err := getUnixUser(target)
@@ -309,23 +334,30 @@ func (c *Client) removeACLCitrine(ctx context.Context, username, path string, re
 		return err
 	}
 
-	cmd := exec.CommandContext(ctx, "/usr/bin/eos", "-r", unixUser.Uid, unixUser.Gid, "acl", "--sys", "--recursive", fmt.Sprintf("%s:%s=%s", aclType, target, perm), path)
+	args := []string{"-r", unixUser.Uid, unixUser.Gid, "acl", "--sys"}
+	if finfo.IsDir {
+		args = append(args, "--recursive")
+	}
+	args = append(args, fmt.Sprintf("%s:%s=%s", aclType, target, perm), path)
+	cmd := exec.CommandContext(ctx, "/usr/bin/eos", args...)
 	_, _, err = c.execute(cmd)
 	return err
 }
 
-func (c *Client) UpdateACL(ctx context.Context, username, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
-	return c.AddACL(ctx, username, path, readOnly, recipient, shareList)
+func (c *Client) UpdateACL(ctx context.Context, username, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+	return c.AddACL(ctx, username, path, permissions, recipient, shareList)
 }
 
-func (c *Client) getACLForPath(ctx context.Context, username, path string) (*aclManager, error) {
+// getACLForPath returns the sys.acl of path and its file info. The ACLs of
+// folders apply to their whole tree, the ones of files only to the file.
+func (c *Client) getACLForPath(ctx context.Context, username, path string) (*aclManager, *FileInfo, error) {
 	finfo, err := c.GetFileInfoByPath(ctx, username, path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	aclManager := c.newAclManager(ctx, finfo.SysACL)
-	return aclManager, nil
+	return aclManager, finfo, nil
 }
 
 // GetFileInfoByInode returns the FileInfo by the given inode
@@ -405,6 +437,44 @@ func (c *Client) Rename(ctx context.Context, username, oldPath, newPath string)
 	return err
 }
 
+// Chown makes owner the owner of the resource at path and of all the resources under it
+func (c *Client) Chown(ctx context.Context, owner, path string) error {
+	ownerUser, err := getUnixUser(owner)
+	if err != nil {
+		return err
+	}
+	// changing the owner is only possible from root user
+	unixUser, err := getUnixUser(rootUser)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, "/usr/bin/eos", "-r", unixUser.Uid, unixUser.Gid, "chown", "-r", ownerUser.Uid+":"+ownerUser.Gid, path)
+	_, _, err = c.execute(cmd)
+	return err
+}
+
+// SetQuota limits the bytes that owner can store in the quota node at path,
+// 0 removes the limit
+func (c *Client) SetQuota(ctx context.Context, owner, path string, maxBytes uint64) error {
+	ownerUser, err := getUnixUser(owner)
+	if err != nil {
+		return err
+	}
+	// the quota is only managed by root user
+	unixUser, err := getUnixUser(rootUser)
+	if err != nil {
+		return err
+	}
+	var cmd *exec.Cmd
+	if maxBytes == 0 {
+		cmd = exec.CommandContext(ctx, "/usr/bin/eos", "-r", unixUser.Uid, unixUser.Gid, "quota", "rm", "-u", ownerUser.Uid, "-p", path)
+	} else {
+		cmd = exec.CommandContext(ctx, "/usr/bin/eos", "-r", unixUser.Uid, unixUser.Gid, "quota", "set", "-u", ownerUser.Uid, "-v", strconv.FormatUint(maxBytes, 10), "-p", path)
+	}
+	_, _, err = c.execute(cmd)
+	return err
+}
+
 // List the contents of the directory given by path
 func (c *Client) List(ctx context.Context, username, path string) ([]*FileInfo, error) {
 	unixUser, err := getUnixUser(username)
@@ -957,10 +1027,10 @@ func (m *aclManager) deleteUser(ctx context.Context, username string) error {
 	return nil
 }
 
-func (m *aclManager) addUser(ctx context.Context, username string, readOnly bool) error {
+func (m *aclManager) addUser(ctx context.Context, username string, permissions api.SharePermissions) error {
 	m.deleteUser(ctx, username)
 
-	perm := getEosPerm(readOnly)
+	perm := getEosPerm(permissions)
 	sysAcl := strings.Join([]string{string(aclTypeUser), username, perm}, ":")
 	newEntry, err := newAclEntry(ctx, sysAcl)
 	if err != nil {
@@ -979,9 +1049,9 @@ func (m *aclManager) deleteGroup(ctx context.Context, group string) {
 	}
 }
 
-func (m *aclManager) addGroup(ctx context.Context, group string, readOnly bool) error {
+func (m *aclManager) addGroup(ctx context.Context, group string, permissions api.SharePermissions) error {
 	m.deleteGroup(ctx, group)
-	perm := getEosPerm(readOnly)
+	perm := getEosPerm(permissions)
 	sysAcl := strings.Join([]string{string(aclTypeGroup), group, perm}, ":")
 	newEntry, err := newAclEntry(ctx, sysAcl)
 	if err != nil {
@@ -1000,9 +1070,9 @@ func (m *aclManager) deleteUnixGroup(ctx context.Context, unixGroup string) {
 	}
 }
 
-func (m *aclManager) addUnixGroup(ctx context.Context, unixGroup string, readOnly bool) error {
+func (m *aclManager) addUnixGroup(ctx context.Context, unixGroup string, permissions api.SharePermissions) error {
 	m.deleteUnixGroup(ctx, unixGroup)
-	perm := getEosPerm(readOnly)
+	perm := getEosPerm(permissions)
 	sysAcl := strings.Join([]string{string(aclTypeUnixGroup), unixGroup, perm}, ":")
 	newEntry, err := newAclEntry(ctx, sysAcl)
 	if err != nil {
@@ -1012,11 +1082,23 @@ func (m *aclManager) addUnixGroup(ctx context.Context, unixGroup string, readOnl
 	return nil
 }
 
-func getEosPerm(readOnly bool) string {
-	if readOnly {
+// getEosPerm maps the ownCloud permissions to the EOS ACL flags:
+// w allows to create and update the files, !u forbids updating them and
+// +d/!d allow or forbid the deletions. EOS cannot grant updates without
+// creations, api.CheckSharePermissions refuses them on the folders and
+// on the files there is nothing to create.
+func getEosPerm(permissions api.SharePermissions) string {
+	if permissions&(api.SharePermissionCreate|api.SharePermissionUpdate) == 0 {
 		return "rx"
 	}
-	return "rwx+d"
+	perm := "rwx"
+	if !permissions.Has(api.SharePermissionUpdate) {
+		perm += "!u"
+	}
+	if permissions.Has(api.SharePermissionDelete) {
+		return perm + "+d"
+	}
+	return perm + "!d"
 }
 
 func (m *aclManager) serialize() string {
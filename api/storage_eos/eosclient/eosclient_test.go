@@ -73,7 +73,7 @@ func TestAddACL(t *testing.T) {
 		Identity: "labradorsvc",
 		Type:     api.ShareRecipient_USER,
 	}
-	err := client.AddACL(ctx, username, home, true, recipient, nil)
+	err := client.AddACL(ctx, username, home, api.SharePermissionsReadOnly, recipient, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -89,3 +89,22 @@ func TestRemoveACL(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestGetEosPerm(t *testing.T) {
+	tests := []struct {
+		permissions api.SharePermissions
+		perm        string
+	}{
+		{api.SharePermissionsReadOnly, "rx"},
+		{api.SharePermissionsReadOnly | api.SharePermissionShare, "rx"},
+		{api.SharePermissionsReadWrite, "rwx+d"},
+		{api.SharePermissionRead | api.SharePermissionUpdate | api.SharePermissionCreate, "rwx!d"},
+		{api.SharePermissionRead | api.SharePermissionCreate, "rwx!u!d"},
+		{api.SharePermissionRead | api.SharePermissionUpdate, "rwx!d"},
+	}
+	for _, tt := range tests {
+		if perm := getEosPerm(tt.permissions); perm != tt.perm {
+			t.Errorf("permissions %d: expected %q, got %q", tt.permissions, tt.perm, perm)
+		}
+	}
+}
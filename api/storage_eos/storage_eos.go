@@ -18,6 +18,11 @@ import (
 
 var hiddenReg = regexp.MustCompile(`\.sys\..#.`)
 
+const versionPrefix = ".sys.v#."
+
+// rootUser runs the operations on the files of several users.
+const rootUser = "root"
+
 func getUserFromContext(ctx context.Context) (*api.User, error) {
 	u, ok := api.ContextGetUser(ctx)
 	if !ok {
@@ -168,14 +173,14 @@ func (fs *eosStorage) GetPathByID(ctx context.Context, id string) (string, error
 	return fi.Path, nil
 }
 
-func (fs *eosStorage) SetACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (fs *eosStorage) SetACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	u, err := getUserFromContext(ctx)
 	if err != nil {
 		return err
 	}
 
 	path = fs.getInternalPath(ctx, path)
-	return fs.c.AddACL(ctx, u.AccountId, path, readOnly, recipient, shareList)
+	return fs.c.AddACL(ctx, u.AccountId, path, permissions, recipient, shareList)
 
 }
 
@@ -190,14 +195,14 @@ func (fs *eosStorage) UnsetACL(ctx context.Context, path string, recipient *api.
 
 }
 
-func (fs *eosStorage) UpdateACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (fs *eosStorage) UpdateACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	u, err := getUserFromContext(ctx)
 	if err != nil {
 		return err
 	}
 
 	path = fs.getInternalPath(ctx, path)
-	return fs.c.AddACL(ctx, u.AccountId, path, readOnly, recipient, shareList)
+	return fs.c.AddACL(ctx, u.AccountId, path, permissions, recipient, shareList)
 }
 
 func (fs *eosStorage) GetMetadata(ctx context.Context, path string) (*api.Metadata, error) {
@@ -229,6 +234,15 @@ func (fs *eosStorage) ListFolder(ctx context.Context, path string) ([]*api.Metad
 	if err != nil {
 		return nil, err
 	}
+	// the versions folders of the files are in the same listing,
+	// their IDs are returned with the files even when they are hidden.
+	versionFolders := map[string]string{}
+	for _, eosFileInfo := range eosFileInfos {
+		if base := gopath.Base(eosFileInfo.File); eosFileInfo.IsDir && strings.HasPrefix(base, versionPrefix) {
+			versionFolders[strings.TrimPrefix(base, versionPrefix)] = fmt.Sprintf("%d", eosFileInfo.Inode)
+		}
+	}
+
 	finfos := []*api.Metadata{}
 	for _, eosFileInfo := range eosFileInfos {
 		// filter out sys files
@@ -244,6 +258,9 @@ func (fs *eosStorage) ListFolder(ctx context.Context, path string) ([]*api.Metad
 		if fs.forceReadOnly {
 			finfo.IsReadOnly = true
 		}
+		if !finfo.IsDir {
+			finfo.VersionFolderId = versionFolders[gopath.Base(eosFileInfo.File)]
+		}
 
 		finfos = append(finfos, finfo)
 	}
@@ -287,6 +304,33 @@ func (fs *eosStorage) Move(ctx context.Context, oldPath, newPath string) error {
 	return fs.c.Rename(ctx, u.AccountId, oldPath, newPath)
 }
 
+// TransferOwnership changes the owner of the files before moving them, as
+// root since neither user can write in the home of the other. A transfer
+// stopped in between is completed by running it again.
+func (fs *eosStorage) TransferOwnership(ctx context.Context, from, fromPath, to, toPath string) error {
+	fromPath = fs.getInternalPath(ctx, fromPath)
+	toPath = fs.getInternalPath(ctx, toPath)
+	if err := fs.c.Chown(ctx, to, fromPath); err != nil {
+		return err
+	}
+	return fs.c.Rename(ctx, rootUser, fromPath, toPath)
+}
+
+// ProvisionDir creates the directory as root, as the owner may not be
+// allowed to write in its parent, and hands it over to the owner.
+func (fs *eosStorage) ProvisionDir(ctx context.Context, owner, p string) error {
+	p = fs.getInternalPath(ctx, p)
+	if err := fs.c.CreateDir(ctx, rootUser, p); err != nil {
+		return err
+	}
+	return fs.c.Chown(ctx, owner, p)
+}
+
+func (fs *eosStorage) SetQuota(ctx context.Context, owner, p string, maxBytes uint64) error {
+	p = fs.getInternalPath(ctx, p)
+	return fs.c.SetQuota(ctx, owner, p, maxBytes)
+}
+
 func (fs *eosStorage) Download(ctx context.Context, path string) (io.ReadCloser, error) {
 	u, err := getUserFromContext(ctx)
 	if err != nil {
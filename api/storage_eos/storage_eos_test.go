@@ -2,84 +2,112 @@ package storage_eos
 
 import (
 	"bytes"
-	"fmt"
+	"context"
 	"io/ioutil"
+	gopath "path"
 	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+	"github.com/cernbox/reva/api/storage_eos/eosclient"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
 )
 
-var ruid uint = 95491
-var rgid uint = 2763
+// TestConformance runs on the EOS instance at REVA_TEST_EOS_MGM_URL, in a new
+// folder under REVA_TEST_EOS_NAMESPACE for every test, writable by alice.
+func TestConformance(t *testing.T) {
+	env := conformance.GetEnv(t, "REVA_TEST_EOS_MGM_URL", "REVA_TEST_EOS_NAMESPACE")
+	conformance.TestStorage(t, func(t *testing.T) api.Storage {
+		s, err := New(&Options{Namespace: newTestFolder(t, env[0], env[1]), MasterURL: env[0], Logger: zap.NewNop()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	})
+}
 
-func TestEosFileInfoByPath(t *testing.T) {
-	i := &instance{mgmURL: "root://eosbackup.cern.ch"}
-	fi, err := i.eosFileInfoByPath(ruid, rgid, "/eos/scratch/user/g/gonzalhu/jeronimo")
+// newTestFolder creates a new folder under namespace, removed after the test.
+func newTestFolder(t *testing.T, mgmURL, namespace string) string {
+	root, err := New(&Options{Namespace: namespace, MasterURL: mgmURL, Logger: zap.NewNop()})
 	if err != nil {
-		fmt.Println(err)
-		t.Fail()
+		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", fi)
+	ctx := api.ContextSetUser(context.Background(), &api.User{AccountId: "alice"})
+	folder := "/reva-conformance-" + uuid.Must(uuid.NewV4()).String()
+	if err := root.CreateDir(ctx, folder); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { root.Delete(ctx, folder) })
+	return gopath.Join(namespace, folder)
 }
 
-func TestEosFind(t *testing.T) {
-	i := instance{mgmURL: "root://eosbackup.cern.ch"}
-	finfos, err := i.eosFind(ruid, rgid, "/eos/scratch/user/g/gonzalhu/jeronimo")
+// TestClient runs the commands of the EOS client the storage relies on,
+// on the same instance as TestConformance.
+func TestClient(t *testing.T) {
+	env := conformance.GetEnv(t, "REVA_TEST_EOS_MGM_URL", "REVA_TEST_EOS_NAMESPACE")
+	folder := newTestFolder(t, env[0], env[1])
+	c, err := eosclient.New(&eosclient.Options{URL: env[0], CacheDirectory: t.TempDir(), Logger: zap.NewNop()})
 	if err != nil {
-		fmt.Println(err)
-		t.Fail()
+		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", finfos)
-}
+	ctx := context.Background()
+	file := gopath.Join(folder, "hello_world.txt")
 
-func TestEosRead(t *testing.T) {
-	i := instance{mgmURL: "root://eosbackup.cern.ch", cacheDirectory: "/tmp"}
-	stream, err := i.eosRead(ruid, rgid, "/eos/scratch/user/g/gonzalhu/jeronimo/test1.txt")
-	if err != nil {
-		fmt.Println(err)
-		t.Fail()
-		return
+	for i := 0; i < 2; i++ {
+		b := ioutil.NopCloser(bytes.NewBufferString("hello world!"))
+		if err := c.Write(ctx, "alice", file, b); err != nil {
+			t.Fatal(err)
+		}
 	}
-	data, err := ioutil.ReadAll(stream)
+
+	fi, err := c.GetFileInfoByPath(ctx, "alice", file)
 	if err != nil {
-		fmt.Println(err)
-		t.Fail()
+		t.Fatal(err)
+	}
+	if fi.IsDir || fi.Size != uint64(len("hello world!")) {
+		t.Fatalf("unexpected file info %+v", fi)
 	}
-	fmt.Println(string(data))
-}
 
-func TestEosWrite(t *testing.T) {
-	i := instance{mgmURL: "root://eosbackup.cern.ch", cacheDirectory: "/tmp"}
-	b := ioutil.NopCloser(bytes.NewBufferString("hello world!"))
-	err := i.eosWrite(ruid, rgid, "/eos/scratch/user/g/gonzalhu/jeronimo/hello_world.txt", b)
+	stream, err := c.Read(ctx, "alice", file)
 	if err != nil {
-		fmt.Println(err)
-		t.Fail()
-		return
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(stream)
+	stream.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello world!" {
+		t.Fatalf("expected hello world!, got %q", data)
 	}
-}
 
-func TestEosListDeletedEntries(t *testing.T) {
-	i := instance{mgmURL: "root://eosbackup.cern.ch", cacheDirectory: "/tmp"}
-	entries, err := i.eosListDeletedEntries(ruid, rgid)
+	finfos, err := c.List(ctx, "alice", folder)
 	if err != nil {
-		fmt.Println(err)
-		t.Fail()
-		return
+		t.Fatal(err)
 	}
-	for _, e := range entries {
-		fmt.Println(e)
+	found := false
+	for _, fi := range finfos {
+		found = found || gopath.Base(fi.File) == "hello_world.txt"
+	}
+	if !found {
+		t.Fatalf("expected hello_world.txt in the listing of %s", folder)
 	}
-}
 
-func TestEosListVersions(t *testing.T) {
-	i := instance{mgmURL: "root://eosbackup.cern.ch", cacheDirectory: "/tmp"}
-	entries, err := i.eosListVersionsForFile(ruid, rgid, "/eos/scratch/user/g/gonzalhu/jeronimo/hello_world.txt")
+	// the second write keeps the first one as a version
+	versions, err := c.ListVersions(ctx, "alice", file)
 	if err != nil {
-		fmt.Println(err)
-		t.Fail()
-		return
+		t.Fatal(err)
 	}
-	for _, e := range entries {
-		fmt.Println(e.File)
+	if len(versions) == 0 {
+		t.Fatal("expected a version of the file")
 	}
 
+	if err := c.Remove(ctx, "alice", file); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListDeletedEntries(ctx, "alice"); err != nil {
+		t.Fatal(err)
+	}
 }
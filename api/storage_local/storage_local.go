@@ -73,14 +73,14 @@ func (fs *localStorage) GetPathByID(ctx context.Context, id string) (string, err
 	return "", api.NewError(api.StorageNotSupportedErrorCode)
 }
 
-func (fs *localStorage) SetACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (fs *localStorage) SetACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	return api.NewError(api.StorageNotSupportedErrorCode)
 }
 
 func (fs *localStorage) UnsetACL(ctx context.Context, path string, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	return api.NewError(api.StorageNotSupportedErrorCode)
 }
-func (fs *localStorage) UpdateACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (fs *localStorage) UpdateACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	return api.NewError(api.StorageNotSupportedErrorCode)
 }
 
@@ -91,7 +91,7 @@ func (fs *localStorage) GetQuota(ctx context.Context, name string) (int, int, er
 
 func (fs *localStorage) CreateDir(ctx context.Context, name string) error {
 	name = fs.addNamespace(name)
-	return os.Mkdir(name, 0644)
+	return convertError(os.Mkdir(name, 0755))
 }
 
 func (fs *localStorage) Delete(ctx context.Context, name string) error {
@@ -109,7 +109,7 @@ func (fs *localStorage) Delete(ctx context.Context, name string) error {
 func (fs *localStorage) Move(ctx context.Context, oldName, newName string) error {
 	oldName = fs.addNamespace(oldName)
 	newName = fs.addNamespace(newName)
-	return os.Rename(oldName, newName)
+	return convertError(os.Rename(oldName, newName))
 }
 
 func (fs *localStorage) GetMetadata(ctx context.Context, name string) (*api.Metadata, error) {
@@ -147,17 +147,17 @@ func (fs *localStorage) Upload(ctx context.Context, name string, r io.ReadCloser
 	// is supposed to be written.
 	tmp, err := ioutil.TempFile(path.Dir(name), ".alustotmp-")
 	if err != nil {
-		return err
+		return convertError(err)
 	}
 	_, err = io.Copy(tmp, r)
+	tmp.Close()
 	if err != nil {
+		os.Remove(tmp.Name())
 		return err
 	}
 	if err := os.Rename(tmp.Name(), name); err != nil {
-		if os.IsNotExist(err) {
-			return api.NewError(api.StorageNotFoundErrorCode)
-		}
-		return err
+		os.Remove(tmp.Name())
+		return convertError(err)
 	}
 	return nil
 }
@@ -166,9 +166,7 @@ func (fs *localStorage) Download(ctx context.Context, name string) (io.ReadClose
 	name = fs.addNamespace(name)
 	r, err := os.Open(name)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, api.NewError(api.StorageNotFoundErrorCode)
-		}
+		return nil, convertError(err)
 	}
 	return r, nil
 }
@@ -196,3 +194,14 @@ func (fs *localStorage) ListRecycle(ctx context.Context, path string) ([]*api.Re
 func (fs *localStorage) RestoreRecycleEntry(ctx context.Context, restoreKey string) error {
 	return api.NewError(api.StorageNotSupportedErrorCode)
 }
+
+// convertError converts the errors of the os package to api errors.
+func convertError(err error) error {
+	if os.IsNotExist(err) {
+		return api.NewError(api.StorageNotFoundErrorCode).WithMessage(err.Error())
+	}
+	if os.IsExist(err) {
+		return api.NewError(api.StorageAlreadyExistsErrorCode).WithMessage(err.Error())
+	}
+	return err
+}
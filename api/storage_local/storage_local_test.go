@@ -0,0 +1,24 @@
+package storage_local
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+
+	"go.uber.org/zap"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.TestStorage(t, func(t *testing.T) api.Storage {
+		folder, err := ioutil.TempDir("", "storage-local")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the folder is removed when the test finishes
+		t.Cleanup(func() { os.RemoveAll(folder) })
+		return New(&Options{Namespace: folder, Logger: zap.NewNop()})
+	})
+}
@@ -0,0 +1,285 @@
+package storage_ocm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	gopath "path"
+	"strings"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/storage_webdav"
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+)
+
+type ocmStorage struct {
+	ocmShareManager api.OCMShareManager
+	client          *http.Client
+	logger          *zap.Logger
+}
+
+type Options struct {
+	Timeout int `json:"timeout"` // seconds to wait for the responses of the remote providers
+}
+
+// New returns the storage of the federated shares received by the user in the
+// context, mounted as /<share_id> once accepted. The files are accessed on the
+// provider of the owner over WebDAV, with the secret of the share.
+func New(opt *Options, om api.OCMShareManager, logger *zap.Logger) api.Storage {
+	if opt.Timeout == 0 {
+		opt.Timeout = 30
+	}
+	return &ocmStorage{ocmShareManager: om, client: storage_webdav.NewHTTPClient(time.Second * time.Duration(opt.Timeout)), logger: logger}
+}
+
+// getReceivedShare returns the share name is in, the path of name relative
+// to the share and the storage of its files.
+func (fs *ocmStorage) getReceivedShare(ctx context.Context, name string) (*api.OCMShare, string, api.Storage, error) {
+	// path is /42/Photos/Test
+	items := strings.Split(name, "/")
+	if len(items) < 2 || items[1] == "" {
+		return nil, "", nil, api.NewError(api.StorageNotFoundErrorCode).WithMessage(name)
+	}
+
+	share, err := fs.ocmShareManager.GetReceivedOCMShare(ctx, items[1])
+	if err != nil {
+		if api.IsErrorCode(err, api.OCMShareNotFoundErrorCode) {
+			return nil, "", nil, api.NewError(api.StorageNotFoundErrorCode).WithMessage(name)
+		}
+		return nil, "", nil, err
+	}
+	// only the accepted shares are mounted
+	if share.State != api.OCMShare_ACCEPTED {
+		return nil, "", nil, api.NewError(api.StorageNotFoundErrorCode).WithMessage(name)
+	}
+
+	secret, err := fs.ocmShareManager.GetReceivedOCMShareSecret(ctx, share.Id)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	storage, err := storage_webdav.New(&storage_webdav.Options{URL: share.WebdavUrl, Username: secret, Client: fs.client}, fs.logger)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	relativePath := gopath.Join("/", gopath.Join(items[2:]...))
+	fs.logger.Debug("resolve received ocm share path", zap.String("path", name), zap.String("relativepath", relativePath), zap.String("share_id", share.Id), zap.String("webdav_url", share.WebdavUrl))
+	return share, relativePath, storage, nil
+}
+
+// setShareInfo mounts md, relative to the share, under /<share_id> and
+// records the received share it is accessed through.
+func setShareInfo(md *api.Metadata, share *api.OCMShare) {
+	md.Path = gopath.Join("/", share.Id, md.Path)
+	md.Id = strings.TrimPrefix(md.Path, "/")
+
+	p := api.SharePermissions(share.Permissions)
+	md.ShareId = share.Id
+	md.ShareOwnerId = share.OwnerId
+	md.ShareTarget = share.Name
+	md.SharePermissions = share.Permissions
+	md.IsReadOnly = !p.Has(api.SharePermissionUpdate)
+	md.IsShareable = false
+}
+
+func (fs *ocmStorage) GetPathByID(ctx context.Context, id string) (string, error) {
+	// the ids are the paths of the entries
+	p := gopath.Join("/", id)
+	if _, err := fs.GetMetadata(ctx, p); err != nil {
+		return "", err
+	}
+	return p, nil
+}
+
+func (fs *ocmStorage) GetMetadata(ctx context.Context, name string) (*api.Metadata, error) {
+	if name == "/" {
+		return &api.Metadata{
+			Path:  "/",
+			Size:  0,
+			Etag:  "TODO",
+			Mtime: 0,
+			IsDir: true,
+		}, nil
+	}
+
+	share, p, storage, err := fs.getReceivedShare(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	md, err := storage.GetMetadata(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	setShareInfo(md, share)
+	return md, nil
+}
+
+func (fs *ocmStorage) listRoot(ctx context.Context) ([]*api.Metadata, error) {
+	l := ctx_zap.Extract(ctx)
+	shares, err := fs.ocmShareManager.ListReceivedOCMShares(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mds := []*api.Metadata{}
+	for _, share := range shares {
+		if share.State != api.OCMShare_ACCEPTED {
+			continue
+		}
+		md, err := fs.GetMetadata(ctx, "/"+share.Id)
+		if err != nil {
+			// a remote provider being down does not hide the other shares
+			l.Warn("error accessing received ocm share", zap.Error(err), zap.String("share_id", share.Id), zap.String("webdav_url", share.WebdavUrl))
+			continue
+		}
+		mds = append(mds, md)
+	}
+	return mds, nil
+}
+
+// name is /<share_id>/a/b/c
+func (fs *ocmStorage) ListFolder(ctx context.Context, name string) ([]*api.Metadata, error) {
+	if name == "/" {
+		return fs.listRoot(ctx)
+	}
+
+	share, p, storage, err := fs.getReceivedShare(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	mds, err := storage.ListFolder(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	for _, md := range mds {
+		setShareInfo(md, share)
+	}
+	return mds, nil
+}
+
+func (fs *ocmStorage) Download(ctx context.Context, name string) (io.ReadCloser, error) {
+	_, p, storage, err := fs.getReceivedShare(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return storage.Download(ctx, p)
+}
+
+func (fs *ocmStorage) Upload(ctx context.Context, name string, r io.ReadCloser) error {
+	share, p, storage, err := fs.getReceivedShare(ctx, name)
+	if err != nil {
+		r.Close()
+		return err
+	}
+
+	// overwriting a file is an update, uploading a new one a creation
+	required := api.SharePermissionCreate
+	if _, err := storage.GetMetadata(ctx, p); err == nil {
+		required = api.SharePermissionUpdate
+	}
+	if err := checkSharePermissions(share, required); err != nil {
+		r.Close()
+		return err
+	}
+	return storage.Upload(ctx, p, r)
+}
+
+func (fs *ocmStorage) Move(ctx context.Context, oldName, newName string) error {
+	oldShare, oldPath, storage, err := fs.getReceivedShare(ctx, oldName)
+	if err != nil {
+		return err
+	}
+	newShare, newPath, _, err := fs.getReceivedShare(ctx, newName)
+	if err != nil {
+		return err
+	}
+	if oldShare.Id != newShare.Id {
+		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("cross-share rename forbidden")
+	}
+	if oldPath == "/" {
+		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("the root of a share cannot be renamed")
+	}
+	if err := checkSharePermissions(oldShare, api.SharePermissionCreate|api.SharePermissionDelete); err != nil {
+		return err
+	}
+	return storage.Move(ctx, oldPath, newPath)
+}
+
+func (fs *ocmStorage) CreateDir(ctx context.Context, name string) error {
+	share, p, storage, err := fs.getReceivedShare(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err := checkSharePermissions(share, api.SharePermissionCreate); err != nil {
+		return err
+	}
+	return storage.CreateDir(ctx, p)
+}
+
+func (fs *ocmStorage) Delete(ctx context.Context, name string) error {
+	share, p, storage, err := fs.getReceivedShare(ctx, name)
+	if err != nil {
+		return err
+	}
+	// the share itself is removed by rejecting it
+	if p == "/" {
+		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("the root of a share cannot be deleted")
+	}
+	if err := checkSharePermissions(share, api.SharePermissionDelete); err != nil {
+		return err
+	}
+	return storage.Delete(ctx, p)
+}
+
+// checkSharePermissions returns an error if the received share does not
+// grant the required permissions, that the remote provider enforces anyway.
+func checkSharePermissions(share *api.OCMShare, required api.SharePermissions) error {
+	if !api.SharePermissions(share.Permissions).Has(required) {
+		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("ocm share " + share.Id)
+	}
+	return nil
+}
+
+func (fs *ocmStorage) GetQuota(ctx context.Context, name string) (int, int, error) {
+	return 0, 0, api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *ocmStorage) SetACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+	return api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *ocmStorage) UnsetACL(ctx context.Context, path string, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+	return api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *ocmStorage) UpdateACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+	return api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *ocmStorage) ListRevisions(ctx context.Context, path string) ([]*api.Revision, error) {
+	return nil, api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *ocmStorage) DownloadRevision(ctx context.Context, path, revisionKey string) (io.ReadCloser, error) {
+	return nil, api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *ocmStorage) RestoreRevision(ctx context.Context, path, revisionKey string) error {
+	return api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *ocmStorage) EmptyRecycle(ctx context.Context, path string) error {
+	return api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *ocmStorage) ListRecycle(ctx context.Context, path string) ([]*api.RecycleEntry, error) {
+	return nil, api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *ocmStorage) RestoreRecycleEntry(ctx context.Context, restoreKey string) error {
+	return api.NewError(api.StorageNotSupportedErrorCode)
+}
@@ -0,0 +1,124 @@
+package storage_ocm_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+	"github.com/cernbox/reva/api/ocm_share_manager_sqlite"
+	"github.com/cernbox/reva/api/storage_ocm"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/webdav"
+)
+
+// newServer returns a WebDAV server serving the files of a share under
+// /ocm/webdav to the clients authenticated with secret.
+func newServer(secret string) *httptest.Server {
+	h := &webdav.Handler{Prefix: "/ocm/webdav", FileSystem: webdav.NewMemFS(), LockSystem: webdav.NewMemLS()}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, _, ok := r.BasicAuth(); !ok || user != secret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
+}
+
+func TestOCMStorage(t *testing.T) {
+	server := newServer("secret")
+	defer server.Close()
+
+	om, err := ocm_share_manager_sqlite.New("")
+	conformance.Check(t, err)
+	share := &api.OCMShare{OwnerId: "alice@" + server.URL, Recipient: "bob", Name: "docs", ProviderId: "1", Permissions: uint32(api.SharePermissionsReadWrite), WebdavUrl: server.URL + "/ocm/webdav"}
+	rw, err := om.AddReceivedOCMShare(context.Background(), share, "secret")
+	conformance.Check(t, err)
+	share.ProviderId = "2"
+	share.Permissions = uint32(api.SharePermissionsReadOnly)
+	ro, err := om.AddReceivedOCMShare(context.Background(), share, "secret")
+	conformance.Check(t, err)
+
+	fs := storage_ocm.New(&storage_ocm.Options{}, om, zap.NewNop())
+	bobCtx := conformance.UserContext("bob")
+
+	// the shares are mounted once accepted
+	mds, err := fs.ListFolder(bobCtx, "/")
+	conformance.Check(t, err)
+	if len(mds) != 0 {
+		t.Fatalf("expected no mounted shares, got %d", len(mds))
+	}
+	_, err = fs.GetMetadata(bobCtx, "/"+rw.Id)
+	conformance.ExpectCode(t, err, api.StorageNotFoundErrorCode)
+	_, err = om.SetReceivedOCMShareState(bobCtx, rw.Id, api.OCMShare_ACCEPTED)
+	conformance.Check(t, err)
+	_, err = om.SetReceivedOCMShareState(bobCtx, ro.Id, api.OCMShare_ACCEPTED)
+	conformance.Check(t, err)
+
+	mds, err = fs.ListFolder(bobCtx, "/")
+	conformance.Check(t, err)
+	if len(mds) != 2 || mds[0].Path != "/"+rw.Id || !mds[0].IsDir || mds[0].ShareTarget != "docs" {
+		t.Fatalf("unexpected mounted shares: %+v", mds)
+	}
+
+	root := "/" + rw.Id
+	conformance.Check(t, fs.CreateDir(bobCtx, root+"/dir"))
+	conformance.ExpectCode(t, fs.CreateDir(bobCtx, root+"/dir"), api.StorageAlreadyExistsErrorCode)
+	conformance.Check(t, fs.Upload(bobCtx, root+"/dir/file.txt", ioutil.NopCloser(strings.NewReader("hello"))))
+
+	md, err := fs.GetMetadata(bobCtx, root+"/dir/file.txt")
+	conformance.Check(t, err)
+	if md.Path != root+"/dir/file.txt" || md.IsDir || md.Size != 5 || md.ShareId != rw.Id || md.IsReadOnly {
+		t.Fatalf("unexpected metadata: %+v", md)
+	}
+	path, err := fs.GetPathByID(bobCtx, md.Id)
+	conformance.Check(t, err)
+	if path != md.Path {
+		t.Fatalf("expected path %s for id %s, got %s", md.Path, md.Id, path)
+	}
+
+	mds, err = fs.ListFolder(bobCtx, root+"/dir")
+	conformance.Check(t, err)
+	if len(mds) != 1 || mds[0].Path != root+"/dir/file.txt" {
+		t.Fatalf("unexpected folder content: %+v", mds)
+	}
+
+	conformance.Check(t, fs.Move(bobCtx, root+"/dir/file.txt", root+"/file.txt"))
+	r, err := fs.Download(bobCtx, root+"/file.txt")
+	conformance.Check(t, err)
+	data, err := ioutil.ReadAll(r)
+	r.Close()
+	conformance.Check(t, err)
+	if string(data) != "hello" {
+		t.Fatalf("unexpected content %q", data)
+	}
+	_, err = fs.Download(bobCtx, root+"/dir/file.txt")
+	conformance.ExpectCode(t, err, api.StorageNotFoundErrorCode)
+
+	// the read-only share cannot be written
+	conformance.ExpectCode(t, fs.CreateDir(bobCtx, "/"+ro.Id+"/other"), api.StoragePermissionDeniedErrorCode)
+	conformance.ExpectCode(t, fs.Delete(bobCtx, "/"+ro.Id+"/file.txt"), api.StoragePermissionDeniedErrorCode)
+	conformance.ExpectCode(t, fs.Move(bobCtx, root+"/file.txt", "/"+ro.Id+"/file.txt"), api.StoragePermissionDeniedErrorCode)
+
+	conformance.Check(t, fs.Delete(bobCtx, root+"/file.txt"))
+	conformance.ExpectCode(t, fs.Delete(bobCtx, root), api.StoragePermissionDeniedErrorCode)
+
+	// the shares are only mounted for their recipient
+	_, err = fs.GetMetadata(conformance.UserContext("carol"), root)
+	conformance.ExpectCode(t, err, api.StorageNotFoundErrorCode)
+
+	// a wrong secret is refused by the remote provider
+	conformance.Check(t, om.RemoveReceivedOCMShare(context.Background(), "1", "secret"))
+	share.ProviderId = "3"
+	wrong, err := om.AddReceivedOCMShare(context.Background(), share, "wrong")
+	conformance.Check(t, err)
+	_, err = om.SetReceivedOCMShareState(bobCtx, wrong.Id, api.OCMShare_ACCEPTED)
+	conformance.Check(t, err)
+	_, err = fs.GetMetadata(bobCtx, "/"+wrong.Id)
+	conformance.ExpectCode(t, err, api.StoragePermissionDeniedErrorCode)
+}
@@ -3,9 +3,11 @@ package storage_public_link
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"path"
 	"strings"
+	"sync"
 
 	"github.com/cernbox/reva/api"
 	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
@@ -16,13 +18,22 @@ type linkStorage struct {
 	vfs         api.VirtualStorage
 	linkManager api.PublicLinkManager
 	logger      *zap.Logger
+
+	// dropping are the paths chosen by dropName for the uploads in
+	// progress, so two files dropped at once do not get the same name.
+	dropMu   sync.Mutex
+	dropping map[string]bool
 }
 
 type Options struct {
 }
 
+// maxDropRenames is the number of names tried for a file dropped in a
+// drop-only link before giving up, see dropName.
+const maxDropRenames = 100
+
 func New(opt *Options, vfs api.VirtualStorage, lm api.PublicLinkManager, logger *zap.Logger) api.Storage {
-	return &linkStorage{vfs, lm, logger}
+	return &linkStorage{vfs: vfs, linkManager: lm, logger: logger, dropping: map[string]bool{}}
 }
 
 func getPublicLinkFromContext(ctx context.Context) (*api.PublicLink, error) {
@@ -91,7 +102,7 @@ func (fs *linkStorage) getLinkMetadata(ctx context.Context, link *api.PublicLink
 	return finfo, nil
 }
 
-func (fs *linkStorage) SetACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (fs *linkStorage) SetACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	return api.NewError(api.StorageNotSupportedErrorCode)
 }
 
@@ -99,7 +110,7 @@ func (fs *linkStorage) UnsetACL(ctx context.Context, path string, recipient *api
 	return api.NewError(api.StorageNotSupportedErrorCode)
 }
 
-func (fs *linkStorage) UpdateACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (fs *linkStorage) UpdateACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	return api.NewError(api.StorageNotSupportedErrorCode)
 }
 
@@ -118,6 +129,12 @@ func (fs *linkStorage) GetMetadata(ctx context.Context, p string) (*api.Metadata
 		return nil, err
 	}
 
+	// the content of drop-only links is hidden, so uploaders cannot
+	// find out which files have already been dropped.
+	if link.DropOnly && linkRelativePath != "" {
+		return nil, api.NewError(api.StorageNotFoundErrorCode).WithMessage(p)
+	}
+
 	linkMetadata, err := fs.getLinkMetadata(ctx, link)
 	if err != nil {
 		return nil, err
@@ -204,7 +221,17 @@ func (fs *linkStorage) Download(ctx context.Context, name string) (io.ReadCloser
 	}
 
 	p = path.Join(link.Path, p)
-	return fs.vfs.Download(ctx, p)
+	r, err := fs.vfs.Download(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the downloads that can be served are counted
+	if err := fs.linkManager.RecordPublicLinkDownload(ctx, link.Token); err != nil {
+		r.Close()
+		return nil, err
+	}
+	return r, nil
 }
 
 func (fs *linkStorage) Upload(ctx context.Context, name string, r io.ReadCloser) error {
@@ -218,16 +245,18 @@ func (fs *linkStorage) Upload(ctx context.Context, name string, r io.ReadCloser)
 	}
 
 	if link.DropOnly {
-		// we cannot append a uuid to the filename as the ocproxy and other clients
-		// may rely on stat-upload-stat mechanism to check for a valid write, thus changing
-		// the filename will trigger a not found error and thus aborting the operation.
-		// To allow for uuid appended to filenames we need a mapping from link to uuid,
-		// which I discourage as it adds another redirection and more complexity to the system.
-		// The workaround is to perfrom a stat pre-uploaed and abort if filename already exsits.
-		_, err := fs.GetMetadata(ctx, name)
-		if err == nil {
-			// filename alrady exists, we abort
-			return api.NewError(api.StorageAlreadyExistsErrorCode)
+		// files are only dropped in the folder of the link and never overwritten,
+		// the clashing names are renamed like file (2).txt.
+		if p == "" || strings.Contains(p, "/") {
+			return dropOnlyError(link.Id)
+		}
+		p, err = fs.dropName(ctx, link, p)
+		if err != nil {
+			return err
+		}
+		defer fs.releaseDropName(link, p)
+		if f, ok := api.ContextGetUploadedFile(ctx); ok {
+			f.Name = p
 		}
 	}
 
@@ -235,6 +264,50 @@ func (fs *linkStorage) Upload(ctx context.Context, name string, r io.ReadCloser)
 	return fs.vfs.Upload(ctx, p, r)
 }
 
+// dropName returns the first name derived from name that does not exist
+// in the folder of the drop-only link and is not being dropped, and
+// reserves it until releaseDropName is called once the file is written.
+// The names are reserved in this daemon only, as the storages have no way
+// to create a file only if it does not exist. A name is reserved before
+// the storage is checked, so that the lock is not held during the checks.
+func (fs *linkStorage) dropName(ctx context.Context, link *api.PublicLink, name string) (string, error) {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	candidate := name
+	for i := 2; i <= maxDropRenames+1; i++ {
+		if fs.reserveDropName(link, candidate) {
+			_, err := fs.vfs.GetMetadata(ctx, path.Join(link.Path, candidate))
+			if api.IsErrorCode(err, api.StorageNotFoundErrorCode) {
+				return candidate, nil
+			}
+			fs.releaseDropName(link, candidate)
+			if err != nil {
+				return "", err
+			}
+		}
+		candidate = fmt.Sprintf("%s (%d)%s", base, i, ext)
+	}
+	return "", api.NewError(api.StorageAlreadyExistsErrorCode).WithMessage(name)
+}
+
+// reserveDropName returns false if the name is already being dropped.
+func (fs *linkStorage) reserveDropName(link *api.PublicLink, name string) bool {
+	fs.dropMu.Lock()
+	defer fs.dropMu.Unlock()
+	p := path.Join(link.Path, name)
+	if fs.dropping[p] {
+		return false
+	}
+	fs.dropping[p] = true
+	return true
+}
+
+func (fs *linkStorage) releaseDropName(link *api.PublicLink, name string) {
+	fs.dropMu.Lock()
+	defer fs.dropMu.Unlock()
+	delete(fs.dropping, path.Join(link.Path, name))
+}
+
 func (fs *linkStorage) Move(ctx context.Context, oldName, newName string) error {
 	oldLink, oldPath, ctx, err := fs.getLink(ctx, oldName)
 	if err != nil {
@@ -332,10 +405,10 @@ func (fs *linkStorage) RestoreRecycleEntry(ctx context.Context, restoreKey strin
 	return api.NewError(api.StorageNotSupportedErrorCode)
 }
 
-type dropOnlyError string
-
-func (e dropOnlyError) Error() string { return "link is drop only: " + string(e) }
-
-type readOnlyError string
+func dropOnlyError(id string) error {
+	return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("link is drop only: " + id)
+}
 
-func (e readOnlyError) Error() string { return "link is read only: " + string(e) }
+func readOnlyError(id string) error {
+	return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("link is read only: " + id)
+}
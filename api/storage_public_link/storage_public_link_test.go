@@ -0,0 +1,135 @@
+package storage_public_link_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+	"github.com/cernbox/reva/api/mount"
+	"github.com/cernbox/reva/api/public_link_manager_memory"
+	"github.com/cernbox/reva/api/storage_public_link"
+	"github.com/cernbox/reva/api/virtual_storage"
+
+	"go.uber.org/zap"
+)
+
+func upload(fs api.Storage, ctx context.Context, p, content string) error {
+	return fs.Upload(ctx, p, ioutil.NopCloser(strings.NewReader(content)))
+}
+
+func TestDropOnlyLink(t *testing.T) {
+	ctx := context.Background()
+	vfs := virtual_storage.NewVFS(zap.NewNop(), nil)
+	conformance.Check(t, vfs.AddMount(ctx, mount.New("home", "/", nil, conformance.NewMemoryStorage())))
+	conformance.Check(t, vfs.CreateDir(ctx, "/alice"))
+	conformance.Check(t, vfs.CreateDir(ctx, "/alice/drop"))
+	conformance.Check(t, upload(vfs, ctx, "/alice/drop/report.txt", "mine"))
+
+	lm := public_link_manager_memory.New(vfs, nil, nil)
+	aliceCtx := api.ContextSetUser(ctx, &api.User{AccountId: "alice"})
+	pl, err := lm.CreatePublicLink(aliceCtx, "/alice/drop", &api.PublicLinkOptions{DropOnly: true})
+	conformance.Check(t, err)
+
+	fs := storage_public_link.New(&storage_public_link.Options{}, vfs, lm, zap.NewNop())
+	linkCtx := api.ContextSetPublicLink(ctx, pl)
+	root := "/" + pl.Token
+
+	// the files are dropped without overwriting the existing ones
+	conformance.Check(t, upload(fs, linkCtx, root+"/report.txt", "first"))
+	conformance.Check(t, upload(fs, linkCtx, root+"/report.txt", "second"))
+	conformance.Check(t, upload(fs, linkCtx, root+"/notes", "third"))
+	for p, content := range map[string]string{
+		"/alice/drop/report.txt":     "mine",
+		"/alice/drop/report (2).txt": "first",
+		"/alice/drop/report (3).txt": "second",
+		"/alice/drop/notes":          "third",
+	} {
+		r, err := vfs.Download(ctx, p)
+		conformance.Check(t, err)
+		data, err := ioutil.ReadAll(r)
+		r.Close()
+		conformance.Check(t, err)
+		if string(data) != content {
+			t.Fatalf("expected %q in %s, got %q", content, p, data)
+		}
+	}
+
+	// the name the file is written under is reported to the caller
+	uploaded := &api.UploadedFile{}
+	conformance.Check(t, upload(fs, api.ContextSetUploadedFile(linkCtx, uploaded), root+"/report.txt", "fourth"))
+	if uploaded.Name != "report (4).txt" {
+		t.Fatalf("expected the file to be written as report (4).txt, got %q", uploaded.Name)
+	}
+
+	// a name is not given to two files dropped at once
+	r, w := io.Pipe()
+	done := make(chan error)
+	go func() { done <- fs.Upload(linkCtx, root+"/slides.pdf", r) }()
+	// the write returns once the upload reads it, so once the name is chosen
+	if _, err := w.Write([]byte("first")); err != nil {
+		t.Fatal(err)
+	}
+	uploaded = &api.UploadedFile{}
+	conformance.Check(t, upload(fs, api.ContextSetUploadedFile(linkCtx, uploaded), root+"/slides.pdf", "second"))
+	w.Close()
+	conformance.Check(t, <-done)
+	if uploaded.Name != "slides (2).pdf" {
+		t.Fatalf("expected the file to be written as slides (2).pdf, got %q", uploaded.Name)
+	}
+
+	// the folder of the link is visible but not its content
+	md, err := fs.GetMetadata(linkCtx, root)
+	conformance.Check(t, err)
+	if !md.IsDir {
+		t.Fatalf("expected the folder of the link, got %+v", md)
+	}
+	_, err = fs.GetMetadata(linkCtx, root+"/report.txt")
+	conformance.ExpectCode(t, err, api.StorageNotFoundErrorCode)
+	_, err = fs.ListFolder(linkCtx, root)
+	conformance.ExpectCode(t, err, api.StoragePermissionDeniedErrorCode)
+	_, err = fs.Download(linkCtx, root+"/report.txt")
+	conformance.ExpectCode(t, err, api.StoragePermissionDeniedErrorCode)
+
+	// nothing else can be changed
+	conformance.ExpectCode(t, fs.CreateDir(linkCtx, root+"/dir"), api.StoragePermissionDeniedErrorCode)
+	conformance.ExpectCode(t, upload(fs, linkCtx, root+"/dir/file.txt", "nested"), api.StoragePermissionDeniedErrorCode)
+	conformance.ExpectCode(t, fs.Delete(linkCtx, root+"/report.txt"), api.StoragePermissionDeniedErrorCode)
+	conformance.ExpectCode(t, fs.Move(linkCtx, root+"/report.txt", root+"/other.txt"), api.StoragePermissionDeniedErrorCode)
+}
+
+func TestLinkDownloadLimit(t *testing.T) {
+	ctx := context.Background()
+	vfs := virtual_storage.NewVFS(zap.NewNop(), nil)
+	conformance.Check(t, vfs.AddMount(ctx, mount.New("home", "/", nil, conformance.NewMemoryStorage())))
+	conformance.Check(t, vfs.CreateDir(ctx, "/alice"))
+	conformance.Check(t, vfs.CreateDir(ctx, "/alice/dir"))
+	conformance.Check(t, upload(vfs, ctx, "/alice/dir/file.txt", "hello"))
+
+	lm := public_link_manager_memory.New(vfs, nil, nil)
+	aliceCtx := api.ContextSetUser(ctx, &api.User{AccountId: "alice"})
+	pl, err := lm.CreatePublicLink(aliceCtx, "/alice/dir", &api.PublicLinkOptions{ReadOnly: true, MaxDownloads: 1})
+	conformance.Check(t, err)
+
+	fs := storage_public_link.New(&storage_public_link.Options{}, vfs, lm, zap.NewNop())
+	linkCtx := api.ContextSetPublicLink(ctx, pl)
+	p := "/" + pl.Token + "/file.txt"
+
+	// the missing files are not counted
+	_, err = fs.Download(linkCtx, "/"+pl.Token+"/missing.txt")
+	conformance.ExpectCode(t, err, api.StorageNotFoundErrorCode)
+	r, err := fs.Download(linkCtx, p)
+	conformance.Check(t, err)
+	r.Close()
+	_, err = fs.Download(linkCtx, p)
+	conformance.ExpectCode(t, err, api.PublicLinkDownloadLimitErrorCode)
+
+	got, err := lm.InspectPublicLink(aliceCtx, pl.Id)
+	conformance.Check(t, err)
+	if got.Downloads != 1 {
+		t.Fatalf("expected one download, got %+v", got)
+	}
+}
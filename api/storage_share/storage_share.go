@@ -41,12 +41,21 @@ func (fs *shareStorage) getReceivedShare(ctx context.Context, name string) (*api
 	if err != nil {
 		return nil, "", err
 	}
+	// only the accepted shares are mounted
+	if share.State != api.FolderShare_ACCEPTED {
+		return nil, "", api.NewError(api.StorageNotFoundErrorCode).WithMessage(name)
+	}
 
 	var relativePath string
 	if len(items) > 2 {
 		relativePath = path.Join(items[2:]...)
 	}
 
+	// a shared file has nothing below it
+	if share.ItemType == api.FolderShare_FILE && relativePath != "" {
+		return nil, "", api.NewError(api.StorageNotFoundErrorCode).WithMessage(name)
+	}
+
 	fs.logger.Debug("resolve received share path", zap.String("path", name), zap.String("relativepath", relativePath), zap.String("sharepath", share.Path), zap.String("share_id", share.Id))
 	return share, relativePath, nil
 }
@@ -72,18 +81,27 @@ func (fs *shareStorage) getReceivedShareMetadata(ctx context.Context, share *api
 	}
 
 	finfo.ShareTarget = share.Target
-	finfo.IsShareable = false // TODO(labkode): add re-shares
+	finfo.IsShareable = api.GetSharePermissions(share).Has(api.SharePermissionShare)
 	return finfo, nil
 }
 
-func (fs *shareStorage) SetACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+// setShareInfo records on md the received share it is accessed through,
+// so it can be re-shared. md.Id is the id of the entry for the owner.
+func setShareInfo(md *api.Metadata, share *api.FolderShare) {
+	md.ShareId = share.Id
+	md.ShareOwnerId = share.OwnerId
+	md.ShareFileId = md.Id
+	md.SharePermissions = uint32(api.GetSharePermissions(share))
+}
+
+func (fs *shareStorage) SetACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	return api.NewError(api.StorageNotSupportedErrorCode)
 }
 
 func (fs *shareStorage) UnsetACL(ctx context.Context, path string, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	return api.NewError(api.StorageNotSupportedErrorCode)
 }
-func (fs *shareStorage) UpdateACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (fs *shareStorage) UpdateACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	return api.NewError(api.StorageNotSupportedErrorCode)
 }
 
@@ -115,6 +133,7 @@ func (fs *shareStorage) GetMetadata(ctx context.Context, p string) (*api.Metadat
 		return nil, err
 	}
 
+	setShareInfo(md, share)
 	md.IsReadOnly = shareMetadata.IsReadOnly
 	md.Path = path.Join("/", share.Id, strings.TrimPrefix(md.Path, shareMetadata.Path))
 	md.Id = share.Id
@@ -131,6 +150,9 @@ func (fs *shareStorage) listRoot(ctx context.Context) ([]*api.Metadata, error) {
 
 	finfos := []*api.Metadata{}
 	for _, share := range shares {
+		if share.State != api.FolderShare_ACCEPTED {
+			continue
+		}
 		p := path.Join("/", share.Id)
 		fi, err := fs.GetMetadata(ctx, p)
 		if err != nil {
@@ -152,6 +174,9 @@ func (fs *shareStorage) ListFolder(ctx context.Context, name string) ([]*api.Met
 	if err != nil {
 		return nil, err
 	}
+	if share.ItemType == api.FolderShare_FILE {
+		return nil, api.NewError(api.PathInvalidError).WithMessage("not a folder: " + name)
+	}
 
 	newCtx := api.ContextSetUser(ctx, &api.User{AccountId: share.OwnerId})
 	shareMetadata, err := fs.getReceivedShareMetadata(newCtx, share)
@@ -167,6 +192,7 @@ func (fs *shareStorage) ListFolder(ctx context.Context, name string) ([]*api.Met
 	for _, md := range mds {
 		originalPath := md.Path
 		p := path.Join(share.Id, strings.TrimPrefix(md.Path, shareMetadata.Path))
+		setShareInfo(md, share)
 		md.Path = path.Join("/", p)
 		md.Id = p
 		md.ShareTarget = shareMetadata.ShareTarget
@@ -195,12 +221,17 @@ func (fs *shareStorage) Upload(ctx context.Context, name string, r io.ReadCloser
 		return err
 	}
 
-	if share.ReadOnly {
-		return api.NewError(api.StoragePermissionDeniedErrorCode)
-	}
-
 	p = path.Join(share.Path, p)
 	newCtx := api.ContextSetUser(ctx, &api.User{AccountId: share.OwnerId})
+
+	// overwriting a file is an update, uploading a new one a creation
+	required := api.SharePermissionCreate
+	if _, err := fs.vs.GetMetadata(newCtx, p); err == nil {
+		required = api.SharePermissionUpdate
+	}
+	if err := checkSharePermissions(share, required); err != nil {
+		return err
+	}
 	return fs.vs.Upload(newCtx, p, r)
 }
 
@@ -214,8 +245,9 @@ func (fs *shareStorage) Move(ctx context.Context, oldName, newName string) error
 		return err
 	}
 
-	if oldShare.ReadOnly {
-		return api.NewError(api.StoragePermissionDeniedErrorCode)
+	// a rename removes the entry and creates a new one
+	if err := checkSharePermissions(oldShare, api.SharePermissionCreate|api.SharePermissionDelete); err != nil {
+		return err
 	}
 
 	if oldShare.Id != newShare.Id {
@@ -245,8 +277,8 @@ func (fs *shareStorage) CreateDir(ctx context.Context, name string) error {
 		return err
 	}
 
-	if share.ReadOnly {
-		return api.NewError(api.StoragePermissionDeniedErrorCode)
+	if err := checkSharePermissions(share, api.SharePermissionCreate); err != nil {
+		return err
 	}
 
 	p = path.Join(share.Path, p)
@@ -260,15 +292,28 @@ func (fs *shareStorage) Delete(ctx context.Context, name string) error {
 		return err
 	}
 
-	if share.ReadOnly {
+	// shared files can be updated but not deleted
+	if share.ItemType == api.FolderShare_FILE {
 		return api.NewError(api.StoragePermissionDeniedErrorCode)
 	}
+	if err := checkSharePermissions(share, api.SharePermissionDelete); err != nil {
+		return err
+	}
 
 	p = path.Join(share.Path, p)
 	newCtx := api.ContextSetUser(ctx, &api.User{AccountId: share.OwnerId})
 	return fs.vs.Delete(newCtx, p)
 }
 
+// checkSharePermissions returns an error if the received share does not
+// grant the required permissions.
+func checkSharePermissions(share *api.FolderShare, required api.SharePermissions) error {
+	if !api.GetSharePermissions(share).Has(required) {
+		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("share " + share.Id)
+	}
+	return nil
+}
+
 func (fs *shareStorage) ListRevisions(ctx context.Context, path string) ([]*api.Revision, error) {
 	return nil, api.NewError(api.StorageNotSupportedErrorCode)
 }
@@ -0,0 +1,109 @@
+package storage_share_test
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+	"github.com/cernbox/reva/api/mount"
+	"github.com/cernbox/reva/api/share_manager_memory"
+	"github.com/cernbox/reva/api/storage_share"
+	"github.com/cernbox/reva/api/virtual_storage"
+
+	"go.uber.org/zap"
+)
+
+func TestFileShare(t *testing.T) {
+	ctx := context.Background()
+	vfs := virtual_storage.NewVFS(zap.NewNop(), nil)
+	conformance.Check(t, vfs.AddMount(ctx, mount.New("home", "/", nil, conformance.NewMemoryStorage())))
+	conformance.Check(t, vfs.CreateDir(ctx, "/alice"))
+	conformance.Check(t, vfs.CreateDir(ctx, "/alice/dir"))
+	conformance.Check(t, vfs.Upload(ctx, "/alice/file.txt", ioutil.NopCloser(strings.NewReader("hello"))))
+
+	sm := share_manager_memory.New(vfs, conformance.NewUserManager())
+	aliceCtx := conformance.UserContext("alice")
+	fileShare, err := sm.AddFolderShare(aliceCtx, "/alice/file.txt", &api.ShareRecipient{Identity: "bob", Type: api.ShareRecipient_USER}, &api.FolderShareOptions{Permissions: api.SharePermissionsReadWrite})
+	conformance.Check(t, err)
+	dirShare, err := sm.AddFolderShare(aliceCtx, "/alice/dir", &api.ShareRecipient{Identity: "bob", Type: api.ShareRecipient_USER}, &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	conformance.Check(t, err)
+
+	// the shares are mounted once accepted
+	fs := storage_share.New(&storage_share.Options{}, vfs, sm, zap.NewNop())
+	bobCtx := conformance.UserContext("bob")
+	p := "/" + fileShare.Id
+	mds, err := fs.ListFolder(bobCtx, "/")
+	conformance.Check(t, err)
+	if len(mds) != 0 {
+		t.Fatalf("expected no mounted shares, got %d", len(mds))
+	}
+	_, err = fs.GetMetadata(bobCtx, p)
+	conformance.ExpectCode(t, err, api.StorageNotFoundErrorCode)
+
+	_, err = sm.MountReceivedShare(bobCtx, fileShare.Id, "from alice.txt")
+	conformance.Check(t, err)
+	_, err = sm.MountReceivedShare(bobCtx, dirShare.Id, "")
+	conformance.Check(t, err)
+	mds, err = fs.ListFolder(bobCtx, "/")
+	conformance.Check(t, err)
+	if len(mds) != 2 {
+		t.Fatalf("expected the two received shares, got %d", len(mds))
+	}
+
+	// the received file is surfaced as a file
+	md, err := fs.GetMetadata(bobCtx, p)
+	conformance.Check(t, err)
+	if md.IsDir || md.Size != 5 || md.ShareTarget != "/from alice.txt" {
+		t.Fatalf("expected shared file of 5 bytes, got %+v", md)
+	}
+
+	conformance.Check(t, fs.Upload(bobCtx, p, ioutil.NopCloser(strings.NewReader("hello bob"))))
+	r, err := fs.Download(bobCtx, p)
+	conformance.Check(t, err)
+	data, err := ioutil.ReadAll(r)
+	conformance.Check(t, err)
+	if string(data) != "hello bob" {
+		t.Fatalf("expected updated content, got %q", data)
+	}
+
+	_, err = fs.ListFolder(bobCtx, p)
+	conformance.ExpectCode(t, err, api.PathInvalidError)
+	_, err = fs.GetMetadata(bobCtx, p+"/other")
+	conformance.ExpectCode(t, err, api.StorageNotFoundErrorCode)
+	conformance.ExpectCode(t, fs.Delete(bobCtx, p), api.StoragePermissionDeniedErrorCode)
+
+	// rejecting the share unmounts it
+	conformance.Check(t, sm.UnmountReceivedShare(bobCtx, fileShare.Id))
+	_, err = fs.GetMetadata(bobCtx, p)
+	conformance.ExpectCode(t, err, api.StorageNotFoundErrorCode)
+}
+
+func TestSharePermissions(t *testing.T) {
+	ctx := context.Background()
+	vfs := virtual_storage.NewVFS(zap.NewNop(), nil)
+	conformance.Check(t, vfs.AddMount(ctx, mount.New("home", "/", nil, conformance.NewMemoryStorage())))
+	conformance.Check(t, vfs.CreateDir(ctx, "/alice"))
+	conformance.Check(t, vfs.CreateDir(ctx, "/alice/dir"))
+	conformance.Check(t, vfs.Upload(ctx, "/alice/dir/file.txt", ioutil.NopCloser(strings.NewReader("hello"))))
+
+	sm := share_manager_memory.New(vfs, conformance.NewUserManager())
+	permissions := api.SharePermissionRead | api.SharePermissionCreate
+	share, err := sm.AddFolderShare(conformance.UserContext("alice"), "/alice/dir", &api.ShareRecipient{Identity: "bob", Type: api.ShareRecipient_USER}, &api.FolderShareOptions{Permissions: permissions})
+	conformance.Check(t, err)
+
+	fs := storage_share.New(&storage_share.Options{}, vfs, sm, zap.NewNop())
+	bobCtx := conformance.UserContext("bob")
+	p := "/" + share.Id
+	_, err = sm.MountReceivedShare(bobCtx, share.Id, "")
+	conformance.Check(t, err)
+
+	// new entries can be created, existing ones cannot be changed
+	conformance.Check(t, fs.Upload(bobCtx, p+"/new.txt", ioutil.NopCloser(strings.NewReader("new"))))
+	conformance.Check(t, fs.CreateDir(bobCtx, p+"/subdir"))
+	conformance.ExpectCode(t, fs.Upload(bobCtx, p+"/file.txt", ioutil.NopCloser(strings.NewReader("changed"))), api.StoragePermissionDeniedErrorCode)
+	conformance.ExpectCode(t, fs.Delete(bobCtx, p+"/file.txt"), api.StoragePermissionDeniedErrorCode)
+	conformance.ExpectCode(t, fs.Move(bobCtx, p+"/file.txt", p+"/moved.txt"), api.StoragePermissionDeniedErrorCode)
+}
@@ -122,14 +122,14 @@ func (fs *eosStorage) isPathMigrated(ctx context.Context, key string) bool {
 	return migrated
 }
 
-func (fs *eosStorage) SetACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (fs *eosStorage) SetACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	_, err := getUserFromContext(ctx)
 	if err != nil {
 		return err
 	}
 
 	ts, _, _, path := fs.getStorageForPath(ctx, path)
-	return ts.SetACL(ctx, path, readOnly, recipient, shareList)
+	return ts.SetACL(ctx, path, permissions, recipient, shareList)
 
 }
 
@@ -143,14 +143,14 @@ func (fs *eosStorage) UnsetACL(ctx context.Context, path string, recipient *api.
 
 }
 
-func (fs *eosStorage) UpdateACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (fs *eosStorage) UpdateACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	_, err := getUserFromContext(ctx)
 	if err != nil {
 		return err
 	}
 
 	ts, _, _, path := fs.getStorageForPath(ctx, path)
-	return ts.UpdateACL(ctx, path, readOnly, recipient, shareList)
+	return ts.UpdateACL(ctx, path, permissions, recipient, shareList)
 }
 
 func (fs *eosStorage) GetQuota(ctx context.Context, p string) (int, int, error) {
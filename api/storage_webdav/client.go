@@ -0,0 +1,259 @@
+package storage_webdav
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	gopath "path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cernbox/reva/api"
+)
+
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:">
+	<d:prop>
+		<d:resourcetype/>
+		<d:getcontentlength/>
+		<d:getlastmodified/>
+		<d:getetag/>
+		<d:getcontenttype/>
+		<d:quota-used-bytes/>
+		<d:quota-available-bytes/>
+	</d:prop>
+</d:propfind>`
+
+// webdavClient accesses the files of a WebDAV server under base.
+type webdavClient struct {
+	base     *url.URL
+	username string
+	password string
+	client   *http.Client
+}
+
+// davEntry is a file or folder found with PROPFIND, path is relative to base.
+type davEntry struct {
+	path  string
+	isDir bool
+	size  uint64
+	mtime uint64
+	etag  string
+	mime  string
+
+	// quota of the folder, -1 if the server does not report it
+	quotaUsed      int64
+	quotaAvailable int64
+}
+
+type multistatus struct {
+	Responses []struct {
+		Href      string `xml:"DAV: href"`
+		Propstats []struct {
+			Status string `xml:"DAV: status"`
+			Prop   struct {
+				ResourceType struct {
+					Collection *struct{} `xml:"DAV: collection"`
+				} `xml:"DAV: resourcetype"`
+				ContentLength  string `xml:"DAV: getcontentlength"`
+				LastModified   string `xml:"DAV: getlastmodified"`
+				Etag           string `xml:"DAV: getetag"`
+				ContentType    string `xml:"DAV: getcontenttype"`
+				QuotaUsed      string `xml:"DAV: quota-used-bytes"`
+				QuotaAvailable string `xml:"DAV: quota-available-bytes"`
+			} `xml:"DAV: prop"`
+		} `xml:"DAV: propstat"`
+	} `xml:"DAV: response"`
+}
+
+func newWebDAVClient(base, username, password string, client *http.Client) (*webdavClient, error) {
+	u, err := url.Parse(strings.TrimSuffix(base, "/"))
+	if err != nil {
+		return nil, err
+	}
+	return &webdavClient{base: u, username: username, password: password, client: client}, nil
+}
+
+func (c *webdavClient) getURL(p string) string {
+	u := *c.base
+	u.Path = gopath.Join("/", c.base.Path, p)
+	if strings.HasSuffix(p, "/") && p != "/" {
+		u.Path += "/"
+	}
+	return u.String()
+}
+
+func (c *webdavClient) do(ctx context.Context, method, p string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest(method, c.getURL(p), body)
+	if err != nil {
+		return nil, err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return res, nil
+	}
+	defer res.Body.Close()
+	return nil, getError(method, p, res.StatusCode)
+}
+
+// getError returns the error matching the status of a failed request.
+func getError(method, p string, status int) error {
+	msg := fmt.Sprintf("%s %s returned status %d", method, p, status)
+	switch status {
+	case http.StatusNotFound, http.StatusConflict:
+		return api.NewError(api.StorageNotFoundErrorCode).WithMessage(msg)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage(msg)
+	case http.StatusMethodNotAllowed, http.StatusPreconditionFailed:
+		return api.NewError(api.StorageAlreadyExistsErrorCode).WithMessage(msg)
+	case http.StatusNotImplemented:
+		return api.NewError(api.StorageNotSupportedErrorCode).WithMessage(msg)
+	default:
+		return errors.New(msg)
+	}
+}
+
+// propfind returns the entry at p, and its children if depth is 1.
+func (c *webdavClient) propfind(ctx context.Context, p string, depth int) ([]*davEntry, error) {
+	headers := map[string]string{"Depth": strconv.Itoa(depth), "Content-Type": "application/xml; charset=utf-8"}
+	res, err := c.do(ctx, "PROPFIND", p, strings.NewReader(propfindBody), headers)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	ms := &multistatus{}
+	if err := xml.NewDecoder(res.Body).Decode(ms); err != nil {
+		return nil, fmt.Errorf("invalid propfind response for %s: %v", p, err)
+	}
+
+	entries := []*davEntry{}
+	for _, r := range ms.Responses {
+		href, err := url.Parse(r.Href)
+		if err != nil {
+			return nil, err
+		}
+		e := &davEntry{path: gopath.Join("/", strings.TrimPrefix(href.Path, c.base.Path)), quotaUsed: -1, quotaAvailable: -1}
+		for _, ps := range r.Propstats {
+			if ps.Status != "" && !strings.Contains(ps.Status, " 200 ") {
+				continue
+			}
+			prop := ps.Prop
+			if prop.ResourceType.Collection != nil {
+				e.isDir = true
+			}
+			if prop.ContentLength != "" {
+				e.size, _ = strconv.ParseUint(prop.ContentLength, 10, 64)
+			}
+			if t, err := http.ParseTime(prop.LastModified); err == nil {
+				e.mtime = uint64(t.Unix())
+			}
+			if prop.Etag != "" {
+				e.etag = prop.Etag
+			}
+			if mt, _, err := mime.ParseMediaType(prop.ContentType); err == nil {
+				e.mime = mt
+			}
+			if v, err := strconv.ParseInt(prop.QuotaUsed, 10, 64); err == nil {
+				e.quotaUsed = v
+			}
+			if v, err := strconv.ParseInt(prop.QuotaAvailable, 10, 64); err == nil {
+				e.quotaAvailable = v
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func (c *webdavClient) get(ctx context.Context, p string) (io.ReadCloser, error) {
+	res, err := c.do(ctx, "GET", p, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+func (c *webdavClient) put(ctx context.Context, p string, r io.Reader) error {
+	// the body is buffered so that the request has a length, that
+	// some servers require to accept the upload.
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	res, err := c.do(ctx, "PUT", p, bytes.NewReader(data), nil)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func (c *webdavClient) mkcol(ctx context.Context, p string) error {
+	res, err := c.do(ctx, "MKCOL", p, nil, nil)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func (c *webdavClient) delete(ctx context.Context, p string) error {
+	res, err := c.do(ctx, "DELETE", p, nil, nil)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func (c *webdavClient) move(ctx context.Context, oldPath, newPath string) error {
+	headers := map[string]string{"Destination": c.getURL(newPath), "Overwrite": "F"}
+	res, err := c.do(ctx, "MOVE", oldPath, nil, headers)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// toMetadata returns the metadata of the entry, the ids are the paths.
+func (e *davEntry) toMetadata() *api.Metadata {
+	p := e.path
+	md := &api.Metadata{
+		Id:    p,
+		Path:  p,
+		Size:  e.size,
+		Mtime: e.mtime,
+		IsDir: e.isDir,
+		Etag:  e.etag,
+		Mime:  e.mime,
+	}
+	if md.Etag == "" {
+		md.Etag = fmt.Sprintf("\"%d\"", e.mtime)
+	}
+	if md.Mime == "" || e.isDir {
+		md.Mime = api.DetectMimeType(e.isDir, p)
+	}
+	return md
+}
+
+// NewHTTPClient returns a client waiting up to timeout for the responses,
+// the transfer of the files themselves is not limited.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, ResponseHeaderTimeout: timeout}}
+}
@@ -0,0 +1,212 @@
+package storage_webdav
+
+import (
+	"context"
+	"io"
+	"net/http"
+	gopath "path"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"go.uber.org/zap"
+)
+
+type Options struct {
+	// URL of the WebDAV folder mounted, like https://cloud.example.org/remote.php/webdav
+	URL string `json:"url"`
+
+	// Username and Password authenticate the users without their own credentials,
+	// the requests are anonymous if Username is empty.
+	Username string `json:"username"`
+	Password string `json:"password"`
+
+	// Credentials maps account ids to the credentials they use on the server.
+	Credentials map[string]*Credentials `json:"credentials"`
+
+	Timeout int `json:"timeout"` // seconds to wait for the responses of the server
+
+	// Client sends the requests, it is created from Timeout if nil.
+	Client *http.Client `json:"-"`
+}
+
+// Credentials are the basic auth credentials of a user on the server.
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (opt *Options) init() {
+	if opt.Timeout == 0 {
+		opt.Timeout = 30
+	}
+	if opt.Client == nil {
+		opt.Client = NewHTTPClient(time.Second * time.Duration(opt.Timeout))
+	}
+}
+
+type webdavStorage struct {
+	opt    *Options
+	logger *zap.Logger
+}
+
+// New returns a storage accessing the files of a WebDAV server.
+func New(opt *Options, logger *zap.Logger) (api.Storage, error) {
+	opt.init()
+	// fail early on an invalid url
+	if _, err := newWebDAVClient(opt.URL, "", "", opt.Client); err != nil {
+		return nil, err
+	}
+	return &webdavStorage{opt: opt, logger: logger}, nil
+}
+
+// getClient returns the client authenticated as the user in the context.
+func (fs *webdavStorage) getClient(ctx context.Context) (*webdavClient, error) {
+	username, password := fs.opt.Username, fs.opt.Password
+	if u, ok := api.ContextGetUser(ctx); ok {
+		if c, ok := fs.opt.Credentials[u.AccountId]; ok {
+			username, password = c.Username, c.Password
+		}
+	}
+	fs.logger.Debug("webdav client", zap.String("url", fs.opt.URL), zap.String("username", username))
+	return newWebDAVClient(fs.opt.URL, username, password, fs.opt.Client)
+}
+
+func (fs *webdavStorage) GetPathByID(ctx context.Context, id string) (string, error) {
+	// the ids are the paths of the entries
+	p := gopath.Join("/", id)
+	if _, err := fs.GetMetadata(ctx, p); err != nil {
+		return "", err
+	}
+	return p, nil
+}
+
+func (fs *webdavStorage) GetMetadata(ctx context.Context, name string) (*api.Metadata, error) {
+	client, err := fs.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := client.propfind(ctx, name, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, api.NewError(api.StorageNotFoundErrorCode).WithMessage(name)
+	}
+	return entries[0].toMetadata(), nil
+}
+
+func (fs *webdavStorage) ListFolder(ctx context.Context, name string) ([]*api.Metadata, error) {
+	client, err := fs.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := client.propfind(ctx, name, 1)
+	if err != nil {
+		return nil, err
+	}
+	p := gopath.Join("/", name)
+	mds := []*api.Metadata{}
+	for _, e := range entries {
+		// the folder itself is in the response
+		if e.path == p {
+			continue
+		}
+		mds = append(mds, e.toMetadata())
+	}
+	return mds, nil
+}
+
+func (fs *webdavStorage) Download(ctx context.Context, name string) (io.ReadCloser, error) {
+	client, err := fs.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.get(ctx, name)
+}
+
+func (fs *webdavStorage) Upload(ctx context.Context, name string, r io.ReadCloser) error {
+	defer r.Close()
+	client, err := fs.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.put(ctx, name, r)
+}
+
+func (fs *webdavStorage) Move(ctx context.Context, oldName, newName string) error {
+	client, err := fs.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.move(ctx, oldName, newName)
+}
+
+func (fs *webdavStorage) CreateDir(ctx context.Context, name string) error {
+	client, err := fs.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.mkcol(ctx, name)
+}
+
+func (fs *webdavStorage) Delete(ctx context.Context, name string) error {
+	client, err := fs.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.delete(ctx, name)
+}
+
+// GetQuota returns the quota of the folder reported by the server with
+// the quota properties of RFC 4331.
+func (fs *webdavStorage) GetQuota(ctx context.Context, name string) (int, int, error) {
+	client, err := fs.getClient(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	entries, err := client.propfind(ctx, name, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(entries) == 0 || entries[0].quotaUsed < 0 || entries[0].quotaAvailable < 0 {
+		return 0, 0, api.NewError(api.StorageNotSupportedErrorCode).WithMessage("quota not reported by the server")
+	}
+	e := entries[0]
+	return int(e.quotaUsed + e.quotaAvailable), int(e.quotaUsed), nil
+}
+
+func (fs *webdavStorage) SetACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+	return api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *webdavStorage) UnsetACL(ctx context.Context, path string, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+	return api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *webdavStorage) UpdateACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+	return api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *webdavStorage) ListRevisions(ctx context.Context, path string) ([]*api.Revision, error) {
+	return nil, api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *webdavStorage) DownloadRevision(ctx context.Context, path, revisionKey string) (io.ReadCloser, error) {
+	return nil, api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *webdavStorage) RestoreRevision(ctx context.Context, path, revisionKey string) error {
+	return api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *webdavStorage) EmptyRecycle(ctx context.Context, path string) error {
+	return api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *webdavStorage) ListRecycle(ctx context.Context, path string) ([]*api.RecycleEntry, error) {
+	return nil, api.NewError(api.StorageNotSupportedErrorCode)
+}
+
+func (fs *webdavStorage) RestoreRecycleEntry(ctx context.Context, restoreKey string) error {
+	return api.NewError(api.StorageNotSupportedErrorCode)
+}
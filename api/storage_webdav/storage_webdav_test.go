@@ -0,0 +1,118 @@
+package storage_webdav_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+	"github.com/cernbox/reva/api/storage_webdav"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/webdav"
+)
+
+// newServer returns a WebDAV server serving its files under /dav
+// to the clients authenticated with one of users.
+func newServer(users map[string]string) *httptest.Server {
+	h := &webdav.Handler{Prefix: "/dav", FileSystem: webdav.NewMemFS(), LockSystem: webdav.NewMemLS()}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || users[username] == "" || users[username] != password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
+}
+
+func TestWebDAVStorage(t *testing.T) {
+	server := newServer(map[string]string{"service": "secret"})
+	defer server.Close()
+
+	fs, err := storage_webdav.New(&storage_webdav.Options{URL: server.URL + "/dav", Username: "service", Password: "secret"}, zap.NewNop())
+	conformance.Check(t, err)
+	ctx := conformance.UserContext("alice")
+
+	conformance.Check(t, fs.CreateDir(ctx, "/dir"))
+	conformance.ExpectCode(t, fs.CreateDir(ctx, "/dir"), api.StorageAlreadyExistsErrorCode)
+	conformance.Check(t, fs.Upload(ctx, "/dir/file.txt", ioutil.NopCloser(strings.NewReader("hello"))))
+
+	md, err := fs.GetMetadata(ctx, "/dir/file.txt")
+	conformance.Check(t, err)
+	if md.Path != "/dir/file.txt" || md.IsDir || md.Size != 5 || md.Etag == "" || md.Mime != "text/plain" {
+		t.Fatalf("unexpected metadata: %+v", md)
+	}
+	p, err := fs.GetPathByID(ctx, md.Id)
+	conformance.Check(t, err)
+	if p != md.Path {
+		t.Fatalf("expected path %s for id %s, got %s", md.Path, md.Id, p)
+	}
+
+	md, err = fs.GetMetadata(ctx, "/")
+	conformance.Check(t, err)
+	if md.Path != "/" || !md.IsDir {
+		t.Fatalf("unexpected metadata of the root: %+v", md)
+	}
+
+	mds, err := fs.ListFolder(ctx, "/dir")
+	conformance.Check(t, err)
+	if len(mds) != 1 || mds[0].Path != "/dir/file.txt" {
+		t.Fatalf("unexpected folder content: %+v", mds)
+	}
+
+	conformance.Check(t, fs.Move(ctx, "/dir/file.txt", "/file.txt"))
+	r, err := fs.Download(ctx, "/file.txt")
+	conformance.Check(t, err)
+	data, err := ioutil.ReadAll(r)
+	r.Close()
+	conformance.Check(t, err)
+	if string(data) != "hello" {
+		t.Fatalf("unexpected content %q", data)
+	}
+	_, err = fs.Download(ctx, "/dir/file.txt")
+	conformance.ExpectCode(t, err, api.StorageNotFoundErrorCode)
+	_, err = fs.GetMetadata(ctx, "/missing")
+	conformance.ExpectCode(t, err, api.StorageNotFoundErrorCode)
+
+	conformance.Check(t, fs.Delete(ctx, "/dir"))
+	mds, err = fs.ListFolder(ctx, "/")
+	conformance.Check(t, err)
+	if len(mds) != 1 || mds[0].Path != "/file.txt" {
+		t.Fatalf("unexpected folder content: %+v", mds)
+	}
+
+	// the test server does not report quotas
+	_, _, err = fs.GetQuota(ctx, "/")
+	conformance.ExpectCode(t, err, api.StorageNotSupportedErrorCode)
+	_, err = fs.ListRevisions(ctx, "/file.txt")
+	conformance.ExpectCode(t, err, api.StorageNotSupportedErrorCode)
+}
+
+func TestWebDAVStorageCredentials(t *testing.T) {
+	server := newServer(map[string]string{"alice": "alice-secret"})
+	defer server.Close()
+
+	opt := &storage_webdav.Options{
+		URL:         server.URL + "/dav/",
+		Credentials: map[string]*storage_webdav.Credentials{"alice": {Username: "alice", Password: "alice-secret"}},
+	}
+	fs, err := storage_webdav.New(opt, zap.NewNop())
+	conformance.Check(t, err)
+
+	// the users use their own credentials, the others are anonymous
+	_, err = fs.GetMetadata(conformance.UserContext("alice"), "/")
+	conformance.Check(t, err)
+	_, err = fs.GetMetadata(conformance.UserContext("bob"), "/")
+	conformance.ExpectCode(t, err, api.StoragePermissionDeniedErrorCode)
+	_, err = fs.GetMetadata(context.Background(), "/")
+	conformance.ExpectCode(t, err, api.StoragePermissionDeniedErrorCode)
+
+	_, err = storage_webdav.New(&storage_webdav.Options{URL: "://invalid"}, zap.NewNop())
+	if err == nil {
+		t.Fatal("expected an error for an invalid url")
+	}
+}
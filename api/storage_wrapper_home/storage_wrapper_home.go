@@ -56,26 +56,26 @@ func (fs *homeStorage) removeNamespace(ctx context.Context, user *api.User, np s
 	return "", err
 }
 
-func (fs *homeStorage) SetACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (fs *homeStorage) SetACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	u, err := getUserFromContext(ctx)
 	if err != nil {
 		return err
 	}
 	path = fs.getInternalPath(ctx, u, path)
-	err = fs.wrappedStorage.SetACL(ctx, path, readOnly, recipient, shareList)
+	err = fs.wrappedStorage.SetACL(ctx, path, permissions, recipient, shareList)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (fs *homeStorage) UpdateACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (fs *homeStorage) UpdateACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	u, err := getUserFromContext(ctx)
 	if err != nil {
 		return err
 	}
 	path = fs.getInternalPath(ctx, u, path)
-	err = fs.wrappedStorage.UpdateACL(ctx, path, readOnly, recipient, shareList)
+	err = fs.wrappedStorage.UpdateACL(ctx, path, permissions, recipient, shareList)
 	if err != nil {
 		return err
 	}
@@ -185,6 +185,17 @@ func (fs *homeStorage) Move(ctx context.Context, oldPath, newPath string) error
 	return fs.wrappedStorage.Move(ctx, oldPath, newPath)
 }
 
+// TransferOwnership moves the files from the home of from to the home of to.
+// The wrapped storages with no notion of owners only need the files moved.
+func (fs *homeStorage) TransferOwnership(ctx context.Context, from, fromPath, to, toPath string) error {
+	fromPath = fs.getInternalPath(ctx, &api.User{AccountId: from}, fromPath)
+	toPath = fs.getInternalPath(ctx, &api.User{AccountId: to}, toPath)
+	if ts, ok := fs.wrappedStorage.(api.OwnershipTransferStorage); ok {
+		return ts.TransferOwnership(ctx, from, fromPath, to, toPath)
+	}
+	return fs.wrappedStorage.Move(ctx, fromPath, toPath)
+}
+
 func (fs *homeStorage) Download(ctx context.Context, path string) (io.ReadCloser, error) {
 	u, err := getUserFromContext(ctx)
 	if err != nil {
@@ -0,0 +1,256 @@
+package storage_wrapper_share_lifecycle
+
+import (
+	"context"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+)
+
+// New returns the virtual storage keeping the shares and the public links in
+// step with their files: they are suspended when the files are deleted, and
+// reactivated or purged when the files are restored or purged from the
+// recycle bin. The shares are updated on a best effort basis and the failures
+// are left to the share fsck. They are suspended before the files are deleted,
+// as the ownCloud links are kept on version folders that cannot be resolved
+// after, and reactivated if the deletion fails. Otherwise the files are
+// changed first.
+func New(vs api.VirtualStorage, sm api.ShareManager, lm api.PublicLinkManager) api.VirtualStorage {
+	return &lifecycleStorage{VirtualStorage: vs, sm: sm, lm: lm}
+}
+
+type lifecycleStorage struct {
+	api.VirtualStorage
+	sm api.ShareManager
+	lm api.PublicLinkManager
+}
+
+// reference is a share or a public link, with the id of its file.
+type reference struct {
+	fileID    string
+	suspended bool
+}
+
+func (fs *lifecycleStorage) Delete(ctx context.Context, p string) error {
+	l := ctx_zap.Extract(ctx)
+
+	// the files under p are only known before they go to the recycle bin
+	refs, err := fs.getReferences(ctx)
+	if err != nil {
+		l.Error("error getting the shares of the user", zap.Error(err))
+	}
+	fileIDs, err := fs.getFileIDsUnder(ctx, p, refs)
+	if err != nil {
+		l.Error("error getting the shares under deleted path", zap.Error(err), zap.String("path", p))
+	}
+
+	fs.suspend(ctx, fileIDs, true)
+	if err := fs.VirtualStorage.Delete(ctx, p); err != nil {
+		fs.suspend(ctx, fileIDs, false)
+		return err
+	}
+	return nil
+}
+
+func (fs *lifecycleStorage) RestoreRecycleEntry(ctx context.Context, restoreKey string) error {
+	l := ctx_zap.Extract(ctx)
+
+	// the restore path is only known while the entry is in the recycle bin
+	entry, err := fs.getRecycleEntry(ctx, restoreKey)
+	if err != nil {
+		l.Error("error getting the recycle entry to restore", zap.Error(err), zap.String("key", restoreKey))
+	}
+	if err := fs.VirtualStorage.RestoreRecycleEntry(ctx, restoreKey); err != nil {
+		return err
+	}
+	if entry == nil {
+		return nil
+	}
+
+	// the suspended references found again are those of the restored files
+	fileIDs, err := fs.getFileIDsUnder(ctx, entry.RestorePath, fs.getSuspendedReferences(ctx))
+	if err != nil {
+		l.Error("error getting the shares under restored path", zap.Error(err), zap.String("path", entry.RestorePath))
+	}
+	fs.suspend(ctx, fileIDs, false)
+	return nil
+}
+
+func (fs *lifecycleStorage) EmptyRecycle(ctx context.Context, p string) error {
+	l := ctx_zap.Extract(ctx)
+	entries, err := fs.VirtualStorage.ListRecycle(ctx, p)
+	if err != nil {
+		l.Error("error listing the recycle bin to empty", zap.Error(err), zap.String("path", p))
+	}
+	if err := fs.VirtualStorage.EmptyRecycle(ctx, p); err != nil {
+		return err
+	}
+
+	// the suspended references of the purged entries are those of the purged
+	// files. The files under the folders are not listed in their entries and
+	// some storages do not know the ids of the entries, the files of the
+	// other suspended references are looked up in their case.
+	purged := map[string]bool{}
+	lookup := err != nil // the entries could not be listed
+	for _, e := range entries {
+		purged[e.FileId] = e.FileId != ""
+		lookup = lookup || e.IsDir || e.FileId == ""
+	}
+	fileIDs := []string{}
+	for _, ref := range fs.getSuspendedReferences(ctx) {
+		if purged[ref.fileID] {
+			fileIDs = append(fileIDs, ref.fileID)
+			continue
+		}
+		if !lookup {
+			continue
+		}
+		if _, err := fs.VirtualStorage.GetMetadata(ctx, ref.fileID); api.IsErrorCode(err, api.StorageNotFoundErrorCode) {
+			fileIDs = append(fileIDs, ref.fileID)
+		}
+	}
+	if len(fileIDs) == 0 {
+		return nil
+	}
+	shares, err := fs.sm.PurgeFolderShares(ctx, fileIDs)
+	if err != nil {
+		l.Error("error purging shares of purged files", zap.Error(err))
+	}
+	for _, share := range shares {
+		l.Info("share purged with its file", zap.String("share_id", share.Id))
+	}
+	links, err := fs.lm.PurgePublicLinks(ctx, fileIDs)
+	if err != nil {
+		l.Error("error purging public links of purged files", zap.Error(err))
+	}
+	for _, pl := range links {
+		l.Info("public link purged with its file", zap.String("id", pl.Id))
+	}
+	return nil
+}
+
+// getFileIDsUnder returns the id of the file at p and the ids of the files
+// under it with one of refs. The folders under p are listed rather than the
+// files of refs looked up, so the calls to the storage grow with the tree
+// and not with the shares of the user.
+func (fs *lifecycleStorage) getFileIDsUnder(ctx context.Context, p string, refs []*reference) ([]string, error) {
+	md, err := fs.VirtualStorage.GetMetadata(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	fileIDs := []string{getFileID(md)}
+	if !md.IsDir || len(refs) == 0 {
+		return fileIDs, nil
+	}
+
+	referenced := map[string]bool{}
+	for _, ref := range refs {
+		referenced[ref.fileID] = true
+	}
+	folders := []string{md.Path}
+	for len(folders) > 0 {
+		children, err := fs.VirtualStorage.ListFolder(ctx, folders[0])
+		if err != nil {
+			return fileIDs, err
+		}
+		folders = folders[1:]
+		for _, child := range children {
+			if id := getFileID(child); referenced[id] {
+				fileIDs = append(fileIDs, id)
+			}
+			if child.IsDir {
+				folders = append(folders, child.Path)
+			}
+		}
+	}
+	return fileIDs, nil
+}
+
+// getRecycleEntry returns the entry of the recycle bin with restoreKey.
+func (fs *lifecycleStorage) getRecycleEntry(ctx context.Context, restoreKey string) (*api.RecycleEntry, error) {
+	m, err := fs.VirtualStorage.GetMount(restoreKey)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := fs.VirtualStorage.ListRecycle(ctx, m.GetMountPoint())
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.RestoreKey == restoreKey {
+			return e, nil
+		}
+	}
+	return nil, api.NewError(api.StorageNotFoundErrorCode).WithMessage("recycle entry " + restoreKey)
+}
+
+// getReferences returns the shares and the public links managed by the user.
+func (fs *lifecycleStorage) getReferences(ctx context.Context) ([]*reference, error) {
+	shares, err := fs.sm.ListFolderShares(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	links, err := fs.lm.ListPublicLinks(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	refs := []*reference{}
+	for _, share := range shares {
+		refs = append(refs, &reference{fileID: share.Path, suspended: share.Suspended})
+	}
+	for _, pl := range links {
+		refs = append(refs, &reference{fileID: pl.Path, suspended: pl.Suspended})
+	}
+	return refs, nil
+}
+
+func (fs *lifecycleStorage) getSuspendedReferences(ctx context.Context) []*reference {
+	l := ctx_zap.Extract(ctx)
+	refs, err := fs.getReferences(ctx)
+	if err != nil {
+		l.Error("error getting the suspended shares", zap.Error(err))
+		return nil
+	}
+	suspended := []*reference{}
+	for _, ref := range refs {
+		if ref.suspended {
+			suspended = append(suspended, ref)
+		}
+	}
+	return suspended
+}
+
+// suspend suspends or reactivates the shares and the public links of the files.
+func (fs *lifecycleStorage) suspend(ctx context.Context, fileIDs []string, suspended bool) {
+	l := ctx_zap.Extract(ctx)
+	if len(fileIDs) == 0 {
+		return
+	}
+	shares, err := fs.sm.SuspendFolderShares(ctx, fileIDs, suspended)
+	if err != nil {
+		l.Error("error changing the suspension of shares", zap.Error(err), zap.Bool("suspended", suspended))
+	}
+	for _, share := range shares {
+		l.Info("share suspension changed with its file", zap.String("share_id", share.Id), zap.Bool("suspended", suspended))
+	}
+	links, err := fs.lm.SuspendPublicLinks(ctx, fileIDs, suspended)
+	if err != nil {
+		l.Error("error changing the suspension of public links", zap.Error(err), zap.Bool("suspended", suspended))
+	}
+	for _, pl := range links {
+		l.Info("public link suspension changed with its file", zap.String("id", pl.Id), zap.Bool("suspended", suspended))
+	}
+}
+
+// getFileID returns the id of the file for its owner, as the managers keep it.
+func getFileID(md *api.Metadata) string {
+	if md.ShareFileId != "" {
+		return md.ShareFileId
+	}
+	if md.MigId != "" {
+		return md.MigId
+	}
+	return md.Id
+}
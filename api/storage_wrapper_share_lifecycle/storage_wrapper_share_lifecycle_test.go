@@ -0,0 +1,103 @@
+package storage_wrapper_share_lifecycle_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+	"github.com/cernbox/reva/api/mount"
+	"github.com/cernbox/reva/api/public_link_manager_memory"
+	"github.com/cernbox/reva/api/share_manager_memory"
+	"github.com/cernbox/reva/api/storage_wrapper_share_lifecycle"
+	"github.com/cernbox/reva/api/virtual_storage"
+
+	"go.uber.org/zap"
+)
+
+func TestShareLifecycle(t *testing.T) {
+	ctx := context.Background()
+	vfs := virtual_storage.NewVFS(zap.NewNop(), nil)
+	conformance.Check(t, vfs.AddMount(ctx, mount.New("home", "/", nil, conformance.NewMemoryStorage())))
+	conformance.Check(t, vfs.CreateDir(ctx, "/alice"))
+	conformance.Check(t, vfs.CreateDir(ctx, "/alice/dir"))
+	conformance.Check(t, vfs.CreateDir(ctx, "/alice/dir/sub"))
+
+	sm := share_manager_memory.New(vfs, conformance.NewUserManager())
+	lm := public_link_manager_memory.New(vfs, nil, nil)
+	fs := storage_wrapper_share_lifecycle.New(vfs, sm, lm)
+	aliceCtx := conformance.UserContext("alice")
+	bobCtx := conformance.UserContext("bob")
+	share, err := sm.AddFolderShare(aliceCtx, "/alice/dir/sub", &api.ShareRecipient{Identity: "bob", Type: api.ShareRecipient_USER}, &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	conformance.Check(t, err)
+	pl, err := lm.CreatePublicLink(aliceCtx, "/alice/dir", &api.PublicLinkOptions{ReadOnly: true})
+	conformance.Check(t, err)
+
+	// deleting the folder suspends the shares of the files under it
+	conformance.Check(t, fs.Delete(aliceCtx, "/alice/dir"))
+	_, err = sm.GetReceivedFolderShare(bobCtx, share.Id)
+	conformance.ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+	_, err = lm.AuthenticatePublicLink(ctx, pl.Token, "")
+	conformance.ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+
+	// restoring it reactivates them
+	entries, err := fs.ListRecycle(aliceCtx, "/alice")
+	conformance.Check(t, err)
+	if len(entries) != 1 {
+		t.Fatalf("expected one recycle entry, got %d", len(entries))
+	}
+	conformance.Check(t, fs.RestoreRecycleEntry(aliceCtx, entries[0].RestoreKey))
+	_, err = sm.GetReceivedFolderShare(bobCtx, share.Id)
+	conformance.Check(t, err)
+	_, err = lm.AuthenticatePublicLink(ctx, pl.Token, "")
+	conformance.Check(t, err)
+
+	// emptying the recycle bin purges them
+	conformance.Check(t, fs.Delete(aliceCtx, "/alice/dir"))
+	conformance.Check(t, fs.EmptyRecycle(aliceCtx, "/alice"))
+	_, err = sm.GetFolderShare(aliceCtx, share.Id)
+	conformance.ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+	_, err = lm.InspectPublicLink(aliceCtx, pl.Id)
+	conformance.ExpectCode(t, err, api.PublicLinkNotFoundErrorCode)
+}
+
+// countingStorage counts the metadata lookups.
+type countingStorage struct {
+	api.VirtualStorage
+	lookups int
+}
+
+func (fs *countingStorage) GetMetadata(ctx context.Context, p string) (*api.Metadata, error) {
+	fs.lookups++
+	return fs.VirtualStorage.GetMetadata(ctx, p)
+}
+
+func TestDeleteLookups(t *testing.T) {
+	ctx := context.Background()
+	vfs := virtual_storage.NewVFS(zap.NewNop(), nil)
+	conformance.Check(t, vfs.AddMount(ctx, mount.New("home", "/", nil, conformance.NewMemoryStorage())))
+	for _, p := range []string{"/alice", "/alice/dir", "/alice/dir/sub", "/alice/other", "/alice/other/a", "/alice/other/b"} {
+		conformance.Check(t, vfs.CreateDir(ctx, p))
+	}
+
+	sm := share_manager_memory.New(vfs, conformance.NewUserManager())
+	lm := public_link_manager_memory.New(vfs, nil, nil)
+	cs := &countingStorage{VirtualStorage: vfs}
+	fs := storage_wrapper_share_lifecycle.New(cs, sm, lm)
+	aliceCtx := conformance.UserContext("alice")
+	bobCtx := conformance.UserContext("bob")
+	share, err := sm.AddFolderShare(aliceCtx, "/alice/dir/sub", &api.ShareRecipient{Identity: "bob", Type: api.ShareRecipient_USER}, &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	conformance.Check(t, err)
+	for _, p := range []string{"/alice/other", "/alice/other/a", "/alice/other/b"} {
+		_, err := lm.CreatePublicLink(aliceCtx, p, &api.PublicLinkOptions{ReadOnly: true})
+		conformance.Check(t, err)
+	}
+
+	// the deleted folder is listed, the files of the other shares are not looked up
+	conformance.Check(t, fs.Delete(aliceCtx, "/alice/dir"))
+	if cs.lookups != 1 {
+		t.Fatalf("expected one lookup, got %d", cs.lookups)
+	}
+	_, err = sm.GetReceivedFolderShare(bobCtx, share.Id)
+	conformance.ExpectCode(t, err, api.FolderShareNotFoundErrorCode)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	gopath "path"
+	"sort"
 	"strings"
 
 	"github.com/cernbox/reva/api"
@@ -16,18 +17,27 @@ import (
 
 const versionPrefix = ".sys.v#."
 
+// maxQueryIDs is the number of file IDs queried at once.
+const maxQueryIDs = 500
+
+// systemTagPrefix prefixes the uid of the system tags, that belong
+// to a project instead of to a user.
+const systemTagPrefix = "project:"
+
 type tagManager struct {
 	db  *sql.DB
 	vfs api.VirtualStorage
+	pm  api.ProjectManager
+	um  api.UserManager
 }
 
-func New(dbUsername, dbPassword, dbHost string, dbPort int, dbName string, vfs api.VirtualStorage) api.TagManager {
+func New(dbUsername, dbPassword, dbHost string, dbPort int, dbName string, vfs api.VirtualStorage, pm api.ProjectManager, um api.UserManager) api.TagManager {
 	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", dbUsername, dbPassword, dbHost, dbPort, dbName))
 	if err != nil {
 		panic(err)
 	}
 
-	return &tagManager{db: db, vfs: vfs}
+	return &tagManager{db: db, vfs: vfs, pm: pm, um: um}
 }
 
 func (lm *tagManager) getTag(ctx context.Context, uid, prefix, fileID, key string) (*api.Tag, error) {
@@ -40,7 +50,7 @@ func (lm *tagManager) getTag(ctx context.Context, uid, prefix, fileID, key strin
 	query := "select id,item_type,coalesce(tag_val, '') as tag_val from cbox_metadata where uid=? and fileid_prefix=? and fileid=? and tag_key=?"
 	if err := lm.db.QueryRow(query, uid, prefix, fileID, key).Scan(&id, &itemType, &tagVal); err != nil {
 		if err == sql.ErrNoRows {
-			return nil, api.NewError(api.PublicLinkNotFoundErrorCode)
+			return nil, api.NewError(api.TagNotFoundErrorCode)
 		}
 		return nil, err
 	}
@@ -50,18 +60,13 @@ func (lm *tagManager) getTag(ctx context.Context, uid, prefix, fileID, key strin
 
 }
 
-func (lm *tagManager) SetTag(ctx context.Context, key, val, path string) error {
-	l := ctx_zap.Extract(ctx)
-	u, err := getUserFromContext(ctx)
-	if err != nil {
-		l.Error("error getting user from ctx", zap.Error(err))
-		return err
-	}
-
+// getFileID returns the file ID the tags of path are bound to, that for files
+// is the ID of their versions folder. The versions folder is created if create
+// is set and it is missing, otherwise the file ID is empty.
+func (lm *tagManager) getFileID(ctx context.Context, path string, create bool) (*api.Metadata, string, string, error) {
 	md, err := lm.vfs.GetMetadata(ctx, path)
 	if err != nil {
-		l.Error("error getting md for path", zap.String("path", path), zap.Error(err))
-		return err
+		return nil, "", "", err
 	}
 
 	var fileID string
@@ -72,29 +77,66 @@ func (lm *tagManager) SetTag(ctx context.Context, key, val, path string) error {
 	}
 
 	prefix, fileID := splitFileID(fileID)
+	if !md.IsDir {
+		var versionFolderID string
+		if create {
+			versionFolderID, err = lm.getVersionFolderID(ctx, md.Path)
+		} else {
+			versionFolderID, err = lm.findVersionFolderID(ctx, md.Path)
+		}
+		if err != nil {
+			return nil, "", "", err
+		}
+		if versionFolderID == "" {
+			return md, prefix, "", nil
+		}
+		_, fileID = splitFileID(versionFolderID)
+	}
+	return md, prefix, fileID, nil
+}
+
+func (lm *tagManager) SetTag(ctx context.Context, key, val, path string) error {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("error getting user from ctx", zap.Error(err))
+		return err
+	}
+	return lm.setTag(ctx, u.AccountId, key, val, path)
+}
 
-	var itemType api.Tag_ItemType
+// SetSystemTag sets a tag of the project on path, only the owner,
+// the admins and the writers of the project can set them.
+func (lm *tagManager) SetSystemTag(ctx context.Context, project, key, val, path string) error {
+	l := ctx_zap.Extract(ctx)
+	if err := lm.checkProjectMember(ctx, project, true); err != nil {
+		l.Error("error checking project membership", zap.String("project", project), zap.Error(err))
+		return err
+	}
+	return lm.setTag(ctx, systemTagPrefix+project, key, val, path)
+}
+
+func (lm *tagManager) setTag(ctx context.Context, uid, key, val, path string) error {
+	l := ctx_zap.Extract(ctx)
+	md, prefix, fileID, err := lm.getFileID(ctx, path, true)
+	if err != nil {
+		l.Error("error getting file id for path", zap.String("path", path), zap.Error(err))
+		return err
+	}
+
+	itemType := api.Tag_FILE
 	if md.IsDir {
 		itemType = api.Tag_FOLDER
-	} else {
-		itemType = api.Tag_FILE
-		// if link points to a file we need to use the versions folder inode.
-		versionFolderID, err := lm.getVersionFolderID(ctx, md.Path)
-		_, fileID = splitFileID(versionFolderID)
-		if err != nil {
-			l.Error("error getting versions folder for file", zap.Error(err))
-			return err
-		}
 	}
 
 	// if tag exists, we don't create a new one
-	if _, err := lm.getTag(ctx, u.AccountId, prefix, fileID, key); err == nil {
+	if _, err := lm.getTag(ctx, uid, prefix, fileID, key); err == nil {
 		l.Info("aborting creation of new tag, as tag already exists")
 		return nil
 	}
 
 	stmtString := "insert into cbox_metadata set item_type=?,uid=?,fileid_prefix=?,fileid=?,tag_key=?,tag_val=?"
-	stmtValues := []interface{}{itemType, u.AccountId, prefix, fileID, key, val}
+	stmtValues := []interface{}{itemType, uid, prefix, fileID, key, val}
 
 	stmt, err := lm.db.Prepare(stmtString)
 	if err != nil {
@@ -114,7 +156,7 @@ func (lm *tagManager) SetTag(ctx context.Context, key, val, path string) error {
 		return err
 	}
 
-	l.Info("tag inserted", zap.Int64("id", lastId), zap.String("key", key), zap.String("val", val), zap.String("uid", u.AccountId))
+	l.Info("tag inserted", zap.Int64("id", lastId), zap.String("key", key), zap.String("val", val), zap.String("uid", uid))
 	return nil
 }
 
@@ -125,29 +167,28 @@ func (lm *tagManager) UnSetTag(ctx context.Context, key, val, path string) error
 		l.Error("error getting user from ctx", zap.Error(err))
 		return err
 	}
+	return lm.unSetTag(ctx, u.AccountId, key, path)
+}
 
-	md, err := lm.vfs.GetMetadata(ctx, path)
+func (lm *tagManager) UnSetSystemTag(ctx context.Context, project, key, val, path string) error {
+	l := ctx_zap.Extract(ctx)
+	if err := lm.checkProjectMember(ctx, project, true); err != nil {
+		l.Error("error checking project membership", zap.String("project", project), zap.Error(err))
+		return err
+	}
+	return lm.unSetTag(ctx, systemTagPrefix+project, key, path)
+}
+
+func (lm *tagManager) unSetTag(ctx context.Context, uid, key, path string) error {
+	l := ctx_zap.Extract(ctx)
+	_, prefix, fileID, err := lm.getFileID(ctx, path, false)
 	if err != nil {
 		// return nil as the orphan background job will clean orphans
-		l.Error("error getting md for path, tag is orphan", zap.String("path", path), zap.Error(err))
+		l.Error("error getting file id for path, tag is orphan", zap.String("path", path), zap.Error(err))
 		return nil
 	}
-
-	var fileID string
-	if md.MigId != "" {
-		fileID = md.MigId
-	} else {
-		fileID = md.Id
-	}
-
-	prefix, fileID := splitFileID(fileID)
-	if !md.IsDir {
-		versionFolderID, err := lm.getVersionFolderID(ctx, md.Path)
-		_, fileID = splitFileID(versionFolderID)
-		if err != nil {
-			l.Error("error getting versions folder for file", zap.Error(err))
-			return err
-		}
+	if fileID == "" {
+		return nil
 	}
 
 	stmt, err := lm.db.Prepare("delete from cbox_metadata where uid=? and fileid_prefix=? and fileid=? and tag_key=?")
@@ -156,7 +197,7 @@ func (lm *tagManager) UnSetTag(ctx context.Context, key, val, path string) error
 		return err
 	}
 
-	res, err := stmt.Exec(u.AccountId, prefix, fileID, key)
+	res, err := stmt.Exec(uid, prefix, fileID, key)
 	if err != nil {
 		l.Error("error executing stmt for removing tag", zap.Error(err))
 		return err
@@ -171,6 +212,109 @@ func (lm *tagManager) UnSetTag(ctx context.Context, key, val, path string) error
 	return nil
 }
 
+// RenameTag renames all the tags of the user with oldKey,
+// the files already tagged with newKey keep a single tag.
+func (lm *tagManager) RenameTag(ctx context.Context, oldKey, newKey string) error {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("error getting user from ctx", zap.Error(err))
+		return err
+	}
+	return lm.renameTag(ctx, u.AccountId, oldKey, newKey)
+}
+
+// RenameSystemTag renames a tag of the project, only the owner
+// and the admins of the project can rename them.
+func (lm *tagManager) RenameSystemTag(ctx context.Context, project, oldKey, newKey string) error {
+	l := ctx_zap.Extract(ctx)
+	if err := lm.checkProjectMember(ctx, project, false); err != nil {
+		l.Error("error checking project membership", zap.String("project", project), zap.Error(err))
+		return err
+	}
+	return lm.renameTag(ctx, systemTagPrefix+project, oldKey, newKey)
+}
+
+func (lm *tagManager) renameTag(ctx context.Context, uid, oldKey, newKey string) error {
+	l := ctx_zap.Extract(ctx)
+	if newKey == "" {
+		return api.NewError(api.PathInvalidError).WithMessage("the new tag key can not be empty")
+	}
+	if oldKey == newKey {
+		return nil
+	}
+
+	tx, err := lm.db.Begin()
+	if err != nil {
+		l.Error("error starting transaction", zap.Error(err))
+		return err
+	}
+	defer tx.Rollback()
+
+	existing := map[string]bool{}
+	rows, err := tx.Query("select fileid_prefix, fileid from cbox_metadata where uid=? and tag_key=?", uid, newKey)
+	if err != nil {
+		l.Error("error querying tags", zap.Error(err))
+		return err
+	}
+	for rows.Next() {
+		var prefix, fileID string
+		if err := rows.Scan(&prefix, &fileID); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[joinFileID(prefix, fileID)] = true
+	}
+	rows.Close()
+
+	type row struct {
+		id     int64
+		fileID string
+	}
+	renamed := []*row{}
+	rows, err = tx.Query("select id, fileid_prefix, fileid from cbox_metadata where uid=? and tag_key=?", uid, oldKey)
+	if err != nil {
+		l.Error("error querying tags", zap.Error(err))
+		return err
+	}
+	for rows.Next() {
+		var prefix, fileID string
+		r := &row{}
+		if err := rows.Scan(&r.id, &prefix, &fileID); err != nil {
+			rows.Close()
+			return err
+		}
+		r.fileID = joinFileID(prefix, fileID)
+		renamed = append(renamed, r)
+	}
+	rows.Close()
+
+	if len(renamed) == 0 {
+		return api.NewError(api.TagNotFoundErrorCode).WithMessage(oldKey)
+	}
+
+	for _, r := range renamed {
+		if existing[r.fileID] {
+			_, err = tx.Exec("delete from cbox_metadata where id=?", r.id)
+		} else {
+			_, err = tx.Exec("update cbox_metadata set tag_key=? where id=?", newKey, r.id)
+		}
+		if err != nil {
+			l.Error("error renaming tag", zap.Int64("id", r.id), zap.Error(err))
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		l.Error("error committing transaction", zap.Error(err))
+		return err
+	}
+	l.Info("tag renamed", zap.String("uid", uid), zap.String("key", oldKey), zap.String("new_key", newKey), zap.Int("count", len(renamed)))
+	return nil
+}
+
+// GetTagsForKey returns the tags of the user and the system tags of the
+// projects of the user with the key, or all of them if the key is empty.
 func (lm *tagManager) GetTagsForKey(ctx context.Context, key string) ([]*api.Tag, error) {
 	l := ctx_zap.Extract(ctx)
 	u, err := getUserFromContext(ctx)
@@ -179,8 +323,139 @@ func (lm *tagManager) GetTagsForKey(ctx context.Context, key string) ([]*api.Tag
 		return nil, err
 	}
 
-	query := "select id, item_type, fileid_prefix, fileid, coalesce(tag_val, '') as tag_val from cbox_metadata where uid=? and tag_key=?"
-	rows, err := lm.db.Query(query, u.AccountId, key)
+	uids := lm.getTagOwners(ctx, u)
+	query := "select id, item_type, uid, fileid_prefix, fileid, tag_key, coalesce(tag_val, '') as tag_val from cbox_metadata where uid in (?" + strings.Repeat(",?", len(uids)-1) + ")"
+	args := uids
+	if key != "" {
+		query += " and tag_key=?"
+		args = append(args, key)
+	}
+
+	tags, err := lm.queryTags(query, args...)
+	if err != nil {
+		l.Error("error querying tags", zap.Error(err))
+		return nil, err
+	}
+
+	resolved := []*api.Tag{}
+	for _, tag := range tags {
+		if tag.ItemType == api.Tag_FILE {
+			fileID, err := lm.getFileIDFromVersionFolderID(ctx, joinFileID(tag.FileIdPrefix, tag.FileId))
+			if err != nil {
+				l.Warn("tag is not accessible", zap.Int64("id", tag.Id), zap.Error(err))
+				continue
+			}
+			_, tag.FileId = splitFileID(fileID)
+		}
+		resolved = append(resolved, tag)
+	}
+	return resolved, nil
+}
+
+// GetTagsForPath returns the tags of the user and the system tags
+// of the projects of the user on path.
+func (lm *tagManager) GetTagsForPath(ctx context.Context, path string) ([]*api.Tag, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("error getting user from ctx", zap.Error(err))
+		return nil, err
+	}
+
+	md, prefix, fileID, err := lm.getFileID(ctx, path, false)
+	if err != nil {
+		l.Error("error getting file id for path", zap.String("path", path), zap.Error(err))
+		return nil, err
+	}
+	if fileID == "" {
+		// files without versions folder have no tags
+		return []*api.Tag{}, nil
+	}
+
+	uids := lm.getTagOwners(ctx, u)
+	query := "select id, item_type, uid, fileid_prefix, fileid, tag_key, coalesce(tag_val, '') as tag_val from cbox_metadata where fileid_prefix=? and fileid=? and uid in (?" + strings.Repeat(",?", len(uids)-1) + ")"
+	tags, err := lm.queryTags(query, append([]interface{}{prefix, fileID}, uids...)...)
+	if err != nil {
+		l.Error("error querying tags", zap.Error(err))
+		return nil, err
+	}
+
+	_, id := splitFileID(md.Id)
+	for _, tag := range tags {
+		tag.FileId = id
+	}
+	return tags, nil
+}
+
+// GetTagsForFolder returns the tags of the user and the system tags of the projects
+// of the user on the folder and on its entries. The tags are queried by the IDs
+// of the listing, so that a listing costs the same whatever the number of tags.
+func (lm *tagManager) GetTagsForFolder(ctx context.Context, path string) ([]*api.Tag, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("error getting user from ctx", zap.Error(err))
+		return nil, err
+	}
+
+	md, err := lm.vfs.GetMetadata(ctx, path)
+	if err != nil {
+		l.Error("error getting metadata", zap.String("path", path), zap.Error(err))
+		return nil, err
+	}
+	if !md.IsDir {
+		return lm.GetTagsForPath(ctx, path)
+	}
+	mds, err := lm.vfs.ListFolder(ctx, path)
+	if err != nil {
+		l.Error("error listing folder", zap.String("path", path), zap.Error(err))
+		return nil, err
+	}
+
+	ids := getListingFileIDs(append([]*api.Metadata{md}, mds...))
+	if len(ids) == 0 {
+		return []*api.Tag{}, nil
+	}
+	fileIDs := []interface{}{}
+	for id := range ids {
+		_, fileID := splitFileID(id)
+		fileIDs = append(fileIDs, fileID)
+	}
+
+	uids := lm.getTagOwners(ctx, u)
+	tags := []*api.Tag{}
+	for len(fileIDs) > 0 {
+		n := len(fileIDs)
+		if n > maxQueryIDs {
+			n = maxQueryIDs
+		}
+		query := "select id, item_type, uid, fileid_prefix, fileid, tag_key, coalesce(tag_val, '') as tag_val from cbox_metadata where uid in (?" + strings.Repeat(",?", len(uids)-1) + ") and fileid in (?" + strings.Repeat(",?", n-1) + ")"
+		args := append(append([]interface{}{}, uids...), fileIDs[:n]...)
+		chunk, err := lm.queryTags(query, args...)
+		if err != nil {
+			l.Error("error querying tags", zap.Error(err))
+			return nil, err
+		}
+		tags = append(tags, chunk...)
+		fileIDs = fileIDs[n:]
+	}
+
+	listed := []*api.Tag{}
+	for _, tag := range tags {
+		// the inodes are only unique with the prefix
+		id, ok := ids[joinFileID(tag.FileIdPrefix, tag.FileId)]
+		if !ok {
+			continue
+		}
+		tag.FileIdPrefix, tag.FileId = splitFileID(id)
+		listed = append(listed, tag)
+	}
+	sort.Slice(listed, func(i, j int) bool { return listed[i].Id < listed[j].Id })
+	return listed, nil
+}
+
+func (lm *tagManager) queryTags(query string, args ...interface{}) ([]*api.Tag, error) {
+	rows, err := lm.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -189,49 +464,150 @@ func (lm *tagManager) GetTagsForKey(ctx context.Context, key string) ([]*api.Tag
 	var (
 		id           int64
 		itemType     int
+		uid          string
 		fileIDPrefix string
 		fileID       string
+		tagKey       string
 		tagVal       string
 	)
 
 	tags := []*api.Tag{}
 	for rows.Next() {
-		err := rows.Scan(&id, &itemType, &fileIDPrefix, &fileID, &tagVal)
+		err := rows.Scan(&id, &itemType, &uid, &fileIDPrefix, &fileID, &tagKey, &tagVal)
 		if err != nil {
 			return nil, err
 		}
 
-		tag := &api.Tag{Id: id, ItemType: api.Tag_ItemType(itemType), Uid: u.AccountId, FileIdPrefix: fileIDPrefix, FileId: fileID, TagKey: key, TagValue: tagVal}
+		tag := &api.Tag{Id: id, ItemType: api.Tag_ItemType(itemType), Uid: uid, FileIdPrefix: fileIDPrefix, FileId: fileID, TagKey: tagKey, TagValue: tagVal}
+		if strings.HasPrefix(uid, systemTagPrefix) {
+			tag.Project = strings.TrimPrefix(uid, systemTagPrefix)
+		}
+		tags = append(tags, tag)
+	}
 
-		if tag.ItemType == api.Tag_FILE {
-			fileID = joinFileID(tag.FileIdPrefix, tag.FileId)
-			md, err := lm.vfs.GetMetadata(ctx, fileID)
-			if err != nil {
-				// TOOD(labkode): log wan here
-				continue
-			}
+	return tags, rows.Err()
+}
 
-			versionFolder := md.Path
-			filename := getFileIDFromVersionFolder(versionFolder)
+// getFileIDFromVersionFolderID returns the ID of the file of a versions folder.
+func (lm *tagManager) getFileIDFromVersionFolderID(ctx context.Context, id string) (string, error) {
+	md, err := lm.vfs.GetMetadata(ctx, id)
+	if err != nil {
+		return "", err
+	}
 
-			md, err = lm.vfs.GetMetadata(ctx, filename)
-			if err != nil {
-				// TOOD(labkode): log wan here
-				continue
-			}
-			_, id := splitFileID(md.Id)
-			tag.FileId = id
-		}
+	filename := getFileIDFromVersionFolder(md.Path)
+	md, err = lm.vfs.GetMetadata(ctx, filename)
+	if err != nil {
+		return "", err
+	}
+	return md.Id, nil
+}
 
-		tags = append(tags, tag)
+// getTagOwners returns the uids of the tags the user sees: the user and
+// the projects the user is owner or member of.
+func (lm *tagManager) getTagOwners(ctx context.Context, u *api.User) []interface{} {
+	l := ctx_zap.Extract(ctx)
+	uids := []interface{}{u.AccountId}
+	projects, err := lm.getUserProjects(ctx, u.AccountId)
+	if err != nil {
+		// the tags of the user are still returned
+		l.Error("error getting projects of user", zap.Error(err))
 	}
+	for _, p := range projects {
+		uids = append(uids, systemTagPrefix+p.Name)
+	}
+	return uids
+}
 
-	err = rows.Err()
+// getUserProjects returns the projects the user is owner or member of.
+func (lm *tagManager) getUserProjects(ctx context.Context, username string) ([]*api.Project, error) {
+	groups, err := lm.um.GetUserGroups(ctx, username)
 	if err != nil {
 		return nil, err
 	}
+	member := map[string]bool{}
+	for _, g := range groups {
+		member[g] = true
+	}
 
-	return tags, nil
+	projects, err := lm.pm.GetAllProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userProjects := []*api.Project{}
+	for _, p := range projects {
+		if p.Owner == username || member[p.AdminGroup] || member[p.WritersGroup] || member[p.ReadersGroup] {
+			userProjects = append(userProjects, p)
+		}
+	}
+	return userProjects, nil
+}
+
+// checkProjectMember checks that the user is the owner or an admin of the project,
+// or a writer of it if writers is set.
+func (lm *tagManager) checkProjectMember(ctx context.Context, name string, writers bool) error {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	project, err := lm.pm.GetProject(ctx, name)
+	if err != nil {
+		return err
+	}
+	if project.Owner == u.AccountId {
+		return nil
+	}
+
+	groups, err := lm.um.GetUserGroups(ctx, u.AccountId)
+	if err != nil {
+		return err
+	}
+	for _, g := range groups {
+		if g == project.AdminGroup || (writers && g == project.WritersGroup) {
+			return nil
+		}
+	}
+	return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("user can not manage the system tags of project " + name)
+}
+
+// getListingFileIDs returns the IDs of the entries of a listing by the ID their
+// tags are bound to. The files are bound to the ID of their versions folder,
+// that the storage sets on the files or that is in the listing itself, and the
+// files without a versions folder have no tags.
+func getListingFileIDs(mds []*api.Metadata) map[string]string {
+	versionFolders := map[string]string{}
+	for _, md := range mds {
+		if base := gopath.Base(md.Path); md.IsDir && strings.HasPrefix(base, versionPrefix) {
+			versionFolders[getFileIDFromVersionFolder(md.Path)] = md.Id
+		}
+	}
+
+	ids := map[string]string{}
+	for _, md := range mds {
+		if strings.HasPrefix(gopath.Base(md.Path), versionPrefix) {
+			continue
+		}
+		fileID := md.Id
+		if md.MigId != "" {
+			fileID = md.MigId
+		}
+		if !md.IsDir {
+			versionFolderID := md.VersionFolderId
+			if versionFolderID == "" {
+				versionFolderID = versionFolders[md.Path]
+			}
+			if versionFolderID == "" {
+				continue
+			}
+			prefix, _ := splitFileID(fileID)
+			_, inode := splitFileID(versionFolderID)
+			fileID = joinFileID(prefix, inode)
+		}
+		ids[fileID] = md.Id
+	}
+	return ids
 }
 
 func getFileIDFromVersionFolder(p string) string {
@@ -282,3 +658,15 @@ func (lm *tagManager) getVersionFolderID(ctx context.Context, p string) (string,
 	}
 	return md.Id, nil
 }
+
+// findVersionFolderID returns the ID of the versions folder of p, empty if it does not exist.
+func (lm *tagManager) findVersionFolderID(ctx context.Context, p string) (string, error) {
+	md, err := lm.vfs.GetMetadata(ctx, getVersionFolder(p))
+	if err != nil {
+		if api.IsErrorCode(err, api.StorageNotFoundErrorCode) {
+			return "", nil
+		}
+		return "", err
+	}
+	return md.Id, nil
+}
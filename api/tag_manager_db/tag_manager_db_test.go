@@ -0,0 +1,57 @@
+package tag_manager_db
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	db := conformance.GetMySQL(t)
+	conformance.TestTagManager(t, func(t *testing.T, vfs api.VirtualStorage, pm api.ProjectManager, um api.UserManager) api.TagManager {
+		return New(db.Username, db.Password, db.Host, db.Port, db.Name, vfs, pm, um)
+	})
+}
+
+func TestGetListingFileIDs(t *testing.T) {
+	mds := []*api.Metadata{
+		{Id: "home:1", Path: "/home/dir", IsDir: true},
+		{Id: "home:2", Path: "/home/dir/sub", IsDir: true},
+		{Id: "home:3", Path: "/home/dir/migrated", IsDir: true, MigId: "old:30"},
+		// the versions folder set by the storage
+		{Id: "home:4", Path: "/home/dir/a.txt", VersionFolderId: "home:40"},
+		// the versions folder in the listing
+		{Id: "home:5", Path: "/home/dir/b.txt"},
+		{Id: "home:50", Path: "/home/dir/.sys.v#.b.txt", IsDir: true},
+		// a file without versions folder has no tags
+		{Id: "home:6", Path: "/home/dir/c.txt"},
+	}
+
+	ids := getListingFileIDs(mds)
+	expected := map[string]string{
+		"home:1":  "home:1",
+		"home:2":  "home:2",
+		"old:30":  "home:3",
+		"home:40": "home:4",
+		"home:50": "home:5",
+	}
+	if len(ids) != len(expected) {
+		t.Fatalf("expected %d ids, got %v", len(expected), ids)
+	}
+	for k, v := range expected {
+		if ids[k] != v {
+			t.Fatalf("expected %s bound to %s, got %v", v, k, ids)
+		}
+	}
+}
+
+func TestVersionFolder(t *testing.T) {
+	p := "/home/dir/a.txt"
+	if v := getVersionFolder(p); v != "/home/dir/.sys.v#.a.txt" {
+		t.Fatalf("unexpected versions folder %s", v)
+	}
+	if f := getFileIDFromVersionFolder(getVersionFolder(p)); f != p {
+		t.Fatalf("expected %s, got %s", p, f)
+	}
+}
@@ -0,0 +1,338 @@
+package tag_manager_memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+)
+
+// systemTagPrefix prefixes the uid of the system tags, that belong
+// to a project instead of to a user.
+const systemTagPrefix = "project:"
+
+// New returns a tag manager that keeps the tags in memory, so they are
+// neither shared between several daemons nor kept across restarts.
+func New(vfs api.VirtualStorage, pm api.ProjectManager, um api.UserManager) api.TagManager {
+	return &tagManager{vfs: vfs, pm: pm, um: um, tags: map[int64]*api.Tag{}}
+}
+
+type tagManager struct {
+	sync.Mutex
+	vfs    api.VirtualStorage
+	pm     api.ProjectManager
+	um     api.UserManager
+	tags   map[int64]*api.Tag
+	lastID int64
+}
+
+func (tm *tagManager) SetTag(ctx context.Context, key, val, path string) error {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return tm.setTag(ctx, u.AccountId, "", key, val, path)
+}
+
+func (tm *tagManager) SetSystemTag(ctx context.Context, project, key, val, path string) error {
+	if err := tm.checkProjectMember(ctx, project, true); err != nil {
+		return err
+	}
+	return tm.setTag(ctx, systemTagPrefix+project, project, key, val, path)
+}
+
+func (tm *tagManager) setTag(ctx context.Context, uid, project, key, val, path string) error {
+	l := ctx_zap.Extract(ctx)
+	md, err := tm.vfs.GetMetadata(ctx, path)
+	if err != nil {
+		l.Error("error getting md for path", zap.String("path", path), zap.Error(err))
+		return err
+	}
+
+	itemType := api.Tag_FILE
+	if md.IsDir {
+		itemType = api.Tag_FOLDER
+	}
+	prefix, fileID := splitFileID(getFileID(md))
+
+	tm.Lock()
+	defer tm.Unlock()
+	// if tag exists, we don't create a new one
+	if tm.findTag(uid, prefix, fileID, key) != nil {
+		return nil
+	}
+	tm.lastID++
+	tm.tags[tm.lastID] = &api.Tag{Id: tm.lastID, ItemType: itemType, Uid: uid, FileIdPrefix: prefix, FileId: fileID, TagKey: key, TagValue: val, Project: project}
+	return nil
+}
+
+func (tm *tagManager) findTag(uid, prefix, fileID, key string) *api.Tag {
+	for _, t := range tm.tags {
+		if t.Uid == uid && t.FileIdPrefix == prefix && t.FileId == fileID && t.TagKey == key {
+			return t
+		}
+	}
+	return nil
+}
+
+func (tm *tagManager) UnSetTag(ctx context.Context, key, val, path string) error {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return tm.unSetTag(ctx, u.AccountId, key, path)
+}
+
+func (tm *tagManager) UnSetSystemTag(ctx context.Context, project, key, val, path string) error {
+	if err := tm.checkProjectMember(ctx, project, true); err != nil {
+		return err
+	}
+	return tm.unSetTag(ctx, systemTagPrefix+project, key, path)
+}
+
+func (tm *tagManager) unSetTag(ctx context.Context, uid, key, path string) error {
+	l := ctx_zap.Extract(ctx)
+	md, err := tm.vfs.GetMetadata(ctx, path)
+	if err != nil {
+		// return nil as the orphan background job will clean orphans
+		l.Error("error getting md for path, tag is orphan", zap.String("path", path), zap.Error(err))
+		return nil
+	}
+
+	prefix, fileID := splitFileID(getFileID(md))
+	tm.Lock()
+	defer tm.Unlock()
+	if t := tm.findTag(uid, prefix, fileID, key); t != nil {
+		delete(tm.tags, t.Id)
+	}
+	return nil
+}
+
+func (tm *tagManager) RenameTag(ctx context.Context, oldKey, newKey string) error {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return tm.renameTag(u.AccountId, oldKey, newKey)
+}
+
+func (tm *tagManager) RenameSystemTag(ctx context.Context, project, oldKey, newKey string) error {
+	if err := tm.checkProjectMember(ctx, project, false); err != nil {
+		return err
+	}
+	return tm.renameTag(systemTagPrefix+project, oldKey, newKey)
+}
+
+// renameTag renames the tags with oldKey, the files
+// already tagged with newKey keep a single tag.
+func (tm *tagManager) renameTag(uid, oldKey, newKey string) error {
+	if newKey == "" {
+		return api.NewError(api.PathInvalidError).WithMessage("the new tag key can not be empty")
+	}
+	if oldKey == newKey {
+		return nil
+	}
+
+	tm.Lock()
+	defer tm.Unlock()
+	found := false
+	for _, t := range tm.tags {
+		if t.Uid != uid || t.TagKey != oldKey {
+			continue
+		}
+		found = true
+		if tm.findTag(uid, t.FileIdPrefix, t.FileId, newKey) != nil {
+			delete(tm.tags, t.Id)
+		} else {
+			t.TagKey = newKey
+		}
+	}
+	if !found {
+		return api.NewError(api.TagNotFoundErrorCode).WithMessage(oldKey)
+	}
+	return nil
+}
+
+func (tm *tagManager) GetTagsForKey(ctx context.Context, key string) ([]*api.Tag, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	uids := map[string]bool{u.AccountId: true}
+	projects, err := tm.getUserProjects(ctx, u.AccountId)
+	if err != nil {
+		// the tags of the user are still returned
+		l.Error("error getting projects of user", zap.Error(err))
+	}
+	for _, p := range projects {
+		uids[systemTagPrefix+p.Name] = true
+	}
+
+	return tm.filterTags(func(t *api.Tag) bool {
+		return uids[t.Uid] && (key == "" || t.TagKey == key)
+	}), nil
+}
+
+func (tm *tagManager) GetTagsForPath(ctx context.Context, path string) ([]*api.Tag, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	md, err := tm.vfs.GetMetadata(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, fileID := splitFileID(getFileID(md))
+	return tm.filterTags(func(t *api.Tag) bool {
+		return t.FileIdPrefix == prefix && t.FileId == fileID && (t.Uid == u.AccountId || t.Project != "")
+	}), nil
+}
+
+// GetTagsForFolder returns the tags of the user and the system tags of the
+// projects of the user on the folder and on its entries.
+func (tm *tagManager) GetTagsForFolder(ctx context.Context, path string) ([]*api.Tag, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	md, err := tm.vfs.GetMetadata(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	mds := []*api.Metadata{md}
+	if md.IsDir {
+		entries, err := tm.vfs.ListFolder(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		mds = append(mds, entries...)
+	}
+	ids := map[string]string{}
+	for _, md := range mds {
+		prefix, fileID := splitFileID(getFileID(md))
+		ids[prefix+":"+fileID] = md.Id
+	}
+
+	uids := map[string]bool{u.AccountId: true}
+	projects, err := tm.getUserProjects(ctx, u.AccountId)
+	if err != nil {
+		// the tags of the user are still returned
+		l.Error("error getting projects of user", zap.Error(err))
+	}
+	for _, p := range projects {
+		uids[systemTagPrefix+p.Name] = true
+	}
+
+	tags := tm.filterTags(func(t *api.Tag) bool {
+		_, ok := ids[t.FileIdPrefix+":"+t.FileId]
+		return ok && uids[t.Uid]
+	})
+	for _, t := range tags {
+		t.FileIdPrefix, t.FileId = splitFileID(ids[t.FileIdPrefix+":"+t.FileId])
+	}
+	return tags, nil
+}
+
+// filterTags returns copies of the tags matching filter in creation order, like the databases do.
+func (tm *tagManager) filterTags(filter func(t *api.Tag) bool) []*api.Tag {
+	tm.Lock()
+	defer tm.Unlock()
+	tags := []*api.Tag{}
+	for _, t := range tm.tags {
+		if filter(t) {
+			tag := *t
+			tags = append(tags, &tag)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Id < tags[j].Id })
+	return tags
+}
+
+// getUserProjects returns the projects the user is owner or member of.
+func (tm *tagManager) getUserProjects(ctx context.Context, username string) ([]*api.Project, error) {
+	groups, err := tm.um.GetUserGroups(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	member := map[string]bool{}
+	for _, g := range groups {
+		member[g] = true
+	}
+
+	projects, err := tm.pm.GetAllProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userProjects := []*api.Project{}
+	for _, p := range projects {
+		if p.Owner == username || member[p.AdminGroup] || member[p.WritersGroup] || member[p.ReadersGroup] {
+			userProjects = append(userProjects, p)
+		}
+	}
+	return userProjects, nil
+}
+
+// checkProjectMember checks that the user is the owner or an admin of the project,
+// or a writer of it if writers is set.
+func (tm *tagManager) checkProjectMember(ctx context.Context, name string, writers bool) error {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	project, err := tm.pm.GetProject(ctx, name)
+	if err != nil {
+		return err
+	}
+	if project.Owner == u.AccountId {
+		return nil
+	}
+
+	groups, err := tm.um.GetUserGroups(ctx, u.AccountId)
+	if err != nil {
+		return err
+	}
+	for _, g := range groups {
+		if g == project.AdminGroup || (writers && g == project.WritersGroup) {
+			return nil
+		}
+	}
+	return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("user can not manage the system tags of project " + name)
+}
+
+func getFileID(md *api.Metadata) string {
+	if md.MigId != "" {
+		return md.MigId
+	}
+	return md.Id
+}
+
+func getUserFromContext(ctx context.Context) (*api.User, error) {
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		return nil, api.NewError(api.ContextUserRequiredError)
+	}
+	return u, nil
+}
+
+// splitFileID returns the two parts of a fileID.
+// A fileID like home:1234 will be separated into the prefix (home) and the inode(1234).
+func splitFileID(fileID string) (string, string) {
+	tokens := strings.SplitN(fileID, ":", 2)
+	if len(tokens) == 1 {
+		return "", tokens[0]
+	}
+	return tokens[0], tokens[1]
+}
@@ -0,0 +1,14 @@
+package tag_manager_memory
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.TestTagManager(t, func(t *testing.T, vfs api.VirtualStorage, pm api.ProjectManager, um api.UserManager) api.TagManager {
+		return New(vfs, pm, um)
+	})
+}
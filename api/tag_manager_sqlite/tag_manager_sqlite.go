@@ -0,0 +1,387 @@
+package tag_manager_sqlite
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/sqlite_db"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+)
+
+// migrations of the schema, they are only ever appended.
+var migrations = []string{
+	`create table tags (
+		id integer primary key autoincrement,
+		uid text not null,
+		item_type integer not null,
+		fileid_prefix text not null,
+		fileid text not null,
+		tag_key text not null,
+		tag_val text not null default '',
+		unique (uid, fileid_prefix, fileid, tag_key)
+	);
+	create index tags_file on tags (fileid_prefix, fileid)`,
+}
+
+// systemTagPrefix prefixes the uid of the system tags, that belong
+// to a project instead of to a user.
+const systemTagPrefix = "project:"
+
+// maxQueryIDs is the number of file IDs queried at once,
+// below the limit of variables of a statement.
+const maxQueryIDs = 500
+
+const tagColumns = "id, uid, item_type, fileid_prefix, fileid, tag_key, tag_val"
+
+// New returns a tag manager that keeps the tags in the SQLite database in file,
+// with the same semantics as the db tag manager. Unlike the db one, the tags
+// of files are bound to the file ID itself.
+func New(file string, vfs api.VirtualStorage, pm api.ProjectManager, um api.UserManager) (api.TagManager, error) {
+	db, err := sqlite_db.Open(file, "tag_manager", migrations)
+	if err != nil {
+		return nil, err
+	}
+	return &tagManager{db: db, vfs: vfs, pm: pm, um: um}, nil
+}
+
+type tagManager struct {
+	db  *sql.DB
+	vfs api.VirtualStorage
+	pm  api.ProjectManager
+	um  api.UserManager
+}
+
+func (tm *tagManager) SetTag(ctx context.Context, key, val, path string) error {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return tm.setTag(ctx, u.AccountId, key, val, path)
+}
+
+func (tm *tagManager) SetSystemTag(ctx context.Context, project, key, val, path string) error {
+	if err := tm.checkProjectMember(ctx, project, true); err != nil {
+		return err
+	}
+	return tm.setTag(ctx, systemTagPrefix+project, key, val, path)
+}
+
+func (tm *tagManager) setTag(ctx context.Context, uid, key, val, path string) error {
+	l := ctx_zap.Extract(ctx)
+	md, err := tm.vfs.GetMetadata(ctx, path)
+	if err != nil {
+		l.Error("error getting md for path", zap.String("path", path), zap.Error(err))
+		return err
+	}
+
+	itemType := api.Tag_FILE
+	if md.IsDir {
+		itemType = api.Tag_FOLDER
+	}
+	prefix, fileID := splitFileID(getFileID(md))
+
+	// if tag exists, we don't create a new one
+	query := "insert or ignore into tags (uid, item_type, fileid_prefix, fileid, tag_key, tag_val) values (?, ?, ?, ?, ?, ?)"
+	if _, err := tm.db.Exec(query, uid, itemType, prefix, fileID, key, val); err != nil {
+		l.Error("error inserting tag", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (tm *tagManager) UnSetTag(ctx context.Context, key, val, path string) error {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return tm.unSetTag(ctx, u.AccountId, key, path)
+}
+
+func (tm *tagManager) UnSetSystemTag(ctx context.Context, project, key, val, path string) error {
+	if err := tm.checkProjectMember(ctx, project, true); err != nil {
+		return err
+	}
+	return tm.unSetTag(ctx, systemTagPrefix+project, key, path)
+}
+
+func (tm *tagManager) unSetTag(ctx context.Context, uid, key, path string) error {
+	l := ctx_zap.Extract(ctx)
+	md, err := tm.vfs.GetMetadata(ctx, path)
+	if err != nil {
+		// return nil as the orphan background job will clean orphans
+		l.Error("error getting md for path, tag is orphan", zap.String("path", path), zap.Error(err))
+		return nil
+	}
+
+	prefix, fileID := splitFileID(getFileID(md))
+	if _, err := tm.db.Exec("delete from tags where uid=? and fileid_prefix=? and fileid=? and tag_key=?", uid, prefix, fileID, key); err != nil {
+		l.Error("error removing tag", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (tm *tagManager) RenameTag(ctx context.Context, oldKey, newKey string) error {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return tm.renameTag(ctx, u.AccountId, oldKey, newKey)
+}
+
+func (tm *tagManager) RenameSystemTag(ctx context.Context, project, oldKey, newKey string) error {
+	if err := tm.checkProjectMember(ctx, project, false); err != nil {
+		return err
+	}
+	return tm.renameTag(ctx, systemTagPrefix+project, oldKey, newKey)
+}
+
+// renameTag renames the tags with oldKey, the files
+// already tagged with newKey keep a single tag.
+func (tm *tagManager) renameTag(ctx context.Context, uid, oldKey, newKey string) error {
+	l := ctx_zap.Extract(ctx)
+	if newKey == "" {
+		return api.NewError(api.PathInvalidError).WithMessage("the new tag key can not be empty")
+	}
+	if oldKey == newKey {
+		return nil
+	}
+
+	tx, err := tm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.QueryRow("select count(*) from tags where uid=? and tag_key=?", uid, oldKey).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return api.NewError(api.TagNotFoundErrorCode).WithMessage(oldKey)
+	}
+
+	query := "delete from tags where uid=? and tag_key=? and (fileid_prefix, fileid) in (select fileid_prefix, fileid from tags where uid=? and tag_key=?)"
+	if _, err := tx.Exec(query, uid, oldKey, uid, newKey); err != nil {
+		l.Error("error removing duplicated tags", zap.Error(err))
+		return err
+	}
+	if _, err := tx.Exec("update tags set tag_key=? where uid=? and tag_key=?", newKey, uid, oldKey); err != nil {
+		l.Error("error renaming tags", zap.Error(err))
+		return err
+	}
+	return tx.Commit()
+}
+
+func (tm *tagManager) GetTagsForKey(ctx context.Context, key string) ([]*api.Tag, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	uids := []interface{}{u.AccountId}
+	projects, err := tm.getUserProjects(ctx, u.AccountId)
+	if err != nil {
+		// the tags of the user are still returned
+		l.Error("error getting projects of user", zap.Error(err))
+	}
+	for _, p := range projects {
+		uids = append(uids, systemTagPrefix+p.Name)
+	}
+
+	query := "select " + tagColumns + " from tags where uid in (?" + strings.Repeat(",?", len(uids)-1) + ")"
+	args := uids
+	if key != "" {
+		query += " and tag_key=?"
+		args = append(args, key)
+	}
+	return tm.queryTags(query+" order by id", args...)
+}
+
+func (tm *tagManager) GetTagsForPath(ctx context.Context, path string) ([]*api.Tag, error) {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	md, err := tm.vfs.GetMetadata(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, fileID := splitFileID(getFileID(md))
+	query := "select " + tagColumns + " from tags where fileid_prefix=? and fileid=? and (uid=? or uid like ?) order by id"
+	return tm.queryTags(query, prefix, fileID, u.AccountId, systemTagPrefix+"%")
+}
+
+// GetTagsForFolder returns the tags of the user and the system tags of the projects
+// of the user on the folder and on its entries, queried by the IDs of the listing.
+func (tm *tagManager) GetTagsForFolder(ctx context.Context, path string) ([]*api.Tag, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	md, err := tm.vfs.GetMetadata(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	mds := []*api.Metadata{md}
+	if md.IsDir {
+		entries, err := tm.vfs.ListFolder(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		mds = append(mds, entries...)
+	}
+	ids := map[string]string{}
+	fileIDs := []interface{}{}
+	for _, md := range mds {
+		prefix, fileID := splitFileID(getFileID(md))
+		ids[prefix+":"+fileID] = md.Id
+		fileIDs = append(fileIDs, fileID)
+	}
+
+	uids := []interface{}{u.AccountId}
+	projects, err := tm.getUserProjects(ctx, u.AccountId)
+	if err != nil {
+		// the tags of the user are still returned
+		l.Error("error getting projects of user", zap.Error(err))
+	}
+	for _, p := range projects {
+		uids = append(uids, systemTagPrefix+p.Name)
+	}
+
+	tags := []*api.Tag{}
+	for len(fileIDs) > 0 {
+		n := len(fileIDs)
+		if n > maxQueryIDs {
+			n = maxQueryIDs
+		}
+		query := "select " + tagColumns + " from tags where uid in (?" + strings.Repeat(",?", len(uids)-1) + ") and fileid in (?" + strings.Repeat(",?", n-1) + ")"
+		chunk, err := tm.queryTags(query, append(append([]interface{}{}, uids...), fileIDs[:n]...)...)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range chunk {
+			// the inodes are only unique with the prefix
+			id, ok := ids[tag.FileIdPrefix+":"+tag.FileId]
+			if !ok {
+				continue
+			}
+			tag.FileIdPrefix, tag.FileId = splitFileID(id)
+			tags = append(tags, tag)
+		}
+		fileIDs = fileIDs[n:]
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Id < tags[j].Id })
+	return tags, nil
+}
+
+func (tm *tagManager) queryTags(query string, args ...interface{}) ([]*api.Tag, error) {
+	rows, err := tm.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []*api.Tag{}
+	for rows.Next() {
+		tag := &api.Tag{}
+		var itemType int
+		if err := rows.Scan(&tag.Id, &tag.Uid, &itemType, &tag.FileIdPrefix, &tag.FileId, &tag.TagKey, &tag.TagValue); err != nil {
+			return nil, err
+		}
+		tag.ItemType = api.Tag_ItemType(itemType)
+		if strings.HasPrefix(tag.Uid, systemTagPrefix) {
+			tag.Project = strings.TrimPrefix(tag.Uid, systemTagPrefix)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// getUserProjects returns the projects the user is owner or member of.
+func (tm *tagManager) getUserProjects(ctx context.Context, username string) ([]*api.Project, error) {
+	groups, err := tm.um.GetUserGroups(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	member := map[string]bool{}
+	for _, g := range groups {
+		member[g] = true
+	}
+
+	projects, err := tm.pm.GetAllProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userProjects := []*api.Project{}
+	for _, p := range projects {
+		if p.Owner == username || member[p.AdminGroup] || member[p.WritersGroup] || member[p.ReadersGroup] {
+			userProjects = append(userProjects, p)
+		}
+	}
+	return userProjects, nil
+}
+
+// checkProjectMember checks that the user is the owner or an admin of the project,
+// or a writer of it if writers is set.
+func (tm *tagManager) checkProjectMember(ctx context.Context, name string, writers bool) error {
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	project, err := tm.pm.GetProject(ctx, name)
+	if err != nil {
+		return err
+	}
+	if project.Owner == u.AccountId {
+		return nil
+	}
+
+	groups, err := tm.um.GetUserGroups(ctx, u.AccountId)
+	if err != nil {
+		return err
+	}
+	for _, g := range groups {
+		if g == project.AdminGroup || (writers && g == project.WritersGroup) {
+			return nil
+		}
+	}
+	return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("user can not manage the system tags of project " + name)
+}
+
+func getFileID(md *api.Metadata) string {
+	if md.MigId != "" {
+		return md.MigId
+	}
+	return md.Id
+}
+
+func getUserFromContext(ctx context.Context) (*api.User, error) {
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		return nil, api.NewError(api.ContextUserRequiredError)
+	}
+	return u, nil
+}
+
+// splitFileID returns the two parts of a fileID.
+// A fileID like home:1234 will be separated into the prefix (home) and the inode(1234).
+func splitFileID(fileID string) (string, string) {
+	tokens := strings.SplitN(fileID, ":", 2)
+	if len(tokens) == 1 {
+		return "", tokens[0]
+	}
+	return tokens[0], tokens[1]
+}
@@ -0,0 +1,18 @@
+package tag_manager_sqlite
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.TestTagManager(t, func(t *testing.T, vfs api.VirtualStorage, pm api.ProjectManager, um api.UserManager) api.TagManager {
+		tm, err := New("", vfs, pm, um)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	})
+}
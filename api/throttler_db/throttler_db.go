@@ -0,0 +1,126 @@
+package throttler_db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cernbox/reva/api"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+)
+
+/*
+create table cbox_auth_throttle (
+	throttle_key varchar(255) not null primary key,
+	failures int not null,
+	last bigint not null
+);
+*/
+
+type throttler struct {
+	db     *sql.DB
+	policy *api.ThrottlePolicy
+}
+
+// New returns a throttler that keeps the failed attempts in a database,
+// so they are shared between all the daemons using the same database.
+func New(dbUsername, dbPassword, dbHost string, dbPort int, dbName string, policy *api.ThrottlePolicy) (api.Throttler, error) {
+	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", dbUsername, dbPassword, dbHost, dbPort, dbName))
+	if err != nil {
+		return nil, err
+	}
+
+	return &throttler{db: db, policy: policy}, nil
+}
+
+func (t *throttler) Wait(ctx context.Context, key string) (time.Duration, error) {
+	l := ctx_zap.Extract(ctx)
+	failures, last, err := t.getAttempts(key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		l.Error("", zap.Error(err))
+		return 0, err
+	}
+	return t.policy.Wait(failures, last, time.Now()), nil
+}
+
+func (t *throttler) Attempt(ctx context.Context, key string) (time.Duration, error) {
+	l := ctx_zap.Extract(ctx)
+	now := time.Now()
+
+	// the row is created first so that concurrent attempts on a new key
+	// are serialized by the lock on it instead of both inserting it.
+	if _, err := t.db.Exec("insert ignore into cbox_auth_throttle set throttle_key=?,failures=0,last=0", key); err != nil {
+		l.Error("", zap.Error(err))
+		return 0, err
+	}
+
+	tx, err := t.db.Begin()
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var (
+		failures int
+		last     int64
+	)
+	query := "select failures, last from cbox_auth_throttle where throttle_key=? for update"
+	if err := tx.QueryRow(query, key).Scan(&failures, &last); err != nil {
+		l.Error("", zap.Error(err))
+		return 0, err
+	}
+	if t.policy.Expired(time.Unix(last, 0), now) {
+		failures = 0
+	} else if wait := t.policy.Wait(failures, time.Unix(last, 0), now); wait > 0 {
+		return wait, nil
+	}
+
+	if _, err := tx.Exec("update cbox_auth_throttle set failures=?, last=? where throttle_key=?", failures+1, now.Unix(), key); err != nil {
+		l.Error("", zap.Error(err))
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		l.Error("", zap.Error(err))
+		return 0, err
+	}
+	return 0, nil
+}
+
+func (t *throttler) Release(ctx context.Context, key string) error {
+	l := ctx_zap.Extract(ctx)
+	stmt := "update cbox_auth_throttle set failures=greatest(failures-1, 0) where throttle_key=?"
+	if _, err := t.db.Exec(stmt, key); err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (t *throttler) Reset(ctx context.Context, key string) error {
+	l := ctx_zap.Extract(ctx)
+	if _, err := t.db.Exec("delete from cbox_auth_throttle where throttle_key=?", key); err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (t *throttler) getAttempts(key string) (int, time.Time, error) {
+	var (
+		failures int
+		last     int64
+	)
+	query := "select failures, last from cbox_auth_throttle where throttle_key=?"
+	if err := t.db.QueryRow(query, key).Scan(&failures, &last); err != nil {
+		return 0, time.Time{}, err
+	}
+	return failures, time.Unix(last, 0), nil
+}
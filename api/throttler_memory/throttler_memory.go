@@ -0,0 +1,107 @@
+package throttler_memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cernbox/reva/api"
+)
+
+type attempts struct {
+	failures int
+	last     time.Time
+}
+
+type throttler struct {
+	sync.Mutex
+	policy  *api.ThrottlePolicy
+	entries map[string]*attempts
+	now     func() time.Time
+	done    chan struct{}
+}
+
+// New returns a throttler that keeps the failed attempts in memory,
+// so they are neither shared between several daemons nor kept across restarts.
+// The throttler is an io.Closer, closing it stops the purge of the entries.
+func New(policy *api.ThrottlePolicy) api.Throttler {
+	t := &throttler{policy: policy, entries: map[string]*attempts{}, now: time.Now, done: make(chan struct{})}
+	go t.purge(time.Minute)
+	return t
+}
+
+// Close stops the purge, it must be called once.
+func (t *throttler) Close() error {
+	close(t.done)
+	return nil
+}
+
+func (t *throttler) Wait(ctx context.Context, key string) (time.Duration, error) {
+	t.Lock()
+	defer t.Unlock()
+	a, ok := t.entries[key]
+	if !ok {
+		return 0, nil
+	}
+	return t.policy.Wait(a.failures, a.last, t.now()), nil
+}
+
+func (t *throttler) Attempt(ctx context.Context, key string) (time.Duration, error) {
+	t.Lock()
+	defer t.Unlock()
+	now := t.now()
+	a, ok := t.entries[key]
+	if !ok || t.policy.Expired(a.last, now) {
+		a = &attempts{}
+		t.entries[key] = a
+	}
+	if wait := t.policy.Wait(a.failures, a.last, now); wait > 0 {
+		return wait, nil
+	}
+	a.failures++
+	a.last = now
+	return 0, nil
+}
+
+func (t *throttler) Release(ctx context.Context, key string) error {
+	t.Lock()
+	defer t.Unlock()
+	a, ok := t.entries[key]
+	if !ok {
+		return nil
+	}
+	a.failures--
+	if a.failures <= 0 {
+		delete(t.entries, key)
+	}
+	return nil
+}
+
+func (t *throttler) Reset(ctx context.Context, key string) error {
+	t.Lock()
+	defer t.Unlock()
+	delete(t.entries, key)
+	return nil
+}
+
+// purge removes the expired entries periodically, else the map
+// would grow with every client IP that ever failed a login.
+func (t *throttler) purge(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+		}
+		t.Lock()
+		now := t.now()
+		for k, a := range t.entries {
+			if t.policy.Expired(a.last, now) {
+				delete(t.entries, k)
+			}
+		}
+		t.Unlock()
+	}
+}
@@ -0,0 +1,138 @@
+package throttler_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cernbox/reva/api"
+)
+
+func TestThrottler(t *testing.T) {
+	policy := &api.ThrottlePolicy{
+		FreeAttempts:    2,
+		BaseDelay:       time.Second,
+		MaxDelay:        10 * time.Second,
+		LockoutAttempts: 8,
+		LockoutDuration: time.Hour,
+		Window:          time.Hour,
+	}
+	now := time.Unix(1000, 0)
+	th := &throttler{policy: policy, entries: map[string]*attempts{}, now: func() time.Time { return now }}
+	ctx := context.Background()
+
+	// failures, then expected wait after that failure
+	expected := []time.Duration{0, 0, time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, time.Hour}
+	for i, exp := range expected {
+		if wait, err := th.Attempt(ctx, "account:alice"); err != nil || wait != 0 {
+			t.Fatalf("attempt %d: expected to be recorded, got wait %s and error %v", i+1, wait, err)
+		}
+		wait, err := th.Wait(ctx, "account:alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wait != exp {
+			t.Fatalf("failure %d: expected wait %s, got %s", i+1, exp, wait)
+		}
+		// an attempt during the wait is refused and not recorded
+		if wait > 0 {
+			if refused, _ := th.Attempt(ctx, "account:alice"); refused != wait {
+				t.Fatalf("failure %d: expected attempt to be refused for %s, got %s", i+1, wait, refused)
+			}
+			if i < len(expected)-1 {
+				now = now.Add(wait)
+			}
+		}
+	}
+
+	now = now.Add(30 * time.Minute)
+	if wait, _ := th.Wait(ctx, "account:alice"); wait != 30*time.Minute {
+		t.Fatalf("expected account to be locked for 30m, got %s", wait)
+	}
+	if wait, _ := th.Wait(ctx, "account:bob"); wait != 0 {
+		t.Fatalf("expected no wait for another key, got %s", wait)
+	}
+
+	// after the window the failures are forgotten
+	now = now.Add(time.Hour)
+	if wait, _ := th.Attempt(ctx, "account:alice"); wait != 0 {
+		t.Fatalf("expected failures to be forgotten after the window, got %s", wait)
+	}
+	if failures := th.entries["account:alice"].failures; failures != 1 {
+		t.Fatalf("expected the counter to be restarted, got %d failures", failures)
+	}
+
+	th.Reset(ctx, "account:alice")
+	if _, ok := th.entries["account:alice"]; ok {
+		t.Fatal("expected key to be removed after reset")
+	}
+}
+
+func TestParallelAttempts(t *testing.T) {
+	policy := &api.ThrottlePolicy{FreeAttempts: 2, BaseDelay: time.Second, MaxDelay: 10 * time.Second, Window: time.Hour}
+	now := time.Unix(1000, 0)
+	th := &throttler{policy: policy, entries: map[string]*attempts{}, now: func() time.Time { return now }}
+	ctx := context.Background()
+
+	// a burst is counted before any of its attempts fails
+	accepted := 0
+	results := make(chan time.Duration)
+	for i := 0; i < 10; i++ {
+		go func() {
+			wait, _ := th.Attempt(ctx, "ip:10.0.0.1")
+			results <- wait
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		if <-results == 0 {
+			accepted++
+		}
+	}
+	if accepted != 3 {
+		t.Fatalf("expected the free attempts and one more to be accepted, got %d", accepted)
+	}
+
+	// the attempts that succeed are not failures
+	for i := 0; i < accepted; i++ {
+		th.Release(ctx, "ip:10.0.0.1")
+	}
+	if _, ok := th.entries["ip:10.0.0.1"]; ok {
+		t.Fatal("expected key to be removed once all its attempts are released")
+	}
+}
+
+func TestPurge(t *testing.T) {
+	policy := &api.ThrottlePolicy{FreeAttempts: 2, BaseDelay: time.Second, MaxDelay: 10 * time.Second, Window: time.Hour}
+	now := time.Unix(1000, 0)
+	th := &throttler{policy: policy, entries: map[string]*attempts{}, now: func() time.Time { return now }, done: make(chan struct{})}
+	th.Attempt(context.Background(), "ip:10.0.0.1")
+	th.Lock()
+	now = now.Add(2 * time.Hour)
+	th.Unlock()
+
+	stopped := make(chan struct{})
+	go func() {
+		th.purge(time.Millisecond)
+		close(stopped)
+	}()
+	for i := 0; ; i++ {
+		th.Lock()
+		_, ok := th.entries["ip:10.0.0.1"]
+		th.Unlock()
+		if !ok {
+			break
+		}
+		if i == 1000 {
+			t.Fatal("expected the expired entry to be purged")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// closing the throttler stops the purge
+	th.Close()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("expected the purge to stop")
+	}
+}
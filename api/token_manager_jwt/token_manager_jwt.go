@@ -2,7 +2,6 @@ package token_manager_jwt
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -27,6 +26,9 @@ func (tm *tokenManager) ForgeUserToken(ctx context.Context, user *api.User) (str
 	claims["account_id"] = user.AccountId
 	claims["display_name"] = user.DisplayName
 	claims["groups"] = user.Groups
+	claims["read_only"] = user.ReadOnly
+	claims["restricted_path"] = user.RestrictedPath
+	claims["app_password"] = user.AppPassword
 	claims["exp"] = time.Now().Add(time.Second * time.Duration(3600))
 	tokenString, err := token.SignedString([]byte(tm.signSecret))
 	if err != nil {
@@ -43,41 +45,46 @@ func (tm *tokenManager) DismantleUserToken(ctx context.Context, token string) (*
 	})
 	if err != nil {
 		l.Error("invalid token", zap.Error(err), zap.String("token", token))
-		return nil, err
+		return nil, api.NewError(api.TokenInvalidErrorCode).WithMessage(err.Error())
 	}
 	if !rawToken.Valid {
-		l.Error("invalid token", zap.Error(err), zap.String("token", token))
-		return nil, err
+		l.Error("invalid token", zap.String("token", token))
+		return nil, api.NewError(api.TokenInvalidErrorCode)
 
 	}
 
 	claims := rawToken.Claims.(jwt.MapClaims)
 	accountID, ok := claims["account_id"].(string)
 	if !ok {
-		return nil, errors.New("account_id claim is not a string")
+		return nil, api.NewError(api.TokenInvalidErrorCode).WithMessage("account_id claim is not a string")
 	}
 
 	displayName, _ := claims["display_name"].(string) // no displayname is not an error
 
-	rawGroups, ok := claims["groups"].([]interface{})
-	if !ok {
-		return nil, errors.New("groups claim is not a []interface{}")
-	}
+	rawGroups, _ := claims["groups"].([]interface{}) // users without groups have a null claim
 	groups := []string{}
 	for _, g := range rawGroups {
 		group, ok := g.(string)
 		if !ok {
-			err := errors.New(fmt.Sprintf("group %+v can not be casted to string", g))
+			err := api.NewError(api.TokenInvalidErrorCode).WithMessage(fmt.Sprintf("group %+v can not be casted to string", g))
 			l.Error("", zap.Error(err))
 			return nil, err
 		}
 		groups = append(groups, group)
 	}
 
+	// tokens forged before the introduction of restricted credentials do not have these claims
+	readOnly, _ := claims["read_only"].(bool)
+	restrictedPath, _ := claims["restricted_path"].(string)
+	appPassword, _ := claims["app_password"].(bool)
+
 	user := &api.User{
-		AccountId:   accountID,
-		Groups:      groups,
-		DisplayName: displayName,
+		AccountId:      accountID,
+		Groups:         groups,
+		DisplayName:    displayName,
+		ReadOnly:       readOnly,
+		RestrictedPath: restrictedPath,
+		AppPassword:    appPassword,
 	}
 	return user, nil
 }
@@ -94,6 +101,7 @@ func (tm *tokenManager) ForgePublicLinkToken(ctx context.Context, pl *api.Public
 	claims["expires"] = pl.Expires
 	claims["read_only"] = pl.ReadOnly
 	claims["drop_only"] = pl.DropOnly
+	claims["notify_uploads"] = pl.NotifyUploads
 	claims["mtime"] = pl.Mtime
 	claims["item_type"] = pl.ItemType
 	claims["share_name"] = pl.Name
@@ -113,11 +121,11 @@ func (tm *tokenManager) DismantlePublicLinkToken(ctx context.Context, token stri
 	})
 	if err != nil {
 		l.Error("invalid token", zap.Error(err), zap.String("token", token))
-		return nil, err
+		return nil, api.NewError(api.TokenInvalidErrorCode).WithMessage(err.Error())
 	}
 	if !rawToken.Valid {
-		l.Error("invalid token", zap.Error(err), zap.String("token", token))
-		return nil, err
+		l.Error("invalid token", zap.String("token", token))
+		return nil, api.NewError(api.TokenInvalidErrorCode)
 
 	}
 
@@ -136,51 +144,59 @@ func (tm *tokenManager) DismantlePublicLinkToken(ctx context.Context, token stri
 	claims := rawToken.Claims.(jwt.MapClaims)
 	token, ok := claims["token"].(string)
 	if !ok {
-		return nil, errors.New("token claim is not a string")
+		return nil, api.NewError(api.TokenInvalidErrorCode).WithMessage("token claim is not a string")
 	}
 	owner, ok := claims["owner"].(string)
 	if !ok {
-		return nil, errors.New("owner claim is not a string")
+		return nil, api.NewError(api.TokenInvalidErrorCode).WithMessage("owner claim is not a string")
 	}
 	readOnly, ok := claims["read_only"].(bool)
 	if !ok {
-		return nil, errors.New("read_only claim is not a bool")
+		return nil, api.NewError(api.TokenInvalidErrorCode).WithMessage("read_only claim is not a bool")
 	}
 	dropOnly, ok := claims["drop_only"].(bool)
 	if !ok {
-		return nil, errors.New("drop_only claim is not a bool")
+		return nil, api.NewError(api.TokenInvalidErrorCode).WithMessage("drop_only claim is not a bool")
 	}
 	path, ok := claims["path"].(string)
 	if !ok {
-		return nil, errors.New("path claim is not a string")
+		return nil, api.NewError(api.TokenInvalidErrorCode).WithMessage("path claim is not a string")
 	}
 	protected, ok := claims["protected"].(bool)
 	if !ok {
-		return nil, errors.New("protected claim is not a bool")
+		return nil, api.NewError(api.TokenInvalidErrorCode).WithMessage("protected claim is not a bool")
 	}
 	mtime, ok := claims["mtime"].(float64)
 	if !ok {
-		return nil, errors.New("mtime claim is not a float64")
+		return nil, api.NewError(api.TokenInvalidErrorCode).WithMessage("mtime claim is not a float64")
 	}
 	itemType, ok := claims["item_type"].(float64)
 	if !ok {
-		return nil, errors.New("item_type claim is not a float64")
+		return nil, api.NewError(api.TokenInvalidErrorCode).WithMessage("item_type claim is not a float64")
 	}
 	shareName, ok := claims["share_name"].(string)
 	if !ok {
-		return nil, errors.New("share_name claim is not a string")
+		return nil, api.NewError(api.TokenInvalidErrorCode).WithMessage("share_name claim is not a string")
 	}
 
+	// tokens forged before the id and expiration were read back may not have these claims
+	id, _ := claims["id"].(string)
+	expires, _ := claims["expires"].(float64)
+	notifyUploads, _ := claims["notify_uploads"].(bool)
+
 	pl := &api.PublicLink{
-		Token:     token,
-		OwnerId:   owner,
-		ReadOnly:  readOnly,
-		Path:      path,
-		Protected: protected,
-		Mtime:     uint64(mtime),
-		ItemType:  api.PublicLink_ItemType(itemType),
-		Name:      shareName,
-		DropOnly:  dropOnly,
+		Id:            id,
+		Token:         token,
+		OwnerId:       owner,
+		ReadOnly:      readOnly,
+		Path:          path,
+		Protected:     protected,
+		Expires:       uint64(expires),
+		Mtime:         uint64(mtime),
+		ItemType:      api.PublicLink_ItemType(itemType),
+		Name:          shareName,
+		DropOnly:      dropOnly,
+		NotifyUploads: notifyUploads,
 	}
 	return pl, nil
 }
@@ -0,0 +1,11 @@
+package token_manager_jwt
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.TestTokenManager(t, New("secret"))
+}
@@ -16,15 +16,30 @@ import (
 type vfs struct {
 	l      *zap.Logger
 	mounts []api.Mount
+	bus    api.EventBus
 }
 
-func NewVFS(logger *zap.Logger) api.VirtualStorage {
+// NewVFS returns the virtual storage, the changes done through it
+// are published to bus, that can be nil.
+func NewVFS(logger *zap.Logger, bus api.EventBus) api.VirtualStorage {
 	vfs := new(vfs)
 	vfs.l = logger
 	vfs.mounts = []api.Mount{}
+	vfs.bus = bus
 	return vfs
 }
 
+func (v *vfs) publish(ctx context.Context, t api.FileEvent_Type, p string) {
+	api.PublishFileEvent(ctx, v.bus, v, &api.FileEvent{Type: t, Path: p})
+}
+
+func shareTarget(recipient *api.ShareRecipient) string {
+	if recipient == nil {
+		return ""
+	}
+	return recipient.Type.String() + ":" + recipient.Identity
+}
+
 func (v *vfs) ListMounts(ctx context.Context) ([]api.Mount, error) {
 	return v.mounts, nil
 }
@@ -51,20 +66,24 @@ func (v *vfs) AddMount(ctx context.Context, mount api.Mount) error {
 }
 
 func (v *vfs) GetMount(p string) (api.Mount, error) {
-	// TODO(labkode): if more than 2 matches, check for longest
 	p = path.Clean(p)
 	if err := validatePath(p); err != nil {
 		v.l.Error("", zap.Error(err))
 		return nil, err
 	}
 
+	// the longest mount point wins, so /shared can be mounted next to /
+	var match api.Mount
 	for _, m := range v.mounts {
-		if strings.HasPrefix(p, m.GetMountPoint()) {
-			return m, nil
-		}
 		if strings.HasPrefix(p, m.GetMountPointId()) {
 			return m, nil
 		}
+		if strings.HasPrefix(p, m.GetMountPoint()) && (match == nil || len(m.GetMountPoint()) > len(match.GetMountPoint())) {
+			match = m
+		}
+	}
+	if match != nil {
+		return match, nil
 	}
 
 	err := api.NewError(api.StorageNotFoundErrorCode).WithMessage(p)
@@ -96,18 +115,26 @@ func (v *vfs) GetPathByID(ctx context.Context, id string) (string, error) {
 	return m.GetPathByID(ctx, id)
 }
 
-func (v *vfs) SetACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (v *vfs) SetACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	derefPath, err := v.getDereferencedPath(ctx, path)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
 		return err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, true); err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
 	m, err := v.GetMount(derefPath)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
 		return err
 	}
-	return m.SetACL(ctx, derefPath, readOnly, recipient, shareList)
+	if err := m.SetACL(ctx, derefPath, permissions, recipient, shareList); err != nil {
+		return err
+	}
+	api.PublishFileEvent(ctx, v.bus, v, &api.FileEvent{Type: api.FileEvent_SHARE_ADDED, Path: derefPath, Target: shareTarget(recipient)})
+	return nil
 
 }
 
@@ -117,26 +144,42 @@ func (v *vfs) UnsetACL(ctx context.Context, path string, recipient *api.ShareRec
 		v.l.Error("", zap.Error(err))
 		return err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, true); err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
 	m, err := v.GetMount(derefPath)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
 		return err
 	}
-	return m.UnsetACL(ctx, derefPath, recipient, shareList)
+	if err := m.UnsetACL(ctx, derefPath, recipient, shareList); err != nil {
+		return err
+	}
+	api.PublishFileEvent(ctx, v.bus, v, &api.FileEvent{Type: api.FileEvent_SHARE_REMOVED, Path: derefPath, Target: shareTarget(recipient)})
+	return nil
 }
 
-func (v *vfs) UpdateACL(ctx context.Context, path string, readOnly bool, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
+func (v *vfs) UpdateACL(ctx context.Context, path string, permissions api.SharePermissions, recipient *api.ShareRecipient, shareList []*api.FolderShare) error {
 	derefPath, err := v.getDereferencedPath(ctx, path)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
 		return err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, true); err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
 	m, err := v.GetMount(derefPath)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
 		return err
 	}
-	return m.UpdateACL(ctx, derefPath, readOnly, recipient, shareList)
+	if err := m.UpdateACL(ctx, derefPath, permissions, recipient, shareList); err != nil {
+		return err
+	}
+	api.PublishFileEvent(ctx, v.bus, v, &api.FileEvent{Type: api.FileEvent_SHARE_UPDATED, Path: derefPath, Target: shareTarget(recipient)})
+	return nil
 }
 
 func (v *vfs) GetQuota(ctx context.Context, path string) (int, int, error) {
@@ -145,6 +188,10 @@ func (v *vfs) GetQuota(ctx context.Context, path string) (int, int, error) {
 		v.l.Error("", zap.Error(err))
 		return 0, 0, err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, false); err != nil {
+		v.l.Error("", zap.Error(err))
+		return 0, 0, err
+	}
 	m, err := v.GetMount(derefPath)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
@@ -159,12 +206,20 @@ func (v *vfs) CreateDir(ctx context.Context, path string) error {
 		v.l.Error("", zap.Error(err))
 		return err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, true); err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
 	m, err := v.GetMount(derefPath)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
 		return err
 	}
-	return m.CreateDir(ctx, derefPath)
+	if err := m.CreateDir(ctx, derefPath); err != nil {
+		return err
+	}
+	v.publish(ctx, api.FileEvent_CREATED, derefPath)
+	return nil
 }
 
 func (v *vfs) Delete(ctx context.Context, path string) error {
@@ -173,12 +228,20 @@ func (v *vfs) Delete(ctx context.Context, path string) error {
 		v.l.Error("", zap.Error(err))
 		return err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, true); err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
 	m, err := v.GetMount(derefPath)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
 		return err
 	}
-	return m.Delete(ctx, derefPath)
+	if err := m.Delete(ctx, derefPath); err != nil {
+		return err
+	}
+	v.publish(ctx, api.FileEvent_DELETED, derefPath)
+	return nil
 }
 
 func (v *vfs) Move(ctx context.Context, oldPath, newPath string) error {
@@ -193,6 +256,15 @@ func (v *vfs) Move(ctx context.Context, oldPath, newPath string) error {
 		return err
 	}
 
+	if err := api.CheckUserScope(ctx, derefOldPath, true); err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
+	if err := api.CheckUserScope(ctx, derefNewPath, true); err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
+
 	//TODO(labkode): handle 3rd party copy between two different mount points
 	fromMount, err := v.GetMount(derefOldPath)
 	if err != nil {
@@ -205,21 +277,114 @@ func (v *vfs) Move(ctx context.Context, oldPath, newPath string) error {
 		return err
 	}
 	if fromMount.GetMountPoint() == toMount.GetMountPoint() {
-		err := fromMount.Move(ctx, derefOldPath, derefNewPath)
-		v.l.Error("", zap.Error(err))
-		return err
+		if err := fromMount.Move(ctx, derefOldPath, derefNewPath); err != nil {
+			v.l.Error("", zap.Error(err))
+			return err
+		}
+		api.PublishFileEvent(ctx, v.bus, v, &api.FileEvent{Type: api.FileEvent_MOVED, Path: derefNewPath, OldPath: derefOldPath})
+		return nil
 	}
 
 	err = api.NewError(api.StorageNotSupportedErrorCode).WithMessage("inter-mount move not supported")
 	v.l.Error("", zap.Error(err))
 	return err
 }
+
+// TransferOwnership hands files over within a mount, the paths are tree paths
+// as the ids are only resolved for the user in the context.
+func (v *vfs) TransferOwnership(ctx context.Context, from, fromPath, to, toPath string) error {
+	fromPath, toPath = path.Clean(fromPath), path.Clean(toPath)
+	if !v.isTreePath(fromPath) || !v.isTreePath(toPath) {
+		err := api.NewError(api.PathInvalidError).WithMessage("ownership transfer needs tree paths")
+		v.l.Error("", zap.Error(err))
+		return err
+	}
+
+	fromMount, err := v.GetMount(fromPath)
+	if err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
+	toMount, err := v.GetMount(toPath)
+	if err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
+	if fromMount.GetMountPoint() != toMount.GetMountPoint() {
+		err := api.NewError(api.StorageNotSupportedErrorCode).WithMessage("inter-mount ownership transfer not supported")
+		v.l.Error("", zap.Error(err))
+		return err
+	}
+	ts, ok := fromMount.(api.OwnershipTransferStorage)
+	if !ok {
+		err := api.NewError(api.StorageNotSupportedErrorCode).WithMessage("ownership transfer not supported by mount " + fromMount.GetMountPoint())
+		v.l.Error("", zap.Error(err))
+		return err
+	}
+
+	// no event is published as the events are for the user in the context
+	if err := ts.TransferOwnership(ctx, from, fromPath, to, toPath); err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// getProvisioningMount returns the mount of the tree path p if it can
+// provision directories.
+func (v *vfs) getProvisioningMount(p string) (api.ProvisioningStorage, error) {
+	if !v.isTreePath(p) {
+		return nil, api.NewError(api.PathInvalidError).WithMessage("provisioning needs tree paths")
+	}
+	m, err := v.GetMount(p)
+	if err != nil {
+		return nil, err
+	}
+	ps, ok := m.(api.ProvisioningStorage)
+	if !ok {
+		return nil, api.NewError(api.StorageNotSupportedErrorCode).WithMessage("provisioning not supported by mount " + m.GetMountPoint())
+	}
+	return ps, nil
+}
+
+func (v *vfs) ProvisionDir(ctx context.Context, owner, p string) error {
+	p = path.Clean(p)
+	ps, err := v.getProvisioningMount(p)
+	if err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
+	if err := ps.ProvisionDir(ctx, owner, p); err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (v *vfs) SetQuota(ctx context.Context, owner, p string, maxBytes uint64) error {
+	p = path.Clean(p)
+	ps, err := v.getProvisioningMount(p)
+	if err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
+	if err := ps.SetQuota(ctx, owner, p, maxBytes); err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 func (v *vfs) GetMetadata(ctx context.Context, path string) (*api.Metadata, error) {
 	derefPath, err := v.getDereferencedPath(ctx, path)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
 		return nil, err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, false); err != nil {
+		v.l.Error("", zap.Error(err))
+		return nil, err
+	}
 
 	/*
 		if derefPath == "/" {
@@ -286,6 +451,10 @@ func (v *vfs) ListFolder(ctx context.Context, p string) ([]*api.Metadata, error)
 		v.l.Error("", zap.Error(err))
 		return nil, err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, false); err != nil {
+		v.l.Error("", zap.Error(err))
+		return nil, err
+	}
 	/*
 		if derefPath == "/" {
 			return v.listRootNode(ctx)
@@ -312,6 +481,10 @@ func (v *vfs) Upload(ctx context.Context, path string, r io.ReadCloser) error {
 		v.l.Error("", zap.Error(err))
 		return err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, true); err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
 	m, err := v.GetMount(derefPath)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
@@ -322,6 +495,7 @@ func (v *vfs) Upload(ctx context.Context, path string, r io.ReadCloser) error {
 		v.l.Error("", zap.Error(err))
 		return err
 	}
+	v.publish(ctx, api.FileEvent_WRITTEN, derefPath)
 	return nil
 }
 
@@ -333,6 +507,10 @@ func (v *vfs) Download(ctx context.Context, path string) (io.ReadCloser, error)
 		v.l.Error("", zap.Error(err))
 		return nil, err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, false); err != nil {
+		v.l.Error("", zap.Error(err))
+		return nil, err
+	}
 
 	l.Debug("", zap.String("derefPath", derefPath))
 	m, err := v.GetMount(derefPath)
@@ -355,6 +533,10 @@ func (v *vfs) ListRevisions(ctx context.Context, path string) ([]*api.Revision,
 		v.l.Error("", zap.Error(err))
 		return nil, err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, false); err != nil {
+		v.l.Error("", zap.Error(err))
+		return nil, err
+	}
 	m, err := v.GetMount(derefPath)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
@@ -375,6 +557,10 @@ func (v *vfs) DownloadRevision(ctx context.Context, path, revisionKey string) (i
 		v.l.Error("", zap.Error(err))
 		return nil, err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, false); err != nil {
+		v.l.Error("", zap.Error(err))
+		return nil, err
+	}
 	m, err := v.GetMount(derefPath)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
@@ -394,6 +580,10 @@ func (v *vfs) RestoreRevision(ctx context.Context, path, revisionKey string) err
 		v.l.Error("", zap.Error(err))
 		return err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, true); err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
 	m, err := v.GetMount(derefPath)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
@@ -404,6 +594,7 @@ func (v *vfs) RestoreRevision(ctx context.Context, path, revisionKey string) err
 		v.l.Error("", zap.Error(err))
 		return err
 	}
+	v.publish(ctx, api.FileEvent_WRITTEN, derefPath)
 	return nil
 }
 
@@ -413,6 +604,10 @@ func (v *vfs) EmptyRecycle(ctx context.Context, path string) error {
 		v.l.Error("", zap.Error(err))
 		return err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, true); err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
 	m, err := v.GetMount(derefPath)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
@@ -432,6 +627,10 @@ func (v *vfs) ListRecycle(ctx context.Context, path string) ([]*api.RecycleEntry
 		v.l.Error("", zap.Error(err))
 		return nil, err
 	}
+	if err := api.CheckUserScope(ctx, derefPath, false); err != nil {
+		v.l.Error("", zap.Error(err))
+		return nil, err
+	}
 	m, err := v.GetMount(derefPath)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
@@ -446,12 +645,20 @@ func (v *vfs) ListRecycle(ctx context.Context, path string) ([]*api.RecycleEntry
 }
 
 func (v *vfs) RestoreRecycleEntry(ctx context.Context, restoreKey string) error {
+	// the restore key is opaque, so restricted credentials with a path
+	// are always denied as we cannot know where the entry will be restored.
+	if err := api.CheckUserScope(ctx, restoreKey, true); err != nil {
+		v.l.Error("", zap.Error(err))
+		return err
+	}
+
 	m, err := v.GetMount(restoreKey)
 	if err != nil {
 		v.l.Error("", zap.Error(err))
 		return err
 	}
 
+	// no event is published as the restored path is not known here
 	return m.RestoreRecycleEntry(ctx, restoreKey)
 }
 
@@ -0,0 +1,288 @@
+package webhook_manager_db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	gopath "path"
+	"strings"
+	"time"
+
+	"github.com/cernbox/reva/api"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+)
+
+/*
+create table cbox_webhooks (
+	id int not null auto_increment primary key,
+	owner varchar(64) not null,
+	path varchar(4096) not null,
+	is_recursive tinyint not null default 0,
+	url varchar(4096) not null,
+	events varchar(1024) not null default '',
+	secret varchar(255) not null default '',
+	ctime bigint not null
+);
+
+create table cbox_webhook_dead_letters (
+	id int not null auto_increment primary key,
+	webhook_id int not null,
+	payload text not null,
+	error text not null,
+	attempts int not null,
+	ctime bigint not null,
+	index (webhook_id)
+);
+*/
+
+type webhookManager struct {
+	db *sql.DB
+}
+
+func New(dbUsername, dbPassword, dbHost string, dbPort int, dbName string) (api.WebhookManager, error) {
+	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", dbUsername, dbPassword, dbHost, dbPort, dbName))
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookManager{db: db}, nil
+}
+
+func (m *webhookManager) AddWebhook(ctx context.Context, wh *api.Webhook, secret string) (*api.Webhook, error) {
+	l := ctx_zap.Extract(ctx)
+	u, err := getUserFromContext(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	path := gopath.Clean(wh.Path)
+	if !gopath.IsAbs(path) {
+		err := api.NewError(api.PathInvalidError).WithMessage("webhook path must be absolute: " + wh.Path)
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	ctime := time.Now().Unix()
+	stmt, err := m.db.Prepare("insert into cbox_webhooks set owner=?,path=?,is_recursive=?,url=?,events=?,secret=?,ctime=?")
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	result, err := stmt.Exec(u.AccountId, path, wh.Recursive, wh.Url, formatEvents(wh.Events), secret, ctime)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	lastID, err := result.LastInsertId()
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	newWebhook := &api.Webhook{
+		Id:        fmt.Sprintf("%d", lastID),
+		OwnerId:   u.AccountId,
+		Path:      path,
+		Recursive: wh.Recursive,
+		Url:       wh.Url,
+		Events:    wh.Events,
+		Ctime:     uint64(ctime),
+	}
+	l.Info("webhook created", zap.String("id", newWebhook.Id), zap.String("path", path), zap.String("url", wh.Url))
+	return newWebhook, nil
+}
+
+func (m *webhookManager) GetWebhook(ctx context.Context, id string) (*api.Webhook, error) {
+	l := ctx_zap.Extract(ctx)
+	query := "select id, owner, path, is_recursive, url, events, ctime from cbox_webhooks where id=?"
+	wh, err := scanWebhook(m.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, api.NewError(api.WebhookNotFoundErrorCode)
+		}
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	return wh, nil
+}
+
+func (m *webhookManager) ListWebhooks(ctx context.Context) ([]*api.Webhook, error) {
+	l := ctx_zap.Extract(ctx)
+	query := "select id, owner, path, is_recursive, url, events, ctime from cbox_webhooks"
+	rows, err := m.db.Query(query)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []*api.Webhook{}
+	for rows.Next() {
+		wh, err := scanWebhook(rows)
+		if err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		webhooks = append(webhooks, wh)
+	}
+	if err := rows.Err(); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (m *webhookManager) RemoveWebhook(ctx context.Context, id string) error {
+	l := ctx_zap.Extract(ctx)
+	res, err := m.db.Exec("delete from cbox_webhooks where id=?", id)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	rowCnt, err := res.RowsAffected()
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	if rowCnt == 0 {
+		err := api.NewError(api.WebhookNotFoundErrorCode)
+		l.Error("", zap.Error(err), zap.String("id", id))
+		return err
+	}
+
+	if _, err := m.db.Exec("delete from cbox_webhook_dead_letters where webhook_id=?", id); err != nil {
+		l.Error("error removing dead letters of webhook", zap.Error(err), zap.String("id", id))
+		return err
+	}
+	return nil
+}
+
+func (m *webhookManager) GetWebhookSecret(ctx context.Context, id string) (string, error) {
+	l := ctx_zap.Extract(ctx)
+	var secret string
+	if err := m.db.QueryRow("select secret from cbox_webhooks where id=?", id).Scan(&secret); err != nil {
+		if err == sql.ErrNoRows {
+			return "", api.NewError(api.WebhookNotFoundErrorCode)
+		}
+		l.Error("", zap.Error(err))
+		return "", err
+	}
+	return secret, nil
+}
+
+func (m *webhookManager) AddDeadLetter(ctx context.Context, dl *api.DeadLetter) error {
+	l := ctx_zap.Extract(ctx)
+	stmt := "insert into cbox_webhook_dead_letters set webhook_id=?,payload=?,error=?,attempts=?,ctime=?"
+	if _, err := m.db.Exec(stmt, dl.WebhookId, dl.Payload, dl.Error, dl.Attempts, time.Now().Unix()); err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (m *webhookManager) GetDeadLetter(ctx context.Context, id string) (*api.DeadLetter, error) {
+	l := ctx_zap.Extract(ctx)
+	dl := &api.DeadLetter{}
+	query := "select id, webhook_id, payload, error, attempts, ctime from cbox_webhook_dead_letters where id=?"
+	if err := m.db.QueryRow(query, id).Scan(&dl.Id, &dl.WebhookId, &dl.Payload, &dl.Error, &dl.Attempts, &dl.Ctime); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, api.NewError(api.DeadLetterNotFoundErrorCode)
+		}
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	return dl, nil
+}
+
+func (m *webhookManager) ListDeadLetters(ctx context.Context, webhookID string) ([]*api.DeadLetter, error) {
+	l := ctx_zap.Extract(ctx)
+	query := "select id, webhook_id, payload, error, attempts, ctime from cbox_webhook_dead_letters where webhook_id=? order by id"
+	rows, err := m.db.Query(query, webhookID)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	dls := []*api.DeadLetter{}
+	for rows.Next() {
+		dl := &api.DeadLetter{}
+		if err := rows.Scan(&dl.Id, &dl.WebhookId, &dl.Payload, &dl.Error, &dl.Attempts, &dl.Ctime); err != nil {
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		dls = append(dls, dl)
+	}
+	if err := rows.Err(); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	return dls, nil
+}
+
+func (m *webhookManager) RemoveDeadLetter(ctx context.Context, id string) error {
+	l := ctx_zap.Extract(ctx)
+	res, err := m.db.Exec("delete from cbox_webhook_dead_letters where id=?", id)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	rowCnt, err := res.RowsAffected()
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	if rowCnt == 0 {
+		return api.NewError(api.DeadLetterNotFoundErrorCode)
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWebhook(row scanner) (*api.Webhook, error) {
+	var events string
+	wh := &api.Webhook{}
+	if err := row.Scan(&wh.Id, &wh.OwnerId, &wh.Path, &wh.Recursive, &wh.Url, &events, &wh.Ctime); err != nil {
+		return nil, err
+	}
+	wh.Events = parseEvents(events)
+	return wh, nil
+}
+
+// the event types are stored by name, so the column stays readable
+// and does not depend on the numbering of the enum.
+func formatEvents(events []api.FileEvent_Type) string {
+	names := []string{}
+	for _, e := range events {
+		names = append(names, e.String())
+	}
+	return strings.Join(names, ",")
+}
+
+func parseEvents(events string) []api.FileEvent_Type {
+	types := []api.FileEvent_Type{}
+	for _, name := range strings.Split(events, ",") {
+		if t, ok := api.FileEvent_Type_value[name]; ok {
+			types = append(types, api.FileEvent_Type(t))
+		}
+	}
+	return types
+}
+
+func getUserFromContext(ctx context.Context) (*api.User, error) {
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		return nil, api.NewError(api.ContextUserRequiredError)
+	}
+	return u, nil
+}
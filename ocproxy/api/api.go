@@ -4,14 +4,11 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
-	"crypto/md5"
 	"crypto/tls"
-	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"html/template"
-	"image/color"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -33,11 +30,8 @@ import (
 	reva_api "github.com/cernbox/reva/api"
 
 	"github.com/bluele/gcache"
-	"github.com/disintegration/imaging"
-	"github.com/gofrs/uuid"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
-	"github.com/rwcarlsen/goexif/exif"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -76,9 +70,11 @@ func (p *proxy) registerRoutes() {
 	p.router.HandleFunc("/remote.php/webdav{path:.*}", p.tokenAuth(p.delete)).Methods("DELETE")
 	p.router.HandleFunc("/remote.php/webdav{path:.*}", p.tokenAuth(p.move)).Methods("MOVE")
 
-	// favorites routes
-	p.router.HandleFunc("/remote.php/dav/files/{username}/{path:.*}", p.tokenAuth(p.getFav)).Methods("REPORT")
-	p.router.HandleFunc("/remote.php/webdav{path:.*}", p.tokenAuth(p.getFav)).Methods("REPORT")
+	// favorites and search routes
+	p.router.HandleFunc("/remote.php/dav/files/{username}/{path:.*}", p.tokenAuth(p.report)).Methods("REPORT")
+	p.router.HandleFunc("/remote.php/webdav{path:.*}", p.tokenAuth(p.report)).Methods("REPORT")
+	p.router.HandleFunc("/remote.php/dav/files/{username}/{path:.*}", p.tokenAuth(p.davSearch)).Methods("SEARCH")
+	p.router.HandleFunc("/remote.php/webdav{path:.*}", p.tokenAuth(p.davSearch)).Methods("SEARCH")
 	p.router.HandleFunc("/index.php/apps/files/api/v1/files/{path:.*}", p.tokenAuth(p.modifyFav)).Methods("POST")
 
 	// public link webdav access
@@ -94,6 +90,21 @@ func (p *proxy) registerRoutes() {
 	p.router.HandleFunc("/public.php/webdav{path:.*}", p.tokenAuth(p.delete)).Methods("DELETE")
 	p.router.HandleFunc("/public.php/webdav{path:.*}", p.tokenAuth(p.move)).Methods("MOVE")
 
+	// open cloud mesh routes, the other providers access the shared files
+	// with the secret of the share as basic auth user name
+	p.router.HandleFunc("/ocm-provider", p.getOCMProvider).Methods("GET")
+	p.router.HandleFunc("/ocm-provider/", p.getOCMProvider).Methods("GET")
+	p.router.HandleFunc("/ocm/shares", p.addOCMShare).Methods("POST")
+	p.router.HandleFunc("/ocm/notifications", p.notifyOCMShare).Methods("POST")
+	p.router.HandleFunc("/ocm/webdav{path:.*}", p.ocmAuth(p.tokenAuth(p.get))).Methods("GET")
+	p.router.HandleFunc("/ocm/webdav{path:.*}", p.ocmAuth(p.tokenAuth(p.put))).Methods("PUT")
+	p.router.HandleFunc("/ocm/webdav{path:.*}", p.ocmAuth(p.tokenAuth(p.options))).Methods("OPTIONS")
+	p.router.HandleFunc("/ocm/webdav{path:.*}", p.ocmAuth(p.tokenAuth(p.head))).Methods("HEAD")
+	p.router.HandleFunc("/ocm/webdav{path:.*}", p.ocmAuth(p.tokenAuth(p.mkcol))).Methods("MKCOL")
+	p.router.HandleFunc("/ocm/webdav{path:.*}", p.ocmAuth(p.tokenAuth(p.propfind))).Methods("PROPFIND")
+	p.router.HandleFunc("/ocm/webdav{path:.*}", p.ocmAuth(p.tokenAuth(p.delete))).Methods("DELETE")
+	p.router.HandleFunc("/ocm/webdav{path:.*}", p.ocmAuth(p.tokenAuth(p.move))).Methods("MOVE")
+
 	// gallery app routes
 	p.router.HandleFunc("/index.php/apps/gallery/config.public", p.getGalleryConfig).Methods("GET")
 	p.router.HandleFunc("/index.php/apps/gallery/config", p.getGalleryConfig).Methods("GET")
@@ -106,10 +117,14 @@ func (p *proxy) registerRoutes() {
 	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/shares/{share_id}", p.tokenAuth(p.getShare)).Methods("GET")
 	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/shares/{share_id}", p.tokenAuth(p.deleteShare)).Methods("DELETE")
 	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/shares/{share_id}", p.tokenAuth(p.updateShare)).Methods("PUT")
+	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/shares/pending/{share_id}", p.tokenAuth(p.acceptShare)).Methods("POST")
+	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/shares/pending/{share_id}", p.tokenAuth(p.rejectShare)).Methods("DELETE")
 	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/remote_shares", p.tokenAuth(p.getRemoteShares)).Methods("GET")
-	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/remote_shares/{share_id}", p.tokenAuth(p.getShare)).Methods("GET")
-	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/remote_shares/{share_id}", p.tokenAuth(p.deleteShare)).Methods("DELETE")
-	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/remote_shares/{share_id}", p.tokenAuth(p.updateShare)).Methods("PUT")
+	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/remote_shares/pending", p.tokenAuth(p.getPendingRemoteShares)).Methods("GET")
+	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/remote_shares/pending/{share_id}", p.tokenAuth(p.acceptRemoteShare)).Methods("POST")
+	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/remote_shares/pending/{share_id}", p.tokenAuth(p.declineRemoteShare)).Methods("DELETE")
+	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/remote_shares/{share_id}", p.tokenAuth(p.getRemoteShare)).Methods("GET")
+	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/remote_shares/{share_id}", p.tokenAuth(p.declineRemoteShare)).Methods("DELETE")
 	p.router.HandleFunc("/ocs/v2.php/apps/files_sharing/api/v1/sharees", p.tokenAuth(p.search)).Methods("GET")
 
 	p.router.HandleFunc("/ocs/v1.php/apps/files_sharing/api/v1/shares", p.tokenAuth(p.getShares)).Methods("GET")
@@ -120,9 +135,11 @@ func (p *proxy) registerRoutes() {
 	p.router.HandleFunc("/ocs/v1.php/apps/files_sharing/api/v1/shares/pending/{share_id}", p.tokenAuth(p.acceptShare)).Methods("POST")
 	p.router.HandleFunc("/ocs/v1.php/apps/files_sharing/api/v1/shares/pending/{share_id}", p.tokenAuth(p.rejectShare)).Methods("DELETE")
 	p.router.HandleFunc("/ocs/v1.php/apps/files_sharing/api/v1/remote_shares", p.tokenAuth(p.getRemoteShares)).Methods("GET")
-	p.router.HandleFunc("/ocs/v1.php/apps/files_sharing/api/v1/remote_shares/{share_id}", p.tokenAuth(p.getShare)).Methods("GET")
-	p.router.HandleFunc("/ocs/v1.php/apps/files_sharing/api/v1/remote_shares/{share_id}", p.tokenAuth(p.deleteShare)).Methods("DELETE")
-	p.router.HandleFunc("/ocs/v1.php/apps/files_sharing/api/v1/remote_shares/{share_id}", p.tokenAuth(p.updateShare)).Methods("PUT")
+	p.router.HandleFunc("/ocs/v1.php/apps/files_sharing/api/v1/remote_shares/pending", p.tokenAuth(p.getPendingRemoteShares)).Methods("GET")
+	p.router.HandleFunc("/ocs/v1.php/apps/files_sharing/api/v1/remote_shares/pending/{share_id}", p.tokenAuth(p.acceptRemoteShare)).Methods("POST")
+	p.router.HandleFunc("/ocs/v1.php/apps/files_sharing/api/v1/remote_shares/pending/{share_id}", p.tokenAuth(p.declineRemoteShare)).Methods("DELETE")
+	p.router.HandleFunc("/ocs/v1.php/apps/files_sharing/api/v1/remote_shares/{share_id}", p.tokenAuth(p.getRemoteShare)).Methods("GET")
+	p.router.HandleFunc("/ocs/v1.php/apps/files_sharing/api/v1/remote_shares/{share_id}", p.tokenAuth(p.declineRemoteShare)).Methods("DELETE")
 	p.router.HandleFunc("/ocs/v1.php/apps/files_sharing/api/v1/sharees", p.tokenAuth(p.search)).Methods("GET")
 
 	// public link routes
@@ -1120,7 +1137,7 @@ func (p *proxy) unSetTag(ctx context.Context, key, path string) error {
 
 func (p *proxy) getTagsForKey(ctx context.Context, key string) ([]*reva_api.Tag, error) {
 	gCtx := GetContextWithAuth(ctx)
-	stream, err := p.getTagClient().GetTags(gCtx, &reva_api.TagReq{TagKey: "fav"})
+	stream, err := p.getTagClient().GetTags(gCtx, &reva_api.TagReq{TagKey: key})
 	if err != nil {
 		return nil, err
 	}
@@ -1357,6 +1374,13 @@ type OCSShare struct {
 	URL                  string     `json:"url"`
 	State                ShareState `json:"state"`
 	Expiration           string     `json:"expiration,omitempty"`
+
+	// access statistics of the public links
+	MaxDownloads     uint64 `json:"max_downloads,omitempty"`
+	Downloads        uint64 `json:"downloads,omitempty"`
+	Views            uint64 `json:"views,omitempty"`
+	LastAccess       int    `json:"last_access,omitempty"`
+	LastAccessIPHash string `json:"last_access_ip_hash,omitempty"`
 }
 
 type NewShareOCSRequest struct {
@@ -1384,6 +1408,9 @@ type Options struct {
 	OwnCloudSharePrefix string
 	RevaSharePrefix     string
 
+	OwnCloudOCMSharePrefix string
+	RevaOCMSharePrefix     string
+
 	OwnCloudPublicLinkPrefix string
 	RevaPublicLinkPrefix     string
 
@@ -1409,6 +1436,10 @@ type Options struct {
 
 	MailServer            string
 	MailServerFromAddress string
+
+	// TrustedProxies are the IPs and networks of the proxies in front of
+	// ocproxy, only their X-Forwarded-For is believed.
+	TrustedProxies []string
 }
 
 func (opt *Options) init() {
@@ -1435,6 +1466,14 @@ func (opt *Options) init() {
 		opt.RevaSharePrefix = "/shared-with-me"
 	}
 
+	if opt.OwnCloudOCMSharePrefix == "" {
+		opt.OwnCloudOCMSharePrefix = "/__myfederatedshares"
+	}
+
+	if opt.RevaOCMSharePrefix == "" {
+		opt.RevaOCMSharePrefix = "/ocm-shares"
+	}
+
 	if opt.RevaPublicLinkPrefix == "" {
 		opt.RevaPublicLinkPrefix = "/public-links"
 	}
@@ -1494,6 +1533,11 @@ func New(opt *Options) (http.Handler, error) {
 		return nil, err
 	}
 
+	trustedProxies, err := reva_api.ParseTrustedProxies(opt.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
 	tr := &http.Transport{
 		//	DisableKeepAlives:   opts.DisableKeepAlives,
 		//IdleConnTimeout:     time.Duration(opts.IdleConnTimeout) * time.Second,
@@ -1518,6 +1562,9 @@ func New(opt *Options) (http.Handler, error) {
 		ownCloudSharePrefix: opt.OwnCloudSharePrefix,
 		revaSharePrefix:     opt.RevaSharePrefix,
 
+		ownCloudOCMSharePrefix: opt.OwnCloudOCMSharePrefix,
+		revaOCMSharePrefix:     opt.RevaOCMSharePrefix,
+
 		ownCloudPublicLinkPrefix: opt.OwnCloudPublicLinkPrefix,
 		revaPublicLinkPrefix:     opt.RevaPublicLinkPrefix,
 
@@ -1545,6 +1592,9 @@ func New(opt *Options) (http.Handler, error) {
 
 		mailServer:            opt.MailServer,
 		mailServerFromAddress: opt.MailServerFromAddress,
+		dropNotifications:    newDropNotifications(),
+
+		trustedProxies: trustedProxies,
 	}
 
 	proxy.registerRoutes()
@@ -1568,6 +1618,9 @@ type proxy struct {
 	ownCloudSharePrefix string
 	revaSharePrefix     string
 
+	ownCloudOCMSharePrefix string
+	revaOCMSharePrefix     string
+
 	ownCloudPublicLinkPrefix string
 	revaPublicLinkPrefix     string
 
@@ -1594,6 +1647,9 @@ type proxy struct {
 
 	mailServer            string
 	mailServerFromAddress string
+	dropNotifications     *dropNotifications
+
+	trustedProxies reva_api.TrustedProxies
 }
 
 // TODO(labkode): store this global var inside the proxy
@@ -1636,6 +1692,22 @@ func (p *proxy) getShareClient() reva_api.ShareClient {
 	return reva_api.NewShareClient(conn)
 }
 
+func (p *proxy) getPreviewClient() reva_api.PreviewClient {
+	conn, err := p.getConn()
+	if err != nil {
+		panic(err)
+	}
+	return reva_api.NewPreviewClient(conn)
+}
+
+func (p *proxy) getSearchClient() reva_api.SearchClient {
+	conn, err := p.getConn()
+	if err != nil {
+		panic(err)
+	}
+	return reva_api.NewSearchClient(conn)
+}
+
 func (p *proxy) getAuthClient() reva_api.AuthClient {
 	conn, err := p.getConn()
 	if err != nil {
@@ -1644,13 +1716,21 @@ func (p *proxy) getAuthClient() reva_api.AuthClient {
 	return reva_api.NewAuthClient(conn)
 }
 
+func (p *proxy) getOCMClient() reva_api.OCMClient {
+	conn, err := p.getConn()
+	if err != nil {
+		panic(err)
+	}
+	return reva_api.NewOCMClient(conn)
+}
+
 func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	p.router.ServeHTTP(w, r)
 }
 
 func (p *proxy) basicAuth(h http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
+		ctx := reva_api.ContextSetClientIP(r.Context(), p.getClientIP(r))
 		normalizedPath := mux.Vars(r)["path"]
 		normalizedPath = path.Join("/", path.Clean(normalizedPath))
 		mux.Vars(r)["path"] = normalizedPath
@@ -1695,13 +1775,18 @@ func (p *proxy) basicAuth(h http.HandlerFunc) http.HandlerFunc {
 
 		// try to authenticate user with username and password
 		gReq := &reva_api.ForgeUserTokenReq{ClientId: username, ClientSecret: password}
-		gTokenRes, err := authClient.ForgeUserToken(ctx, gReq)
+		gTokenRes, err := authClient.ForgeUserToken(p.getContextWithClientIP(ctx, r), gReq)
 		if err != nil {
 			p.logger.Error("", zap.Error(err))
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 
 		}
+		if gTokenRes.Status == reva_api.StatusCode_TOO_MANY_ATTEMPTS {
+			p.logger.Warn("too many failed login attempts", zap.String("username", username), zap.Uint64("retry_after", gTokenRes.RetryAfter))
+			p.writeTooManyAttempts(gTokenRes.RetryAfter, w)
+			return
+		}
 		if gTokenRes.Status != reva_api.StatusCode_OK {
 			p.logger.Warn("token is not valid", zap.Int("status", int(gTokenRes.Status)))
 			w.Header().Set("WWW-Authenticate", "Basic Realm='owncloud credentials'")
@@ -3331,7 +3416,7 @@ func (p *proxy) createPublicLinkShare(ctx context.Context, newShare *NewShareOCS
 
 }
 
-func (p *proxy) createFolderShare(ctx context.Context, newShare *NewShareOCSRequest, readOnly bool, w http.ResponseWriter, r *http.Request) {
+func (p *proxy) createFolderShare(ctx context.Context, newShare *NewShareOCSRequest, permissions reva_api.SharePermissions, expiration int64, w http.ResponseWriter, r *http.Request) {
 	recipientType := reva_api.ShareRecipient_USER
 	if newShare.ShareType == ShareTypeGroup {
 		recipientType = reva_api.ShareRecipient_GROUP
@@ -3343,9 +3428,10 @@ func (p *proxy) createFolderShare(ctx context.Context, newShare *NewShareOCSRequ
 	}
 
 	newFolderShareReq := &reva_api.NewFolderShareReq{
-		Path:      newShare.Path,
-		ReadOnly:  readOnly,
-		Recipient: recipient,
+		Path:        newShare.Path,
+		Permissions: uint32(permissions),
+		Recipient:   recipient,
+		Expiration:  uint64(expiration),
 	}
 
 	gCtx := GetContextWithAuth(ctx)
@@ -3419,6 +3505,8 @@ func (p *proxy) createShare(w http.ResponseWriter, r *http.Request) {
 			shareType = ShareTypeGroup
 		} else if shareTypeString == "3" {
 			shareType = ShareTypePublicLink
+		} else if shareTypeString == "6" {
+			shareType = ShareTypeFederated
 		}
 		newShare.ShareType = shareType
 
@@ -3513,7 +3601,10 @@ func (p *proxy) createShare(w http.ResponseWriter, r *http.Request) {
 		p.createPublicLinkShare(ctx, newShare, readOnly, dropOnly, expiration, w, r)
 		return
 	} else if newShare.ShareType == ShareTypeUser || newShare.ShareType == ShareTypeGroup {
-		p.createFolderShare(ctx, newShare, readOnly, w, r)
+		p.createFolderShare(ctx, newShare, getRequestedSharePermissions(newShare.Permissions), expiration, w, r)
+		return
+	} else if newShare.ShareType == ShareTypeFederated {
+		p.createOCMShare(ctx, newShare, getRequestedSharePermissions(newShare.Permissions), w, r)
 		return
 	} else {
 		w.WriteHeader(http.StatusNotImplemented)
@@ -3522,23 +3613,6 @@ func (p *proxy) createShare(w http.ResponseWriter, r *http.Request) {
 
 }
 
-func (p *proxy) getRemoteShares(w http.ResponseWriter, r *http.Request) {
-	shares := []*OCSShare{}
-	meta := &ResponseMeta{Status: "ok", StatusCode: 100}
-	payload := &OCSPayload{Meta: meta, Data: shares}
-	ocsRes := &OCSResponse{OCS: payload}
-	encoded, err := json.Marshal(ocsRes)
-	if err != nil {
-		p.logger.Error("", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	w.Write(encoded)
-
-}
-
 func (p *proxy) getShares(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	onlySharedWithOthers := r.URL.Query().Get("only_shared_with_others") == "true"
@@ -3742,35 +3816,38 @@ func (p *proxy) getFolderShares(ctx context.Context, onlyForPath string) ([]*OCS
 }
 
 func (p *proxy) receivedFolderShareToOCSShare(ctx context.Context, share *reva_api.FolderShare) (*OCSShare, error) {
-	ocPath := p.getSharedMountPath(ctx, share)
-	revaPath := p.getRevaPath(ctx, ocPath)
-	md, err := p.getCachedMetadata(ctx, revaPath)
-	if err != nil {
-		return nil, err
+	// only the accepted shares are mounted, the others are described by the share
+	md := &reva_api.Metadata{Id: share.Path}
+	if share.State == reva_api.FolderShare_ACCEPTED {
+		ocPath := p.getSharedMountPath(ctx, share)
+		revaPath := p.getRevaPath(ctx, ocPath)
+		mountMD, err := p.getCachedMetadata(ctx, revaPath)
+		if err != nil {
+			return nil, err
+		}
+		md = mountMD
 	}
 
-	var itemType ItemType = ItemTypeFolder
+	itemType, mimeType := getShareItemType(share, share.Target)
 	shareType := ShareTypeUser
 	if share.Recipient.Type == reva_api.ShareRecipient_GROUP {
 		shareType = ShareTypeGroup
 	}
 
-	var mimeType = "httpd/unix-directory"
-	var permissions Permission
-	if share.ReadOnly {
-		permissions = PermissionRead
-	} else {
-		permissions = PermissionReadWrite
-	}
+	permissions := getSharePermissions(share)
 
 	var shareWith string = share.Recipient.Identity
+	expiration := formatShareExpiration(share)
+
+	// a re-share is owned by whoever re-shared it, the file by the owner of the share
+	initiator := reva_api.GetShareInitiator(share)
 
 	targetPath := path.Join(p.ownCloudSharePrefix, share.Target+fmt.Sprintf(" (id:%s)", share.Id))
 	ocsShare := &OCSShare{
 		ShareType:            shareType,
 		ID:                   share.Id,
 		DisplayNameFileOwner: share.OwnerId,
-		DisplayNameOwner:     share.OwnerId,
+		DisplayNameOwner:     initiator,
 		FileSource:           md.Id,
 		FileTarget:           targetPath,
 		ItemSource:           md.Id,
@@ -3779,45 +3856,40 @@ func (p *proxy) receivedFolderShareToOCSShare(ctx context.Context, share *reva_a
 		Path:                 targetPath,
 		Permissions:          permissions,
 		ShareTime:            int(share.Mtime),
-		State:                ShareStateAccepted,
+		State:                getShareState(share),
 		UIDFileOwner:         share.OwnerId,
-		UIDOwner:             share.OwnerId,
+		UIDOwner:             initiator,
 		ShareWith:            &shareWith,
 		ShareWithDisplayName: shareWith,
+		Expiration:           expiration,
 	}
 	return ocsShare, nil
 }
 func (p *proxy) folderShareToOCSShare(ctx context.Context, share *reva_api.FolderShare) (*OCSShare, error) {
-	// TODO(labkode): harden check
-	user, _ := reva_api.ContextGetUser(ctx)
-	owner := user.AccountId
+	owner := share.OwnerId
+	initiator := reva_api.GetShareInitiator(share)
 
 	md, err := p.getMetadata(ctx, share.Path)
 	if err != nil {
 		return nil, err
 	}
 
-	var itemType ItemType = ItemTypeFolder
+	itemType, mimeType := getShareItemType(share, md.Path)
 	shareType := ShareTypeUser
 	if share.Recipient.Type == reva_api.ShareRecipient_GROUP {
 		shareType = ShareTypeGroup
 	}
 
-	var mimeType = "httpd/unix-directory"
-	var permissions Permission
-	if share.ReadOnly {
-		permissions = PermissionRead
-	} else {
-		permissions = PermissionReadWrite
-	}
+	permissions := getSharePermissions(share)
 
 	var shareWith string = share.Recipient.Identity
+	expiration := formatShareExpiration(share)
 
 	ocsShare := &OCSShare{
 		ShareType:            shareType,
 		ID:                   share.Id,
 		DisplayNameFileOwner: owner,
-		DisplayNameOwner:     owner,
+		DisplayNameOwner:     initiator,
 		FileSource:           md.Id,
 		FileTarget:           md.Path,
 		ItemSource:           md.Id,
@@ -3829,9 +3901,10 @@ func (p *proxy) folderShareToOCSShare(ctx context.Context, share *reva_api.Folde
 		ShareTime:            int(share.Mtime),
 		State:                ShareStateAccepted,
 		UIDFileOwner:         owner,
-		UIDOwner:             owner,
+		UIDOwner:             initiator,
 		ShareWith:            &shareWith,
 		ShareWithDisplayName: shareWith,
+		Expiration:           expiration,
 	}
 	return ocsShare, nil
 }
@@ -3913,6 +3986,11 @@ func (p *proxy) publicLinkToOCSShare(ctx context.Context, pl *reva_api.PublicLin
 		ShareWithDisplayName: shareWith,
 		Expiration:           expiration,
 		URL:                  fmt.Sprintf("https://%s/index.php/s/%s", p.overwriteHost, pl.Token),
+		MaxDownloads:         pl.MaxDownloads,
+		Downloads:            pl.Downloads,
+		Views:                pl.Views,
+		LastAccess:           int(pl.LastAccess),
+		LastAccessIPHash:     pl.LastAccessIpHash,
 	}
 	return ocsShare, nil
 }
@@ -3938,6 +4016,15 @@ func (p *proxy) getReceivedShares(w http.ResponseWriter, r *http.Request, path s
 
 	}
 
+	state := r.URL.Query().Get("state")
+	filtered = []*OCSShare{}
+	for _, v := range ocsShares {
+		if matchShareState(state, v) {
+			filtered = append(filtered, v)
+		}
+	}
+	ocsShares = filtered
+
 	meta := &ResponseMeta{Status: "ok", StatusCode: 200}
 	payload := &OCSPayload{Meta: meta, Data: ocsShares}
 	ocsRes := &OCSResponse{OCS: payload}
@@ -4250,10 +4337,9 @@ func (p *proxy) isFolderShare(ctx context.Context, shareID string) (bool, error)
 	return true, nil
 }
 
-// TODO(labkode): check for updateReadOnly
-func (p *proxy) updateFolderShare(shareID string, readOnly bool, w http.ResponseWriter, r *http.Request) {
+func (p *proxy) updateFolderShare(shareID string, updateExpiration bool, expiration int64, updatePermissions bool, permissions reva_api.SharePermissions, w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	req := &reva_api.UpdateFolderShareReq{Id: shareID, ReadOnly: readOnly, UpdateReadOnly: true}
+	req := &reva_api.UpdateFolderShareReq{Id: shareID, Permissions: uint32(permissions), UpdatePermissions: updatePermissions, UpdateExpiration: updateExpiration, Expiration: uint64(expiration)}
 	gCtx := GetContextWithAuth(ctx)
 	res, err := p.getShareClient().UpdateFolderShare(gCtx, req)
 	if err != nil {
@@ -4467,7 +4553,7 @@ func (p *proxy) updateShare(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if found {
-		p.updateFolderShare(shareID, readOnly, w, r)
+		p.updateFolderShare(shareID, updateExpiration, expiration, updatePermissions, getRequestedSharePermissions(newShare.Permissions), w, r)
 		return
 	}
 
@@ -4475,9 +4561,6 @@ func (p *proxy) updateShare(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotFound)
 }
 
-func (p *proxy) acceptShare(w http.ResponseWriter, r *http.Request) {
-}
-
 func (p *proxy) rejectShare(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	shareID := mux.Vars(r)["share_id"]
@@ -4509,7 +4592,7 @@ func (p *proxy) isNotFoundError(err error) bool {
 
 func (p *proxy) writeError(status reva_api.StatusCode, w http.ResponseWriter, r *http.Request) {
 	p.logger.Warn("write error", zap.Int("status", int(status)))
-	if status == reva_api.StatusCode_STORAGE_NOT_FOUND {
+	if status == reva_api.StatusCode_STORAGE_NOT_FOUND || status == reva_api.StatusCode_PREVIEW_NOT_SUPPORTED || status == reva_api.StatusCode_FOLDER_SHARE_NOT_FOUND || status == reva_api.StatusCode_OCM_SHARE_NOT_FOUND {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
@@ -4517,6 +4600,18 @@ func (p *proxy) writeError(status reva_api.StatusCode, w http.ResponseWriter, r
 		w.WriteHeader(http.StatusForbidden)
 		return
 	}
+	if status == reva_api.StatusCode_PUBLIC_LINK_DOWNLOAD_LIMIT {
+		w.WriteHeader(http.StatusGone)
+		return
+	}
+	if status == reva_api.StatusCode_FOLDER_SHARE_INVALID_PERMISSIONS || status == reva_api.StatusCode_PATH_INVALID {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if status == reva_api.StatusCode_PUBLIC_LINK_PASSWORD_TOO_SHORT || status == reva_api.StatusCode_PUBLIC_LINK_PASSWORD_TOO_WEAK || status == reva_api.StatusCode_PUBLIC_LINK_PASSWORD_BANNED || status == reva_api.StatusCode_PUBLIC_LINK_PASSWORD_REQUIRED {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 	w.WriteHeader(http.StatusInternalServerError)
 }
 
@@ -4537,10 +4632,16 @@ const (
 	ShareTypeUser       ShareType = 0
 	ShareTypeGroup                = 1
 	ShareTypePublicLink           = 3
+	ShareTypeFederated            = 6
 
-	PermissionRead      Permission = 1
-	PermissionReadWrite Permission = 15
-	PermissionDropOnly  Permission = 4
+	PermissionRead          Permission = 1
+	PermissionUpdate        Permission = 2
+	PermissionCreate        Permission = 4
+	PermissionDelete        Permission = 8
+	PermissionShare         Permission = 16
+	PermissionFileReadWrite Permission = 3
+	PermissionReadWrite     Permission = 15
+	PermissionDropOnly      Permission = 4
 
 	ItemTypeFile   ItemType = "file"
 	ItemTypeFolder ItemType = "folder"
@@ -4645,7 +4746,7 @@ func (p *proxy) renderPublicLink(w http.ResponseWriter, r *http.Request) {
 	}
 
 	client := p.getAuthClient()
-	res, err := client.ForgePublicLinkToken(ctx, &reva_api.ForgePublicLinkTokenReq{Token: token, Password: password})
+	res, err := client.ForgePublicLinkToken(p.getContextWithClientIP(ctx, r), &reva_api.ForgePublicLinkTokenReq{Token: token, Password: password})
 	if err != nil {
 		// render link not found template
 		p.logger.Error("", zap.Error(err))
@@ -4653,6 +4754,13 @@ func (p *proxy) renderPublicLink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if res.Status == reva_api.StatusCode_PUBLIC_LINK_DOWNLOAD_LIMIT {
+		p.logger.Warn("public link download limit reached", zap.String("token", token))
+		w.WriteHeader(http.StatusGone)
+		w.Write([]byte(publicLinkTemplateNotFound))
+		return
+	}
+
 	if res.Status != reva_api.StatusCode_OK {
 		tpl, err := template.New("public_link_password").Parse(publicLinkTemplatePassword)
 		if err != nil {
@@ -4661,7 +4769,19 @@ func (p *proxy) renderPublicLink(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		tpl.Execute(w, nil)
+		data := struct {
+			Message string
+		}{}
+		if res.Status == reva_api.StatusCode_TOO_MANY_ATTEMPTS {
+			p.logger.Warn("too many failed public link attempts", zap.String("token", token), zap.Uint64("retry_after", res.RetryAfter))
+			data.Message = fmt.Sprintf("Too many failed attempts, please try again in %d seconds.", res.RetryAfter)
+			w.Header().Set("Retry-After", fmt.Sprintf("%d", res.RetryAfter))
+			w.WriteHeader(http.StatusTooManyRequests)
+		} else if password != "" {
+			data.Message = "The password is wrong. Try again."
+		}
+
+		tpl.Execute(w, data)
 		return
 	}
 
@@ -4698,9 +4818,10 @@ func (p *proxy) renderPublicLink(w http.ResponseWriter, r *http.Request) {
 		data := struct {
 			Token         string
 			AccessToken   string
+			ShareName     string
 			Note          string
 			OverwriteHost string
-		}{AccessToken: res.Token, Token: token, Note: "The CERN Cloud Storage", OverwriteHost: p.overwriteHost}
+		}{AccessToken: res.Token, Token: token, ShareName: pl.Name, Note: "The CERN Cloud Storage", OverwriteHost: p.overwriteHost}
 
 		if pl.DropOnly {
 			tpl, err := template.New("public_link_drop_only").Parse(publicLinkDropOnly)
@@ -4818,218 +4939,42 @@ func (p *proxy) getPublicPreview(w http.ResponseWriter, r *http.Request) {
 	p.getPreview(w, r)
 }
 
-// TODO(labkode): refactor getGalleryPreview and getPreview
 func (p *proxy) getGalleryPreview(w http.ResponseWriter, r *http.Request) {
 	p.logger.Info("get request for gallery preview")
-	ctx := r.Context()
-	reqPath := mux.Vars(r)["path"]
-	widthString := r.URL.Query().Get("width")
-	heightString := r.URL.Query().Get("height")
-
-	width, err := strconv.ParseInt(widthString, 10, 64)
-	if err != nil {
-		p.logger.Warn("", zap.String("x", widthString))
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-
-	height, err := strconv.ParseInt(heightString, 10, 64)
-	if err != nil {
-		p.logger.Warn("", zap.String("y", heightString))
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-
-	revaPath := p.getRevaPath(ctx, reqPath)
-	gCtx := GetContextWithAuth(ctx)
-	gReq := &reva_api.PathReq{Path: revaPath}
-	mdRes, err := p.getStorageClient().Inspect(gCtx, gReq)
-	if err != nil {
-		p.logger.Error("", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	if mdRes.Status != reva_api.StatusCode_OK {
-		p.writeError(mdRes.Status, w, r)
-		return
-	}
-
-	md := mdRes.Metadata
-	md.Path = p.getOCPath(ctx, md)
-	if md.IsDir {
-		p.logger.Warn("file is a folder")
-		w.WriteHeader(http.StatusNotImplemented)
-		return
-	}
-
-	// check if the file is already stored
-	key := fmt.Sprintf("%s-%s-%d-%d", reqPath, md.Etag, width, height)
-	thumbname := getMD5Hash(key)
-	target := path.Join(p.temporaryFolder, thumbname)
-	p.logger.Info("preparing preview", zap.String("path", reqPath), zap.String("key", key), zap.String("target", target))
-
-	if _, err := os.Stat(target); err == nil {
-		p.logger.Info("preview found on disk for path", zap.String("path", reqPath), zap.String("preview", target))
-		fd, err := os.Open(target)
-		defer fd.Close()
-		if err != nil {
-			p.logger.Error("", zap.Error(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-
-		w.Header().Set("Content-Type", md.Mime)
-		w.Header().Set("ETag", md.Etag)
-		w.Header().Set("OC-FileId", md.Id)
-		w.Header().Set("OC-ETag", md.Etag)
-		t := time.Unix(int64(md.Mtime), 0)
-		lastModifiedString := t.Format(time.RFC1123)
-		w.Header().Set("Last-Modified", lastModifiedString)
-		if md.Checksum != "" {
-			w.Header().Set("OC-Checksum", md.Checksum)
-		}
-		w.WriteHeader(http.StatusOK)
-		io.Copy(w, fd)
-		return
-	}
-
-	// TODO(labkode): check for size limit
-	p.logger.Info("generating preview for path", zap.String("path", reqPath), zap.String("preview", target))
-
-	stream, err := p.getStorageClient().ReadFile(gCtx, gReq)
-	if err != nil {
-		p.logger.Error("", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	contents := []byte{}
-	for {
-		dcRes, err := stream.Recv()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			p.logger.Error("", zap.Error(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-		if dcRes.Status != reva_api.StatusCode_OK {
-			p.writeError(dcRes.Status, w, r)
-			return
-		}
-
-		dc := dcRes.DataChunk
-
-		if dc != nil {
-			if dc.Length > 0 {
-				contents = append(contents, dc.Data...)
-			}
-		}
-	}
-
-	basename := path.Base(reqPath)
-	format, err := imaging.FormatFromFilename(basename)
-	if err != nil {
-		p.logger.Error("", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	var rotate int
-	var flip FlipDirection
-	reader := bytes.NewReader(contents)
-
-	ex, err := exif.Decode(reader)
-	if err == nil {
-		rotate, flip = exifOrientation(ex)
-	}
-
-	_, err = reader.Seek(0, 0)
-	if err != nil {
-		p.logger.Error("", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	img, err := imaging.Decode(reader)
-	if err != nil {
-		panic(err)
-	}
-
-	img = imaging.Thumbnail(img, int(width), int(height), imaging.Linear)
-
-	// apply transformations
-	if rotate > 0 {
-		img = imaging.Rotate(img, float64(rotate), color.Transparent)
-	}
-	if flip == FlipVertical {
-		img = imaging.FlipV(img)
-	} else if flip == FlipHorizontal {
-		img = imaging.FlipH(img)
-	}
-
-	fd, err := os.Create(target)
-	defer fd.Close()
-	if err != nil {
-		p.logger.Error("", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	err = imaging.Encode(fd, img, format)
-	if err != nil {
-		p.logger.Error("", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	fd.Close()
-	// TODO(labkode): use a multi-writer to write to respone and to disk at same time
-
-	w.Header().Set("Content-Type", md.Mime)
-	w.Header().Set("ETag", md.Etag)
-	w.Header().Set("OC-FileId", md.Id)
-	w.Header().Set("OC-ETag", md.Etag)
-	t := time.Unix(int64(md.Mtime), 0)
-	lastModifiedString := t.Format(time.RFC1123)
-	w.Header().Set("Last-Modified", lastModifiedString)
-	if md.Checksum != "" {
-		w.Header().Set("OC-Checksum", md.Checksum)
-	}
-	w.WriteHeader(http.StatusOK)
-
-	fd, err = os.Open(target)
-	defer fd.Close()
-	io.Copy(w, fd)
-
+	p.writePreview(w, r, path.Clean(mux.Vars(r)["path"]), r.URL.Query().Get("width"), r.URL.Query().Get("height"))
 }
 
 func (p *proxy) getPreview(w http.ResponseWriter, r *http.Request) {
 	p.logger.Info("get request for preview")
-	ctx := r.Context()
-	reqPath := path.Clean(mux.Vars(r)["path"])
-	//etag := r.URL.Query().Get("c")
-	widthString := r.URL.Query().Get("x")
-	heightString := r.URL.Query().Get("y")
+	p.writePreview(w, r, path.Clean(mux.Vars(r)["path"]), r.URL.Query().Get("x"), r.URL.Query().Get("y"))
+}
 
-	width, err := strconv.ParseInt(widthString, 10, 64)
+// writePreview writes the preview generated by the preview service,
+// a=1 asks to keep the aspect ratio instead of cropping the image.
+func (p *proxy) writePreview(w http.ResponseWriter, r *http.Request, reqPath, widthString, heightString string) {
+	ctx := r.Context()
+	width, err := strconv.ParseUint(widthString, 10, 64)
 	if err != nil {
 		p.logger.Warn("", zap.String("x", widthString))
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	height, err := strconv.ParseInt(heightString, 10, 64)
+	height, err := strconv.ParseUint(heightString, 10, 64)
 	if err != nil {
 		p.logger.Warn("", zap.String("y", heightString))
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
+	mode := reva_api.PreviewReq_CROP
+	if r.URL.Query().Get("a") == "1" {
+		mode = reva_api.PreviewReq_FIT
+	}
+
 	gCtx := GetContextWithAuth(ctx)
 	revaPath := p.getRevaPath(ctx, reqPath)
-	gReq := &reva_api.PathReq{Path: revaPath}
-	mdRes, err := p.getStorageClient().Inspect(gCtx, gReq)
+	mdRes, err := p.getStorageClient().Inspect(gCtx, &reva_api.PathReq{Path: revaPath})
 	if err != nil {
 		p.logger.Error("", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
@@ -5047,48 +4992,15 @@ func (p *proxy) getPreview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// check if the file is already stored
-	key := fmt.Sprintf("%s-%s-%d-%d", reqPath, md.Etag, width, height)
-	thumbname := getMD5Hash(key)
-	target := path.Join(p.temporaryFolder, thumbname)
-	p.logger.Info("preparing preview", zap.String("path", reqPath), zap.String("key", key), zap.String("target", target))
-
-	if _, err := os.Stat(target); err == nil {
-		p.logger.Info("preview found on disk for path", zap.String("path", reqPath), zap.String("preview", target))
-		fd, err := os.Open(target)
-		defer fd.Close()
-		if err != nil {
-			p.logger.Error("", zap.Error(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-
-		w.Header().Set("Content-Type", md.Mime)
-		w.Header().Set("ETag", md.Etag)
-		w.Header().Set("OC-FileId", md.Id)
-		w.Header().Set("OC-ETag", md.Etag)
-		t := time.Unix(int64(md.Mtime), 0)
-		lastModifiedString := t.Format(time.RFC1123)
-		w.Header().Set("Last-Modified", lastModifiedString)
-		if md.Checksum != "" {
-			w.Header().Set("OC-Checksum", md.Checksum)
-		}
-		w.WriteHeader(http.StatusOK)
-		io.Copy(w, fd)
-		return
-	}
-
-	// TODO(labkode): check for size limit
-	p.logger.Info("generating preview for path", zap.String("path", reqPath), zap.String("preview", target))
-
-	stream, err := p.getStorageClient().ReadFile(gCtx, gReq)
+	req := &reva_api.PreviewReq{Path: revaPath, Width: width, Height: height, Mode: mode}
+	stream, err := p.getPreviewClient().ReadPreview(gCtx, req)
 	if err != nil {
 		p.logger.Error("", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	contents := []byte{}
+	wroteHeader := false
 	for {
 		dcRes, err := stream.Recv()
 		if err == io.EOF {
@@ -5096,7 +5008,9 @@ func (p *proxy) getPreview(w http.ResponseWriter, r *http.Request) {
 		}
 		if err != nil {
 			p.logger.Error("", zap.Error(err))
-			w.WriteHeader(http.StatusInternalServerError)
+			if !wroteHeader {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
 			return
 		}
 		if dcRes.Status != reva_api.StatusCode_OK {
@@ -5104,96 +5018,31 @@ func (p *proxy) getPreview(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		dc := dcRes.DataChunk
-
-		if dc != nil {
-			if dc.Length > 0 {
-				contents = append(contents, dc.Data...)
+		if !wroteHeader {
+			// text files have an image preview, so the type is not the one of the file
+			contentType := md.Mime
+			if dcRes.DataChunk != nil {
+				contentType = http.DetectContentType(dcRes.DataChunk.Data)
 			}
+			w.Header().Set("Content-Type", contentType)
+			w.Header().Set("ETag", md.Etag)
+			w.Header().Set("OC-FileId", md.Id)
+			w.Header().Set("OC-ETag", md.Etag)
+			t := time.Unix(int64(md.Mtime), 0)
+			lastModifiedString := t.Format(time.RFC1123)
+			w.Header().Set("Last-Modified", lastModifiedString)
+			w.WriteHeader(http.StatusOK)
+			wroteHeader = true
 		}
-	}
 
-	basename := path.Base(reqPath)
-	if token, ok := reva_api.ContextGetPublicLinkToken(ctx); ok && token != "" {
-		if pl, ok := reva_api.ContextGetPublicLink(ctx); ok {
-			if reqPath == "/" { // preview for file pl
-				basename = pl.Name
+		dc := dcRes.DataChunk
+		if dc != nil && dc.Length > 0 {
+			if _, err := w.Write(dc.Data); err != nil {
+				p.logger.Error("", zap.Error(err))
+				return
 			}
 		}
 	}
-
-	format, err := imaging.FormatFromFilename(basename)
-	if err != nil {
-		p.logger.Error("", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	var rotate int
-	var flip FlipDirection
-	reader := bytes.NewReader(contents)
-
-	ex, err := exif.Decode(reader)
-	if err == nil {
-		rotate, flip = exifOrientation(ex)
-	}
-
-	_, err = reader.Seek(0, 0)
-	if err != nil {
-		p.logger.Error("", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	img, err := imaging.Decode(reader)
-	if err != nil {
-		panic(err)
-	}
-
-	img = imaging.Thumbnail(img, int(width), int(height), imaging.Linear)
-
-	// apply transformations
-	if rotate > 0 {
-		img = imaging.Rotate(img, float64(rotate), color.Transparent)
-	}
-	if flip == FlipVertical {
-		img = imaging.FlipV(img)
-	} else if flip == FlipHorizontal {
-		img = imaging.FlipH(img)
-	}
-
-	fd, err := os.Create(target)
-	defer fd.Close()
-	if err != nil {
-		p.logger.Error("", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	err = imaging.Encode(fd, img, format)
-	if err != nil {
-		p.logger.Error("", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	fd.Close()
-	// TODO(labkode): use a multi-writer to write to respone and to disk at same time
-
-	w.Header().Set("Content-Type", md.Mime)
-	w.Header().Set("ETag", md.Etag)
-	w.Header().Set("OC-FileId", md.Id)
-	w.Header().Set("OC-ETag", md.Etag)
-	t := time.Unix(int64(md.Mtime), 0)
-	lastModifiedString := t.Format(time.RFC1123)
-	w.Header().Set("Last-Modified", lastModifiedString)
-	if md.Checksum != "" {
-		w.Header().Set("OC-Checksum", md.Checksum)
-	}
-	w.WriteHeader(http.StatusOK)
-
-	fd, err = os.Open(target)
-	defer fd.Close()
-	io.Copy(w, fd)
 }
 
 func (p *proxy) get(w http.ResponseWriter, r *http.Request) {
@@ -5420,6 +5269,16 @@ func (p *proxy) mkcol(w http.ResponseWriter, r *http.Request) {
 }
 
 func (p *proxy) proppatch(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if p.isTagsPropPatch(body) {
+		p.patchTags(w, r, body)
+		return
+	}
 	w.WriteHeader(http.StatusOK)
 }
 func (p *proxy) move(w http.ResponseWriter, r *http.Request) {
@@ -5459,6 +5318,9 @@ func (p *proxy) move(w http.ResponseWriter, r *http.Request) {
 		davPrefix := "public.php/webdav"
 		index := strings.Index(destinationURL.Path, davPrefix)
 		destinationPath = path.Join("/", string(destinationURL.Path[index+len(davPrefix):]))
+	} else if strings.HasPrefix(destinationURL.Path, "/ocm/webdav") {
+		davPrefix := "/ocm/webdav"
+		destinationPath = path.Join("/", string(destinationURL.Path[len(davPrefix):]))
 	} else { // url is /remote.php/dav/gonzalhu/files
 		username := mux.Vars(r)["username"]
 		davPrefix := fmt.Sprintf("remote.php/dav/files/%s", username)
@@ -5644,7 +5506,8 @@ func (p *proxy) put(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// all the chunks have been sent, we need to close the tx
-	emptyRes, err := p.getStorageClient().FinishWriteTx(gCtx, &reva_api.TxEnd{Path: revaPath, TxId: txInfo.TxId})
+	var header metadata.MD
+	emptyRes, err := p.getStorageClient().FinishWriteTx(gCtx, &reva_api.TxEnd{Path: revaPath, TxId: txInfo.TxId}, grpc.Header(&header))
 	if err != nil {
 		p.logger.Error("", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
@@ -5656,6 +5519,10 @@ func (p *proxy) put(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if p.finishDropUpload(ctx, revaPath, header, w) {
+		return
+	}
+
 	modifiedMdRes, err := p.getStorageClient().Inspect(gCtx, gReq)
 	if err != nil {
 		p.logger.Error("", zap.Error(err))
@@ -5963,7 +5830,8 @@ func (p *proxy) putChunked(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// all the chunks have been sent, we need to close the tx
-	emptyRes, err := p.getStorageClient().FinishWriteTx(gCtx, &reva_api.TxEnd{Path: chunkInfo.path, TxId: txInfo.TxId})
+	var header metadata.MD
+	emptyRes, err := p.getStorageClient().FinishWriteTx(gCtx, &reva_api.TxEnd{Path: chunkInfo.path, TxId: txInfo.TxId}, grpc.Header(&header))
 	if err != nil {
 		p.logger.Error("", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
@@ -5974,6 +5842,10 @@ func (p *proxy) putChunked(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if p.finishDropUpload(ctx, chunkInfo.path, header, w) {
+		return
+	}
+
 	modifiedMdRes, err := p.getStorageClient().Inspect(gCtx, gReq)
 	if err != nil {
 		p.logger.Error("", zap.Error(err))
@@ -6024,6 +5896,13 @@ func (p *proxy) propfind(w http.ResponseWriter, r *http.Request) {
 		children = true
 	}
 
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	var mds []*reva_api.Metadata
 	mdRes, err := p.getStorageClient().Inspect(gCtx, gReq)
 	if err != nil {
@@ -6068,7 +5947,7 @@ func (p *proxy) propfind(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	mdsInXML, err := p.mdsToXML(ctx, mds)
+	mdsInXML, err := p.mdsToXML(ctx, path, mds, p.isTagsPropRequested(body))
 	if err != nil {
 		p.logger.Error("", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
@@ -6210,10 +6089,32 @@ func getChunkBLOBInfo(path string) (*chunkBLOBInfo, error) {
 	}, nil
 }
 
-func (p *proxy) mdsToXML(ctx context.Context, mds []*reva_api.Metadata) (string, error) {
+func (p *proxy) mdsToXML(ctx context.Context, path string, mds []*reva_api.Metadata, withTags bool) (string, error) {
+	// public links have no tags
+	var tags map[string][]*reva_api.Tag
+	if _, ok := reva_api.ContextGetUser(ctx); ok && withTags {
+		var err error
+		if len(mds) > 1 {
+			tags, err = p.getTagsByFileID(ctx, path)
+		} else if len(mds) == 1 {
+			// without the entries there is no need to list the folder
+			var fileTags []*reva_api.Tag
+			if fileTags, err = p.getFileTags(ctx, path); err == nil {
+				tags = map[string][]*reva_api.Tag{mds[0].Id: fileTags}
+			}
+		}
+		if err != nil {
+			p.logger.Error("error getting tags of user", zap.Error(err))
+		}
+	}
+
 	responses := []*responseXML{}
 	for _, md := range mds {
-		res, err := p.mdToPropResponse(ctx, md)
+		var props []propertyXML
+		if tags != nil {
+			props = p.getTagProps(tags[md.Id])
+		}
+		res, err := p.mdToPropResponse(ctx, md, props...)
 		if err != nil {
 			return "", err
 		}
@@ -6342,6 +6243,11 @@ func (p *proxy) mdToPropResponse(ctx context.Context, md *reva_api.Metadata, pro
 
 		response.Href = ref
 
+	} else if val := ctx.Value("ocm-dav-uri"); val != nil { // ocm share access
+		response.Href = path.Join("/ocm/webdav", md.Path)
+		if md.IsDir {
+			response.Href = path.Join("/ocm/webdav", md.Path) + "/"
+		}
 	} else { // public link access
 		response.Href = path.Join("/public.php/webdav", md.Path)
 		if md.IsDir {
@@ -6407,89 +6313,44 @@ type errorXML struct {
 	InnerXML []byte   `xml:",innerxml"`
 }
 
-// exifOrientation parses the  EXIF data in r and returns the stored
-// orientation as the angle and flip necessary to transform the image.
-func exifOrientation(ex *exif.Exif) (int, FlipDirection) {
-	var (
-		angle    int
-		flipMode FlipDirection
-	)
-	tag, err := ex.Get(exif.Orientation)
-	if err != nil {
-		return 0, 0
-	}
-	orient, err := tag.Int(0)
-	if err != nil {
-		return 0, 0
-	}
-	switch orient {
-	case topLeftSide:
-		// do nothing
-	case topRightSide:
-		flipMode = 2
-	case bottomRightSide:
-		angle = 180
-	case bottomLeftSide:
-		angle = 180
-		flipMode = 2
-	case leftSideTop:
-		angle = -90
-		flipMode = 2
-	case rightSideTop:
-		angle = -90
-	case rightSideBottom:
-		angle = 90
-		flipMode = 2
-	case leftSideBottom:
-		angle = 90
-	}
-	return angle, flipMode
-}
-
-// Exif Orientation Tag values
-// Exif Orientation Tag values
-// http://sylvana.net/jpegcrop/exif_orientation.html
-const (
-	topLeftSide     = 1
-	topRightSide    = 2
-	bottomRightSide = 3
-	bottomLeftSide  = 4
-	leftSideTop     = 5
-	rightSideTop    = 6
-	rightSideBottom = 7
-	leftSideBottom  = 8
-)
-
-// The FlipDirection type is used by the Flip option in DecodeOpts
-// to indicate in which direction to flip an image.
-type FlipDirection int
-
-// FlipVertical and FlipHorizontal are two possible FlipDirections
-// values to indicate in which direction an image will be flipped.
-const (
-	FlipVertical FlipDirection = 1 << iota
-	FlipHorizontal
-)
-
-func getMD5Hash(text string) string {
-	hasher := md5.New()
-	hasher.Write([]byte(text))
-	return hex.EncodeToString(hasher.Sum(nil))
-}
-
 func GetContextWithAuth(ctx context.Context) context.Context {
 	if token, ok := reva_api.ContextGetPublicLinkToken(ctx); ok && token != "" {
 		header := metadata.New(map[string]string{"authorization": "pl-bearer " + token})
-		return metadata.NewOutgoingContext(ctx, header)
+		return metadata.NewOutgoingContext(ctx, withClientIP(ctx, header))
 	}
 
 	if token, ok := reva_api.ContextGetAccessToken(ctx); ok && token != "" {
 		header := metadata.New(map[string]string{"authorization": "user-bearer " + token})
-		return metadata.NewOutgoingContext(ctx, header)
+		return metadata.NewOutgoingContext(ctx, withClientIP(ctx, header))
 	}
 	return ctx
 }
 
+// withClientIP forwards the IP of the HTTP client to REVA, to be recorded in the audit events.
+func withClientIP(ctx context.Context, header metadata.MD) metadata.MD {
+	if ip, ok := reva_api.ContextGetClientIP(ctx); ok && ip != "" {
+		header.Set("x-forwarded-for", ip)
+	}
+	return header
+}
+
+// getClientIP returns the IP of the HTTP client, taking into account
+// the X-Forwarded-For header when it is set by a trusted proxy in front of us.
+func (p *proxy) getClientIP(r *http.Request) string {
+	return p.trustedProxies.ClientIP(r.RemoteAddr, strings.Join(r.Header["X-Forwarded-For"], ","))
+}
+
+// getContextWithClientIP forwards the IP of the HTTP client to REVA,
+// so failed authentication attempts can be throttled per client.
+func (p *proxy) getContextWithClientIP(ctx context.Context, r *http.Request) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "x-forwarded-for", p.getClientIP(r))
+}
+
+func (p *proxy) writeTooManyAttempts(retryAfter uint64, w http.ResponseWriter) {
+	w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfter))
+	w.WriteHeader(http.StatusTooManyRequests)
+}
+
 func (p *proxy) publicLinkAuth(h http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -6508,7 +6369,7 @@ func (p *proxy) publicLinkAuth(h http.HandlerFunc) http.HandlerFunc {
 
 func (p *proxy) tokenAuth(h http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
+		ctx := reva_api.ContextSetClientIP(r.Context(), p.getClientIP(r))
 		normalizedPath := mux.Vars(r)["path"]
 		normalizedPath = path.Join("/", path.Clean(normalizedPath))
 		mux.Vars(r)["path"] = normalizedPath
@@ -6529,13 +6390,19 @@ func (p *proxy) tokenAuth(h http.HandlerFunc) http.HandlerFunc {
 		if token == "" {
 			if username, password, ok := r.BasicAuth(); ok {
 				req := &reva_api.ForgeUserTokenReq{ClientId: username, ClientSecret: password}
-				res, err := authClient.ForgeUserToken(ctx, req)
+				res, err := authClient.ForgeUserToken(p.getContextWithClientIP(ctx, r), req)
 				if err != nil {
 					p.logger.Warn("error authentication user with basic auth", zap.String("username", username), zap.Error(err))
 					w.WriteHeader(http.StatusUnauthorized)
 					return
 				}
 
+				if res.Status == reva_api.StatusCode_TOO_MANY_ATTEMPTS {
+					p.logger.Warn("too many failed login attempts", zap.String("username", username), zap.Uint64("retry_after", res.RetryAfter))
+					p.writeTooManyAttempts(res.RetryAfter, w)
+					return
+				}
+
 				if res.Status != reva_api.StatusCode_OK {
 					p.logger.Warn("grpc auth req failed", zap.String("username", username), zap.Int("code", int(res.Status)))
 					w.WriteHeader(http.StatusUnauthorized)
@@ -6596,19 +6463,6 @@ func (p *proxy) getRevaPath(ctx context.Context, ocPath string) string {
 			// apply  public link
 			revaPath = strings.TrimPrefix(ocPath, p.ownCloudPublicLinkPrefix)
 			revaPath = path.Join(p.revaPublicLinkPrefix, pl.Token, revaPath)
-
-			// if public link is drop only we add random uuid to avoid
-			// clashes on file upload.
-			if pl.DropOnly {
-				// if path points to publc link we do not add
-				// the uuid, as the link will not be resolved.
-				if ocPath != "/" {
-					uuid := uuid.Must(uuid.NewV4()).String()
-					dir, fn := path.Split(revaPath)
-					fn = fmt.Sprintf("%s-%s", uuid, fn)
-					revaPath = path.Join(dir, fn)
-				}
-			}
 		}
 	} else {
 		if strings.HasPrefix(ocPath, p.ownCloudSharePrefix) {
@@ -6626,6 +6480,21 @@ func (p *proxy) getRevaPath(ctx context.Context, ocPath string) string {
 			}
 
 			revaPath = path.Join(p.revaSharePrefix, revaPath)
+		} else if strings.HasPrefix(ocPath, p.ownCloudOCMSharePrefix) {
+			revaPath = strings.TrimPrefix(ocPath, p.ownCloudOCMSharePrefix)
+			// remove file target before contacting reva
+			revaPath = strings.TrimPrefix(revaPath, "/")
+			tokens := strings.Split(revaPath, "/")
+			_, id, err := p.splitRootPath(ctx, tokens[0])
+			if err != nil {
+				p.logger.Error("error removing file target from ocPath", zap.Error(err), zap.String("ocPath", ocPath))
+			}
+			revaPath = path.Join("/", id)
+			if len(tokens) > 1 {
+				revaPath = path.Join(revaPath, path.Join(tokens[1:]...))
+			}
+
+			revaPath = path.Join(p.revaOCMSharePrefix, revaPath)
 		} else if strings.HasPrefix(ocPath, p.ownCloudPersonalProjectsPrefix) {
 			revaPath = strings.TrimPrefix(ocPath, p.ownCloudPersonalProjectsPrefix)
 			revaPath = path.Join(p.revaPersonalProjectsPrefix, revaPath)
@@ -6676,6 +6545,13 @@ func (p *proxy) getOCPath(ctx context.Context, md *reva_api.Metadata) string {
 			tokens[0] = p.addShareTarget(ctx, tokens[0], md)
 			ocPath = path.Join("/", path.Join(tokens...))
 			ocPath = path.Join(p.ownCloudSharePrefix, ocPath)
+		} else if strings.HasPrefix(revaPath, p.revaOCMSharePrefix) {
+			ocPath = strings.TrimPrefix(revaPath, p.revaOCMSharePrefix)
+			ocPath = strings.TrimPrefix(ocPath, "/")
+			tokens := strings.Split(ocPath, "/")
+			tokens[0] = p.addShareTarget(ctx, tokens[0], md)
+			ocPath = path.Join("/", path.Join(tokens...))
+			ocPath = path.Join(p.ownCloudOCMSharePrefix, ocPath)
 		} else {
 			if strings.HasPrefix(revaPath, p.revaHomePrefix) {
 				ocPath = strings.TrimPrefix(revaPath, p.revaHomePrefix)
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	reva_api "github.com/cernbox/reva/api"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+// maxDropNotifications is the number of uploads to a drop-only link mailed
+// to its owner in a dropNotificationWindow, the uploads after them are not
+// notified so anyone holding the link cannot flood the owner.
+const (
+	maxDropNotifications   = 10
+	dropNotificationWindow = time.Hour
+)
+
+// dropNotifications counts the notifications sent for each link
+// in the current window.
+type dropNotifications struct {
+	mu      sync.Mutex
+	windows map[string]*dropWindow
+}
+
+type dropWindow struct {
+	start time.Time
+	count int
+}
+
+func newDropNotifications() *dropNotifications {
+	return &dropNotifications{windows: map[string]*dropWindow{}}
+}
+
+// allow returns true if another upload to the link can be notified.
+func (n *dropNotifications) allow(token string, now time.Time) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	// forget the windows that are over
+	for t, w := range n.windows {
+		if now.Sub(w.start) >= dropNotificationWindow {
+			delete(n.windows, t)
+		}
+	}
+
+	w, ok := n.windows[token]
+	if !ok {
+		w = &dropWindow{start: now}
+		n.windows[token] = w
+	}
+	if w.count >= maxDropNotifications {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// finishDropUpload answers an upload to a drop-only link. The content of
+// these links cannot be inspected, and the file may have been renamed to
+// avoid overwriting another one, so no metadata is returned.
+// The name the file was written under is read from the header of the
+// FinishWriteTx response.
+// It returns false if the upload was not done through a drop-only link.
+func (p *proxy) finishDropUpload(ctx context.Context, revaPath string, header metadata.MD, w http.ResponseWriter) bool {
+	pl, ok := reva_api.ContextGetPublicLink(ctx)
+	if !ok || !pl.DropOnly {
+		return false
+	}
+	if pl.NotifyUploads {
+		filename := path.Base(revaPath)
+		if names := header.Get(reva_api.UploadedNameMetadata); len(names) > 0 && names[0] != "" {
+			filename = names[0]
+		}
+		if p.dropNotifications.allow(pl.Token, time.Now()) {
+			go p.notifyDropUpload(pl, filename)
+		} else {
+			p.logger.Warn("too many uploads to notify to drop-only link", zap.String("token", pl.Token))
+		}
+	}
+	w.WriteHeader(http.StatusCreated)
+	return true
+}
+
+// mailHeaderValue strips the line breaks from s so it cannot add headers
+// to the mail, and encodes it if it is not ASCII.
+func mailHeaderValue(s string) string {
+	s = strings.NewReplacer("\r", "", "\n", "").Replace(s)
+	return mime.QEncoding.Encode("utf-8", s)
+}
+
+// notifyDropUpload mails the owner of a drop-only link about a dropped file.
+func (p *proxy) notifyDropUpload(pl *reva_api.PublicLink, filename string) {
+	if p.mailServer == "" {
+		p.logger.Warn("no mail server configured to notify the upload", zap.String("token", pl.Token))
+		return
+	}
+
+	// the names are chosen by whoever holds the link
+	filename = strings.NewReplacer("\r", "", "\n", "").Replace(filename)
+	subject := mailHeaderValue(fmt.Sprintf("File '%s' uploaded to '%s'", filename, pl.Name))
+
+	mailBody := "To: %s@cern.ch\r\n" +
+		"Subject: %s\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"\r\n" +
+		"The file '%s' has been uploaded to your folder '%s' through its upload-only public link.\r\n" +
+		"\r\n" +
+		"Best regards,\r\n" +
+		"CERNBox Team"
+	mailBody = fmt.Sprintf(mailBody, pl.OwnerId, subject, filename, pl.Name)
+
+	to := []string{pl.OwnerId + "@cern.ch"}
+	if err := smtp.SendMail(p.mailServer, nil, p.mailServerFromAddress, to, []byte(mailBody)); err != nil {
+		err = errors.Wrap(err, "error sending mail")
+		p.logger.Error("", zap.Error(err), zap.String("token", pl.Token))
+		return
+	}
+	p.logger.Info("notified upload to drop-only link", zap.String("token", pl.Token), zap.String("owner", pl.OwnerId))
+}
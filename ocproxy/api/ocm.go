@@ -0,0 +1,413 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	reva_api "github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/ocm"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// OCSRemoteShare is a share received from another provider, in the format
+// of the files_sharing remote_shares API of ownCloud.
+type OCSRemoteShare struct {
+	ID          string     `json:"id"`
+	Remote      string     `json:"remote"`
+	RemoteID    string     `json:"remote_id"`
+	Name        string     `json:"name"`
+	Owner       string     `json:"owner"`
+	User        string     `json:"user"`
+	MountPoint  string     `json:"mountpoint"`
+	Accepted    int        `json:"accepted"`
+	Permissions Permission `json:"permissions"`
+	MimeType    string     `json:"mimetype"`
+	Type        ItemType   `json:"type"`
+	MTime       int        `json:"mtime"`
+}
+
+// getOCMBaseURL returns the URL the other providers reach this one at,
+// the overwrite host if configured or the host of the request.
+func (p *proxy) getOCMBaseURL(r *http.Request) string {
+	if p.overwriteHost != "" {
+		return fmt.Sprintf("https://%s", p.overwriteHost)
+	}
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, r.Host)
+}
+
+// getOCMProvider serves the discovery document of this provider.
+func (p *proxy) getOCMProvider(w http.ResponseWriter, r *http.Request) {
+	base := p.getOCMBaseURL(r)
+	provider := &ocm.Provider{
+		Enabled:    true,
+		APIVersion: ocm.APIVersion,
+		EndPoint:   base + "/ocm",
+		Provider:   "reva",
+		ResourceTypes: []*ocm.ResourceType{
+			{
+				Name:       "file",
+				ShareTypes: []string{"user"},
+				Protocols:  map[string]string{ocm.ProtocolWebDAV: "/ocm/webdav/"},
+			},
+		},
+	}
+	p.writeOCMJSON(http.StatusOK, provider, w)
+}
+
+// addOCMShare receives a share created by a user of another provider
+// with one of the users of this one.
+func (p *proxy) addOCMShare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	share := &ocm.Share{}
+	if err := json.NewDecoder(r.Body).Decode(share); err != nil {
+		p.logger.Error("", zap.Error(err))
+		p.writeOCMError(http.StatusBadRequest, "invalid share", w)
+		return
+	}
+	if share.Protocol == nil || share.Protocol.Name != ocm.ProtocolWebDAV || share.Protocol.Options == nil {
+		p.writeOCMError(http.StatusBadRequest, "only the webdav protocol is supported", w)
+		return
+	}
+
+	// the recipient may be sent with the address of this provider
+	shareWith := share.ShareWith
+	if user, _, err := ocm.ParseAddress(shareWith); err == nil {
+		shareWith = user
+	}
+
+	req := &reva_api.IncomingOCMShareReq{
+		ShareWith:    shareWith,
+		Name:         share.Name,
+		ProviderId:   share.ProviderID,
+		Owner:        share.Owner,
+		SharedSecret: share.Protocol.Options.SharedSecret,
+		Permissions:  uint32(ocm.GetSharePermissions(share.Protocol.Options.Permissions)),
+	}
+	res, err := p.getOCMClient().AddReceivedOCMShare(ctx, req)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		p.writeOCMError(http.StatusInternalServerError, "error adding share", w)
+		return
+	}
+	if res.Status != reva_api.StatusCode_OK {
+		p.writeOCMStatus(res.Status, w)
+		return
+	}
+	p.writeOCMJSON(http.StatusCreated, struct{}{}, w)
+}
+
+// notifyOCMShare receives the changes of the shares made on another provider.
+func (p *proxy) notifyOCMShare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	n := &ocm.Notification{}
+	if err := json.NewDecoder(r.Body).Decode(n); err != nil || n.Notification == nil {
+		p.writeOCMError(http.StatusBadRequest, "invalid notification", w)
+		return
+	}
+
+	req := &reva_api.OCMNotificationReq{Type: n.NotificationType, ProviderId: n.ProviderID, SharedSecret: n.Notification.SharedSecret}
+	res, err := p.getOCMClient().NotifyOCMShare(ctx, req)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		p.writeOCMError(http.StatusInternalServerError, "error applying notification", w)
+		return
+	}
+	if res.Status != reva_api.StatusCode_OK {
+		p.writeOCMStatus(res.Status, w)
+		return
+	}
+	p.writeOCMJSON(http.StatusCreated, struct{}{}, w)
+}
+
+func (p *proxy) writeOCMStatus(status reva_api.StatusCode, w http.ResponseWriter) {
+	p.logger.Warn("ocm request failed", zap.Int("status", int(status)))
+	switch status {
+	case reva_api.StatusCode_PATH_INVALID:
+		p.writeOCMError(http.StatusBadRequest, "invalid request", w)
+	case reva_api.StatusCode_STORAGE_PERMISSIONDENIED:
+		p.writeOCMError(http.StatusForbidden, "provider not trusted", w)
+	case reva_api.StatusCode_OCM_SHARE_NOT_FOUND:
+		p.writeOCMError(http.StatusNotFound, "share not found", w)
+	case reva_api.StatusCode_STORAGE_NOT_SUPPORTED:
+		p.writeOCMError(http.StatusNotImplemented, "not supported", w)
+	default:
+		p.writeOCMError(http.StatusInternalServerError, "internal error", w)
+	}
+}
+
+func (p *proxy) writeOCMError(code int, msg string, w http.ResponseWriter) {
+	p.writeOCMJSON(code, map[string]string{"message": msg}, w)
+}
+
+func (p *proxy) writeOCMJSON(code int, v interface{}, w http.ResponseWriter) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(encoded)
+}
+
+// ocmAuth authenticates the other providers accessing the files of a share
+// with its secret, that is the token of the public link created for it.
+func (p *proxy) ocmAuth(h http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		secret, _, ok := r.BasicAuth()
+		if !ok || secret == "" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="ocm"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		client := p.getAuthClient()
+		res, err := client.ForgePublicLinkToken(p.getContextWithClientIP(ctx, r), &reva_api.ForgePublicLinkTokenReq{Token: secret, Password: ""})
+		if err != nil {
+			p.logger.Error("", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if res.Status == reva_api.StatusCode_TOO_MANY_ATTEMPTS {
+			p.writeTooManyAttempts(res.RetryAfter, w)
+			return
+		}
+		if res.Status != reva_api.StatusCode_OK {
+			p.logger.Warn("invalid ocm share secret", zap.Int("code", int(res.Status)))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		r.Header.Set("X-Access-Token", res.Token)
+		ctx = context.WithValue(ctx, "ocm-dav-uri", true)
+		h(w, r.WithContext(ctx))
+	})
+}
+
+// createOCMShare shares the file or folder at newShare.Path with a user of another provider.
+func (p *proxy) createOCMShare(ctx context.Context, newShare *NewShareOCSRequest, permissions reva_api.SharePermissions, w http.ResponseWriter, r *http.Request) {
+	gCtx := GetContextWithAuth(ctx)
+	req := &reva_api.NewOCMShareReq{Path: newShare.Path, Recipient: newShare.ShareWith, Permissions: uint32(permissions)}
+	res, err := p.getOCMClient().CreateOCMShare(gCtx, req)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if res.Status != reva_api.StatusCode_OK {
+		p.writeError(res.Status, w, r)
+		return
+	}
+
+	ocsShare := p.ocmShareToOCSShare(ctx, res.Share)
+	meta := &ResponseMeta{Status: "ok", StatusCode: 200}
+	payload := &OCSPayload{Meta: meta, Data: ocsShare}
+	ocsRes := &OCSResponse{OCS: payload}
+	encoded, err := json.Marshal(ocsRes)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(encoded)
+}
+
+func (p *proxy) ocmShareToOCSShare(ctx context.Context, share *reva_api.OCMShare) *OCSShare {
+	ocPath := p.getPlainOCPath(ctx, share.Path)
+	user, _ := reva_api.ContextGetUser(ctx)
+	var owner string
+	if user != nil {
+		owner = user.AccountId
+	}
+	recipient := share.Recipient
+	return &OCSShare{
+		ID:                   share.Id,
+		ShareType:            ShareTypeFederated,
+		UIDOwner:             owner,
+		DisplayNameOwner:     owner,
+		Permissions:          Permission(share.Permissions),
+		ShareTime:            int(share.Ctime),
+		UIDFileOwner:         owner,
+		DisplayNameFileOwner: owner,
+		Path:                 ocPath,
+		MimeType:             reva_api.DetectMimeType(false, share.Name),
+		FileTarget:           path.Join("/", share.Name),
+		ShareWith:            &recipient,
+		ShareWithDisplayName: recipient,
+		Name:                 share.Name,
+		State:                getOCMShareState(share),
+	}
+}
+
+// getOCMShareState returns the OCS state of an ocm share.
+func getOCMShareState(share *reva_api.OCMShare) ShareState {
+	switch share.State {
+	case reva_api.OCMShare_PENDING:
+		return ShareStatePending
+	case reva_api.OCMShare_REJECTED:
+		return ShareStateRejected
+	}
+	return ShareStateAccepted
+}
+
+func (p *proxy) receivedOCMShareToOCSRemoteShare(ctx context.Context, share *reva_api.OCMShare) *OCSRemoteShare {
+	owner, remote, err := ocm.ParseAddress(share.OwnerId)
+	if err != nil {
+		owner = share.OwnerId
+	}
+	var accepted int
+	if share.State == reva_api.OCMShare_ACCEPTED {
+		accepted = 1
+	}
+	return &OCSRemoteShare{
+		ID:          share.Id,
+		Remote:      remote,
+		RemoteID:    share.ProviderId,
+		Name:        path.Join("/", share.Name),
+		Owner:       owner,
+		User:        share.Recipient,
+		MountPoint:  path.Join(p.ownCloudOCMSharePrefix, fmt.Sprintf("%s (id:%s)", share.Name, share.Id)),
+		Accepted:    accepted,
+		Permissions: Permission(share.Permissions),
+		MimeType:    "httpd/unix-directory",
+		Type:        ItemTypeFolder,
+		MTime:       int(share.Ctime),
+	}
+}
+
+// listReceivedOCMShares returns the shares received from other providers in the given state.
+func (p *proxy) listReceivedOCMShares(ctx context.Context, state reva_api.OCMShare_State) ([]*OCSRemoteShare, error) {
+	gCtx := GetContextWithAuth(ctx)
+	stream, err := p.getOCMClient().ListReceivedOCMShares(gCtx, &reva_api.EmptyReq{})
+	if err != nil {
+		return nil, err
+	}
+
+	shares := []*OCSRemoteShare{}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if res.Status != reva_api.StatusCode_OK {
+			return nil, errors.New(fmt.Sprintf("error listing received ocm shares: status=%d", res.Status))
+		}
+		if res.Share.State == state {
+			shares = append(shares, p.receivedOCMShareToOCSRemoteShare(ctx, res.Share))
+		}
+	}
+	return shares, nil
+}
+
+func (p *proxy) writeRemoteShares(data interface{}, w http.ResponseWriter) {
+	meta := &ResponseMeta{Status: "ok", StatusCode: 100}
+	payload := &OCSPayload{Meta: meta, Data: data}
+	ocsRes := &OCSResponse{OCS: payload}
+	encoded, err := json.Marshal(ocsRes)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(encoded)
+}
+
+// getRemoteShares lists the accepted shares received from other providers.
+func (p *proxy) getRemoteShares(w http.ResponseWriter, r *http.Request) {
+	shares, err := p.listReceivedOCMShares(r.Context(), reva_api.OCMShare_ACCEPTED)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	p.writeRemoteShares(shares, w)
+}
+
+// getPendingRemoteShares lists the shares received from other providers
+// that are not accepted or rejected yet.
+func (p *proxy) getPendingRemoteShares(w http.ResponseWriter, r *http.Request) {
+	shares, err := p.listReceivedOCMShares(r.Context(), reva_api.OCMShare_PENDING)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	p.writeRemoteShares(shares, w)
+}
+
+func (p *proxy) getRemoteShare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	shareID := mux.Vars(r)["share_id"]
+	shares, err := p.listReceivedOCMShares(ctx, reva_api.OCMShare_ACCEPTED)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	for _, share := range shares {
+		if share.ID == shareID {
+			p.writeRemoteShares(share, w)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+// acceptRemoteShare accepts a share received from another provider, mounting it.
+func (p *proxy) acceptRemoteShare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	shareID := mux.Vars(r)["share_id"]
+	gCtx := GetContextWithAuth(ctx)
+
+	res, err := p.getOCMClient().AcceptReceivedOCMShare(gCtx, &reva_api.OCMShareIDReq{Id: shareID})
+	if err != nil {
+		err = errors.Wrapf(err, "error accepting received ocm share: id=%s", shareID)
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if res.Status != reva_api.StatusCode_OK {
+		p.writeError(res.Status, w, r)
+		return
+	}
+	p.writeRemoteShares(p.receivedOCMShareToOCSRemoteShare(ctx, res.Share), w)
+}
+
+// declineRemoteShare rejects a share received from another provider, unmounting it.
+func (p *proxy) declineRemoteShare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	shareID := mux.Vars(r)["share_id"]
+	gCtx := GetContextWithAuth(ctx)
+
+	res, err := p.getOCMClient().RejectReceivedOCMShare(gCtx, &reva_api.OCMShareIDReq{Id: shareID})
+	if err != nil {
+		err = errors.Wrapf(err, "error rejecting received ocm share: id=%s", shareID)
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if res.Status != reva_api.StatusCode_OK {
+		p.writeError(res.Status, w, r)
+		return
+	}
+	p.writeRemoteShares(nil, w)
+}
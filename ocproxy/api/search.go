@@ -0,0 +1,285 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	reva_api "github.com/cernbox/reva/api"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// xmlNode is a generic XML element, used to walk the search
+// conditions that can be nested in any order.
+type xmlNode struct {
+	XMLName  xml.Name
+	Content  string    `xml:",chardata"`
+	Children []xmlNode `xml:",any"`
+}
+
+func (n *xmlNode) child(local string) *xmlNode {
+	for i := range n.Children {
+		if n.Children[i].XMLName.Local == local {
+			return &n.Children[i]
+		}
+	}
+	return nil
+}
+
+// find returns the first element named local in the tree of n, including n.
+func (n *xmlNode) find(local string) *xmlNode {
+	if n.XMLName.Local == local {
+		return n
+	}
+	for i := range n.Children {
+		if found := n.Children[i].find(local); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// report serves the favorites listed by the ownCloud clients with a
+// filter-files REPORT, and the searches done with a search-files REPORT.
+func (p *proxy) report(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	root := &xmlNode{}
+	if len(body) == 0 || xml.Unmarshal(body, root) != nil || root.XMLName.Local != "search-files" {
+		p.getFav(w, r)
+		return
+	}
+
+	req := &reva_api.SearchReq{Path: p.getRevaPath(r.Context(), mux.Vars(r)["path"])}
+	if pattern := root.find("pattern"); pattern != nil {
+		req.Query = strings.TrimSpace(pattern.Content)
+	}
+	if limit := root.find("limit"); limit != nil {
+		req.Limit, _ = strconv.ParseUint(strings.TrimSpace(limit.Content), 10, 64)
+	}
+	p.writeSearchResults(w, r, req)
+}
+
+// davSearch serves the WebDAV SEARCH method (RFC 5323) with the conditions
+// supported by the search service combined with d:and.
+func (p *proxy) davSearch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	root := &xmlNode{}
+	if err := xml.NewDecoder(r.Body).Decode(root); err != nil && err != io.EOF {
+		p.logger.Warn("invalid search request", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	basicSearch := root.find("basicsearch")
+	if basicSearch == nil {
+		p.logger.Warn("search request without basicsearch")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	req := &reva_api.SearchReq{Path: p.getRevaPath(ctx, mux.Vars(r)["path"])}
+	if href := basicSearch.find("href"); href != nil {
+		if ocPath, ok := p.getSearchScope(strings.TrimSpace(href.Content)); ok {
+			req.Path = p.getRevaPath(ctx, ocPath)
+		}
+	}
+	if nresults := basicSearch.find("nresults"); nresults != nil {
+		req.Limit, _ = strconv.ParseUint(strings.TrimSpace(nresults.Content), 10, 64)
+	}
+	if where := basicSearch.child("where"); where != nil {
+		for _, cond := range where.Children {
+			if err := addSearchCondition(req, &cond); err != nil {
+				p.logger.Warn("unsupported search condition", zap.Error(err))
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+	}
+	p.writeSearchResults(w, r, req)
+}
+
+// getSearchScope returns the ownCloud path of the href of the scope,
+// that can be relative to the user files or to the webdav endpoint.
+func (p *proxy) getSearchScope(href string) (string, bool) {
+	href = path.Clean("/" + href)
+	for _, prefix := range []string{"/remote.php/dav/files/", "/files/"} {
+		if strings.HasPrefix(href, prefix) {
+			// skip the username
+			tail := strings.SplitN(strings.TrimPrefix(href, prefix), "/", 2)
+			if len(tail) == 1 {
+				return "/", true
+			}
+			return "/" + tail[1], true
+		}
+	}
+	if strings.HasPrefix(href, "/remote.php/webdav") {
+		return path.Clean("/" + strings.TrimPrefix(href, "/remote.php/webdav")), true
+	}
+	return "", false
+}
+
+func addSearchCondition(req *reva_api.SearchReq, cond *xmlNode) error {
+	op := cond.XMLName.Local
+	if op == "and" {
+		for _, c := range cond.Children {
+			if err := addSearchCondition(req, &c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if op == "contains" {
+		req.Query = strings.TrimSpace(req.Query + " " + cond.Content)
+		return nil
+	}
+
+	prop := cond.child("prop")
+	literal := cond.child("literal")
+	if prop == nil || len(prop.Children) == 0 || literal == nil {
+		return fmt.Errorf("search condition %s needs a prop and a literal", op)
+	}
+	name := prop.Children[0].XMLName.Local
+	value := strings.TrimSpace(literal.Content)
+
+	switch {
+	case name == "displayname" && (op == "like" || op == "eq"):
+		req.Name = likeToPattern(value)
+	case name == "getcontenttype" && (op == "like" || op == "eq"):
+		req.Mime = strings.TrimSuffix(value, "%")
+	case name == "getcontentlength":
+		size, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		return setRange(op, size, &req.MinSize, &req.MaxSize)
+	case name == "getlastmodified":
+		mtime, err := parseSearchTime(value)
+		if err != nil {
+			return err
+		}
+		return setRange(op, mtime, &req.MinMtime, &req.MaxMtime)
+	case name == "favorite" && op == "eq":
+		if value == "1" {
+			req.Tags = append(req.Tags, "fav")
+		}
+	case name == "tags" && op == "eq":
+		req.Tags = append(req.Tags, value)
+	default:
+		return fmt.Errorf("search condition %s on %s is not supported", op, name)
+	}
+	return nil
+}
+
+// likeToPattern converts the wildcards of a like literal to a shell pattern.
+func likeToPattern(like string) string {
+	return strings.NewReplacer("%", "*", "_", "?").Replace(like)
+}
+
+func setRange(op string, v uint64, min, max *uint64) error {
+	switch op {
+	case "gt":
+		*min = v + 1
+	case "gte":
+		*min = v
+	case "lt":
+		if v == 0 {
+			return fmt.Errorf("lt 0 matches nothing")
+		}
+		*max = v - 1
+	case "lte":
+		*max = v
+	case "eq":
+		*min, *max = v, v
+	default:
+		return fmt.Errorf("search condition %s is not supported on ranges", op)
+	}
+	return nil
+}
+
+// parseSearchTime accepts the dates of the DAV properties and unix timestamps.
+func parseSearchTime(s string) (uint64, error) {
+	if t, err := time.Parse(time.RFC1123, s); err == nil {
+		return uint64(t.Unix()), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return uint64(t.Unix()), nil
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+func (p *proxy) writeSearchResults(w http.ResponseWriter, r *http.Request, req *reva_api.SearchReq) {
+	ctx := r.Context()
+	// request comes from remote.php/dav/files/gonzalhu/...
+	if mux.Vars(r)["username"] != "" {
+		ctx = context.WithValue(ctx, "user-dav-uri", true)
+	}
+
+	gCtx := GetContextWithAuth(ctx)
+	stream, err := p.getSearchClient().Search(gCtx, req)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	responses := []*responseXML{}
+	for {
+		mdRes, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			p.logger.Error("", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if mdRes.Status != reva_api.StatusCode_OK {
+			p.writeError(mdRes.Status, w, r)
+			return
+		}
+
+		md := mdRes.Metadata
+		md.Path = p.getOCPath(ctx, md)
+		md.Id = p.getOCId(ctx, md.Id)
+		res, err := p.mdToPropResponse(ctx, md)
+		if err != nil {
+			p.logger.Error("error converting search result to xml", zap.Error(err))
+			continue
+		}
+		responses = append(responses, res)
+	}
+	// RFC 5323 reports the truncated results with a 507 response on the scope
+	if len(stream.Trailer().Get(reva_api.SearchTruncatedMetadata)) > 0 {
+		responses = append(responses, &responseXML{Href: (&url.URL{Path: r.URL.Path}).String(), Status: "HTTP/1.1 507 Insufficient Storage", ResponseDescription: "Only part of the matching files were returned"})
+	}
+
+	responsesXML, err := xml.Marshal(&responses)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	msg := `<?xml version="1.0" encoding="utf-8"?><d:multistatus xmlns:d="DAV:" `
+	msg += `xmlns:s="http://sabredav.org/ns" xmlns:oc="http://owncloud.org/ns">`
+	msg += string(responsesXML) + `</d:multistatus>`
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	w.Write([]byte(msg))
+}
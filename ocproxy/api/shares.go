@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	reva_api "github.com/cernbox/reva/api"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// getShareItemType returns the OCS item type and mime type of a user or group share.
+func getShareItemType(share *reva_api.FolderShare, name string) (ItemType, string) {
+	if share.ItemType == reva_api.FolderShare_FILE {
+		return ItemTypeFile, reva_api.DetectMimeType(false, name)
+	}
+	return ItemTypeFolder, reva_api.DetectMimeType(true, name)
+}
+
+// getSharePermissions returns the OCS permissions of a user or group share.
+func getSharePermissions(share *reva_api.FolderShare) Permission {
+	return Permission(reva_api.GetSharePermissions(share))
+}
+
+// getRequestedSharePermissions returns the permissions requested for a user
+// or group share, read-only when the client does not send them.
+func getRequestedSharePermissions(permissions JSONInt) reva_api.SharePermissions {
+	if !permissions.Set {
+		return reva_api.SharePermissionsReadOnly
+	}
+	return reva_api.SharePermissions(permissions.Value)
+}
+
+// formatShareExpiration returns the OCS expiration of a user or group share,
+// in the format of the OCS API.
+func formatShareExpiration(share *reva_api.FolderShare) string {
+	if share.Expiration == 0 {
+		return ""
+	}
+	return time.Unix(int64(share.Expiration), 0).Format("2006-01-02 15:04:05")
+}
+
+// getShareState returns the OCS state of a received share.
+func getShareState(share *reva_api.FolderShare) ShareState {
+	switch share.State {
+	case reva_api.FolderShare_PENDING:
+		return ShareStatePending
+	case reva_api.FolderShare_REJECTED:
+		return ShareStateRejected
+	}
+	return ShareStateAccepted
+}
+
+// matchShareState returns true if the received share is in the state requested
+// by the client, all for "all" and only the accepted ones by default, like ownCloud.
+func matchShareState(state string, share *OCSShare) bool {
+	switch state {
+	case "all":
+		return true
+	case "":
+		return share.State == ShareStateAccepted
+	}
+	return state == strconv.Itoa(int(share.State))
+}
+
+// acceptShare mounts a pending or rejected share under its current target.
+func (p *proxy) acceptShare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	shareID := mux.Vars(r)["share_id"]
+	gCtx := GetContextWithAuth(ctx)
+
+	req := &reva_api.ReceivedShareReq{ShareId: shareID}
+	res, err := p.getShareClient().MountReceivedShare(gCtx, req)
+	if err != nil {
+		err = errors.Wrapf(err, "error mounting received share: id=%s", shareID)
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if res.Status != reva_api.StatusCode_OK {
+		p.writeError(res.Status, w, r)
+		return
+	}
+
+	ocsShare, err := p.receivedFolderShareToOCSShare(ctx, res.Share)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	meta := &ResponseMeta{Status: "ok", StatusCode: 100}
+	payload := &OCSPayload{Meta: meta, Data: []*OCSShare{ocsShare}}
+	ocsRes := &OCSResponse{OCS: payload}
+	encoded, err := json.Marshal(ocsRes)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(encoded)
+}
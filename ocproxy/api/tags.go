@@ -0,0 +1,233 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+
+	reva_api "github.com/cernbox/reva/api"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// favTag is the key of the tag used for the favorites.
+const favTag = "fav"
+
+// isTagsPropRequested returns true if the PROPFIND body asks for the tags or
+// the favorite flag, that are only computed when asked for as they need a
+// call to the tagger.
+func (p *proxy) isTagsPropRequested(body []byte) bool {
+	root := &xmlNode{}
+	if len(body) == 0 || xml.Unmarshal(body, root) != nil {
+		return false
+	}
+	prop := root.find("prop")
+	if prop == nil {
+		return false
+	}
+	return prop.child("tags") != nil || prop.child("system-tags") != nil || prop.child("favorite") != nil
+}
+
+func (p *proxy) isTagsPropPatch(body []byte) bool {
+	root := &xmlNode{}
+	if len(body) == 0 || xml.Unmarshal(body, root) != nil || root.XMLName.Local != "propertyupdate" {
+		return false
+	}
+	for _, update := range root.Children {
+		if prop := update.child("prop"); prop != nil {
+			if prop.child("tags") != nil || prop.child("favorite") != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *proxy) getFileTags(ctx context.Context, path string) ([]*reva_api.Tag, error) {
+	gCtx := GetContextWithAuth(ctx)
+	stream, err := p.getTagClient().GetFileTags(gCtx, &reva_api.PathReq{Path: p.getRevaPath(ctx, path)})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []*reva_api.Tag{}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if res.Status != reva_api.StatusCode_OK {
+			return nil, reva_api.NewError(reva_api.UnknownError)
+		}
+		tags = append(tags, res.Tag)
+	}
+	return tags, nil
+}
+
+// getTagsByFileID returns the tags of the path and, for a folder, of its
+// entries, by the ID of the file they are set on, so that the tags of a
+// listing are fetched with a single call.
+func (p *proxy) getTagsByFileID(ctx context.Context, path string) (map[string][]*reva_api.Tag, error) {
+	gCtx := GetContextWithAuth(ctx)
+	stream, err := p.getTagClient().GetFolderTags(gCtx, &reva_api.PathReq{Path: p.getRevaPath(ctx, path)})
+	if err != nil {
+		return nil, err
+	}
+
+	byID := map[string][]*reva_api.Tag{}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if res.Status != reva_api.StatusCode_OK {
+			return nil, reva_api.NewError(reva_api.UnknownError)
+		}
+		id := res.Tag.FileIdPrefix + ":" + res.Tag.FileId
+		byID[id] = append(byID[id], res.Tag)
+	}
+	return byID, nil
+}
+
+// getTagProps returns the oc:tags, oc:system-tags and oc:favorite properties
+// of a file with the tags.
+func (p *proxy) getTagProps(tags []*reva_api.Tag) []propertyXML {
+	fav := "0"
+	var userTags, systemTags bytes.Buffer
+	for _, tag := range tags {
+		var key bytes.Buffer
+		xml.EscapeText(&key, []byte(tag.TagKey))
+		switch {
+		case tag.Project != "":
+			var project bytes.Buffer
+			xml.EscapeText(&project, []byte(tag.Project))
+			systemTags.WriteString(`<oc:system-tag oc:project="` + project.String() + `">` + key.String() + `</oc:system-tag>`)
+		case tag.TagKey == favTag:
+			fav = "1"
+		default:
+			userTags.WriteString("<oc:tag>" + key.String() + "</oc:tag>")
+		}
+	}
+
+	return []propertyXML{
+		{xml.Name{Space: "", Local: "oc:tags"}, "", userTags.Bytes()},
+		{xml.Name{Space: "", Local: "oc:system-tags"}, "", systemTags.Bytes()},
+		{xml.Name{Space: "", Local: "oc:favorite"}, "", []byte(fav)},
+	}
+}
+
+// patchTags applies the changes to the oc:tags and oc:favorite properties.
+// Setting oc:tags replaces the tags of the user on the file,
+// the system tags can not be changed with a PROPPATCH.
+func (p *proxy) patchTags(w http.ResponseWriter, r *http.Request, body []byte) {
+	ctx := r.Context()
+	path := mux.Vars(r)["path"]
+
+	root := &xmlNode{}
+	if err := xml.Unmarshal(body, root); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	patched := []propertyXML{}
+	for _, update := range root.Children {
+		prop := update.child("prop")
+		if prop == nil {
+			continue
+		}
+		remove := update.XMLName.Local == "remove"
+
+		if fav := prop.child("favorite"); fav != nil {
+			var err error
+			if !remove && strings.TrimSpace(fav.Content) == "1" {
+				err = p.setTag(ctx, favTag, path)
+			} else {
+				err = p.unSetTag(ctx, favTag, path)
+			}
+			if err != nil {
+				p.logger.Error("error updating favorite", zap.String("path", path), zap.Error(err))
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			patched = append(patched, propertyXML{xml.Name{Space: "", Local: "oc:favorite"}, "", nil})
+		}
+
+		if tags := prop.child("tags"); tags != nil {
+			keys := []string{}
+			if !remove {
+				for _, t := range tags.Children {
+					if key := strings.TrimSpace(t.Content); t.XMLName.Local == "tag" && key != "" {
+						keys = append(keys, key)
+					}
+				}
+			}
+			if err := p.replaceTags(ctx, path, keys); err != nil {
+				p.logger.Error("error updating tags", zap.String("path", path), zap.Error(err))
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			patched = append(patched, propertyXML{xml.Name{Space: "", Local: "oc:tags"}, "", nil})
+		}
+	}
+
+	res := &responseXML{
+		Href:     r.URL.Path,
+		Propstat: []propstatXML{{Prop: patched, Status: "HTTP/1.1 200 OK"}},
+	}
+	resXML, err := xml.Marshal(res)
+	if err != nil {
+		p.logger.Error("", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	msg := `<?xml version="1.0" encoding="utf-8"?><d:multistatus xmlns:d="DAV:" `
+	msg += `xmlns:s="http://sabredav.org/ns" xmlns:oc="http://owncloud.org/ns">`
+	msg += string(resXML) + `</d:multistatus>`
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	w.Write([]byte(msg))
+}
+
+// replaceTags sets the tags of the user on path to keys.
+func (p *proxy) replaceTags(ctx context.Context, path string, keys []string) error {
+	tags, err := p.getFileTags(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	wanted := map[string]bool{}
+	for _, key := range keys {
+		wanted[key] = true
+	}
+
+	for _, tag := range tags {
+		if tag.Project != "" || tag.TagKey == favTag {
+			continue
+		}
+		if wanted[tag.TagKey] {
+			delete(wanted, tag.TagKey)
+			continue
+		}
+		if err := p.unSetTag(ctx, tag.TagKey, path); err != nil {
+			return err
+		}
+	}
+
+	for key := range wanted {
+		if err := p.setTag(ctx, key, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -386,6 +386,9 @@ var publicLinkTemplatePassword = `
         <form method="post">
           <fieldset>
             <div class="warning-info">This share is password-protected</div>
+            {{if .Message}}
+            <div class="warning">{{.Message}}</div>
+            {{end}}
             <p>
               <label for="password" class="infield">Password</label>
               <input type="hidden" name="requesttoken" value="GxEhFD47HA8bZlM1MyQfGChWczklBDM4KyQVO18ATHE=:hvNGFqhLL7cYAVIiqo+qr1KMyTWijp62F95Hkn7Nxs0=" />
@@ -770,195 +773,118 @@ with open(source_fn, 'r') as content_file:
 `
 
 var publicLinkDropOnly = `
-
-
-
-
-
-
-
-
-
-
-
-
-
 <!DOCTYPE html>
-<html class="ng-csp" data-placeholder-focus="false" lang="en" >
+<html lang="en">
 	<head>
 		<meta charset="utf-8">
-		<title> CERNBox</title>
+		<title>{{ .ShareName }} - CERNBox</title>
 		<meta http-equiv="X-UA-Compatible" content="IE=edge">
 		<meta name="referrer" content="never">
 		<meta name="viewport" content="width=device-width, minimum-scale=1.0, maximum-scale=1.0">
 		<meta name="theme-color" content="#1d2d44">
-
 		<link rel="icon" href="/core/img/favicon.ico">
-		<link rel="apple-touch-icon-precomposed" href="/core/img/favicon-touch.png">
-		<link rel="mask-icon" sizes="any" href="/core/img/favicon-mask.svg" color="#1d2d44">
-		<link rel="stylesheet" href="/core/css/styles.css">
-		<link rel="stylesheet" href="/core/css/inputs.css">
-		<link rel="stylesheet" href="/core/css/header.css">
-		<link rel="stylesheet" href="/core/css/icons.css">
-		<link rel="stylesheet" href="/core/css/fonts.css">
-		<link rel="stylesheet" href="/core/css/apps.css">
-		<link rel="stylesheet" href="/core/css/global.css">
-		<link rel="stylesheet" href="/core/css/fixes.css">
-		<link rel="stylesheet" href="/core/css/multiselect.css">
-		<link rel="stylesheet" href="/core/css/mobile.css">
-		<link rel="stylesheet" href="/core/vendor/select2/select2.css">
-		<link rel="stylesheet" href="/core/vendor/jquery-ui/themes/base/jquery-ui.css">
-		<link rel="stylesheet" href="/core/css/jquery-ui-fixes.css">
-		<link rel="stylesheet" href="/core/css/tooltip.css">
-		<link rel="stylesheet" href="/core/css/share.css">
-		<link rel="stylesheet" href="/apps/files_versions/css/versions.css">
-		<link rel="stylesheet" href="/core/css/jquery.ocdialog.css">
-		<link rel="stylesheet" href="/apps/files_sharing/css/public.css">
-		<link rel="stylesheet" href="/apps/files_sharing/css/upload.css">
-		<link rel="stylesheet" href="/apps/files_sharing/css/mobile.css">
-		<link rel="stylesheet" href="/apps/files/css/files.css">
-		<link rel="stylesheet" href="/apps/files/css/upload.css">
-    		<link rel="stylesheet" href="/apps/cernbox-theme/core/css/styles.css">
-		<script src="/index.php/core/js/oc.js"></script>
-		<script src="/core/vendor/jquery/dist/jquery.min.js"></script>
-		<script src="/core/vendor/jquery-migrate/jquery-migrate.min.js"></script>
-		<script src="/core/vendor/jquery-ui/ui/jquery-ui.custom.js"></script>
-		<script src="/core/vendor/underscore/underscore.js"></script>
-		<script src="/core/vendor/moment/min/moment-with-locales.js"></script>
-		<script src="/core/vendor/handlebars/handlebars.js"></script>
-		<script src="/core/vendor/blueimp-md5/js/md5.js"></script>
-		<script src="/core/vendor/bootstrap/js/tooltip.js"></script>
-		<script src="/core/vendor/backbone/backbone.js"></script>
-		<script src="/core/vendor/es6-promise/dist/es6-promise.js"></script>
-		<script src="/core/vendor/davclient.js/lib/client.js"></script>
-		<script src="/core/vendor/clipboard/dist/clipboard.js"></script>
-		<script src="/core/vendor/bowser/src/bowser.js"></script>
-		<script src="/core/js/jquery.ocdialog.js"></script>
-		<script src="/core/js/oc-dialogs.js"></script>
-		<script src="/core/js/js.js"></script>
-		<script src="/core/js/l10n.js"></script>
-		<script src="/core/js/octemplate.js"></script>
-		<script src="/core/js/eventsource.js"></script>
-		<script src="/core/js/config.js"></script>
-		<script src="/core/search/js/search.js"></script>
-		<script src="/core/js/oc-requesttoken.js"></script>
-		<script src="/core/js/apps.js"></script>
-		<script src="/core/js/mimetype.js"></script>
-		<script src="/core/js/mimetypelist.js"></script>
-		<script src="/core/vendor/snapjs/dist/latest/snap.js"></script>
-		<script src="/core/js/oc-backbone.js"></script>
-		<script src="/core/js/backgroundjobs.js"></script>
-		<script src="/core/js/shareconfigmodel.js"></script>
-		<script src="/core/js/sharemodel.js"></script>
-		<script src="/core/js/sharescollection.js"></script>
-		<script src="/core/js/shareitemmodel.js"></script>
-		<script src="/core/js/sharedialogresharerinfoview.js"></script>
-		<script src="/core/js/sharedialoglinklistview.js"></script>
-		<script src="/core/js/sharedialoglinkshareview.js"></script>
-		<script src="/core/js/sharedialogmailview.js"></script>
-		<script src="/core/js/sharedialoglinksocialview.js"></script>
-		<script src="/core/js/sharedialogexpirationview.js"></script>
-		<script src="/core/js/sharedialogshareelistview.js"></script>
-		<script src="/core/js/sharedialogview.js"></script>
-		<script src="/core/js/share.js"></script>
-		<script src="/core/js/files/fileinfo.js"></script>
-		<script src="/core/js/files/client.js"></script>
-		<script src="/apps/files/js/file-upload.js"></script>
-		<script src="/apps/files_sharing/js/public.js"></script>
-		<script src="/apps/files_sharing/js/PublicUploadView.js"></script>
-		<script src="/apps/files/js/fileactions.js"></script>
-		<script src="/apps/files/js/fileactionsmenu.js"></script>
-		<script src="/apps/files/js/jquery.fileupload.js"></script>
-		<script src="/apps/files/js/filesummary.js"></script>
-		<script src="/apps/files/js/breadcrumb.js"></script>
-		<script src="/apps/files/js/fileinfomodel.js"></script>
-		<script src="/apps/files/js/newfilemenu.js"></script>
-		<script src="/apps/files/js/files.js"></script>
-		<script src="/apps/files/js/filelist.js"></script>
-		<script src="/apps/files/js/keyboardshortcuts.js"></script>
-		<script>
-		(function ($, OC) {
-
-			$(document).ready(function () {
-				var data = $("data[key='cernboxauthtoken']");
-				var accessToken = data.attr('x-access-token');
-				if(accessToken) {
-					OC["X-Access-Token"] = accessToken;
-					/*
-					OC.Files.getClient()["_defaultHeaders"]["X-Access-Token"] = accessToken;
-
-					$.ajaxSetup({
-						    headers: { 'X-Access-Token': accessToken }
-					});
-
-					$(document).on('ajaxSend',function(elm, xhr, settings) {
-						xhr.setRequestHeader('X-Access-Token', accessToken);
-					});
-					*/
-
-					XMLHttpRequest.prototype.origOpen = XMLHttpRequest.prototype.open;
-					XMLHttpRequest.prototype.open   = function () {
-						this.origOpen.apply(this, arguments);
-						this.setRequestHeader('X-Access-Token', accessToken);
-					};
-
-				}
-			});
-
-		})(jQuery, OC);
-		</script>
-	<body id="body-public">
-	<data key="cernboxauthtoken" x-access-token="{{ .AccessToken }}" />
+		<style>
+		body { margin: 0; font-family: "Open Sans", Frutiger, Calibri, "Myriad Pro", Myriad, sans-serif; color: #333; background: #f5f5f5; }
+		header { background: #1d2d44; color: #fff; padding: 12px 20px; font-size: 18px; }
+		#content { max-width: 640px; margin: 40px auto; padding: 0 20px; }
+		#drop { border: 2px dashed #aaa; border-radius: 6px; background: #fff; padding: 50px 20px; text-align: center; cursor: pointer; }
+		#drop.hover { border-color: #1d2d44; background: #eef2f7; }
+		#drop p { margin: 8px 0; }
+		#files { display: none; }
+		#uploads { list-style: none; padding: 0; margin-top: 20px; }
+		#uploads li { background: #fff; margin: 4px 0; padding: 8px 12px; border-radius: 4px; display: flex; justify-content: space-between; }
+		#uploads .done { color: #2e7d32; }
+		#uploads .error { color: #c62828; }
+		footer { text-align: center; color: #777; font-size: 13px; }
+		</style>
+	</head>
+	<body>
+	<header>CERNBox &ndash; {{ .ShareName }}</header>
 	<noscript>
 	<div id="nojavascript">
 	<div> This application requires JavaScript for correct operation. Please <a href="http://enable-javascript.com/" target="_blank" rel="noreferrer">enable JavaScript</a> and reload the page.</div>
 	</div>
 	</noscript>
 
-	<div id="notification-container">
-		<div id="notification" style="display: none;"></div>
-	</div>
-
-	<input type="hidden" id="filesApp" name="filesApp" value="1">
-	<input type="hidden" id="isPublic" name="isPublic" value="1">
-	<input type="hidden" name="dir" value="/" id="dir">
-	<input type="hidden" name="downloadURL" value="https://{{ .OverwriteHost }}/index.php/s/{{ .Token }}/download?x-access-token={{ .AccessToken }}" id="downloadURL">
-	<input type="hidden" name="sharingToken" value="{{ .Token }}" id="sharingToken">
-	<input type="hidden" name="filename" value="/" id="filename">
-	<input type="hidden" name="mimetype" value="httpd/unix-directory" id="mimetype">
-	<input type="hidden" name="previewSupported" value="false" id="previewSupported">
-	<input type="hidden" name="mimetypeIcon" value="/core/img/filetypes/folder.svg" id="mimetypeIcon">
-	<input type="hidden" name="filesize" value="28" id="filesize">
-	<input type="hidden" name="maxSizeAnimateGif" value="10" id="maxSizeAnimateGif">
-
-<header>
-	<div id="header" class="share-folder" data-protected="false" data-owner-display-name="admin" data-owner="admin" data-name="Documents">
-		<a href="/" title="" id="owncloud">
-		<h1 class="logo-icon">ownCloud</h1></a>
-		<div id="logo-claim" style="display:none;">Enterprise Edition</div>
-	</div>
-</header>
-	<header>
-		<div id="header" class="share-folder" data-protected="false"
-			 data-owner-display-name="admin" data-owner="admin" data-name="Test folder">
-			<a href="/index.php" title="" id="owncloud">
-				<h1 class="logo-icon"></h1>
-			</a>
-
-			<div id="logo-claim" style="display:none;"></div>
-		</div>
-	</header>
-
-<div id="content-wrapper">
 	<div id="content">
-		<div id="preview">
-		<div id="notification"></div>
-		<div class="uploadForm"><div>
+		<div id="drop">
+			<p><strong>Drop files here</strong> or click to select them</p>
+			<p>The uploaded files are not visible to you nor to other uploaders.
+			A file with the name of an existing one is renamed, nothing is overwritten.</p>
+			<input type="file" id="files" multiple>
+		</div>
+		<ul id="uploads"></ul>
 	</div>
-</div>
+
 	<footer>
 		<p class="info">
 		<a href="https://cernbox.web.cern.ch" target="_blank" rel="noreferrer">CERNBox</a> &ndash; {{ .Note }}</p>
 	</footer>
+
+	<script>
+	(function () {
+		var accessToken = "{{ .AccessToken }}";
+		var drop = document.getElementById("drop");
+		var input = document.getElementById("files");
+		var uploads = document.getElementById("uploads");
+
+		function upload(file) {
+			var item = document.createElement("li");
+			var name = document.createElement("span");
+			var state = document.createElement("span");
+			name.textContent = file.name;
+			state.textContent = "uploading";
+			item.appendChild(name);
+			item.appendChild(state);
+			uploads.appendChild(item);
+
+			var xhr = new XMLHttpRequest();
+			xhr.open("PUT", "/public.php/webdav/" + encodeURIComponent(file.name));
+			xhr.setRequestHeader("X-Access-Token", accessToken);
+			xhr.upload.onprogress = function (e) {
+				if (e.lengthComputable) {
+					state.textContent = Math.round(e.loaded * 100 / e.total) + "%";
+				}
+			};
+			xhr.onload = function () {
+				if (xhr.status >= 200 && xhr.status < 300) {
+					state.textContent = "uploaded";
+					state.className = "done";
+				} else {
+					state.textContent = "failed (" + xhr.status + ")";
+					state.className = "error";
+				}
+			};
+			xhr.onerror = function () {
+				state.textContent = "failed";
+				state.className = "error";
+			};
+			xhr.send(file);
+		}
+
+		function uploadAll(files) {
+			for (var i = 0; i < files.length; i++) {
+				upload(files[i]);
+			}
+		}
+
+		drop.addEventListener("click", function () { input.click(); });
+		input.addEventListener("change", function () {
+			uploadAll(input.files);
+			input.value = "";
+		});
+		drop.addEventListener("dragover", function (e) {
+			e.preventDefault();
+			drop.className = "hover";
+		});
+		drop.addEventListener("dragleave", function () { drop.className = ""; });
+		drop.addEventListener("drop", function (e) {
+			e.preventDefault();
+			drop.className = "";
+			uploadAll(e.dataTransfer.files);
+		});
+	})();
+	</script>
+	</body>
 </html>
 `
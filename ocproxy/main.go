@@ -46,6 +46,8 @@ func init() {
 	gc.Add("apps-mail-server", "cernmx.cern.ch:25", "An IMAP mail server where to send mails")
 	gc.Add("apps-mail-server-from-address", "cernbox-noreply@cern.ch", "The sender of the mail (FROM header)")
 
+	gc.Add("trusted-proxies", "127.0.0.1,::1", "comma separated list of the IPs and networks of the proxies in front of ocproxy, whose X-Forwarded-For is trusted")
+
 	gc.Add("cache-size", 1000000, "cache size for md records")
 	gc.Add("cache-eviction", 86400, "cache eviction time in seconds for md records")
 
@@ -79,6 +81,7 @@ func main() {
 		CacheEviction:         gc.GetInt("cache-eviction"),
 		MailServer:            gc.GetString("apps-mail-server"),
 		MailServerFromAddress: gc.GetString("apps-mail-server-from-address"),
+		TrustedProxies:        strings.Split(gc.GetString("trusted-proxies"), ","),
 	}
 
 	_, err := api.New(opts)
@@ -0,0 +1,109 @@
+package admincmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/reva-cli/util"
+	"github.com/codegangsta/cli"
+	"github.com/ryanuber/columnize"
+)
+
+var FsckSharesCommand = cli.Command{
+	Name:      "fsck",
+	Usage:     "Find the shares and public links of all the users out of step with their files, only for admins",
+	ArgsUsage: "Usage: fsck [--repair] [--purge-suspended]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "repair",
+			Usage: "suspend the orphans and reactivate the suspended ones whose files are back",
+		},
+		cli.BoolFlag{
+			Name:  "purge-suspended",
+			Usage: "with --repair, also purge the suspended ones whose files are not found, even if still in the recycle bin, the orphans are purged by the next run",
+		},
+	},
+	Action: fsckShares,
+}
+
+func fsckShares(c *cli.Context) error {
+	client, err := util.GetSharingClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	req := &api.FsckSharesReq{Repair: c.Bool("repair"), PurgeSuspended: c.Bool("purge-suspended")}
+	stream, err := client.FsckShares(util.GetContextWithAuth(), req)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	lines := []string{"#Kind|ID|Owner|FileID|Problem|Repaired"}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if res.Status != api.StatusCode_OK {
+			return cli.NewExitError(res.Status, 1)
+		}
+		e := res.Entry
+		lines = append(lines, fmt.Sprintf("%s|%s|%s|%s|%s|%t", e.Kind, e.Id, e.OwnerId, e.FileId, e.Problem, e.Repaired))
+	}
+	fmt.Fprintln(c.App.Writer, columnize.SimpleFormat(lines))
+	return nil
+}
+
+var TransferOwnershipCommand = cli.Command{
+	Name:      "transfer",
+	Usage:     "Hand files of a user over to another with their shares and public links, only for admins. Run it again to resume a stopped transfer",
+	ArgsUsage: "Usage: transfer <from-user> <from-path> <to-user> <to-path>",
+	Action:    transferOwnership,
+}
+
+func transferOwnership(c *cli.Context) error {
+	if len(c.Args()) < 4 {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetSharingClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	req := &api.TransferOwnershipReq{
+		FromUser: c.Args().Get(0),
+		FromPath: c.Args().Get(1),
+		ToUser:   c.Args().Get(2),
+		ToPath:   c.Args().Get(3),
+	}
+	stream, err := client.TransferOwnership(util.GetContextWithAuth(), req)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	// the progress is printed as it comes, the transfers can be long
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if res.Status != api.StatusCode_OK {
+			return cli.NewExitError(res.Status, 1)
+		}
+		p := res.Progress
+		line := fmt.Sprintf("%s %s %s", p.Step, p.Id, p.Path)
+		if p.Skipped {
+			line += " (done before)"
+		}
+		fmt.Fprintln(c.App.Writer, line)
+	}
+	return nil
+}
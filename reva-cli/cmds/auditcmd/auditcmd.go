@@ -0,0 +1,113 @@
+package auditcmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/reva-cli/util"
+	"github.com/codegangsta/cli"
+	"github.com/ryanuber/columnize"
+)
+
+var ListAuditEventsCommand = cli.Command{
+	Name:      "list",
+	Usage:     "List audit events, only admins can see the events of other users",
+	ArgsUsage: "Usage: list [--account <account>] [--path <path>] [--file-id <id>] [--action <action>] [--from <date>] [--to <date>] [--limit <n>]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "account",
+			Usage: "only events done by this account",
+		},
+		cli.StringFlag{
+			Name:  "path",
+			Usage: "only events on this path and its children",
+		},
+		cli.StringFlag{
+			Name:  "file-id",
+			Usage: "only events on this file ID",
+		},
+		cli.StringFlag{
+			Name:  "action",
+			Usage: "only events of this action, like delete or download",
+		},
+		cli.StringFlag{
+			Name:  "from",
+			Usage: "only events after this date, in RFC3339 format",
+		},
+		cli.StringFlag{
+			Name:  "to",
+			Usage: "only events before this date, in RFC3339 format",
+		},
+		cli.IntFlag{
+			Name:  "limit",
+			Value: 100,
+			Usage: "maximum number of events to show, the most recent ones",
+		},
+	},
+	Action: listAuditEvents,
+}
+
+func listAuditEvents(c *cli.Context) error {
+	req := &api.ListAuditEventsReq{
+		AccountId: c.String("account"),
+		Path:      c.String("path"),
+		FileId:    c.String("file-id"),
+		Action:    c.String("action"),
+		Limit:     uint64(c.Int("limit")),
+	}
+	var err error
+	if req.From, err = parseDate(c.String("from")); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if req.To, err = parseDate(c.String("to")); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	client, err := util.GetAuditClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	stream, err := client.ListAuditEvents(ctx, req)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	lines := []string{"#Time|Account|Action|Path|FileID|Target|Result|ClientIP|TraceID"}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if res.Status != api.StatusCode_OK {
+			return cli.NewExitError(res.Status, 1)
+		}
+		e := res.AuditEvent
+		account := e.AccountId
+		if e.PublicLinkToken != "" {
+			account = fmt.Sprintf("%s (link %s)", account, e.PublicLinkToken)
+		}
+		t := time.Unix(int64(e.Time), 0).Format(time.RFC3339)
+		line := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%s|%s", t, account, e.Action, e.Path, e.FileId, e.Target, e.Result, e.ClientIp, e.TraceId)
+		lines = append(lines, line)
+	}
+	fmt.Fprintln(c.App.Writer, columnize.SimpleFormat(lines))
+	return nil
+}
+
+func parseDate(date string) (uint64, error) {
+	if date == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(t.Unix()), nil
+}
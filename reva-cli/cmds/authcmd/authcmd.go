@@ -3,10 +3,13 @@ package authcmd
 import (
 	"context"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/cernbox/reva/api"
 	"github.com/cernbox/reva/reva-cli/util"
 	"github.com/codegangsta/cli"
+	"github.com/ryanuber/columnize"
 )
 
 var ForgePublicLinkTokenCommand = cli.Command{
@@ -29,6 +32,132 @@ var VerifyTokenCommand = cli.Command{
 	Action:    verifyToken,
 }
 
+var AppPasswordCommand = cli.Command{
+	Name:  "app-password",
+	Usage: "Manage application passwords for sync and WebDAV clients",
+	Subcommands: []cli.Command{
+		CreateAppPasswordCommand,
+		ListAppPasswordsCommand,
+		RevokeAppPasswordCommand,
+	},
+}
+
+var CreateAppPasswordCommand = cli.Command{
+	Name:      "create",
+	Usage:     "Creates an application password",
+	ArgsUsage: "Usage: create <label> [--read-only] [--path <path>]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "read-only",
+			Usage: "restricts the app password to read-only access",
+		},
+		cli.StringFlag{
+			Name:  "path",
+			Usage: "restricts the app password to the given path",
+		},
+	},
+	Action: createAppPassword,
+}
+
+var ListAppPasswordsCommand = cli.Command{
+	Name:      "list",
+	Usage:     "List application passwords",
+	ArgsUsage: "Usage: list",
+	Action:    listAppPasswords,
+}
+
+var RevokeAppPasswordCommand = cli.Command{
+	Name:      "revoke",
+	Usage:     "Revokes an application password",
+	ArgsUsage: "Usage: revoke <id>",
+	Action:    revokeAppPassword,
+}
+
+func createAppPassword(c *cli.Context) error {
+	label := c.Args().First()
+	if label == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetAuthClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	req := &api.NewAppPasswordReq{Label: label, ReadOnly: c.Bool("read-only"), Path: c.String("path")}
+	ctx := util.GetContextWithAuth()
+	res, err := client.CreateAppPassword(ctx, req)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	ap := res.AppPassword
+	fmt.Fprintf(c.App.Writer, "ID: %s\nLabel: %s\nReadOnly: %t\nPath: %s\nPassword: %s\n", ap.Id, ap.Label, ap.ReadOnly, ap.Path, res.Password)
+	fmt.Fprintln(c.App.Writer, "Save the password now, it will not be shown again.")
+	return nil
+}
+
+func listAppPasswords(c *cli.Context) error {
+	ctx := util.GetContextWithAuth()
+	client, err := util.GetAuthClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	stream, err := client.ListAppPasswords(ctx, &api.EmptyReq{})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	lines := []string{"#ID|Label|ReadOnly|Path|Created|LastUsed"}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if res.Status != api.StatusCode_OK {
+			return cli.NewExitError(res.Status, 1)
+		}
+		ap := res.AppPassword
+		created := time.Unix(int64(ap.Ctime), 0).Format(time.RFC3339)
+		lastUsed := "never"
+		if ap.Atime != 0 {
+			lastUsed = time.Unix(int64(ap.Atime), 0).Format(time.RFC3339)
+		}
+		line := fmt.Sprintf("%s|%s|%t|%s|%s|%s", ap.Id, ap.Label, ap.ReadOnly, ap.Path, created, lastUsed)
+		lines = append(lines, line)
+	}
+	fmt.Fprintln(c.App.Writer, columnize.SimpleFormat(lines))
+	return nil
+}
+
+func revokeAppPassword(c *cli.Context) error {
+	id := c.Args().First()
+	if id == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetAuthClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	res, err := client.RevokeAppPassword(ctx, &api.AppPasswordIDReq{Id: id})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	return nil
+}
+
 func forgePublicLinkToken(c *cli.Context) error {
 	if len(c.Args()) < 1 {
 		return cli.NewExitError(c.Command.ArgsUsage, 1)
@@ -8,9 +8,16 @@ import (
 	"github.com/codegangsta/cli"
 
 	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/reva-cli/cmds/admincmd"
+	"github.com/cernbox/reva/reva-cli/cmds/auditcmd"
 	"github.com/cernbox/reva/reva-cli/cmds/authcmd"
+	"github.com/cernbox/reva/reva-cli/cmds/ocmcmd"
+	"github.com/cernbox/reva/reva-cli/cmds/previewcmd"
+	"github.com/cernbox/reva/reva-cli/cmds/projectcmd"
 	"github.com/cernbox/reva/reva-cli/cmds/sharecmd"
 	"github.com/cernbox/reva/reva-cli/cmds/storagecmd"
+	"github.com/cernbox/reva/reva-cli/cmds/tagcmd"
+	"github.com/cernbox/reva/reva-cli/cmds/webhookcmd"
 	"github.com/cernbox/reva/reva-cli/util"
 
 	"golang.org/x/net/context"
@@ -27,6 +34,7 @@ var StorageCommands = cli.Command{
 		storagecmd.UploadFileCommand,
 		storagecmd.ListFolderCommand,
 		storagecmd.DeleteCommand,
+		storagecmd.WatchCommand,
 
 		storagecmd.ListRecycleCommand,
 		storagecmd.RestoreRecycleEntryCommand,
@@ -59,11 +67,27 @@ var ShareCommands = cli.Command{
 	},
 }
 
+var OCMCommands = cli.Command{
+	Name:  "ocm",
+	Usage: "Open Cloud Mesh commands, to share with the users of other providers",
+	Subcommands: []cli.Command{
+		ocmcmd.CreateOCMShareCommand,
+		ocmcmd.ListOCMSharesCommand,
+		ocmcmd.RemoveOCMShareCommand,
+		ocmcmd.ListReceivedOCMSharesCommand,
+		ocmcmd.AcceptReceivedOCMShareCommand,
+		ocmcmd.RejectReceivedOCMShareCommand,
+	},
+}
+
 var PreviewCommands = cli.Command{
-	Name:        "preview",
-	Aliases:     []string{"pre", "prev"},
-	Usage:       "Preview commands",
-	Subcommands: []cli.Command{},
+	Name:    "preview",
+	Aliases: []string{"pre", "prev"},
+	Usage:   "Preview commands",
+	Subcommands: []cli.Command{
+		previewcmd.DownloadPreviewCommand,
+		previewcmd.PurgePreviewsCommand,
+	},
 }
 
 var AuthCommands = cli.Command{
@@ -72,6 +96,72 @@ var AuthCommands = cli.Command{
 	Subcommands: []cli.Command{
 		authcmd.ForgePublicLinkTokenCommand,
 		authcmd.VerifyTokenCommand,
+		authcmd.AppPasswordCommand,
+	},
+}
+
+var AuditCommands = cli.Command{
+	Name:  "audit",
+	Usage: "Audit commands",
+	Subcommands: []cli.Command{
+		auditcmd.ListAuditEventsCommand,
+	},
+}
+
+var WebhookCommands = cli.Command{
+	Name:  "webhook",
+	Usage: "Webhook commands",
+	Subcommands: []cli.Command{
+		webhookcmd.AddWebhookCommand,
+		webhookcmd.ListWebhooksCommand,
+		webhookcmd.RemoveWebhookCommand,
+		webhookcmd.ListDeadLettersCommand,
+		webhookcmd.RetryDeadLetterCommand,
+	},
+}
+
+var TagCommands = cli.Command{
+	Name:  "tag",
+	Usage: "Tag commands",
+	Subcommands: []cli.Command{
+		tagcmd.ListTagsCommand,
+		tagcmd.GetFileTagsCommand,
+		tagcmd.SetTagCommand,
+		tagcmd.UnSetTagCommand,
+		tagcmd.RenameTagCommand,
+	},
+}
+
+var ProjectCommands = cli.Command{
+	Name:  "project",
+	Usage: "Project commands, to provision the project spaces",
+	Subcommands: []cli.Command{
+		projectcmd.CreateProjectCommand,
+		projectcmd.UpdateProjectCommand,
+		projectcmd.DeleteProjectCommand,
+		projectcmd.InspectProjectCommand,
+		projectcmd.ListProjectsCommand,
+	},
+}
+
+var AdminCommands = cli.Command{
+	Name:  "admin",
+	Usage: "Admin commands, on the data of all the users",
+	Subcommands: []cli.Command{
+		{
+			Name:  "shares",
+			Usage: "Shares and public links of all the users",
+			Subcommands: []cli.Command{
+				admincmd.FsckSharesCommand,
+			},
+		},
+		{
+			Name:  "users",
+			Usage: "Files of the users, with their shares and public links",
+			Subcommands: []cli.Command{
+				admincmd.TransferOwnershipCommand,
+			},
+		},
 	},
 }
 
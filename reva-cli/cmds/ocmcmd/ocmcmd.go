@@ -0,0 +1,227 @@
+package ocmcmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/reva-cli/util"
+	"github.com/codegangsta/cli"
+	"github.com/ryanuber/columnize"
+)
+
+var CreateOCMShareCommand = cli.Command{
+	Name:      "share-create",
+	Usage:     "Shares a folder or a file with a user of another provider",
+	ArgsUsage: "Usage: share-create <path> <user@https://provider> [--read-write] [--permissions <bits>]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "read-write",
+			Usage: "Sets the share to read-write so people can add/delete files",
+		},
+		cli.IntFlag{
+			Name:  "permissions",
+			Usage: "ownCloud permission bits of the share (1 read, 2 update, 4 create, 8 delete, 16 share), overrides read-write",
+		},
+	},
+	Action: createOCMShare,
+}
+
+var ListOCMSharesCommand = cli.Command{
+	Name:      "share-list",
+	Usage:     "List the shares with users of other providers",
+	ArgsUsage: "Usage: share-list",
+	Action:    listOCMShares,
+}
+
+var RemoveOCMShareCommand = cli.Command{
+	Name:      "share-remove",
+	Usage:     "Removes a share with a user of another provider",
+	ArgsUsage: "Usage: share-remove <id>",
+	Action:    removeOCMShare,
+}
+
+var ListReceivedOCMSharesCommand = cli.Command{
+	Name:      "received-list",
+	Usage:     "List the shares received from users of other providers",
+	ArgsUsage: "Usage: received-list",
+	Action:    listReceivedOCMShares,
+}
+
+var AcceptReceivedOCMShareCommand = cli.Command{
+	Name:      "received-accept",
+	Usage:     "Accepts a received share, mounting it",
+	ArgsUsage: "Usage: received-accept <id>",
+	Action:    acceptReceivedOCMShare,
+}
+
+var RejectReceivedOCMShareCommand = cli.Command{
+	Name:      "received-reject",
+	Usage:     "Rejects a received share, unmounting it",
+	ArgsUsage: "Usage: received-reject <id>",
+	Action:    rejectReceivedOCMShare,
+}
+
+func createOCMShare(c *cli.Context) error {
+	if len(c.Args()) < 2 {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	permissions := api.SharePermissionsReadOnly
+	if c.Bool("read-write") {
+		permissions = api.SharePermissionsReadWrite
+	}
+	if p := c.Int("permissions"); p != 0 {
+		permissions = api.SharePermissions(p)
+	}
+
+	client, err := util.GetOCMClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	req := &api.NewOCMShareReq{Path: c.Args().Get(0), Recipient: c.Args().Get(1), Permissions: uint32(permissions)}
+	ctx := util.GetContextWithAuth()
+	res, err := client.CreateOCMShare(ctx, req)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	fmt.Fprintf(c.App.Writer, "OCM share %s created\n", res.Share.Id)
+	return nil
+}
+
+func listOCMShares(c *cli.Context) error {
+	client, err := util.GetOCMClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	stream, err := client.ListOCMShares(ctx, &api.EmptyReq{})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	lines := []string{"#ID|Path|Recipient|Permissions|State|Created"}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if res.Status != api.StatusCode_OK {
+			return cli.NewExitError(res.Status, 1)
+		}
+		s := res.Share
+		created := time.Unix(int64(s.Ctime), 0).Format(time.RFC3339)
+		line := fmt.Sprintf("%s|%s|%s|%d|%s|%s", s.Id, s.Path, s.Recipient, s.Permissions, s.State, created)
+		lines = append(lines, line)
+	}
+	fmt.Fprintln(c.App.Writer, columnize.SimpleFormat(lines))
+	return nil
+}
+
+func removeOCMShare(c *cli.Context) error {
+	id := c.Args().First()
+	if id == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetOCMClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	res, err := client.RemoveOCMShare(ctx, &api.OCMShareIDReq{Id: id})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	return nil
+}
+
+func listReceivedOCMShares(c *cli.Context) error {
+	client, err := util.GetOCMClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	stream, err := client.ListReceivedOCMShares(ctx, &api.EmptyReq{})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	lines := []string{"#ID|Name|Owner|Permissions|State|WebDAV|Created"}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if res.Status != api.StatusCode_OK {
+			return cli.NewExitError(res.Status, 1)
+		}
+		s := res.Share
+		created := time.Unix(int64(s.Ctime), 0).Format(time.RFC3339)
+		line := fmt.Sprintf("%s|%s|%s|%d|%s|%s|%s", s.Id, s.Name, s.OwnerId, s.Permissions, s.State, s.WebdavUrl, created)
+		lines = append(lines, line)
+	}
+	fmt.Fprintln(c.App.Writer, columnize.SimpleFormat(lines))
+	return nil
+}
+
+func acceptReceivedOCMShare(c *cli.Context) error {
+	id := c.Args().First()
+	if id == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetOCMClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	res, err := client.AcceptReceivedOCMShare(ctx, &api.OCMShareIDReq{Id: id})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	return nil
+}
+
+func rejectReceivedOCMShare(c *cli.Context) error {
+	id := c.Args().First()
+	if id == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetOCMClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	res, err := client.RejectReceivedOCMShare(ctx, &api.OCMShareIDReq{Id: id})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	return nil
+}
@@ -1,18 +1,123 @@
 package previewcmd
 
 import (
-	"fmt"
+	"io"
+	"os"
 
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/reva-cli/util"
 	"github.com/codegangsta/cli"
 )
 
 var DownloadPreviewCommand = cli.Command{
 	Name:      "download",
-	Usage:     "Download an image preview",
-	ArgsUsage: "Usage: download <path>",
-	Action:    download,
+	Usage:     "Download the preview of a file",
+	ArgsUsage: "Usage: download <path> <localpath> [--width 128] [--height 128] [--fit]",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "width",
+			Value: 128,
+			Usage: "width of the preview in pixels",
+		},
+		cli.IntFlag{
+			Name:  "height",
+			Value: 128,
+			Usage: "height of the preview in pixels",
+		},
+		cli.BoolFlag{
+			Name:  "fit",
+			Usage: "keep the aspect ratio instead of cropping the image",
+		},
+	},
+	Action: download,
 }
 
-func download(c *cli.Context) {
-	fmt.Println("not implemented")
+var PurgePreviewsCommand = cli.Command{
+	Name:      "purge",
+	Usage:     "Remove the cached previews of a file, or of all files",
+	ArgsUsage: "Usage: purge <path> | purge --all",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "remove the previews of all files, only for admins",
+		},
+	},
+	Action: purge,
+}
+
+func download(c *cli.Context) error {
+	if len(c.Args()) < 2 {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+	path := c.Args().First()
+	if path == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	localFile := c.Args().Get(1)
+	if localFile == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetPreviewClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	mode := api.PreviewReq_CROP
+	if c.Bool("fit") {
+		mode = api.PreviewReq_FIT
+	}
+	req := &api.PreviewReq{Path: path, Width: uint64(c.Int("width")), Height: uint64(c.Int("height")), Mode: mode}
+	stream, err := client.ReadPreview(util.GetContextWithAllAuths(path), req)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	fd, err := os.OpenFile(localFile, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0660)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer fd.Close()
+
+	for {
+		dcRes, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if dcRes.Status != api.StatusCode_OK {
+			return cli.NewExitError(dcRes.Status, 1)
+		}
+		dc := dcRes.DataChunk
+		if dc != nil && dc.Length > 0 {
+			if _, err := fd.Write(dc.Data); err != nil {
+				return cli.NewExitError(err, 1)
+			}
+		}
+	}
+	return nil
+}
+
+func purge(c *cli.Context) error {
+	path := c.Args().First()
+	if path == "" && !c.Bool("all") {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetPreviewClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	res, err := client.PurgePreviews(util.GetContextWithAllAuths(path), &api.PathReq{Path: path})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	return nil
 }
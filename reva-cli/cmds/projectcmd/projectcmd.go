@@ -0,0 +1,232 @@
+package projectcmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/reva-cli/util"
+	"github.com/codegangsta/cli"
+	"github.com/ryanuber/columnize"
+)
+
+var groupFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "admins",
+		Usage: "group with all the permissions on the project",
+	},
+	cli.StringFlag{
+		Name:  "writers",
+		Usage: "group with read and write access to the project",
+	},
+	cli.StringFlag{
+		Name:  "readers",
+		Usage: "group with read access to the project",
+	},
+	cli.Uint64Flag{
+		Name:  "quota",
+		Usage: "maximum size of the project in bytes, 0 for no limit",
+	},
+}
+
+var CreateProjectCommand = cli.Command{
+	Name:      "create",
+	Usage:     "Creates a project space owned by a user, the path and the groups not given are named after the project",
+	ArgsUsage: "Usage: create <name> <owner> [--path <path>] [--admins <group>] [--writers <group>] [--readers <group>] [--quota <bytes>]",
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "path",
+			Usage: "path of the project under the root of the projects",
+		},
+	}, groupFlags...),
+	Action: createProject,
+}
+
+var UpdateProjectCommand = cli.Command{
+	Name:      "update",
+	Usage:     "Changes the owner, the groups or the quota of a project",
+	ArgsUsage: "Usage: update <name> [--owner <owner>] [--admins <group>] [--writers <group>] [--readers <group>] [--quota <bytes>]",
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "owner",
+			Usage: "new owner of the project",
+		},
+	}, groupFlags...),
+	Action: updateProject,
+}
+
+var DeleteProjectCommand = cli.Command{
+	Name:      "delete",
+	Usage:     "Deletes a project, its files are kept without the access of its groups",
+	ArgsUsage: "Usage: delete <name> [--delete-files]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "delete-files",
+			Usage: "delete the files of the project too",
+		},
+	},
+	Action: deleteProject,
+}
+
+var InspectProjectCommand = cli.Command{
+	Name:      "info",
+	Usage:     "Shows a project",
+	ArgsUsage: "Usage: info <name>",
+	Action:    inspectProject,
+}
+
+var ListProjectsCommand = cli.Command{
+	Name:      "list",
+	Usage:     "List projects",
+	ArgsUsage: "Usage: list",
+	Action:    listProjects,
+}
+
+func createProject(c *cli.Context) error {
+	if len(c.Args()) < 2 {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetProjectClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	req := &api.NewProjectReq{
+		Project: &api.ProjectSpace{
+			Name:         c.Args().Get(0),
+			Owner:        c.Args().Get(1),
+			Path:         c.String("path"),
+			AdminGroup:   c.String("admins"),
+			WritersGroup: c.String("writers"),
+			ReadersGroup: c.String("readers"),
+		},
+		Quota: c.Uint64("quota"),
+	}
+	ctx := util.GetContextWithAuth()
+	res, err := client.CreateProject(ctx, req)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	printProjects(c, res.Project)
+	return nil
+}
+
+func updateProject(c *cli.Context) error {
+	name := c.Args().First()
+	if name == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetProjectClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	req := &api.UpdateProjectReq{
+		Name:         name,
+		Owner:        c.String("owner"),
+		AdminGroup:   c.String("admins"),
+		WritersGroup: c.String("writers"),
+		ReadersGroup: c.String("readers"),
+		Quota:        c.Uint64("quota"),
+		UpdateQuota:  c.IsSet("quota"),
+	}
+	ctx := util.GetContextWithAuth()
+	res, err := client.UpdateProject(ctx, req)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	printProjects(c, res.Project)
+	return nil
+}
+
+func deleteProject(c *cli.Context) error {
+	name := c.Args().First()
+	if name == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetProjectClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	res, err := client.DeleteProject(ctx, &api.DeleteProjectReq{Name: name, DeleteFiles: c.Bool("delete-files")})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	return nil
+}
+
+func inspectProject(c *cli.Context) error {
+	name := c.Args().First()
+	if name == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetProjectClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	res, err := client.GetProject(ctx, &api.ProjectReq{Name: name})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	printProjects(c, res.Project)
+	return nil
+}
+
+func listProjects(c *cli.Context) error {
+	client, err := util.GetProjectClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	stream, err := client.ListProjects(ctx, &api.EmptyReq{})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	projects := []*api.ProjectSpace{}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if res.Status != api.StatusCode_OK {
+			return cli.NewExitError(res.Status, 1)
+		}
+		projects = append(projects, res.Project)
+	}
+	printProjects(c, projects...)
+	return nil
+}
+
+func printProjects(c *cli.Context, projects ...*api.ProjectSpace) {
+	lines := []string{"#Name|Path|Owner|Admins|Writers|Readers"}
+	for _, p := range projects {
+		line := fmt.Sprintf("%s|%s|%s|%s|%s|%s", p.Name, p.Path, p.Owner, p.AdminGroup, p.WritersGroup, p.ReadersGroup)
+		lines = append(lines, line)
+	}
+	fmt.Fprintln(c.App.Writer, columnize.SimpleFormat(lines))
+}
@@ -15,13 +15,21 @@ import (
 
 var CreateFolderShareCommand = cli.Command{
 	Name:      "folder-share-create",
-	Usage:     "Creates a folder share",
+	Usage:     "Creates a share of a folder or a file",
 	ArgsUsage: "Usage: folder-share-create <path> <recipient-type> <recipient> <read-only>",
 	Flags: []cli.Flag{
 		cli.BoolFlag{
 			Name:  "read-write",
 			Usage: "Sets the share to read-write so people can add/delete files",
 		},
+		cli.StringFlag{
+			Name:  "expiration",
+			Usage: "date when the share is removed, in RFC3339 format",
+		},
+		cli.IntFlag{
+			Name:  "permissions",
+			Usage: "ownCloud permission bits of the share (1 read, 2 update, 4 create, 8 delete, 16 share), overrides read-write",
+		},
 	},
 	Action: createFolderShare,
 }
@@ -49,6 +57,14 @@ var UpdateFolderShareCommand = cli.Command{
 			Name:  "read-write",
 			Usage: "Sets the share to read-write so people can add/delete files",
 		},
+		cli.StringFlag{
+			Name:  "expiration",
+			Usage: "date when the share is removed, in RFC3339 format, or never",
+		},
+		cli.IntFlag{
+			Name:  "permissions",
+			Usage: "ownCloud permission bits of the share (1 read, 2 update, 4 create, 8 delete, 16 share), overrides read-write",
+		},
 	},
 	Action: updateFolderShare,
 }
@@ -62,14 +78,20 @@ var ListReceivedSharesCommand = cli.Command{
 
 var MountReceivedShareCommand = cli.Command{
 	Name:      "received-share-mount",
-	Usage:     "Marks a received share to be mounted",
+	Usage:     "Accepts a received share and mounts it",
 	ArgsUsage: "Usage: received-share-mount <share-id>",
-	Action:    mountReceivedShare,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "target",
+			Usage: "name to mount the share under, the current one if not set",
+		},
+	},
+	Action: mountReceivedShare,
 }
 
 var UnmountReceivedShareCommmand = cli.Command{
 	Name:      "received-share-unmount",
-	Usage:     "Marks a received share to be unmounted",
+	Usage:     "Rejects a received share and unmounts it",
 	ArgsUsage: "Usage: received-share-unmount <share-id>",
 	Action:    unmountReceivedShare,
 }
@@ -87,6 +109,18 @@ var CreatePublicLinkCommand = cli.Command{
 			Name:  "read-write",
 			Usage: "Sets the link contents to read-write to people can add/delete files",
 		},
+		cli.BoolFlag{
+			Name:  "drop-only",
+			Usage: "Sets the link to upload-only so people can only drop new files",
+		},
+		cli.BoolFlag{
+			Name:  "notify-uploads",
+			Usage: "Sends a mail to the owner when files are dropped in an upload-only link",
+		},
+		cli.IntFlag{
+			Name:  "max-downloads",
+			Usage: "Number of downloads after which the link stops working, unlimited if 0",
+		},
 		cli.StringFlag{
 			Name:  "expiration",
 			Usage: "expiration time for the link, like 2018-02-28:12:45:00",
@@ -145,6 +179,30 @@ var UpdatePublicLinkCommand = cli.Command{
 			Name:  "set-read-only",
 			Usage: "set read-only field to the value from --read-only flag",
 		},
+		cli.BoolFlag{
+			Name:  "drop-only",
+			Usage: "set link to upload-only",
+		},
+		cli.BoolFlag{
+			Name:  "set-drop-only",
+			Usage: "set drop-only field to the value from --drop-only flag",
+		},
+		cli.BoolFlag{
+			Name:  "notify-uploads",
+			Usage: "send a mail to the owner when files are dropped in the link",
+		},
+		cli.BoolFlag{
+			Name:  "set-notify-uploads",
+			Usage: "set notify-uploads field to the value from --notify-uploads flag",
+		},
+		cli.IntFlag{
+			Name:  "max-downloads",
+			Usage: "number of downloads after which the link stops working, unlimited if 0",
+		},
+		cli.BoolFlag{
+			Name:  "set-max-downloads",
+			Usage: "set max-downloads field to the value from --max-downloads flag",
+		},
 	},
 	Action: updatePublicLink,
 }
@@ -172,7 +230,17 @@ func inspectPublicLink(c *cli.Context) error {
 	link := linkRes.PublicLink
 	modified := time.Unix(int64(link.Mtime), 0).Format(time.RFC3339)
 	expires := time.Unix(int64(link.Expires), 0).Format(time.RFC3339)
-	fmt.Fprintf(c.App.Writer, "ID: %s\nToken: %s\nProtected: %t\nReadOnly: %t\nModify: %s Timestamp: %d\nExpires: %s Timestamp: %d\nPath: %s\n", link.Id, link.Token, link.Protected, link.ReadOnly, modified, link.Mtime, expires, link.Expires, link.Path)
+	fmt.Fprintf(c.App.Writer, "ID: %s\nToken: %s\nProtected: %t\nReadOnly: %t\nDropOnly: %t\nNotifyUploads: %t\nModify: %s Timestamp: %d\nExpires: %s Timestamp: %d\nPath: %s\n", link.Id, link.Token, link.Protected, link.ReadOnly, link.DropOnly, link.NotifyUploads, modified, link.Mtime, expires, link.Expires, link.Path)
+
+	lastAccess := "never"
+	if link.LastAccess != 0 {
+		lastAccess = time.Unix(int64(link.LastAccess), 0).Format(time.RFC3339)
+	}
+	maxDownloads := "unlimited"
+	if link.MaxDownloads != 0 {
+		maxDownloads = fmt.Sprintf("%d", link.MaxDownloads)
+	}
+	fmt.Fprintf(c.App.Writer, "Views: %d\nDownloads: %d\nMaxDownloads: %s\nLastAccess: %s\nLastAccessIPHash: %s\n", link.Views, link.Downloads, maxDownloads, lastAccess, link.LastAccessIpHash)
 	return nil
 }
 
@@ -188,9 +256,12 @@ func createPublicLink(c *cli.Context) error {
 	}
 
 	req := &api.NewLinkReq{
-		Password: c.String("password"),
-		ReadOnly: !c.Bool("read-write"),
-		Path:     path,
+		Password:      c.String("password"),
+		ReadOnly:      !c.Bool("read-write") && !c.Bool("drop-only"),
+		DropOnly:      c.Bool("drop-only"),
+		NotifyUploads: c.Bool("notify-uploads"),
+		MaxDownloads:  uint64(c.Int("max-downloads")),
+		Path:          path,
 	}
 
 	if c.String("expiration") != "" {
@@ -213,7 +284,7 @@ func createPublicLink(c *cli.Context) error {
 
 	modified := time.Unix(int64(link.Mtime), 0).Format(time.RFC3339)
 	expires := time.Unix(int64(link.Expires), 0).Format(time.RFC3339)
-	fmt.Fprintf(c.App.Writer, "Token: %s\nProtected: %t\nReadOnly: %t\nModify: %s Timestamp: %d\nExpires: %s Timestamp: %d\nPath: %s\n", link.Token, link.Protected, link.ReadOnly, modified, link.Mtime, expires, link.Expires, link.Path)
+	fmt.Fprintf(c.App.Writer, "Token: %s\nProtected: %t\nReadOnly: %t\nDropOnly: %t\nNotifyUploads: %t\nModify: %s Timestamp: %d\nExpires: %s Timestamp: %d\nPath: %s\n", link.Token, link.Protected, link.ReadOnly, link.DropOnly, link.NotifyUploads, modified, link.Mtime, expires, link.Expires, link.Path)
 	return nil
 }
 
@@ -303,6 +374,21 @@ func updatePublicLink(c *cli.Context) error {
 		req.ReadOnly = c.Bool("read-only")
 	}
 
+	if c.Bool("set-drop-only") {
+		req.UpdateDropOnly = true
+		req.DropOnly = c.Bool("drop-only")
+	}
+
+	if c.Bool("set-notify-uploads") {
+		req.UpdateNotifyUploads = true
+		req.NotifyUploads = c.Bool("notify-uploads")
+	}
+
+	if c.Bool("set-max-downloads") {
+		req.UpdateMaxDownloads = true
+		req.MaxDownloads = uint64(c.Int("max-downloads"))
+	}
+
 	ctx := util.GetContextWithAuth()
 	linkRes, err := client.UpdatePublicLink(ctx, req)
 	if err != nil {
@@ -315,7 +401,7 @@ func updatePublicLink(c *cli.Context) error {
 
 	modified := time.Unix(int64(link.Mtime), 0).Format(time.RFC3339)
 	expires := time.Unix(int64(link.Expires), 0).Format(time.RFC3339)
-	fmt.Fprintf(c.App.Writer, "Token: %s\nProtected: %t\nReadOnly: %t\nModify: %s Timestamp: %d\nExpires: %s Timestamp: %d\nPath: %s\n", link.Token, link.Protected, link.ReadOnly, modified, link.Mtime, expires, link.Expires, link.Path)
+	fmt.Fprintf(c.App.Writer, "Token: %s\nProtected: %t\nReadOnly: %t\nDropOnly: %t\nNotifyUploads: %t\nModify: %s Timestamp: %d\nExpires: %s Timestamp: %d\nPath: %s\n", link.Token, link.Protected, link.ReadOnly, link.DropOnly, link.NotifyUploads, modified, link.Mtime, expires, link.Expires, link.Path)
 	return nil
 }
 
@@ -370,7 +456,12 @@ func createFolderShare(c *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
-	req := &api.NewFolderShareReq{Path: path, ReadOnly: !readWrite, Recipient: &api.ShareRecipient{Identity: recipient, Type: recipientType}}
+	expiration, err := parseExpiration(c.String("expiration"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	req := &api.NewFolderShareReq{Path: path, ReadOnly: !readWrite, Permissions: uint32(c.Int("permissions")), Recipient: &api.ShareRecipient{Identity: recipient, Type: recipientType}, Expiration: expiration}
 
 	ctx := util.GetContextWithAuth()
 	res, err := client.AddFolderShare(ctx, req)
@@ -384,7 +475,7 @@ func createFolderShare(c *cli.Context) error {
 
 	modified := time.Unix(int64(share.Mtime), 0).Format(time.RFC3339)
 
-	fmt.Fprintf(c.App.Writer, "ID: %s\nReadOnly: %t\nType: %s Recipient: %s\nModify: %s Timestamp: %d\nPath: %s\n", share.Id, share.ReadOnly, recipientTypeString, share.Recipient.Identity, modified, share.Mtime, share.Path)
+	fmt.Fprintf(c.App.Writer, "ID: %s\nReadOnly: %t\nPermissions: %d\nType: %s Recipient: %s\nModify: %s Timestamp: %d\nPath: %s\n", share.Id, share.ReadOnly, api.GetSharePermissions(share), recipientTypeString, share.Recipient.Identity, modified, share.Mtime, share.Path)
 	return nil
 }
 
@@ -452,7 +543,16 @@ func updateFolderShare(c *cli.Context) error {
 
 	readWrite := c.Bool("read-write")
 
-	req := &api.UpdateFolderShareReq{Id: id, ReadOnly: !readWrite, UpdateReadOnly: true}
+	expiration, err := parseExpiration(c.String("expiration"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	req := &api.UpdateFolderShareReq{Id: id, ReadOnly: !readWrite, UpdateReadOnly: true, UpdateExpiration: c.String("expiration") != "", Expiration: expiration}
+	if permissions := c.Int("permissions"); permissions != 0 {
+		req.Permissions = uint32(permissions)
+		req.UpdatePermissions = true
+	}
 	ctx := util.GetContextWithAuth()
 	client, err := util.GetSharingClient()
 	if err != nil {
@@ -470,7 +570,7 @@ func updateFolderShare(c *cli.Context) error {
 	modified := time.Unix(int64(share.Mtime), 0).Format(time.RFC3339)
 
 	recipientTypeString := getRecipientTypeHuman(share.Recipient.Type)
-	fmt.Fprintf(c.App.Writer, "ID: %s\nReadOnly: %t\nType: %s Recipient: %s\nModify: %s Timestamp: %d\nPath: %s\n", share.Id, share.ReadOnly, recipientTypeString, share.Recipient.Identity, modified, share.Mtime, share.Path)
+	fmt.Fprintf(c.App.Writer, "ID: %s\nReadOnly: %t\nPermissions: %d\nType: %s Recipient: %s\nModify: %s Timestamp: %d\nPath: %s\n", share.Id, share.ReadOnly, api.GetSharePermissions(share), recipientTypeString, share.Recipient.Identity, modified, share.Mtime, share.Path)
 	return nil
 
 }
@@ -486,7 +586,7 @@ func listReceivedShares(c *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
-	lines := []string{"#ID|ReadOnly|Type|From|To|Modified|Path"}
+	lines := []string{"#ID|ReadOnly|Type|From|To|Modified|Path|State|Target"}
 	for {
 		res, err := stream.Recv()
 		if err == io.EOF {
@@ -500,16 +600,63 @@ func listReceivedShares(c *cli.Context) error {
 		}
 		share := res.Share
 		recipientType := getRecipientTypeHuman(share.Recipient.Type)
-		line := fmt.Sprintf("%s|%t|%s|%s|%s|%d|%s", share.Id, share.ReadOnly, recipientType, share.OwnerId, share.Recipient.Identity, share.Mtime, share.Path)
+		line := fmt.Sprintf("%s|%t|%s|%s|%s|%d|%s|%s|%s", share.Id, share.ReadOnly, recipientType, api.GetShareInitiator(share), share.Recipient.Identity, share.Mtime, share.Path, share.State, share.Target)
 		lines = append(lines, line)
 	}
 	fmt.Fprintln(c.App.Writer, columnize.SimpleFormat(lines))
 	return nil
 }
 
-func mountReceivedShare(c *cli.Context) {
-	fmt.Println("not implemented")
+func mountReceivedShare(c *cli.Context) error {
+	id := c.Args().First()
+	if id == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetSharingClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	res, err := client.MountReceivedShare(ctx, &api.ReceivedShareReq{ShareId: id, Target: c.String("target")})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	fmt.Fprintf(c.App.Writer, "ID: %s\nState: %s\nTarget: %s\n", res.Share.Id, res.Share.State, res.Share.Target)
+	return nil
+}
+
+func unmountReceivedShare(c *cli.Context) error {
+	id := c.Args().First()
+	if id == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetSharingClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	_, err = client.UnmountReceivedShare(ctx, &api.ReceivedShareReq{ShareId: id})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return nil
 }
-func unmountReceivedShare(c *cli.Context) {
-	fmt.Println("not implemented")
+
+// parseExpiration returns the unix time of an RFC3339 date, 0 for never.
+func parseExpiration(date string) (uint64, error) {
+	if date == "" || date == "never" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(t.Unix()), nil
 }
@@ -71,6 +71,19 @@ var ListFolderCommand = cli.Command{
 	Action:    listFolder,
 }
 
+var WatchCommand = cli.Command{
+	Name:      "watch",
+	Usage:     "Print the changes under a path as they happen",
+	ArgsUsage: "Usage: watch <path> [--recursive]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive",
+			Usage: "watch the whole subtree instead of only the direct children",
+		},
+	},
+	Action: watch,
+}
+
 var DeleteCommand = cli.Command{
 	Name:      "delete",
 	Usage:     "Delete an entry",
@@ -172,6 +185,47 @@ func listFolder(c *cli.Context) error {
 	return nil
 }
 
+func watch(c *cli.Context) error {
+	path := c.Args().First()
+	if path == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetStorageClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	req := &api.WatchReq{Path: path, Recursive: c.Bool("recursive")}
+	stream, err := client.Watch(util.GetContextWithAllAuths(path), req)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if res.Status != api.StatusCode_OK {
+			return cli.NewExitError(res.Status, 1)
+		}
+		e := res.FileEvent
+		t := time.Unix(int64(e.Time), 0).Format(time.RFC3339)
+		line := fmt.Sprintf("%s %s %s %s", t, e.Type, e.AccountId, e.Path)
+		if e.OldPath != "" {
+			line += " (from " + e.OldPath + ")"
+		}
+		if e.Target != "" {
+			line += " " + e.Target
+		}
+		fmt.Fprintln(c.App.Writer, line)
+	}
+}
+
 func download(c *cli.Context) error {
 	if len(c.Args()) < 2 {
 		return cli.NewExitError(c.Command.ArgsUsage, 1)
@@ -0,0 +1,179 @@
+package tagcmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/reva-cli/util"
+	"github.com/codegangsta/cli"
+	"github.com/ryanuber/columnize"
+)
+
+var projectFlag = cli.StringFlag{
+	Name:  "project",
+	Usage: "name of the project of the system tag",
+}
+
+var ListTagsCommand = cli.Command{
+	Name:      "list",
+	Usage:     "List the files with a tag, or all the tags if no key is given",
+	ArgsUsage: "Usage: list [<key>] [--project <name>]",
+	Flags:     []cli.Flag{projectFlag},
+	Action:    listTags,
+}
+
+var GetFileTagsCommand = cli.Command{
+	Name:      "get",
+	Usage:     "List the tags of a file",
+	ArgsUsage: "Usage: get <path>",
+	Action:    getFileTags,
+}
+
+var SetTagCommand = cli.Command{
+	Name:      "set",
+	Usage:     "Tags a file",
+	ArgsUsage: "Usage: set <path> <key> [<value>] [--project <name>]",
+	Flags:     []cli.Flag{projectFlag},
+	Action:    setTag,
+}
+
+var UnSetTagCommand = cli.Command{
+	Name:      "unset",
+	Usage:     "Removes a tag from a file",
+	ArgsUsage: "Usage: unset <path> <key> [--project <name>]",
+	Flags:     []cli.Flag{projectFlag},
+	Action:    unSetTag,
+}
+
+var RenameTagCommand = cli.Command{
+	Name:      "rename",
+	Usage:     "Renames a tag on all the files",
+	ArgsUsage: "Usage: rename <key> <new-key> [--project <name>]",
+	Flags:     []cli.Flag{projectFlag},
+	Action:    renameTag,
+}
+
+func listTags(c *cli.Context) error {
+	client, err := util.GetTagClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	req := &api.TagReq{TagKey: c.Args().First(), Project: c.String("project")}
+	ctx := util.GetContextWithAuth()
+	stream, err := client.GetTags(ctx, req)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return printTags(c, stream)
+}
+
+func getFileTags(c *cli.Context) error {
+	path := c.Args().First()
+	if path == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetTagClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	stream, err := client.GetFileTags(util.GetContextWithAllAuths(path), &api.PathReq{Path: path})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return printTags(c, stream)
+}
+
+type tagStream interface {
+	Recv() (*api.TagResponse, error)
+}
+
+func printTags(c *cli.Context, stream tagStream) error {
+	lines := []string{"#Key|Value|Project|Type|FileID"}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if res.Status != api.StatusCode_OK {
+			return cli.NewExitError(res.Status, 1)
+		}
+		tag := res.Tag
+		line := fmt.Sprintf("%s|%s|%s|%s|%s:%s", tag.TagKey, tag.TagValue, tag.Project, tag.ItemType, tag.FileIdPrefix, tag.FileId)
+		lines = append(lines, line)
+	}
+	fmt.Fprintln(c.App.Writer, columnize.SimpleFormat(lines))
+	return nil
+}
+
+func setTag(c *cli.Context) error {
+	if len(c.Args()) < 2 {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetTagClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	path := c.Args().Get(0)
+	req := &api.TagReq{Path: path, TagKey: c.Args().Get(1), TagVal: c.Args().Get(2), Project: c.String("project")}
+	res, err := client.SetTag(util.GetContextWithAllAuths(path), req)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	return nil
+}
+
+func unSetTag(c *cli.Context) error {
+	if len(c.Args()) < 2 {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetTagClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	path := c.Args().Get(0)
+	req := &api.TagReq{Path: path, TagKey: c.Args().Get(1), Project: c.String("project")}
+	res, err := client.UnSetTag(util.GetContextWithAllAuths(path), req)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	return nil
+}
+
+func renameTag(c *cli.Context) error {
+	if len(c.Args()) < 2 {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetTagClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	req := &api.RenameTagReq{TagKey: c.Args().Get(0), NewTagKey: c.Args().Get(1), Project: c.String("project")}
+	ctx := util.GetContextWithAuth()
+	res, err := client.RenameTag(ctx, req)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package webhookcmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/reva-cli/util"
+	"github.com/codegangsta/cli"
+	"github.com/ryanuber/columnize"
+)
+
+var AddWebhookCommand = cli.Command{
+	Name:      "add",
+	Usage:     "Adds a webhook that receives the events under a path",
+	ArgsUsage: "Usage: add <path> <url> [--recursive] [--events WRITTEN,DELETED,...] [--secret <secret>]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive",
+			Usage: "send the events of the whole subtree instead of only the direct children",
+		},
+		cli.StringFlag{
+			Name:  "events",
+			Usage: "comma separated list of event types to send, all if empty",
+		},
+		cli.StringFlag{
+			Name:  "secret",
+			Usage: "secret to sign the payloads with HMAC-SHA256",
+		},
+	},
+	Action: addWebhook,
+}
+
+var ListWebhooksCommand = cli.Command{
+	Name:      "list",
+	Usage:     "List webhooks",
+	ArgsUsage: "Usage: list",
+	Action:    listWebhooks,
+}
+
+var RemoveWebhookCommand = cli.Command{
+	Name:      "remove",
+	Usage:     "Removes a webhook and its dead letters",
+	ArgsUsage: "Usage: remove <id>",
+	Action:    removeWebhook,
+}
+
+var ListDeadLettersCommand = cli.Command{
+	Name:      "dead-letters",
+	Usage:     "List the payloads that could not be delivered to a webhook",
+	ArgsUsage: "Usage: dead-letters <webhook-id>",
+	Action:    listDeadLetters,
+}
+
+var RetryDeadLetterCommand = cli.Command{
+	Name:      "retry",
+	Usage:     "Sends again a payload that could not be delivered",
+	ArgsUsage: "Usage: retry <dead-letter-id>",
+	Action:    retryDeadLetter,
+}
+
+func addWebhook(c *cli.Context) error {
+	if len(c.Args()) < 2 {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	events := []api.FileEvent_Type{}
+	if c.String("events") != "" {
+		for _, name := range strings.Split(c.String("events"), ",") {
+			t, ok := api.FileEvent_Type_value[strings.ToUpper(strings.TrimSpace(name))]
+			if !ok {
+				return cli.NewExitError("unknown event type: "+name, 1)
+			}
+			events = append(events, api.FileEvent_Type(t))
+		}
+	}
+
+	client, err := util.GetWebhooksClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	req := &api.NewWebhookReq{
+		Path:      c.Args().Get(0),
+		Url:       c.Args().Get(1),
+		Recursive: c.Bool("recursive"),
+		Events:    events,
+		Secret:    c.String("secret"),
+	}
+	ctx := util.GetContextWithAuth()
+	res, err := client.AddWebhook(ctx, req)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	fmt.Fprintf(c.App.Writer, "Webhook %s created\n", res.Webhook.Id)
+	return nil
+}
+
+func listWebhooks(c *cli.Context) error {
+	client, err := util.GetWebhooksClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	stream, err := client.ListWebhooks(ctx, &api.EmptyReq{})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	lines := []string{"#ID|Owner|Path|Recursive|Events|URL|Created"}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if res.Status != api.StatusCode_OK {
+			return cli.NewExitError(res.Status, 1)
+		}
+		wh := res.Webhook
+		events := "all"
+		if len(wh.Events) > 0 {
+			names := []string{}
+			for _, e := range wh.Events {
+				names = append(names, e.String())
+			}
+			events = strings.Join(names, ",")
+		}
+		created := time.Unix(int64(wh.Ctime), 0).Format(time.RFC3339)
+		line := fmt.Sprintf("%s|%s|%s|%t|%s|%s|%s", wh.Id, wh.OwnerId, wh.Path, wh.Recursive, events, wh.Url, created)
+		lines = append(lines, line)
+	}
+	fmt.Fprintln(c.App.Writer, columnize.SimpleFormat(lines))
+	return nil
+}
+
+func removeWebhook(c *cli.Context) error {
+	id := c.Args().First()
+	if id == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetWebhooksClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	res, err := client.RemoveWebhook(ctx, &api.WebhookIDReq{Id: id})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	return nil
+}
+
+func listDeadLetters(c *cli.Context) error {
+	id := c.Args().First()
+	if id == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetWebhooksClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	stream, err := client.ListDeadLetters(ctx, &api.WebhookIDReq{Id: id})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	lines := []string{"#ID|Attempts|Created|Error|Payload"}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if res.Status != api.StatusCode_OK {
+			return cli.NewExitError(res.Status, 1)
+		}
+		dl := res.DeadLetter
+		created := time.Unix(int64(dl.Ctime), 0).Format(time.RFC3339)
+		line := fmt.Sprintf("%s|%d|%s|%s|%s", dl.Id, dl.Attempts, created, dl.Error, dl.Payload)
+		lines = append(lines, line)
+	}
+	fmt.Fprintln(c.App.Writer, columnize.SimpleFormat(lines))
+	return nil
+}
+
+func retryDeadLetter(c *cli.Context) error {
+	id := c.Args().First()
+	if id == "" {
+		return cli.NewExitError(c.Command.ArgsUsage, 1)
+	}
+
+	client, err := util.GetWebhooksClient()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	ctx := util.GetContextWithAuth()
+	res, err := client.RetryDeadLetter(ctx, &api.DeadLetterIDReq{Id: id})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if res.Status != api.StatusCode_OK {
+		return cli.NewExitError(res.Status, 1)
+	}
+	return nil
+}
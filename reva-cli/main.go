@@ -23,7 +23,13 @@ func main() {
 		cmds.StorageCommands,
 		cmds.AuthCommands,
 		cmds.ShareCommands,
+		cmds.OCMCommands,
 		cmds.PreviewCommands,
+		cmds.AuditCommands,
+		cmds.WebhookCommands,
+		cmds.TagCommands,
+		cmds.ProjectCommands,
+		cmds.AdminCommands,
 		cmds.LoginCommand,
 	}
 
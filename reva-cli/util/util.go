@@ -134,6 +134,46 @@ func GetPreviewClient() (api.PreviewClient, error) {
 	return api.NewPreviewClient(conn), nil
 }
 
+func GetAuditClient() (api.AuditClient, error) {
+	conn, err := getConn()
+	if err != nil {
+		return nil, err
+	}
+	return api.NewAuditClient(conn), nil
+}
+
+func GetWebhooksClient() (api.WebhooksClient, error) {
+	conn, err := getConn()
+	if err != nil {
+		return nil, err
+	}
+	return api.NewWebhooksClient(conn), nil
+}
+
+func GetProjectClient() (api.ProjectClient, error) {
+	conn, err := getConn()
+	if err != nil {
+		return nil, err
+	}
+	return api.NewProjectClient(conn), nil
+}
+
+func GetTagClient() (api.TaggerClient, error) {
+	conn, err := getConn()
+	if err != nil {
+		return nil, err
+	}
+	return api.NewTaggerClient(conn), nil
+}
+
+func GetOCMClient() (api.OCMClient, error) {
+	conn, err := getConn()
+	if err != nil {
+		return nil, err
+	}
+	return api.NewOCMClient(conn), nil
+}
+
 func GetContextWithAuth() context.Context {
 	token := GetAccessToken()
 	header := metadata.New(map[string]string{"authorization": "user-bearer " + token})
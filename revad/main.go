@@ -7,36 +7,69 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"os"
+	"path"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/cernbox/cboxredirectd/api/redismigrator"
 	"github.com/cernbox/gohub/goconfig"
 	"github.com/cernbox/gohub/gologger"
 
 	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/app_password_manager_db"
+	"github.com/cernbox/reva/api/audit_sink_chain"
+	"github.com/cernbox/reva/api/audit_sink_file"
+	"github.com/cernbox/reva/api/audit_sink_syslog"
+	"github.com/cernbox/reva/api/audit_sink_webhook"
+	"github.com/cernbox/reva/api/auth_manager_app_password"
+	"github.com/cernbox/reva/api/auth_manager_chain"
 	"github.com/cernbox/reva/api/auth_manager_impersonate"
 	"github.com/cernbox/reva/api/auth_manager_ldap"
+	"github.com/cernbox/reva/api/event_bus_memory"
 	"github.com/cernbox/reva/api/mount"
+	"github.com/cernbox/reva/api/ocm_share_manager_sqlite"
+	"github.com/cernbox/reva/api/preview_cache_disk"
 	"github.com/cernbox/reva/api/project_manager_db"
+	"github.com/cernbox/reva/api/project_manager_sqlite"
+	"github.com/cernbox/reva/api/public_link_manager_memory"
 	"github.com/cernbox/reva/api/public_link_manager_owncloud"
+	"github.com/cernbox/reva/api/public_link_manager_sqlite"
+	"github.com/cernbox/reva/api/search_index_local"
+	"github.com/cernbox/reva/api/share_manager_memory"
 	"github.com/cernbox/reva/api/share_manager_owncloud"
+	"github.com/cernbox/reva/api/share_manager_sqlite"
 	"github.com/cernbox/reva/api/storage_all_projects"
 	"github.com/cernbox/reva/api/storage_eos"
 	"github.com/cernbox/reva/api/storage_homemigration"
 	"github.com/cernbox/reva/api/storage_local"
+	"github.com/cernbox/reva/api/storage_ocm"
 	"github.com/cernbox/reva/api/storage_public_link"
 	"github.com/cernbox/reva/api/storage_share"
 	"github.com/cernbox/reva/api/storage_usermigration"
+	"github.com/cernbox/reva/api/storage_webdav"
 	"github.com/cernbox/reva/api/storage_wrapper_home"
+	"github.com/cernbox/reva/api/storage_wrapper_share_lifecycle"
 	"github.com/cernbox/reva/api/tag_manager_db"
+	"github.com/cernbox/reva/api/tag_manager_memory"
+	"github.com/cernbox/reva/api/tag_manager_sqlite"
+	"github.com/cernbox/reva/api/throttler_db"
+	"github.com/cernbox/reva/api/throttler_memory"
 	"github.com/cernbox/reva/api/token_manager_jwt"
 	"github.com/cernbox/reva/api/user_manager_cboxgroupd"
 	"github.com/cernbox/reva/api/virtual_storage"
+	"github.com/cernbox/reva/api/webhook_manager_db"
+	"github.com/cernbox/reva/revad/svcs/auditsvc"
 	"github.com/cernbox/reva/revad/svcs/authsvc"
+	"github.com/cernbox/reva/revad/svcs/ocmsvc"
 	"github.com/cernbox/reva/revad/svcs/previewsvc"
+	"github.com/cernbox/reva/revad/svcs/projectsvc"
+	"github.com/cernbox/reva/revad/svcs/searchsvc"
 	"github.com/cernbox/reva/revad/svcs/sharesvc"
 	"github.com/cernbox/reva/revad/svcs/storagesvc"
 	"github.com/cernbox/reva/revad/svcs/taggersvc"
+	"github.com/cernbox/reva/revad/svcs/webhooksvc"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/grpc-ecosystem/go-grpc-middleware/auth"
@@ -58,6 +91,7 @@ import (
 var gc *goconfig.GoConfig
 var logger *zap.Logger
 var vs api.VirtualStorage
+var eventBus api.EventBus
 var tokenManager api.TokenManager
 var authManager api.AuthManager
 var publicLinkManager api.PublicLinkManager
@@ -65,6 +99,14 @@ var shareManager api.ShareManager
 var userManager api.UserManager
 var projectManager api.ProjectManager
 var tagManager api.TagManager
+var appPasswordManager api.AppPasswordManager
+var throttler api.Throttler
+var webhookManager api.WebhookManager
+var ocmShareManager api.OCMShareManager
+var previewCache api.PreviewCache
+var searchIndex api.SearchIndex
+var auditSink api.AuditSink
+var auditLog api.AuditLog
 
 func main() {
 
@@ -75,34 +117,89 @@ func main() {
 	// TODO(labkode): remove this hack for the migration scenario
 	applyMigrationLogic()
 
+	trustedProxies, err := api.ParseTrustedProxies(strings.Split(gc.GetString("trusted-proxies"), ","))
+	if err != nil {
+		logger.Fatal("invalid trusted proxies", zap.Error(err))
+	}
+
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		grpc_ctxtags.StreamServerInterceptor(),
+		trustedProxies.StreamServerInterceptor(),
+		grpc_opentracing.StreamServerInterceptor(),
+		grpc_prometheus.StreamServerInterceptor,
+		grpc_zap.StreamServerInterceptor(logger),
+		grpc_auth.StreamServerInterceptor(getAuthFunc(tokenManager)),
+	}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		grpc_ctxtags.UnaryServerInterceptor(),
+		trustedProxies.UnaryServerInterceptor(),
+		grpc_opentracing.UnaryServerInterceptor(),
+		grpc_prometheus.UnaryServerInterceptor,
+		grpc_zap.UnaryServerInterceptor(logger),
+		grpc_auth.UnaryServerInterceptor(getAuthFunc(tokenManager)),
+	}
+	// the audit interceptors go after the auth ones to know the user
+	if auditSink != nil {
+		streamInterceptors = append(streamInterceptors, auditsvc.StreamServerInterceptor(auditSink))
+		unaryInterceptors = append(unaryInterceptors, auditsvc.UnaryServerInterceptor(auditSink))
+	}
+	streamInterceptors = append(streamInterceptors, grpc_recovery.StreamServerInterceptor())
+	unaryInterceptors = append(unaryInterceptors, grpc_recovery.UnaryServerInterceptor())
+
 	server := grpc.NewServer(
-		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
-			grpc_ctxtags.StreamServerInterceptor(),
-			grpc_opentracing.StreamServerInterceptor(),
-			grpc_prometheus.StreamServerInterceptor,
-			grpc_zap.StreamServerInterceptor(logger),
-			grpc_auth.StreamServerInterceptor(getAuthFunc(tokenManager)),
-			grpc_recovery.StreamServerInterceptor(),
-		)),
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-			grpc_ctxtags.UnaryServerInterceptor(),
-			grpc_opentracing.UnaryServerInterceptor(),
-			grpc_prometheus.UnaryServerInterceptor,
-			grpc_zap.UnaryServerInterceptor(logger),
-			grpc_auth.UnaryServerInterceptor(getAuthFunc(tokenManager)),
-			grpc_recovery.UnaryServerInterceptor(),
-		)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
 	)
 
 	// register prometheus metrics
 	grpc_prometheus.Register(server)
 	http.Handle("/metrics", promhttp.Handler())
 
-	api.RegisterAuthServer(server, authsvc.New(authManager, tokenManager, publicLinkManager))
-	api.RegisterStorageServer(server, storagesvc.New(vs, gc.GetString("svc-storage-tx-temporary-folder")))
-	api.RegisterShareServer(server, sharesvc.New(publicLinkManager, shareManager))
-	api.RegisterPreviewServer(server, previewsvc.New())
+	api.RegisterAuthServer(server, authsvc.New(authManager, tokenManager, publicLinkManager, appPasswordManager, throttler))
+	// the deletions and the recycle bin operations of the users go through the
+	// storage service, so it is there that the shares follow their files
+	lifecycleStorage := storage_wrapper_share_lifecycle.New(vs, shareManager, publicLinkManager)
+	api.RegisterStorageServer(server, storagesvc.New(lifecycleStorage, eventBus, gc.GetString("svc-storage-tx-temporary-folder")))
+	shareOpts := &sharesvc.Options{
+		ExpirationSweepInterval: time.Second * time.Duration(gc.GetInt("share-expiration-sweep-interval")),
+		Logger:                  logger,
+		Admins:                  strings.Split(gc.GetString("share-admins"), ","),
+	}
+	api.RegisterShareServer(server, sharesvc.New(publicLinkManager, shareManager, vs, eventBus, shareOpts))
+	api.RegisterPreviewServer(server, previewsvc.New(vs, previewCache, int64(gc.GetInt("preview-max-file-size")), gc.GetInt("preview-max-pixels"), strings.Split(gc.GetString("preview-admins"), ",")))
 	api.RegisterTaggerServer(server, taggersvc.New(tagManager))
+	api.RegisterProjectServer(server, projectsvc.New(projectManager, vs, strings.Split(gc.GetString("project-admins"), ","), gc.GetString("project-root")))
+	if webhookManager != nil {
+		webhookOpts := &webhooksvc.Options{
+			Workers:        gc.GetInt("webhook-workers"),
+			QueueSize:      gc.GetInt("webhook-queue-size"),
+			MaxAttempts:    gc.GetInt("webhook-max-attempts"),
+			RetryDelay:     time.Second * time.Duration(gc.GetInt("webhook-retry-delay")),
+			Timeout:        time.Second * time.Duration(gc.GetInt("webhook-timeout")),
+			ReloadInterval: time.Second * time.Duration(gc.GetInt("webhook-reload-interval")),
+			HomeMounts:     parseHomeMounts(gc.GetString("webhook-home-mounts")),
+			Logger:         logger,
+		}
+		api.RegisterWebhooksServer(server, webhooksvc.New(webhookManager, eventBus, strings.Split(gc.GetString("webhook-admins"), ","), webhookOpts))
+	}
+	if searchIndex != nil {
+		searchOpts := &searchsvc.Options{
+			MaxContentSize: int64(gc.GetInt("search-max-content-size")),
+			CrawlPaths:     strings.Split(gc.GetString("search-crawl-paths"), ","),
+			ProjectRoot:    gc.GetString("project-root"),
+			Logger:         logger,
+		}
+		api.RegisterSearchServer(server, searchsvc.New(searchIndex, vs, shareManager, projectManager, userManager, tagManager, eventBus, searchOpts))
+	}
+	api.RegisterAuditServer(server, auditsvc.New(auditLog, strings.Split(gc.GetString("audit-admins"), ",")))
+	if ocmShareManager != nil {
+		ocmOpts := &ocmsvc.Options{
+			ProviderURL:      gc.GetString("ocm-provider-url"),
+			TrustedProviders: strings.Split(gc.GetString("ocm-trusted-providers"), ","),
+			Timeout:          time.Second * time.Duration(gc.GetInt("ocm-timeout")),
+		}
+		api.RegisterOCMServer(server, ocmsvc.New(ocmShareManager, publicLinkManager, tokenManager, ocmOpts))
+	}
 
 	logger.Info("listening for grpc connecitons on: " + gc.GetString("tcp-address"))
 	lis, err := net.Listen("tcp", gc.GetString("tcp-address"))
@@ -192,6 +289,28 @@ func loadMountTable(mt *api.MountTable) error {
 				panic(err)
 			}
 
+			mount := mount.New(mte.MountID, mte.MountPoint, mte.MountOptions, storage)
+			mounts = append(mounts, mount)
+		case "webdav":
+			bytes, err := json.Marshal(mte.StorageOptions)
+			if err != nil {
+				panic(err)
+			}
+			opts := &storage_webdav.Options{}
+			err = json.Unmarshal(bytes, opts)
+			if err != nil {
+				panic(err)
+			}
+			storage, err := storage_webdav.New(opts, logger)
+			if err != nil {
+				panic(err)
+			}
+
+			storage, err = applyStorageWrappers(storage, mte.StorageWrappers)
+			if err != nil {
+				panic(err)
+			}
+
 			mount := mount.New(mte.MountID, mte.MountPoint, mte.MountOptions, storage)
 			mounts = append(mounts, mount)
 		case "share":
@@ -231,6 +350,28 @@ func loadMountTable(mt *api.MountTable) error {
 				panic(err)
 			}
 
+			mount := mount.New(mte.MountID, mte.MountPoint, mte.MountOptions, storage)
+			mounts = append(mounts, mount)
+		case "ocm":
+			if ocmShareManager == nil {
+				panic("the ocm storage requires ocm-enabled")
+			}
+			bytes, err := json.Marshal(mte.StorageOptions)
+			if err != nil {
+				panic(err)
+			}
+			opts := &storage_ocm.Options{}
+			err = json.Unmarshal(bytes, opts)
+			if err != nil {
+				panic(err)
+			}
+			storage := storage_ocm.New(opts, ocmShareManager, logger)
+
+			storage, err = applyStorageWrappers(storage, mte.StorageWrappers)
+			if err != nil {
+				panic(err)
+			}
+
 			mount := mount.New(mte.MountID, mte.MountPoint, mte.MountOptions, storage)
 			mounts = append(mounts, mount)
 		case "all_projects":
@@ -360,6 +501,7 @@ func init() {
 	gc.SetConfigName("revad")
 	gc.AddConfigurationPaths("/etc/revad")
 	gc.Add("tcp-address", "localhost:9999", "tcp address to listen for connections.")
+	gc.Add("trusted-proxies", "127.0.0.1,::1", "Comma separated list of the IPs and networks of the proxies, like ocproxy, whose x-forwarded-for is trusted to tell the IP of the clients.")
 	gc.Add("sign-key", "bar", "the key to sign the JWT token.")
 	gc.Add("app-log", "stderr", "file to log application information")
 	gc.Add("http-log", "stderr", "file to log http log information")
@@ -376,22 +518,39 @@ func init() {
 	gc.Add("auth-manager-ldap-filter", "(samaccountname=%s)", "Filter for LDAP queries.")
 	gc.Add("auth-manager-ldap-bind-username", "DN=foo,OU=Users,OU=Organic Units,DC=cern,DC=ch", "Username to bind to LDAP.")
 	gc.Add("auth-manager-ldap-bind-password", "bar", "Password to bind to LDAP.")
+	gc.Add("auth-manager-app-passwords-enabled", false, "If set, app passwords can be managed and are accepted before the credentials checked by auth-manager.")
+
+	gc.Add("app-password-manager", "db", "Implementation to use for the app password manager")
+	gc.Add("app-password-manager-db-username", "foo", "Username to access the database.")
+	gc.Add("app-password-manager-db-password", "bar", "Password to access the database.")
+	gc.Add("app-password-manager-db-hostname", "localhost", "Host where to access the database.")
+	gc.Add("app-password-manager-db-port", 3306, "Port where to access the database.")
+	gc.Add("app-password-manager-db-name", "", "Name of the database.")
 
 	gc.Add("user-manager", "cboxgroupd", "Implementation to use for the user manager")
 	gc.Add("user-manager-cboxgroupd-uri", "http://localhost:2002", "URI of the CERNBox Group Daemon")
 	gc.Add("user-manager-cboxgroupd-secret", "bar", "Secret to talk to the CERNBox Group Daemon")
 
-	gc.Add("project-manager", "db", "Implementation to use for the project manager")
+	gc.Add("project-manager", "db", "Implementation to use for the project manager (db, sqlite).")
+	gc.Add("project-manager-sqlite-file", "", "SQLite database file for the projects, if default, assumes os.Tempdir/reva.db.")
 	gc.Add("project-manager-db-username", "foo", "Username to access the database.")
 	gc.Add("project-manager-db-password", "bar", "Password to access the database.")
 	gc.Add("project-manager-db-hostname", "localhost", "Host where to access the database.")
 	gc.Add("project-manager-db-port", 3306, "Port where to access the database.")
 	gc.Add("project-manager-db-name", "", "Name of the database.")
+	gc.Add("project-admins", "", "Comma separated list of accounts allowed to create, update and delete the projects.")
+	gc.Add("project-root", "/eos/project", "Path of the projects in the virtual storage, where they are provisioned.")
 
 	gc.Add("token-manager", "jwt", "Implementation to use for the token manager")
 	gc.Add("token-manager-jwt-secret", "bar", "Secret to sign JWT tokens.")
 
-	gc.Add("public-link-manager", "owncloud", "Implementation to use for the public link manager")
+	gc.Add("share-manager", "owncloud", "Implementation to use for the share manager (owncloud, sqlite, memory). The owncloud one uses the public-link-manager-owncloud-db settings.")
+	gc.Add("share-manager-sqlite-file", "", "SQLite database file for the shares, if default, assumes os.Tempdir/reva.db.")
+	gc.Add("share-expiration-sweep-interval", 300, "Interval in seconds to remove the expired shares and their ACLs, 0 to disable it.")
+	gc.Add("share-admins", "", "Comma separated list of accounts allowed to check and repair the shares and public links of every user.")
+
+	gc.Add("public-link-manager", "owncloud", "Implementation to use for the public link manager (owncloud, sqlite, memory)")
+	gc.Add("public-link-manager-sqlite-file", "", "SQLite database file for the public links, if default, assumes os.Tempdir/reva.db.")
 	gc.Add("public-link-manager-owncloud-db-username", "foo", "Username to access the owncloud database.")
 	gc.Add("public-link-manager-owncloud-db-password", "bar", "Password to access the owncloud database.")
 	gc.Add("public-link-manager-owncloud-db-hostname", "localhost", "Host where to access the owncloud database.")
@@ -399,14 +558,46 @@ func init() {
 	gc.Add("public-link-manager-owncloud-db-name", "owncloud", "Name of the owncloud database.")
 	gc.Add("public-link-manager-owncloud-cache-size", 1000000, "cache size for metadata operations of public link to files.")
 	gc.Add("public-link-manager-owncloud-cache-eviction", 86400, "cache eviction in seconds to purge elements.")
-
-	gc.Add("tag-manager", "db", "Implementation to use for the tag manager")
+	gc.Add("public-link-password-min-length", 0, "Minimum number of characters of the passwords of the public links.")
+	gc.Add("public-link-password-min-classes", 0, "Minimum number of kinds of characters (lower case, upper case, digits, others) of the passwords of the public links.")
+	gc.Add("public-link-password-banned", "", "Comma separated list of passwords refused for the public links.")
+	gc.Add("public-link-password-required-for-writable", false, "Require a password on the public links that are not read-only.")
+	gc.Add("public-link-password-hash-cost", 0, "bcrypt cost of the hashes of the passwords of the public links, if 0 the default of the manager. Weaker hashes are upgraded on authentication.")
+	gc.Add("public-link-ip-hash-secret", "", "Secret keying the hashes of the IPs of the last clients of the public links, if empty the IPs are not recorded.")
+
+	gc.Add("tag-manager", "db", "Implementation to use for the tag manager (db, sqlite, memory)")
+	gc.Add("tag-manager-sqlite-file", "", "SQLite database file for the tags, if default, assumes os.Tempdir/reva.db.")
 	gc.Add("tag-manager-db-username", "foo", "Username to access the  database.")
 	gc.Add("tag-manager-db-password", "bar", "Password to access the  database.")
 	gc.Add("tag-manager-db-hostname", "localhost", "Host where to access the  database.")
 	gc.Add("tag-manager-db-port", 3306, "Port where to access the  database.")
 	gc.Add("tag-manager-db-name", "", "Name of the  database.")
 
+	gc.Add("throttler", "memory", "Implementation to use for the throttler of failed authentication attempts (memory, db)")
+	gc.Add("throttler-free-attempts", 3, "Number of failed attempts allowed before applying a wait.")
+	gc.Add("throttler-base-delay", 1, "Wait in seconds after the first penalized failure, it doubles on every failure.")
+	gc.Add("throttler-max-delay", 300, "Maximum wait in seconds between attempts before the lockout.")
+	gc.Add("throttler-lockout-attempts", 10, "Number of failed attempts after which the account, link or IP is locked. Zero means never lock.")
+	gc.Add("throttler-lockout-duration", 900, "Duration of the lockout in seconds.")
+	gc.Add("throttler-window", 3600, "Time in seconds after which failed attempts are forgotten.")
+	gc.Add("throttler-db-username", "foo", "Username to access the database.")
+	gc.Add("throttler-db-password", "bar", "Password to access the database.")
+	gc.Add("throttler-db-hostname", "localhost", "Host where to access the database.")
+	gc.Add("throttler-db-port", 3306, "Port where to access the database.")
+	gc.Add("throttler-db-name", "", "Name of the database.")
+
+	gc.Add("audit-enabled", false, "If set, an audit event is emitted for every storage and sharing action.")
+	gc.Add("audit-sinks", "file", "Comma separated list of sinks for the audit events (file, syslog, webhook).")
+	gc.Add("audit-admins", "", "Comma separated list of accounts allowed to query the audit events of every user.")
+	gc.Add("audit-file", "/var/log/revad/audit.log", "File where to append the audit events as JSON lines. It is the only sink that can be queried.")
+	gc.Add("audit-syslog-network", "", "Network to reach the syslog daemon (udp, tcp), empty means the local daemon.")
+	gc.Add("audit-syslog-address", "", "Address of the syslog daemon, empty means the local daemon.")
+	gc.Add("audit-syslog-tag", "revad-audit", "Tag of the audit events sent to syslog.")
+	gc.Add("audit-webhook-url", "", "URL where to POST the audit events.")
+	gc.Add("audit-webhook-secret", "", "Secret to sign the audit events sent to the webhook.")
+	gc.Add("audit-webhook-timeout", 10, "Timeout in seconds of the requests to the webhook.")
+	gc.Add("audit-webhook-queue-size", 10000, "Number of audit events waiting to be sent to the webhook before new ones are dropped.")
+
 	gc.Add("mig-redis-tcp-address", "localhost:6379", "redis tcp address")
 	gc.Add("mig-redis-read-timeout", 3, "timeout for socket reads. If reached, commands will fail with a timeout instead of blocking. Zero means default.")
 	gc.Add("mig-redis-write-timeout", 0, "timeout for socket writes. If reached, commands will fail with a timeout instead of blocking. Zero means mig-redis-read-timeout.")
@@ -424,6 +615,45 @@ func init() {
 	gc.Add("mig-eoshome-homedir-script", "/root/eoshome-homedir-creation.sh", "script to create home directory on EOSHOME")
 	gc.Add("mig-eoshome-homedir-script-enabled", false, "if set enables creation of home dirs in EOSHOME")
 
+	gc.Add("event-bus-buffer-size", 1000, "Number of file events buffered per watcher, a watcher that falls behind is disconnected.")
+
+	gc.Add("webhooks-enabled", false, "If set, the file events are delivered to the webhooks.")
+	gc.Add("webhook-admins", "", "Comma separated list of accounts allowed to manage the webhooks.")
+	gc.Add("webhook-workers", 4, "Number of concurrent deliveries to the webhooks.")
+	gc.Add("webhook-queue-size", 10000, "Number of deliveries waiting to be sent before new ones go to the dead letters.")
+	gc.Add("webhook-max-attempts", 5, "Number of attempts to deliver a payload before it goes to the dead letters.")
+	gc.Add("webhook-retry-delay", 10, "Wait in seconds before the first retry, it doubles on every retry.")
+	gc.Add("webhook-timeout", 10, "Timeout in seconds of the requests to the webhooks.")
+	gc.Add("webhook-reload-interval", 60, "Interval in seconds to reload the webhooks changed by other daemons.")
+	gc.Add("webhook-home-mounts", "/home=/eos/user", "Comma separated mount points resolving per user and the path the homes are under, as in /home=/eos/user. The webhooks match the paths resolved to the homes.")
+	gc.Add("webhook-manager", "db", "Implementation to use for the webhook manager")
+	gc.Add("webhook-manager-db-username", "foo", "Username to access the database.")
+	gc.Add("webhook-manager-db-password", "bar", "Password to access the database.")
+	gc.Add("webhook-manager-db-hostname", "localhost", "Host where to access the database.")
+	gc.Add("webhook-manager-db-port", 3306, "Port where to access the database.")
+	gc.Add("webhook-manager-db-name", "", "Name of the database.")
+
+	gc.Add("ocm-enabled", false, "If set, files can be shared with the users of other providers with the Open Cloud Mesh protocol.")
+	gc.Add("ocm-provider-url", "", "Public URL of the ocproxy in front of this daemon, that identifies this provider in the federated addresses.")
+	gc.Add("ocm-trusted-providers", "", "Comma separated list of the URLs of the providers to exchange shares with, if empty no provider is trusted.")
+	gc.Add("ocm-timeout", 30, "Timeout in seconds of the requests to the other providers.")
+	gc.Add("ocm-share-manager", "sqlite", "Implementation to use for the ocm share manager")
+	gc.Add("ocm-share-manager-sqlite-file", "", "SQLite database file for the ocm shares, if default, assumes os.Tempdir/reva.db.")
+
+	gc.Add("preview-max-file-size", 50*1024*1024, "Size in bytes of the largest file to generate a preview for.")
+	gc.Add("preview-max-pixels", 50*1000*1000, "Number of pixels of the largest image to generate a preview for, checked before decoding it.")
+	gc.Add("preview-admins", "", "Comma separated list of accounts allowed to purge all the cached previews.")
+	gc.Add("preview-cache", "disk", "Implementation to use for the preview cache, none to disable it.")
+	gc.Add("preview-cache-disk-folder", "", "Folder to store the previews, if default, assumes os.Tempdir/reva-previews.")
+	gc.Add("preview-cache-disk-max-size", 1024*1024*1024, "Size in bytes of the cached previews, the least recently used are removed above it.")
+
+	gc.Add("search-enabled", false, "If set, the changes to the files are indexed and can be searched. The files are found by ID, so the files of the local storage are never found.")
+	gc.Add("search-max-content-size", 1024*1024, "Size in bytes of the largest text file whose content is indexed.")
+	gc.Add("search-crawl-paths", "/home", "Comma separated paths crawled as a user the first time the user searches, to index the files not changed since the daemon started.")
+	gc.Add("search-index", "local", "Implementation to use for the search index")
+	gc.Add("search-index-local-file", "", "File where the index is saved, if empty the index is only kept in memory.")
+	gc.Add("search-index-local-save-interval", 60, "Interval in seconds to save the index when it changed.")
+
 	gc.Add("svc-storage-tx-temporary-folder", "", "temporary folder to create and assemble write tx, if default, assumes os.Tempdir")
 
 	gc.BindFlags()
@@ -431,14 +661,32 @@ func init() {
 
 	logger = gologger.New(gc.GetString("log-level"), gc.GetString("app-log"))
 
-	vs = virtual_storage.NewVFS(logger)
+	eventBus = event_bus_memory.New(gc.GetInt("event-bus-buffer-size"))
+	vs = virtual_storage.NewVFS(logger, eventBus)
 	userManager = getUserManager()
 	shareManager = getShareManager()
 	publicLinkManager = getPublicLinkManager()
 	projectManager = getProjectManager()
 	tokenManager = getTokenManager()
+	if gc.GetBool("auth-manager-app-passwords-enabled") {
+		appPasswordManager = getAppPasswordManager()
+	}
 	authManager = getAuthManager()
 	tagManager = getTagManager()
+	throttler = getThrottler()
+	if gc.GetBool("webhooks-enabled") {
+		webhookManager = getWebhookManager()
+	}
+	if gc.GetBool("audit-enabled") {
+		auditSink, auditLog = getAuditSink()
+	}
+	if gc.GetBool("ocm-enabled") {
+		ocmShareManager = getOCMShareManager()
+	}
+	previewCache = getPreviewCache()
+	if gc.GetBool("search-enabled") {
+		searchIndex = getSearchIndex()
+	}
 }
 
 func getUserManager() api.UserManager {
@@ -447,29 +695,132 @@ func getUserManager() api.UserManager {
 	return userManager
 }
 func getShareManager() api.ShareManager {
-	shareManager, err := share_manager_owncloud.New(gc.GetString("public-link-manager-owncloud-db-username"), gc.GetString("public-link-manager-owncloud-db-password"), gc.GetString("public-link-manager-owncloud-db-hostname"), gc.GetInt("public-link-manager-owncloud-db-port"), gc.GetString("public-link-manager-owncloud-db-name"), vs, userManager)
-	if err != nil {
-		panic(err)
+	driver := gc.GetString("share-manager")
+	switch driver {
+	case "owncloud":
+		shareManager, err := share_manager_owncloud.New(gc.GetString("public-link-manager-owncloud-db-username"), gc.GetString("public-link-manager-owncloud-db-password"), gc.GetString("public-link-manager-owncloud-db-hostname"), gc.GetInt("public-link-manager-owncloud-db-port"), gc.GetString("public-link-manager-owncloud-db-name"), vs, userManager)
+		if err != nil {
+			panic(err)
+		}
+		return shareManager
+	case "sqlite":
+		shareManager, err := share_manager_sqlite.New(getSQLiteFile("share-manager-sqlite-file"), vs, userManager)
+		if err != nil {
+			panic(err)
+		}
+		return shareManager
+	case "memory":
+		return share_manager_memory.New(vs, userManager)
+	default:
+		panic("share manager driver not found: " + driver)
 	}
-	return shareManager
 }
 func getPublicLinkManager() api.PublicLinkManager {
-	publicLinkManager, err := public_link_manager_owncloud.New(gc.GetString("public-link-manager-owncloud-db-username"), gc.GetString("public-link-manager-owncloud-db-password"), gc.GetString("public-link-manager-owncloud-db-hostname"), gc.GetInt("public-link-manager-owncloud-db-port"), gc.GetString("public-link-manager-owncloud-db-name"), gc.GetInt("public-link-manager-owncloud-cache-size"), gc.GetInt("public-link-manager-owncloud-cache-eviction"), vs)
-	if err != nil {
-		panic(err)
+	driver := gc.GetString("public-link-manager")
+	policy := getPasswordPolicy()
+	ipHasher := api.NewClientIPHasher(gc.GetString("public-link-ip-hash-secret"))
+	switch driver {
+	case "owncloud":
+		publicLinkManager, err := public_link_manager_owncloud.New(gc.GetString("public-link-manager-owncloud-db-username"), gc.GetString("public-link-manager-owncloud-db-password"), gc.GetString("public-link-manager-owncloud-db-hostname"), gc.GetInt("public-link-manager-owncloud-db-port"), gc.GetString("public-link-manager-owncloud-db-name"), gc.GetInt("public-link-manager-owncloud-cache-size"), gc.GetInt("public-link-manager-owncloud-cache-eviction"), vs, policy, ipHasher)
+		if err != nil {
+			panic(err)
+		}
+		return publicLinkManager
+	case "sqlite":
+		publicLinkManager, err := public_link_manager_sqlite.New(getSQLiteFile("public-link-manager-sqlite-file"), vs, policy, ipHasher)
+		if err != nil {
+			panic(err)
+		}
+		return publicLinkManager
+	case "memory":
+		return public_link_manager_memory.New(vs, policy, ipHasher)
+	default:
+		panic("public link manager driver not found: " + driver)
+	}
+}
+
+func getPasswordPolicy() *api.PasswordPolicy {
+	policy := &api.PasswordPolicy{
+		MinLength:           gc.GetInt("public-link-password-min-length"),
+		MinClasses:          gc.GetInt("public-link-password-min-classes"),
+		RequiredForWritable: gc.GetBool("public-link-password-required-for-writable"),
+		HashCost:            gc.GetInt("public-link-password-hash-cost"),
+	}
+	for _, banned := range strings.Split(gc.GetString("public-link-password-banned"), ",") {
+		if banned = strings.TrimSpace(banned); banned != "" {
+			policy.Banned = append(policy.Banned, banned)
+		}
 	}
-	return publicLinkManager
+	return policy
+}
+
+// parseHomeMounts parses the mount points and homes like /home=/eos/user,/home2=/eos/user2.
+func parseHomeMounts(s string) map[string]string {
+	m := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) == 2 && kv[0] != "" && kv[1] != "" {
+			m[kv[0]] = kv[1]
+		}
+	}
+	return m
+}
+
+// getSQLiteFile returns the database file set in key, by default all the managers share os.Tempdir/reva.db.
+func getSQLiteFile(key string) string {
+	file := gc.GetString(key)
+	if file == "" {
+		file = path.Join(os.TempDir(), "reva.db")
+	}
+	return file
 }
 func getProjectManager() api.ProjectManager {
-	projectManager := project_manager_db.New(gc.GetString("project-manager-db-username"), gc.GetString("project-manager-db-password"), gc.GetString("project-manager-db-hostname"), gc.GetInt("project-manager-db-port"), gc.GetString("project-manager-db-name"), vs)
-	return projectManager
+	driver := gc.GetString("project-manager")
+	switch driver {
+	case "db":
+		projectManager, err := project_manager_db.New(gc.GetString("project-manager-db-username"), gc.GetString("project-manager-db-password"), gc.GetString("project-manager-db-hostname"), gc.GetInt("project-manager-db-port"), gc.GetString("project-manager-db-name"), vs)
+		if err != nil {
+			panic(err)
+		}
+		return projectManager
+	case "sqlite":
+		projectManager, err := project_manager_sqlite.New(getSQLiteFile("project-manager-sqlite-file"))
+		if err != nil {
+			panic(err)
+		}
+		return projectManager
+	default:
+		panic("project manager driver not found: " + driver)
+	}
 }
 
 func getTokenManager() api.TokenManager {
 	tokenManager := token_manager_jwt.New(gc.GetString("token-manager-jwt-secret"))
 	return tokenManager
 }
+func getAppPasswordManager() api.AppPasswordManager {
+	driver := gc.GetString("app-password-manager")
+	switch driver {
+	case "db":
+		appPasswordManager, err := app_password_manager_db.New(gc.GetString("app-password-manager-db-username"), gc.GetString("app-password-manager-db-password"), gc.GetString("app-password-manager-db-hostname"), gc.GetInt("app-password-manager-db-port"), gc.GetString("app-password-manager-db-name"))
+		if err != nil {
+			panic(err)
+		}
+		return appPasswordManager
+	default:
+		panic("app password manager driver not found: " + driver)
+	}
+}
 func getAuthManager() api.AuthManager {
+	authManager := getDriverAuthManager()
+	if gc.GetBool("auth-manager-app-passwords-enabled") {
+		// app passwords go first so their restrictions are applied
+		// even if the driver would accept the same credentials, like impersonate does.
+		return auth_manager_chain.New(auth_manager_app_password.New(appPasswordManager), authManager)
+	}
+	return authManager
+}
+func getDriverAuthManager() api.AuthManager {
 	driver := gc.GetString("auth-manager")
 	switch driver {
 	case "impersonate":
@@ -487,8 +838,137 @@ func getAuthManager() api.AuthManager {
 	}
 }
 func getTagManager() api.TagManager {
-	tagManager := tag_manager_db.New(gc.GetString("tag-manager-db-username"), gc.GetString("tag-manager-db-password"), gc.GetString("tag-manager-db-hostname"), gc.GetInt("tag-manager-db-port"), gc.GetString("tag-manager-db-name"), vs)
-	return tagManager
+	driver := gc.GetString("tag-manager")
+	switch driver {
+	case "db":
+		return tag_manager_db.New(gc.GetString("tag-manager-db-username"), gc.GetString("tag-manager-db-password"), gc.GetString("tag-manager-db-hostname"), gc.GetInt("tag-manager-db-port"), gc.GetString("tag-manager-db-name"), vs, projectManager, userManager)
+	case "sqlite":
+		tagManager, err := tag_manager_sqlite.New(getSQLiteFile("tag-manager-sqlite-file"), vs, projectManager, userManager)
+		if err != nil {
+			panic(err)
+		}
+		return tagManager
+	case "memory":
+		return tag_manager_memory.New(vs, projectManager, userManager)
+	default:
+		panic("tag manager driver not found: " + driver)
+	}
+}
+
+func getThrottler() api.Throttler {
+	policy := &api.ThrottlePolicy{
+		FreeAttempts:    gc.GetInt("throttler-free-attempts"),
+		BaseDelay:       time.Second * time.Duration(gc.GetInt("throttler-base-delay")),
+		MaxDelay:        time.Second * time.Duration(gc.GetInt("throttler-max-delay")),
+		LockoutAttempts: gc.GetInt("throttler-lockout-attempts"),
+		LockoutDuration: time.Second * time.Duration(gc.GetInt("throttler-lockout-duration")),
+		Window:          time.Second * time.Duration(gc.GetInt("throttler-window")),
+	}
+
+	driver := gc.GetString("throttler")
+	switch driver {
+	case "memory":
+		return throttler_memory.New(policy)
+	case "db":
+		throttler, err := throttler_db.New(gc.GetString("throttler-db-username"), gc.GetString("throttler-db-password"), gc.GetString("throttler-db-hostname"), gc.GetInt("throttler-db-port"), gc.GetString("throttler-db-name"), policy)
+		if err != nil {
+			panic(err)
+		}
+		return throttler
+	default:
+		panic("throttler driver not found: " + driver)
+	}
+}
+
+func getWebhookManager() api.WebhookManager {
+	driver := gc.GetString("webhook-manager")
+	switch driver {
+	case "db":
+		webhookManager, err := webhook_manager_db.New(gc.GetString("webhook-manager-db-username"), gc.GetString("webhook-manager-db-password"), gc.GetString("webhook-manager-db-hostname"), gc.GetInt("webhook-manager-db-port"), gc.GetString("webhook-manager-db-name"))
+		if err != nil {
+			panic(err)
+		}
+		return webhookManager
+	default:
+		panic("webhook manager driver not found: " + driver)
+	}
+}
+
+func getOCMShareManager() api.OCMShareManager {
+	driver := gc.GetString("ocm-share-manager")
+	switch driver {
+	case "sqlite":
+		ocmShareManager, err := ocm_share_manager_sqlite.New(getSQLiteFile("ocm-share-manager-sqlite-file"))
+		if err != nil {
+			panic(err)
+		}
+		return ocmShareManager
+	default:
+		panic("ocm share manager driver not found: " + driver)
+	}
+}
+
+func getPreviewCache() api.PreviewCache {
+	driver := gc.GetString("preview-cache")
+	switch driver {
+	case "disk":
+		folder := gc.GetString("preview-cache-disk-folder")
+		if folder == "" {
+			folder = path.Join(os.TempDir(), "reva-previews")
+		}
+		previewCache, err := preview_cache_disk.New(folder, int64(gc.GetInt("preview-cache-disk-max-size")))
+		if err != nil {
+			panic(err)
+		}
+		return previewCache
+	case "none":
+		return nil
+	default:
+		panic("preview cache driver not found: " + driver)
+	}
+}
+
+func getSearchIndex() api.SearchIndex {
+	driver := gc.GetString("search-index")
+	switch driver {
+	case "local":
+		searchIndex, err := search_index_local.New(gc.GetString("search-index-local-file"), time.Second*time.Duration(gc.GetInt("search-index-local-save-interval")), logger)
+		if err != nil {
+			panic(err)
+		}
+		return searchIndex
+	default:
+		panic("search index driver not found: " + driver)
+	}
+}
+
+// getAuditSink returns the sink for the audit events and the sink that can be queried back, if any.
+func getAuditSink() (api.AuditSink, api.AuditLog) {
+	var log api.AuditLog
+	sinks := []api.AuditSink{}
+	for _, driver := range strings.Split(gc.GetString("audit-sinks"), ",") {
+		switch strings.TrimSpace(driver) {
+		case "file":
+			sink, err := audit_sink_file.New(gc.GetString("audit-file"))
+			if err != nil {
+				panic(err)
+			}
+			log = sink.(api.AuditLog)
+			sinks = append(sinks, sink)
+		case "syslog":
+			sink, err := audit_sink_syslog.New(gc.GetString("audit-syslog-network"), gc.GetString("audit-syslog-address"), gc.GetString("audit-syslog-tag"))
+			if err != nil {
+				panic(err)
+			}
+			sinks = append(sinks, sink)
+		case "webhook":
+			sink := audit_sink_webhook.New(gc.GetString("audit-webhook-url"), gc.GetString("audit-webhook-secret"), gc.GetInt("audit-webhook-timeout"), gc.GetInt("audit-webhook-queue-size"), logger)
+			sinks = append(sinks, sink)
+		default:
+			panic("audit sink driver not found: " + driver)
+		}
+	}
+	return audit_sink_chain.New(sinks...), log
 }
 
 func applyMigrationLogic() {
@@ -0,0 +1,59 @@
+package auditsvc
+
+import (
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+)
+
+// New returns the service to query the audit log. The admins can query
+// the events of every user, the others only the events of their own actions.
+// log can be nil if none of the configured sinks can be queried.
+func New(log api.AuditLog, admins []string) api.AuditServer {
+	m := map[string]bool{}
+	for _, a := range admins {
+		m[a] = true
+	}
+	return &svc{log: log, admins: m}
+}
+
+type svc struct {
+	log    api.AuditLog
+	admins map[string]bool
+}
+
+func (s *svc) ListAuditEvents(req *api.ListAuditEventsReq, stream api.Audit_ListAuditEventsServer) error {
+	ctx := stream.Context()
+	l := ctx_zap.Extract(ctx)
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		err := api.NewError(api.ContextUserRequiredError)
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	if s.log == nil {
+		err := api.NewError(api.StorageNotSupportedErrorCode).WithMessage("audit log is not queryable")
+		l.Error("", zap.Error(err))
+		return stream.Send(&api.AuditEventResponse{Status: api.GetStatus(err)})
+	}
+
+	if !s.admins[u.AccountId] || api.IsUserRestricted(u) {
+		req.AccountId = u.AccountId
+	}
+
+	events, err := s.log.ListAuditEvents(ctx, req)
+	if err != nil {
+		l.Error("error listing audit events", zap.Error(err))
+		return err
+	}
+	for _, e := range events {
+		res := &api.AuditEventResponse{AuditEvent: e}
+		if err := stream.Send(res); err != nil {
+			l.Error("error streaming audit event", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
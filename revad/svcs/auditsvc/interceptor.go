@@ -0,0 +1,151 @@
+package auditsvc
+
+import (
+	"time"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/gofrs/uuid"
+	"github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// auditedMethods maps the audited gRPC methods to the action recorded in the events.
+// Only the calls that modify or give access to the content are audited,
+// listing and inspecting are too frequent to be worth it.
+var auditedMethods = map[string]string{
+	"/api.Storage/CreateDir":           "create_dir",
+	"/api.Storage/Delete":              "delete",
+	"/api.Storage/Move":                "move",
+	"/api.Storage/FinishWriteTx":       "upload",
+	"/api.Storage/ReadFile":            "download",
+	"/api.Storage/ReadRevision":        "download_revision",
+	"/api.Storage/RestoreRevision":     "restore_revision",
+	"/api.Storage/RestoreRecycleEntry": "restore_recycle_entry",
+	"/api.Storage/EmptyRecycle":        "empty_recycle",
+	"/api.Storage/SetACL":              "set_acl",
+	"/api.Storage/UpdateACL":           "update_acl",
+	"/api.Storage/UnsetACL":            "unset_acl",
+
+	"/api.Share/AddFolderShare":       "add_folder_share",
+	"/api.Share/UpdateFolderShare":    "update_folder_share",
+	"/api.Share/UnshareFolder":        "unshare_folder",
+	"/api.Share/MountReceivedShare":   "mount_received_share",
+	"/api.Share/UnmountReceivedShare": "unmount_received_share",
+	"/api.Share/CreatePublicLink":     "create_public_link",
+	"/api.Share/UpdatePublicLink":     "update_public_link",
+	"/api.Share/RevokePublicLink":     "revoke_public_link",
+}
+
+// UnaryServerInterceptor emits an audit event for every audited unary call.
+// The event is put in the context of the call, so the services can complete it
+// with what is only known to them, like the file ID, see api.AuditSetResource.
+// It must run after the authentication interceptor, to know the user.
+func UnaryServerInterceptor(sink api.AuditSink) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		action, ok := auditedMethods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		e := newEvent(ctx, info.FullMethod, action)
+		setRequest(e, req)
+		res, err := handler(api.ContextSetAuditEvent(ctx, e), req)
+		e.Result = getResult(res, err)
+		emit(ctx, sink, e)
+		return res, err
+	}
+}
+
+// StreamServerInterceptor emits an audit event for every audited streaming call.
+func StreamServerInterceptor(sink api.AuditSink) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		action, ok := auditedMethods[info.FullMethod]
+		if !ok {
+			return handler(srv, stream)
+		}
+
+		ctx := stream.Context()
+		e := newEvent(ctx, info.FullMethod, action)
+		wrapped := &auditedStream{WrappedServerStream: grpc_middleware.WrapServerStream(stream), event: e}
+		wrapped.WrappedContext = api.ContextSetAuditEvent(ctx, e)
+		err := handler(srv, wrapped)
+		e.Result = getResult(nil, err)
+		emit(ctx, sink, e)
+		return err
+	}
+}
+
+// auditedStream captures the request of server streaming calls,
+// that is only received inside the handler.
+type auditedStream struct {
+	*grpc_middleware.WrappedServerStream
+	event *api.AuditEvent
+}
+
+func (s *auditedStream) RecvMsg(m interface{}) error {
+	err := s.WrappedServerStream.RecvMsg(m)
+	if err == nil {
+		setRequest(s.event, m)
+	}
+	return err
+}
+
+func newEvent(ctx context.Context, method, action string) *api.AuditEvent {
+	e := &api.AuditEvent{
+		Id:       uuid.Must(uuid.NewV4()).String(),
+		Time:     uint64(time.Now().Unix()),
+		Action:   action,
+		Method:   method,
+		ClientIp: api.GetClientIP(ctx),
+	}
+	if u, ok := api.ContextGetUser(ctx); ok {
+		e.AccountId = u.AccountId
+	}
+	if pl, ok := api.ContextGetPublicLink(ctx); ok {
+		e.PublicLinkToken = pl.Token
+	}
+	if tid, ok := grpc_ctxtags.Extract(ctx).Values()["tid"].(string); ok {
+		e.TraceId = tid
+	}
+	return e
+}
+
+// setRequest fills the event with the resources named in the request,
+// the services can refine them afterwards.
+func setRequest(e *api.AuditEvent, req interface{}) {
+	if r, ok := req.(interface{ GetPath() string }); ok && e.Path == "" {
+		e.Path = r.GetPath()
+	}
+	if r, ok := req.(interface{ GetOldPath() string }); ok && e.Path == "" {
+		e.Path = r.GetOldPath()
+	}
+	if r, ok := req.(interface{ GetNewPath() string }); ok && e.Target == "" {
+		e.Target = r.GetNewPath()
+	}
+}
+
+// getResult returns OK or the status of the failure, that is either returned
+// as an error or in the status field of the response.
+func getResult(res interface{}, err error) string {
+	if err != nil {
+		return api.GetStatus(err).String()
+	}
+	if r, ok := res.(interface{ GetStatus() api.StatusCode }); ok {
+		return r.GetStatus().String()
+	}
+	return api.StatusCode_OK.String()
+}
+
+// emit never fails the call: the action already happened and an unavailable
+// sink must not make it look like it did not.
+func emit(ctx context.Context, sink api.AuditSink, e *api.AuditEvent) {
+	if err := sink.Emit(ctx, e); err != nil {
+		l := ctx_zap.Extract(ctx)
+		l.Error("error emitting audit event", zap.Error(err), zap.String("id", e.Id), zap.String("action", e.Action), zap.String("path", e.Path))
+	}
+}
@@ -1,29 +1,52 @@
 package authsvc
 
 import (
+	"strings"
+	"time"
+
 	"github.com/cernbox/reva/api"
+	"github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 )
 
-func New(am api.AuthManager, tm api.TokenManager, lm api.PublicLinkManager) api.AuthServer {
-	return &svc{am: am, tm: tm, lm: lm}
+// New returns the auth service. apm is nil if the app passwords are disabled,
+// their methods are then unimplemented.
+func New(am api.AuthManager, tm api.TokenManager, lm api.PublicLinkManager, apm api.AppPasswordManager, throttler api.Throttler) api.AuthServer {
+	return &svc{am: am, tm: tm, lm: lm, apm: apm, throttler: throttler}
 }
 
 type svc struct {
-	am api.AuthManager
-	tm api.TokenManager
-	lm api.PublicLinkManager
+	am        api.AuthManager
+	tm        api.TokenManager
+	lm        api.PublicLinkManager
+	apm       api.AppPasswordManager
+	throttler api.Throttler
 }
 
 func (s *svc) ForgeUserToken(ctx context.Context, req *api.ForgeUserTokenReq) (*api.TokenResponse, error) {
 	l := ctx_zap.Extract(ctx)
+	accountKey := "account:" + req.ClientId
+	keys := getThrottleKeys(ctx, accountKey)
+	recorded, wait := s.attempt(ctx, keys)
+	if wait > 0 {
+		throttledAttempts.WithLabelValues("user").Inc()
+		l.Warn("login attempt throttled", zap.String("client_id", req.ClientId), zap.Strings("keys", keys), zap.Duration("retry_after", wait))
+		return &api.TokenResponse{Status: api.StatusCode_TOO_MANY_ATTEMPTS, RetryAfter: retryAfter(wait)}, nil
+	}
+
 	user, err := s.am.Authenticate(ctx, req.ClientId, req.ClientSecret)
 	if err != nil {
-		l.Error("", zap.Error(err))
+		failedAttempts.WithLabelValues("user").Inc()
+		wait := s.wait(ctx, keys)
+		l.Error("login attempt failed", zap.Error(err), zap.String("client_id", req.ClientId), zap.Strings("keys", keys), zap.Duration("retry_after", wait))
 		return nil, err
 	}
+	s.release(ctx, recorded)
+	s.reset(ctx, accountKey)
 
 	token, err := s.tm.ForgeUserToken(ctx, user)
 	if err != nil {
@@ -50,14 +73,44 @@ func (s *svc) DismantleUserToken(ctx context.Context, req *api.TokenReq) (*api.U
 
 func (s *svc) ForgePublicLinkToken(ctx context.Context, req *api.ForgePublicLinkTokenReq) (*api.TokenResponse, error) {
 	l := ctx_zap.Extract(ctx)
+
+	// links are probed with an empty password to know if they are protected,
+	// these attempts cannot guess anything so they are not throttled.
+	linkKey := "link:" + req.Token
+	keys := getThrottleKeys(ctx, linkKey)
+	var recorded []string
+	if req.Password != "" {
+		var wait time.Duration
+		if recorded, wait = s.attempt(ctx, keys); wait > 0 {
+			throttledAttempts.WithLabelValues("public_link").Inc()
+			l.Warn("public link attempt throttled", zap.String("token", req.Token), zap.Strings("keys", keys), zap.Duration("retry_after", wait))
+			return &api.TokenResponse{Status: api.StatusCode_TOO_MANY_ATTEMPTS, RetryAfter: retryAfter(wait)}, nil
+		}
+	}
+
 	pl, err := s.lm.AuthenticatePublicLink(ctx, req.Token, req.Password)
 	if err != nil {
 		if api.IsErrorCode(err, api.PublicLinkInvalidPasswordErrorCode) {
+			if req.Password != "" {
+				failedAttempts.WithLabelValues("public_link").Inc()
+				wait := s.wait(ctx, keys)
+				l.Warn("public link attempt failed", zap.String("token", req.Token), zap.Strings("keys", keys), zap.Duration("retry_after", wait))
+			}
 			return &api.TokenResponse{Status: api.StatusCode_PUBLIC_LINK_INVALID_PASSWORD}, nil
 		}
+		// only a wrong password is a failed attempt
+		s.release(ctx, recorded)
+		if api.IsErrorCode(err, api.PublicLinkDownloadLimitErrorCode) {
+			l.Warn("public link download limit reached", zap.String("token", req.Token))
+			return &api.TokenResponse{Status: api.StatusCode_PUBLIC_LINK_DOWNLOAD_LIMIT}, nil
+		}
 		l.Error("", zap.Error(err))
 		return nil, err
 	}
+	if req.Password != "" {
+		s.release(ctx, recorded)
+		s.reset(ctx, linkKey)
+	}
 
 	token, err := s.tm.ForgePublicLinkToken(ctx, pl)
 	if err != nil {
@@ -80,8 +133,76 @@ func (s *svc) DismantlePublicLinkToken(ctx context.Context, req *api.TokenReq) (
 	return userRes, nil
 }
 
+func (s *svc) CreateAppPassword(ctx context.Context, req *api.NewAppPasswordReq) (*api.AppPasswordResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	// else a read-only app password could be used to mint a read-write one
+	if _, err := api.CheckUnrestrictedUser(ctx, "app passwords cannot be managed"); err != nil {
+		l.Error("", zap.Error(err))
+		return &api.AppPasswordResponse{Status: api.GetStatus(err)}, nil
+	}
+
+	ap, password, err := s.apm.CreateAppPassword(ctx, req.Label, req.ReadOnly, req.Path)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return &api.AppPasswordResponse{Status: api.GetStatus(err)}, nil
+	}
+	return &api.AppPasswordResponse{AppPassword: ap, Password: password}, nil
+}
+
+func (s *svc) ListAppPasswords(req *api.EmptyReq, stream api.Auth_ListAppPasswordsServer) error {
+	ctx := stream.Context()
+	l := ctx_zap.Extract(ctx)
+	if _, err := api.CheckUnrestrictedUser(ctx, "app passwords cannot be managed"); err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	aps, err := s.apm.ListAppPasswords(ctx)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+	for _, ap := range aps {
+		if err := stream.Send(&api.AppPasswordResponse{AppPassword: ap}); err != nil {
+			l.Error("", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *svc) RevokeAppPassword(ctx context.Context, req *api.AppPasswordIDReq) (*api.EmptyResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	if _, err := api.CheckUnrestrictedUser(ctx, "app passwords cannot be managed"); err != nil {
+		l.Error("", zap.Error(err))
+		return &api.EmptyResponse{Status: api.GetStatus(err)}, nil
+	}
+
+	if err := s.apm.RevokeAppPassword(ctx, req.Id); err != nil {
+		l.Error("", zap.Error(err))
+		return &api.EmptyResponse{Status: api.GetStatus(err)}, nil
+	}
+	return &api.EmptyResponse{}, nil
+}
+
 // Override the Auth function to avoid checking the bearer token for this service
 // https://github.com/grpc-ecosystem/go-grpc-middleware/tree/master/auth#type-serviceauthfuncoverride
+// The app password methods act on behalf of the user logged in, so for them we do require the user token.
 func (s *svc) AuthFuncOverride(ctx context.Context, fullMethodName string) (context.Context, error) {
-	return ctx, nil
+	if !strings.HasSuffix(fullMethodName, "AppPassword") && !strings.HasSuffix(fullMethodName, "AppPasswords") {
+		return ctx, nil
+	}
+	if s.apm == nil {
+		return nil, grpc.Errorf(codes.Unimplemented, "app passwords are disabled")
+	}
+
+	token, err := grpc_auth.AuthFromMD(ctx, "user-bearer")
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.tm.DismantleUserToken(ctx, token)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "invalid user auth token: %v", err)
+	}
+	return api.ContextSetUser(ctx, user), nil
 }
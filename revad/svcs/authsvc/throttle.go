@@ -0,0 +1,103 @@
+package authsvc
+
+import (
+	"math"
+	"time"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+var (
+	failedAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "reva_auth_failed_attempts_total",
+		Help: "Number of failed authentication attempts.",
+	}, []string{"type"})
+
+	throttledAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "reva_auth_throttled_attempts_total",
+		Help: "Number of authentication attempts refused because of previous failures.",
+	}, []string{"type"})
+)
+
+func init() {
+	prometheus.MustRegister(failedAttempts, throttledAttempts)
+}
+
+// getThrottleKeys returns the keys to throttle an attempt on the given key,
+// adding the IP of the client when known.
+func getThrottleKeys(ctx context.Context, key string) []string {
+	keys := []string{key}
+	if ip := api.GetClientIP(ctx); ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	return keys
+}
+
+// The throttler errors are logged but do not block the authentication:
+// an unavailable store must not lock everybody out.
+
+func (s *svc) wait(ctx context.Context, keys []string) time.Duration {
+	l := ctx_zap.Extract(ctx)
+	var max time.Duration
+	for _, key := range keys {
+		wait, err := s.throttler.Wait(ctx, key)
+		if err != nil {
+			l.Error("error checking throttler", zap.Error(err), zap.String("key", key))
+			continue
+		}
+		if wait > max {
+			max = wait
+		}
+	}
+	return max
+}
+
+// attempt records the attempt on every key before it is processed, and
+// returns the keys it has been recorded on. If a key imposes a wait the attempt
+// is released from the keys already recorded and the wait is returned.
+func (s *svc) attempt(ctx context.Context, keys []string) ([]string, time.Duration) {
+	l := ctx_zap.Extract(ctx)
+	recorded := []string{}
+	for _, key := range keys {
+		wait, err := s.throttler.Attempt(ctx, key)
+		if err != nil {
+			l.Error("error recording attempt", zap.Error(err), zap.String("key", key))
+			continue
+		}
+		if wait > 0 {
+			s.release(ctx, recorded)
+			return nil, wait
+		}
+		recorded = append(recorded, key)
+	}
+	return recorded, 0
+}
+
+// release forgets the attempt recorded on the keys, once it has succeeded.
+func (s *svc) release(ctx context.Context, keys []string) {
+	l := ctx_zap.Extract(ctx)
+	for _, key := range keys {
+		if err := s.throttler.Release(ctx, key); err != nil {
+			l.Error("error releasing attempt", zap.Error(err), zap.String("key", key))
+		}
+	}
+}
+
+// reset is only called with the account or link key: the failures of an IP
+// are not forgotten on success, else an attacker could interleave
+// logins to its own account to escape the IP throttling.
+func (s *svc) reset(ctx context.Context, key string) {
+	l := ctx_zap.Extract(ctx)
+	if err := s.throttler.Reset(ctx, key); err != nil {
+		l.Error("error resetting throttler", zap.Error(err), zap.String("key", key))
+	}
+}
+
+func retryAfter(wait time.Duration) uint64 {
+	return uint64(math.Ceil(wait.Seconds()))
+}
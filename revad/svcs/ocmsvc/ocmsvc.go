@@ -0,0 +1,375 @@
+package ocmsvc
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/ocm"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// Options configures the federation with the other providers.
+type Options struct {
+	// ProviderURL is the public URL of this provider, where the other
+	// providers discover its OCM endpoint.
+	ProviderURL string
+	// TrustedProviders are the URLs of the providers to exchange shares
+	// with, if empty no provider is trusted.
+	TrustedProviders []string
+	Timeout          time.Duration
+}
+
+// New returns the service to share files with the users of other providers.
+// The shared secret of an outgoing share is the token of a public link to
+// the shared path, the remote provider accesses the files through it.
+func New(om api.OCMShareManager, lm api.PublicLinkManager, tm api.TokenManager, opts *Options) api.OCMServer {
+	trusted := map[string]bool{}
+	for _, p := range opts.TrustedProviders {
+		if p = strings.TrimSpace(p); p != "" {
+			trusted[strings.TrimSuffix(p, "/")] = true
+		}
+	}
+	return &svc{om: om, lm: lm, tm: tm, opts: opts, trusted: trusted, client: ocm.NewClient(opts.Timeout)}
+}
+
+type svc struct {
+	om      api.OCMShareManager
+	lm      api.PublicLinkManager
+	tm      api.TokenManager
+	opts    *Options
+	trusted map[string]bool
+	client  *ocm.Client
+}
+
+func (s *svc) CreateOCMShare(ctx context.Context, req *api.NewOCMShareReq) (*api.OCMShareResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	// the share is not bound by the restrictions of the credential
+	u, err := api.CheckUnrestrictedUser(ctx, "ocm shares cannot be created")
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return &api.OCMShareResponse{Status: api.GetStatus(err)}, nil
+	}
+
+	recipient, provider, err := ocm.ParseAddress(req.Recipient)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return &api.OCMShareResponse{Status: api.GetStatus(err)}, nil
+	}
+	if err := s.checkTrusted(provider); err != nil {
+		l.Error("", zap.Error(err))
+		return &api.OCMShareResponse{Status: api.GetStatus(err)}, nil
+	}
+
+	permissions := api.SharePermissions(req.Permissions)
+	if permissions == 0 {
+		permissions = api.SharePermissionsReadOnly
+	}
+	readOnly, err := isLinkReadOnly(permissions)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return &api.OCMShareResponse{Status: api.GetStatus(err)}, nil
+	}
+
+	p, err := s.client.Discover(ctx, provider)
+	if err != nil {
+		l.Error("error discovering provider of recipient", zap.Error(err), zap.String("provider", provider))
+		return nil, err
+	}
+	link, err := s.lm.CreatePublicLink(ctx, req.Path, &api.PublicLinkOptions{ReadOnly: readOnly})
+	if err != nil {
+		l.Error("error creating public link for ocm share", zap.Error(err))
+		return &api.OCMShareResponse{Status: api.GetStatus(err)}, nil
+	}
+
+	share := &api.OCMShare{Path: req.Path, Name: path.Base(req.Path), Recipient: ocm.FormatAddress(recipient, provider), Permissions: uint32(permissions), Endpoint: p.EndPoint}
+	share, err = s.om.AddOCMShare(ctx, share, link.Token)
+	if err != nil {
+		l.Error("error adding ocm share", zap.Error(err))
+		s.revokeLink(ctx, link.Token)
+		return nil, err
+	}
+
+	owner := ocm.FormatAddress(u.AccountId, s.opts.ProviderURL)
+	newShare := &ocm.Share{
+		ShareWith:    recipient,
+		Name:         share.Name,
+		ProviderID:   share.ProviderId,
+		Owner:        owner,
+		Sender:       owner,
+		ShareType:    "user",
+		ResourceType: "file",
+		Protocol: &ocm.Protocol{
+			Name:    ocm.ProtocolWebDAV,
+			Options: &ocm.ProtocolOptions{SharedSecret: link.Token, Permissions: ocm.GetProtocolPermissions(permissions)},
+		},
+	}
+	if err := s.client.SendShare(ctx, p.EndPoint, newShare); err != nil {
+		l.Error("error sending ocm share to provider of recipient", zap.Error(err), zap.String("recipient", share.Recipient))
+		if err := s.om.RemoveOCMShare(ctx, share.Id); err != nil {
+			l.Error("error removing ocm share not sent", zap.Error(err))
+		}
+		s.revokeLink(ctx, link.Token)
+		return nil, err
+	}
+	return &api.OCMShareResponse{Share: share}, nil
+}
+
+func (s *svc) ListOCMShares(req *api.EmptyReq, stream api.OCM_ListOCMSharesServer) error {
+	ctx := stream.Context()
+	l := ctx_zap.Extract(ctx)
+	shares, err := s.om.ListOCMShares(ctx)
+	if err != nil {
+		l.Error("error listing ocm shares", zap.Error(err))
+		return err
+	}
+	for _, share := range shares {
+		if err := stream.Send(&api.OCMShareResponse{Share: share}); err != nil {
+			l.Error("error streaming ocm share", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveOCMShare removes the share and its public link, and notifies
+// the recipient. The share is removed even if the notification fails.
+func (s *svc) RemoveOCMShare(ctx context.Context, req *api.OCMShareIDReq) (*api.EmptyResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	share, err := s.om.GetOCMShare(ctx, req.Id)
+	if err != nil {
+		if api.IsErrorCode(err, api.OCMShareNotFoundErrorCode) {
+			return &api.EmptyResponse{Status: api.StatusCode_OCM_SHARE_NOT_FOUND}, nil
+		}
+		l.Error("error getting ocm share", zap.Error(err))
+		return nil, err
+	}
+	secret, err := s.om.GetOCMShareSecret(ctx, share.Id)
+	if err != nil {
+		l.Error("error getting ocm share secret", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.om.RemoveOCMShare(ctx, share.Id); err != nil {
+		l.Error("error removing ocm share", zap.Error(err))
+		return nil, err
+	}
+	s.revokeLink(ctx, secret)
+	s.notify(ctx, share.Endpoint, ocm.NotificationShareUnshared, share.ProviderId, secret)
+	return &api.EmptyResponse{}, nil
+}
+
+func (s *svc) ListReceivedOCMShares(req *api.EmptyReq, stream api.OCM_ListReceivedOCMSharesServer) error {
+	ctx := stream.Context()
+	l := ctx_zap.Extract(ctx)
+	shares, err := s.om.ListReceivedOCMShares(ctx)
+	if err != nil {
+		l.Error("error listing received ocm shares", zap.Error(err))
+		return err
+	}
+	for _, share := range shares {
+		if err := stream.Send(&api.OCMShareResponse{Share: share}); err != nil {
+			l.Error("error streaming received ocm share", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *svc) AcceptReceivedOCMShare(ctx context.Context, req *api.OCMShareIDReq) (*api.OCMShareResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	share, err := s.setReceivedShareState(ctx, req.Id, api.OCMShare_ACCEPTED)
+	if err != nil {
+		if api.IsErrorCode(err, api.OCMShareNotFoundErrorCode) {
+			return &api.OCMShareResponse{Status: api.StatusCode_OCM_SHARE_NOT_FOUND}, nil
+		}
+		l.Error("error accepting received ocm share", zap.Error(err))
+		return nil, err
+	}
+	return &api.OCMShareResponse{Share: share}, nil
+}
+
+func (s *svc) RejectReceivedOCMShare(ctx context.Context, req *api.OCMShareIDReq) (*api.EmptyResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	if _, err := s.setReceivedShareState(ctx, req.Id, api.OCMShare_REJECTED); err != nil {
+		if api.IsErrorCode(err, api.OCMShareNotFoundErrorCode) {
+			return &api.EmptyResponse{Status: api.StatusCode_OCM_SHARE_NOT_FOUND}, nil
+		}
+		l.Error("error rejecting received ocm share", zap.Error(err))
+		return nil, err
+	}
+	return &api.EmptyResponse{}, nil
+}
+
+// setReceivedShareState records the answer of the recipient and notifies the owner.
+func (s *svc) setReceivedShareState(ctx context.Context, id string, state api.OCMShare_State) (*api.OCMShare, error) {
+	share, err := s.om.SetReceivedOCMShareState(ctx, id, state)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := s.om.GetReceivedOCMShareSecret(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	notification := ocm.NotificationShareAccepted
+	if state == api.OCMShare_REJECTED {
+		notification = ocm.NotificationShareDeclined
+	}
+	s.notify(ctx, share.Endpoint, notification, share.ProviderId, secret)
+	return share, nil
+}
+
+// AddReceivedOCMShare stores a share sent by a remote provider. The provider of
+// the owner is discovered to find where to access the files and send the
+// notifications, and the secret is checked against it before the share is stored.
+func (s *svc) AddReceivedOCMShare(ctx context.Context, req *api.IncomingOCMShareReq) (*api.OCMShareResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	if req.ShareWith == "" || req.ProviderId == "" || req.SharedSecret == "" {
+		err := api.NewError(api.PathInvalidError).WithMessage("shareWith, providerId and sharedSecret are required")
+		l.Error("", zap.Error(err))
+		return &api.OCMShareResponse{Status: api.GetStatus(err)}, nil
+	}
+	owner, provider, err := ocm.ParseAddress(req.Owner)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return &api.OCMShareResponse{Status: api.GetStatus(err)}, nil
+	}
+	if err := s.checkTrusted(provider); err != nil {
+		l.Error("", zap.Error(err))
+		return &api.OCMShareResponse{Status: api.GetStatus(err)}, nil
+	}
+
+	p, err := s.client.Discover(ctx, provider)
+	if err != nil {
+		l.Error("error discovering provider of owner", zap.Error(err), zap.String("provider", provider))
+		return nil, err
+	}
+	webdavURL, err := p.WebDAVURL(provider)
+	if err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	// the request is not authenticated, only the provider of the
+	// owner can issue a secret that grants access to its files.
+	if err := s.client.CheckSecret(ctx, webdavURL, req.SharedSecret); err != nil {
+		l.Error("error checking secret of received ocm share", zap.Error(err), zap.String("provider", provider))
+		return &api.OCMShareResponse{Status: api.GetStatus(err)}, nil
+	}
+
+	share := &api.OCMShare{
+		OwnerId:     ocm.FormatAddress(owner, provider),
+		Recipient:   req.ShareWith,
+		Name:        req.Name,
+		Permissions: req.Permissions,
+		ProviderId:  req.ProviderId,
+		Endpoint:    p.EndPoint,
+		WebdavUrl:   webdavURL,
+	}
+	share, err = s.om.AddReceivedOCMShare(ctx, share, req.SharedSecret)
+	if err != nil {
+		l.Error("error adding received ocm share", zap.Error(err))
+		return nil, err
+	}
+	return &api.OCMShareResponse{Share: share}, nil
+}
+
+// NotifyOCMShare applies the notifications of the remote providers,
+// they are authenticated by the secret of the share.
+func (s *svc) NotifyOCMShare(ctx context.Context, req *api.OCMNotificationReq) (*api.EmptyResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	var err error
+	switch req.Type {
+	case ocm.NotificationShareAccepted:
+		err = s.om.SetOCMShareState(ctx, req.ProviderId, req.SharedSecret, api.OCMShare_ACCEPTED)
+	case ocm.NotificationShareDeclined:
+		err = s.om.SetOCMShareState(ctx, req.ProviderId, req.SharedSecret, api.OCMShare_REJECTED)
+	case ocm.NotificationShareUnshared:
+		err = s.om.RemoveReceivedOCMShare(ctx, req.ProviderId, req.SharedSecret)
+	default:
+		err = api.NewError(api.StorageNotSupportedErrorCode).WithMessage("unsupported notification: " + req.Type)
+	}
+	if err != nil {
+		if api.IsErrorCode(err, api.OCMShareNotFoundErrorCode) {
+			return &api.EmptyResponse{Status: api.StatusCode_OCM_SHARE_NOT_FOUND}, nil
+		}
+		l.Error("error applying ocm notification", zap.Error(err), zap.String("type", req.Type))
+		return &api.EmptyResponse{Status: api.GetStatus(err)}, nil
+	}
+	return &api.EmptyResponse{}, nil
+}
+
+// notify sends a notification about a share to the remote provider, a failure
+// is only logged as the share has already changed on this side.
+func (s *svc) notify(ctx context.Context, endPoint, notificationType, providerID, secret string) {
+	n := &ocm.Notification{
+		NotificationType: notificationType,
+		ResourceType:     "file",
+		ProviderID:       providerID,
+		Notification:     &ocm.NotificationData{SharedSecret: secret},
+	}
+	if err := s.client.SendNotification(ctx, endPoint, n); err != nil {
+		l := ctx_zap.Extract(ctx)
+		l.Warn("error sending ocm notification", zap.Error(err), zap.String("endpoint", endPoint), zap.String("type", notificationType))
+	}
+}
+
+func (s *svc) revokeLink(ctx context.Context, token string) {
+	l := ctx_zap.Extract(ctx)
+	link, err := s.lm.InspectPublicLinkByToken(ctx, token)
+	if err == nil {
+		err = s.lm.RevokePublicLink(ctx, link.Id)
+	}
+	if err != nil && !api.IsErrorCode(err, api.PublicLinkNotFoundErrorCode) {
+		l.Error("error revoking public link of ocm share", zap.Error(err))
+	}
+}
+
+// isLinkReadOnly returns whether the public link granting the permissions
+// of an ocm share is read-only. The links are either read-only or fully
+// read-write, so the other permissions are refused rather than widened.
+func isLinkReadOnly(permissions api.SharePermissions) (bool, error) {
+	switch permissions {
+	case api.SharePermissionsReadOnly:
+		return true, nil
+	case api.SharePermissionsReadWrite:
+		return false, nil
+	}
+	return false, api.NewError(api.StorageNotSupportedErrorCode).WithMessage(fmt.Sprintf("ocm shares are read-only or read-write, permissions %d are not supported", permissions))
+}
+
+// checkTrusted checks that the provider is trusted, before any request is sent to it.
+func (s *svc) checkTrusted(provider string) error {
+	if !s.trusted[provider] {
+		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("provider not trusted: " + provider)
+	}
+	return nil
+}
+
+// Override the Auth function to avoid checking the bearer token for the requests
+// of the remote providers, that ocproxy forwards, they are authenticated by the
+// secret of the share. The other methods act on behalf of the user logged in.
+// https://github.com/grpc-ecosystem/go-grpc-middleware/tree/master/auth#type-serviceauthfuncoverride
+func (s *svc) AuthFuncOverride(ctx context.Context, fullMethodName string) (context.Context, error) {
+	if strings.HasSuffix(fullMethodName, "/AddReceivedOCMShare") || strings.HasSuffix(fullMethodName, "/NotifyOCMShare") {
+		return ctx, nil
+	}
+
+	token, err := grpc_auth.AuthFromMD(ctx, "user-bearer")
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.tm.DismantleUserToken(ctx, token)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "invalid user auth token: %v", err)
+	}
+	return api.ContextSetUser(ctx, user), nil
+}
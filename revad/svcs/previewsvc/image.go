@@ -0,0 +1,153 @@
+package previewsvc
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"net/http"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/disintegration/imaging"
+	"github.com/rwcarlsen/goexif/exif"
+)
+
+// formats are the image formats with a preview, by the name
+// registered in the image package.
+var formats = map[string]imaging.Format{
+	"jpeg": imaging.JPEG,
+	"png":  imaging.PNG,
+	"gif":  imaging.GIF,
+	"bmp":  imaging.BMP,
+}
+
+// generate returns the preview of the file, encoded in the same format
+// for images and in PNG for the text and PDF files rendered to an image.
+// Images larger than maxPixels have no preview.
+func generate(name string, data []byte, maxPixels int, width, height int, mode api.PreviewReq_Mode) ([]byte, error) {
+	img, format, err := decode(name, data, maxPixels)
+	if err != nil {
+		return nil, err
+	}
+
+	if mode == api.PreviewReq_FIT {
+		img = imaging.Fit(img, width, height, imaging.Linear)
+	} else {
+		img = imaging.Thumbnail(img, width, height, imaging.Linear)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := imaging.Encode(buf, img, format); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decode returns the image to preview and the format of the preview.
+// The image format is detected from the content, so it does not depend
+// on the name or the mime type of the file, the first page of the PDF
+// files and the other files are rendered when possible.
+func decode(name string, data []byte, maxPixels int) (image.Image, imaging.Format, error) {
+	cfg, imageFormat, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		if http.DetectContentType(data) == "application/pdf" {
+			img, err := renderPDF(data)
+			return img, imaging.PNG, err
+		}
+		img, err := renderText(name, data)
+		return img, imaging.PNG, err
+	}
+	format, ok := formats[imageFormat]
+	if !ok {
+		return nil, 0, api.NewError(api.PreviewNotSupportedErrorCode).WithMessage("unsupported image format " + imageFormat)
+	}
+	// a small file can declare a huge image, the size is checked
+	// from the header before allocating the decoded pixels.
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width > maxPixels/cfg.Height {
+		return nil, 0, api.NewError(api.PreviewNotSupportedErrorCode).WithMessage(fmt.Sprintf("image of %dx%d pixels is too large", cfg.Width, cfg.Height))
+	}
+
+	img, err := imaging.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, api.NewError(api.PreviewNotSupportedErrorCode).WithMessage(err.Error())
+	}
+
+	// the orientation is applied before resizing, otherwise
+	// the width and height would be swapped for rotated photos.
+	if ex, err := exif.Decode(bytes.NewReader(data)); err == nil {
+		rotate, flip := exifOrientation(ex)
+		if rotate != 0 {
+			img = imaging.Rotate(img, float64(rotate), color.Transparent)
+		}
+		if flip == FlipVertical {
+			img = imaging.FlipV(img)
+		} else if flip == FlipHorizontal {
+			img = imaging.FlipH(img)
+		}
+	}
+	return img, format, nil
+}
+
+// exifOrientation parses the  EXIF data in r and returns the stored
+// orientation as the angle and flip necessary to transform the image.
+func exifOrientation(ex *exif.Exif) (int, FlipDirection) {
+	var (
+		angle    int
+		flipMode FlipDirection
+	)
+	tag, err := ex.Get(exif.Orientation)
+	if err != nil {
+		return 0, 0
+	}
+	orient, err := tag.Int(0)
+	if err != nil {
+		return 0, 0
+	}
+	switch orient {
+	case topLeftSide:
+		// do nothing
+	case topRightSide:
+		flipMode = 2
+	case bottomRightSide:
+		angle = 180
+	case bottomLeftSide:
+		angle = 180
+		flipMode = 2
+	case leftSideTop:
+		angle = -90
+		flipMode = 2
+	case rightSideTop:
+		angle = -90
+	case rightSideBottom:
+		angle = 90
+		flipMode = 2
+	case leftSideBottom:
+		angle = 90
+	}
+	return angle, flipMode
+}
+
+// Exif Orientation Tag values
+// http://sylvana.net/jpegcrop/exif_orientation.html
+const (
+	topLeftSide     = 1
+	topRightSide    = 2
+	bottomRightSide = 3
+	bottomLeftSide  = 4
+	leftSideTop     = 5
+	rightSideTop    = 6
+	rightSideBottom = 7
+	leftSideBottom  = 8
+)
+
+// The FlipDirection type is used by the Flip option in DecodeOpts
+// to indicate in which direction to flip an image.
+type FlipDirection int
+
+// FlipVertical and FlipHorizontal are two possible FlipDirections
+// values to indicate in which direction an image will be flipped.
+const (
+	FlipVertical FlipDirection = 1 << iota
+	FlipHorizontal
+)
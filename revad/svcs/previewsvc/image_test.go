@@ -0,0 +1,167 @@
+package previewsvc
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/disintegration/imaging"
+)
+
+const maxPixels = 1000 * 1000
+
+func TestGenerate(t *testing.T) {
+	buf := &bytes.Buffer{}
+	src := imaging.New(200, 100, color.White)
+	if err := imaging.Encode(buf, src, imaging.PNG); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		mode          api.PreviewReq_Mode
+		width, height int
+	}{
+		{api.PreviewReq_CROP, 32, 32},
+		{api.PreviewReq_FIT, 32, 16},
+	}
+	for _, test := range tests {
+		preview, err := generate("image.png", buf.Bytes(), maxPixels, 32, 32, test.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(preview))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if format != "png" || cfg.Width != test.width || cfg.Height != test.height {
+			t.Errorf("%s: got %s %dx%d, expected png %dx%d", test.mode, format, cfg.Width, cfg.Height, test.width, test.height)
+		}
+	}
+
+	// the size is checked before decoding
+	large := &bytes.Buffer{}
+	if err := imaging.Encode(large, imaging.New(2000, 1000, color.White), imaging.PNG); err != nil {
+		t.Fatal(err)
+	}
+	_, err := generate("large.png", large.Bytes(), maxPixels, 32, 32, api.PreviewReq_CROP)
+	if !api.IsErrorCode(err, api.PreviewNotSupportedErrorCode) {
+		t.Errorf("expected preview not supported for a large image, got %v", err)
+	}
+
+	_, err = generate("file.bin", []byte("\x00\x01\x02"), maxPixels, 32, 32, api.PreviewReq_CROP)
+	if !api.IsErrorCode(err, api.PreviewNotSupportedErrorCode) {
+		t.Errorf("expected preview not supported, got %v", err)
+	}
+}
+
+func TestGenerateText(t *testing.T) {
+	notebook := `{"cells": [
+		{"cell_type": "markdown", "source": ["# Analysis\n", "of the data"]},
+		{"cell_type": "code", "source": "import ROOT\nf = ROOT.TFile(\"data.root\")"}
+	]}`
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"notes.txt", "first line\n\tindented line\n"},
+		{"main.go", "package main\n\nfunc main() {}\n"},
+		{"README.md", "# Title\n\nSome *text*.\n"},
+		{"data.json", `{"a": [1, 2, 3]}`},
+		{"analysis.ipynb", notebook},
+		{"Makefile", "all:\n\tgo build\n"},
+	}
+	for _, test := range tests {
+		preview, err := generate(test.name, []byte(test.data), maxPixels, 64, 64, api.PreviewReq_CROP)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(preview))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if format != "png" || cfg.Width != 64 || cfg.Height != 64 {
+			t.Errorf("%s: got %s %dx%d, expected png 64x64", test.name, format, cfg.Width, cfg.Height)
+		}
+	}
+
+	// the PDF files are not rendered as text, even if they are plain ASCII
+	unsupported := map[string]string{
+		"broken.ipynb": "{",
+		"broken.pdf":   "%PDF-1.4\n1 0 obj\n<< /Type /Catalog >>\nendobj\n",
+		"empty.pdf":    string(newPDF("")),
+	}
+	for name, data := range unsupported {
+		_, err := generate(name, []byte(data), maxPixels, 64, 64, api.PreviewReq_CROP)
+		if !api.IsErrorCode(err, api.PreviewNotSupportedErrorCode) {
+			t.Errorf("%s: expected preview not supported, got %v", name, err)
+		}
+	}
+}
+
+func TestGeneratePDF(t *testing.T) {
+	data := newPDF("BT /F1 24 Tf 72 720 Td (Analysis) Tj ET 72 600 300 50 re S")
+	preview, err := generate("paper.pdf", data, maxPixels, 64, 64, api.PreviewReq_FIT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, format, err := image.Decode(bytes.NewReader(preview))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the preview keeps the proportions of the letter page
+	if format != "png" || img.Bounds().Dx() != 49 || img.Bounds().Dy() != 64 {
+		t.Fatalf("got %s %v, expected png 49x64", format, img.Bounds())
+	}
+
+	// the text is drawn at the top left of the page
+	page, err := renderPDF(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasInk(page, image.Rect(100, 80, 300, 140)) {
+		t.Error("expected the text to be drawn at the top of the page")
+	}
+	if hasInk(page, image.Rect(0, 1000, 900, 1150)) {
+		t.Error("expected the bottom of the page to be blank")
+	}
+}
+
+func hasInk(img image.Image, r image.Rectangle) bool {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if r, g, b, _ := img.At(x, y).RGBA(); r != 0xffff || g != 0xffff || b != 0xffff {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newPDF returns a PDF file of a letter page drawn by the content stream.
+func newPDF(content string) []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 /MediaBox [0 0 612 792] >>",
+		"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 5 0 R >> >> /Contents 4 0 R >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content)+1, content),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString("%PDF-1.4\n")
+	offsets := []int{}
+	for i, obj := range objects {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
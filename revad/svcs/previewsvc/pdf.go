@@ -0,0 +1,116 @@
+package previewsvc
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/disintegration/imaging"
+	"github.com/ledongthuc/pdf"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/inconsolata"
+	"golang.org/x/image/math/fixed"
+)
+
+// the first page of a PDF is rendered pdfWidth pixels wide, with the text
+// and the rectangles drawn on it. The images and the other shapes are left
+// out, there is no full PDF renderer in pure Go.
+const (
+	pdfWidth = 900
+	// pdfLargeText is the size in pixels from which the text is drawn in bold
+	pdfLargeText = 16
+)
+
+var (
+	pdfRectColor = color.RGBA{0xcc, 0xcc, 0xcc, 0xff}
+	// letterBox is the size in points of the pages without a valid MediaBox
+	letterBox = [4]float64{0, 0, 612, 792}
+)
+
+// renderPDF renders the first page of a PDF file to an image.
+func renderPDF(data []byte) (img image.Image, err error) {
+	// the PDF parser panics on malformed files
+	defer func() {
+		if r := recover(); r != nil {
+			img, err = nil, api.NewError(api.PreviewNotSupportedErrorCode).WithMessage(fmt.Sprintf("invalid PDF file: %v", r))
+		}
+	}()
+
+	r, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, api.NewError(api.PreviewNotSupportedErrorCode).WithMessage("invalid PDF file: " + err.Error())
+	}
+	if r.NumPage() < 1 {
+		return nil, api.NewError(api.PreviewNotSupportedErrorCode).WithMessage("PDF file without pages")
+	}
+	page := r.Page(1)
+	content := page.Content()
+	if len(content.Text) == 0 && len(content.Rect) == 0 {
+		return nil, api.NewError(api.PreviewNotSupportedErrorCode).WithMessage("no text or shapes on the first page of the PDF file")
+	}
+
+	box := getMediaBox(page)
+	scale := pdfWidth / (box[2] - box[0])
+	height := int((box[3] - box[1]) * scale)
+	// a very tall page is cut, the preview is cropped or fitted afterwards anyway
+	if height > 4*pdfWidth {
+		height = 4 * pdfWidth
+	}
+	// the PDF coordinates start at the bottom left of the page
+	toImage := func(x, y float64) (int, int) {
+		return int((x - box[0]) * scale), height - int((y-box[1])*scale)
+	}
+
+	canvas := imaging.New(pdfWidth, height, color.White)
+	for _, rect := range content.Rect {
+		x0, y0 := toImage(rect.Min.X, rect.Max.Y)
+		x1, y1 := toImage(rect.Max.X, rect.Min.Y)
+		drawOutline(canvas, image.Rect(x0, y0, x1, y1).Canon().Intersect(canvas.Bounds()), pdfRectColor)
+	}
+	// every character of the text is placed on its own, so the
+	// fixed width faces do not shift the words of the page.
+	for _, t := range content.Text {
+		face := font.Face(basicfont.Face7x13)
+		if t.FontSize*scale >= pdfLargeText {
+			face = inconsolata.Bold8x16
+		}
+		x, y := toImage(t.X, t.Y)
+		d := &font.Drawer{Dst: canvas, Src: image.NewUniform(textColor), Face: face, Dot: fixed.P(x, y)}
+		d.DrawString(t.S)
+	}
+	return canvas, nil
+}
+
+// getMediaBox returns the box of the page in points, as llx lly urx ury,
+// inherited from the parent pages if not set on the page.
+func getMediaBox(page pdf.Page) [4]float64 {
+	for v := page.V; !v.IsNull(); v = v.Key("Parent") {
+		mb := v.Key("MediaBox")
+		if mb.Kind() != pdf.Array || mb.Len() != 4 {
+			continue
+		}
+		box := [4]float64{}
+		for i := range box {
+			box[i] = mb.Index(i).Float64()
+		}
+		if box[2]-box[0] < 1 || box[3]-box[1] < 1 {
+			return letterBox
+		}
+		return box
+	}
+	return letterBox
+}
+
+func drawOutline(img *image.NRGBA, r image.Rectangle, c color.Color) {
+	if r.Empty() {
+		return
+	}
+	drawRect(img, image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+1), c)
+	drawRect(img, image.Rect(r.Min.X, r.Max.Y-1, r.Max.X, r.Max.Y), c)
+	drawRect(img, image.Rect(r.Min.X, r.Min.Y, r.Min.X+1, r.Max.Y), c)
+	drawRect(img, image.Rect(r.Max.X-1, r.Min.Y, r.Max.X, r.Max.Y), c)
+}
@@ -1,15 +1,189 @@
 package previewsvc
 
 import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"time"
+
 	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+var (
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "reva_preview_cache_requests_total",
+		Help: "Number of previews looked up in the cache, by result.",
+	}, []string{"result"})
+
+	generationDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name: "reva_preview_generation_seconds",
+		Help: "Time spent reading the files and generating their previews.",
+	})
 )
 
-func New() api.PreviewServer {
-	return new(svc)
+func init() {
+	prometheus.MustRegister(cacheRequests, generationDuration)
+}
+
+// maxDimension is the largest width or height of a preview.
+const maxDimension = 4096
+
+// New returns the service that generates the previews of the files
+// of vs. Files larger than maxFileSize bytes and images larger than
+// maxPixels pixels have no preview.
+// The previews are stored in cache when not nil, and only
+// the admins can purge all the cached previews.
+func New(vs api.VirtualStorage, cache api.PreviewCache, maxFileSize int64, maxPixels int, admins []string) api.PreviewServer {
+	m := map[string]bool{}
+	for _, a := range admins {
+		m[a] = true
+	}
+	return &svc{vs: vs, cache: cache, maxFileSize: maxFileSize, maxPixels: maxPixels, admins: m}
+}
+
+type svc struct {
+	vs          api.VirtualStorage
+	cache       api.PreviewCache
+	maxFileSize int64
+	maxPixels   int
+	admins      map[string]bool
 }
 
-type svc struct{}
+func (s *svc) ReadPreview(req *api.PreviewReq, stream api.Preview_ReadPreviewServer) error {
+	ctx := stream.Context()
+	l := ctx_zap.Extract(ctx)
+
+	if req.Width == 0 || req.Height == 0 || req.Width > maxDimension || req.Height > maxDimension {
+		err := api.NewError(api.PathInvalidError).WithMessage(fmt.Sprintf("preview size must be between 1 and %d pixels", maxDimension))
+		l.Error("", zap.Error(err))
+		return stream.Send(&api.DataChunkResponse{Status: api.GetStatus(err)})
+	}
+
+	md, err := s.vs.GetMetadata(ctx, req.Path)
+	if err != nil {
+		l.Error("error getting metadata", zap.Error(err))
+		return stream.Send(&api.DataChunkResponse{Status: api.GetStatus(err)})
+	}
+	if md.IsDir || int64(md.Size) > s.maxFileSize {
+		err := api.NewError(api.PreviewNotSupportedErrorCode).WithMessage("no preview for " + req.Path)
+		l.Warn("", zap.Error(err), zap.Bool("dir", md.IsDir), zap.Uint64("size", md.Size))
+		return stream.Send(&api.DataChunkResponse{Status: api.GetStatus(err)})
+	}
 
-func (s *svc) ReadPreview(req *api.PathReq, stream api.Preview_ReadPreviewServer) error {
+	variant := fmt.Sprintf("%dx%d-%s", req.Width, req.Height, req.Mode)
+	preview, ok := s.getCached(ctx, md, variant)
+	if !ok {
+		preview, err = s.generate(ctx, req)
+		if err != nil {
+			l.Warn("error generating preview", zap.Error(err), zap.String("path", req.Path))
+			return stream.Send(&api.DataChunkResponse{Status: api.GetStatus(err)})
+		}
+		s.putCached(ctx, md, variant, preview)
+	}
+
+	// send data chunks of maximum 3 MiB
+	reader := bytes.NewReader(preview)
+	buffer := make([]byte, 1024*1024*3)
+	for {
+		n, _ := reader.Read(buffer)
+		if n == 0 {
+			break
+		}
+		dc := &api.DataChunk{Data: buffer[:n], Length: uint64(n)}
+		if err := stream.Send(&api.DataChunkResponse{DataChunk: dc}); err != nil {
+			l.Error("", zap.Error(err))
+			return err
+		}
+	}
 	return nil
 }
+
+func (s *svc) generate(ctx context.Context, req *api.PreviewReq) ([]byte, error) {
+	l := ctx_zap.Extract(ctx)
+	start := time.Now()
+	readCloser, err := s.vs.Download(ctx, req.Path)
+	if err != nil {
+		l.Error("error reading file from fs", zap.Error(err))
+		return nil, err
+	}
+	defer readCloser.Close()
+	data, err := ioutil.ReadAll(readCloser)
+	if err != nil {
+		l.Error("error reading file from fs", zap.Error(err))
+		return nil, err
+	}
+
+	preview, err := generate(req.Path, data, s.maxPixels, int(req.Width), int(req.Height), req.Mode)
+	if err != nil {
+		return nil, err
+	}
+	generationDuration.Observe(time.Since(start).Seconds())
+	l.Info("preview generated", zap.String("path", req.Path), zap.Int("size", len(preview)))
+	return preview, nil
+}
+
+func (s *svc) getCached(ctx context.Context, md *api.Metadata, variant string) ([]byte, bool) {
+	if s.cache == nil {
+		return nil, false
+	}
+	preview, ok := s.cache.Get(ctx, md.Id, md.Etag, variant)
+	if ok {
+		cacheRequests.WithLabelValues("hit").Inc()
+	} else {
+		cacheRequests.WithLabelValues("miss").Inc()
+	}
+	return preview, ok
+}
+
+// putCached fails open, the preview is still sent if it cannot be cached.
+func (s *svc) putCached(ctx context.Context, md *api.Metadata, variant string, preview []byte) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.Put(ctx, md.Id, md.Etag, variant, preview); err != nil {
+		l := ctx_zap.Extract(ctx)
+		l.Error("error caching preview", zap.Error(err), zap.String("id", md.Id))
+	}
+}
+
+// PurgePreviews removes the cached previews of a path the user can access,
+// or of all the files for the admins when the path is empty.
+func (s *svc) PurgePreviews(ctx context.Context, req *api.PathReq) (*api.EmptyResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	if s.cache == nil {
+		return &api.EmptyResponse{}, nil
+	}
+
+	fileID := ""
+	if req.Path == "" {
+		u, ok := api.ContextGetUser(ctx)
+		if !ok {
+			err := api.NewError(api.ContextUserRequiredError)
+			l.Error("", zap.Error(err))
+			return nil, err
+		}
+		if !s.admins[u.AccountId] || api.IsUserRestricted(u) {
+			err := api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("all the previews can only be purged by admins")
+			l.Error("", zap.Error(err))
+			return &api.EmptyResponse{Status: api.GetStatus(err)}, nil
+		}
+	} else {
+		md, err := s.vs.GetMetadata(ctx, req.Path)
+		if err != nil {
+			l.Error("error getting metadata", zap.Error(err))
+			return &api.EmptyResponse{Status: api.GetStatus(err)}, nil
+		}
+		fileID = md.Id
+	}
+
+	if err := s.cache.Purge(ctx, fileID); err != nil {
+		l.Error("error purging previews", zap.Error(err))
+		return nil, err
+	}
+	return &api.EmptyResponse{}, nil
+}
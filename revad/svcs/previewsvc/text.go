@@ -0,0 +1,198 @@
+package previewsvc
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"net/http"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/disintegration/imaging"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/inconsolata"
+	"golang.org/x/image/math/fixed"
+)
+
+// the text is rendered on a page of textColumns x textLines characters,
+// that is resized afterwards like any other image.
+const (
+	textColumns = 80
+	textLines   = 40
+	textMargin  = 16
+	tabWidth    = 4
+)
+
+var (
+	textColor    = color.RGBA{0x33, 0x33, 0x33, 0xff}
+	headingColor = color.RGBA{0x00, 0x00, 0x00, 0xff}
+	codeColor    = color.RGBA{0x1a, 0x4d, 0x99, 0xff}
+	codeFill     = color.RGBA{0xf2, 0xf2, 0xf2, 0xff}
+)
+
+type textStyle int
+
+const (
+	styleText textStyle = iota
+	styleHeading
+	styleCode
+)
+
+type textLine struct {
+	text  string
+	style textStyle
+}
+
+// renderText renders the first lines of a text, Markdown, JSON
+// or notebook file to an image.
+func renderText(name string, data []byte) (image.Image, error) {
+	lines, err := getTextLines(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	face := inconsolata.Regular8x16
+	bold := inconsolata.Bold8x16
+	width := 2*textMargin + textColumns*face.Advance
+	lineHeight := face.Height
+	img := imaging.New(width, 2*textMargin+textLines*lineHeight, color.White)
+
+	for i, line := range lines {
+		top := textMargin + i*lineHeight
+		d := &font.Drawer{Dst: img, Src: image.NewUniform(textColor), Face: face}
+		switch line.style {
+		case styleHeading:
+			d.Src = image.NewUniform(headingColor)
+			d.Face = bold
+		case styleCode:
+			d.Src = image.NewUniform(codeColor)
+			fill := image.Rect(textMargin/2, top, width-textMargin/2, top+lineHeight)
+			drawRect(img, fill, codeFill)
+		}
+		d.Dot = fixed.P(textMargin, top+face.Ascent)
+		d.DrawString(line.text)
+	}
+	return img, nil
+}
+
+func drawRect(img *image.NRGBA, r image.Rectangle, c color.Color) {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.Set(x, y, c)
+		}
+	}
+}
+
+// getTextLines returns the lines to render, at most textLines
+// of at most textColumns characters.
+func getTextLines(name string, data []byte) ([]*textLine, error) {
+	lines := []*textLine{}
+	switch ext := path.Ext(name); {
+	case ext == ".ipynb":
+		cells, err := getNotebookCells(data)
+		if err != nil {
+			return nil, api.NewError(api.PreviewNotSupportedErrorCode).WithMessage("invalid notebook: " + err.Error())
+		}
+		for _, cell := range cells {
+			style := styleText
+			if cell.CellType == "code" {
+				style = styleCode
+			}
+			for _, l := range splitLines(cell.source) {
+				if style == styleText && strings.HasPrefix(l, "#") {
+					lines = append(lines, &textLine{text: l, style: styleHeading})
+				} else {
+					lines = append(lines, &textLine{text: l, style: style})
+				}
+			}
+			lines = append(lines, &textLine{})
+		}
+	case ext == ".md" || ext == ".markdown":
+		for _, l := range splitLines(string(data)) {
+			if strings.HasPrefix(l, "#") {
+				lines = append(lines, &textLine{text: l, style: styleHeading})
+			} else {
+				lines = append(lines, &textLine{text: l})
+			}
+		}
+	case ext == ".json":
+		buf := &bytes.Buffer{}
+		if err := json.Indent(buf, data, "", "  "); err == nil {
+			data = buf.Bytes()
+		}
+		lines = getPlainLines(data)
+	// the files without a known extension are previewed if their content is text
+	case api.IsTextFile(name) || isText(data):
+		lines = getPlainLines(data)
+	default:
+		return nil, api.NewError(api.PreviewNotSupportedErrorCode).WithMessage("no preview for the type of " + name)
+	}
+
+	if len(lines) > textLines {
+		lines = lines[:textLines]
+	}
+	for _, l := range lines {
+		if utf8.RuneCountInString(l.text) > textColumns {
+			l.text = string([]rune(l.text)[:textColumns])
+		}
+	}
+	return lines, nil
+}
+
+func getPlainLines(data []byte) []*textLine {
+	lines := []*textLine{}
+	for _, l := range splitLines(string(data)) {
+		lines = append(lines, &textLine{text: l})
+	}
+	return lines
+}
+
+// splitLines returns the first lines of s, with the tabs expanded
+// and the invalid UTF-8 sequences replaced.
+func splitLines(s string) []string {
+	s = strings.ToValidUTF8(s, "�")
+	lines := strings.SplitN(strings.Replace(s, "\r\n", "\n", -1), "\n", textLines+1)
+	if len(lines) > textLines {
+		lines = lines[:textLines]
+	}
+	for i, l := range lines {
+		lines[i] = strings.Replace(l, "\t", strings.Repeat(" ", tabWidth), -1)
+	}
+	return lines
+}
+
+func isText(data []byte) bool {
+	return strings.HasPrefix(http.DetectContentType(data), "text/plain")
+}
+
+type notebookCell struct {
+	CellType string          `json:"cell_type"`
+	Source   json.RawMessage `json:"source"`
+	source   string
+}
+
+// getNotebookCells returns the cells of a Jupyter notebook,
+// whose source is either a string or a list of lines.
+func getNotebookCells(data []byte) ([]*notebookCell, error) {
+	nb := &struct {
+		Cells []*notebookCell `json:"cells"`
+	}{}
+	if err := json.Unmarshal(data, nb); err != nil {
+		return nil, err
+	}
+	for _, cell := range nb.Cells {
+		var lines []string
+		if err := json.Unmarshal(cell.Source, &lines); err == nil {
+			cell.source = strings.Join(lines, "")
+			continue
+		}
+		if err := json.Unmarshal(cell.Source, &cell.source); err != nil {
+			return nil, err
+		}
+	}
+	return nb.Cells, nil
+}
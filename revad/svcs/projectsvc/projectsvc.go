@@ -0,0 +1,364 @@
+package projectsvc
+
+import (
+	"path"
+	"strings"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// New returns the service to provision the project spaces under root, the
+// path of the projects in the virtual storage. Only the admins can manage
+// the projects.
+func New(pm api.ProjectManager, vs api.VirtualStorage, admins []string, root string) api.ProjectServer {
+	m := map[string]bool{}
+	for _, a := range admins {
+		m[a] = true
+	}
+	return &svc{projectManager: pm, vs: vs, admins: m, root: root}
+}
+
+type svc struct {
+	projectManager api.ProjectManager
+	vs             api.VirtualStorage
+	admins         map[string]bool
+	root           string
+}
+
+// CreateProject records the project and creates its directory owned by the
+// owner, with the ACLs of its groups and its quota. The project is forgotten
+// if it cannot be provisioned, so that creating it can be tried again.
+func (s *svc) CreateProject(ctx context.Context, req *api.NewProjectReq) (*api.ProjectResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	if err := s.checkAdmin(ctx); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	if req.Project == nil {
+		err := api.NewError(api.ProjectInvalidErrorCode).WithMessage("missing project")
+		l.Error("", zap.Error(err))
+		return &api.ProjectResponse{Status: api.GetStatus(err)}, nil
+	}
+
+	p := &api.Project{
+		Name:         req.Project.Name,
+		Path:         req.Project.Path,
+		Owner:        req.Project.Owner,
+		AdminGroup:   req.Project.AdminGroup,
+		WritersGroup: req.Project.WritersGroup,
+		ReadersGroup: req.Project.ReadersGroup,
+	}
+	if err := validate(p); err != nil {
+		l.Error("", zap.Error(err))
+		return &api.ProjectResponse{Status: api.GetStatus(err)}, nil
+	}
+
+	project, err := s.projectManager.CreateProject(ctx, p)
+	if err != nil {
+		l.Error("error creating project", zap.Error(err), zap.String("name", p.Name))
+		if api.IsErrorCode(err, api.ProjectAlreadyExistsErrorCode) || api.IsErrorCode(err, api.ProjectInvalidErrorCode) {
+			return &api.ProjectResponse{Status: api.GetStatus(err)}, nil
+		}
+		return nil, err
+	}
+
+	if err := s.provision(ctx, project, req.Quota); err != nil {
+		l.Error("error provisioning project, forgetting it", zap.Error(err), zap.String("name", project.Name))
+		if err := s.projectManager.DeleteProject(ctx, project.Name); err != nil {
+			l.Error("error forgetting project", zap.Error(err), zap.String("name", project.Name))
+		}
+		return &api.ProjectResponse{Status: api.GetStatus(err)}, nil
+	}
+	l.Info("project created", zap.String("name", project.Name), zap.String("path", project.Path), zap.String("owner", project.Owner))
+	return &api.ProjectResponse{Project: toProjectSpace(project)}, nil
+}
+
+// UpdateProject changes the owner and the groups set in the request, and the
+// ACLs of the groups are all set again, so an update that failed on the
+// storage is completed by running it again.
+func (s *svc) UpdateProject(ctx context.Context, req *api.UpdateProjectReq) (*api.ProjectResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	if err := s.checkAdmin(ctx); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	old, err := s.projectManager.GetProject(ctx, req.Name)
+	if err != nil {
+		l.Error("error getting project", zap.Error(err), zap.String("name", req.Name))
+		if api.IsErrorCode(err, api.ProjectNotFoundErrorCode) {
+			return &api.ProjectResponse{Status: api.GetStatus(err)}, nil
+		}
+		return nil, err
+	}
+
+	// the managers may hand out the project they keep
+	oldACLs := groupACLs(old)
+	p := *old
+	if req.Owner != "" {
+		p.Owner = req.Owner
+	}
+	if req.AdminGroup != "" {
+		p.AdminGroup = req.AdminGroup
+	}
+	if req.WritersGroup != "" {
+		p.WritersGroup = req.WritersGroup
+	}
+	if req.ReadersGroup != "" {
+		p.ReadersGroup = req.ReadersGroup
+	}
+	project, err := s.projectManager.UpdateProject(ctx, &p)
+	if err != nil {
+		l.Error("error updating project", zap.Error(err), zap.String("name", req.Name))
+		if api.IsErrorCode(err, api.ProjectNotFoundErrorCode) || api.IsErrorCode(err, api.ProjectInvalidErrorCode) {
+			return &api.ProjectResponse{Status: api.GetStatus(err)}, nil
+		}
+		return nil, err
+	}
+
+	dir := s.getPath(project)
+	if req.Owner != "" {
+		if err := s.provisionDir(ctx, project.Owner, dir); err != nil {
+			l.Error("error changing owner of project", zap.Error(err), zap.String("name", project.Name))
+			return &api.ProjectResponse{Status: api.GetStatus(err)}, nil
+		}
+	}
+	for _, acl := range oldACLs {
+		if isGroupOf(project, acl.Identity) {
+			continue
+		}
+		if err := s.vs.UnsetACL(ownerContext(ctx, project), dir, acl, []*api.FolderShare{}); err != nil {
+			l.Error("error unsetting acl of project group", zap.Error(err), zap.String("name", project.Name), zap.String("group", acl.Identity))
+			return &api.ProjectResponse{Status: api.GetStatus(err)}, nil
+		}
+	}
+	if err := s.setACLs(ctx, project); err != nil {
+		return &api.ProjectResponse{Status: api.GetStatus(err)}, nil
+	}
+	if req.UpdateQuota {
+		if err := s.setQuota(ctx, project, req.Quota); err != nil {
+			return &api.ProjectResponse{Status: api.GetStatus(err)}, nil
+		}
+	}
+	l.Info("project updated", zap.String("name", project.Name), zap.String("owner", project.Owner))
+	return &api.ProjectResponse{Project: toProjectSpace(project)}, nil
+}
+
+// DeleteProject forgets the project. Its files are kept without the ACLs
+// of the groups, unless delete_files is set.
+func (s *svc) DeleteProject(ctx context.Context, req *api.DeleteProjectReq) (*api.EmptyResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	if err := s.checkAdmin(ctx); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	project, err := s.projectManager.GetProject(ctx, req.Name)
+	if err != nil {
+		l.Error("error getting project", zap.Error(err), zap.String("name", req.Name))
+		if api.IsErrorCode(err, api.ProjectNotFoundErrorCode) {
+			return &api.EmptyResponse{Status: api.GetStatus(err)}, nil
+		}
+		return nil, err
+	}
+
+	dir := s.getPath(project)
+	if req.DeleteFiles {
+		if err := s.vs.Delete(ownerContext(ctx, project), dir); err != nil && !api.IsErrorCode(err, api.StorageNotFoundErrorCode) {
+			l.Error("error deleting files of project", zap.Error(err), zap.String("name", project.Name))
+			return &api.EmptyResponse{Status: api.GetStatus(err)}, nil
+		}
+	} else {
+		for _, acl := range groupACLs(project) {
+			if err := s.vs.UnsetACL(ownerContext(ctx, project), dir, acl, []*api.FolderShare{}); err != nil && !api.IsErrorCode(err, api.StorageNotFoundErrorCode) {
+				l.Error("error unsetting acl of project group", zap.Error(err), zap.String("name", project.Name), zap.String("group", acl.Identity))
+				return &api.EmptyResponse{Status: api.GetStatus(err)}, nil
+			}
+		}
+	}
+
+	if err := s.projectManager.DeleteProject(ctx, project.Name); err != nil {
+		l.Error("error deleting project", zap.Error(err), zap.String("name", project.Name))
+		return nil, err
+	}
+	l.Info("project deleted", zap.String("name", project.Name), zap.Bool("delete_files", req.DeleteFiles))
+	return &api.EmptyResponse{}, nil
+}
+
+func (s *svc) GetProject(ctx context.Context, req *api.ProjectReq) (*api.ProjectResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	if err := s.checkAdmin(ctx); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	project, err := s.projectManager.GetProject(ctx, req.Name)
+	if err != nil {
+		l.Error("error getting project", zap.Error(err), zap.String("name", req.Name))
+		if api.IsErrorCode(err, api.ProjectNotFoundErrorCode) {
+			return &api.ProjectResponse{Status: api.GetStatus(err)}, nil
+		}
+		return nil, err
+	}
+	return &api.ProjectResponse{Project: toProjectSpace(project)}, nil
+}
+
+func (s *svc) ListProjects(req *api.EmptyReq, stream api.Project_ListProjectsServer) error {
+	ctx := stream.Context()
+	l := ctx_zap.Extract(ctx)
+	if err := s.checkAdmin(ctx); err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	projects, err := s.projectManager.GetAllProjects(ctx)
+	if err != nil {
+		l.Error("error listing projects", zap.Error(err))
+		return err
+	}
+	for _, project := range projects {
+		if err := stream.Send(&api.ProjectResponse{Project: toProjectSpace(project)}); err != nil {
+			l.Error("error streaming project", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// provision creates the directory of the project with the ACLs of its
+// groups and limits its size if quota is not 0.
+func (s *svc) provision(ctx context.Context, project *api.Project, quota uint64) error {
+	l := ctx_zap.Extract(ctx)
+	if err := s.provisionDir(ctx, project.Owner, s.getPath(project)); err != nil {
+		l.Error("error creating directory of project", zap.Error(err), zap.String("name", project.Name))
+		return err
+	}
+	if err := s.setACLs(ctx, project); err != nil {
+		return err
+	}
+	if quota > 0 {
+		return s.setQuota(ctx, project, quota)
+	}
+	return nil
+}
+
+// provisionDir creates dir for the owner. The storages that cannot create
+// directories for other users get it created by the owner, the parent of
+// dir must then exist.
+func (s *svc) provisionDir(ctx context.Context, owner, dir string) error {
+	ps, ok := s.vs.(api.ProvisioningStorage)
+	if ok {
+		err := ps.ProvisionDir(ctx, owner, dir)
+		if !api.IsErrorCode(err, api.StorageNotSupportedErrorCode) {
+			return err
+		}
+	}
+
+	ownerCtx := api.ContextSetUser(ctx, &api.User{AccountId: owner})
+	if _, err := s.vs.GetMetadata(ownerCtx, dir); err == nil {
+		return nil
+	} else if !api.IsErrorCode(err, api.StorageNotFoundErrorCode) {
+		return err
+	}
+	return s.vs.CreateDir(ownerCtx, dir)
+}
+
+// setACLs grants the admins of the project all the permissions, its writers
+// read and write access and its readers read access.
+func (s *svc) setACLs(ctx context.Context, project *api.Project) error {
+	l := ctx_zap.Extract(ctx)
+	dir := s.getPath(project)
+	permissions := []api.SharePermissions{api.SharePermissionsAll, api.SharePermissionsReadWrite, api.SharePermissionsReadOnly}
+	for i, group := range []string{project.AdminGroup, project.WritersGroup, project.ReadersGroup} {
+		if group == "" {
+			continue
+		}
+		recipient := &api.ShareRecipient{Identity: group, Type: api.ShareRecipient_GROUP}
+		if err := s.vs.SetACL(ownerContext(ctx, project), dir, permissions[i], recipient, []*api.FolderShare{}); err != nil {
+			l.Error("error setting acl of project group", zap.Error(err), zap.String("name", project.Name), zap.String("group", group))
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *svc) setQuota(ctx context.Context, project *api.Project, quota uint64) error {
+	l := ctx_zap.Extract(ctx)
+	ps, ok := s.vs.(api.ProvisioningStorage)
+	if !ok {
+		err := api.NewError(api.StorageNotSupportedErrorCode).WithMessage("quota not supported")
+		l.Error("", zap.Error(err))
+		return err
+	}
+	if err := ps.SetQuota(ctx, project.Owner, s.getPath(project), quota); err != nil {
+		l.Error("error setting quota of project", zap.Error(err), zap.String("name", project.Name))
+		return err
+	}
+	return nil
+}
+
+func (s *svc) getPath(project *api.Project) string {
+	return path.Join(s.root, project.Path)
+}
+
+func (s *svc) checkAdmin(ctx context.Context) error {
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		return api.NewError(api.ContextUserRequiredError)
+	}
+	if !s.admins[u.AccountId] || api.IsUserRestricted(u) {
+		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("projects can only be managed by admins")
+	}
+	return nil
+}
+
+// validate returns an error if the name of the project is not a single path
+// element, if its path leaves the projects or if it has no owner.
+func validate(project *api.Project) error {
+	if project.Name == "" || project.Name == "." || project.Name == ".." || strings.Contains(project.Name, "/") {
+		return api.NewError(api.ProjectInvalidErrorCode).WithMessage("invalid project name: " + project.Name)
+	}
+	if project.Path != "" && (path.IsAbs(project.Path) || path.Clean(project.Path) != project.Path || project.Path == "." || strings.HasPrefix(project.Path, "..")) {
+		return api.NewError(api.ProjectInvalidErrorCode).WithMessage("the path of the project must be relative to the projects: " + project.Path)
+	}
+	if project.Owner == "" {
+		return api.NewError(api.ProjectInvalidErrorCode).WithMessage("the project needs an owner")
+	}
+	return nil
+}
+
+// ownerContext returns ctx on behalf of the owner of the project, to set
+// the ACLs on the storages that check who changes them.
+func ownerContext(ctx context.Context, project *api.Project) context.Context {
+	return api.ContextSetUser(ctx, &api.User{AccountId: project.Owner})
+}
+
+// groupACLs returns the recipients of the ACLs of the groups of the project.
+func groupACLs(project *api.Project) []*api.ShareRecipient {
+	recipients := []*api.ShareRecipient{}
+	for _, g := range []string{project.AdminGroup, project.WritersGroup, project.ReadersGroup} {
+		if g != "" {
+			recipients = append(recipients, &api.ShareRecipient{Identity: g, Type: api.ShareRecipient_GROUP})
+		}
+	}
+	return recipients
+}
+
+func isGroupOf(project *api.Project, group string) bool {
+	return group == project.AdminGroup || group == project.WritersGroup || group == project.ReadersGroup
+}
+
+func toProjectSpace(project *api.Project) *api.ProjectSpace {
+	return &api.ProjectSpace{
+		Name:         project.Name,
+		Path:         project.Path,
+		Owner:        project.Owner,
+		AdminGroup:   project.AdminGroup,
+		WritersGroup: project.WritersGroup,
+		ReadersGroup: project.ReadersGroup,
+	}
+}
@@ -0,0 +1,114 @@
+package projectsvc
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+	"github.com/cernbox/reva/api/mount"
+	"github.com/cernbox/reva/api/project_manager_sqlite"
+	"github.com/cernbox/reva/api/virtual_storage"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+func expectStatus(t *testing.T, got, expected api.StatusCode) {
+	t.Helper()
+	if got != expected {
+		t.Fatalf("expected status %s, got %s", expected, got)
+	}
+}
+
+func TestProjects(t *testing.T) {
+	ctx := context.Background()
+	storage := conformance.NewMemoryStorage()
+	vfs := virtual_storage.NewVFS(zap.NewNop(), nil)
+	conformance.Check(t, vfs.AddMount(ctx, mount.New("eos", "/eos", nil, storage)))
+	for _, p := range []string{"/eos/project", "/eos/project/a"} {
+		conformance.Check(t, vfs.CreateDir(ctx, p))
+	}
+	pm, err := project_manager_sqlite.New("")
+	conformance.Check(t, err)
+	s := New(pm, vfs, []string{"admin"}, "/eos/project")
+	adminCtx := api.ContextSetUser(ctx, &api.User{AccountId: "admin"})
+	req := &api.NewProjectReq{Project: &api.ProjectSpace{Name: "atlas", Owner: "alice", ReadersGroup: "atlas-all"}}
+
+	// only the admins can manage the projects
+	if _, err := s.CreateProject(api.ContextSetUser(ctx, &api.User{AccountId: "alice"}), req); !api.IsErrorCode(err, api.StoragePermissionDeniedErrorCode) {
+		t.Fatalf("expected the creation to be denied, got %v", err)
+	}
+	res, err := s.CreateProject(adminCtx, &api.NewProjectReq{Project: &api.ProjectSpace{Name: "../atlas", Owner: "alice"}})
+	conformance.Check(t, err)
+	expectStatus(t, res.Status, api.StatusCode_PROJECT_INVALID)
+
+	// the storage has no quota, the project is forgotten
+	res, err = s.CreateProject(adminCtx, &api.NewProjectReq{Project: req.Project, Quota: 1 << 30})
+	conformance.Check(t, err)
+	expectStatus(t, res.Status, api.StatusCode_STORAGE_NOT_SUPPORTED)
+	_, err = pm.GetProject(ctx, "atlas")
+	if !api.IsErrorCode(err, api.ProjectNotFoundErrorCode) {
+		t.Fatalf("expected the project to be forgotten, got %v", err)
+	}
+
+	res, err = s.CreateProject(adminCtx, req)
+	conformance.Check(t, err)
+	expectStatus(t, res.Status, api.StatusCode_OK)
+	if res.Project.Path != "a/atlas" || res.Project.AdminGroup != "cernbox-project-atlas-admins" {
+		t.Fatalf("unexpected project: %+v", res.Project)
+	}
+	acl := storage.ACL("/project/a/atlas")
+	if len(acl) != 3 || acl["GROUP:cernbox-project-atlas-admins"] != api.SharePermissionsAll || acl["GROUP:cernbox-project-atlas-writers"] != api.SharePermissionsReadWrite || acl["GROUP:atlas-all"] != api.SharePermissionsReadOnly {
+		t.Fatalf("unexpected acl: %v", acl)
+	}
+	res, err = s.CreateProject(adminCtx, req)
+	conformance.Check(t, err)
+	expectStatus(t, res.Status, api.StatusCode_PROJECT_ALREADY_EXISTS)
+
+	// the paths of the projects do not overlap
+	for _, p := range []string{"a/atlas", "a", "a/atlas/sub"} {
+		res, err = s.CreateProject(adminCtx, &api.NewProjectReq{Project: &api.ProjectSpace{Name: "other", Path: p, Owner: "bob"}})
+		conformance.Check(t, err)
+		expectStatus(t, res.Status, api.StatusCode_PROJECT_INVALID)
+	}
+	res, err = s.CreateProject(adminCtx, &api.NewProjectReq{Project: &api.ProjectSpace{Name: "atlas2", Owner: "bob"}})
+	conformance.Check(t, err)
+	expectStatus(t, res.Status, api.StatusCode_OK)
+
+	// the acl of the replaced group is removed
+	res, err = s.UpdateProject(adminCtx, &api.UpdateProjectReq{Name: "atlas", ReadersGroup: "atlas-readers"})
+	conformance.Check(t, err)
+	expectStatus(t, res.Status, api.StatusCode_OK)
+	acl = storage.ACL("/project/a/atlas")
+	if _, ok := acl["GROUP:atlas-all"]; ok || len(acl) != 3 || acl["GROUP:atlas-readers"] != api.SharePermissionsReadOnly {
+		t.Fatalf("unexpected acl: %v", acl)
+	}
+	res, err = s.UpdateProject(adminCtx, &api.UpdateProjectReq{Name: "cms"})
+	conformance.Check(t, err)
+	expectStatus(t, res.Status, api.StatusCode_PROJECT_NOT_FOUND)
+
+	// the files are kept by default
+	del, err := s.DeleteProject(adminCtx, &api.DeleteProjectReq{Name: "atlas"})
+	conformance.Check(t, err)
+	expectStatus(t, del.Status, api.StatusCode_OK)
+	if acl := storage.ACL("/project/a/atlas"); len(acl) != 0 {
+		t.Fatalf("expected no acl left, got %v", acl)
+	}
+	if _, err := vfs.GetMetadata(ctx, "/eos/project/a/atlas"); err != nil {
+		t.Fatal(err)
+	}
+	res, err = s.GetProject(adminCtx, &api.ProjectReq{Name: "atlas"})
+	conformance.Check(t, err)
+	expectStatus(t, res.Status, api.StatusCode_PROJECT_NOT_FOUND)
+
+	// creating it again reuses the directory, deleting the files removes it
+	res, err = s.CreateProject(adminCtx, req)
+	conformance.Check(t, err)
+	expectStatus(t, res.Status, api.StatusCode_OK)
+	del, err = s.DeleteProject(adminCtx, &api.DeleteProjectReq{Name: "atlas", DeleteFiles: true})
+	conformance.Check(t, err)
+	expectStatus(t, del.Status, api.StatusCode_OK)
+	if _, err := vfs.GetMetadata(ctx, "/eos/project/a/atlas"); !api.IsErrorCode(err, api.StorageNotFoundErrorCode) {
+		t.Fatalf("expected the files to be deleted, got %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package searchsvc
+
+import (
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// Options configures the indexing of the files.
+type Options struct {
+	MaxContentSize int64 // larger text files are only indexed by name
+	// CrawlPaths are crawled as a user the first time the user searches,
+	// to index the files that were not changed since the indexer started.
+	CrawlPaths  []string
+	ProjectRoot string // path of the projects, their files are searched by their members
+	Logger      *zap.Logger
+}
+
+// crawlQueueSize is the number of users waiting for their files to be crawled,
+// the users searching while the queue is full are crawled on a later search.
+const crawlQueueSize = 100
+
+type indexer struct {
+	index api.SearchIndex
+	vs    api.VirtualStorage
+	pm    api.ProjectManager
+	opts  *Options
+
+	crawlPaths []string
+	crawls     chan string
+	mu         sync.Mutex
+	crawled    map[string]bool
+}
+
+func newIndexer(index api.SearchIndex, vs api.VirtualStorage, pm api.ProjectManager, opts *Options) *indexer {
+	ix := &indexer{index: index, vs: vs, pm: pm, opts: opts, crawls: make(chan string, crawlQueueSize), crawled: map[string]bool{}}
+	for _, p := range opts.CrawlPaths {
+		if p != "" {
+			ix.crawlPaths = append(ix.crawlPaths, p)
+		}
+	}
+	return ix
+}
+
+// start indexes the changes published to the bus and crawls
+// the files of the users until the daemon stops.
+func (ix *indexer) start(bus api.EventBus) {
+	go func() {
+		for {
+			events, cancel := bus.Subscribe("/", true)
+			for e := range events {
+				ix.handle(e)
+			}
+			cancel()
+			ix.opts.Logger.Error("search indexer did not keep up with the events, some changes were not indexed")
+		}
+	}()
+	go func() {
+		for accountID := range ix.crawls {
+			ix.crawl(accountID)
+		}
+	}()
+}
+
+func (ix *indexer) handle(e *api.FileEvent) {
+	ctx := ctx_zap.ToContext(context.Background(), ix.opts.Logger)
+	switch e.Type {
+	case api.FileEvent_CREATED, api.FileEvent_WRITTEN, api.FileEvent_MOVED:
+		if e.Metadata == nil {
+			return
+		}
+		ix.indexFile(ctx, e.AccountId, e.Path, e.ParentId, e.Metadata)
+	case api.FileEvent_DELETED:
+		if err := ix.index.RemoveName(ctx, e.ParentId, path.Base(e.Path)); err != nil {
+			ix.opts.Logger.Error("error removing file from index", zap.Error(err), zap.String("path", e.Path))
+		}
+	}
+}
+
+// indexFile indexes the file p of the folder parentID, as seen by accountID.
+func (ix *indexer) indexFile(ctx context.Context, accountID, p, parentID string, md *api.Metadata) {
+	doc := &api.SearchDocument{
+		ID:       md.Id,
+		ParentID: parentID,
+		Name:     path.Base(p),
+		Mime:     md.Mime,
+		Size:     md.Size,
+		Mtime:    md.Mtime,
+		IsDir:    md.IsDir,
+		Readers:  ix.getReaders(ctx, accountID, p, md),
+	}
+	if ix.hasTextContent(md, p) && accountID != "" {
+		doc.Content = ix.readContent(ctx, accountID, p)
+	}
+	if err := ix.index.Index(ctx, doc); err != nil {
+		ix.opts.Logger.Error("error indexing file", zap.Error(err), zap.String("path", p))
+	}
+}
+
+// getReaders returns the accounts the file is indexed for: the user that
+// changed it and the owner of the file, that is the owner of the share it was
+// changed through or of the project it is in. The users the file is shared
+// with search as its owner, see svc.getReaders.
+func (ix *indexer) getReaders(ctx context.Context, accountID, p string, md *api.Metadata) []string {
+	readers := []string{}
+	if accountID != "" {
+		readers = append(readers, accountID)
+	}
+	if md.ShareOwnerId != "" {
+		readers = append(readers, md.ShareOwnerId)
+	}
+	if ix.opts.ProjectRoot != "" && api.IsWatchedPath(ix.opts.ProjectRoot, true, p) {
+		projects, err := ix.pm.GetAllProjects(ctx)
+		if err != nil {
+			ix.opts.Logger.Error("error getting projects", zap.Error(err))
+			return readers
+		}
+		for _, project := range projects {
+			if project.Owner != "" && api.IsWatchedPath(path.Join(ix.opts.ProjectRoot, project.Path), true, p) {
+				readers = append(readers, project.Owner)
+			}
+		}
+	}
+	return readers
+}
+
+// crawlOnce queues the crawl of the files of the user, if not crawled yet.
+func (ix *indexer) crawlOnce(accountID string) {
+	if len(ix.crawlPaths) == 0 {
+		return
+	}
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	if ix.crawled[accountID] {
+		return
+	}
+	select {
+	case ix.crawls <- accountID:
+		ix.crawled[accountID] = true
+	default:
+	}
+}
+
+// crawl indexes the files under the crawl paths as the user.
+func (ix *indexer) crawl(accountID string) {
+	l := ix.opts.Logger.With(zap.String("account_id", accountID))
+	ctx := ctx_zap.ToContext(context.Background(), l)
+	ctx = api.ContextSetUser(ctx, &api.User{AccountId: accountID})
+	for _, p := range ix.crawlPaths {
+		md, err := ix.vs.GetMetadata(ctx, p)
+		if err != nil {
+			l.Warn("error crawling files to index", zap.Error(err), zap.String("path", p))
+			continue
+		}
+		ix.crawlFolder(ctx, accountID, p, md.Id)
+	}
+	l.Info("files of user crawled for the search")
+}
+
+func (ix *indexer) crawlFolder(ctx context.Context, accountID, p, id string) {
+	mds, err := ix.vs.ListFolder(ctx, p)
+	if err != nil {
+		ix.opts.Logger.Warn("error crawling files to index", zap.Error(err), zap.String("path", p))
+		return
+	}
+	for _, md := range mds {
+		// the entries are listed with the path of the user
+		ix.indexFile(ctx, accountID, md.Path, id, md)
+		if md.IsDir {
+			ix.crawlFolder(ctx, accountID, md.Path, md.Id)
+		}
+	}
+}
+
+func (ix *indexer) hasTextContent(md *api.Metadata, p string) bool {
+	if md.IsDir || int64(md.Size) > ix.opts.MaxContentSize {
+		return false
+	}
+	return strings.HasPrefix(md.Mime, "text/") || api.IsTextFile(p)
+}
+
+// readContent reads the file as the user that changed it, as the path
+// is the one seen by this user. The file is indexed by name only if
+// it cannot be read.
+func (ix *indexer) readContent(ctx context.Context, accountID, p string) string {
+	ctx = api.ContextSetUser(ctx, &api.User{AccountId: accountID})
+	readCloser, err := ix.vs.Download(ctx, p)
+	if err != nil {
+		ix.opts.Logger.Warn("error reading file to index", zap.Error(err), zap.String("path", p))
+		return ""
+	}
+	defer readCloser.Close()
+	data, err := ioutil.ReadAll(io.LimitReader(readCloser, ix.opts.MaxContentSize))
+	if err != nil {
+		ix.opts.Logger.Warn("error reading file to index", zap.Error(err), zap.String("path", p))
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,180 @@
+package searchsvc
+
+import (
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+	// lookupsPerResult bounds the files looked up in the storage
+	// for a search, to skip the ones the user cannot access anymore.
+	// The search is reported as truncated when the bound is reached.
+	lookupsPerResult = 2
+)
+
+// New returns the search service and starts indexing the changes
+// published to bus. The files found are only returned if the
+// user can access them. The files are found by ID, so the search
+// only returns files on the storages resolving the IDs to paths,
+// like EOS, and never the files of the local storage.
+func New(index api.SearchIndex, vs api.VirtualStorage, sm api.ShareManager, pm api.ProjectManager, um api.UserManager, tm api.TagManager, bus api.EventBus, opts *Options) api.SearchServer {
+	ix := newIndexer(index, vs, pm, opts)
+	ix.start(bus)
+	return &svc{index: index, ix: ix, vs: vs, sm: sm, pm: pm, um: um, tm: tm}
+}
+
+type svc struct {
+	index api.SearchIndex
+	ix    *indexer
+	vs    api.VirtualStorage
+	sm    api.ShareManager
+	pm    api.ProjectManager
+	um    api.UserManager
+	tm    api.TagManager
+}
+
+func (s *svc) Search(req *api.SearchReq, stream api.Search_SearchServer) error {
+	ctx := stream.Context()
+	l := ctx_zap.Extract(ctx)
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		err := api.NewError(api.ContextUserRequiredError)
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	s.ix.crawlOnce(u.AccountId)
+	readers, err := s.getReaders(ctx, u.AccountId)
+	if err != nil {
+		l.Error("error getting search readers", zap.Error(err))
+		return err
+	}
+
+	docs, err := s.index.Search(ctx, readers, req)
+	if err != nil {
+		l.Error("error searching index", zap.Error(err))
+		return err
+	}
+
+	var tagged map[string]bool
+	if len(req.Tags) > 0 {
+		tagged, err = s.getTaggedIDs(ctx, req.Tags)
+		if err != nil {
+			l.Error("error getting tagged files", zap.Error(err))
+			return err
+		}
+	}
+
+	sent, lookups := 0, 0
+	for i, doc := range docs {
+		if sent >= limit || lookups >= lookupsPerResult*limit || ctx.Err() != nil {
+			stream.SetTrailer(metadata.Pairs(api.SearchTruncatedMetadata, "true"))
+			l.Info("search truncated", zap.Int("sent", sent), zap.Int("left", len(docs)-i))
+			break
+		}
+		if tagged != nil && !tagged[doc.ID] {
+			continue
+		}
+
+		// the file is looked up as the user, so the ones the user
+		// cannot access anymore are left out. Not found only means
+		// not found for this user, the index is left as it is.
+		lookups++
+		md, err := s.vs.GetMetadata(ctx, doc.ID)
+		if err != nil {
+			continue
+		}
+		if req.Path != "" && !api.IsWatchedPath(req.Path, true, md.Path) {
+			continue
+		}
+
+		if err := stream.Send(&api.MetadataResponse{Metadata: md}); err != nil {
+			l.Error("error streaming search result", zap.Error(err))
+			return err
+		}
+		sent++
+	}
+	return nil
+}
+
+// getReaders returns the accounts the user searches the files of: the user,
+// the owners of the shares the user receives and the owners of the projects
+// the user is a member of. The files found are then checked with the ACLs
+// of the storage, as they are looked up as the user.
+func (s *svc) getReaders(ctx context.Context, accountID string) ([]string, error) {
+	readers := []string{accountID}
+	seen := map[string]bool{accountID: true}
+	add := func(r string) {
+		if r != "" && !seen[r] {
+			seen[r] = true
+			readers = append(readers, r)
+		}
+	}
+
+	shares, err := s.sm.ListReceivedShares(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, share := range shares {
+		if share.State != api.FolderShare_REJECTED {
+			add(share.OwnerId)
+		}
+	}
+
+	groups, err := s.um.GetUserGroups(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	member := map[string]bool{}
+	for _, g := range groups {
+		member[g] = true
+	}
+	projects, err := s.pm.GetAllProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range projects {
+		if p.Owner == accountID || member[p.AdminGroup] || member[p.WritersGroup] || member[p.ReadersGroup] {
+			add(p.Owner)
+		}
+	}
+	return readers, nil
+}
+
+// getTaggedIDs returns the IDs of the files having all the tags of the user.
+func (s *svc) getTaggedIDs(ctx context.Context, keys []string) (map[string]bool, error) {
+	var ids map[string]bool
+	for _, key := range keys {
+		tags, err := s.tm.GetTagsForKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		keyIDs := map[string]bool{}
+		for _, tag := range tags {
+			md, err := s.vs.GetMetadata(ctx, tag.FileIdPrefix+":"+tag.FileId)
+			if err != nil {
+				continue
+			}
+			if ids == nil || ids[md.Id] {
+				keyIDs[md.Id] = true
+			}
+		}
+		ids = keyIDs
+	}
+	return ids, nil
+}
@@ -0,0 +1,118 @@
+package searchsvc
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+	"github.com/cernbox/reva/api/event_bus_memory"
+	"github.com/cernbox/reva/api/mount"
+	"github.com/cernbox/reva/api/project_manager_sqlite"
+	"github.com/cernbox/reva/api/search_index_local"
+	"github.com/cernbox/reva/api/share_manager_memory"
+	"github.com/cernbox/reva/api/virtual_storage"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type searchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	names   []string
+	trailer metadata.MD
+}
+
+func (s *searchStream) Context() context.Context { return s.ctx }
+
+func (s *searchStream) Send(res *api.MetadataResponse) error {
+	s.names = append(s.names, res.Metadata.Path[strings.LastIndex(res.Metadata.Path, "/")+1:])
+	return nil
+}
+
+func (s *searchStream) SetTrailer(md metadata.MD) { s.trailer = metadata.Join(s.trailer, md) }
+
+func search(t *testing.T, s api.SearchServer, accountID string, req *api.SearchReq) *searchStream {
+	t.Helper()
+	stream := &searchStream{ctx: api.ContextSetUser(context.Background(), &api.User{AccountId: accountID})}
+	conformance.Check(t, s.Search(req, stream))
+	return stream
+}
+
+func expectNames(t *testing.T, got []string, expected ...string) {
+	t.Helper()
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	bus := event_bus_memory.New(10)
+	vfs := virtual_storage.NewVFS(zap.NewNop(), bus)
+	conformance.Check(t, vfs.AddMount(ctx, mount.New("home", "/", nil, conformance.NewMemoryStorage())))
+	for _, p := range []string{"/alice", "/alice/dir", "/project", "/project/proj"} {
+		conformance.Check(t, vfs.CreateDir(ctx, p))
+	}
+	aliceCtx := api.ContextSetUser(ctx, &api.User{AccountId: "alice"})
+	// the files written before the service starts are crawled
+	conformance.Check(t, vfs.Upload(aliceCtx, "/alice/dir/notes.txt", ioutil.NopCloser(strings.NewReader("meeting minutes"))))
+
+	sm := share_manager_memory.New(vfs, conformance.NewUserManager())
+	pm, err := project_manager_sqlite.New("")
+	conformance.Check(t, err)
+	_, err = pm.CreateProject(ctx, &api.Project{Name: "proj", Path: "proj", Owner: "projowner", ReadersGroup: "proj-readers"})
+	conformance.Check(t, err)
+	index, err := search_index_local.New("", time.Hour, zap.NewNop())
+	conformance.Check(t, err)
+	opts := &Options{MaxContentSize: 1024, CrawlPaths: []string{"/alice", ""}, ProjectRoot: "/project", Logger: zap.NewNop()}
+	s := New(index, vfs, sm, pm, conformance.NewUserManager(), nil, bus, opts)
+
+	// the first search starts the crawl
+	expectNames(t, search(t, s, "alice", &api.SearchReq{Query: "minutes"}).names)
+	for i := 0; ; i++ {
+		if names := search(t, s, "alice", &api.SearchReq{Query: "minutes"}).names; len(names) == 1 {
+			break
+		}
+		if i == 100 {
+			t.Fatal("expected the crawled file to be found")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the recipients of a share search the files of its owner
+	expectNames(t, search(t, s, "bob", &api.SearchReq{Query: "minutes"}).names)
+	_, err = sm.AddFolderShare(aliceCtx, "/alice/dir", &api.ShareRecipient{Identity: "bob", Type: api.ShareRecipient_USER}, &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	conformance.Check(t, err)
+	expectNames(t, search(t, s, "bob", &api.SearchReq{Query: "minutes"}).names, "notes.txt")
+	expectNames(t, search(t, s, "carol", &api.SearchReq{Query: "minutes"}).names)
+
+	// the members of a project search the files written in it by the others
+	carolCtx := api.ContextSetUser(ctx, &api.User{AccountId: "carol"})
+	conformance.Check(t, vfs.Upload(carolCtx, "/project/proj/plan.txt", ioutil.NopCloser(strings.NewReader("project plan"))))
+	for i := 0; ; i++ {
+		if names := search(t, s, "bob", &api.SearchReq{Query: "plan"}).names; len(names) == 1 {
+			break
+		}
+		if i == 100 {
+			t.Fatal("expected the file of the project to be found by its reader")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	expectNames(t, search(t, s, "dave", &api.SearchReq{Query: "plan"}).names)
+
+	// the results beyond the limit are reported
+	stream := search(t, s, "bob", &api.SearchReq{Limit: 1})
+	if len(stream.names) != 1 || len(stream.trailer.Get(api.SearchTruncatedMetadata)) == 0 {
+		t.Fatalf("expected one result reported as truncated, got %v %v", stream.names, stream.trailer)
+	}
+	stream = search(t, s, "bob", &api.SearchReq{Query: "plan"})
+	if len(stream.trailer.Get(api.SearchTruncatedMetadata)) != 0 {
+		t.Fatalf("expected the results not to be truncated, got %v", stream.trailer)
+	}
+}
@@ -0,0 +1,74 @@
+package sharesvc
+
+import (
+	"time"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// Options configures the service and its sweeping of the expired shares.
+type Options struct {
+	ExpirationSweepInterval time.Duration // 0 disables the sweeping
+	Logger                  *zap.Logger
+	Admins                  []string // accounts allowed to check the shares of all the users
+}
+
+type sweeper struct {
+	shareManager api.ShareManager
+	vs           api.VirtualStorage
+	bus          api.EventBus
+	opts         *Options
+	done         chan struct{}
+}
+
+// start removes the expired shares every sweep interval until done is closed.
+func (sw *sweeper) start() {
+	if sw.opts.ExpirationSweepInterval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(sw.opts.ExpirationSweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-sw.done:
+				return
+			case <-ticker.C:
+				sw.sweep()
+			}
+		}
+	}()
+}
+
+// sweep removes the expired shares and publishes their expiration.
+func (sw *sweeper) sweep() {
+	ctx := ctx_zap.ToContext(context.Background(), sw.opts.Logger)
+	shares, err := sw.shareManager.ExpireFolderShares(ctx)
+	if err != nil {
+		sw.opts.Logger.Error("error expiring shares", zap.Error(err))
+		return
+	}
+	for _, share := range shares {
+		sw.opts.Logger.Info("share expired", zap.String("share_id", share.Id), zap.String("owner", share.OwnerId))
+		sw.publishExpiration(ctx, share)
+	}
+}
+
+// publishExpiration publishes the expiration of a share on the tree of its owner.
+func (sw *sweeper) publishExpiration(ctx context.Context, share *api.FolderShare) {
+	if !sw.bus.HasSubscribers() {
+		return
+	}
+	ctx = api.ContextSetUser(ctx, &api.User{AccountId: share.OwnerId})
+	md, err := sw.vs.GetMetadata(ctx, share.Path)
+	if err != nil {
+		sw.opts.Logger.Error("error resolving path of expired share for file event", zap.Error(err), zap.String("share_id", share.Id))
+		return
+	}
+	target := share.Recipient.Type.String() + ":" + share.Recipient.Identity
+	api.PublishFileEvent(ctx, sw.bus, sw.vs, &api.FileEvent{Type: api.FileEvent_SHARE_EXPIRED, Path: md.Path, FileId: md.Id, Target: target})
+}
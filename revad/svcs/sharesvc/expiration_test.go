@@ -0,0 +1,98 @@
+package sharesvc
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+	"github.com/cernbox/reva/api/event_bus_memory"
+	"github.com/cernbox/reva/api/mount"
+	"github.com/cernbox/reva/api/share_manager_memory"
+	"github.com/cernbox/reva/api/virtual_storage"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+func TestSweeper(t *testing.T) {
+	ctx := context.Background()
+	bus := event_bus_memory.New(10)
+	vfs := virtual_storage.NewVFS(zap.NewNop(), bus)
+	if err := vfs.AddMount(ctx, mount.New("home", "/", nil, conformance.NewMemoryStorage())); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{"/alice", "/alice/dir"} {
+		if err := vfs.CreateDir(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sm := share_manager_memory.New(vfs, conformance.NewUserManager())
+	aliceCtx := api.ContextSetUser(ctx, &api.User{AccountId: "alice"})
+	recipient := &api.ShareRecipient{Identity: "bob", Type: api.ShareRecipient_USER}
+	share, err := sm.AddFolderShare(aliceCtx, "/alice/dir", recipient, &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly, Expiration: uint64(time.Now().Unix() - 10)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, cancel := bus.Subscribe("/alice", true)
+	defer cancel()
+	sw := &sweeper{shareManager: sm, vs: vfs, bus: bus, opts: &Options{Logger: zap.NewNop()}}
+	sw.sweep()
+
+	types := []api.FileEvent_Type{}
+	for len(types) < 2 {
+		select {
+		case e := <-events:
+			if e.Path != "/alice/dir" || e.Target != "USER:bob" || e.AccountId != "alice" {
+				t.Fatalf("expected event on /alice/dir for bob, got %+v", e)
+			}
+			types = append(types, e.Type)
+		case <-time.After(time.Second):
+			t.Fatalf("expected the removal and the expiration of the share, got %v", types)
+		}
+	}
+	if types[0] != api.FileEvent_SHARE_REMOVED || types[1] != api.FileEvent_SHARE_EXPIRED {
+		t.Fatalf("expected the removal then the expiration of the share, got %v", types)
+	}
+
+	if _, err := sm.GetFolderShare(aliceCtx, share.Id); !api.IsErrorCode(err, api.FolderShareNotFoundErrorCode) {
+		t.Fatalf("expected share to be removed, got %v", err)
+	}
+}
+
+// countingShareManager counts the sweeps of the expired shares.
+type countingShareManager struct {
+	api.ShareManager
+	sweeps chan struct{}
+}
+
+func (sm *countingShareManager) ExpireFolderShares(ctx context.Context) ([]*api.FolderShare, error) {
+	sm.sweeps <- struct{}{}
+	return []*api.FolderShare{}, nil
+}
+
+func TestSweeperStops(t *testing.T) {
+	sm := &countingShareManager{sweeps: make(chan struct{}, 100)}
+	s := New(nil, sm, nil, event_bus_memory.New(10), &Options{ExpirationSweepInterval: time.Millisecond, Logger: zap.NewNop()})
+	select {
+	case <-sm.sweeps:
+	case <-time.After(time.Second):
+		t.Fatal("expected the expired shares to be swept")
+	}
+
+	// a sweep may be running when the service is closed, none starts after
+	if err := s.(io.Closer).Close(); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	for len(sm.sweeps) > 0 {
+		<-sm.sweeps
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := len(sm.sweeps); n != 0 {
+		t.Fatalf("expected no sweep after closing the service, got %d", n)
+	}
+}
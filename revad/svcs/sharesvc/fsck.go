@@ -0,0 +1,180 @@
+package sharesvc
+
+import (
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// FsckShares checks the shares and the public links of all the users against
+// their files, and repairs the ones out of step if asked. The shares of the
+// files deleted, restored or purged while revad could not follow, or by other
+// means than revad, are found this way.
+// The orphans are suspended rather than purged, as a file may only be missing
+// for a while, like when the storage is recovering, and the suspended ones are
+// reactivated by the next runs if the file is back. They are purged by a later
+// run with PurgeSuspended, once the file is not in the recycle bin anymore.
+func (s *svc) FsckShares(req *api.FsckSharesReq, stream api.Share_FsckSharesServer) error {
+	ctx := stream.Context()
+	l := ctx_zap.Extract(ctx)
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		err := api.NewError(api.ContextUserRequiredError)
+		l.Error("", zap.Error(err))
+		return err
+	}
+	if !s.admins[u.AccountId] || api.IsUserRestricted(u) {
+		err := api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("the shares of all the users can only be checked by admins")
+		l.Error("", zap.Error(err))
+		return stream.Send(&api.FsckSharesResponse{Status: api.GetStatus(err)})
+	}
+
+	bins := recycleBins{}
+	shares, err := s.shareManager.ListAllFolderShares(ctx, "")
+	if err != nil {
+		l.Error("error listing all the shares", zap.Error(err))
+		return err
+	}
+	for _, share := range shares {
+		entry := s.checkFile(ctx, share.OwnerId, share.Path, share.Suspended)
+		if entry == nil {
+			continue
+		}
+		entry.Kind = api.FsckEntry_FOLDER_SHARE
+		entry.Id = share.Id
+		if req.Repair {
+			entry.Repaired = s.repairShare(ctx, entry, req.PurgeSuspended && s.isPurged(ctx, bins, entry))
+		}
+		if err := stream.Send(&api.FsckSharesResponse{Entry: entry}); err != nil {
+			l.Error("error streaming fsck entry", zap.Error(err))
+			return err
+		}
+	}
+
+	links, err := s.linkManager.ListAllPublicLinks(ctx, "")
+	if err != nil {
+		l.Error("error listing all the public links", zap.Error(err))
+		return err
+	}
+	for _, pl := range links {
+		entry := s.checkFile(ctx, pl.OwnerId, pl.Path, pl.Suspended)
+		if entry == nil {
+			continue
+		}
+		entry.Kind = api.FsckEntry_PUBLIC_LINK
+		entry.Id = pl.Id
+		if req.Repair {
+			entry.Repaired = s.repairLink(ctx, entry, req.PurgeSuspended && s.isPurged(ctx, bins, entry))
+		}
+		if err := stream.Send(&api.FsckSharesResponse{Entry: entry}); err != nil {
+			l.Error("error streaming fsck entry", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// checkFile returns the problem of a share or a public link on the file of
+// owner, or nil if it is in step with the file. The files that cannot be
+// checked are skipped, so nothing is purged on a failure of the storage.
+func (s *svc) checkFile(ctx context.Context, owner, fileID string, suspended bool) *api.FsckEntry {
+	l := ctx_zap.Extract(ctx)
+	entry := &api.FsckEntry{OwnerId: owner, FileId: fileID}
+	_, err := s.vs.GetMetadata(api.ContextSetUser(ctx, &api.User{AccountId: owner}), fileID)
+	switch {
+	case err == nil && suspended:
+		entry.Problem = api.FsckEntry_RESTORED
+	case err == nil:
+		return nil
+	case api.IsErrorCode(err, api.StorageNotFoundErrorCode) && suspended:
+		entry.Problem = api.FsckEntry_SUSPENDED
+	case api.IsErrorCode(err, api.StorageNotFoundErrorCode):
+		entry.Problem = api.FsckEntry_ORPHAN
+	default:
+		l.Error("error checking file of share", zap.Error(err), zap.String("file_id", fileID), zap.String("owner", owner))
+		return nil
+	}
+	return entry
+}
+
+// recycleBins keeps the recycle entries of the owners listed during a run,
+// by owner and mount point.
+type recycleBins map[string][]*api.RecycleEntry
+
+// isPurged returns whether the file of a suspended entry is not in the
+// recycle bin of its owner, where it could still be restored from. The
+// entries of the folders do not list the files under them, and some
+// storages do not know the ids of the deleted files, so the file is taken
+// as restorable while such entries are there.
+func (s *svc) isPurged(ctx context.Context, bins recycleBins, entry *api.FsckEntry) bool {
+	l := ctx_zap.Extract(ctx)
+	if entry.Problem != api.FsckEntry_SUSPENDED {
+		return false
+	}
+	m, err := s.vs.GetMount(entry.FileId)
+	if err != nil {
+		l.Error("error getting mount of suspended file", zap.Error(err), zap.String("file_id", entry.FileId))
+		return false
+	}
+	key := entry.OwnerId + ":" + m.GetMountPoint()
+	entries, ok := bins[key]
+	if !ok {
+		// the storages without recycle bin delete the files for good
+		entries, err = m.ListRecycle(api.ContextSetUser(ctx, &api.User{AccountId: entry.OwnerId}), m.GetMountPoint())
+		if err != nil && !api.IsErrorCode(err, api.StorageNotSupportedErrorCode) {
+			l.Error("error listing recycle bin of owner", zap.Error(err), zap.String("owner", entry.OwnerId))
+			return false
+		}
+		bins[key] = entries
+	}
+	for _, e := range entries {
+		if e.IsDir || e.FileId == "" || e.FileId == entry.FileId {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *svc) repairShare(ctx context.Context, entry *api.FsckEntry, purgeSuspended bool) bool {
+	l := ctx_zap.Extract(ctx)
+	var err error
+	switch {
+	case entry.Problem == api.FsckEntry_RESTORED:
+		_, err = s.shareManager.SuspendFolderShares(ctx, []string{entry.FileId}, false)
+	case entry.Problem == api.FsckEntry_ORPHAN:
+		_, err = s.shareManager.SuspendFolderShares(ctx, []string{entry.FileId}, true)
+	case entry.Problem == api.FsckEntry_SUSPENDED && purgeSuspended:
+		_, err = s.shareManager.PurgeFolderShares(ctx, []string{entry.FileId})
+	default:
+		return false
+	}
+	if err != nil {
+		l.Error("error repairing share", zap.Error(err), zap.String("share_id", entry.Id))
+		return false
+	}
+	l.Info("share repaired", zap.String("share_id", entry.Id), zap.String("problem", entry.Problem.String()))
+	return true
+}
+
+func (s *svc) repairLink(ctx context.Context, entry *api.FsckEntry, purgeSuspended bool) bool {
+	l := ctx_zap.Extract(ctx)
+	var err error
+	switch {
+	case entry.Problem == api.FsckEntry_RESTORED:
+		_, err = s.linkManager.SuspendPublicLinks(ctx, []string{entry.FileId}, false)
+	case entry.Problem == api.FsckEntry_ORPHAN:
+		_, err = s.linkManager.SuspendPublicLinks(ctx, []string{entry.FileId}, true)
+	case entry.Problem == api.FsckEntry_SUSPENDED && purgeSuspended:
+		_, err = s.linkManager.PurgePublicLinks(ctx, []string{entry.FileId})
+	default:
+		return false
+	}
+	if err != nil {
+		l.Error("error repairing public link", zap.Error(err), zap.String("id", entry.Id))
+		return false
+	}
+	l.Info("public link repaired", zap.String("id", entry.Id), zap.String("problem", entry.Problem.String()))
+	return true
+}
@@ -0,0 +1,133 @@
+package sharesvc
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+	"github.com/cernbox/reva/api/mount"
+	"github.com/cernbox/reva/api/public_link_manager_memory"
+	"github.com/cernbox/reva/api/share_manager_memory"
+	"github.com/cernbox/reva/api/virtual_storage"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+type fsckStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*api.FsckSharesResponse
+}
+
+func (s *fsckStream) Context() context.Context { return s.ctx }
+
+func (s *fsckStream) Send(res *api.FsckSharesResponse) error {
+	s.responses = append(s.responses, res)
+	return nil
+}
+
+func TestFsckShares(t *testing.T) {
+	ctx := context.Background()
+	vfs := virtual_storage.NewVFS(zap.NewNop(), nil)
+	if err := vfs.AddMount(ctx, mount.New("home", "/", nil, conformance.NewMemoryStorage())); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{"/alice", "/alice/dir", "/alice/gone", "/alice/back"} {
+		if err := vfs.CreateDir(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sm := share_manager_memory.New(vfs, conformance.NewUserManager())
+	lm := public_link_manager_memory.New(vfs, nil, nil)
+	aliceCtx := api.ContextSetUser(ctx, &api.User{AccountId: "alice"})
+	recipient := &api.ShareRecipient{Identity: "bob", Type: api.ShareRecipient_USER}
+	share, err := sm.AddFolderShare(aliceCtx, "/alice/dir", recipient, &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orphan, err := sm.AddFolderShare(aliceCtx, "/alice/gone", recipient, &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := lm.CreatePublicLink(aliceCtx, "/alice/back", &api.PublicLinkOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lm.SuspendPublicLinks(ctx, []string{restored.Path}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := vfs.Delete(aliceCtx, "/alice/gone"); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &svc{shareManager: sm, linkManager: lm, vs: vfs, admins: map[string]bool{"admin": true}}
+
+	// only the admins can check the shares of all the users
+	stream := &fsckStream{ctx: aliceCtx}
+	if err := s.FsckShares(&api.FsckSharesReq{}, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.responses) != 1 || stream.responses[0].Status != api.StatusCode_STORAGE_PERMISSIONDENIED {
+		t.Fatalf("expected the check to be denied, got %+v", stream.responses)
+	}
+
+	stream = &fsckStream{ctx: api.ContextSetUser(ctx, &api.User{AccountId: "admin"})}
+	if err := s.FsckShares(&api.FsckSharesReq{Repair: true}, stream); err != nil {
+		t.Fatal(err)
+	}
+	problems := map[string]api.FsckEntry_Problem{}
+	for _, res := range stream.responses {
+		if !res.Entry.Repaired {
+			t.Fatalf("expected repaired entry, got %+v", res.Entry)
+		}
+		problems[res.Entry.Id] = res.Entry.Problem
+	}
+	if len(problems) != 2 || problems[orphan.Id] != api.FsckEntry_ORPHAN || problems[restored.Id] != api.FsckEntry_RESTORED {
+		t.Fatalf("expected the orphan share and the restored link, got %v", problems)
+	}
+
+	// the orphan share is only suspended, the file may come back
+	got, err := sm.GetFolderShare(aliceCtx, orphan.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Suspended {
+		t.Fatalf("expected orphan share to be suspended, got %+v", got)
+	}
+	if _, err := sm.GetFolderShare(aliceCtx, share.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lm.AuthenticatePublicLink(ctx, restored.Token, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// and purged by a later run asked to, once the file cannot be restored
+	// from the recycle bin
+	for _, run := range []struct {
+		req          *api.FsckSharesReq
+		emptyRecycle bool
+	}{
+		{req: &api.FsckSharesReq{Repair: true}},
+		{req: &api.FsckSharesReq{Repair: true, PurgeSuspended: true}},
+		{req: &api.FsckSharesReq{Repair: true, PurgeSuspended: true}, emptyRecycle: true},
+	} {
+		if run.emptyRecycle {
+			if err := vfs.EmptyRecycle(aliceCtx, "/alice"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		stream = &fsckStream{ctx: api.ContextSetUser(ctx, &api.User{AccountId: "admin"})}
+		if err := s.FsckShares(run.req, stream); err != nil {
+			t.Fatal(err)
+		}
+		if len(stream.responses) != 1 || stream.responses[0].Entry.Id != orphan.Id || stream.responses[0].Entry.Problem != api.FsckEntry_SUSPENDED || stream.responses[0].Entry.Repaired != run.emptyRecycle {
+			t.Fatalf("expected the suspended share, got %+v", stream.responses)
+		}
+	}
+	if _, err := sm.GetFolderShare(aliceCtx, orphan.Id); !api.IsErrorCode(err, api.FolderShareNotFoundErrorCode) {
+		t.Fatalf("expected suspended share to be purged, got %v", err)
+	}
+}
@@ -9,13 +9,32 @@ import (
 	"go.uber.org/zap"
 )
 
-func New(lm api.PublicLinkManager, sm api.ShareManager) api.ShareServer {
-	return &svc{linkManager: lm, shareManager: sm}
+// New returns the service to manage the shares and starts removing
+// the expired ones every opts.ExpirationSweepInterval. The service is
+// an io.Closer, closing it stops the sweeping.
+func New(lm api.PublicLinkManager, sm api.ShareManager, vs api.VirtualStorage, bus api.EventBus, opts *Options) api.ShareServer {
+	sw := &sweeper{shareManager: sm, vs: vs, bus: bus, opts: opts, done: make(chan struct{})}
+	sw.start()
+	admins := map[string]bool{}
+	for _, a := range opts.Admins {
+		admins[a] = true
+	}
+	return &svc{linkManager: lm, shareManager: sm, vs: vs, bus: bus, admins: admins, sweeper: sw}
 }
 
 type svc struct {
 	linkManager  api.PublicLinkManager
 	shareManager api.ShareManager
+	vs           api.VirtualStorage
+	bus          api.EventBus
+	admins       map[string]bool
+	sweeper      *sweeper
+}
+
+// Close stops the sweeping of the expired shares, it must be called once.
+func (s *svc) Close() error {
+	close(s.sweeper.done)
+	return nil
 }
 
 func (s *svc) ListReceivedShares(req *api.EmptyReq, stream api.Share_ListReceivedSharesServer) error {
@@ -47,12 +66,28 @@ func (s *svc) IsPublicLinkProtected(ctx context.Context, req *api.PublicLinkToke
 	return res, nil
 }
 
-func (s *svc) MountReceivedShare(ctx context.Context, req *api.ReceivedShareReq) (*api.EmptyResponse, error) {
-	return &api.EmptyResponse{}, nil
+func (s *svc) MountReceivedShare(ctx context.Context, req *api.ReceivedShareReq) (*api.ReceivedShareResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	s.auditReceivedShare(ctx, req.ShareId)
+	share, err := s.shareManager.MountReceivedShare(ctx, req.ShareId, req.Target)
+	if err != nil {
+		if api.IsErrorCode(err, api.FolderShareNotFoundErrorCode) {
+			return &api.ReceivedShareResponse{Status: api.StatusCode_FOLDER_SHARE_NOT_FOUND}, nil
+		}
+		if api.IsErrorCode(err, api.PathInvalidError) {
+			return &api.ReceivedShareResponse{Status: api.StatusCode_PATH_INVALID}, nil
+		}
+		err = errors.Wrapf(err, "error mounting received share: id=%s", req.ShareId)
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	return &api.ReceivedShareResponse{Share: share}, nil
 }
 
 func (s *svc) UnmountReceivedShare(ctx context.Context, req *api.ReceivedShareReq) (*api.EmptyResponse, error) {
 	l := ctx_zap.Extract(ctx)
+	s.auditReceivedShare(ctx, req.ShareId)
 	err := s.shareManager.UnmountReceivedShare(ctx, req.ShareId)
 	if err != nil {
 		err = errors.Wrapf(err, "error unmounting received share: id=%s", req.ShareId)
@@ -98,28 +133,71 @@ func (s *svc) GetFolderShare(ctx context.Context, req *api.ShareIDReq) (*api.Fol
 
 func (s *svc) AddFolderShare(ctx context.Context, req *api.NewFolderShareReq) (*api.FolderShareResponse, error) {
 	l := ctx_zap.Extract(ctx)
-	share, err := s.shareManager.AddFolderShare(ctx, req.Path, req.Recipient, req.ReadOnly)
+	// the shares would outlive the restrictions of the credential
+	if _, err := api.CheckUnrestrictedUser(ctx, "shares cannot be managed"); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	opts := &api.FolderShareOptions{
+		Permissions: api.SharePermissions(req.Permissions),
+		Expiration:  req.Expiration,
+	}
+	if req.Permissions == 0 {
+		opts.Permissions = api.PermissionsFromReadOnly(req.ReadOnly)
+	}
+	share, err := s.shareManager.AddFolderShare(ctx, req.Path, req.Recipient, opts)
 	if err != nil {
+		if api.IsErrorCode(err, api.FolderShareInvalidPermissionsErrorCode) {
+			return &api.FolderShareResponse{Status: api.StatusCode_FOLDER_SHARE_INVALID_PERMISSIONS}, nil
+		}
 		l.Error("error creating folder share", zap.Error(err))
 		return nil, err
 	}
+	s.auditFolderShare(ctx, share)
 	folderShareRes := &api.FolderShareResponse{FolderShare: share}
 	return folderShareRes, nil
 }
 
 func (s *svc) UpdateFolderShare(ctx context.Context, req *api.UpdateFolderShareReq) (*api.FolderShareResponse, error) {
 	l := ctx_zap.Extract(ctx)
-	share, err := s.shareManager.UpdateFolderShare(ctx, req.Id, req.UpdateReadOnly, req.ReadOnly)
+	if _, err := api.CheckUnrestrictedUser(ctx, "shares cannot be managed"); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	opts := &api.FolderShareOptions{
+		Permissions:       api.SharePermissions(req.Permissions),
+		Expiration:        req.Expiration,
+		UpdatePermissions: req.UpdatePermissions,
+		UpdateExpiration:  req.UpdateExpiration,
+	}
+	if !req.UpdatePermissions && req.UpdateReadOnly {
+		opts.Permissions = api.PermissionsFromReadOnly(req.ReadOnly)
+		opts.UpdatePermissions = true
+	}
+	share, err := s.shareManager.UpdateFolderShare(ctx, req.Id, opts)
 	if err != nil {
+		if api.IsErrorCode(err, api.FolderShareInvalidPermissionsErrorCode) {
+			return &api.FolderShareResponse{Status: api.StatusCode_FOLDER_SHARE_INVALID_PERMISSIONS}, nil
+		}
 		l.Error("error updating folder share", zap.Error(err))
 		return nil, err
 	}
+	s.auditFolderShare(ctx, share)
 	folderShareRes := &api.FolderShareResponse{FolderShare: share}
 	return folderShareRes, nil
 }
 
 func (s *svc) UnshareFolder(ctx context.Context, req *api.UnshareFolderReq) (*api.EmptyResponse, error) {
 	l := ctx_zap.Extract(ctx)
+	if _, err := api.CheckUnrestrictedUser(ctx, "shares cannot be managed"); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	if _, ok := api.ContextGetAuditEvent(ctx); ok {
+		if share, err := s.shareManager.GetFolderShare(ctx, req.Id); err == nil {
+			s.auditFolderShare(ctx, share)
+		}
+	}
 	err := s.shareManager.Unshare(ctx, req.Id)
 	if err != nil {
 		l.Error("error deleting folder share", zap.Error(err))
@@ -147,18 +225,29 @@ func (s *svc) ListPublicLinks(req *api.ListPublicLinksReq, stream api.Share_List
 }
 func (s *svc) CreatePublicLink(ctx context.Context, req *api.NewLinkReq) (*api.PublicLinkResponse, error) {
 	l := ctx_zap.Extract(ctx)
+	if _, err := api.CheckUnrestrictedUser(ctx, "shares cannot be managed"); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
 	opts := &api.PublicLinkOptions{
-		Password:   req.Password,
-		Expiration: req.Expires,
-		ReadOnly:   req.ReadOnly,
-		DropOnly:   req.DropOnly,
+		Password:      req.Password,
+		Expiration:    req.Expires,
+		ReadOnly:      req.ReadOnly,
+		DropOnly:      req.DropOnly,
+		NotifyUploads: req.NotifyUploads,
+		MaxDownloads:  req.MaxDownloads,
 	}
 
 	publicLink, err := s.linkManager.CreatePublicLink(ctx, req.Path, opts)
 	if err != nil {
+		if isPasswordPolicyError(err) {
+			return &api.PublicLinkResponse{Status: api.GetStatus(err)}, nil
+		}
 		l.Error("error creating public link", zap.Error(err))
 		return nil, err
 	}
+	s.auditPublicLink(ctx, publicLink)
+	s.publishLinkEvent(ctx, api.FileEvent_LINK_CREATED, publicLink)
 	publicLinkRes := &api.PublicLinkResponse{PublicLink: publicLink}
 	return publicLinkRes, nil
 }
@@ -179,32 +268,108 @@ func (s *svc) InspectPublicLink(ctx context.Context, req *api.ShareIDReq) (*api.
 
 func (s *svc) RevokePublicLink(ctx context.Context, req *api.ShareIDReq) (*api.EmptyResponse, error) {
 	l := ctx_zap.Extract(ctx)
-	err := s.linkManager.RevokePublicLink(ctx, req.Id)
+	if _, err := api.CheckUnrestrictedUser(ctx, "shares cannot be managed"); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+	// the link is inspected before being revoked, to record what it was sharing
+	publicLink, err := s.linkManager.InspectPublicLink(ctx, req.Id)
+	if err == nil {
+		s.auditPublicLink(ctx, publicLink)
+	}
+	err = s.linkManager.RevokePublicLink(ctx, req.Id)
 	if err != nil {
 		l.Error("error revoking public link", zap.Error(err))
 		return nil, err
 	}
+	if publicLink != nil {
+		s.publishLinkEvent(ctx, api.FileEvent_LINK_REVOKED, publicLink)
+	}
 	return &api.EmptyResponse{}, nil
 }
 
 func (s *svc) UpdatePublicLink(ctx context.Context, req *api.UpdateLinkReq) (*api.PublicLinkResponse, error) {
 	l := ctx_zap.Extract(ctx)
+	if _, err := api.CheckUnrestrictedUser(ctx, "shares cannot be managed"); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
 	opts := &api.PublicLinkOptions{
-		Password:         req.Password,
-		Expiration:       req.Expiration,
-		ReadOnly:         req.ReadOnly,
-		DropOnly:         req.DropOnly,
-		UpdatePassword:   req.UpdatePassword,
-		UpdateExpiration: req.UpdateExpiration,
-		UpdateReadOnly:   req.UpdateReadOnly,
-		UpdateDropOnly:   req.DropOnly,
+		Password:            req.Password,
+		Expiration:          req.Expiration,
+		ReadOnly:            req.ReadOnly,
+		DropOnly:            req.DropOnly,
+		UpdatePassword:      req.UpdatePassword,
+		UpdateExpiration:    req.UpdateExpiration,
+		UpdateReadOnly:      req.UpdateReadOnly,
+		UpdateDropOnly:      req.UpdateDropOnly || req.DropOnly,
+		NotifyUploads:       req.NotifyUploads,
+		UpdateNotifyUploads: req.UpdateNotifyUploads,
+		MaxDownloads:        req.MaxDownloads,
+		UpdateMaxDownloads:  req.UpdateMaxDownloads,
 	}
 
 	publicLink, err := s.linkManager.UpdatePublicLink(ctx, req.Id, opts)
 	if err != nil {
+		if isPasswordPolicyError(err) {
+			return &api.PublicLinkResponse{Status: api.GetStatus(err)}, nil
+		}
 		l.Error("error updating public link", zap.Error(err))
 		return nil, err
 	}
+	s.auditPublicLink(ctx, publicLink)
+	s.publishLinkEvent(ctx, api.FileEvent_LINK_UPDATED, publicLink)
 	publicLinkRes := &api.PublicLinkResponse{PublicLink: publicLink}
 	return publicLinkRes, nil
 }
+
+// isPasswordPolicyError returns true if err is the refusal of a password by the policy,
+// to be reported to the client instead of failing the call.
+func isPasswordPolicyError(err error) bool {
+	return api.IsErrorCode(err, api.PublicLinkPasswordTooShortErrorCode) ||
+		api.IsErrorCode(err, api.PublicLinkPasswordTooWeakErrorCode) ||
+		api.IsErrorCode(err, api.PublicLinkPasswordBannedErrorCode) ||
+		api.IsErrorCode(err, api.PublicLinkPasswordRequiredErrorCode)
+}
+
+// auditFolderShare records the shared folder and the recipient in the audit event.
+func (s *svc) auditFolderShare(ctx context.Context, share *api.FolderShare) {
+	api.AuditSetResource(ctx, s.vs, share.Path)
+	if share.Recipient != nil {
+		api.AuditSetTarget(ctx, share.Recipient.Type.String()+":"+share.Recipient.Identity)
+	}
+}
+
+// auditPublicLink records the shared resource and the token of the link in the audit event.
+func (s *svc) auditPublicLink(ctx context.Context, pl *api.PublicLink) {
+	api.AuditSetResource(ctx, s.vs, pl.Path)
+	api.AuditSetTarget(ctx, "link:"+pl.Token)
+}
+
+// publishLinkEvent publishes a change of a link. The path of a link is the ID
+// of the shared resource, so it is resolved to publish the event on the tree path.
+func (s *svc) publishLinkEvent(ctx context.Context, t api.FileEvent_Type, pl *api.PublicLink) {
+	if !s.bus.HasSubscribers() {
+		return
+	}
+	md, err := s.vs.GetMetadata(ctx, pl.Path)
+	if err != nil {
+		l := ctx_zap.Extract(ctx)
+		l.Error("error resolving path of public link for file event", zap.Error(err), zap.String("id", pl.Id))
+		return
+	}
+	api.PublishFileEvent(ctx, s.bus, s.vs, &api.FileEvent{Type: t, Path: md.Path, FileId: md.Id, Target: pl.Token})
+}
+
+// auditReceivedShare records the received share in the audit event. The path of the share
+// is in the namespace of its owner, so its metadata is not resolved.
+func (s *svc) auditReceivedShare(ctx context.Context, id string) {
+	e, ok := api.ContextGetAuditEvent(ctx)
+	if !ok {
+		return
+	}
+	e.Target = "share:" + id
+	if share, err := s.shareManager.GetReceivedFolderShare(ctx, id); err == nil {
+		e.Path = share.Path
+	}
+}
@@ -0,0 +1,185 @@
+package sharesvc
+
+import (
+	"path"
+	"strings"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// TransferOwnership hands files of a user over to another with their shares
+// and public links, when the user leaves. Each step finds what is left to do
+// from the files and the shares, so a transfer that stopped is resumed by
+// running it again with the same request.
+func (s *svc) TransferOwnership(req *api.TransferOwnershipReq, stream api.Share_TransferOwnershipServer) error {
+	ctx := stream.Context()
+	l := ctx_zap.Extract(ctx)
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		err := api.NewError(api.ContextUserRequiredError)
+		l.Error("", zap.Error(err))
+		return err
+	}
+	if !s.admins[u.AccountId] || api.IsUserRestricted(u) {
+		err := api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("the files of other users can only be transferred by admins")
+		l.Error("", zap.Error(err))
+		return stream.Send(&api.TransferOwnershipResponse{Status: api.GetStatus(err)})
+	}
+	if req.FromUser == "" || req.ToUser == "" || req.FromUser == req.ToUser {
+		err := api.NewError(api.UserNotFoundErrorCode).WithMessage("the files need another user to be transferred to")
+		l.Error("", zap.Error(err))
+		return stream.Send(&api.TransferOwnershipResponse{Status: api.GetStatus(err)})
+	}
+	ts, ok := s.vs.(api.OwnershipTransferStorage)
+	if !ok {
+		err := api.NewError(api.StorageNotSupportedErrorCode).WithMessage("ownership transfer not supported")
+		l.Error("", zap.Error(err))
+		return stream.Send(&api.TransferOwnershipResponse{Status: api.GetStatus(err)})
+	}
+
+	fromCtx := api.ContextSetUser(ctx, &api.User{AccountId: req.FromUser})
+	toCtx := api.ContextSetUser(ctx, &api.User{AccountId: req.ToUser})
+	fromPath, toPath := path.Clean(req.FromPath), path.Clean(req.ToPath)
+	send := func(progress *api.TransferProgress) error {
+		if err := stream.Send(&api.TransferOwnershipResponse{Progress: progress}); err != nil {
+			l.Error("error streaming transfer progress", zap.Error(err))
+			return err
+		}
+		return nil
+	}
+
+	// the files are moved first, the shares are then found under toPath
+	_, err := s.vs.GetMetadata(fromCtx, fromPath)
+	switch {
+	case err == nil:
+		if _, err := s.vs.GetMetadata(toCtx, toPath); err == nil {
+			err := api.NewError(api.StorageAlreadyExistsErrorCode).WithMessage(toPath)
+			l.Error("", zap.Error(err))
+			return stream.Send(&api.TransferOwnershipResponse{Status: api.GetStatus(err)})
+		} else if !api.IsErrorCode(err, api.StorageNotFoundErrorCode) {
+			l.Error("error checking transfer target", zap.Error(err), zap.String("path", toPath))
+			return err
+		}
+		if err := ts.TransferOwnership(ctx, req.FromUser, fromPath, req.ToUser, toPath); err != nil {
+			l.Error("error transferring files", zap.Error(err), zap.String("from", req.FromUser), zap.String("path", fromPath))
+			return err
+		}
+		l.Info("files transferred", zap.String("from", req.FromUser), zap.String("from_path", fromPath), zap.String("to", req.ToUser), zap.String("to_path", toPath))
+		if err := send(&api.TransferProgress{Step: api.TransferProgress_FILES, Path: toPath}); err != nil {
+			return err
+		}
+	case api.IsErrorCode(err, api.StorageNotFoundErrorCode):
+		// moved by a previous run, unless there was nothing to move
+		if _, err := s.vs.GetMetadata(toCtx, toPath); err != nil {
+			l.Error("error finding transferred files", zap.Error(err), zap.String("path", toPath))
+			return stream.Send(&api.TransferOwnershipResponse{Status: api.GetStatus(err)})
+		}
+		if err := send(&api.TransferProgress{Step: api.TransferProgress_FILES, Path: toPath, Skipped: true}); err != nil {
+			return err
+		}
+	default:
+		l.Error("error checking transferred files", zap.Error(err), zap.String("path", fromPath))
+		return err
+	}
+
+	shares, err := s.shareManager.ListAllFolderShares(ctx, req.FromUser)
+	if err != nil {
+		l.Error("error listing the shares of the user", zap.Error(err), zap.String("owner", req.FromUser))
+		return err
+	}
+	fileIDs := []string{}
+	for _, share := range shares {
+		if s.isUnder(toCtx, share.Path, toPath) {
+			fileIDs = append(fileIDs, share.Path)
+		}
+	}
+	transferred, err := s.shareManager.TransferFolderShares(ctx, fileIDs, req.FromUser, req.ToUser)
+	if err != nil {
+		l.Error("error transferring shares", zap.Error(err))
+		return err
+	}
+	for _, share := range transferred {
+		if err := send(&api.TransferProgress{Step: api.TransferProgress_SHARE, Id: share.Id, Path: share.Path}); err != nil {
+			return err
+		}
+	}
+
+	links, err := s.linkManager.ListAllPublicLinks(ctx, req.FromUser)
+	if err != nil {
+		l.Error("error listing the public links of the user", zap.Error(err), zap.String("owner", req.FromUser))
+		return err
+	}
+	fileIDs = []string{}
+	for _, pl := range links {
+		if s.isUnder(toCtx, pl.Path, toPath) {
+			fileIDs = append(fileIDs, pl.Path)
+		}
+	}
+	transferredLinks, err := s.linkManager.TransferPublicLinks(ctx, fileIDs, req.FromUser, req.ToUser)
+	if err != nil {
+		l.Error("error transferring public links", zap.Error(err))
+		return err
+	}
+	for _, pl := range transferredLinks {
+		if err := send(&api.TransferProgress{Step: api.TransferProgress_PUBLIC_LINK, Id: pl.Id, Path: pl.Path}); err != nil {
+			return err
+		}
+	}
+
+	// the ACLs are set again for the new owner, all of them as the shares
+	// transferred by a previous run cannot be told apart. The re-shares are
+	// owned by the owner of the file too, so their parents are found with them.
+	shares, err = s.shareManager.ListAllFolderShares(ctx, req.ToUser)
+	if err != nil {
+		l.Error("error listing the shares of the user", zap.Error(err), zap.String("owner", req.ToUser))
+		return err
+	}
+	sharesByID := map[string]*api.FolderShare{}
+	for _, share := range shares {
+		sharesByID[share.Id] = share
+	}
+	for _, share := range shares {
+		if !s.isUnder(toCtx, share.Path, toPath) {
+			continue
+		}
+		if err := s.vs.SetACL(toCtx, share.Path, grantedPermissions(share, sharesByID), share.Recipient, []*api.FolderShare{}); err != nil {
+			l.Error("error setting acl of transferred share", zap.Error(err), zap.String("share_id", share.Id))
+			return err
+		}
+		if err := send(&api.TransferProgress{Step: api.TransferProgress_ACL, Id: share.Id, Path: share.Path}); err != nil {
+			return err
+		}
+	}
+
+	return send(&api.TransferProgress{Step: api.TransferProgress_DONE, Path: toPath})
+}
+
+// grantedPermissions returns the permissions granted by the share, capped at
+// the ones of the shares it re-shares, like the share managers set its ACL.
+func grantedPermissions(share *api.FolderShare, sharesByID map[string]*api.FolderShare) api.SharePermissions {
+	p := api.GetSharePermissions(share)
+	seen := map[string]bool{share.Id: true}
+	for parent, ok := sharesByID[share.ParentId]; ok && !seen[parent.Id]; parent, ok = sharesByID[parent.ParentId] {
+		seen[parent.Id] = true
+		p &= api.GetSharePermissions(parent)
+	}
+	return p
+}
+
+// isUnder returns true if the file is at p or under it for the user in ctx.
+// The files that cannot be found are left for the share fsck.
+func (s *svc) isUnder(ctx context.Context, fileID, p string) bool {
+	l := ctx_zap.Extract(ctx)
+	md, err := s.vs.GetMetadata(ctx, fileID)
+	if err != nil {
+		if !api.IsErrorCode(err, api.StorageNotFoundErrorCode) {
+			l.Error("error resolving file of share", zap.Error(err), zap.String("file_id", fileID))
+		}
+		return false
+	}
+	return md.Path == p || strings.HasPrefix(md.Path, p+"/")
+}
@@ -0,0 +1,166 @@
+package sharesvc
+
+import (
+	"testing"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/conformance"
+	"github.com/cernbox/reva/api/mount"
+	"github.com/cernbox/reva/api/public_link_manager_memory"
+	"github.com/cernbox/reva/api/share_manager_memory"
+	"github.com/cernbox/reva/api/storage_wrapper_home"
+	"github.com/cernbox/reva/api/virtual_storage"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+type transferStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*api.TransferOwnershipResponse
+}
+
+func (s *transferStream) Context() context.Context { return s.ctx }
+
+func (s *transferStream) Send(res *api.TransferOwnershipResponse) error {
+	s.responses = append(s.responses, res)
+	return nil
+}
+
+// steps returns the steps streamed, with the skipped ones in parentheses.
+func (s *transferStream) steps(t *testing.T) []string {
+	steps := []string{}
+	for _, res := range s.responses {
+		if res.Status != api.StatusCode_OK {
+			t.Fatalf("expected progress, got status %s", res.Status)
+		}
+		step := res.Progress.Step.String()
+		if res.Progress.Skipped {
+			step = "(" + step + ")"
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+func expectSteps(t *testing.T, got []string, expected ...string) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("expected steps %v, got %v", expected, got)
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Fatalf("expected steps %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestTransferOwnership(t *testing.T) {
+	ctx := context.Background()
+	storage := conformance.NewMemoryStorage()
+	for _, p := range []string{"/a", "/a/alice", "/b", "/b/bob"} {
+		if err := storage.CreateDir(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	vfs := virtual_storage.NewVFS(zap.NewNop(), nil)
+	if err := vfs.AddMount(ctx, mount.New("home", "/home", nil, storage_wrapper_home.New(storage))); err != nil {
+		t.Fatal(err)
+	}
+	aliceCtx := api.ContextSetUser(ctx, &api.User{AccountId: "alice"})
+	bobCtx := api.ContextSetUser(ctx, &api.User{AccountId: "bob"})
+	for _, p := range []string{"/home/docs", "/home/docs/sub", "/home/keep"} {
+		if err := vfs.CreateDir(aliceCtx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sm := share_manager_memory.New(vfs, conformance.NewUserManager())
+	lm := public_link_manager_memory.New(vfs, nil, nil)
+	recipient := &api.ShareRecipient{Identity: "carol", Type: api.ShareRecipient_USER}
+	share, err := sm.AddFolderShare(aliceCtx, "/home/docs", recipient, &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept, err := sm.AddFolderShare(aliceCtx, "/home/keep", recipient, &api.FolderShareOptions{Permissions: api.SharePermissionsReadOnly})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pl, err := lm.CreatePublicLink(aliceCtx, "/home/docs/sub", &api.PublicLinkOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &svc{shareManager: sm, linkManager: lm, vs: vfs, admins: map[string]bool{"admin": true}}
+	req := &api.TransferOwnershipReq{FromUser: "alice", FromPath: "/home/docs", ToUser: "bob", ToPath: "/home/from alice"}
+
+	// only the admins can transfer the files of other users
+	stream := &transferStream{ctx: aliceCtx}
+	if err := s.TransferOwnership(req, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.responses) != 1 || stream.responses[0].Status != api.StatusCode_STORAGE_PERMISSIONDENIED {
+		t.Fatalf("expected the transfer to be denied, got %+v", stream.responses)
+	}
+
+	adminCtx := api.ContextSetUser(ctx, &api.User{AccountId: "admin"})
+	stream = &transferStream{ctx: adminCtx}
+	if err := s.TransferOwnership(req, stream); err != nil {
+		t.Fatal(err)
+	}
+	expectSteps(t, stream.steps(t), "FILES", "SHARE", "PUBLIC_LINK", "ACL", "DONE")
+
+	md, err := vfs.GetMetadata(bobCtx, "/home/from alice/sub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vfs.GetMetadata(aliceCtx, "/home/docs"); !api.IsErrorCode(err, api.StorageNotFoundErrorCode) {
+		t.Fatalf("expected the files to be gone from the home of alice, got %v", err)
+	}
+	shares, err := sm.ListFolderShares(bobCtx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shares) != 1 || shares[0].Id != share.Id {
+		t.Fatalf("expected the share to be transferred to bob, got %+v", shares)
+	}
+	if _, err := sm.GetFolderShare(aliceCtx, kept.Id); err != nil {
+		t.Fatal(err)
+	}
+	links, err := lm.ListPublicLinks(bobCtx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(links) != 1 || links[0].Id != pl.Id || links[0].Path != md.Id {
+		t.Fatalf("expected the link to be transferred to bob, got %+v", links)
+	}
+
+	// running it again only sets the ACLs again
+	if err := storage.UnsetACL(ctx, "/b/bob/from alice", recipient, nil); err != nil {
+		t.Fatal(err)
+	}
+	stream = &transferStream{ctx: adminCtx}
+	if err := s.TransferOwnership(req, stream); err != nil {
+		t.Fatal(err)
+	}
+	expectSteps(t, stream.steps(t), "(FILES)", "ACL", "DONE")
+	if p := storage.ACL("/b/bob/from alice")["USER:carol"]; p != api.SharePermissionsReadOnly {
+		t.Fatalf("expected the acl of carol to be set again, got %d", p)
+	}
+}
+
+func TestGrantedPermissions(t *testing.T) {
+	parent := &api.FolderShare{Id: "1", Permissions: uint32(api.SharePermissionsReadOnly | api.SharePermissionShare)}
+	reshare := &api.FolderShare{Id: "2", ParentId: "1", Permissions: uint32(api.SharePermissionsAll)}
+	sharesByID := map[string]*api.FolderShare{"1": parent, "2": reshare}
+
+	// the re-share grants no more than the share it re-shares
+	if p := grantedPermissions(reshare, sharesByID); p != api.SharePermissionsReadOnly|api.SharePermissionShare {
+		t.Fatalf("expected the permissions of the parent share, got %d", p)
+	}
+	if p := grantedPermissions(parent, sharesByID); p != api.SharePermissionsReadOnly|api.SharePermissionShare {
+		t.Fatalf("expected the permissions of the share, got %d", p)
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cernbox/reva/api"
 
@@ -17,25 +19,31 @@ import (
 	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
-func New(vs api.VirtualStorage, temporaryFolder string) api.StorageServer {
+func New(vs api.VirtualStorage, bus api.EventBus, temporaryFolder string) api.StorageServer {
 	s := new(svc)
 	if temporaryFolder == "" {
 		temporaryFolder = os.TempDir()
 	}
 	s.vs = vs
+	s.bus = bus
 	s.temporaryFolder = temporaryFolder
 	return s
 }
 
 type svc struct {
 	vs              api.VirtualStorage
+	bus             api.EventBus
 	temporaryFolder string
 }
 
 func (s *svc) RestoreRevision(ctx context.Context, req *api.RevisionReq) (*api.EmptyResponse, error) {
 	l := ctx_zap.Extract(ctx)
+	api.AuditSetResource(ctx, s.vs, req.Path)
+	api.AuditSetTarget(ctx, req.RevKey)
 	if err := s.vs.RestoreRevision(ctx, req.Path, req.RevKey); err != nil {
 		l.Error("", zap.Error(err))
 		return nil, err
@@ -45,7 +53,8 @@ func (s *svc) RestoreRevision(ctx context.Context, req *api.RevisionReq) (*api.E
 
 func (s *svc) UpdateACL(ctx context.Context, req *api.ACLReq) (*api.EmptyResponse, error) {
 	l := ctx_zap.Extract(ctx)
-	err := s.vs.UpdateACL(ctx, req.Path, req.ReadOnly, req.Recipient, req.Shares)
+	auditACL(ctx, s.vs, req)
+	err := s.vs.UpdateACL(ctx, req.Path, getACLPermissions(req), req.Recipient, req.Shares)
 	if err != nil {
 		l.Error("", zap.Error(err))
 		return nil, err
@@ -55,7 +64,8 @@ func (s *svc) UpdateACL(ctx context.Context, req *api.ACLReq) (*api.EmptyRespons
 
 func (s *svc) SetACL(ctx context.Context, req *api.ACLReq) (*api.EmptyResponse, error) {
 	l := ctx_zap.Extract(ctx)
-	err := s.vs.SetACL(ctx, req.Path, req.ReadOnly, req.Recipient, req.Shares)
+	auditACL(ctx, s.vs, req)
+	err := s.vs.SetACL(ctx, req.Path, getACLPermissions(req), req.Recipient, req.Shares)
 	if err != nil {
 		l.Error("", zap.Error(err))
 		return nil, err
@@ -63,8 +73,18 @@ func (s *svc) SetACL(ctx context.Context, req *api.ACLReq) (*api.EmptyResponse,
 	return &api.EmptyResponse{}, nil
 }
 
+// getACLPermissions returns the permissions of the request, the clients
+// only sending the read-only flag get read-only or read-write permissions.
+func getACLPermissions(req *api.ACLReq) api.SharePermissions {
+	if req.Permissions != 0 {
+		return api.SharePermissions(req.Permissions)
+	}
+	return api.PermissionsFromReadOnly(req.ReadOnly)
+}
+
 func (s *svc) UnsetACL(ctx context.Context, req *api.ACLReq) (*api.EmptyResponse, error) {
 	l := ctx_zap.Extract(ctx)
+	auditACL(ctx, s.vs, req)
 	err := s.vs.UnsetACL(ctx, req.Path, req.Recipient, req.Shares)
 	if err != nil {
 		l.Error("", zap.Error(err))
@@ -75,6 +95,7 @@ func (s *svc) UnsetACL(ctx context.Context, req *api.ACLReq) (*api.EmptyResponse
 
 func (s *svc) RestoreRecycleEntry(ctx context.Context, req *api.RecycleEntryReq) (*api.EmptyResponse, error) {
 	l := ctx_zap.Extract(ctx)
+	api.AuditSetTarget(ctx, req.RestoreKey)
 	if err := s.vs.RestoreRecycleEntry(ctx, req.RestoreKey); err != nil {
 		l.Error("", zap.Error(err))
 		return nil, err
@@ -85,6 +106,8 @@ func (s *svc) RestoreRecycleEntry(ctx context.Context, req *api.RecycleEntryReq)
 func (s *svc) ReadRevision(req *api.RevisionReq, stream api.Storage_ReadRevisionServer) error {
 	ctx := stream.Context()
 	l := ctx_zap.Extract(ctx)
+	api.AuditSetResource(ctx, s.vs, req.Path)
+	api.AuditSetTarget(ctx, req.RevKey)
 	readCloser, err := s.vs.DownloadRevision(ctx, req.Path, req.RevKey)
 	defer func() {
 		l.Debug("closing fd when reading version for path: " + req.Path)
@@ -123,6 +146,7 @@ func (s *svc) ReadRevision(req *api.RevisionReq, stream api.Storage_ReadRevision
 func (s *svc) ReadFile(req *api.PathReq, stream api.Storage_ReadFileServer) error {
 	ctx := stream.Context()
 	l := ctx_zap.Extract(ctx)
+	api.AuditSetResource(ctx, s.vs, req.Path)
 	readCloser, err := s.vs.Download(ctx, req.Path)
 	if err != nil {
 		l.Error("error reading file from fs", zap.Error(err))
@@ -238,11 +262,13 @@ func (s *svc) CreateDir(ctx context.Context, req *api.PathReq) (*api.EmptyRespon
 		l.Error("", zap.Error(err))
 		return nil, err
 	}
+	api.AuditSetResource(ctx, s.vs, req.Path)
 	return &api.EmptyResponse{}, nil
 }
 
 func (s *svc) Delete(ctx context.Context, req *api.PathReq) (*api.EmptyResponse, error) {
 	l := ctx_zap.Extract(ctx)
+	api.AuditSetResource(ctx, s.vs, req.Path)
 	if err := s.vs.Delete(ctx, req.Path); err != nil {
 		l.Error("", zap.Error(err))
 		return nil, err
@@ -434,15 +460,22 @@ func (s *svc) FinishWriteTx(ctx context.Context, req *api.TxEnd) (*api.EmptyResp
 		return nil, err
 	}
 
-	if err := s.vs.Upload(ctx, req.Path, fd); err != nil {
+	// the storage may write the file under another name, like in a drop-only link
+	uploaded := &api.UploadedFile{Name: path.Base(req.Path)}
+	if err := s.vs.Upload(api.ContextSetUploadedFile(ctx, uploaded), req.Path, fd); err != nil {
 		return nil, err
 	}
+	api.AuditSetResource(ctx, s.vs, path.Join(path.Dir(req.Path), uploaded.Name))
+	if err := grpc.SetHeader(ctx, metadata.Pairs(api.UploadedNameMetadata, uploaded.Name)); err != nil {
+		l.Error("", zap.Error(err))
+	}
 
 	return &api.EmptyResponse{}, nil
 }
 
 func (s *svc) Move(ctx context.Context, req *api.MoveReq) (*api.EmptyResponse, error) {
 	l := ctx_zap.Extract(ctx)
+	api.AuditSetResource(ctx, s.vs, req.OldPath)
 	if err := s.vs.Move(ctx, req.OldPath, req.NewPath); err != nil {
 		l.Error("", zap.Error(err))
 		return nil, err
@@ -450,6 +483,99 @@ func (s *svc) Move(ctx context.Context, req *api.MoveReq) (*api.EmptyResponse, e
 	return &api.EmptyResponse{}, nil
 }
 
+// auditACL records the recipient of an ACL change in the audit event.
+func auditACL(ctx context.Context, vs api.VirtualStorage, req *api.ACLReq) {
+	api.AuditSetResource(ctx, vs, req.Path)
+	if req.Recipient != nil {
+		api.AuditSetTarget(ctx, req.Recipient.Type.String()+":"+req.Recipient.Identity)
+	}
+}
+
+func (s *svc) Watch(req *api.WatchReq, stream api.Storage_WatchServer) error {
+	ctx := stream.Context()
+	l := ctx_zap.Extract(ctx)
+	p := path.Clean(req.Path)
+
+	// the watched path must be visible to the user
+	if _, err := s.vs.GetMetadata(ctx, p); err != nil {
+		l.Error("", zap.Error(err))
+		return stream.Send(&api.FileEventResponse{Status: api.GetStatus(err)})
+	}
+
+	events, cancel := s.bus.Subscribe(p, req.Recursive)
+	defer cancel()
+
+	visibleParents := map[string]visibleParent{}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				err := api.NewError(api.WatchOverflowErrorCode)
+				l.Warn("watcher did not keep up with the events", zap.String("path", p))
+				return stream.Send(&api.FileEventResponse{Status: api.GetStatus(err)})
+			}
+			if isACLEvent(e) {
+				// the access of the user to the parents may have changed
+				visibleParents = map[string]visibleParent{}
+			}
+			if !s.isEventVisible(ctx, e, visibleParents) {
+				continue
+			}
+			if err := stream.Send(&api.FileEventResponse{FileEvent: e}); err != nil {
+				l.Error("", zap.Error(err))
+				return err
+			}
+		}
+	}
+}
+
+// visibleParentsTTL bounds how long the access of a watcher to a folder is
+// trusted, as the ACLs can be changed out of the watched path.
+const visibleParentsTTL = 30 * time.Second
+
+// visibleParent is the ID of a parent as seen by the watcher.
+type visibleParent struct {
+	id      string
+	expires time.Time
+}
+
+// isACLEvent returns true if the event changes the ACLs of the files.
+func isACLEvent(e *api.FileEvent) bool {
+	return e.Type == api.FileEvent_SHARE_ADDED || e.Type == api.FileEvent_SHARE_UPDATED ||
+		e.Type == api.FileEvent_SHARE_REMOVED || e.Type == api.FileEvent_SHARE_EXPIRED
+}
+
+// isEventVisible checks that the user of the context sees the same resource as
+// the user that did the change. Paths like /home are resolved per user, so the
+// same path can point to different folders: the parent of the changed path must have
+// the same ID for both users. The IDs of the parents are cached in visibleParents
+// for visibleParentsTTL, and dropped on the ACL changes seen by the watch.
+func (s *svc) isEventVisible(ctx context.Context, e *api.FileEvent, visibleParents map[string]visibleParent) bool {
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		return false
+	}
+	if err := api.CheckUserScope(ctx, e.Path, false); err != nil {
+		return false
+	}
+	if u.AccountId == e.AccountId {
+		return true
+	}
+
+	parent := path.Dir(e.Path)
+	vp, ok := visibleParents[parent]
+	if !ok || time.Now().After(vp.expires) {
+		vp = visibleParent{expires: time.Now().Add(visibleParentsTTL)}
+		if md, err := s.vs.GetMetadata(ctx, parent); err == nil {
+			vp.id = md.Id
+		}
+		visibleParents[parent] = vp
+	}
+	return vp.id != "" && vp.id == e.ParentId
+}
+
 func (s *svc) getTxFolder(txID string) string {
 	return filepath.Join(s.temporaryFolder, txID)
 }
@@ -15,6 +15,8 @@ type svc struct {
 	tm api.TagManager
 }
 
+// GetTags returns the tags of the user and the system tags of the projects
+// of the user with the key, or all of them if the key is empty.
 func (s *svc) GetTags(req *api.TagReq, stream api.Tagger_GetTagsServer) error {
 	ctx := stream.Context()
 	l := ctx_zap.Extract(ctx)
@@ -23,6 +25,47 @@ func (s *svc) GetTags(req *api.TagReq, stream api.Tagger_GetTagsServer) error {
 		l.Error("error getting tags for key", zap.String("key", req.TagKey), zap.Error(err))
 		return err
 	}
+	for _, tag := range tags {
+		if req.Project != "" && tag.Project != req.Project {
+			continue
+		}
+		res := &api.TagResponse{Tag: tag}
+		if err := stream.Send(res); err != nil {
+			l.Error("error sending tag response", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *svc) GetFileTags(req *api.PathReq, stream api.Tagger_GetFileTagsServer) error {
+	ctx := stream.Context()
+	l := ctx_zap.Extract(ctx)
+	tags, err := s.tm.GetTagsForPath(ctx, req.Path)
+	if err != nil {
+		l.Error("error getting tags for path", zap.String("path", req.Path), zap.Error(err))
+		return stream.Send(&api.TagResponse{Status: api.GetStatus(err)})
+	}
+	for _, tag := range tags {
+		res := &api.TagResponse{Tag: tag}
+		if err := stream.Send(res); err != nil {
+			l.Error("error sending tag response", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// GetFolderTags returns the tags of the user and the system tags of the
+// projects of the user on the folder and on its entries.
+func (s *svc) GetFolderTags(req *api.PathReq, stream api.Tagger_GetFolderTagsServer) error {
+	ctx := stream.Context()
+	l := ctx_zap.Extract(ctx)
+	tags, err := s.tm.GetTagsForFolder(ctx, req.Path)
+	if err != nil {
+		l.Error("error getting tags for folder", zap.String("path", req.Path), zap.Error(err))
+		return stream.Send(&api.TagResponse{Status: api.GetStatus(err)})
+	}
 	for _, tag := range tags {
 		res := &api.TagResponse{Tag: tag}
 		if err := stream.Send(res); err != nil {
@@ -33,20 +76,48 @@ func (s *svc) GetTags(req *api.TagReq, stream api.Tagger_GetTagsServer) error {
 	return nil
 }
 
+// SetTag sets a tag of the user, or a system tag of the project if set.
 func (s *svc) SetTag(ctx context.Context, req *api.TagReq) (*api.EmptyResponse, error) {
 	l := ctx_zap.Extract(ctx)
-	if err := s.tm.SetTag(ctx, req.TagKey, req.TagVal, req.Path); err != nil {
-		l.Error("error setting tag", zap.String("key", req.TagKey), zap.String("val", req.TagVal), zap.String("path", req.Path), zap.Error(err))
-		return &api.EmptyResponse{Status: api.StatusCode_UNKNOWN}, nil
+	var err error
+	if req.Project != "" {
+		err = s.tm.SetSystemTag(ctx, req.Project, req.TagKey, req.TagVal, req.Path)
+	} else {
+		err = s.tm.SetTag(ctx, req.TagKey, req.TagVal, req.Path)
+	}
+	if err != nil {
+		l.Error("error setting tag", zap.String("key", req.TagKey), zap.String("val", req.TagVal), zap.String("path", req.Path), zap.String("project", req.Project), zap.Error(err))
+		return &api.EmptyResponse{Status: api.GetStatus(err)}, nil
 	}
 	return &api.EmptyResponse{}, nil
 }
 
 func (s *svc) UnSetTag(ctx context.Context, req *api.TagReq) (*api.EmptyResponse, error) {
 	l := ctx_zap.Extract(ctx)
-	if err := s.tm.UnSetTag(ctx, req.TagKey, req.TagVal, req.Path); err != nil {
-		l.Error("error unsetting tag", zap.String("key", req.TagKey), zap.String("val", req.TagVal), zap.String("path", req.Path), zap.Error(err))
-		return &api.EmptyResponse{Status: api.StatusCode_UNKNOWN}, nil
+	var err error
+	if req.Project != "" {
+		err = s.tm.UnSetSystemTag(ctx, req.Project, req.TagKey, req.TagVal, req.Path)
+	} else {
+		err = s.tm.UnSetTag(ctx, req.TagKey, req.TagVal, req.Path)
+	}
+	if err != nil {
+		l.Error("error unsetting tag", zap.String("key", req.TagKey), zap.String("val", req.TagVal), zap.String("path", req.Path), zap.String("project", req.Project), zap.Error(err))
+		return &api.EmptyResponse{Status: api.GetStatus(err)}, nil
+	}
+	return &api.EmptyResponse{}, nil
+}
+
+func (s *svc) RenameTag(ctx context.Context, req *api.RenameTagReq) (*api.EmptyResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	var err error
+	if req.Project != "" {
+		err = s.tm.RenameSystemTag(ctx, req.Project, req.TagKey, req.NewTagKey)
+	} else {
+		err = s.tm.RenameTag(ctx, req.TagKey, req.NewTagKey)
+	}
+	if err != nil {
+		l.Error("error renaming tag", zap.String("key", req.TagKey), zap.String("new_key", req.NewTagKey), zap.String("project", req.Project), zap.Error(err))
+		return &api.EmptyResponse{Status: api.GetStatus(err)}, nil
 	}
 	return &api.EmptyResponse{}, nil
 }
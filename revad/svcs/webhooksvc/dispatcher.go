@@ -0,0 +1,273 @@
+package webhooksvc
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/gofrs/uuid"
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// Options configures the delivery of the webhooks.
+type Options struct {
+	Workers        int
+	QueueSize      int
+	MaxAttempts    int
+	RetryDelay     time.Duration // doubled on every failed attempt
+	Timeout        time.Duration
+	ReloadInterval time.Duration // to pick up the changes done by other daemons
+	// HomeMounts maps the mount points resolving per user, like /home,
+	// to the path the homes are under, like /eos/user. The paths of the events
+	// and of the webhooks are resolved to the homes, as in /eos/user/a/alice,
+	// so that a webhook matches the changes done through any mount.
+	HomeMounts map[string]string
+	Logger     *zap.Logger
+}
+
+// payload is the JSON body POSTed to the webhooks.
+type payload struct {
+	DeliveryID string        `json:"delivery_id"`
+	WebhookID  string        `json:"webhook_id"`
+	Type       string        `json:"type"`
+	Path       string        `json:"path"`
+	OldPath    string        `json:"old_path,omitempty"`
+	AccountID  string        `json:"account_id"`
+	Time       uint64        `json:"time"`
+	Target     string        `json:"target,omitempty"`
+	Metadata   *api.Metadata `json:"metadata,omitempty"`
+}
+
+type rule struct {
+	webhook *api.Webhook
+	secret  string
+}
+
+type delivery struct {
+	webhookID string
+	url       string
+	secret    string
+	body      []byte
+	attempts  int
+}
+
+type dispatcher struct {
+	wm     api.WebhookManager
+	opts   *Options
+	client *http.Client
+	queue  chan *delivery
+
+	sync.RWMutex
+	rules []*rule
+}
+
+func newDispatcher(wm api.WebhookManager, opts *Options) *dispatcher {
+	return &dispatcher{
+		wm:     wm,
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+		queue:  make(chan *delivery, opts.QueueSize),
+	}
+}
+
+// start loads the rules and dispatches the events of the bus until the daemon stops.
+func (d *dispatcher) start(bus api.EventBus) {
+	if err := d.reload(); err != nil {
+		d.opts.Logger.Error("error loading webhooks", zap.Error(err))
+	}
+	for i := 0; i < d.opts.Workers; i++ {
+		go d.work()
+	}
+	go func() {
+		for range time.Tick(d.opts.ReloadInterval) {
+			if err := d.reload(); err != nil {
+				d.opts.Logger.Error("error reloading webhooks", zap.Error(err))
+			}
+		}
+	}()
+	go func() {
+		for {
+			// only the events of a webhook are completed and delivered,
+			// a webhook on a subtree does not slow down the whole storage
+			events, cancel := bus.SubscribeFunc(d.isWatched)
+			for e := range events {
+				d.dispatch(e)
+			}
+			cancel()
+			d.opts.Logger.Error("webhook dispatcher did not keep up with the events, some were not delivered")
+		}
+	}()
+}
+
+func (d *dispatcher) getContext() context.Context {
+	return ctx_zap.ToContext(context.Background(), d.opts.Logger)
+}
+
+func (d *dispatcher) reload() error {
+	ctx := d.getContext()
+	webhooks, err := d.wm.ListWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+	rules := []*rule{}
+	for _, wh := range webhooks {
+		secret, err := d.wm.GetWebhookSecret(ctx, wh.Id)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, &rule{webhook: wh, secret: secret})
+	}
+
+	d.Lock()
+	d.rules = rules
+	d.Unlock()
+	return nil
+}
+
+// resolvePath returns the path p seen by the user accountID, resolved to
+// the same path for all the users. A path under a home mount cannot be
+// resolved without the user, an empty path is returned then.
+func (d *dispatcher) resolvePath(accountID, p string) string {
+	if p == "" {
+		return ""
+	}
+	for mountPoint, homes := range d.opts.HomeMounts {
+		if !api.IsWatchedPath(mountPoint, true, p) {
+			continue
+		}
+		if accountID == "" {
+			return ""
+		}
+		return path.Join(homes, accountID[:1], accountID, strings.TrimPrefix(path.Clean(p), path.Clean(mountPoint)))
+	}
+	return p
+}
+
+// isWatched returns true if a webhook matches e.
+func (d *dispatcher) isWatched(e *api.FileEvent) bool {
+	d.RLock()
+	defer d.RUnlock()
+	p, oldPath := d.resolvePath(e.AccountId, e.Path), d.resolvePath(e.AccountId, e.OldPath)
+	for _, r := range d.rules {
+		if matches(r.webhook, e.Type, p, oldPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *dispatcher) dispatch(e *api.FileEvent) {
+	d.RLock()
+	rules := d.rules
+	d.RUnlock()
+
+	p, oldPath := d.resolvePath(e.AccountId, e.Path), d.resolvePath(e.AccountId, e.OldPath)
+	for _, r := range rules {
+		if !matches(r.webhook, e.Type, p, oldPath) {
+			continue
+		}
+		pl := &payload{
+			DeliveryID: uuid.Must(uuid.NewV4()).String(),
+			WebhookID:  r.webhook.Id,
+			Type:       e.Type.String(),
+			Path:       p,
+			OldPath:    oldPath,
+			AccountID:  e.AccountId,
+			Time:       e.Time,
+			Target:     e.Target,
+			Metadata:   e.Metadata,
+		}
+		body, err := json.Marshal(pl)
+		if err != nil {
+			d.opts.Logger.Error("error encoding webhook payload", zap.Error(err))
+			continue
+		}
+		d.enqueue(&delivery{webhookID: r.webhook.Id, url: r.webhook.Url, secret: r.secret, body: body})
+	}
+}
+
+// matches returns true if the webhook watches the event of type t
+// on the resolved paths p and oldPath.
+func matches(wh *api.Webhook, t api.FileEvent_Type, p, oldPath string) bool {
+	if (p == "" || !api.IsWatchedPath(wh.Path, wh.Recursive, p)) && (oldPath == "" || !api.IsWatchedPath(wh.Path, wh.Recursive, oldPath)) {
+		return false
+	}
+	if len(wh.Events) == 0 {
+		return true
+	}
+	for _, et := range wh.Events {
+		if et == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *dispatcher) enqueue(dv *delivery) {
+	select {
+	case d.queue <- dv:
+	default:
+		d.deadLetter(dv, fmt.Errorf("webhook queue is full"))
+	}
+}
+
+func (d *dispatcher) work() {
+	for dv := range d.queue {
+		err := d.post(dv)
+		if err == nil {
+			continue
+		}
+		dv.attempts++
+		if dv.attempts >= d.opts.MaxAttempts {
+			d.deadLetter(dv, err)
+			continue
+		}
+		delay := d.opts.RetryDelay << uint(dv.attempts-1)
+		d.opts.Logger.Warn("error delivering webhook, retrying", zap.Error(err), zap.String("webhook", dv.webhookID), zap.Int("attempts", dv.attempts), zap.Duration("delay", delay))
+		retry := dv
+		time.AfterFunc(delay, func() { d.enqueue(retry) })
+	}
+}
+
+func (d *dispatcher) post(dv *delivery) error {
+	req, err := http.NewRequest("POST", dv.url, bytes.NewReader(dv.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if dv.secret != "" {
+		mac := hmac.New(sha256.New, []byte(dv.secret))
+		mac.Write(dv.body)
+		req.Header.Set("X-Reva-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", res.StatusCode)
+	}
+	return nil
+}
+
+func (d *dispatcher) deadLetter(dv *delivery, err error) {
+	d.opts.Logger.Error("webhook not delivered, moved to dead letters", zap.Error(err), zap.String("webhook", dv.webhookID), zap.Int("attempts", dv.attempts))
+	dl := &api.DeadLetter{WebhookId: dv.webhookID, Payload: string(dv.body), Error: err.Error(), Attempts: uint64(dv.attempts)}
+	if err := d.wm.AddDeadLetter(d.getContext(), dl); err != nil {
+		d.opts.Logger.Error("error storing dead letter, payload lost", zap.Error(err), zap.String("webhook", dv.webhookID), zap.ByteString("payload", dv.body))
+	}
+}
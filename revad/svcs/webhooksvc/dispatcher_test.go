@@ -0,0 +1,141 @@
+package webhooksvc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cernbox/reva/api"
+	"github.com/cernbox/reva/api/event_bus_memory"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+type memoryManager struct {
+	sync.Mutex
+	api.WebhookManager
+	webhooks    []*api.Webhook
+	secret      string
+	deadLetters []*api.DeadLetter
+}
+
+func (m *memoryManager) ListWebhooks(ctx context.Context) ([]*api.Webhook, error) {
+	return m.webhooks, nil
+}
+
+func (m *memoryManager) GetWebhookSecret(ctx context.Context, id string) (string, error) {
+	return m.secret, nil
+}
+
+func (m *memoryManager) AddDeadLetter(ctx context.Context, dl *api.DeadLetter) error {
+	m.Lock()
+	defer m.Unlock()
+	m.deadLetters = append(m.deadLetters, dl)
+	return nil
+}
+
+func (m *memoryManager) getDeadLetters() []*api.DeadLetter {
+	m.Lock()
+	defer m.Unlock()
+	return m.deadLetters
+}
+
+func TestDispatcher(t *testing.T) {
+	received := make(chan *payload, 10)
+	failures := 1
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		if r.Header.Get("X-Reva-Signature") != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+			t.Error("wrong signature")
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		p := &payload{}
+		json.Unmarshal(body, p)
+		received <- p
+	}))
+	defer server.Close()
+
+	wm := &memoryManager{
+		secret: "secret",
+		webhooks: []*api.Webhook{
+			{Id: "1", Path: "/eos/project/a", Recursive: true, Url: server.URL, Events: []api.FileEvent_Type{api.FileEvent_WRITTEN}},
+			{Id: "2", Path: "/eos/project/b", Url: "http://127.0.0.1:1/unreachable"},
+		},
+	}
+	bus := event_bus_memory.New(10)
+	d := newDispatcher(wm, &Options{Workers: 1, QueueSize: 10, MaxAttempts: 2, RetryDelay: time.Millisecond, Timeout: time.Second, ReloadInterval: time.Hour, Logger: zap.NewNop()})
+	d.start(bus)
+	for !bus.HasSubscribers() {
+		time.Sleep(time.Millisecond)
+	}
+
+	bus.Publish(&api.FileEvent{Type: api.FileEvent_DELETED, Path: "/eos/project/a/file.txt"})
+	bus.Publish(&api.FileEvent{Type: api.FileEvent_WRITTEN, Path: "/eos/project/a/docs/file.txt", Metadata: &api.Metadata{Id: "eos:42"}})
+	bus.Publish(&api.FileEvent{Type: api.FileEvent_CREATED, Path: "/eos/project/b/dir"})
+
+	select {
+	case p := <-received:
+		if p.WebhookID != "1" || p.Type != "WRITTEN" || p.Path != "/eos/project/a/docs/file.txt" || p.Metadata.Id != "eos:42" {
+			t.Fatalf("unexpected payload: %+v", p)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook not delivered after retry")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(wm.getDeadLetters()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	dls := wm.getDeadLetters()
+	if len(dls) != 1 || dls[0].WebhookId != "2" || dls[0].Attempts != 2 {
+		t.Fatalf("expected one dead letter for webhook 2 after 2 attempts, got %+v", dls)
+	}
+
+	select {
+	case p := <-received:
+		t.Fatalf("unexpected delivery: %+v", p)
+	default:
+	}
+}
+
+func TestHomeMounts(t *testing.T) {
+	d := newDispatcher(&memoryManager{}, &Options{HomeMounts: map[string]string{"/home": "/eos/user"}, Logger: zap.NewNop()})
+	d.rules = []*rule{{webhook: &api.Webhook{Id: "1", Path: "/eos/user/a/alice/docs", Recursive: true}}}
+
+	tests := []struct {
+		e       *api.FileEvent
+		watched bool
+	}{
+		{&api.FileEvent{Path: "/home/docs/file.txt", AccountId: "alice"}, true},
+		{&api.FileEvent{Path: "/eos/user/a/alice/docs/file.txt", AccountId: "bob"}, true},
+		{&api.FileEvent{Path: "/home/docs/file.txt", AccountId: "bob"}, false},
+		{&api.FileEvent{Path: "/home/docs/file.txt"}, false},
+		{&api.FileEvent{Type: api.FileEvent_MOVED, Path: "/home/file.txt", OldPath: "/home/docs/file.txt", AccountId: "alice"}, true},
+	}
+	for _, test := range tests {
+		if d.isWatched(test.e) != test.watched {
+			t.Errorf("%+v: expected watched=%v", test.e, test.watched)
+		}
+	}
+	if p := d.resolvePath("alice", "/home"); p != "/eos/user/a/alice" {
+		t.Errorf("expected the home to resolve to /eos/user/a/alice, got %s", p)
+	}
+}
@@ -0,0 +1,174 @@
+package webhooksvc
+
+import (
+	"net/url"
+
+	"github.com/cernbox/reva/api"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/tags/zap"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// New returns the service to manage the webhooks and starts delivering
+// the events of bus to them. Only the admins can manage the webhooks.
+func New(wm api.WebhookManager, bus api.EventBus, admins []string, opts *Options) api.WebhooksServer {
+	m := map[string]bool{}
+	for _, a := range admins {
+		m[a] = true
+	}
+	d := newDispatcher(wm, opts)
+	d.start(bus)
+	return &svc{webhookManager: wm, dispatcher: d, admins: m}
+}
+
+type svc struct {
+	webhookManager api.WebhookManager
+	dispatcher     *dispatcher
+	admins         map[string]bool
+}
+
+func (s *svc) AddWebhook(ctx context.Context, req *api.NewWebhookReq) (*api.WebhookResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	if err := s.checkAdmin(ctx); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	u, err := url.Parse(req.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		err := api.NewError(api.PathInvalidError).WithMessage("webhook url must be an http or https url: " + req.Url)
+		l.Error("", zap.Error(err))
+		return &api.WebhookResponse{Status: api.GetStatus(err)}, nil
+	}
+
+	// a path under a home mount is the one of the admin
+	user, _ := api.ContextGetUser(ctx)
+	wh := &api.Webhook{Path: s.dispatcher.resolvePath(user.AccountId, req.Path), Recursive: req.Recursive, Url: req.Url, Events: req.Events}
+	wh, err = s.webhookManager.AddWebhook(ctx, wh, req.Secret)
+	if err != nil {
+		l.Error("error adding webhook", zap.Error(err))
+		return nil, err
+	}
+	s.reload(ctx)
+	return &api.WebhookResponse{Webhook: wh}, nil
+}
+
+func (s *svc) ListWebhooks(req *api.EmptyReq, stream api.Webhooks_ListWebhooksServer) error {
+	ctx := stream.Context()
+	l := ctx_zap.Extract(ctx)
+	if err := s.checkAdmin(ctx); err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	webhooks, err := s.webhookManager.ListWebhooks(ctx)
+	if err != nil {
+		l.Error("error listing webhooks", zap.Error(err))
+		return err
+	}
+	for _, wh := range webhooks {
+		if err := stream.Send(&api.WebhookResponse{Webhook: wh}); err != nil {
+			l.Error("error streaming webhook", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *svc) RemoveWebhook(ctx context.Context, req *api.WebhookIDReq) (*api.EmptyResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	if err := s.checkAdmin(ctx); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.webhookManager.RemoveWebhook(ctx, req.Id); err != nil {
+		if api.IsErrorCode(err, api.WebhookNotFoundErrorCode) {
+			return &api.EmptyResponse{Status: api.StatusCode_WEBHOOK_NOT_FOUND}, nil
+		}
+		l.Error("error removing webhook", zap.Error(err))
+		return nil, err
+	}
+	s.reload(ctx)
+	return &api.EmptyResponse{}, nil
+}
+
+func (s *svc) ListDeadLetters(req *api.WebhookIDReq, stream api.Webhooks_ListDeadLettersServer) error {
+	ctx := stream.Context()
+	l := ctx_zap.Extract(ctx)
+	if err := s.checkAdmin(ctx); err != nil {
+		l.Error("", zap.Error(err))
+		return err
+	}
+
+	dls, err := s.webhookManager.ListDeadLetters(ctx, req.Id)
+	if err != nil {
+		l.Error("error listing dead letters", zap.Error(err))
+		return err
+	}
+	for _, dl := range dls {
+		if err := stream.Send(&api.DeadLetterResponse{DeadLetter: dl}); err != nil {
+			l.Error("error streaming dead letter", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// RetryDeadLetter sends again the payload of the dead letter,
+// that goes back to the dead letters if it fails again.
+func (s *svc) RetryDeadLetter(ctx context.Context, req *api.DeadLetterIDReq) (*api.EmptyResponse, error) {
+	l := ctx_zap.Extract(ctx)
+	if err := s.checkAdmin(ctx); err != nil {
+		l.Error("", zap.Error(err))
+		return nil, err
+	}
+
+	dl, err := s.webhookManager.GetDeadLetter(ctx, req.Id)
+	if err != nil {
+		if api.IsErrorCode(err, api.DeadLetterNotFoundErrorCode) {
+			return &api.EmptyResponse{Status: api.StatusCode_DEAD_LETTER_NOT_FOUND}, nil
+		}
+		l.Error("error getting dead letter", zap.Error(err))
+		return nil, err
+	}
+
+	wh, err := s.webhookManager.GetWebhook(ctx, dl.WebhookId)
+	if err != nil {
+		l.Error("error getting webhook of dead letter", zap.Error(err))
+		return &api.EmptyResponse{Status: api.GetStatus(err)}, nil
+	}
+	secret, err := s.webhookManager.GetWebhookSecret(ctx, wh.Id)
+	if err != nil {
+		l.Error("error getting webhook secret", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.webhookManager.RemoveDeadLetter(ctx, dl.Id); err != nil {
+		l.Error("error removing dead letter", zap.Error(err))
+		return nil, err
+	}
+	s.dispatcher.enqueue(&delivery{webhookID: wh.Id, url: wh.Url, secret: secret, body: []byte(dl.Payload)})
+	return &api.EmptyResponse{}, nil
+}
+
+// reload applies the changes to the webhooks right away in this daemon,
+// the other daemons pick them up on their next periodic reload.
+func (s *svc) reload(ctx context.Context) {
+	if err := s.dispatcher.reload(); err != nil {
+		l := ctx_zap.Extract(ctx)
+		l.Error("error reloading webhooks", zap.Error(err))
+	}
+}
+
+func (s *svc) checkAdmin(ctx context.Context) error {
+	u, ok := api.ContextGetUser(ctx)
+	if !ok {
+		return api.NewError(api.ContextUserRequiredError)
+	}
+	if !s.admins[u.AccountId] || api.IsUserRestricted(u) {
+		return api.NewError(api.StoragePermissionDeniedErrorCode).WithMessage("webhooks can only be managed by admins")
+	}
+	return nil
+}